@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultClusterConfigKubeconfigKey is the key of the kubeconfig secret used if none is specified
+const DefaultClusterConfigKubeconfigKey = "kubeconfig"
+
+// +kubebuilder:object:root=true
+
+// ClusterConfig references a remote cluster where the scale targets of ScaledObjects can live
+// +kubebuilder:resource:path=clusterconfigs,scope=Namespaced
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.kubeconfigSecretRef.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ClusterConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterConfigSpec `json:"spec"`
+}
+
+// ClusterConfigSpec is the spec for a ClusterConfig resource
+type ClusterConfigSpec struct {
+	KubeconfigSecretRef KubeconfigSecretRef `json:"kubeconfigSecretRef"`
+}
+
+// KubeconfigSecretRef is a reference to the secret holding the kubeconfig of the remote cluster
+type KubeconfigSecretRef struct {
+	Name string `json:"name"`
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// GetKey returns the key of the secret holding the kubeconfig, "kubeconfig" by default
+func (r KubeconfigSecretRef) GetKey() string {
+	if r.Key != "" {
+		return r.Key
+	}
+	return DefaultClusterConfigKubeconfigKey
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterConfigList is a list of ClusterConfig resources
+type ClusterConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterConfig{}, &ClusterConfigList{})
+}
@@ -34,6 +34,9 @@ const (
 	ConditionFallback ConditionType = "Fallback"
 	// ConditionPaused specifies that the resource is paused.
 	ConditionPaused ConditionType = "Paused"
+	// ConditionVPAConflict specifies that a VerticalPodAutoscaler evicting pods targets the same resource.
+	// It is only present once a conflict has been detected.
+	ConditionVPAConflict ConditionType = "VPAConflict"
 )
 
 const (
@@ -179,6 +182,20 @@ func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason st
 	c.setCondition(ConditionPaused, status, reason, message)
 }
 
+// SetVPAConflictCondition modifies VPAConflict Condition according to input parameters,
+// the Condition is added if it isn't present yet
+func (c *Conditions) SetVPAConflictCondition(status metav1.ConditionStatus, reason string, message string) {
+	if c.getCondition(ConditionVPAConflict).Type == "" {
+		*c = append(*c, Condition{Type: ConditionVPAConflict})
+	}
+	c.setCondition(ConditionVPAConflict, status, reason, message)
+}
+
+// GetVPAConflictCondition returns Condition of type VPAConflict
+func (c *Conditions) GetVPAConflictCondition() Condition {
+	return c.getCondition(ConditionVPAConflict)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
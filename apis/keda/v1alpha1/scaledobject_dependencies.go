@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultDependencyMinAvailableReplicas int32 = 1
+
+// GetNamespacedName returns the namespaced name of the referenced ScaledObject,
+// the namespace of the dependent ScaledObject is used if it isn't specified
+func (d ScaledObjectDependency) GetNamespacedName(namespace string) types.NamespacedName {
+	if d.Namespace != "" {
+		namespace = d.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: d.Name}
+}
+
+// GetMinAvailableReplicas returns the number of ready replicas the referenced ScaledObject needs, 1 by default
+func (d ScaledObjectDependency) GetMinAvailableReplicas() int32 {
+	if d.MinAvailableReplicas != nil {
+		return *d.MinAvailableReplicas
+	}
+	return defaultDependencyMinAvailableReplicas
+}
+
+// ScaledObjectDependencyGraph is a directed graph of ScaledObjects pointing to the ScaledObjects they depend on
+type ScaledObjectDependencyGraph map[types.NamespacedName][]types.NamespacedName
+
+// NewScaledObjectDependencyGraph builds the dependency graph of the ScaledObjects
+func NewScaledObjectDependencyGraph(scaledObjects []ScaledObject) ScaledObjectDependencyGraph {
+	graph := ScaledObjectDependencyGraph{}
+	for i := range scaledObjects {
+		graph.Set(&scaledObjects[i])
+	}
+	return graph
+}
+
+// Set adds the ScaledObject to the graph, replacing the dependencies it had before
+func (g ScaledObjectDependencyGraph) Set(so *ScaledObject) {
+	key := types.NamespacedName{Namespace: so.Namespace, Name: so.Name}
+	dependencies := make([]types.NamespacedName, 0, len(so.Spec.DependsOn))
+	for _, dependency := range so.Spec.DependsOn {
+		dependencies = append(dependencies, dependency.GetNamespacedName(so.Namespace))
+	}
+	g[key] = dependencies
+}
+
+// FindCycle returns the ScaledObjects forming a dependency cycle reachable from start,
+// nil is returned when the graph reachable from start is acyclic
+func (g ScaledObjectDependencyGraph) FindCycle(start types.NamespacedName) []types.NamespacedName {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[types.NamespacedName]int{}
+	path := []types.NamespacedName{}
+
+	var visit func(node types.NamespacedName) []types.NamespacedName
+	visit = func(node types.NamespacedName) []types.NamespacedName {
+		switch state[node] {
+		case visited:
+			return nil
+		case visiting:
+			// the node is on the current path, so the path from it back to itself is a cycle
+			for i := range path {
+				if path[i] == node {
+					return append(append([]types.NamespacedName{}, path[i:]...), node)
+				}
+			}
+		}
+
+		state[node] = visiting
+		path = append(path, node)
+		for _, dependency := range g[node] {
+			if cycle := visit(dependency); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = visited
+		return nil
+	}
+
+	return visit(start)
+}
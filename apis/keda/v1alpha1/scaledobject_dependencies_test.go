@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newDependentScaledObject(name string, dependsOn ...ScaledObjectDependency) ScaledObject {
+	return ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       ScaledObjectSpec{DependsOn: dependsOn},
+	}
+}
+
+func TestScaledObjectDependencyDefaults(t *testing.T) {
+	dependency := ScaledObjectDependency{Name: "db"}
+	assert.Equal(t, types.NamespacedName{Namespace: "default", Name: "db"}, dependency.GetNamespacedName("default"))
+	assert.Equal(t, int32(1), dependency.GetMinAvailableReplicas())
+
+	dependency = ScaledObjectDependency{Name: "db", Namespace: "other", MinAvailableReplicas: int32Ptr(3)}
+	assert.Equal(t, types.NamespacedName{Namespace: "other", Name: "db"}, dependency.GetNamespacedName("default"))
+	assert.Equal(t, int32(3), dependency.GetMinAvailableReplicas())
+}
+
+func TestScaledObjectDependencyGraphFindCycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		scaledObjects []ScaledObject
+		expectedCycle []string
+	}{
+		{
+			name: "chain",
+			scaledObjects: []ScaledObject{
+				newDependentScaledObject("app", ScaledObjectDependency{Name: "pool"}),
+				newDependentScaledObject("pool", ScaledObjectDependency{Name: "db"}),
+				newDependentScaledObject("db"),
+			},
+		},
+		{
+			name: "diamond",
+			scaledObjects: []ScaledObject{
+				newDependentScaledObject("app", ScaledObjectDependency{Name: "cache"}, ScaledObjectDependency{Name: "pool"}),
+				newDependentScaledObject("cache", ScaledObjectDependency{Name: "db"}),
+				newDependentScaledObject("pool", ScaledObjectDependency{Name: "db"}),
+				newDependentScaledObject("db"),
+			},
+		},
+		{
+			name: "self reference",
+			scaledObjects: []ScaledObject{
+				newDependentScaledObject("app", ScaledObjectDependency{Name: "app"}),
+			},
+			expectedCycle: []string{"app", "app"},
+		},
+		{
+			name: "cycle",
+			scaledObjects: []ScaledObject{
+				newDependentScaledObject("app", ScaledObjectDependency{Name: "pool"}),
+				newDependentScaledObject("pool", ScaledObjectDependency{Name: "db"}),
+				newDependentScaledObject("db", ScaledObjectDependency{Name: "app"}),
+			},
+			expectedCycle: []string{"app", "pool", "db", "app"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graph := NewScaledObjectDependencyGraph(test.scaledObjects)
+			cycle := graph.FindCycle(types.NamespacedName{Namespace: "default", Name: "app"})
+			if test.expectedCycle == nil {
+				assert.Nil(t, cycle)
+				return
+			}
+			names := []string{}
+			for _, node := range cycle {
+				names = append(names, node.Name)
+			}
+			assert.Equal(t, test.expectedCycle, names)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+)
+
+func TestCheckTargetClusterValid(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetCluster    string
+		triggers         []ScaleTriggers
+		scalingModifiers *ScalingModifiers
+		predictive       *PredictiveConfig
+		expectedErrMsg   string
+	}{
+		{
+			name:     "no target cluster",
+			triggers: []ScaleTriggers{{Type: "cpu", MetricType: autoscalingv2.UtilizationMetricType}},
+		},
+		{
+			name:          "average value triggers",
+			targetCluster: "remote",
+			triggers:      []ScaleTriggers{{Type: "prometheus"}, {Type: "kafka", MetricType: autoscalingv2.AverageValueMetricType}},
+		},
+		{
+			name:           "cpu trigger",
+			targetCluster:  "remote",
+			triggers:       []ScaleTriggers{{Type: "cpu", MetricType: autoscalingv2.UtilizationMetricType}},
+			expectedErrMsg: "type is cpu, but the CPU & memory scalers can't scale a target in a remote cluster",
+		},
+		{
+			name:           "memory trigger",
+			targetCluster:  "remote",
+			triggers:       []ScaleTriggers{{Type: "memory"}},
+			expectedErrMsg: "type is memory, but the CPU & memory scalers can't scale a target in a remote cluster",
+		},
+		{
+			name:           "value trigger",
+			targetCluster:  "remote",
+			triggers:       []ScaleTriggers{{Type: "prometheus", MetricType: autoscalingv2.ValueMetricType}},
+			expectedErrMsg: "MetricType=Value, but only triggers with metric of type AverageValue can scale a target in a remote cluster",
+		},
+		{
+			name:             "scaling modifiers",
+			targetCluster:    "remote",
+			triggers:         []ScaleTriggers{{Name: "a", Type: "prometheus"}},
+			scalingModifiers: &ScalingModifiers{Formula: "a", Target: "1", MetricType: autoscalingv2.AverageValueMetricType},
+			expectedErrMsg:   "scalingModifiers can't scale a target in a remote cluster",
+		},
+		{
+			name:           "trigger expression",
+			targetCluster:  "remote",
+			triggers:       []ScaleTriggers{{Name: "a", Type: "prometheus", Metadata: map[string]string{TriggerExpressionMetadataKey: "value * 2"}}},
+			expectedErrMsg: "trigger a has an expression, but trigger expressions can't scale a target in a remote cluster",
+		},
+		{
+			name:           "predictive",
+			targetCluster:  "remote",
+			triggers:       []ScaleTriggers{{Type: "prometheus"}},
+			predictive:     &PredictiveConfig{},
+			expectedErrMsg: "predictive scaling can't scale a target in a remote cluster",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			so := &ScaledObject{Spec: ScaledObjectSpec{TargetCluster: test.targetCluster, Triggers: test.triggers}}
+			if test.scalingModifiers != nil {
+				so.Spec.Advanced = &AdvancedConfig{ScalingModifiers: *test.scalingModifiers}
+			}
+			if test.predictive != nil {
+				so.Spec.Advanced = &AdvancedConfig{Predictive: test.predictive}
+			}
+			err := CheckTargetClusterValid(so)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErrMsg)
+			}
+		})
+	}
+}
@@ -57,6 +57,44 @@ const ValidationsHpaOwnershipAnnotation = "validations.keda.sh/hpa-ownership"
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 const PausedAnnotation = "autoscaling.keda.sh/paused"
 
+// AutoScaleAnnotation set to "true" on a Deployment makes KEDA generate a ScaledObject for it
+const AutoScaleAnnotation = "keda.sh/auto-scale"
+
+// AutoScaleTriggerAnnotationPrefix prefixes the Deployment annotations holding the trigger metadata
+// of the generated ScaledObject, as keda.sh/trigger-<trigger type>-<metadata key>
+const AutoScaleTriggerAnnotationPrefix = "keda.sh/trigger-"
+
+// AutoScaleMinReplicaCountAnnotation and AutoScaleMaxReplicaCountAnnotation set the replica counts of the generated ScaledObject
+const AutoScaleMinReplicaCountAnnotation = "keda.sh/min-replica-count"
+const AutoScaleMaxReplicaCountAnnotation = "keda.sh/max-replica-count"
+
+// CanaryTriggerStatus is the last metric of a canary trigger, recorded for validation
+type CanaryTriggerStatus struct {
+	// +optional
+	MetricValue string `json:"metricValue,omitempty"`
+	// +optional
+	IsActive bool `json:"isActive,omitempty"`
+	// +optional
+	Error string `json:"error,omitempty"`
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// ValidationCompleted is set once the trigger has been a canary for canaryDurationSeconds
+	// +optional
+	ValidationCompleted bool `json:"validationCompleted,omitempty"`
+}
+
+// PushScalerStatus is the state of the connection of a push trigger to the server pushing its activity
+type PushScalerStatus struct {
+	Connected bool `json:"connected"`
+	// +optional
+	LastMessageTime *metav1.Time `json:"lastMessageTime,omitempty"`
+	// Error is the reason of the last disconnection
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
 	// +optional
@@ -103,6 +141,32 @@ type ScaledObjectSpec struct {
 	Triggers []ScaleTriggers `json:"triggers"`
 	// +optional
 	Fallback *Fallback `json:"fallback,omitempty"`
+	// OtelExporterEndpoint is the OTLP gRPC endpoint where the scaling decisions are exported as log records
+	// +optional
+	OtelExporterEndpoint string `json:"otelExporterEndpoint,omitempty"`
+	// DependsOn lists the ScaledObjects whose scale targets must have ready replicas before this ScaledObject is scaled up
+	// +optional
+	DependsOn []ScaledObjectDependency `json:"dependsOn,omitempty"`
+	// TargetCluster is the name of the ClusterConfig referencing the remote cluster where the scale target lives
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+	// RespectTopologySpread caps the max replicas to the number of topology domains times maxSkew
+	// of the topologySpreadConstraints of the scale target with whenUnsatisfiable DoNotSchedule
+	// +optional
+	RespectTopologySpread bool `json:"respectTopologySpread,omitempty"`
+	// MinScalingStep is the minimum difference between the current and the desired replicas for the scale target
+	// to be scaled, smaller changes are skipped to prevent thrashing
+	// +optional
+	MinScalingStep *int32 `json:"minScalingStep,omitempty"`
+}
+
+// ScaledObjectDependency references a ScaledObject the scaling of another ScaledObject depends on
+type ScaledObjectDependency struct {
+	Name string `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	MinAvailableReplicas *int32 `json:"minAvailableReplicas,omitempty"`
 }
 
 // Fallback is the spec for fallback options
@@ -119,6 +183,8 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// +optional
+	Predictive *PredictiveConfig `json:"predictive,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -131,6 +197,32 @@ type ScalingModifiers struct {
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
 }
 
+const (
+	PredictiveModelLinear      = "linear"
+	PredictiveModelHoltWinters = "holtWinters"
+)
+
+// PredictiveConfig forecasts the metrics of the triggers from their history, to scale the target ahead of
+// the load instead of when it's already there
+type PredictiveConfig struct {
+	// HorizonMinutes is how far ahead the metrics are forecast
+	HorizonMinutes int32 `json:"horizonMinutes"`
+	// Model is the forecasting model, linear for trends or holtWinters for trends with a season
+	// +kubebuilder:validation:Enum=linear;holtWinters
+	// +optional
+	Model string `json:"model,omitempty"`
+	// HistoryMinutes is how long the metrics are recorded for, 60 minutes or two seasons by default
+	// +optional
+	HistoryMinutes int32 `json:"historyMinutes,omitempty"`
+	// SeasonMinutes is the length of the season of the holtWinters model, e.g. 1440 for a daily pattern
+	// +optional
+	SeasonMinutes int32 `json:"seasonMinutes,omitempty"`
+	// Confidence is the confidence level of the forecast in percent, from 50 to 99. The upper bound of the
+	// prediction interval is used, so higher levels scale earlier. Defaults to 50, the forecast itself
+	// +optional
+	Confidence int32 `json:"confidence,omitempty"`
+}
+
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
 type HorizontalPodAutoscalerConfig struct {
 	// +optional
@@ -174,6 +266,10 @@ type ScaledObjectStatus struct {
 	// +optional
 	Health map[string]HealthStatus `json:"health,omitempty"`
 	// +optional
+	CanaryTriggers map[string]CanaryTriggerStatus `json:"canaryTriggers,omitempty"`
+	// +optional
+	PushScalers map[string]PushScalerStatus `json:"pushScalers,omitempty"`
+	// +optional
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
@@ -254,6 +350,24 @@ func (so *ScaledObject) GetHPAMaxReplicas() int32 {
 	return defaultHPAMaxReplicas
 }
 
+// IsPredictive determines whether the metrics are forecast or not
+func (so *ScaledObject) IsPredictive() bool {
+	return so.Spec.Advanced != nil && so.Spec.Advanced.Predictive != nil
+}
+
+// IsBelowMinScalingStep returns true if the change from the current to the desired replicas is smaller than minScalingStep.
+// Scaling from and to zero replicas is driven by the activation of the triggers, so it's never below the step.
+func (so *ScaledObject) IsBelowMinScalingStep(currentReplicas, desiredReplicas int32) bool {
+	if so.Spec.MinScalingStep == nil || currentReplicas == 0 || desiredReplicas == 0 {
+		return false
+	}
+	diff := desiredReplicas - currentReplicas
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < *so.Spec.MinScalingStep
+}
+
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
 // i.e. that Min is not greater than Max or Idle greater or equal to Min
 func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
@@ -271,6 +385,78 @@ func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
 		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
 	}
 
+	if scaledObject.Spec.MinScalingStep != nil && *scaledObject.Spec.MinScalingStep < 1 {
+		return fmt.Errorf("MinScalingStep=%d must be greater than 0", *scaledObject.Spec.MinScalingStep)
+	}
+
+	return nil
+}
+
+// CheckPredictiveValid checks that the predictive block of the ScaledObject is correctly specified
+func CheckPredictiveValid(scaledObject *ScaledObject) error {
+	if !scaledObject.IsPredictive() {
+		return nil
+	}
+	predictive := scaledObject.Spec.Advanced.Predictive
+
+	if predictive.HorizonMinutes < 1 {
+		return fmt.Errorf("HorizonMinutes=%d must be greater than 0", predictive.HorizonMinutes)
+	}
+	if predictive.HistoryMinutes < 0 {
+		return fmt.Errorf("HistoryMinutes=%d must be greater than 0", predictive.HistoryMinutes)
+	}
+	if predictive.Confidence != 0 && (predictive.Confidence < 50 || predictive.Confidence > 99) {
+		return fmt.Errorf("Confidence=%d must be between 50 and 99", predictive.Confidence)
+	}
+
+	switch predictive.Model {
+	case "", PredictiveModelLinear:
+		if predictive.SeasonMinutes != 0 {
+			return fmt.Errorf("SeasonMinutes can only be set with the %s model", PredictiveModelHoltWinters)
+		}
+		if predictive.HistoryMinutes != 0 && predictive.HistoryMinutes < 3 {
+			return fmt.Errorf("HistoryMinutes=%d must be at least 3 for the %s model", predictive.HistoryMinutes, PredictiveModelLinear)
+		}
+	case PredictiveModelHoltWinters:
+		if predictive.SeasonMinutes < 2 {
+			return fmt.Errorf("SeasonMinutes=%d must be at least 2 for the %s model", predictive.SeasonMinutes, PredictiveModelHoltWinters)
+		}
+		if predictive.HistoryMinutes != 0 && predictive.HistoryMinutes < 2*predictive.SeasonMinutes {
+			return fmt.Errorf("HistoryMinutes=%d must cover at least two seasons of %d minutes", predictive.HistoryMinutes, predictive.SeasonMinutes)
+		}
+	default:
+		return fmt.Errorf("Model=%s must be either %s or %s", predictive.Model, PredictiveModelLinear, PredictiveModelHoltWinters)
+	}
+	return nil
+}
+
+// CheckTargetClusterValid checks that the triggers of a ScaledObject targeting a remote cluster have an AverageValue
+// metric target, the replicas of the remote scale target being computed from it as there is no HPA scaling it.
+// Consequently, it does not support CPU & memory scalers, or scalers targeting a Value metric type. The replicas
+// being computed from the raw metrics of the triggers, scalingModifiers, trigger expressions and predictive
+// scaling aren't supported either.
+func CheckTargetClusterValid(scaledObject *ScaledObject) error {
+	if scaledObject.Spec.TargetCluster == "" {
+		return nil
+	}
+
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Type == cpuString || trigger.Type == memoryString {
+			return fmt.Errorf("type is %s, but the CPU & memory scalers can't scale a target in a remote cluster", trigger.Type)
+		}
+		if trigger.MetricType != "" && trigger.MetricType != autoscalingv2.AverageValueMetricType {
+			return fmt.Errorf("MetricType=%s, but only triggers with metric of type AverageValue can scale a target in a remote cluster", trigger.MetricType)
+		}
+		if trigger.GetExpression() != "" {
+			return fmt.Errorf("trigger %s has an expression, but trigger expressions can't scale a target in a remote cluster", trigger.Name)
+		}
+	}
+	if scaledObject.IsUsingModifiers() {
+		return fmt.Errorf("scalingModifiers can't scale a target in a remote cluster")
+	}
+	if scaledObject.IsPredictive() {
+		return fmt.Errorf("predictive scaling can't scale a target in a remote cluster")
+	}
 	return nil
 }
 
@@ -154,6 +154,9 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		verifyHpas,
 		verifyReplicaCount,
 		verifyFallback,
+		verifyPredictive,
+		verifyTargetCluster,
+		verifyDependencies,
 	}
 
 	for i := range verifyFunctions {
@@ -196,6 +199,52 @@ func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyPredictive(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckPredictiveValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-predictive")
+	}
+	return err
+}
+
+func verifyTargetCluster(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckTargetClusterValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-target-cluster")
+	}
+	return err
+}
+
+// verifyDependencies checks that the dependencies of the ScaledObject don't form a cycle
+func verifyDependencies(incomingSo *ScaledObject, action string, _ bool) error {
+	if len(incomingSo.Spec.DependsOn) == 0 {
+		return nil
+	}
+
+	// dependencies can point to ScaledObjects in other namespaces
+	soList := &ScaledObjectList{}
+	if err := kc.List(context.Background(), soList); err != nil {
+		return err
+	}
+
+	graph := NewScaledObjectDependencyGraph(soList.Items)
+	graph.Set(incomingSo)
+	cycle := graph.FindCycle(types.NamespacedName{Namespace: incomingSo.Namespace, Name: incomingSo.Name})
+	if cycle != nil {
+		names := make([]string, 0, len(cycle))
+		for _, node := range cycle {
+			names = append(names, node.String())
+		}
+		err := fmt.Errorf("dependsOn of ScaledObject '%s' forms a dependency cycle: %s", incomingSo.Name, strings.Join(names, " -> "))
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "dependency-cycle")
+		return err
+	}
+	return nil
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -214,6 +263,9 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	}
 
 	err := ValidateTriggers(triggers)
+	if _, isScaledJob := incomingObject.(*ScaledJob); err == nil && isScaledJob {
+		err = ValidateScaledJobTriggers(triggers)
+	}
 	if err != nil {
 		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
 		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
@@ -335,6 +387,16 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 			return err
 		}
 	}
+
+	// verify expressions of the triggers compile and run with dummy values
+	if incomingSo.IsUsingTriggerExpressions() {
+		_, err = CompileTriggerExpressions(incomingSo.Spec.Triggers)
+		if err != nil {
+			scaledobjectlog.Error(err, "error validating trigger expressions")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "trigger-expressions")
+			return err
+		}
+	}
 	return nil
 }
 
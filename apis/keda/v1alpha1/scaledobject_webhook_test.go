@@ -176,6 +176,26 @@ var _ = It("shouldn't validate the so creation when the fallback is wrong", func
 	}).Should(HaveOccurred())
 })
 
+var _ = It("shouldn't validate the so creation when the predictive season is missing", func() {
+	namespaceName := "wrong-predictive"
+	namespace := createNamespace(namespaceName)
+
+	so := createScaledObject(soName, namespaceName, workloadName, "apps/v1", "Deployment", false, map[string]string{}, "")
+	so.Spec.Advanced = &AdvancedConfig{
+		Predictive: &PredictiveConfig{
+			HorizonMinutes: 10,
+			Model:          PredictiveModelHoltWinters,
+		},
+	}
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), so)
+	}).Should(HaveOccurred())
+})
+
 var _ = It("shouldn't validate the so creation When the fallback are configured and the scaler is either CPU or memory.", func() {
 	namespaceName := "wrong-fallback-cpu-memory"
 	namespace := createNamespace(namespaceName)
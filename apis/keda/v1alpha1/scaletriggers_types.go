@@ -20,10 +20,16 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 )
 
+const (
+	defaultCanaryDuration     = 5 * time.Minute
+	defaultRateOfChangeWindow = time.Minute
+)
+
 // ScaleTriggers reference the scaler that will be used
 type ScaleTriggers struct {
 	Type string `json:"type"`
@@ -32,6 +38,20 @@ type ScaleTriggers struct {
 
 	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
 
+	// Canary triggers are polled and their metrics recorded in the ScaledObject status under their name,
+	// but they don't contribute to the scaling decision. They aren't supported by ScaledJobs.
+	// +optional
+	Canary bool `json:"canary,omitempty"`
+	// +optional
+	CanaryDurationSeconds *int32 `json:"canaryDurationSeconds,omitempty"`
+
+	// RateOfChange scales on the change of the metric by second over the window instead of on its value, the target
+	// being a change by second, so that fast-growing metrics are scaled on before their value crosses a threshold
+	// +optional
+	RateOfChange bool `json:"rateOfChange,omitempty"`
+	// +optional
+	RateOfChangeWindowSeconds *int32 `json:"rateOfChangeWindowSeconds,omitempty"`
+
 	Metadata map[string]string `json:"metadata"`
 	// +optional
 	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
@@ -39,6 +59,22 @@ type ScaleTriggers struct {
 	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
 }
 
+// GetCanaryDuration returns the validation period of a canary trigger, 5 minutes by default
+func (t ScaleTriggers) GetCanaryDuration() time.Duration {
+	if t.CanaryDurationSeconds != nil {
+		return time.Duration(*t.CanaryDurationSeconds) * time.Second
+	}
+	return defaultCanaryDuration
+}
+
+// GetRateOfChangeWindow returns the window the rate of change of the metric is computed over, 1 minute by default
+func (t ScaleTriggers) GetRateOfChangeWindow() time.Duration {
+	if t.RateOfChangeWindowSeconds != nil {
+		return time.Duration(*t.RateOfChangeWindowSeconds) * time.Second
+	}
+	return defaultRateOfChangeWindow
+}
+
 // AuthenticationRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
 // is used to authenticate the scaler with the environment
 type AuthenticationRef struct {
@@ -63,6 +99,23 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 		for i := 0; i < triggersCount; i++ {
 			trigger := triggers[i]
 
+			if trigger.Canary && (trigger.Type == "cpu" || trigger.Type == "memory") {
+				return fmt.Errorf("property \"canary\" is not supported for %q scaler", trigger.Type)
+			}
+			if trigger.Canary && trigger.Name == "" {
+				return fmt.Errorf("property \"canary\" requires the trigger to have a name, its status is recorded under it")
+			}
+			if trigger.CanaryDurationSeconds != nil && *trigger.CanaryDurationSeconds < 0 {
+				return fmt.Errorf("canaryDurationSeconds=%d must not be negative", *trigger.CanaryDurationSeconds)
+			}
+
+			if trigger.RateOfChange && (trigger.Type == "cpu" || trigger.Type == "memory") {
+				return fmt.Errorf("property \"rateOfChange\" is not supported for %q scaler", trigger.Type)
+			}
+			if trigger.RateOfChangeWindowSeconds != nil && *trigger.RateOfChangeWindowSeconds <= 0 {
+				return fmt.Errorf("rateOfChangeWindowSeconds=%d must be greater than 0", *trigger.RateOfChangeWindowSeconds)
+			}
+
 			if trigger.UseCachedMetrics {
 				if trigger.Type == "cpu" || trigger.Type == "memory" || trigger.Type == "cron" {
 					return fmt.Errorf("property \"useCachedMetrics\" is not supported for %q scaler", trigger.Type)
@@ -83,6 +136,18 @@ func ValidateTriggers(triggers []ScaleTriggers) error {
 	return nil
 }
 
+// ValidateScaledJobTriggers checks that the triggers of a ScaledJob don't use the properties supported by
+// ScaledObjects only, it checks:
+// - canary isn't defined, as there is no status to record the metrics of canary triggers of ScaledJobs in
+func ValidateScaledJobTriggers(triggers []ScaleTriggers) error {
+	for _, trigger := range triggers {
+		if trigger.Canary {
+			return fmt.Errorf("property \"canary\" is not supported for ScaledJob triggers")
+		}
+	}
+	return nil
+}
+
 // CombinedTriggersAndAuthenticationsTypes returns a comma separated string of all trigger types and authentication types
 func CombinedTriggersAndAuthenticationsTypes(triggers []ScaleTriggers) (string, string) {
 	var triggersTypes []string
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateTriggers(t *testing.T) {
@@ -84,6 +85,61 @@ func TestValidateTriggers(t *testing.T) {
 			},
 			expectedErrMsg: "",
 		},
+		{
+			name: "unsupported canary property for cpu scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:   "trigger5",
+					Type:   "cpu",
+					Canary: true,
+				},
+			},
+			expectedErrMsg: "property \"canary\" is not supported for \"cpu\" scaler",
+		},
+		{
+			name: "canary property without trigger name",
+			triggers: []ScaleTriggers{
+				{
+					Type:   "kafka",
+					Canary: true,
+				},
+			},
+			expectedErrMsg: "property \"canary\" requires the trigger to have a name, its status is recorded under it",
+		},
+		{
+			name: "supported canary property for kafka scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:   "trigger6",
+					Type:   "kafka",
+					Canary: true,
+				},
+			},
+			expectedErrMsg: "",
+		},
+		{
+			name: "unsupported rateOfChange property for memory scaler",
+			triggers: []ScaleTriggers{
+				{
+					Name:         "trigger7",
+					Type:         "memory",
+					RateOfChange: true,
+				},
+			},
+			expectedErrMsg: "property \"rateOfChange\" is not supported for \"memory\" scaler",
+		},
+		{
+			name: "invalid rateOfChangeWindowSeconds",
+			triggers: []ScaleTriggers{
+				{
+					Name:                      "trigger8",
+					Type:                      "rabbitmq",
+					RateOfChange:              true,
+					RateOfChangeWindowSeconds: ptr.To[int32](0),
+				},
+			},
+			expectedErrMsg: "rateOfChangeWindowSeconds=0 must be greater than 0",
+		},
 		{
 			name:           "empty triggers array should be blocked",
 			triggers:       []ScaleTriggers{},
@@ -103,3 +159,9 @@ func TestValidateTriggers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateScaledJobTriggers(t *testing.T) {
+	assert.NoError(t, ValidateScaledJobTriggers([]ScaleTriggers{{Name: "trigger1", Type: "kafka"}}))
+	assert.EqualError(t, ValidateScaledJobTriggers([]ScaleTriggers{{Name: "trigger1", Type: "kafka", Canary: true}}),
+		"property \"canary\" is not supported for ScaledJob triggers")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+// TriggerExpressionMetadataKey is the trigger metadata holding an expression that computes the metric value
+// of the trigger from the metric values of the other triggers, referenced by their names
+const TriggerExpressionMetadataKey = "expression"
+
+// GetExpression returns the expression computing the metric value of the trigger, empty if there is none
+func (t ScaleTriggers) GetExpression() string {
+	return t.Metadata[TriggerExpressionMetadataKey]
+}
+
+// IsUsingTriggerExpressions returns true if any trigger of the ScaledObject computes its metric value with an expression
+func (so *ScaledObject) IsUsingTriggerExpressions() bool {
+	for _, trigger := range so.Spec.Triggers {
+		if trigger.GetExpression() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CompileTriggerExpressions validates and compiles the expressions of the triggers, the programs are returned by trigger name.
+// Each expression is dry-run with zero values for all the variables, which reports unknown variables and divisions
+// by a zero constant. Divisions by a variable can't be caught this way, they are reported when the expression is evaluated.
+func CompileTriggerExpressions(triggers []ScaleTriggers) (map[string]*vm.Program, error) {
+	programs := map[string]*vm.Program{}
+	for _, trigger := range triggers {
+		expression := trigger.GetExpression()
+		if expression == "" {
+			continue
+		}
+		if trigger.Name == "" {
+			return nil, fmt.Errorf("trigger of type %q with an expression must have a name", trigger.Type)
+		}
+
+		// the variables are the other triggers providing external metrics
+		variables := make(map[string]float64)
+		for _, other := range triggers {
+			if other.Name == "" || other.Name == trigger.Name || other.Type == cpuString || other.Type == memoryString {
+				continue
+			}
+			variables[other.Name] = 0
+		}
+
+		divisorChecker := &zeroDivisorChecker{}
+		program, err := expr.Compile(castToFloatIfNecessary(expression), expr.Env(variables), expr.AsFloat64(), expr.Patch(divisorChecker))
+		if err != nil {
+			return nil, fmt.Errorf("error compiling expression of trigger %q: %w", trigger.Name, err)
+		}
+		if divisorChecker.found {
+			return nil, fmt.Errorf("error validating expression of trigger %q: division by zero", trigger.Name)
+		}
+		if _, err := expr.Run(program, variables); err != nil {
+			return nil, fmt.Errorf("error running expression of trigger %q: %w", trigger.Name, err)
+		}
+		programs[trigger.Name] = program
+	}
+	return programs, nil
+}
+
+// zeroDivisorChecker looks for divisions and modulos by a zero constant in the expression
+type zeroDivisorChecker struct {
+	found bool
+}
+
+func (c *zeroDivisorChecker) Visit(node *ast.Node) {
+	binary, ok := (*node).(*ast.BinaryNode)
+	if !ok || (binary.Operator != "/" && binary.Operator != "%") {
+		return
+	}
+	switch divisor := binary.Right.(type) {
+	case *ast.IntegerNode:
+		c.found = c.found || divisor.Value == 0
+	case *ast.FloatNode:
+		c.found = c.found || divisor.Value == 0
+	}
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileTriggerExpressions(t *testing.T) {
+	newTriggers := func(expression string) []ScaleTriggers {
+		return []ScaleTriggers{
+			{Name: "lag", Type: "kafka"},
+			{Name: "workers", Type: "prometheus"},
+			{Name: "buffer", Type: "prometheus"},
+			{Name: "cpu", Type: "cpu"},
+			{Name: "computed", Type: "prometheus", Metadata: map[string]string{TriggerExpressionMetadataKey: expression}},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		triggers       []ScaleTriggers
+		expectedErrMsg string
+	}{
+		{
+			name:     "arithmetic on other triggers",
+			triggers: newTriggers("lag / workers - buffer"),
+		},
+		{
+			name:     "ternary operator",
+			triggers: newTriggers("workers > 0 ? lag / workers : lag"),
+		},
+		{
+			name:           "unknown variable",
+			triggers:       newTriggers("lag / consumers"),
+			expectedErrMsg: "unknown name consumers",
+		},
+		{
+			name:           "self reference",
+			triggers:       newTriggers("computed + lag"),
+			expectedErrMsg: "unknown name computed",
+		},
+		{
+			name:           "resource triggers are not variables",
+			triggers:       newTriggers("cpu * 2"),
+			expectedErrMsg: "unknown name cpu",
+		},
+		{
+			name:           "division by zero",
+			triggers:       newTriggers("lag / 0"),
+			expectedErrMsg: "division by zero",
+		},
+		{
+			name:           "modulo by zero",
+			triggers:       newTriggers("int(lag) % 0"),
+			expectedErrMsg: "division by zero",
+		},
+		{
+			name:           "syntax error",
+			triggers:       newTriggers("lag / (workers"),
+			expectedErrMsg: "error compiling expression of trigger \"computed\"",
+		},
+		{
+			name: "unnamed trigger with expression",
+			triggers: []ScaleTriggers{
+				{Name: "lag", Type: "kafka"},
+				{Type: "prometheus", Metadata: map[string]string{TriggerExpressionMetadataKey: "lag * 2"}},
+			},
+			expectedErrMsg: "must have a name",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			programs, err := CompileTriggerExpressions(test.triggers)
+			if test.expectedErrMsg == "" {
+				assert.NoError(t, err)
+				assert.Contains(t, programs, "computed")
+				return
+			}
+			assert.ErrorContains(t, err, test.expectedErrMsg)
+		})
+	}
+}
+
+func TestCompiledTriggerExpressionEvaluation(t *testing.T) {
+	programs, err := CompileTriggerExpressions([]ScaleTriggers{
+		{Name: "lag", Type: "kafka"},
+		{Name: "workers", Type: "prometheus"},
+		{Name: "buffer", Type: "prometheus"},
+		{Name: "computed", Type: "prometheus", Metadata: map[string]string{TriggerExpressionMetadataKey: "lag / workers - buffer"}},
+	})
+	assert.NoError(t, err)
+
+	value, err := expr.Run(programs["computed"], map[string]float64{"lag": 100, "workers": 4, "buffer": 5})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(20), value)
+}
@@ -95,6 +95,9 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// +optional
+	WebhookSigningSecret *AuthWebhookSigningSecret `json:"webhookSigningSecret,omitempty"`
 }
 
 // TriggerAuthenticationStatus defines the observed state of TriggerAuthentication
@@ -193,6 +196,12 @@ type AuthConfigMapTargetRef AuthTargetRef
 // AuthSecretTargetRef is used to authenticate using a reference to a secret
 type AuthSecretTargetRef AuthTargetRef
 
+// AuthWebhookSigningSecret is a reference to the secret key used to sign webhook notifications
+type AuthWebhookSigningSecret struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
 // AuthTargetRef is used to authenticate using a reference to a resource
 type AuthTargetRef struct {
 	Parameter string `json:"parameter"`
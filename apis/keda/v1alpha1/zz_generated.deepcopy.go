@@ -35,6 +35,11 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.ScalingModifiers = in.ScalingModifiers
+	if in.Predictive != nil {
+		in, out := &in.Predictive, &out.Predictive
+		*out = new(PredictiveConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -147,6 +152,21 @@ func (in *AuthTargetRef) DeepCopy() *AuthTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthWebhookSigningSecret) DeepCopyInto(out *AuthWebhookSigningSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthWebhookSigningSecret.
+func (in *AuthWebhookSigningSecret) DeepCopy() *AuthWebhookSigningSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthWebhookSigningSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationRef) DeepCopyInto(out *AuthenticationRef) {
 	*out = *in
@@ -354,6 +374,97 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryTriggerStatus) DeepCopyInto(out *CanaryTriggerStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryTriggerStatus.
+func (in *CanaryTriggerStatus) DeepCopy() *CanaryTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfig.
+func (in *ClusterConfig) DeepCopy() *ClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigList) DeepCopyInto(out *ClusterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigList.
+func (in *ClusterConfigList) DeepCopy() *ClusterConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigSpec) DeepCopyInto(out *ClusterConfigSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigSpec.
+func (in *ClusterConfigSpec) DeepCopy() *ClusterConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
@@ -634,6 +745,55 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretRef) DeepCopyInto(out *KubeconfigSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretRef.
+func (in *KubeconfigSecretRef) DeepCopy() *KubeconfigSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictiveConfig) DeepCopyInto(out *PredictiveConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictiveConfig.
+func (in *PredictiveConfig) DeepCopy() *PredictiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushScalerStatus) DeepCopyInto(out *PushScalerStatus) {
+	*out = *in
+	if in.LastMessageTime != nil {
+		in, out := &in.LastMessageTime, &out.LastMessageTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushScalerStatus.
+func (in *PushScalerStatus) DeepCopy() *PushScalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PushScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -667,6 +827,16 @@ func (in *ScaleTarget) DeepCopy() *ScaleTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	*out = *in
+	if in.CanaryDurationSeconds != nil {
+		in, out := &in.CanaryDurationSeconds, &out.CanaryDurationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RateOfChangeWindowSeconds != nil {
+		in, out := &in.RateOfChangeWindowSeconds, &out.RateOfChangeWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
@@ -880,6 +1050,26 @@ func (in *ScaledObjectCustomValidator) DeepCopy() *ScaledObjectCustomValidator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectDependency) DeepCopyInto(out *ScaledObjectDependency) {
+	*out = *in
+	if in.MinAvailableReplicas != nil {
+		in, out := &in.MinAvailableReplicas, &out.MinAvailableReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectDependency.
+func (in *ScaledObjectDependency) DeepCopy() *ScaledObjectDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledObjectList) DeepCopyInto(out *ScaledObjectList) {
 	*out = *in
@@ -967,6 +1157,18 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(Fallback)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]ScaledObjectDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinScalingStep != nil {
+		in, out := &in.MinScalingStep, &out.MinScalingStep
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectSpec.
@@ -1018,6 +1220,20 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.CanaryTriggers != nil {
+		in, out := &in.CanaryTriggers, &out.CanaryTriggers
+		*out = make(map[string]CanaryTriggerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PushScalers != nil {
+		in, out := &in.PushScalers, &out.PushScalers
+		*out = make(map[string]PushScalerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.PausedReplicaCount != nil {
 		in, out := &in.PausedReplicaCount, &out.PausedReplicaCount
 		*out = new(int32)
@@ -1202,6 +1418,11 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = new(AwsSecretManager)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WebhookSigningSecret != nil {
+		in, out := &in.WebhookSigningSecret, &out.WebhookSigningSecret
+		*out = new(AuthWebhookSigningSecret)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationSpec.
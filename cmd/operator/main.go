@@ -17,10 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"net/http"
 	"os"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/spf13/pflag"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -34,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -46,7 +50,12 @@ import (
 	"github.com/kedacore/keda/v2/pkg/k8s"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/scalers/cloudeventsink"
+	"github.com/kedacore/keda/v2/pkg/scalers/pushreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers/remotewrite"
+	webhookscaler "github.com/kedacore/keda/v2/pkg/scalers/webhook"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/predictive"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -67,6 +76,7 @@ func init() {
 func main() {
 	var enablePrometheusMetrics bool
 	var enableOpenTelemetryMetrics bool
+	var enableHighCardinalityMetrics bool
 	var metricsAddr string
 	var probeAddr string
 	var metricsServiceAddr string
@@ -86,8 +96,19 @@ func main() {
 	var validatingWebhookName string
 	var caDirs []string
 	var enableWebhookPatching bool
+	var vpaConflictPolicy string
+	var gracefulShutdownTimeout time.Duration
+	var enableAutoDiscovery bool
+	var remoteWriteAddr string
+	var remoteWriteCertDir string
+	var webhookScalerAddr string
+	var webhookScalerCertDir string
+	var predictiveRedisURL string
+	var cloudEventsAddr string
+	var cloudEventsCertDir string
 	pflag.BoolVar(&enablePrometheusMetrics, "enable-prometheus-metrics", true, "Enable the prometheus metric of keda-operator.")
 	pflag.BoolVar(&enableOpenTelemetryMetrics, "enable-opentelemetry-metrics", false, "Enable the opentelemetry metric of keda-operator.")
+	pflag.BoolVar(&enableHighCardinalityMetrics, "high-cardinality-metrics", false, "Add namespace and name labels of the scaled resource to the per scaler type metrics of keda-operator.")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	pflag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPRC Metrics Service endpoint binds to.")
@@ -108,6 +129,16 @@ func main() {
 	pflag.BoolVar(&enableCertRotation, "enable-cert-rotation", false, "enable automatic generation and rotation of TLS certificates/keys")
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
 	pflag.StringArrayVar(&caDirs, "ca-dir", []string{"/custom/ca"}, "Directory with CA certificates for scalers to authenticate TLS connections. Can be specified multiple times. Defaults to /custom/ca")
+	pflag.StringVar(&vpaConflictPolicy, "vpa-conflict-policy", kedacontrollers.VPAConflictPolicyWarn, "Policy for ScaledObjects whose scale target is also managed by a VerticalPodAutoscaler evicting pods, either warn, only reporting the conflict, or block, which also stops scaling the scale target until the conflict is resolved. Defaults to warn")
+	pflag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second, "Time the manager waits for its runnables to stop on shutdown, in-flight reconciles being given 80% of it to complete. Defaults to 30s")
+	pflag.BoolVar(&enableAutoDiscovery, "enable-auto-discovery", false, "Generate ScaledObjects for the Deployments annotated with keda.sh/auto-scale: \"true\". Defaults to false")
+	pflag.StringVar(&remoteWriteAddr, "remote-write-bind-address", "", "The address the HTTPS Prometheus remote-write endpoint of the prometheus-remote-write scaler binds to. Disabled when empty")
+	pflag.StringVar(&remoteWriteCertDir, "remote-write-cert-dir", "", "Directory with the tls.crt and tls.key served by the Prometheus remote-write endpoint. Defaults to the directory of --cert-dir")
+	pflag.StringVar(&webhookScalerAddr, "webhook-scaler-bind-address", "", "The address the HTTPS endpoint of the webhook scaler binds to. Disabled when empty")
+	pflag.StringVar(&webhookScalerCertDir, "webhook-scaler-cert-dir", "", "Directory with the tls.crt and tls.key served by the endpoint of the webhook scaler. Defaults to the directory of --cert-dir")
+	pflag.StringVar(&cloudEventsAddr, "cloudevents-bind-address", "", "The address the HTTPS CloudEvents endpoint of the cloudevents scaler binds to. Disabled when empty")
+	pflag.StringVar(&cloudEventsCertDir, "cloudevents-cert-dir", "", "Directory with the tls.crt and tls.key served by the CloudEvents endpoint. Defaults to the directory of --cert-dir")
+	pflag.StringVar(&predictiveRedisURL, "predictive-history-redis-url", "", "The URL of the Redis keeping the metric histories of the predictive ScaledObjects across restarts, e.g. redis://:password@redis:6379/0. Histories are only kept in memory when empty")
 	pflag.BoolVar(&enableWebhookPatching, "enable-webhook-patching", true, "Enable patching of webhook resources. Defaults to true.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -155,7 +186,7 @@ func main() {
 	if !enablePrometheusMetrics {
 		metricsAddr = "0"
 	}
-	metricscollector.NewMetricsCollectors(enablePrometheusMetrics, enableOpenTelemetryMetrics)
+	metricscollector.NewMetricsCollectors(enablePrometheusMetrics, enableOpenTelemetryMetrics, enableHighCardinalityMetrics)
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
@@ -176,6 +207,7 @@ func main() {
 		LeaseDuration:           leaseDuration,
 		RenewDeadline:           renewDeadline,
 		RetryPeriod:             retryPeriod,
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -225,15 +257,31 @@ func main() {
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	var predictiveStore predictive.Store
+	if predictiveRedisURL != "" {
+		redisOptions, err := redis.ParseURL(predictiveRedisURL)
+		if err != nil {
+			setupLog.Error(err, "invalid predictive history redis url")
+			os.Exit(1)
+		}
+		predictiveStore = predictive.NewRedisStore(redis.NewClient(redisOptions))
+	}
+
+	// the in-flight work is given a deadline shorter than the graceful shutdown timeout of the manager,
+	// for it to be done before the manager gives up on its runnables
+	reconcileShutdownTimeout := gracefulShutdownTimeout * 4 / 5
+
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister(), predictive.NewHistories(predictiveStore))
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName, secretInformer.Lister())
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
-		Client:       mgr.GetClient(),
-		Scheme:       mgr.GetScheme(),
-		ScaleClient:  scaleClient,
-		ScaleHandler: scaledHandler,
-		EventEmitter: eventEmitter,
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		ScaleClient:             scaleClient,
+		ScaleHandler:            scaledHandler,
+		EventEmitter:            eventEmitter,
+		VPAConflictPolicy:       vpaConflictPolicy,
+		GracefulShutdownTimeout: reconcileShutdownTimeout,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
 	}); err != nil {
@@ -253,6 +301,15 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
+	if enableAutoDiscovery {
+		if err = (&kedacontrollers.DeploymentAutoScaleReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DeploymentAutoScale")
+			os.Exit(1)
+		}
+	}
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
 		Client:       mgr.GetClient(),
 		EventHandler: eventEmitter,
@@ -325,6 +382,48 @@ func main() {
 		os.Exit(1)
 	}
 
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		// the scale events pending export are flushed within the graceful shutdown timeout
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), reconcileShutdownTimeout)
+		defer cancel()
+		return scaledHandler.Shutdown(shutdownCtx)
+	}))
+	if err != nil {
+		setupLog.Error(err, "unable to set up scale handler shutdown")
+		os.Exit(1)
+	}
+
+	// the push endpoints of the scalers are served over HTTPS, once the certificates are generated by the rotation
+	pushEndpoints := []struct {
+		name, address, certDir string
+		handler                http.Handler
+	}{
+		{"Prometheus remote-write", remoteWriteAddr, remoteWriteCertDir, remotewrite.DefaultReceiver},
+		{"CloudEvents", cloudEventsAddr, cloudEventsCertDir, cloudeventsink.DefaultReceiver},
+		{"webhook scaler", webhookScalerAddr, webhookScalerCertDir, webhookscaler.DefaultReceiver},
+	}
+	for _, endpoint := range pushEndpoints {
+		if endpoint.address == "" {
+			continue
+		}
+		if endpoint.certDir == "" {
+			endpoint.certDir = certDir
+		}
+		err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			select {
+			case <-certReady:
+			case <-ctx.Done():
+				return nil
+			}
+			return pushreceiver.ListenAndServe(ctx, endpoint.address, endpoint.certDir, endpoint.handler)
+		}))
+		if err != nil {
+			setupLog.Error(err, "unable to set up "+endpoint.name+" endpoint")
+			os.Exit(1)
+		}
+	}
+
 	kedautil.PrintWelcome(setupLog, kubeVersion, "manager")
 
 	kubeInformerFactory.Start(ctx.Done())
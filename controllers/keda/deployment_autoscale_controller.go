@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=create;delete
+
+// DeploymentAutoScaleReconciler generates a ScaledObject for each Deployment annotated with keda.sh/auto-scale: "true",
+// the triggers are read from the keda.sh/trigger-<trigger type>-<metadata key> annotations of the Deployment.
+// The generated ScaledObject is owned by the Deployment, so it's deleted with it.
+type DeploymentAutoScaleReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager initializes the DeploymentAutoScaleReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *DeploymentAutoScaleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("deployment-autoscale").
+		// only the annotations of the Deployments are relevant
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		// revert the changes made to the generated ScaledObjects
+		Owns(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
+
+// Reconcile creates, updates or deletes the ScaledObject generated for the Deployment according to its annotations.
+func (r *DeploymentAutoScaleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, req.NamespacedName, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			// the generated ScaledObject is garbage collected with the Deployment
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "failed to get Deployment")
+		return ctrl.Result{}, err
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	err := r.Client.Get(ctx, req.NamespacedName, scaledObject)
+	if err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "failed to get ScaledObject")
+		return ctrl.Result{}, err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(scaledObject, deployment) {
+		// never touch a ScaledObject which wasn't generated for the Deployment
+		reqLogger.V(1).Info("ScaledObject with the name of the Deployment isn't generated from its annotations, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	if deployment.Annotations[kedav1alpha1.AutoScaleAnnotation] != "true" {
+		if exists {
+			return ctrl.Result{}, r.deleteGeneratedScaledObject(ctx, reqLogger, scaledObject)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	spec, err := scaledObjectSpecFromAnnotations(deployment)
+	if err != nil {
+		reqLogger.Error(err, "Deployment doesn't have correct auto-scale annotations")
+		// requeuing doesn't help, the Deployment is reconciled again once its annotations change
+		return ctrl.Result{}, nil
+	}
+
+	if !exists {
+		scaledObject = &kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+			Spec:       *spec,
+		}
+		if err := controllerutil.SetControllerReference(deployment, scaledObject, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Client.Create(ctx, scaledObject); err != nil {
+			reqLogger.Error(err, "failed to create ScaledObject for Deployment")
+			return ctrl.Result{}, err
+		}
+		reqLogger.Info("Created ScaledObject for Deployment")
+		return ctrl.Result{}, nil
+	}
+
+	if reflect.DeepEqual(scaledObject.Spec, *spec) {
+		return ctrl.Result{}, nil
+	}
+	scaledObject.Spec = *spec
+	if err := r.Client.Update(ctx, scaledObject); err != nil {
+		reqLogger.Error(err, "failed to update ScaledObject for Deployment")
+		return ctrl.Result{}, err
+	}
+	reqLogger.Info("Updated ScaledObject for Deployment")
+	return ctrl.Result{}, nil
+}
+
+func (r *DeploymentAutoScaleReconciler) deleteGeneratedScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := r.Client.Delete(ctx, scaledObject); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to delete ScaledObject for Deployment")
+		return err
+	}
+	logger.Info("Deleted ScaledObject for Deployment, auto-scale annotation was removed")
+	return nil
+}
+
+// scaledObjectSpecFromAnnotations builds the spec of the ScaledObject scaling the Deployment from its annotations,
+// there is one trigger for each trigger type found in the keda.sh/trigger-<trigger type>-<metadata key> annotations
+func scaledObjectSpecFromAnnotations(deployment *appsv1.Deployment) (*kedav1alpha1.ScaledObjectSpec, error) {
+	metadataByType := map[string]map[string]string{}
+	for annotation, value := range deployment.Annotations {
+		if !strings.HasPrefix(annotation, kedav1alpha1.AutoScaleTriggerAnnotationPrefix) {
+			continue
+		}
+		// trigger types may contain dashes (e.g. azure-queue), but metadata keys don't
+		typeAndKey := strings.TrimPrefix(annotation, kedav1alpha1.AutoScaleTriggerAnnotationPrefix)
+		i := strings.LastIndex(typeAndKey, "-")
+		if i <= 0 || i == len(typeAndKey)-1 {
+			return nil, fmt.Errorf("annotation %s must be of the form %s<trigger type>-<metadata key>", annotation, kedav1alpha1.AutoScaleTriggerAnnotationPrefix)
+		}
+		triggerType, key := typeAndKey[:i], typeAndKey[i+1:]
+		if metadataByType[triggerType] == nil {
+			metadataByType[triggerType] = map[string]string{}
+		}
+		metadataByType[triggerType][key] = value
+	}
+	if len(metadataByType) == 0 {
+		return nil, fmt.Errorf("no trigger found in %s<trigger type>-<metadata key> annotations", kedav1alpha1.AutoScaleTriggerAnnotationPrefix)
+	}
+
+	triggers := make([]kedav1alpha1.ScaleTriggers, 0, len(metadataByType))
+	for triggerType, metadata := range metadataByType {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{Type: triggerType, Metadata: metadata})
+	}
+	sort.Slice(triggers, func(i, j int) bool { return triggers[i].Type < triggers[j].Type })
+
+	spec := &kedav1alpha1.ScaledObjectSpec{
+		ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: deployment.Name},
+		Triggers:       triggers,
+	}
+	var err error
+	if spec.MinReplicaCount, err = replicaCountFromAnnotation(deployment, kedav1alpha1.AutoScaleMinReplicaCountAnnotation); err != nil {
+		return nil, err
+	}
+	if spec.MaxReplicaCount, err = replicaCountFromAnnotation(deployment, kedav1alpha1.AutoScaleMaxReplicaCountAnnotation); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func replicaCountFromAnnotation(deployment *appsv1.Deployment, annotation string) (*int32, error) {
+	value, found := deployment.Annotations[annotation]
+	if !found {
+		return nil, nil
+	}
+	count, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("annotation %s must be a non negative integer, got %q", annotation, value)
+	}
+	replicas := int32(count)
+	return &replicas, nil
+}
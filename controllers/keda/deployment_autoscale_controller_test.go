@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newAutoScaleTestReconciler(t *testing.T, objects ...client.Object) (*DeploymentAutoScaleReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &DeploymentAutoScaleReconciler{Client: fakeClient, Scheme: scheme}, fakeClient
+}
+
+func newAnnotatedDeployment(annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default", UID: "deployment-uid", Annotations: annotations},
+	}
+}
+
+func reconcileDeployment(t *testing.T, r *DeploymentAutoScaleReconciler) {
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "consumer", Namespace: "default"}})
+	assert.NoError(t, err)
+}
+
+func TestDeploymentAutoScaleCreatesScaledObject(t *testing.T) {
+	deployment := newAnnotatedDeployment(map[string]string{
+		kedav1alpha1.AutoScaleAnnotation:                "true",
+		"keda.sh/trigger-kafka-bootstrapServers":        "kafka:9092",
+		"keda.sh/trigger-kafka-topic":                   "orders",
+		"keda.sh/trigger-kafka-consumerGroup":           "consumer",
+		"keda.sh/trigger-azure-queue-queueName":         "orders",
+		kedav1alpha1.AutoScaleMaxReplicaCountAnnotation: "20",
+		"unrelated": "annotation",
+	})
+	r, fakeClient := newAutoScaleTestReconciler(t, deployment)
+
+	reconcileDeployment(t, r)
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, scaledObject))
+	assert.True(t, metav1.IsControlledBy(scaledObject, deployment))
+	assert.Equal(t, "consumer", scaledObject.Spec.ScaleTargetRef.Name)
+	assert.Nil(t, scaledObject.Spec.MinReplicaCount)
+	assert.Equal(t, int32(20), *scaledObject.Spec.MaxReplicaCount)
+	assert.Equal(t, []kedav1alpha1.ScaleTriggers{
+		{Type: "azure-queue", Metadata: map[string]string{"queueName": "orders"}},
+		{Type: "kafka", Metadata: map[string]string{"bootstrapServers": "kafka:9092", "topic": "orders", "consumerGroup": "consumer"}},
+	}, scaledObject.Spec.Triggers)
+
+	// the trigger metadata follow the annotations
+	deployment.Annotations["keda.sh/trigger-kafka-topic"] = "payments"
+	delete(deployment.Annotations, "keda.sh/trigger-azure-queue-queueName")
+	assert.NoError(t, fakeClient.Update(context.Background(), deployment))
+	reconcileDeployment(t, r)
+
+	assert.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, scaledObject))
+	assert.Equal(t, []kedav1alpha1.ScaleTriggers{
+		{Type: "kafka", Metadata: map[string]string{"bootstrapServers": "kafka:9092", "topic": "payments", "consumerGroup": "consumer"}},
+	}, scaledObject.Spec.Triggers)
+}
+
+func TestDeploymentAutoScaleDeletesScaledObjectWhenAnnotationIsRemoved(t *testing.T) {
+	deployment := newAnnotatedDeployment(map[string]string{
+		kedav1alpha1.AutoScaleAnnotation: "true",
+		"keda.sh/trigger-kafka-topic":    "orders",
+	})
+	r, fakeClient := newAutoScaleTestReconciler(t, deployment)
+	reconcileDeployment(t, r)
+
+	delete(deployment.Annotations, kedav1alpha1.AutoScaleAnnotation)
+	assert.NoError(t, fakeClient.Update(context.Background(), deployment))
+	reconcileDeployment(t, r)
+
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, &kedav1alpha1.ScaledObject{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestDeploymentAutoScaleIgnoresScaledObjectNotGenerated(t *testing.T) {
+	deployment := newAnnotatedDeployment(map[string]string{
+		kedav1alpha1.AutoScaleAnnotation: "true",
+		"keda.sh/trigger-kafka-topic":    "orders",
+	})
+	existing := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "consumer"},
+			Triggers:       []kedav1alpha1.ScaleTriggers{{Type: "cron", Metadata: map[string]string{"timezone": "UTC"}}},
+		},
+	}
+	r, fakeClient := newAutoScaleTestReconciler(t, deployment, existing)
+	reconcileDeployment(t, r)
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "consumer", Namespace: "default"}, scaledObject))
+	assert.Equal(t, "cron", scaledObject.Spec.Triggers[0].Type)
+}
+
+func TestScaledObjectSpecFromAnnotationsErrors(t *testing.T) {
+	for name, annotations := range map[string]map[string]string{
+		"no trigger":              {kedav1alpha1.AutoScaleAnnotation: "true"},
+		"missing metadata key":    {kedav1alpha1.AutoScaleAnnotation: "true", "keda.sh/trigger-kafka-": "orders"},
+		"missing trigger type":    {kedav1alpha1.AutoScaleAnnotation: "true", "keda.sh/trigger-topic": "orders"},
+		"malformed replica count": {kedav1alpha1.AutoScaleAnnotation: "true", "keda.sh/trigger-kafka-topic": "orders", kedav1alpha1.AutoScaleMinReplicaCountAnnotation: "one"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := scaledObjectSpecFromAnnotations(newAnnotatedDeployment(annotations))
+			assert.Error(t, err)
+		})
+	}
+}
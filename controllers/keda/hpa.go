@@ -29,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -99,8 +101,8 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 		labels[key] = value
 	}
 
-	minReplicas := scaledObject.GetHPAMinReplicas()
-	maxReplicas := scaledObject.GetHPAMaxReplicas()
+	minReplicas := ptr.To(r.applyPodDisruptionBudgetMinimum(ctx, logger, scaledObject, gvkr, *scaledObject.GetHPAMinReplicas(), scaledObject.GetHPAMaxReplicas()))
+	maxReplicas := r.applyTopologySpreadCap(ctx, logger, scaledObject, *minReplicas, scaledObject.GetHPAMaxReplicas())
 
 	pausedCount, err := executor.GetPausedReplicaCount(scaledObject)
 	if err != nil {
@@ -336,6 +338,15 @@ func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricN
 }
 
 // getHPAName returns generated HPA name for ScaledObject specified in the parameter
+// getCurrentHPA returns the HPA of the ScaledObject, nil if it doesn't exist yet or can't be read
+func (r *ScaledObjectReconciler) getCurrentHPA(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: getHPANameOnEnsure(scaledObject), Namespace: scaledObject.Namespace}, hpa); err != nil {
+		return nil
+	}
+	return hpa
+}
+
 func getHPAName(scaledObject *kedav1alpha1.ScaledObject) string {
 	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Name != "" {
 		return scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Name
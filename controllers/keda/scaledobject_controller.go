@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -32,6 +33,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -48,16 +51,18 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/k8s"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+// +kubebuilder:rbac:groups=keda.sh,resources=clusterconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;list;watch
 // +kubebuilder:rbac:groups="",resources=pods;services;services;secrets;external,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*/scale",verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs=list;watch
@@ -65,6 +70,11 @@ import (
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups="",resources="limitranges",verbs=list;watch
+// +kubebuilder:rbac:groups="",resources="nodes",verbs=list;watch
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=list;watch
+// +kubebuilder:rbac:groups="autoscaling.k8s.io",resources=verticalpodautoscalers,verbs=list
+// +kubebuilder:rbac:groups="argoproj.io",resources=workflows,verbs=list
+// +kubebuilder:rbac:groups="tekton.dev",resources=pipelineruns;taskruns,verbs=list
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -74,6 +84,21 @@ type ScaledObjectReconciler struct {
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
 
+	// DiscoveryClient is used to check whether the scale target exposes /scale subresource,
+	// it is created from the manager's config if not set
+	DiscoveryClient discovery.DiscoveryInterface
+	// RemoteClusters provides the clients for scale targets living in the clusters referenced by ClusterConfigs,
+	// it is created from the Client if not set
+	RemoteClusters *k8s.RemoteClusters
+	// DynamicClient is used to list VerticalPodAutoscalers, it is created from the manager's config if not set
+	DynamicClient dynamic.Interface
+	// VPAConflictPolicy is either VPAConflictPolicyWarn (default) or VPAConflictPolicyBlock
+	VPAConflictPolicy string
+	// GracefulShutdownTimeout is how long in-flight reconciles are allowed to complete once the operator is stopping,
+	// they are canceled right away if it isn't set. It has to be shorter than the GracefulShutdownTimeout of the
+	// manager, which otherwise stops waiting for the reconciles first
+	GracefulShutdownTimeout time.Duration
+
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
 }
@@ -86,6 +111,8 @@ type scaledObjectMetricsData struct {
 var (
 	// A cache mapping "resource.group" to true or false if we know if this resource is scalable.
 	isScalableCache *sync.Map
+	// A cache of the GVK strings the discovery API advertises /scale subresource for.
+	scaleSubresourceCache *sync.Map
 
 	scaledObjectPromMetricsMap  map[string]scaledObjectMetricsData
 	scaledObjectPromMetricsLock *sync.Mutex
@@ -96,6 +123,7 @@ func init() {
 	isScalableCache = &sync.Map{}
 	isScalableCache.Store("deployments.apps", true)
 	isScalableCache.Store("statefulsets.apps", true)
+	scaleSubresourceCache = &sync.Map{}
 
 	scaledObjectPromMetricsMap = make(map[string]scaledObjectMetricsData)
 	scaledObjectPromMetricsLock = &sync.Mutex{}
@@ -121,6 +149,30 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 	if r.EventEmitter == nil {
 		return fmt.Errorf("ScaledObjectReconciler.EventEmitter is not initialized")
 	}
+	if r.DiscoveryClient == nil {
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			return fmt.Errorf("error creating discovery client: %w", err)
+		}
+		r.DiscoveryClient = discoveryClient
+	}
+	if r.RemoteClusters == nil {
+		r.RemoteClusters = k8s.NewRemoteClusters(r.Client)
+	}
+	if r.DynamicClient == nil {
+		dynamicClient, err := dynamic.NewForConfig(mgr.GetConfig())
+		if err != nil {
+			return fmt.Errorf("error creating dynamic client: %w", err)
+		}
+		r.DynamicClient = dynamicClient
+	}
+	switch r.VPAConflictPolicy {
+	case "":
+		r.VPAConflictPolicy = VPAConflictPolicyWarn
+	case VPAConflictPolicyWarn, VPAConflictPolicyBlock:
+	default:
+		return fmt.Errorf("unknown VPA conflict policy %q, must be %q or %q", r.VPAConflictPolicy, VPAConflictPolicyWarn, VPAConflictPolicyBlock)
+	}
 	// Start controller
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
@@ -149,6 +201,12 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// don't leave the HPA and the status half updated if the operator is stopped mid-reconcile
+	if r.GracefulShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = util.ContextWithGracefulShutdown(ctx, r.GracefulShutdownTimeout)
+		defer cancel()
+	}
 	reqLogger := log.FromContext(ctx)
 	// Fetch the ScaledObject instance
 	scaledObject := &kedav1alpha1.ScaledObject{}
@@ -282,15 +340,40 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
+	err = kedav1alpha1.CheckPredictiveValid(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct predictive specification", err
+	}
+
+	err = kedav1alpha1.CheckTargetClusterValid(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct triggers for its target cluster", err
+	}
+
+	if err := r.checkVPAConflict(ctx, logger, scaledObject, conditions); err != nil {
+		if blockErr := r.blockScalingForVPAConflict(ctx, logger, scaledObject); blockErr != nil {
+			return "failed to stop scaling ScaledObject conflicting with a VerticalPodAutoscaler", blockErr
+		}
+		return "ScaledObject conflicts with a VerticalPodAutoscaler", err
+	}
+
 	err = r.updateStatusWithTriggersAndAuthsTypes(ctx, logger, scaledObject)
 	if err != nil {
 		return "Cannot update ScaledObject status with triggers'types and authentications'types", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
-	if err != nil {
-		return "failed to ensure HPA is correctly created for ScaledObject", err
+	newHPACreated := false
+	if scaledObject.Spec.TargetCluster != "" {
+		// HPA can't scale targets in a remote cluster, the scale loop scales them directly
+		if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+			return "failed to delete HPA for ScaledObject targeting a remote cluster", err
+		}
+	} else {
+		// Create a new HPA or update existing one according to ScaledObject
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "failed to ensure HPA is correctly created for ScaledObject", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -363,7 +446,20 @@ func (r *ScaledObjectReconciler) checkIfTargetResourceReachPausedCount(ctx conte
 
 // checkTargetResourceIsScalable checks if resource targeted for scaling exists and exposes /scale subresource
 func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (kedav1alpha1.GroupVersionKindResource, error) {
-	gvkr, err := kedav1alpha1.ParseGVKR(r.restMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
+	restMapper, scaleClient := r.restMapper, r.ScaleClient
+	var remoteCluster *k8s.RemoteCluster
+	if scaledObject.Spec.TargetCluster != "" {
+		var err error
+		remoteCluster, err = r.RemoteClusters.Get(ctx, scaledObject.Namespace, scaledObject.Spec.TargetCluster)
+		if err != nil {
+			logger.Error(err, "Failed to create clients for the remote cluster", "clusterConfig", scaledObject.Spec.TargetCluster)
+			r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, err.Error())
+			return kedav1alpha1.GroupVersionKindResource{}, err
+		}
+		restMapper, scaleClient = remoteCluster.RESTMapper, remoteCluster.ScaleClient
+	}
+
+	gvkr, err := kedav1alpha1.ParseGVKR(restMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
 	if err != nil {
 		msg := "Failed to parse Group, Version, Kind, Resource"
 		logger.Error(err, msg, "apiVersion", scaledObject.Spec.ScaleTargetRef.APIVersion, "kind", scaledObject.Spec.ScaleTargetRef.Kind)
@@ -375,7 +471,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 
 	statusGvkString := ""
 	if scaledObject.Status.ScaleTargetGVKR != nil {
-		statusGvkr, _ := kedav1alpha1.ParseGVKR(r.restMapper, scaledObject.Status.ScaleTargetGVKR.Version, scaledObject.Status.ScaleTargetGVKR.Kind)
+		statusGvkr, _ := kedav1alpha1.ParseGVKR(restMapper, scaledObject.Status.ScaleTargetGVKR.Version, scaledObject.Status.ScaleTargetGVKR.Kind)
 		statusGvkString = statusGvkr.GVKString()
 		logger.V(1).Info("Status Group, Version, Kind, Resource", "GVK", statusGvkString, "Resource", statusGvkr.Resource)
 	}
@@ -392,11 +488,26 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	var scale *autoscalingv1.Scale
 	gr := gvkr.GroupResource()
 	_, isScalable := isScalableCache.Load(gr.String())
-	if !isScalable || wantStatusUpdate {
+	if remoteCluster != nil {
+		// the cache is about the local cluster, the remote target and the access to it are always checked
+		scale, err = scaleClient.Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		if err == nil {
+			err = remoteCluster.CheckScaleAccess(ctx, scaledObject.Namespace, gr)
+		}
+		if err != nil {
+			logger.Error(err, "Failed to get the scale target in the remote cluster", "clusterConfig", scaledObject.Spec.TargetCluster, "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+			r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.ScaledObjectCheckFailed, err.Error())
+			return gvkr, err
+		}
+	} else if !isScalable || wantStatusUpdate {
 		// not cached, let's try to detect /scale subresource
 		// also rechecks when we need to update the status.
 		var errScale error
-		scale, errScale = (r.ScaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		if r.hasScaleSubresource(logger, gvkr) {
+			scale, errScale = scaleClient.Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		} else {
+			errScale = fmt.Errorf("%s doesn't advertise /scale subresource", gvkString)
+		}
 		if errScale != nil {
 			// not able to get /scale subresource -> let's check if the resource even exist in the cluster
 			unstruct := &unstructured.Unstructured{}
@@ -441,6 +552,35 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	return gvkr, nil
 }
 
+// hasScaleSubresource checks via the discovery API if the resource targeted for scaling advertises /scale subresource,
+// the positive results are cached per GVK. The negative ones aren't, as the /scale subresource may be added to a CRD
+// later on. When the discovery isn't conclusive, the /scale subresource is assumed to be present and the scale client
+// is left to report the error.
+func (r *ScaledObjectReconciler) hasScaleSubresource(logger logr.Logger, gvkr kedav1alpha1.GroupVersionKindResource) bool {
+	if r.DiscoveryClient == nil {
+		return true
+	}
+
+	gvkString := gvkr.GVKString()
+	if _, ok := scaleSubresourceCache.Load(gvkString); ok {
+		return true
+	}
+
+	resources, err := r.DiscoveryClient.ServerResourcesForGroupVersion(gvkr.GroupVersion().String())
+	if err != nil {
+		logger.V(1).Info("Not able to discover resources for scale target", "GVK", gvkString, "error", err.Error())
+		return true
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvkr.Resource+"/scale" {
+			scaleSubresourceCache.Store(gvkString, true)
+			return true
+		}
+	}
+	return false
+}
+
 // ensureHPAForScaledObjectExists ensures that in cluster exist up-to-date HPA for specified ScaledObject, returns true if a new HPA was created
 func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (bool, error) {
 	hpaName := getHPANameOnEnsure(scaledObject)
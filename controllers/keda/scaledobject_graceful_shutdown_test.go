@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestReconcileOnManagerShutdown(t *testing.T) {
+	const (
+		managerShutdownTimeout   = 2 * time.Second
+		reconcileShutdownTimeout = 500 * time.Millisecond
+	)
+
+	tests := []struct {
+		name              string
+		reconcileDuration time.Duration
+		expectCanceled    bool
+	}{
+		{"in-flight reconcile completes", 200 * time.Millisecond, false},
+		{"reconcile canceled before the manager gives up", 10 * time.Second, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the Get of the ScaledObject blocks the reconcile until it completes or its context is canceled
+			blocked := make(chan struct{})
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, _ client.WithWatch, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+					close(blocked)
+					select {
+					case <-time.After(test.reconcileDuration):
+						return errors.NewNotFound(kedav1alpha1.Resource("scaledobjects"), key.Name)
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				},
+			}).Build()
+			reconciler := &ScaledObjectReconciler{Client: kubeClient, GracefulShutdownTimeout: reconcileShutdownTimeout}
+
+			shutdownTimeout := managerShutdownTimeout
+			mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:0"}, ctrl.Options{
+				Scheme:                  scheme.Scheme,
+				Metrics:                 metricsserver.Options{BindAddress: "0"},
+				HealthProbeBindAddress:  "0",
+				GracefulShutdownTimeout: &shutdownTimeout,
+			})
+			require.NoError(t, err)
+
+			reconciled := make(chan error, 1)
+			require.NoError(t, mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+				_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "name"}})
+				reconciled <- err
+				return nil
+			})))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan error, 1)
+			go func() { stopped <- mgr.Start(ctx) }()
+
+			select {
+			case <-blocked:
+			case <-time.After(5 * time.Second):
+				t.Fatal("reconcile didn't start")
+			}
+			start := time.Now()
+			cancel()
+
+			select {
+			case err = <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("manager didn't stop")
+			}
+			elapsed := time.Since(start)
+			assert.NoError(t, err, "the manager doesn't give up on the reconcile")
+			assert.Less(t, elapsed, managerShutdownTimeout)
+
+			err = <-reconciled
+			if test.expectCanceled {
+				assert.ErrorIs(t, err, context.Canceled)
+				assert.GreaterOrEqual(t, elapsed, reconcileShutdownTimeout)
+			} else {
+				assert.NoError(t, err)
+				assert.Less(t, elapsed, reconcileShutdownTimeout)
+			}
+		})
+	}
+}
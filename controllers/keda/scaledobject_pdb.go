@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+)
+
+// applyPodDisruptionBudgetMinimum raises minReplicas of the HPA to the highest minAvailable of the
+// PodDisruptionBudgets selecting the pods of the scale target, so that the HPA doesn't scale in below it either.
+// minReplicas is never raised above maxReplicas, and a ScaleDownBlockedByPDB event is emitted when it's raised to a
+// value other than the one of the current HPA. A percentage minAvailable is only applied by the scale executor when
+// scaling in, as it would raise minReplicas along with the replicas on every scale out.
+func (r *ScaledObjectReconciler) applyPodDisruptionBudgetMinimum(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource, minReplicas, maxReplicas int32) int32 {
+	// PodDisruptionBudgets of scale targets in remote clusters aren't visible here
+	if scaledObject.Spec.TargetCluster != "" {
+		return minReplicas
+	}
+
+	podLabels, err := executor.GetScaleTargetPodLabels(ctx, r.Client, scaledObject, gvkr)
+	if err != nil {
+		logger.Error(err, "Error getting the pod labels of the scale target, min replicas aren't checked against PodDisruptionBudgets")
+		return minReplicas
+	}
+	minAvailable, pdbName, err := executor.GetPodDisruptionBudgetMinAvailable(ctx, r.Client, logger, scaledObject.Namespace, podLabels, false)
+	if err != nil {
+		logger.Error(err, "Error getting PodDisruptionBudgets, min replicas aren't checked against them")
+		return minReplicas
+	}
+	if minAvailable > maxReplicas {
+		minAvailable = maxReplicas
+	}
+	if minAvailable <= minReplicas {
+		return minReplicas
+	}
+
+	if currentHPA := r.getCurrentHPA(ctx, scaledObject); currentHPA == nil || ptr.Deref(currentHPA.Spec.MinReplicas, 1) != minAvailable {
+		msg := fmt.Sprintf("Min replicas raised from %d to %d by PodDisruptionBudget %s", minReplicas, minAvailable, pdbName)
+		logger.V(1).Info(msg)
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectReadyType, eventreason.ScaleDownBlockedByPDB, msg)
+	}
+	return minAvailable
+}
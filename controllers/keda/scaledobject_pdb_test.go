@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+)
+
+func newPodDisruptionBudget(name, app string, minAvailable int32) *policyv1.PodDisruptionBudget {
+	pdbMinAvailable := intstr.FromInt32(minAvailable)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &pdbMinAvailable,
+			Selector:     &v1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		},
+	}
+}
+
+func TestPodDisruptionBudgetRaisesMinReplicas(t *testing.T) {
+	percentage := intstr.FromString("50%")
+	percentagePDB := newPodDisruptionBudget("pdb-percentage", "worker", 0)
+	percentagePDB.Spec.MinAvailable = &percentage
+	percentagePDB.Status.ExpectedPods = 10
+	setBasedPDB := newPodDisruptionBudget("pdb", "", 4)
+	setBasedPDB.Spec.Selector = &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{
+		{Key: "app", Operator: v1.LabelSelectorOpIn, Values: []string{"worker", "web"}},
+		{Key: "canary", Operator: v1.LabelSelectorOpDoesNotExist},
+	}}
+
+	tests := []struct {
+		name                string
+		pdb                 *policyv1.PodDisruptionBudget
+		targetCluster       string
+		currentMinReplicas  *int32
+		expectedMinReplicas int32
+		expectEvent         bool
+	}{
+		{name: "min available above min replicas", pdb: newPodDisruptionBudget("pdb", "worker", 3), expectedMinReplicas: 3, expectEvent: true},
+		{name: "min available below min replicas", pdb: newPodDisruptionBudget("pdb", "worker", 1), expectedMinReplicas: 2},
+		{name: "min available above max replicas", pdb: newPodDisruptionBudget("pdb", "worker", 20), expectedMinReplicas: 10, expectEvent: true},
+		{name: "pods not selected", pdb: newPodDisruptionBudget("pdb", "other", 3), expectedMinReplicas: 2},
+		{name: "set-based selector", pdb: setBasedPDB, expectedMinReplicas: 4, expectEvent: true},
+		{name: "percentage min available", pdb: percentagePDB, expectedMinReplicas: 2},
+		{name: "min replicas of the current HPA unchanged", pdb: newPodDisruptionBudget("pdb", "worker", 3), currentMinReplicas: ptr.To[int32](3), expectedMinReplicas: 3},
+		{name: "min replicas of the current HPA changed", pdb: newPodDisruptionBudget("pdb", "worker", 3), currentMinReplicas: ptr.To[int32](2), expectedMinReplicas: 3, expectEvent: true},
+		{name: "remote scale target", pdb: newPodDisruptionBudget("pdb", "worker", 3), targetCluster: "remote", expectedMinReplicas: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			eventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+
+			objects := []client.Object{
+				test.pdb,
+				&appsv1.Deployment{
+					ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default"},
+					Spec: appsv1.DeploymentSpec{
+						Selector: &v1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+						Template: corev1.PodTemplateSpec{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "worker", "tier": "backend"}}},
+					},
+				},
+			}
+			if test.currentMinReplicas != nil {
+				objects = append(objects, &autoscalingv2.HorizontalPodAutoscaler{
+					ObjectMeta: v1.ObjectMeta{Name: "keda-hpa-app", Namespace: "default"},
+					Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: test.currentMinReplicas, MaxReplicas: 10},
+				})
+			}
+			reconciler := &ScaledObjectReconciler{
+				Client:       fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build(),
+				EventEmitter: eventEmitter,
+			}
+
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &v1alpha1.ScaleTarget{Name: "app"},
+					TargetCluster:  test.targetCluster,
+				},
+			}
+			gvkr := &v1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+
+			if test.expectEvent {
+				eventEmitter.EXPECT().Emit(scaledObject, "default", corev1.EventTypeNormal, gomock.Any(), eventreason.ScaleDownBlockedByPDB, gomock.Any())
+			}
+			minReplicas := reconciler.applyPodDisruptionBudgetMinimum(context.Background(), logr.Discard(), scaledObject, gvkr, 2, 10)
+			assert.Equal(t, test.expectedMinReplicas, minReplicas)
+		})
+	}
+}
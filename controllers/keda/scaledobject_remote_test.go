@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_remotecluster"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
+)
+
+func TestReconcileScaledObjectWithTargetClusterDeletesHPA(t *testing.T) {
+	remote := mock_remotecluster.NewServer("default", map[string]int32{"app": 2})
+	defer remote.Close()
+
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{Name: "app"},
+			TargetCluster:  "remote",
+			Triggers:       []v1alpha1.ScaleTriggers{{Type: "prometheus", Metadata: map[string]string{"query": "up", "threshold": "1"}}},
+		},
+	}
+	// the HPA was created while the ScaleTarget was living in the local cluster
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{Name: getHPAName(scaledObject), Namespace: "default"},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.ScaledObject{}).
+		WithObjects(
+			scaledObject,
+			hpa,
+			&v1alpha1.ClusterConfig{
+				ObjectMeta: v1.ObjectMeta{Name: "remote", Namespace: "default"},
+				Spec:       v1alpha1.ClusterConfigSpec{KubeconfigSecretRef: v1alpha1.KubeconfigSecretRef{Name: "kubeconfig"}},
+			},
+			&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "kubeconfig", Namespace: "default"},
+				Data:       map[string][]byte{v1alpha1.DefaultClusterConfigKubeconfigKey: remote.Kubeconfig()},
+			},
+		).Build()
+
+	ctrl := gomock.NewController(t)
+	scaleHandler := mock_scaling.NewMockScaleHandler(ctrl)
+	scaleHandler.EXPECT().HandleScalableObject(gomock.Any(), gomock.Any())
+	reconciler := &ScaledObjectReconciler{
+		Client:                   client,
+		ScaleHandler:             scaleHandler,
+		EventEmitter:             mock_eventemitter.NewMockEventHandler(ctrl),
+		RemoteClusters:           k8s.NewRemoteClusters(client),
+		DynamicClient:            dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{vpaGVR: "VerticalPodAutoscalerList"}),
+		scaledObjectsGenerations: &sync.Map{},
+	}
+
+	conditions := v1alpha1.GetInitializedConditions()
+	msg, err := reconciler.reconcileScaledObject(context.Background(), logr.Discard(), scaledObject, conditions)
+	assert.NoError(t, err)
+	assert.Equal(t, v1alpha1.ScaledObjectConditionReadySuccessMessage, msg)
+
+	// the ScaleTarget in the remote cluster is found through the kubeconfig of the ClusterConfig
+	assert.Equal(t, "apps/v1.Deployment", scaledObject.Status.ScaleTargetKind)
+	assert.Equal(t, int32(2), *scaledObject.Status.OriginalReplicaCount)
+
+	err = client.Get(context.Background(), types.NamespacedName{Name: hpa.Name, Namespace: "default"}, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, errors.IsNotFound(err), "the HPA can't scale a ScaleTarget in a remote cluster")
+}
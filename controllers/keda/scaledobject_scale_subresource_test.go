@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+)
+
+var customScaleTargetGV = schema.GroupVersion{Group: "example.com", Version: "v1"}
+
+// newCustomScaleTargetReconciler returns a reconciler aware of the custom kinds "Worker", which advertises
+// /scale subresource and "Task", which doesn't
+func newCustomScaleTargetReconciler(ctrl *gomock.Controller) (*ScaledObjectReconciler, *mock_client.MockClient, *mock_scale.MockScalesGetter, *mock_eventemitter.MockEventHandler) {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{customScaleTargetGV})
+	restMapper.Add(customScaleTargetGV.WithKind("Worker"), meta.RESTScopeNamespace)
+	restMapper.Add(customScaleTargetGV.WithKind("Task"), meta.RESTScopeNamespace)
+
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &kubetesting.Fake{}}
+	discoveryClient.Resources = []*v1.APIResourceList{
+		{
+			GroupVersion: customScaleTargetGV.String(),
+			APIResources: []v1.APIResource{
+				{Name: "workers", Kind: "Worker", Namespaced: true},
+				{Name: "workers/scale", Kind: "Scale", Group: "autoscaling", Version: "v1", Namespaced: true},
+				{Name: "tasks", Kind: "Task", Namespaced: true},
+			},
+		},
+	}
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	eventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+	return &ScaledObjectReconciler{
+		Client:          client,
+		ScaleClient:     scaleClient,
+		EventEmitter:    eventEmitter,
+		DiscoveryClient: discoveryClient,
+		restMapper:      restMapper,
+	}, client, scaleClient, eventEmitter
+}
+
+func newCustomScaleTargetScaledObject(kind string) *v1alpha1.ScaledObject {
+	return &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				APIVersion: customScaleTargetGV.String(),
+				Kind:       kind,
+				Name:       "target",
+			},
+		},
+	}
+}
+
+func TestCustomResourceWithScaleSubresourceIsScaled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reconciler, client, scaleClient, _ := newCustomScaleTargetReconciler(ctrl)
+	scaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaledObject := newCustomScaleTargetScaledObject("Worker")
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, "ScaledObjectReady", "")
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: 0,
+		},
+	}
+	workersResource := schema.GroupResource{Group: "example.com", Resource: "workers"}
+
+	scaleClient.EXPECT().Scales("namespace").Return(scaleInterface).AnyTimes()
+	scaleInterface.EXPECT().Get(gomock.Any(), workersResource, "target", gomock.Any()).Return(scale, nil).AnyTimes()
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	gvkr, err := reconciler.checkTargetResourceIsScalable(context.TODO(), logr.Discard(), scaledObject)
+	assert.NoError(t, err)
+	assert.Equal(t, "workers", gvkr.Resource)
+	assert.Equal(t, "example.com/v1.Worker", scaledObject.Status.ScaleTargetKind)
+	assert.Equal(t, int32(0), *scaledObject.Status.OriginalReplicaCount)
+
+	// the executor sets the replica count through the /scale subresource of the custom resource
+	scaleInterface.EXPECT().Update(gomock.Any(), workersResource, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ schema.GroupResource, updated *autoscalingv1.Scale, _ v1.UpdateOptions) (*autoscalingv1.Scale, error) {
+			assert.Equal(t, int32(1), updated.Spec.Replicas)
+			return updated, nil
+		})
+	scaleExecutor := executor.NewScaleExecutor(client, scaleClient, nil, record.NewFakeRecorder(1))
+	scaleExecutor.RequestScale(context.TODO(), scaledObject, true, false, &executor.ScaleExecutorOptions{})
+
+	assert.Equal(t, int32(1), scale.Spec.Replicas)
+}
+
+func TestCustomResourceWithoutScaleSubresourceIsRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	reconciler, client, _, eventEmitter := newCustomScaleTargetReconciler(ctrl)
+
+	scaledObject := newCustomScaleTargetScaledObject("Task")
+
+	// the resource exists, but the scale client mustn't be used as /scale isn't advertised
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	eventEmitter.EXPECT().Emit(gomock.Any(), "namespace", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+	_, err := reconciler.checkTargetResourceIsScalable(context.TODO(), logr.Discard(), scaledObject)
+	assert.ErrorContains(t, err, "doesn't advertise /scale subresource")
+
+	_, ok := scaleSubresourceCache.Load("example.com/v1.Task")
+	assert.False(t, ok, "the /scale subresource may be added to the CRD later on")
+
+	// the /scale subresource is detected once it's added to the CRD
+	discoveryClient := reconciler.DiscoveryClient.(*fakediscovery.FakeDiscovery)
+	discoveryClient.Resources[0].APIResources = append(discoveryClient.Resources[0].APIResources,
+		v1.APIResource{Name: "tasks/scale", Kind: "Scale", Group: "autoscaling", Version: "v1", Namespaced: true})
+	gvkr := v1alpha1.GroupVersionKindResource{Group: customScaleTargetGV.Group, Version: customScaleTargetGV.Version, Kind: "Task", Resource: "tasks"}
+	assert.True(t, reconciler.hasScaleSubresource(logr.Discard(), gvkr))
+	_, ok = scaleSubresourceCache.Load("example.com/v1.Task")
+	assert.True(t, ok)
+}
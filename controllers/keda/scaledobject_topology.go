@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// topologyDomainsCacheTTL is how long the number of topology domains is cached for
+const topologyDomainsCacheTTL = time.Minute
+
+type topologyDomainsCacheEntry struct {
+	domains int32
+	expires time.Time
+}
+
+// topologyDomainsCache maps a topology key to the number of topology domains of the schedulable nodes
+var topologyDomainsCache = &sync.Map{}
+
+// applyTopologySpreadCap caps maxReplicas to the number of topology domains times maxSkew of each
+// topologySpreadConstraint of the scale target with whenUnsatisfiable DoNotSchedule, if the ScaledObject respects
+// topology spread, the ScheduleAnyway constraints never blocking the scheduling of the pods. The cap never goes below
+// minReplicas and a TopologyCapApplied event is emitted when it changes the maxReplicas of the current HPA.
+func (r *ScaledObjectReconciler) applyTopologySpreadCap(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, minReplicas, maxReplicas int32) int32 {
+	if !scaledObject.Spec.RespectTopologySpread {
+		return maxReplicas
+	}
+
+	constraints, err := r.getScaleTargetTopologySpreadConstraints(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error getting topologySpreadConstraints of the scale target, max replicas aren't capped")
+		return maxReplicas
+	}
+
+	replicasCap := maxReplicas
+	for _, constraint := range constraints {
+		if constraint.WhenUnsatisfiable == corev1.ScheduleAnyway {
+			continue
+		}
+		domains, err := r.getTopologyDomains(ctx, constraint.TopologyKey)
+		if err != nil {
+			logger.Error(err, "Error counting topology domains, max replicas aren't capped", "topologyKey", constraint.TopologyKey)
+			return maxReplicas
+		}
+		if domains == 0 {
+			// none of the nodes has the topology key, there is nothing to spread over
+			continue
+		}
+		if constraintCap := domains * constraint.MaxSkew; constraintCap < replicasCap {
+			replicasCap = constraintCap
+		}
+	}
+	if replicasCap < minReplicas {
+		replicasCap = minReplicas
+	}
+
+	if currentHPA := r.getCurrentHPA(ctx, scaledObject); replicasCap < maxReplicas && (currentHPA == nil || currentHPA.Spec.MaxReplicas != replicasCap) {
+		msg := fmt.Sprintf("Max replicas capped from %d to %d by the topologySpreadConstraints of the scale target", maxReplicas, replicasCap)
+		logger.V(1).Info(msg)
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectReadyType, eventreason.TopologyCapApplied, msg)
+	}
+	return replicasCap
+}
+
+// getScaleTargetTopologySpreadConstraints returns the topologySpreadConstraints of the pod template of the
+// scale target, only Deployments and StatefulSets are supported
+func (r *ScaledObjectReconciler) getScaleTargetTopologySpreadConstraints(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) ([]corev1.TopologySpreadConstraint, error) {
+	key := types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}
+	switch scaledObject.Status.ScaleTargetKind {
+	case "apps/v1.Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, key, deployment); err != nil {
+			return nil, err
+		}
+		return deployment.Spec.Template.Spec.TopologySpreadConstraints, nil
+	case "apps/v1.StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := r.Client.Get(ctx, key, statefulSet); err != nil {
+			return nil, err
+		}
+		return statefulSet.Spec.Template.Spec.TopologySpreadConstraints, nil
+	default:
+		return nil, nil
+	}
+}
+
+// getTopologyDomains returns the number of distinct values of the topology key on the schedulable nodes
+func (r *ScaledObjectReconciler) getTopologyDomains(ctx context.Context, topologyKey string) (int32, error) {
+	if value, ok := topologyDomainsCache.Load(topologyKey); ok {
+		entry := value.(topologyDomainsCacheEntry)
+		if time.Now().Before(entry.expires) {
+			return entry.domains, nil
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.Client.List(ctx, nodes); err != nil {
+		return 0, err
+	}
+	domains := map[string]bool{}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		if domain, ok := node.Labels[topologyKey]; ok {
+			domains[domain] = true
+		}
+	}
+
+	count := int32(len(domains))
+	topologyDomainsCache.Store(topologyKey, topologyDomainsCacheEntry{domains: count, expires: time.Now().Add(topologyDomainsCacheTTL)})
+	return count, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+)
+
+const zoneTopologyKey = "topology.kubernetes.io/zone"
+
+func newZoneNode(name, zone string, unschedulable bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{zoneTopologyKey: zone}},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+	}
+}
+
+func newTopologySpreadDeployment(maxSkew int32, whenUnsatisfiable corev1.UnsatisfiableConstraintAction) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+						MaxSkew:           maxSkew,
+						TopologyKey:       zoneTopologyKey,
+						WhenUnsatisfiable: whenUnsatisfiable,
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestTopologySpreadCapsMaxReplicas(t *testing.T) {
+	tests := []struct {
+		name                  string
+		respectTopologySpread bool
+		maxSkew               int32
+		whenUnsatisfiable     corev1.UnsatisfiableConstraintAction
+		minReplicas           int32
+		currentMaxReplicas    int32
+		expectedMaxReplicas   int32
+		expectEvent           bool
+	}{
+		{name: "three zones with maxSkew 1", respectTopologySpread: true, maxSkew: 1, minReplicas: 1, expectedMaxReplicas: 3, expectEvent: true},
+		{name: "three zones with maxSkew 2", respectTopologySpread: true, maxSkew: 2, minReplicas: 1, expectedMaxReplicas: 6, expectEvent: true},
+		{name: "cap doesn't go below min replicas", respectTopologySpread: true, maxSkew: 1, minReplicas: 4, expectedMaxReplicas: 4, expectEvent: true},
+		{name: "cap above max replicas", respectTopologySpread: true, maxSkew: 5, minReplicas: 1, expectedMaxReplicas: 10},
+		{name: "topology spread not respected", respectTopologySpread: false, maxSkew: 1, minReplicas: 1, expectedMaxReplicas: 10},
+		{name: "schedule anyway constraint", respectTopologySpread: true, maxSkew: 1, whenUnsatisfiable: corev1.ScheduleAnyway, minReplicas: 1, expectedMaxReplicas: 10},
+		{name: "max replicas of the current HPA unchanged", respectTopologySpread: true, maxSkew: 1, minReplicas: 1, currentMaxReplicas: 3, expectedMaxReplicas: 3},
+		{name: "max replicas of the current HPA changed", respectTopologySpread: true, maxSkew: 1, minReplicas: 1, currentMaxReplicas: 10, expectedMaxReplicas: 3, expectEvent: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			topologyDomainsCache = &sync.Map{}
+			ctrl := gomock.NewController(t)
+			eventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+
+			// the unschedulable node is in a fourth zone, which pods can't be spread to
+			whenUnsatisfiable := test.whenUnsatisfiable
+			if whenUnsatisfiable == "" {
+				whenUnsatisfiable = corev1.DoNotSchedule
+			}
+			objects := []client.Object{
+				newZoneNode("node-a1", "zone-a", false),
+				newZoneNode("node-a2", "zone-a", false),
+				newZoneNode("node-b1", "zone-b", false),
+				newZoneNode("node-c1", "zone-c", false),
+				newZoneNode("node-d1", "zone-d", true),
+				newTopologySpreadDeployment(test.maxSkew, whenUnsatisfiable),
+			}
+			if test.currentMaxReplicas != 0 {
+				objects = append(objects, &autoscalingv2.HorizontalPodAutoscaler{
+					ObjectMeta: v1.ObjectMeta{Name: "keda-hpa-app", Namespace: "default"},
+					Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: test.currentMaxReplicas},
+				})
+			}
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+			reconciler := &ScaledObjectReconciler{Client: kubeClient, EventEmitter: eventEmitter}
+
+			scaledObject := &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: v1alpha1.ScaledObjectSpec{
+					ScaleTargetRef:        &v1alpha1.ScaleTarget{Name: "app"},
+					RespectTopologySpread: test.respectTopologySpread,
+				},
+				Status: v1alpha1.ScaledObjectStatus{ScaleTargetKind: "apps/v1.Deployment"},
+			}
+
+			if test.expectEvent {
+				eventEmitter.EXPECT().Emit(scaledObject, "default", corev1.EventTypeNormal, gomock.Any(), eventreason.TopologyCapApplied, gomock.Any())
+			}
+			maxReplicas := reconciler.applyTopologySpreadCap(context.Background(), logr.Discard(), scaledObject, test.minReplicas, 10)
+			assert.Equal(t, test.expectedMaxReplicas, maxReplicas)
+		})
+	}
+}
+
+func TestTopologyDomainsAreCached(t *testing.T) {
+	topologyDomainsCache = &sync.Map{}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newZoneNode("node-a1", "zone-a", false),
+		newZoneNode("node-b1", "zone-b", false),
+	).Build()
+	reconciler := &ScaledObjectReconciler{Client: client}
+
+	domains, err := reconciler.getTopologyDomains(context.Background(), zoneTopologyKey)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), domains)
+
+	// a new zone isn't seen until the cache entry expires
+	assert.NoError(t, client.Create(context.Background(), newZoneNode("node-c1", "zone-c", false)))
+	domains, err = reconciler.getTopologyDomains(context.Background(), zoneTopologyKey)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), domains)
+}
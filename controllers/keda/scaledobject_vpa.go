@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+const (
+	// VPAConflictPolicyWarn only reports a VerticalPodAutoscaler conflicting with the ScaledObject
+	VPAConflictPolicyWarn = "warn"
+	// VPAConflictPolicyBlock reports a VerticalPodAutoscaler conflicting with the ScaledObject, sets it as not ready
+	// and stops scaling its scale target until the conflict is resolved
+	VPAConflictPolicyBlock = "block"
+)
+
+var vpaGVR = schema.GroupVersionResource{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}
+
+// checkVPAConflict looks for a VerticalPodAutoscaler that evicts pods of the scale target of the ScaledObject,
+// as VPA evicting pods while KEDA is scaling leads to conflicts. A conflict is reported with the VPAConflict condition
+// and a VPAConflictDetected event when the condition becomes true, an error is returned only if the conflict policy
+// is block.
+func (r *ScaledObjectReconciler) checkVPAConflict(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, conditions *kedav1alpha1.Conditions) error {
+	vpaName, err := r.findConflictingVPA(ctx, scaledObject)
+	if err != nil {
+		// the conflict check is best effort, it doesn't prevent the ScaledObject from scaling
+		logger.Error(err, "Error checking for VerticalPodAutoscaler conflicting with ScaledObject")
+		return nil
+	}
+
+	if vpaName == "" {
+		if conflict := conditions.GetVPAConflictCondition(); conflict.IsTrue() {
+			conditions.SetVPAConflictCondition(metav1.ConditionFalse, "NoVPAConflict", "No VerticalPodAutoscaler evicting pods targets the scale target")
+		}
+		return nil
+	}
+
+	msg := fmt.Sprintf("VerticalPodAutoscaler %s evicts pods of the scale target %s, which conflicts with the scaling of ScaledObject", vpaName, scaledObject.Spec.ScaleTargetRef.Name)
+	if conflict := conditions.GetVPAConflictCondition(); !conflict.IsTrue() {
+		logger.Info(msg)
+		r.EventEmitter.Emit(scaledObject, scaledObject.Namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFailedType, eventreason.VPAConflictDetected, msg)
+	}
+	conditions.SetVPAConflictCondition(metav1.ConditionTrue, eventreason.VPAConflictDetected, msg)
+
+	if r.VPAConflictPolicy == VPAConflictPolicyBlock {
+		return fmt.Errorf("%s", msg)
+	}
+	return nil
+}
+
+// blockScalingForVPAConflict stops the scale loop and deletes the HPA of the ScaledObject, so that its scale target
+// isn't scaled while it conflicts with a VerticalPodAutoscaler. The scale loop is started again and the HPA created
+// again once the conflict is resolved, as for a paused ScaledObject.
+func (r *ScaledObjectReconciler) blockScalingForVPAConflict(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := r.stopScaleLoop(ctx, logger, scaledObject); err != nil {
+		return fmt.Errorf("failed to stop the scale loop: %w", err)
+	}
+	if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+		return fmt.Errorf("failed to delete the HPA: %w", err)
+	}
+	return nil
+}
+
+// findConflictingVPA returns the name of a VerticalPodAutoscaler targeting the scale target of the ScaledObject
+// with updateMode Auto or Recreate, empty if there is none or VPA isn't installed in the cluster
+func (r *ScaledObjectReconciler) findConflictingVPA(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (string, error) {
+	vpas, err := r.DynamicClient.Resource(vpaGVR).Namespace(scaledObject.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error listing VerticalPodAutoscalers: %w", err)
+	}
+
+	targetKind := scaledObject.Spec.ScaleTargetRef.Kind
+	if targetKind == "" {
+		targetKind = "Deployment"
+	}
+	for _, vpa := range vpas.Items {
+		kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if kind != targetKind || name != scaledObject.Spec.ScaleTargetRef.Name {
+			continue
+		}
+		updateMode, found, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		// VPA defaults to Auto when updateMode isn't set
+		if !found || updateMode == "" || updateMode == "Auto" || updateMode == "Recreate" {
+			return vpa.GetName(), nil
+		}
+	}
+	return "", nil
+}
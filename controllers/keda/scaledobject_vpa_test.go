@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_eventemitter"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
+)
+
+func newFakeVPA(name, targetKind, targetName, updateMode string) *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "autoscaling.k8s.io/v1",
+		"kind":       "VerticalPodAutoscaler",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"spec": map[string]interface{}{
+			"targetRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": targetKind, "name": targetName},
+		},
+	}}
+	if updateMode != "" {
+		_ = unstructured.SetNestedField(vpa.Object, updateMode, "spec", "updatePolicy", "updateMode")
+	}
+	return vpa
+}
+
+func TestCheckVPAConflict(t *testing.T) {
+	tests := []struct {
+		name             string
+		vpa              *unstructured.Unstructured
+		policy           string
+		expectedConflict bool
+		expectedError    bool
+	}{
+		{name: "no vpa", policy: VPAConflictPolicyWarn},
+		{name: "vpa in Auto mode", vpa: newFakeVPA("vpa", "Deployment", "app", "Auto"), policy: VPAConflictPolicyWarn, expectedConflict: true},
+		{name: "vpa in Recreate mode", vpa: newFakeVPA("vpa", "Deployment", "app", "Recreate"), policy: VPAConflictPolicyWarn, expectedConflict: true},
+		{name: "vpa without update mode", vpa: newFakeVPA("vpa", "Deployment", "app", ""), policy: VPAConflictPolicyWarn, expectedConflict: true},
+		{name: "vpa in Off mode", vpa: newFakeVPA("vpa", "Deployment", "app", "Off"), policy: VPAConflictPolicyWarn},
+		{name: "vpa targeting another deployment", vpa: newFakeVPA("vpa", "Deployment", "other", "Auto"), policy: VPAConflictPolicyWarn},
+		{name: "vpa targeting a statefulset with the same name", vpa: newFakeVPA("vpa", "StatefulSet", "app", "Auto"), policy: VPAConflictPolicyWarn},
+		{name: "vpa in Auto mode with block policy", vpa: newFakeVPA("vpa", "Deployment", "app", "Auto"), policy: VPAConflictPolicyBlock, expectedConflict: true, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			eventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+
+			objects := []runtime.Object{}
+			if test.vpa != nil {
+				objects = append(objects, test.vpa)
+			}
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{vpaGVR: "VerticalPodAutoscalerList"}, objects...)
+			reconciler := &ScaledObjectReconciler{EventEmitter: eventEmitter, DynamicClient: dynamicClient, VPAConflictPolicy: test.policy}
+
+			scaledObject := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"}},
+			}
+			conditions := kedav1alpha1.GetInitializedConditions()
+
+			if test.expectedConflict {
+				eventEmitter.EXPECT().Emit(scaledObject, "default", corev1.EventTypeWarning, gomock.Any(), eventreason.VPAConflictDetected, gomock.Any())
+			}
+			err := reconciler.checkVPAConflict(context.Background(), logr.Discard(), scaledObject, conditions)
+			assert.Equal(t, test.expectedError, err != nil)
+
+			conflict := conditions.GetVPAConflictCondition()
+			if test.expectedConflict {
+				assert.True(t, conflict.IsTrue())
+			} else {
+				assert.Empty(t, conflict.Type)
+			}
+		})
+	}
+}
+
+func TestCheckVPAConflictIsCleared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{vpaGVR: "VerticalPodAutoscalerList"})
+	reconciler := &ScaledObjectReconciler{EventEmitter: mock_eventemitter.NewMockEventHandler(ctrl), DynamicClient: dynamicClient}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"}},
+	}
+	conditions := kedav1alpha1.GetInitializedConditions()
+	conditions.SetVPAConflictCondition(metav1.ConditionTrue, eventreason.VPAConflictDetected, "conflict")
+
+	err := reconciler.checkVPAConflict(context.Background(), logr.Discard(), scaledObject, conditions)
+	assert.NoError(t, err)
+	conflict := conditions.GetVPAConflictCondition()
+	assert.True(t, conflict.IsFalse())
+}
+
+func TestCheckVPAConflictEmitsEventOnlyWhenDetected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	eventEmitter := mock_eventemitter.NewMockEventHandler(ctrl)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{vpaGVR: "VerticalPodAutoscalerList"}, newFakeVPA("vpa", "Deployment", "app", "Auto"))
+	reconciler := &ScaledObjectReconciler{EventEmitter: eventEmitter, DynamicClient: dynamicClient, VPAConflictPolicy: VPAConflictPolicyBlock}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"}},
+	}
+	conditions := kedav1alpha1.GetInitializedConditions()
+
+	// the requeues of the blocked ScaledObject don't emit the event again
+	eventEmitter.EXPECT().Emit(scaledObject, "default", corev1.EventTypeWarning, gomock.Any(), eventreason.VPAConflictDetected, gomock.Any()).Times(1)
+	for i := 0; i < 3; i++ {
+		err := reconciler.checkVPAConflict(context.Background(), logr.Discard(), scaledObject, conditions)
+		assert.Error(t, err)
+	}
+}
+
+func TestBlockScalingForVPAConflict(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"}},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: getHPAName(scaledObject), Namespace: "default"},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(hpa).Build()
+
+	ctrl := gomock.NewController(t)
+	scaleHandler := mock_scaling.NewMockScaleHandler(ctrl)
+	scaleHandler.EXPECT().DeleteScalableObject(gomock.Any(), scaledObject)
+	reconciler := &ScaledObjectReconciler{Client: kubeClient, ScaleHandler: scaleHandler, scaledObjectsGenerations: &sync.Map{}}
+	reconciler.scaledObjectsGenerations.Store("default/app", int64(2))
+
+	err := reconciler.blockScalingForVPAConflict(context.Background(), logr.Discard(), scaledObject)
+	assert.NoError(t, err)
+
+	err = kubeClient.Get(context.Background(), types.NamespacedName{Name: hpa.Name, Namespace: "default"}, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, errors.IsNotFound(err), "the HPA keeps scaling the scale target")
+
+	// the scale loop is started again once the conflict is resolved
+	changed, err := reconciler.scaledObjectGenerationChanged(logr.Discard(), scaledObject)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
@@ -68,6 +68,21 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetScaled is for event when the scale target in a remote cluster for ScaledObject was scaled
+	KEDAScaleTargetScaled = "KEDAScaleTargetScaled"
+
+	// ScaleDownBlockedByPDB is for event when the scale in of the scale target for ScaledObject is limited by a PodDisruptionBudget
+	ScaleDownBlockedByPDB = "ScaleDownBlockedByPDB"
+
+	// ScaleUpBlockedByDependency is for event when the scale up of the scale target for ScaledObject waits for the ScaledObjects it depends on
+	ScaleUpBlockedByDependency = "ScaleUpBlockedByDependency"
+
+	// TopologyCapApplied is for event when the max replicas of ScaledObject are capped by the topologySpreadConstraints of the scale target
+	TopologyCapApplied = "TopologyCapApplied"
+
+	// VPAConflictDetected is for event when a VerticalPodAutoscaler evicting pods targets the same resource as ScaledObject
+	VPAConflictDetected = "VPAConflictDetected"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// scaleAccessVerbs are the verbs KEDA needs on /scale subresource to scale the targets
+var scaleAccessVerbs = []string{"get", "update", "patch"}
+
+// RemoteCluster holds the clients used to scale the targets living in a remote cluster
+type RemoteCluster struct {
+	RESTMapper  meta.RESTMapper
+	ScaleClient scale.ScalesGetter
+
+	authorizationClient authorizationv1client.AuthorizationV1Interface
+	resourceVersions    string
+}
+
+// NewRemoteCluster creates the clients for the cluster reachable with the config
+func NewRemoteCluster(restConfig *rest.Config) (*RemoteCluster, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating discovery client: %w", err)
+	}
+	authorizationClient, err := authorizationv1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating authorization client: %w", err)
+	}
+
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	return &RemoteCluster{
+		RESTMapper: restMapper,
+		ScaleClient: scale.New(
+			discoveryClient.RESTClient(), restMapper,
+			dynamic.LegacyAPIPathResolverFunc,
+			scale.NewDiscoveryScaleKindResolver(discoveryClient),
+		),
+		authorizationClient: authorizationClient,
+	}, nil
+}
+
+// CheckScaleAccess verifies that the credentials of the remote cluster allow to scale the resource in the namespace
+func (c *RemoteCluster) CheckScaleAccess(ctx context.Context, namespace string, gr schema.GroupResource) error {
+	for _, verb := range scaleAccessVerbs {
+		review, err := c.authorizationClient.SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   namespace,
+					Verb:        verb,
+					Group:       gr.Group,
+					Resource:    gr.Resource,
+					Subresource: "scale",
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("error reviewing access to %s/scale in the remote cluster: %w", gr.String(), err)
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("kubeconfig doesn't grant %s access to %s/scale in namespace %s of the remote cluster", verb, gr.String(), namespace)
+		}
+	}
+	return nil
+}
+
+// RemoteClusters creates the clients of the remote clusters referenced by ClusterConfigs and caches them,
+// the clients are recreated when the ClusterConfig or its kubeconfig secret change
+type RemoteClusters struct {
+	client   client.Client
+	mutex    sync.Mutex
+	clusters map[types.NamespacedName]*RemoteCluster
+}
+
+// NewRemoteClusters creates RemoteClusters reading ClusterConfigs and secrets with the client
+func NewRemoteClusters(kubeClient client.Client) *RemoteClusters {
+	return &RemoteClusters{
+		client:   kubeClient,
+		clusters: map[types.NamespacedName]*RemoteCluster{},
+	}
+}
+
+// Get returns the clients of the cluster referenced by the ClusterConfig in the namespace
+func (r *RemoteClusters) Get(ctx context.Context, namespace, clusterConfigName string) (*RemoteCluster, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: clusterConfigName}
+	clusterConfig := &kedav1alpha1.ClusterConfig{}
+	if err := r.client.Get(ctx, key, clusterConfig); err != nil {
+		return nil, fmt.Errorf("error getting ClusterConfig %s: %w", key.String(), err)
+	}
+
+	secretRef := clusterConfig.Spec.KubeconfigSecretRef
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretRef.Name}, secret); err != nil {
+		return nil, fmt.Errorf("error getting kubeconfig secret %s of ClusterConfig %s: %w", secretRef.Name, key.String(), err)
+	}
+	kubeconfig := secret.Data[secretRef.GetKey()]
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("key %s of kubeconfig secret %s of ClusterConfig %s is empty", secretRef.GetKey(), secretRef.Name, key.String())
+	}
+
+	resourceVersions := clusterConfig.ResourceVersion + "/" + secret.ResourceVersion
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if cluster, ok := r.clusters[key]; ok && cluster.resourceVersions == resourceVersions {
+		return cluster, nil
+	}
+
+	restConfig, err := restConfigFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubeconfig of ClusterConfig %s: %w", key.String(), err)
+	}
+	cluster, err := NewRemoteCluster(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	cluster.resourceVersions = resourceVersions
+	r.clusters[key] = cluster
+	return cluster, nil
+}
+
+// restConfigFromKubeconfig builds the config of the current context of the kubeconfig from its server, CA,
+// token and client certificate only. The kubeconfig comes from a secret of a user namespace, so the ways of
+// authenticating that run commands or read files of keda-operator are rejected.
+func restConfigFromKubeconfig(kubeconfig []byte) (*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("current context %q not found", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q not found", kubeContext.Cluster)
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, fmt.Errorf("user %q not found", kubeContext.AuthInfo)
+	}
+
+	switch {
+	case authInfo.Exec != nil:
+		return nil, fmt.Errorf("exec of user %q isn't supported", kubeContext.AuthInfo)
+	case authInfo.AuthProvider != nil:
+		return nil, fmt.Errorf("auth-provider of user %q isn't supported", kubeContext.AuthInfo)
+	case authInfo.TokenFile != "", authInfo.ClientCertificate != "", authInfo.ClientKey != "":
+		return nil, fmt.Errorf("files of user %q aren't supported, their data has to be inlined", kubeContext.AuthInfo)
+	case cluster.CertificateAuthority != "":
+		return nil, fmt.Errorf("certificate-authority file of cluster %q isn't supported, its data has to be inlined", kubeContext.Cluster)
+	case cluster.Server == "":
+		return nil, fmt.Errorf("server of cluster %q is empty", kubeContext.Cluster)
+	}
+
+	return &rest.Config{
+		Host:        cluster.Server,
+		BearerToken: authInfo.Token,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure:   cluster.InsecureSkipTLSVerify,
+			ServerName: cluster.TLSServerName,
+			CAData:     cluster.CertificateAuthorityData,
+			CertData:   authInfo.ClientCertificateData,
+			KeyData:    authInfo.ClientKeyData,
+		},
+	}, nil
+}
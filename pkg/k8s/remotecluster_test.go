@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const tokenKubeconfig = `apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+    tls-server-name: remote.internal
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: keda
+users:
+- name: keda
+  user:
+    token: secret-token
+`
+
+const execKubeconfig = `apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: keda
+users:
+- name: keda
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: /bin/sh
+      args: ["-c", "id"]
+`
+
+const authProviderKubeconfig = `apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: keda
+users:
+- name: keda
+  user:
+    auth-provider:
+      name: oidc
+`
+
+const tokenFileKubeconfig = `apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: keda
+users:
+- name: keda
+  user:
+    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token
+`
+
+func newRemoteClustersClient(t *testing.T, kubeconfig string) client.Client {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kedav1alpha1.ClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "default"},
+			Spec:       kedav1alpha1.ClusterConfigSpec{KubeconfigSecretRef: kedav1alpha1.KubeconfigSecretRef{Name: "kubeconfig"}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeconfig", Namespace: "default"},
+			Data:       map[string][]byte{kedav1alpha1.DefaultClusterConfigKubeconfigKey: []byte(kubeconfig)},
+		},
+	).Build()
+}
+
+func TestRemoteClustersGet(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := newRemoteClustersClient(t, tokenKubeconfig)
+	remoteClusters := NewRemoteClusters(kubeClient)
+
+	cluster, err := remoteClusters.Get(ctx, "default", "remote")
+	assert.NoError(t, err)
+	assert.NotNil(t, cluster.ScaleClient)
+
+	// the clients are cached until the ClusterConfig or its secret change
+	cached, err := remoteClusters.Get(ctx, "default", "remote")
+	assert.NoError(t, err)
+	assert.Same(t, cluster, cached)
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, kubeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "kubeconfig"}, secret))
+	secret.Data["other"] = []byte("changed")
+	assert.NoError(t, kubeClient.Update(ctx, secret))
+	recreated, err := remoteClusters.Get(ctx, "default", "remote")
+	assert.NoError(t, err)
+	assert.NotSame(t, cluster, recreated)
+
+	_, err = remoteClusters.Get(ctx, "default", "missing")
+	assert.ErrorContains(t, err, "error getting ClusterConfig default/missing")
+}
+
+func TestRemoteClustersGetRejectsUnsafeKubeconfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		kubeconfig     string
+		expectedErrMsg string
+	}{
+		{name: "exec", kubeconfig: execKubeconfig, expectedErrMsg: `exec of user "keda" isn't supported`},
+		{name: "auth-provider", kubeconfig: authProviderKubeconfig, expectedErrMsg: `auth-provider of user "keda" isn't supported`},
+		{name: "token file", kubeconfig: tokenFileKubeconfig, expectedErrMsg: `files of user "keda" aren't supported`},
+		{name: "empty", kubeconfig: "", expectedErrMsg: "key kubeconfig of kubeconfig secret kubeconfig of ClusterConfig default/remote is empty"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remoteClusters := NewRemoteClusters(newRemoteClustersClient(t, test.kubeconfig))
+			_, err := remoteClusters.Get(context.Background(), "default", "remote")
+			assert.ErrorContains(t, err, test.expectedErrMsg)
+		})
+	}
+}
+
+func TestRestConfigFromKubeconfig(t *testing.T) {
+	restConfig, err := restConfigFromKubeconfig([]byte(tokenKubeconfig))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://remote.example.com", restConfig.Host)
+	assert.Equal(t, "secret-token", restConfig.BearerToken)
+	assert.Equal(t, "remote.internal", restConfig.TLSClientConfig.ServerName)
+	assert.Nil(t, restConfig.ExecProvider)
+	assert.Nil(t, restConfig.AuthProvider)
+}
+
+func TestCheckScaleAccess(t *testing.T) {
+	// the remote cluster allows to get and update, but not to patch /scale of deployments
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := &authorizationv1.SelfSubjectAccessReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Subresource == "scale" && attributes.Namespace == "default" &&
+			(attributes.Resource != "deployments" || attributes.Verb != "patch")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(review)
+	}))
+	defer server.Close()
+
+	cluster, err := NewRemoteCluster(&rest.Config{Host: server.URL})
+	assert.NoError(t, err)
+
+	err = cluster.CheckScaleAccess(context.Background(), "default", schema.GroupResource{Group: "apps", Resource: "statefulsets"})
+	assert.NoError(t, err)
+
+	err = cluster.CheckScaleAccess(context.Background(), "default", schema.GroupResource{Group: "apps", Resource: "deployments"})
+	assert.EqualError(t, err, "kubeconfig doesn't grant patch access to deployments.apps/scale in namespace default of the remote cluster")
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package metricscollector
 
 import (
+	"context"
+	"errors"
+	"net"
 	"time"
 
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
@@ -35,6 +38,9 @@ const (
 var (
 	collectors        []MetricsCollector
 	promServerMetrics *grpcprom.ServerMetrics
+
+	// highCardinalityMetrics enables the namespace and name labels on the per scaler type metrics
+	highCardinalityMetrics bool
 )
 
 type MetricsCollector interface {
@@ -55,6 +61,12 @@ type MetricsCollector interface {
 	// RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 	RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error)
 
+	// RecordScalerTypeLatency create a measurement of the latency of getting metrics per scaler type
+	RecordScalerTypeLatency(scalerType string, namespace string, scaledResource string, value time.Duration)
+
+	// RecordScalerTypeError counts the number of errors occurred in getting metrics per scaler type and error type
+	RecordScalerTypeError(scalerType string, errorType string)
+
 	// RecordScaledObjectError counts the number of errors with the scaled object
 	RecordScaledObjectError(namespace string, scaledObject string, err error)
 
@@ -79,7 +91,9 @@ type MetricsCollector interface {
 	RecordCloudEventQueueStatus(namespace string, value int)
 }
 
-func NewMetricsCollectors(enablePrometheusMetrics bool, enableOpenTelemetryMetrics bool) {
+func NewMetricsCollectors(enablePrometheusMetrics bool, enableOpenTelemetryMetrics bool, enableHighCardinalityMetrics bool) {
+	highCardinalityMetrics = enableHighCardinalityMetrics
+
 	if enablePrometheusMetrics {
 		promometrics := NewPromMetrics()
 		collectors = append(collectors, promometrics)
@@ -137,6 +151,46 @@ func RecordScalerError(namespace string, scaledObject string, scaler string, tri
 	}
 }
 
+// RecordScalerTypeLatency create a measurement of the latency of getting metrics per scaler type,
+// namespace and name of the scaled resource are recorded only if high cardinality metrics are enabled
+func RecordScalerTypeLatency(scalerType string, namespace string, scaledResource string, value time.Duration) {
+	if !highCardinalityMetrics {
+		namespace, scaledResource = "", ""
+	}
+	for _, element := range collectors {
+		element.RecordScalerTypeLatency(scalerType, namespace, scaledResource, value)
+	}
+}
+
+// RecordScalerTypeError counts the number of errors occurred in getting metrics per scaler type and error type
+func RecordScalerTypeError(scalerType string, err error) {
+	if err == nil {
+		return
+	}
+	errorType := getErrorType(err)
+	for _, element := range collectors {
+		element.RecordScalerTypeError(scalerType, errorType)
+	}
+}
+
+// getErrorType classifies the error to keep the cardinality of the error type label low
+func getErrorType(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "connection"
+	}
+	return "other"
+}
+
 // RecordScaledObjectError counts the number of errors with the scaled object
 func RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	for _, element := range collectors {
@@ -30,6 +30,8 @@ var (
 	otScalerErrorsCounter            api.Int64Counter
 	otScaledObjectErrorsCounter      api.Int64Counter
 	otScaledJobErrorsCounter         api.Int64Counter
+	otScalerTypeErrorsCounter        api.Int64Counter
+	otScalerTypeLatencyHistogram     api.Float64Histogram
 	otTriggerTotalsCounterDeprecated api.Int64UpDownCounter
 	otCrdTotalsCounterDeprecated     api.Int64UpDownCounter
 	otTriggerRegisteredTotalsCounter api.Int64UpDownCounter
@@ -115,6 +117,20 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	otScalerTypeErrorsCounter, err = meter.Int64Counter("keda.scaler.type.errors", api.WithDescription("Number of errors retrieving metrics per scaler type and error type"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScalerTypeLatencyHistogram, err = meter.Float64Histogram(
+		"keda.scaler.type.metrics.latency.seconds",
+		api.WithDescription("The latency of retrieving metrics per scaler type"),
+		api.WithUnit("s"),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	otTriggerTotalsCounterDeprecated, err = meter.Int64UpDownCounter("keda.trigger.totals", api.WithDescription("DEPRECATED - will be removed in 2.16 - use 'keda.trigger.registered.count' instead"))
 	if err != nil {
 		otLog.Error(err, msg)
@@ -380,6 +396,23 @@ func (o *OtelMetrics) RecordScalerError(namespace string, scaledResource string,
 	}
 }
 
+// RecordScalerTypeLatency create a measurement of the latency of getting metrics per scaler type
+func (o *OtelMetrics) RecordScalerTypeLatency(scalerType string, namespace string, scaledResource string, value time.Duration) {
+	opt := api.WithAttributes(
+		attribute.Key("scalerType").String(scalerType),
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("name").String(scaledResource))
+	otScalerTypeLatencyHistogram.Record(context.Background(), value.Seconds(), opt)
+}
+
+// RecordScalerTypeError counts the number of errors occurred in getting metrics per scaler type and error type
+func (o *OtelMetrics) RecordScalerTypeError(scalerType string, errorType string) {
+	opt := api.WithAttributes(
+		attribute.Key("scalerType").String(scalerType),
+		attribute.Key("errorType").String(errorType))
+	otScalerTypeErrorsCounter.Add(context.Background(), 1, opt)
+}
+
 // RecordScaledObjectError counts the number of errors with the scaled object
 func (o *OtelMetrics) RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	opt := api.WithAttributes(
@@ -118,6 +118,27 @@ var (
 		},
 		metricLabels,
 	)
+	// the per scaler type metrics are in their own subsystem, keda_scaler_metrics_latency_seconds and
+	// keda_scaler_errors_total being already registered with other types and labels
+	scalerTypeMetricsLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler_type",
+			Name:      "metrics_latency_seconds",
+			Help:      "The latency of retrieving metrics per scaler type, in seconds. 'namespace' and 'name' are set only with high cardinality metrics enabled.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"scaler_type", "namespace", "name"},
+	)
+	scalerTypeErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler_type",
+			Name:      "errors_total",
+			Help:      "The total number of errors encountered retrieving metrics per scaler type and error type.",
+		},
+		[]string{"scaler_type", "error_type"},
+	)
 	scaledObjectErrorsDeprecated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -245,6 +266,8 @@ func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(scalerActive)
 	metrics.Registry.MustRegister(scalerErrorsDeprecated)
 	metrics.Registry.MustRegister(scalerErrors)
+	metrics.Registry.MustRegister(scalerTypeMetricsLatency)
+	metrics.Registry.MustRegister(scalerTypeErrors)
 	metrics.Registry.MustRegister(scaledObjectErrorsDeprecated)
 	metrics.Registry.MustRegister(scaledObjectErrors)
 	metrics.Registry.MustRegister(scaledObjectPaused)
@@ -328,6 +351,16 @@ func (p *PromMetrics) RecordScalerError(namespace string, scaledResource string,
 	}
 }
 
+// RecordScalerTypeLatency create a measurement of the latency of getting metrics per scaler type
+func (p *PromMetrics) RecordScalerTypeLatency(scalerType string, namespace string, scaledResource string, value time.Duration) {
+	scalerTypeMetricsLatency.WithLabelValues(scalerType, namespace, scaledResource).Observe(value.Seconds())
+}
+
+// RecordScalerTypeError counts the number of errors occurred in getting metrics per scaler type and error type
+func (p *PromMetrics) RecordScalerTypeError(scalerType string, errorType string) {
+	scalerTypeErrors.WithLabelValues(scalerType, errorType).Inc()
+}
+
 // RecordScaledObjectError counts the number of errors with the scaled object
 func (p *PromMetrics) RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscollector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordScalerTypeLatency(t *testing.T) {
+	collectors = []MetricsCollector{&PromMetrics{}}
+	defer func() { collectors = nil; highCardinalityMetrics = false }()
+	scalerTypeMetricsLatency.Reset()
+
+	highCardinalityMetrics = false
+	RecordScalerTypeLatency("kafka", "default", "consumer", 20*time.Millisecond)
+	highCardinalityMetrics = true
+	RecordScalerTypeLatency("redis", "default", "worker", 2*time.Second)
+
+	expected := `
+# HELP keda_scaler_type_metrics_latency_seconds The latency of retrieving metrics per scaler type, in seconds. 'namespace' and 'name' are set only with high cardinality metrics enabled.
+# TYPE keda_scaler_type_metrics_latency_seconds histogram
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.005"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.01"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.025"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.05"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.1"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.25"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="0.5"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="1"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="2.5"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="5"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="10"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="30"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="",namespace="",scaler_type="kafka",le="+Inf"} 1
+keda_scaler_type_metrics_latency_seconds_sum{name="",namespace="",scaler_type="kafka"} 0.02
+keda_scaler_type_metrics_latency_seconds_count{name="",namespace="",scaler_type="kafka"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.005"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.01"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.025"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.05"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.1"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.25"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="0.5"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="1"} 0
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="2.5"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="5"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="10"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="30"} 1
+keda_scaler_type_metrics_latency_seconds_bucket{name="worker",namespace="default",scaler_type="redis",le="+Inf"} 1
+keda_scaler_type_metrics_latency_seconds_sum{name="worker",namespace="default",scaler_type="redis"} 2
+keda_scaler_type_metrics_latency_seconds_count{name="worker",namespace="default",scaler_type="redis"} 1
+`
+	err := testutil.CollectAndCompare(scalerTypeMetricsLatency, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
+
+func TestRecordScalerTypeError(t *testing.T) {
+	collectors = []MetricsCollector{&PromMetrics{}}
+	defer func() { collectors = nil }()
+	scalerTypeErrors.Reset()
+
+	RecordScalerTypeError("sqs", nil)
+	RecordScalerTypeError("sqs", fmt.Errorf("error getting queue length: %w", context.DeadlineExceeded))
+	RecordScalerTypeError("sqs", errors.New("access denied"))
+	RecordScalerTypeError("redis", context.Canceled)
+
+	expected := `
+# HELP keda_scaler_type_errors_total The total number of errors encountered retrieving metrics per scaler type and error type.
+# TYPE keda_scaler_type_errors_total counter
+keda_scaler_type_errors_total{error_type="canceled",scaler_type="redis"} 1
+keda_scaler_type_errors_total{error_type="other",scaler_type="sqs"} 1
+keda_scaler_type_errors_total{error_type="timeout",scaler_type="sqs"} 1
+`
+	err := testutil.CollectAndCompare(scalerTypeErrors, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
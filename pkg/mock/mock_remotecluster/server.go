@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mock_remotecluster fakes the API server of a remote cluster the scale targets of ScaledObjects live in
+package mock_remotecluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Server is an API server of a remote cluster serving the discovery, the access reviews and the /scale subresource
+// of Deployments, whose replicas are kept in memory
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	replicas map[string]int32
+}
+
+// NewServer starts a Server with the Deployments of the namespace and their replicas
+func NewServer(namespace string, replicas map[string]int32) *Server {
+	s := &Server{replicas: replicas}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &metav1.APIVersions{TypeMeta: metav1.TypeMeta{Kind: "APIVersions"}, Versions: []string{"v1"}})
+	})
+	mux.HandleFunc("GET /api/v1", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &metav1.APIResourceList{TypeMeta: metav1.TypeMeta{Kind: "APIResourceList"}, GroupVersion: "v1"})
+	})
+	mux.HandleFunc("GET /apis", func(w http.ResponseWriter, _ *http.Request) {
+		version := metav1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"}
+		writeJSON(w, &metav1.APIGroupList{
+			TypeMeta: metav1.TypeMeta{Kind: "APIGroupList"},
+			Groups:   []metav1.APIGroup{{Name: "apps", Versions: []metav1.GroupVersionForDiscovery{version}, PreferredVersion: version}},
+		})
+	})
+	mux.HandleFunc("GET /apis/apps/v1", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList"},
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "update"}},
+				{Name: "deployments/scale", Kind: "Scale", Group: "autoscaling", Version: "v1", Namespaced: true, Verbs: metav1.Verbs{"get", "update", "patch"}},
+			},
+		})
+	})
+	mux.HandleFunc("POST /apis/authorization.k8s.io/v1/selfsubjectaccessreviews", func(w http.ResponseWriter, r *http.Request) {
+		review := &authorizationv1.SelfSubjectAccessReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Namespace == namespace && attributes.Resource == "deployments" && attributes.Subresource == "scale"
+		writeJSON(w, review)
+	})
+	scalePath := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/{name}/scale", namespace)
+	mux.HandleFunc("GET "+scalePath, func(w http.ResponseWriter, r *http.Request) {
+		replicas, ok := s.Replicas(r.PathValue("name"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, newScale(namespace, r.PathValue("name"), replicas))
+	})
+	mux.HandleFunc("PUT "+scalePath, func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := s.Replicas(r.PathValue("name")); !ok {
+			http.NotFound(w, r)
+			return
+		}
+		scale := &autoscalingv1.Scale{}
+		if err := json.NewDecoder(r.Body).Decode(scale); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mutex.Lock()
+		s.replicas[r.PathValue("name")] = scale.Spec.Replicas
+		s.mutex.Unlock()
+		writeJSON(w, newScale(namespace, r.PathValue("name"), scale.Spec.Replicas))
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Replicas returns the replicas of the Deployment
+func (s *Server) Replicas(name string) (int32, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	replicas, ok := s.replicas[name]
+	return replicas, ok
+}
+
+// Kubeconfig returns a kubeconfig with a token to access the server
+func (s *Server) Kubeconfig() []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: remote
+clusters:
+- name: remote
+  cluster:
+    server: %s
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: keda
+users:
+- name: keda
+  user:
+    token: token
+`, s.URL))
+}
+
+func newScale(namespace, name string, replicas int32) *autoscalingv1.Scale {
+	return &autoscalingv1.Scale{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+		Status:     autoscalingv1.ScaleStatus{Replicas: replicas, Selector: "app=" + name},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, object any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(object)
+}
@@ -42,6 +42,18 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// ForgetScaledObject mocks base method.
+func (m *MockScaleExecutor) ForgetScaledObject(ctx context.Context, scaledObjectIdentifier string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgetScaledObject", ctx, scaledObjectIdentifier)
+}
+
+// ForgetScaledObject indicates an expected call of ForgetScaledObject.
+func (mr *MockScaleExecutorMockRecorder) ForgetScaledObject(ctx, scaledObjectIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetScaledObject", reflect.TypeOf((*MockScaleExecutor)(nil).ForgetScaledObject), ctx, scaledObjectIdentifier)
+}
+
 // RequestJobScale mocks base method.
 func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive, isError bool, scaleTo, maxScale int64) {
 	m.ctrl.T.Helper()
@@ -65,3 +77,17 @@ func (mr *MockScaleExecutorMockRecorder) RequestScale(ctx, scaledObject, isActiv
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestScale), ctx, scaledObject, isActive, isError, options)
 }
+
+// Shutdown mocks base method.
+func (m *MockScaleExecutor) Shutdown(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockScaleExecutorMockRecorder) Shutdown(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockScaleExecutor)(nil).Shutdown), ctx)
+}
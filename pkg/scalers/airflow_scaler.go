@@ -0,0 +1,178 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// airflowTaskInstancesPath lists the task instances of all the DAG runs of all the DAGs
+const airflowTaskInstancesPath = "/api/v1/dags/~/dagRuns/~/taskInstances"
+
+type airflowScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *airflowMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type airflowMetadata struct {
+	triggerIndex int
+
+	URL                       string   `keda:"name=url,                       order=triggerMetadata;resolvedEnv"`
+	States                    []string `keda:"name=states,                    order=triggerMetadata, optional, default=queued"`
+	Pools                     []string `keda:"name=pools,                     order=triggerMetadata, optional"`
+	Queues                    []string `keda:"name=queues,                    order=triggerMetadata, optional"`
+	TargetTaskCount           int64    `keda:"name=targetTaskCount,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetTaskCount int64    `keda:"name=activationTargetTaskCount, order=triggerMetadata, optional"`
+	UnsafeSsl                 bool     `keda:"name=unsafeSsl,                 order=triggerMetadata, optional"`
+
+	Username    string `keda:"name=username,    order=authParams;resolvedEnv, optional"`
+	Password    string `keda:"name=password,    order=authParams;resolvedEnv, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams;resolvedEnv, optional"`
+}
+
+type airflowTaskInstanceCollection struct {
+	TotalEntries int64 `json:"total_entries"`
+}
+
+func (m *airflowMetadata) Validate() error {
+	if m.TargetTaskCount <= 0 {
+		return errors.New("targetTaskCount must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return errors.New("username and password can't be used with bearerToken")
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	return nil
+}
+
+// NewAirflowScaler creates a new airflowScaler
+func NewAirflowScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAirflowMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing airflow metadata: %w", err)
+	}
+
+	return &airflowScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "airflow_scaler"),
+	}, nil
+}
+
+func parseAirflowMetadata(config *scalersconfig.ScalerConfig) (*airflowMetadata, error) {
+	meta := &airflowMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *airflowScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *airflowScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := "airflow-" + strings.Join(s.metadata.States, "-")
+	if len(s.metadata.Pools) > 0 {
+		name += "-" + strings.Join(s.metadata.Pools, "-")
+	}
+	if len(s.metadata.Queues) > 0 {
+		name += "-" + strings.Join(s.metadata.Queues, "-")
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetTaskCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of task instances in the states, filtered by pool and queue
+func (s *airflowScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	tasks, err := s.getTaskInstanceCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting airflow task instances: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(tasks))
+	return []external_metrics.ExternalMetricValue{metric}, tasks > s.metadata.ActivationTargetTaskCount, nil
+}
+
+// getTaskInstanceCount only fetches a single task instance, the count is the total number of entries of the collection
+func (s *airflowScaler) getTaskInstanceCount(ctx context.Context) (int64, error) {
+	query := url.Values{}
+	query.Set("limit", "1")
+	for _, state := range s.metadata.States {
+		query.Add("state", state)
+	}
+	for _, pool := range s.metadata.Pools {
+		query.Add("pool", pool)
+	}
+	for _, queue := range s.metadata.Queues {
+		query.Add("queue", queue)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+airflowTaskInstancesPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+s.metadata.BearerToken)
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return -1, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("unexpected status code %d from airflow: %s", resp.StatusCode, string(body))
+	}
+
+	var collection airflowTaskInstanceCollection
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return -1, fmt.Errorf("error parsing airflow response: %w", err)
+	}
+	return collection.TotalEntries, nil
+}
@@ -0,0 +1,109 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseAirflowMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type airflowMetricIdentifier struct {
+	metadataTestData *parseAirflowMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAirflowMetadata = []parseAirflowMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://airflow-webserver:8080"}, map[string]string{}, false, "url only"},
+	{map[string]string{"url": "http://airflow-webserver:8080", "states": "queued,scheduled", "pools": "default_pool", "queues": "gpu", "targetTaskCount": "10", "activationTargetTaskCount": "1"}, map[string]string{"username": "admin", "password": "admin"}, false, "all metadata with basic auth"},
+	{map[string]string{"url": "http://airflow-webserver:8080"}, map[string]string{"bearerToken": "token"}, false, "bearer token"},
+	{map[string]string{"url": "http://airflow-webserver:8080", "targetTaskCount": "0"}, map[string]string{}, true, "zero targetTaskCount"},
+	{map[string]string{"url": "http://airflow-webserver:8080"}, map[string]string{"username": "admin"}, true, "username without password"},
+	{map[string]string{"url": "http://airflow-webserver:8080"}, map[string]string{"username": "admin", "password": "admin", "bearerToken": "token"}, true, "basic auth and bearer token"},
+}
+
+var airflowMetricIdentifiers = []airflowMetricIdentifier{
+	{&testAirflowMetadata[1], 0, "s0-airflow-queued"},
+	{&testAirflowMetadata[2], 1, "s1-airflow-queued-scheduled-default_pool-gpu"},
+}
+
+func TestAirflowParseMetadata(t *testing.T) {
+	for _, testData := range testAirflowMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseAirflowMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestAirflowGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range airflowMetricIdentifiers {
+		meta, err := parseAirflowMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAirflowScaler := airflowScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAirflowScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestAirflowGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/dags/~/dagRuns/~/taskInstances", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		assert.Equal(t, []string{"queued"}, r.URL.Query()["state"])
+		assert.Equal(t, []string{"default_pool"}, r.URL.Query()["pool"])
+		assert.Equal(t, []string{"celery", "gpu"}, r.URL.Query()["queue"])
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"task_instances":[{"task_id":"extract","state":"queued","pool":"default_pool","queue":"celery"}],"total_entries":7}`))
+	}))
+	defer server.Close()
+
+	s, err := NewAirflowScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"url": server.URL, "pools": "default_pool", "queues": "celery,gpu", "activationTargetTaskCount": "5"},
+		AuthParams:      map[string]string{"bearerToken": "token"},
+	})
+	assert.NoError(t, err)
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "airflow")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(7), metrics[0].Value.Value())
+}
+
+func TestAirflowGetMetricsAndActivityUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401}`))
+	}))
+	defer server.Close()
+
+	s, err := NewAirflowScaler(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"url": server.URL}})
+	assert.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "airflow")
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,287 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	alibabaMNSVersion = "2015-06-06"
+	// alibabaRAMRoleCredentialsURL is where the metadata service of ECS serves the credentials of the RAM role of the instance
+	alibabaRAMRoleCredentialsURL = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
+	// alibabaCredentialsExpiryWindow is how long before their expiration the credentials of the RAM role are renewed
+	alibabaCredentialsExpiryWindow = 5 * time.Minute
+)
+
+type alibabaMNSScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *alibabaMNSMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+
+	// ramRoleCredentialsURL is overridden by the tests
+	ramRoleCredentialsURL string
+	credentialsLock       sync.Mutex
+	credentials           *alibabaCredentials
+}
+
+type alibabaMNSMetadata struct {
+	triggerIndex int
+
+	QueueName string `keda:"name=queueName, order=triggerMetadata"`
+	AccountID string `keda:"name=accountId, order=triggerMetadata;authParams"`
+	Region    string `keda:"name=region,    order=triggerMetadata"`
+	// Endpoint overrides the public endpoint of the region, e.g. with the internal one
+	Endpoint              string  `keda:"name=endpoint,              order=triggerMetadata, optional"`
+	QueueLength           float64 `keda:"name=queueLength,           order=triggerMetadata, optional, default=5"`
+	ActivationQueueLength float64 `keda:"name=activationQueueLength, order=triggerMetadata, optional"`
+	ScaleOnInFlight       bool    `keda:"name=scaleOnInFlight,       order=triggerMetadata, optional, default=true"`
+	ScaleOnDelayed        bool    `keda:"name=scaleOnDelayed,        order=triggerMetadata, optional"`
+
+	AccessKeyID     string `keda:"name=accessKeyId,     order=authParams;resolvedEnv, optional"`
+	AccessKeySecret string `keda:"name=accessKeySecret, order=authParams;resolvedEnv, optional"`
+	SecurityToken   string `keda:"name=securityToken,   order=authParams;resolvedEnv, optional"`
+	// RAMRoleName authenticates with the RAM role of the ECS instance, e.g. the worker role of an ACK node
+	RAMRoleName string `keda:"name=ramRoleName, order=triggerMetadata;authParams, optional"`
+}
+
+func (m *alibabaMNSMetadata) Validate() error {
+	if m.QueueLength <= 0 {
+		return errors.New("queueLength must be greater than 0")
+	}
+	if (m.AccessKeyID == "") != (m.AccessKeySecret == "") {
+		return errors.New("accessKeyId and accessKeySecret must be provided together")
+	}
+	if (m.AccessKeyID == "") == (m.RAMRoleName == "") {
+		return errors.New("exactly one of accessKeyId or ramRoleName must be provided")
+	}
+
+	if m.Endpoint == "" {
+		m.Endpoint = fmt.Sprintf("https://%s.mns.%s.aliyuncs.com", m.AccountID, m.Region)
+	}
+	m.Endpoint = strings.TrimSuffix(m.Endpoint, "/")
+	return nil
+}
+
+// alibabaCredentials are the credentials requests to Alibaba Cloud are signed with
+type alibabaCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	AccessKeySecret string `json:"AccessKeySecret"`
+	SecurityToken   string `json:"SecurityToken"`
+	// Expiration is zero for static credentials
+	Expiration time.Time `json:"Expiration"`
+}
+
+// alibabaMNSQueueAttributes is the response of GetQueueAttributes
+type alibabaMNSQueueAttributes struct {
+	ActiveMessages   int64 `xml:"ActiveMessages"`
+	InactiveMessages int64 `xml:"InactiveMessages"`
+	DelayMessages    int64 `xml:"DelayMessages"`
+}
+
+// alibabaMNSError is the body of the failed responses
+type alibabaMNSError struct {
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+	RequestID string `xml:"RequestId"`
+}
+
+// NewAlibabaMNSScaler creates a new alibabaMNSScaler
+func NewAlibabaMNSScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAlibabaMNSMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing alibaba mns metadata: %w", err)
+	}
+
+	s := &alibabaMNSScaler{
+		metricType:            metricType,
+		metadata:              meta,
+		httpClient:            kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:                InitializeLogger(config, "alibaba_mns_scaler"),
+		ramRoleCredentialsURL: alibabaRAMRoleCredentialsURL,
+	}
+	if meta.AccessKeyID != "" {
+		s.credentials = &alibabaCredentials{AccessKeyID: meta.AccessKeyID, AccessKeySecret: meta.AccessKeySecret, SecurityToken: meta.SecurityToken}
+	}
+	return s, nil
+}
+
+func parseAlibabaMNSMetadata(config *scalersconfig.ScalerConfig) (*alibabaMNSMetadata, error) {
+	meta := &alibabaMNSMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *alibabaMNSScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *alibabaMNSScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("alibaba-mns-%s", s.metadata.QueueName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.QueueLength),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of messages of the queue
+func (s *alibabaMNSScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	length, err := s.getQueueLength(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting attributes of queue %s: %w", s.metadata.QueueName, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(length))
+	return []external_metrics.ExternalMetricValue{metric}, float64(length) > s.metadata.ActivationQueueLength, nil
+}
+
+// getQueueLength returns the visible messages of the queue, with the messages being processed and
+// the delayed ones when enabled
+func (s *alibabaMNSScaler) getQueueLength(ctx context.Context) (int64, error) {
+	credentials, err := s.getCredentials(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error getting credentials: %w", err)
+	}
+
+	resource := "/queues/" + url.PathEscape(s.metadata.QueueName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.Endpoint+resource, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-mns-version", alibabaMNSVersion)
+	if credentials.SecurityToken != "" {
+		req.Header.Set("security-token", credentials.SecurityToken)
+	}
+	req.Header.Set("Authorization", "MNS "+credentials.AccessKeyID+":"+signAlibabaMNSRequest(req, resource, credentials.AccessKeySecret))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var mnsError alibabaMNSError
+		if err := xml.NewDecoder(resp.Body).Decode(&mnsError); err == nil && mnsError.Code != "" {
+			return 0, fmt.Errorf("unexpected status code %d: %s: %s (request id %s)", resp.StatusCode, mnsError.Code, mnsError.Message, mnsError.RequestID)
+		}
+		return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var attributes alibabaMNSQueueAttributes
+	if err := xml.NewDecoder(resp.Body).Decode(&attributes); err != nil {
+		return 0, fmt.Errorf("error decoding queue attributes: %w", err)
+	}
+	length := attributes.ActiveMessages
+	if s.metadata.ScaleOnInFlight {
+		length += attributes.InactiveMessages
+	}
+	if s.metadata.ScaleOnDelayed {
+		length += attributes.DelayMessages
+	}
+	return length, nil
+}
+
+// signAlibabaMNSRequest returns the signature of the request, the HMAC-SHA1 of its method, content,
+// date, x-mns-* headers and resource
+func signAlibabaMNSRequest(req *http.Request, resource, accessKeySecret string) string {
+	var mnsHeaders []string
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-mns-") {
+			mnsHeaders = append(mnsHeaders, name+":"+strings.Join(values, ","))
+		}
+	}
+	// the headers are sorted by name, which the colon can't be part of
+	slices.Sort(mnsHeaders)
+
+	var b strings.Builder
+	b.WriteString(req.Method + "\n")
+	b.WriteString(req.Header.Get("Content-MD5") + "\n")
+	b.WriteString(req.Header.Get("Content-Type") + "\n")
+	b.WriteString(req.Header.Get("Date") + "\n")
+	for _, header := range mnsHeaders {
+		b.WriteString(header + "\n")
+	}
+	b.WriteString(resource)
+
+	mac := hmac.New(sha1.New, []byte(accessKeySecret))
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// getCredentials returns the static credentials, or the credentials of the RAM role of the instance
+// which are renewed before they expire
+func (s *alibabaMNSScaler) getCredentials(ctx context.Context) (*alibabaCredentials, error) {
+	s.credentialsLock.Lock()
+	defer s.credentialsLock.Unlock()
+	if s.credentials != nil && (s.credentials.Expiration.IsZero() || time.Until(s.credentials.Expiration) > alibabaCredentialsExpiryWindow) {
+		return s.credentials, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.ramRoleCredentialsURL+url.PathEscape(s.metadata.RAMRoleName), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from the metadata service for RAM role %s: %s", resp.StatusCode, s.metadata.RAMRoleName, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		alibabaCredentials
+		Code string `json:"Code"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding credentials of RAM role %s: %w", s.metadata.RAMRoleName, err)
+	}
+	if result.Code != "Success" {
+		return nil, fmt.Errorf("metadata service returned %s for RAM role %s", result.Code, s.metadata.RAMRoleName)
+	}
+	s.credentials = &result.alibabaCredentials
+	return s.credentials, nil
+}
@@ -0,0 +1,169 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseAlibabaMNSMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type alibabaMNSMetricIdentifier struct {
+	metadataTestData *parseAlibabaMNSMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAlibabaMNSMetadata = []parseAlibabaMNSMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou"}, map[string]string{"accessKeyId": "id", "accessKeySecret": "secret"}, false, "access key"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou", "endpoint": "https://1234567890.mns.cn-hangzhou-internal.aliyuncs.com/", "queueLength": "10", "activationQueueLength": "1", "scaleOnInFlight": "false", "scaleOnDelayed": "true"}, map[string]string{"accessKeyId": "id", "accessKeySecret": "secret", "securityToken": "token"}, false, "sts token and internal endpoint"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou", "ramRoleName": "ack-worker"}, map[string]string{}, false, "ram role"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou"}, map[string]string{}, true, "no credentials"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou"}, map[string]string{"accessKeyId": "id"}, true, "access key without secret"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou", "ramRoleName": "ack-worker"}, map[string]string{"accessKeyId": "id", "accessKeySecret": "secret"}, true, "access key and ram role"},
+	{map[string]string{"queueName": "orders", "region": "cn-hangzhou"}, map[string]string{"accessKeyId": "id", "accessKeySecret": "secret"}, true, "no account id"},
+	{map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou", "queueLength": "0"}, map[string]string{"accessKeyId": "id", "accessKeySecret": "secret"}, true, "zero queueLength"},
+}
+
+var alibabaMNSMetricIdentifiers = []alibabaMNSMetricIdentifier{
+	{&testAlibabaMNSMetadata[1], 0, "s0-alibaba-mns-orders"},
+	{&testAlibabaMNSMetadata[3], 1, "s1-alibaba-mns-orders"},
+}
+
+func TestAlibabaMNSParseMetadata(t *testing.T) {
+	for _, testData := range testAlibabaMNSMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseAlibabaMNSMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestAlibabaMNSEndpoint(t *testing.T) {
+	meta, err := parseAlibabaMNSMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAlibabaMNSMetadata[1].metadata, AuthParams: testAlibabaMNSMetadata[1].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, "https://1234567890.mns.cn-hangzhou.aliyuncs.com", meta.Endpoint)
+
+	meta, err = parseAlibabaMNSMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAlibabaMNSMetadata[2].metadata, AuthParams: testAlibabaMNSMetadata[2].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, "https://1234567890.mns.cn-hangzhou-internal.aliyuncs.com", meta.Endpoint)
+}
+
+func TestAlibabaMNSGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range alibabaMNSMetricIdentifiers {
+		meta, err := parseAlibabaMNSMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAlibabaMNSScaler := alibabaMNSScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAlibabaMNSScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSignAlibabaMNSRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://1234567890.mns.cn-hangzhou.aliyuncs.com/queues/orders", nil)
+	require.NoError(t, err)
+	req.Header.Set("Date", "Wed, 01 May 2024 12:00:00 GMT")
+	req.Header.Set("x-mns-version", alibabaMNSVersion)
+	assert.Equal(t, "AGrWuOzY5t+tQroord8SJCB4mTg=", signAlibabaMNSRequest(req, "/queues/orders", "secret"))
+}
+
+func TestAlibabaMNSGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/queues/orders", r.URL.Path)
+		assert.Equal(t, alibabaMNSVersion, r.Header.Get("x-mns-version"))
+		assert.Equal(t, "MNS id:"+signAlibabaMNSRequest(r, "/queues/orders", "secret"), r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Queue xmlns="http://mns.aliyuncs.com/doc/v1/">
+  <QueueName>orders</QueueName>
+  <ActiveMessages>7</ActiveMessages>
+  <InactiveMessages>3</InactiveMessages>
+  <DelayMessages>2</DelayMessages>
+</Queue>`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"visible and in flight messages", map[string]string{}, 10, true},
+		{"visible messages", map[string]string{"scaleOnInFlight": "false", "activationQueueLength": "7"}, 7, false},
+		{"all messages", map[string]string{"scaleOnDelayed": "true"}, 12, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["queueName"] = "orders"
+			tc.metadata["accountId"] = "1234567890"
+			tc.metadata["region"] = "cn-hangzhou"
+			tc.metadata["endpoint"] = server.URL
+			s, err := NewAlibabaMNSScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"accessKeyId": "id", "accessKeySecret": "secret"}})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "alibaba-mns")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestAlibabaMNSGetMetricsAndActivityRAMRole(t *testing.T) {
+	var credentialRequests atomic.Int32
+	metadataServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ack-worker", r.URL.Path)
+		credentialRequests.Add(1)
+		_, _ = fmt.Fprintf(w, `{"AccessKeyId":"STS.id","AccessKeySecret":"secret","SecurityToken":"token","Expiration":"%s","LastUpdated":"2024-05-01T12:00:00Z","Code":"Success"}`,
+			time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	defer metadataServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("security-token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "MNS STS.id:"))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error xmlns="http://mns.aliyuncs.com/doc/v1/"><Code>QueueNotExist</Code><Message>The queue name you provided is not exist.</Message><RequestId>5F2B2A</RequestId></Error>`))
+	}))
+	defer server.Close()
+
+	s, err := NewAlibabaMNSScaler(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"queueName": "orders", "accountId": "1234567890", "region": "cn-hangzhou", "endpoint": server.URL, "ramRoleName": "ack-worker"}})
+	require.NoError(t, err)
+	s.(*alibabaMNSScaler).ramRoleCredentialsURL = metadataServer.URL + "/"
+
+	for i := 0; i < 2; i++ {
+		_, _, err = s.GetMetricsAndActivity(context.Background(), "alibaba-mns")
+		assert.ErrorContains(t, err, "QueueNotExist")
+	}
+	// the credentials are reused until they're about to expire
+	assert.Equal(t, int32(1), credentialRequests.Load())
+}
@@ -0,0 +1,286 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-amqp"
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// amqpProtocolManagement is the AMQP Management draft implemented by e.g. Qpid Broker-J and Qpid Dispatch Router,
+	// amqpProtocolArtemis the management API of ActiveMQ Artemis
+	amqpProtocolManagement = "amqpManagement"
+	amqpProtocolArtemis    = "artemis"
+
+	amqpManagementNode    = "$management"
+	amqpArtemisNode       = "activemq.management"
+	amqpStatusCodeOK      = 200
+	amqpArtemisSucceeded  = "_AMQ_OperationSucceeded"
+	amqpArtemisGetCountOp = "getMessageCount"
+)
+
+type amqpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *amqpMetadata
+	logger     logr.Logger
+}
+
+type amqpMetadata struct {
+	triggerIndex int
+
+	// Host is the URL of the broker, e.g. amqps://broker:5671
+	Host      string `keda:"name=host,      order=triggerMetadata;authParams;resolvedEnv"`
+	QueueName string `keda:"name=queueName, order=triggerMetadata"`
+	Protocol  string `keda:"name=protocol,  order=triggerMetadata, enum=amqpManagement;artemis, optional, default=amqpManagement"`
+	// ManagementNode is the address of the management node, EntityType and CountAttribute are the type
+	// read with the AMQP Management protocol and its attribute holding the number of messages
+	ManagementNode        string  `keda:"name=managementNode,        order=triggerMetadata, optional"`
+	EntityType            string  `keda:"name=entityType,            order=triggerMetadata, optional, default=org.apache.qpid.Queue"`
+	CountAttribute        string  `keda:"name=countAttribute,        order=triggerMetadata, optional, default=queueDepthMessages"`
+	QueueLength           float64 `keda:"name=queueLength,           order=triggerMetadata, optional, default=5"`
+	ActivationQueueLength float64 `keda:"name=activationQueueLength, order=triggerMetadata, optional"`
+
+	Username  string `keda:"name=username,  order=authParams;resolvedEnv, optional"`
+	Password  string `keda:"name=password,  order=authParams;resolvedEnv, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+	Cert      string `keda:"name=cert,      order=authParams, optional"`
+	Key       string `keda:"name=key,       order=authParams, optional"`
+}
+
+func (m *amqpMetadata) Validate() error {
+	if !strings.HasPrefix(m.Host, "amqp://") && !strings.HasPrefix(m.Host, "amqps://") {
+		return errors.New("host must be an amqp:// or amqps:// url")
+	}
+	if m.QueueLength <= 0 {
+		return errors.New("queueLength must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+
+	if m.ManagementNode == "" {
+		m.ManagementNode = amqpManagementNode
+		if m.Protocol == amqpProtocolArtemis {
+			m.ManagementNode = amqpArtemisNode
+		}
+	}
+	return nil
+}
+
+// NewAMQPScaler creates a new AMQP 1.0 scaler
+func NewAMQPScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAMQPMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing amqp metadata: %w", err)
+	}
+
+	return &amqpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "amqp_scaler"),
+	}, nil
+}
+
+func parseAMQPMetadata(config *scalersconfig.ScalerConfig) (*amqpMetadata, error) {
+	meta := &amqpMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *amqpScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *amqpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("amqp-%s", s.metadata.QueueName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.QueueLength),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of messages of the queue
+func (s *amqpScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queueLength, err := s.getQueueLength(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting amqp queue length: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, queueLength)
+	return []external_metrics.ExternalMetricValue{metric}, queueLength > s.metadata.ActivationQueueLength, nil
+}
+
+// getQueueLength sends a request to the management node and waits for its response, the connection being
+// opened for each request as the brokers answer it quickly and the polling interval is long
+func (s *amqpScaler) getQueueLength(ctx context.Context) (float64, error) {
+	opts := &amqp.ConnOptions{}
+	if s.metadata.Username != "" {
+		opts.SASLType = amqp.SASLTypePlain(s.metadata.Username, s.metadata.Password)
+	}
+	if strings.HasPrefix(s.metadata.Host, "amqps://") {
+		tlsConfig, err := kedautil.NewTLSConfig(s.metadata.Cert, s.metadata.Key, s.metadata.Ca, s.metadata.UnsafeSsl)
+		if err != nil {
+			return 0, err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	conn, err := amqp.Dial(ctx, s.metadata.Host, opts)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to broker: %w", err)
+	}
+	defer conn.Close()
+
+	session, err := conn.NewSession(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// the AMQP Management responses are sent on a link from the management node, Artemis
+	// sending them to a temporary queue created by the broker for the receiver
+	replyTo := fmt.Sprintf("keda-%s", uuid.NewString())
+	source, receiverOpts := s.metadata.ManagementNode, &amqp.ReceiverOptions{TargetAddress: replyTo}
+	if s.metadata.Protocol == amqpProtocolArtemis {
+		source, receiverOpts = "", &amqp.ReceiverOptions{DynamicAddress: true}
+	}
+	receiver, err := session.NewReceiver(ctx, source, receiverOpts)
+	if err != nil {
+		return 0, fmt.Errorf("error opening link from %s: %w", s.metadata.ManagementNode, err)
+	}
+	if s.metadata.Protocol == amqpProtocolArtemis {
+		replyTo = receiver.Address()
+	}
+
+	sender, err := session.NewSender(ctx, s.metadata.ManagementNode, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error opening link to %s: %w", s.metadata.ManagementNode, err)
+	}
+
+	request := buildAMQPManagementRequest(s.metadata, replyTo)
+	if err := sender.Send(ctx, request, nil); err != nil {
+		return 0, fmt.Errorf("error sending management request: %w", err)
+	}
+
+	response, err := receiver.Receive(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error receiving management response: %w", err)
+	}
+	if err := receiver.AcceptMessage(ctx, response); err != nil {
+		s.logger.V(1).Info("error accepting management response", "error", err)
+	}
+	return parseAMQPManagementResponse(s.metadata, response)
+}
+
+func buildAMQPManagementRequest(meta *amqpMetadata, replyTo string) *amqp.Message {
+	request := &amqp.Message{
+		Properties: &amqp.MessageProperties{
+			MessageID: uuid.NewString(),
+			ReplyTo:   &replyTo,
+		},
+	}
+	if meta.Protocol == amqpProtocolArtemis {
+		request.ApplicationProperties = map[string]any{
+			"_AMQ_ResourceName":  "queue." + meta.QueueName,
+			"_AMQ_OperationName": amqpArtemisGetCountOp,
+		}
+		// the parameters of the operation, encoded as a JSON array
+		request.Value = "[]"
+		return request
+	}
+
+	request.ApplicationProperties = map[string]any{
+		"operation": "READ",
+		"type":      meta.EntityType,
+		"name":      meta.QueueName,
+	}
+	request.Value = map[string]any{}
+	return request
+}
+
+// parseAMQPManagementResponse returns the number of messages of the queue held by the response
+func parseAMQPManagementResponse(meta *amqpMetadata, response *amqp.Message) (float64, error) {
+	if meta.Protocol == amqpProtocolArtemis {
+		body, _ := response.Value.(string)
+		if succeeded, _ := response.ApplicationProperties[amqpArtemisSucceeded].(bool); !succeeded {
+			return 0, fmt.Errorf("%s of queue %s failed: %s", amqpArtemisGetCountOp, meta.QueueName, body)
+		}
+		// the result is a JSON array holding the value returned by the operation
+		var result []float64
+		if err := json.Unmarshal([]byte(body), &result); err != nil || len(result) != 1 {
+			return 0, fmt.Errorf("unexpected result %q of %s", body, amqpArtemisGetCountOp)
+		}
+		return result[0], nil
+	}
+
+	statusCode, _ := amqpNumber(response.ApplicationProperties["statusCode"])
+	if statusCode != amqpStatusCodeOK {
+		return 0, fmt.Errorf("reading %s %s failed with status code %v: %v", meta.EntityType, meta.QueueName,
+			response.ApplicationProperties["statusCode"], response.ApplicationProperties["statusDescription"])
+	}
+	attributes, ok := response.Value.(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("unexpected body %v of the response", response.Value)
+	}
+	count, ok := amqpNumber(attributes[meta.CountAttribute])
+	if !ok {
+		return 0, fmt.Errorf("attribute %s is %v instead of a number", meta.CountAttribute, attributes[meta.CountAttribute])
+	}
+	return count, nil
+}
+
+// amqpNumber returns the value of the numeric types of AMQP as a float64
+func amqpNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
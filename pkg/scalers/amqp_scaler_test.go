@@ -0,0 +1,150 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/go-amqp"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseAMQPMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type amqpMetricIdentifier struct {
+	metadataTestData *parseAMQPMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAMQPMetadata = []parseAMQPMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"host": "amqp://qpid:5672", "queueName": "jobs"}, map[string]string{"username": "admin", "password": "admin"}, false, "amqp management"},
+	{map[string]string{"host": "amqps://artemis:5671", "queueName": "orders", "protocol": "artemis", "queueLength": "10", "activationQueueLength": "1", "unsafeSsl": "true"}, map[string]string{}, false, "artemis"},
+	{map[string]string{"host": "amqp://router:5672", "queueName": "jobs", "managementNode": "$management", "entityType": "org.apache.qpid.dispatch.router.address", "countAttribute": "deliveriesIngress"}, map[string]string{}, false, "custom entity type"},
+	{map[string]string{"host": "http://qpid:8080", "queueName": "jobs"}, map[string]string{}, true, "http host"},
+	{map[string]string{"host": "amqp://qpid:5672"}, map[string]string{}, true, "missing queueName"},
+	{map[string]string{"host": "amqp://qpid:5672", "queueName": "jobs", "protocol": "qmf"}, map[string]string{}, true, "unknown protocol"},
+	{map[string]string{"host": "amqp://qpid:5672", "queueName": "jobs", "queueLength": "0"}, map[string]string{}, true, "zero queueLength"},
+	{map[string]string{"host": "amqp://qpid:5672", "queueName": "jobs"}, map[string]string{"username": "admin"}, true, "username without password"},
+	{map[string]string{"host": "amqps://qpid:5671", "queueName": "jobs"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+}
+
+var amqpMetricIdentifiers = []amqpMetricIdentifier{
+	{&testAMQPMetadata[1], 0, "s0-amqp-jobs"},
+	{&testAMQPMetadata[2], 1, "s1-amqp-orders"},
+}
+
+func TestAMQPParseMetadata(t *testing.T) {
+	for _, testData := range testAMQPMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestAMQPGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range amqpMetricIdentifiers {
+		meta, err := parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAMQPScaler := amqpScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAMQPScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestAMQPManagementRequest(t *testing.T) {
+	meta, err := parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAMQPMetadata[1].metadata, AuthParams: testAMQPMetadata[1].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, "$management", meta.ManagementNode)
+
+	request := buildAMQPManagementRequest(meta, "keda-reply")
+	assert.Equal(t, "keda-reply", *request.Properties.ReplyTo)
+	assert.Equal(t, map[string]any{"operation": "READ", "type": "org.apache.qpid.Queue", "name": "jobs"}, request.ApplicationProperties)
+
+	meta, err = parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAMQPMetadata[2].metadata, AuthParams: testAMQPMetadata[2].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, "activemq.management", meta.ManagementNode)
+
+	request = buildAMQPManagementRequest(meta, "temp-queue://1")
+	assert.Equal(t, map[string]any{"_AMQ_ResourceName": "queue.orders", "_AMQ_OperationName": "getMessageCount"}, request.ApplicationProperties)
+	assert.Equal(t, "[]", request.Value)
+}
+
+func TestAMQPManagementResponse(t *testing.T) {
+	management, err := parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAMQPMetadata[1].metadata, AuthParams: testAMQPMetadata[1].authParams})
+	require.NoError(t, err)
+	artemis, err := parseAMQPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAMQPMetadata[2].metadata})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		meta          *amqpMetadata
+		response      *amqp.Message
+		expectedValue float64
+		expectedError string
+	}{
+		{
+			name:          "queue depth",
+			meta:          management,
+			response:      &amqp.Message{ApplicationProperties: map[string]any{"statusCode": int32(200)}, Value: map[string]any{"name": "jobs", "queueDepthMessages": int64(42)}},
+			expectedValue: 42,
+		},
+		{
+			name:          "unknown queue",
+			meta:          management,
+			response:      &amqp.Message{ApplicationProperties: map[string]any{"statusCode": int32(404), "statusDescription": "Not Found"}},
+			expectedError: "status code 404: Not Found",
+		},
+		{
+			name:          "missing attribute",
+			meta:          management,
+			response:      &amqp.Message{ApplicationProperties: map[string]any{"statusCode": int32(200)}, Value: map[string]any{"name": "jobs"}},
+			expectedError: "queueDepthMessages",
+		},
+		{
+			name:          "artemis message count",
+			meta:          artemis,
+			response:      &amqp.Message{ApplicationProperties: map[string]any{"_AMQ_OperationSucceeded": true}, Value: "[7]"},
+			expectedValue: 7,
+		},
+		{
+			name:          "artemis unknown queue",
+			meta:          artemis,
+			response:      &amqp.Message{ApplicationProperties: map[string]any{"_AMQ_OperationSucceeded": false}, Value: `["AMQ229017: Queue orders does not exist"]`},
+			expectedError: "does not exist",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := parseAMQPManagementResponse(tc.meta, tc.response)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
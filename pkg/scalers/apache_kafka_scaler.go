@@ -23,7 +23,9 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/segmentio/kafka-go"
@@ -45,6 +47,7 @@ type apacheKafkaScaler struct {
 	client          *kafka.Client
 	logger          logr.Logger
 	previousOffsets map[string]map[int]int64
+	lagEvaluator    *kafkaLagEvaluator
 }
 
 type apacheKafkaMetadata struct {
@@ -63,6 +66,12 @@ type apacheKafkaMetadata struct {
 	ScaleToZeroOnInvalidOffset bool `keda:"name=scaleToZeroOnInvalidOffset, order=triggerMetadata, optional"`
 	LimitToPartitionsWithLag   bool `keda:"name=limitToPartitionsWithLag,   order=triggerMetadata, optional"`
 
+	// The lag the scaling is based on: the lag itself, the lag divided by the production rate or the trend of the lag,
+	// the last two being evaluated over the samples of the last LagEvaluationWindowSeconds
+	LagMode                    string  `keda:"name=lagMode,                    order=triggerMetadata, enum=lag;lagRatio;lagTrend, default=lag"`
+	LagRatioThreshold          float64 `keda:"name=lagRatioThreshold,          order=triggerMetadata, default=30"`
+	LagEvaluationWindowSeconds int     `keda:"name=lagEvaluationWindowSeconds, order=triggerMetadata, default=300"`
+
 	// SASL
 	SASLType kafkaSaslType `keda:"name=sasl,     order=triggerMetadata;authParams, enum=none;plaintext;scram_sha256;scram_sha512;gssapi;aws_msk_iam, default=none"`
 	Username string        `keda:"name=username, order=authParams,                 optional"`
@@ -87,6 +96,14 @@ func (a *apacheKafkaMetadata) enableTLS() bool {
 	return a.TLS == stringEnable
 }
 
+// getLagTarget returns the target of the metric, which is the LagRatioThreshold in seconds in lagRatio mode
+func (a *apacheKafkaMetadata) getLagTarget() float64 {
+	if a.LagMode == kafkaLagModeLagRatio {
+		return a.LagRatioThreshold
+	}
+	return float64(a.LagThreshold)
+}
+
 func (a *apacheKafkaMetadata) Validate() error {
 	if a.LagThreshold <= 0 {
 		return fmt.Errorf("lagThreshold must be a positive number")
@@ -94,6 +111,12 @@ func (a *apacheKafkaMetadata) Validate() error {
 	if a.ActivationLagThreshold < 0 {
 		return fmt.Errorf("activationLagThreshold must be a positive number")
 	}
+	if a.LagRatioThreshold <= 0 {
+		return fmt.Errorf("lagRatioThreshold must be a positive number")
+	}
+	if a.LagEvaluationWindowSeconds <= 0 {
+		return fmt.Errorf("lagEvaluationWindowSeconds must be a positive number")
+	}
 	if a.AllowIdleConsumers && a.LimitToPartitionsWithLag {
 		return fmt.Errorf("allowIdleConsumers and limitToPartitionsWithLag cannot be set simultaneously")
 	}
@@ -153,6 +176,7 @@ func NewApacheKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfi
 		metadata:        kafkaMetadata,
 		logger:          logger,
 		previousOffsets: previousOffsets,
+		lagEvaluator:    newKafkaLagEvaluator(time.Duration(kafkaMetadata.LagEvaluationWindowSeconds) * time.Second),
 	}, nil
 }
 
@@ -414,6 +438,9 @@ func (s *apacheKafkaScaler) GetMetricSpecForScaling(context.Context) []v2.Metric
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
 	}
+	if s.metadata.LagMode == kafkaLagModeLagRatio {
+		externalMetric.Target = GetMetricTargetMili(s.metricType, s.metadata.LagRatioThreshold)
+	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2.MetricSpec{metricSpec}
 }
@@ -461,7 +488,7 @@ func (s *apacheKafkaScaler) GetMetricsAndActivity(ctx context.Context, metricNam
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
-	metric := GenerateMetricInMili(metricName, float64(totalLag))
+	metric := GenerateMetricInMili(metricName, totalLag)
 
 	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.ActivationLagThreshold, nil
 }
@@ -469,7 +496,8 @@ func (s *apacheKafkaScaler) GetMetricsAndActivity(ctx context.Context, metricNam
 // getTotalLag returns totalLag, totalLagWithPersistent, error
 // totalLag and totalLagWithPersistent are the summations of lag and lagWithPersistent returned by getLagForPartition function respectively.
 // totalLag maybe less than totalLagWithPersistent when excludePersistentLag is set to `true` due to some partitions deemed as having persistent lag
-func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, error) {
+// In lagRatio and lagTrend modes, totalLag is the ratio or the trend of the summation of lag rather than the summation itself
+func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (float64, int64, error) {
 	topicPartitions, err := s.getTopicPartitions(ctx)
 	if err != nil {
 		return 0, 0, err
@@ -486,15 +514,17 @@ func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, erro
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
 	partitionsWithLag := int64(0)
+	totalProducerOffset := int64(0)
 
 	for topic, partitionsOffsets := range producerOffsets {
-		for partition := range partitionsOffsets {
+		for partition, producerOffset := range partitionsOffsets {
 			lag, lagWithPersistent, err := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets)
 			if err != nil {
 				return 0, 0, err
 			}
 			totalLag += lag
 			totalLagWithPersistent += lagWithPersistent
+			totalProducerOffset += producerOffset
 
 			if lag > 0 {
 				partitionsWithLag++
@@ -502,7 +532,8 @@ func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, erro
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
-	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, topicPartitions, s.metadata.LagThreshold))
+	value := s.lagEvaluator.evaluate(s.metadata.LagMode, kafkaLagSample{time: time.Now(), lag: totalLag, producerOffset: totalProducerOffset})
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, %s %v, topicPartitions %v, threshold %v", totalLag, s.metadata.LagMode, value, topicPartitions, s.metadata.getLagTarget()))
 
 	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Consumer offsets %v, producer offsets %v", consumerOffsets, producerOffsets))
 
@@ -513,11 +544,11 @@ func (s *apacheKafkaScaler) getTotalLag(ctx context.Context) (int64, int64, erro
 			upperBound = partitionsWithLag
 		}
 
-		if (totalLag / s.metadata.LagThreshold) > upperBound {
-			totalLag = upperBound * s.metadata.LagThreshold
+		if target := s.metadata.getLagTarget(); math.Floor(value/target) > float64(upperBound) {
+			value = float64(upperBound) * target
 		}
 	}
-	return totalLag, totalLagWithPersistent, nil
+	return value, totalLagWithPersistent, nil
 }
 
 // getProducerOffsets returns the latest offsets for the given topic partitions
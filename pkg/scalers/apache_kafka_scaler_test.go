@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -121,6 +122,16 @@ var parseApacheKafkaMetadataTestDataset = []parseApacheKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topics", "allowIdleConsumers": "true", "limitToPartitionsWithLag": "false"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topics"}, nil, offsetResetPolicy("latest"), true, false, false},
 	// failure, topic must be specified when limitToPartitionsWithLag is true
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "limitToPartitionsWithLag": "true"}, true, 1, []string{"foobar:9092"}, "my-group", nil, nil, offsetResetPolicy("latest"), false, false, true},
+	// success, lagRatio mode
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagRatio", "lagRatioThreshold": "12.5"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}, nil, offsetResetPolicy("latest"), false, false, false},
+	// success, lagTrend mode
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagTrend", "lagEvaluationWindowSeconds": "60"}, false, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}, nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagMode is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "notvalid"}, true, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}, nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagRatioThreshold is not positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagRatio", "lagRatioThreshold": "0"}, true, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}, nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagEvaluationWindowSeconds is not positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagTrend", "lagEvaluationWindowSeconds": "0"}, true, 1, []string{"foobar:9092"}, "my-group", []string{"my-topic"}, nil, offsetResetPolicy("latest"), false, false, false},
 }
 
 var parseApacheKafkaAuthParamsTestDataset = []parseApacheKafkaAuthParamsTestData{
@@ -364,7 +375,7 @@ func TestApacheKafkaGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockKafkaScaler := apacheKafkaScaler{"", meta, nil, logr.Discard(), make(map[string]map[int]int64)}
+		mockKafkaScaler := apacheKafkaScaler{"", meta, nil, logr.Discard(), make(map[string]map[int]int64), newKafkaLagEvaluator(time.Duration(meta.LagEvaluationWindowSeconds) * time.Second)}
 
 		metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
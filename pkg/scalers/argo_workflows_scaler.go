@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var argoWorkflowListGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "WorkflowList"}
+
+// argoWorkflowsDefaultPhases are the phases of the workflows which haven't completed yet
+var argoWorkflowsDefaultPhases = []string{"Pending", "Running"}
+
+type argoWorkflowsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *argoWorkflowsMetadata
+	kubeClient client.Client
+	logger     logr.Logger
+}
+
+type argoWorkflowsMetadata struct {
+	LabelSelector   string   `keda:"name=labelSelector,   order=triggerMetadata, optional"`
+	Phases          []string `keda:"name=phases,          order=triggerMetadata, optional"`
+	Semaphore       string   `keda:"name=semaphore,       order=triggerMetadata, optional"`
+	Namespace       string   `keda:"name=namespace,       order=triggerMetadata, optional"`
+	Value           float64  `keda:"name=value,           order=triggerMetadata, optional, default=5"`
+	ActivationValue float64  `keda:"name=activationValue, order=triggerMetadata, optional"`
+
+	triggerIndex  int
+	labelSelector labels.Selector
+	// semaphoreConfigMap and semaphoreKey reference the key of the ConfigMap holding the limit of the semaphore
+	semaphoreConfigMap string
+	semaphoreKey       string
+}
+
+func (m *argoWorkflowsMetadata) Validate() error {
+	if m.Value <= 0 {
+		return errors.New("value must be a float greater than 0")
+	}
+
+	selector, err := labels.Parse(m.LabelSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing label selector: %w", err)
+	}
+	m.labelSelector = selector
+
+	if len(m.Phases) == 0 {
+		m.Phases = argoWorkflowsDefaultPhases
+	}
+
+	if m.Semaphore != "" {
+		configMap, key, found := strings.Cut(m.Semaphore, "/")
+		if !found || configMap == "" || key == "" {
+			return fmt.Errorf("semaphore must be of the form <configmap name>/<key>, got %q", m.Semaphore)
+		}
+		m.semaphoreConfigMap, m.semaphoreKey = configMap, key
+	}
+	return nil
+}
+
+// NewArgoWorkflowsScaler creates a new argoWorkflowsScaler
+func NewArgoWorkflowsScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseArgoWorkflowsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing argo workflows metadata: %w", err)
+	}
+
+	return &argoWorkflowsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		logger:     InitializeLogger(config, "argo_workflows_scaler"),
+	}, nil
+}
+
+func parseArgoWorkflowsMetadata(config *scalersconfig.ScalerConfig) (*argoWorkflowsMetadata, error) {
+	meta := &argoWorkflowsMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if meta.Namespace == "" {
+		meta.Namespace = config.ScalableObjectNamespace
+	}
+	return meta, nil
+}
+
+func (s *argoWorkflowsScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *argoWorkflowsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("argo-workflows-%s", s.metadata.Namespace))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of workflows in the phases
+func (s *argoWorkflowsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	workflows, err := s.getWorkflowCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error counting argo workflows: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(workflows))
+	return []external_metrics.ExternalMetricValue{metric}, float64(workflows) > s.metadata.ActivationValue, nil
+}
+
+func (s *argoWorkflowsScaler) getWorkflowCount(ctx context.Context) (int64, error) {
+	workflowList := &unstructured.UnstructuredList{}
+	workflowList.SetGroupVersionKind(argoWorkflowListGVK)
+	listOptions := client.ListOptions{
+		LabelSelector: s.metadata.labelSelector,
+		Namespace:     s.metadata.Namespace,
+	}
+	if err := s.kubeClient.List(ctx, workflowList, &listOptions); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, workflow := range workflowList.Items {
+		// a workflow which hasn't been picked up by the controller yet doesn't have a phase
+		phase, _, _ := unstructured.NestedString(workflow.Object, "status", "phase")
+		if phase == "" {
+			phase = "Pending"
+		}
+		if !slices.Contains(s.metadata.Phases, phase) {
+			continue
+		}
+		if s.metadata.Semaphore != "" && !s.usesSemaphore(workflow) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// usesSemaphore returns whether the workflow is synchronized with the semaphore, either
+// with the single semaphore of spec.synchronization or one of its list of semaphores
+func (s *argoWorkflowsScaler) usesSemaphore(workflow unstructured.Unstructured) bool {
+	semaphores, _, _ := unstructured.NestedSlice(workflow.Object, "spec", "synchronization", "semaphores")
+	if semaphore, found, _ := unstructured.NestedMap(workflow.Object, "spec", "synchronization", "semaphore"); found {
+		semaphores = append(semaphores, semaphore)
+	}
+
+	for _, semaphore := range semaphores {
+		semaphoreMap, ok := semaphore.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(semaphoreMap, "configMapKeyRef", "name")
+		key, _, _ := unstructured.NestedString(semaphoreMap, "configMapKeyRef", "key")
+		if name == s.metadata.semaphoreConfigMap && key == s.metadata.semaphoreKey {
+			return true
+		}
+	}
+	return false
+}
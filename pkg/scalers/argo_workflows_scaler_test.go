@@ -0,0 +1,128 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseArgoWorkflowsMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type argoWorkflowsMetricIdentifier struct {
+	metadataTestData *parseArgoWorkflowsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testArgoWorkflowsMetadata = []parseArgoWorkflowsMetadataTestData{
+	{map[string]string{}, false, "nothing passed"},
+	{map[string]string{"labelSelector": "workflows.argoproj.io/workflow-template=etl", "value": "2"}, false, "label selector"},
+	{map[string]string{"namespace": "argo", "phases": "Pending", "semaphore": "semaphores/etl", "activationValue": "1"}, false, "all metadata"},
+	{map[string]string{"labelSelector": "app in (", "value": "2"}, true, "invalid label selector"},
+	{map[string]string{"semaphore": "etl"}, true, "semaphore without key"},
+	{map[string]string{"value": "0"}, true, "zero value"},
+}
+
+var argoWorkflowsMetricIdentifiers = []argoWorkflowsMetricIdentifier{
+	{&testArgoWorkflowsMetadata[1], 0, "s0-argo-workflows-default"},
+	{&testArgoWorkflowsMetadata[2], 1, "s1-argo-workflows-argo"},
+}
+
+func TestArgoWorkflowsParseMetadata(t *testing.T) {
+	for _, testData := range testArgoWorkflowsMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseArgoWorkflowsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestArgoWorkflowsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range argoWorkflowsMetricIdentifiers {
+		meta, err := parseArgoWorkflowsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "default", TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockArgoWorkflowsScaler := argoWorkflowsScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockArgoWorkflowsScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func createArgoWorkflow(name, namespace, template, phase string, synchronization map[string]interface{}) client.Object {
+	workflow := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{},
+	}}
+	workflow.SetGroupVersionKind(argoWorkflowListGVK.GroupVersion().WithKind("Workflow"))
+	workflow.SetName(name)
+	workflow.SetNamespace(namespace)
+	workflow.SetLabels(map[string]string{"workflows.argoproj.io/workflow-template": template})
+	if phase != "" {
+		workflow.Object["status"] = map[string]interface{}{"phase": phase}
+	}
+	if synchronization != nil {
+		workflow.Object["spec"] = map[string]interface{}{"synchronization": synchronization}
+	}
+	return workflow
+}
+
+func argoSemaphore(configMap, key string) map[string]interface{} {
+	return map[string]interface{}{"configMapKeyRef": map[string]interface{}{"name": configMap, "key": key}}
+}
+
+func TestArgoWorkflowsGetMetricsAndActivity(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createArgoWorkflow("etl-1", "default", "etl", "Running", map[string]interface{}{"semaphore": argoSemaphore("semaphores", "etl")}),
+		createArgoWorkflow("etl-2", "default", "etl", "Pending", map[string]interface{}{"semaphores": []interface{}{argoSemaphore("semaphores", "other"), argoSemaphore("semaphores", "etl")}}),
+		createArgoWorkflow("etl-3", "default", "etl", "", nil),
+		createArgoWorkflow("etl-4", "default", "etl", "Succeeded", map[string]interface{}{"semaphore": argoSemaphore("semaphores", "etl")}),
+		createArgoWorkflow("report-1", "default", "report", "Running", map[string]interface{}{"semaphore": argoSemaphore("semaphores", "report")}),
+		createArgoWorkflow("etl-5", "other", "etl", "Running", nil),
+	).Build()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"all pending and running workflows", map[string]string{"activationValue": "3"}, 4, true},
+		{"workflows matching the label selector", map[string]string{"labelSelector": "workflows.argoproj.io/workflow-template=etl", "activationValue": "3"}, 3, false},
+		{"pending workflows", map[string]string{"phases": "Pending"}, 2, true},
+		{"workflows using the semaphore", map[string]string{"semaphore": "semaphores/etl"}, 2, true},
+		{"workflows of another namespace", map[string]string{"namespace": "other"}, 1, true},
+		{"no workflow in the phases", map[string]string{"phases": "Failed"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewArgoWorkflowsScaler(kubeClient, &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, ScalableObjectNamespace: "default"})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "argo-workflows")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,13 +19,30 @@ import (
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
+const (
+	// awsCloudwatchMaxMetricDataQueries is the maximum number of queries of a GetMetricData request
+	awsCloudwatchMaxMetricDataQueries = 500
+
+	awsCloudwatchValueQueryID = "c1"
+	awsCloudwatchBandQueryID  = "ad1"
+
+	awsCloudwatchDeviationLower = "lower"
+	awsCloudwatchDeviationBoth  = "both"
+)
+
 type awsCloudwatchScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *awsCloudwatchMetadata
-	cwClient   cloudwatch.GetMetricDataAPIClient
+	cwClient   awsCloudwatchClient
 	logger     logr.Logger
 }
 
+// awsCloudwatchClient is the part of the cloudwatch client used by the scaler
+type awsCloudwatchClient interface {
+	cloudwatch.GetMetricDataAPIClient
+	cloudwatch.ListMetricsAPIClient
+}
+
 type awsCloudwatchMetadata struct {
 	awsAuthorization awsutils.AuthorizationMetadata
 
@@ -45,6 +64,15 @@ type awsCloudwatchMetadata struct {
 	MetricStatPeriod     int64  `keda:"name=metricStatPeriod, order=triggerMetadata, optional, default=300"`
 	MetricEndTimeOffset  int64  `keda:"name=metricEndTimeOffset, order=triggerMetadata, optional, default=0"`
 
+	// DimensionAggregation is how the metrics matching the dimension values with a * wildcard are combined, sum by default
+	DimensionAggregation string `keda:"name=dimensionAggregation, order=triggerMetadata, enum=sum;max;min;avg, optional"`
+
+	// AnomalyDetectionBandWidth is the number of standard deviations of the ANOMALY_DETECTION_BAND of the metric,
+	// the scaler scaling on the deviation of the metric from the band rather than on the metric when it is set
+	AnomalyDetectionBandWidth float64 `keda:"name=anomalyDetectionBandWidth, order=triggerMetadata, optional"`
+	// AnomalyDetectionDeviation is the side of the band the deviation is measured from, the upper one by default
+	AnomalyDetectionDeviation string `keda:"name=anomalyDetectionDeviation, order=triggerMetadata, enum=upper;lower;both, optional"`
+
 	AwsRegion   string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
 	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
 }
@@ -75,6 +103,15 @@ func (a *awsCloudwatchMetadata) Validate() error {
 		if err = checkMetricUnit(a.MetricUnit); err != nil {
 			return err
 		}
+	} else if a.DimensionAggregation != "" {
+		return errors.New("dimensionAggregation can't be used with expression")
+	}
+
+	if a.AnomalyDetectionBandWidth < 0 {
+		return errors.New("anomalyDetectionBandWidth must be greater than 0")
+	}
+	if a.AnomalyDetectionDeviation != "" && a.AnomalyDetectionBandWidth == 0 {
+		return errors.New("anomalyDetectionDeviation requires anomalyDetectionBandWidth")
 	}
 
 	if err = checkMetricStat(a.MetricStat); err != nil {
@@ -219,58 +256,31 @@ func (s *awsCloudwatchScaler) Close(context.Context) error {
 }
 
 func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64, error) {
-	var input cloudwatch.GetMetricDataInput
-
 	startTime, endTime := computeQueryWindow(time.Now(), s.metadata.MetricStatPeriod, s.metadata.MetricEndTimeOffset, s.metadata.MetricCollectionTime)
 
-	if s.metadata.Expression != "" {
-		input = cloudwatch.GetMetricDataInput{
-			StartTime: aws.Time(startTime),
-			EndTime:   aws.Time(endTime),
-			ScanBy:    types.ScanByTimestampDescending,
-			MetricDataQueries: []types.MetricDataQuery{
-				{
-					Expression: aws.String(s.metadata.Expression),
-					Id:         aws.String("q1"),
-					Period:     aws.Int32(int32(s.metadata.MetricStatPeriod)),
-				},
-			},
-		}
-	} else {
-		var dimensions []types.Dimension
-		for i := range s.metadata.DimensionName {
-			dimensions = append(dimensions, types.Dimension{
-				Name:  &s.metadata.DimensionName[i],
-				Value: &s.metadata.DimensionValue[i],
-			})
-		}
+	queries, valueQueryID, err := s.getMetricDataQueries(ctx)
+	if err != nil {
+		s.logger.Error(err, "Failed to get metric data queries")
+		return -1, err
+	}
+	if len(queries) == 0 {
+		s.logger.Info("no metric matching the dimensions, returning minMetricValue")
+		return s.metadata.MinMetricValue, nil
+	}
 
-		var metricUnit string
-		if s.metadata.MetricUnit != "" {
-			metricUnit = s.metadata.MetricUnit
-		}
+	if s.metadata.AnomalyDetectionBandWidth > 0 {
+		queries = append(queries, types.MetricDataQuery{
+			Expression: aws.String(fmt.Sprintf("ANOMALY_DETECTION_BAND(%s, %g)", valueQueryID, s.metadata.AnomalyDetectionBandWidth)),
+			Id:         aws.String(awsCloudwatchBandQueryID),
+			ReturnData: aws.Bool(true),
+		})
+	}
 
-		input = cloudwatch.GetMetricDataInput{
-			StartTime: aws.Time(startTime),
-			EndTime:   aws.Time(endTime),
-			ScanBy:    types.ScanByTimestampDescending,
-			MetricDataQueries: []types.MetricDataQuery{
-				{
-					Id: aws.String("c1"),
-					MetricStat: &types.MetricStat{
-						Metric: &types.Metric{
-							Namespace:  aws.String(s.metadata.Namespace),
-							Dimensions: dimensions,
-							MetricName: aws.String(s.metadata.MetricsName),
-						},
-						Period: aws.Int32(int32(s.metadata.MetricStatPeriod)),
-						Stat:   aws.String(s.metadata.MetricStat),
-						Unit:   types.StandardUnit(metricUnit),
-					},
-					ReturnData: aws.Bool(true),
-				},
-			},
-		}
+	input := cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(startTime),
+		EndTime:           aws.Time(endTime),
+		ScanBy:            types.ScanByTimestampDescending,
+		MetricDataQueries: queries,
 	}
 
 	output, err := s.cwClient.GetMetricData(ctx, &input)
@@ -281,9 +291,26 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 
 	s.logger.V(1).Info("Received Metric Data", "data", output)
 
+	results := output.MetricDataResults
+	var bandResults []types.MetricDataResult
+	if s.metadata.AnomalyDetectionBandWidth > 0 {
+		results = nil
+		for _, result := range output.MetricDataResults {
+			if aws.ToString(result.Id) == awsCloudwatchBandQueryID {
+				bandResults = append(bandResults, result)
+			} else if aws.ToString(result.Id) == valueQueryID {
+				results = append(results, result)
+			}
+		}
+		// the model of the anomaly detector has no band yet, e.g. while it is trained
+		if len(results) > 0 && len(results[0].Values) > 0 && !hasMetricDataValues(bandResults) {
+			results[0].Values = nil
+		}
+	}
+
 	// If no metric data results or the first result has no values, and ignoreNullValues is false,
 	// the scaler should return an error to prevent any further scaling actions.
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) == 0 && !s.metadata.IgnoreNullValues {
+	if len(results) > 0 && len(results[0].Values) == 0 && !s.metadata.IgnoreNullValues {
 		emptyMetricsErrMsg := "empty metric data received, ignoreNullValues is false, returning error"
 		s.logger.Error(nil, emptyMetricsErrMsg)
 		return -1, fmt.Errorf("%s", emptyMetricsErrMsg)
@@ -291,11 +318,195 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 
 	var metricValue float64
 
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) > 0 {
-		metricValue = output.MetricDataResults[0].Values[0]
+	if len(results) > 0 && len(results[0].Values) > 0 {
+		metricValue = results[0].Values[0]
+		if s.metadata.AnomalyDetectionBandWidth > 0 {
+			metricValue = s.getAnomalyDeviation(metricValue, bandResults)
+		}
 	} else {
 		s.logger.Info("empty metric data received, returning minMetricValue")
 		metricValue = s.metadata.MinMetricValue
 	}
 	return metricValue, nil
 }
+
+// getMetricDataQueries returns the queries of the metric, and the id of the query of its value. The dimension values
+// with a * wildcard are expanded to the metrics matching them, combined with the dimensionAggregation, and no query
+// is returned when no metric matches them.
+func (s *awsCloudwatchScaler) getMetricDataQueries(ctx context.Context) ([]types.MetricDataQuery, string, error) {
+	if s.metadata.Expression != "" {
+		return []types.MetricDataQuery{
+			{
+				Expression: aws.String(s.metadata.Expression),
+				Id:         aws.String("q1"),
+				Period:     aws.Int32(int32(s.metadata.MetricStatPeriod)),
+			},
+		}, "q1", nil
+	}
+
+	if !s.metadata.hasDimensionWildcards() {
+		var dimensions []types.Dimension
+		for i := range s.metadata.DimensionName {
+			dimensions = append(dimensions, types.Dimension{
+				Name:  &s.metadata.DimensionName[i],
+				Value: &s.metadata.DimensionValue[i],
+			})
+		}
+		return []types.MetricDataQuery{
+			{
+				Id:         aws.String(awsCloudwatchValueQueryID),
+				MetricStat: s.getMetricStat(dimensions),
+				ReturnData: aws.Bool(true),
+			},
+		}, awsCloudwatchValueQueryID, nil
+	}
+
+	metrics, err := s.listMetricsMatchingDimensions(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(metrics) == 0 {
+		return nil, "", nil
+	}
+	// one query per metric, the query of the aggregation and the one of the band
+	if len(metrics) > awsCloudwatchMaxMetricDataQueries-2 {
+		return nil, "", fmt.Errorf("%d metrics match the dimensions, more than the %d that can be aggregated", len(metrics), awsCloudwatchMaxMetricDataQueries-2)
+	}
+
+	queries := make([]types.MetricDataQuery, 0, len(metrics)+1)
+	for i, metric := range metrics {
+		queries = append(queries, types.MetricDataQuery{
+			Id:         aws.String(fmt.Sprintf("m%d", i)),
+			MetricStat: s.getMetricStat(metric.Dimensions),
+			ReturnData: aws.Bool(false),
+		})
+	}
+	aggregation := s.metadata.DimensionAggregation
+	if aggregation == "" {
+		aggregation = "sum"
+	}
+	queries = append(queries, types.MetricDataQuery{
+		Expression: aws.String(fmt.Sprintf("%s(METRICS(\"m\"))", strings.ToUpper(aggregation))),
+		Id:         aws.String(awsCloudwatchValueQueryID),
+		Period:     aws.Int32(int32(s.metadata.MetricStatPeriod)),
+		ReturnData: aws.Bool(true),
+	})
+	return queries, awsCloudwatchValueQueryID, nil
+}
+
+func (s *awsCloudwatchScaler) getMetricStat(dimensions []types.Dimension) *types.MetricStat {
+	return &types.MetricStat{
+		Metric: &types.Metric{
+			Namespace:  aws.String(s.metadata.Namespace),
+			Dimensions: dimensions,
+			MetricName: aws.String(s.metadata.MetricsName),
+		},
+		Period: aws.Int32(int32(s.metadata.MetricStatPeriod)),
+		Stat:   aws.String(s.metadata.MetricStat),
+		Unit:   types.StandardUnit(s.metadata.MetricUnit),
+	}
+}
+
+// listMetricsMatchingDimensions lists the recently active metrics having exactly the dimensions, with values matching
+// the dimension values and their * wildcards
+func (s *awsCloudwatchScaler) listMetricsMatchingDimensions(ctx context.Context) ([]types.Metric, error) {
+	filters := make([]types.DimensionFilter, 0, len(s.metadata.DimensionName))
+	patterns := make(map[string]*regexp.Regexp, len(s.metadata.DimensionName))
+	for i, name := range s.metadata.DimensionName {
+		value := s.metadata.DimensionValue[i]
+		filter := types.DimensionFilter{Name: aws.String(name)}
+		if strings.Contains(value, "*") {
+			patterns[name] = dimensionWildcardRegexp(value)
+		} else {
+			filter.Value = aws.String(value)
+		}
+		filters = append(filters, filter)
+	}
+
+	var metrics []types.Metric
+	paginator := cloudwatch.NewListMetricsPaginator(s.cwClient, &cloudwatch.ListMetricsInput{
+		Namespace:      aws.String(s.metadata.Namespace),
+		MetricName:     aws.String(s.metadata.MetricsName),
+		Dimensions:     filters,
+		RecentlyActive: types.RecentlyActivePt3h,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing the metrics matching the dimensions: %w", err)
+		}
+		for _, metric := range page.Metrics {
+			if matchesDimensionWildcards(metric.Dimensions, len(filters), patterns) {
+				metrics = append(metrics, metric)
+			}
+		}
+	}
+	return metrics, nil
+}
+
+func (a *awsCloudwatchMetadata) hasDimensionWildcards() bool {
+	for _, value := range a.DimensionValue {
+		if strings.Contains(value, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// dimensionWildcardRegexp returns the regexp of a dimension value, whose * match any sequence of characters
+func dimensionWildcardRegexp(value string) *regexp.Regexp {
+	parts := strings.Split(value, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+func matchesDimensionWildcards(dimensions []types.Dimension, count int, patterns map[string]*regexp.Regexp) bool {
+	// the metrics with more dimensions than the ones given are different metrics
+	if len(dimensions) != count {
+		return false
+	}
+	for _, dimension := range dimensions {
+		if pattern, found := patterns[aws.ToString(dimension.Name)]; found && !pattern.MatchString(aws.ToString(dimension.Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasMetricDataValues(results []types.MetricDataResult) bool {
+	for _, result := range results {
+		if len(result.Values) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getAnomalyDeviation returns how far the value is outside of the anomaly detection band, 0 when it is inside the
+// band. The band is made of the lower and upper bound series, whose latest values are compared to the value.
+func (s *awsCloudwatchScaler) getAnomalyDeviation(value float64, bandResults []types.MetricDataResult) float64 {
+	var bounds []float64
+	for _, result := range bandResults {
+		if len(result.Values) > 0 {
+			bounds = append(bounds, result.Values[0])
+		}
+	}
+	if len(bounds) == 0 {
+		return 0
+	}
+	lower, upper := bounds[0], bounds[0]
+	for _, bound := range bounds[1:] {
+		lower, upper = min(lower, bound), max(upper, bound)
+	}
+
+	switch s.metadata.AnomalyDetectionDeviation {
+	case awsCloudwatchDeviationLower:
+		return max(0, lower-value)
+	case awsCloudwatchDeviationBoth:
+		return max(0, value-upper, lower-value)
+	default:
+		return max(0, value-upper)
+	}
+}
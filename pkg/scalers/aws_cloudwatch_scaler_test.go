@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/go-logr/logr"
@@ -494,6 +495,97 @@ var testAWSCloudwatchMetadata = []parseAWSCloudwatchMetadataTestData{
 		false,
 		"Multiple dimensions with valid separator",
 	},
+	// anomaly detection band
+	{
+		map[string]string{
+			"namespace":                 "AWS/SQS",
+			"dimensionName":             "QueueName",
+			"dimensionValue":            "keda",
+			"metricName":                "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":         "5",
+			"minMetricValue":            "0",
+			"anomalyDetectionBandWidth": "2",
+			"anomalyDetectionDeviation": "both",
+			"awsRegion":                 "us-west-2",
+		},
+		testAWSAuthentication,
+		false,
+		"anomaly detection band",
+	},
+	{
+		map[string]string{
+			"namespace":                 "AWS/SQS",
+			"dimensionName":             "QueueName",
+			"dimensionValue":            "keda",
+			"metricName":                "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":         "5",
+			"minMetricValue":            "0",
+			"anomalyDetectionBandWidth": "-1",
+			"awsRegion":                 "us-west-2",
+		},
+		testAWSAuthentication,
+		true,
+		"negative anomalyDetectionBandWidth",
+	},
+	{
+		map[string]string{
+			"namespace":                 "AWS/SQS",
+			"dimensionName":             "QueueName",
+			"dimensionValue":            "keda",
+			"metricName":                "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":         "5",
+			"minMetricValue":            "0",
+			"anomalyDetectionDeviation": "upper",
+			"awsRegion":                 "us-west-2",
+		},
+		testAWSAuthentication,
+		true,
+		"anomalyDetectionDeviation without anomalyDetectionBandWidth",
+	},
+	{
+		map[string]string{
+			"namespace":                 "AWS/SQS",
+			"dimensionName":             "QueueName",
+			"dimensionValue":            "keda",
+			"metricName":                "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":         "5",
+			"minMetricValue":            "0",
+			"anomalyDetectionBandWidth": "2",
+			"anomalyDetectionDeviation": "above",
+			"awsRegion":                 "us-west-2",
+		},
+		testAWSAuthentication,
+		true,
+		"unknown anomalyDetectionDeviation",
+	},
+	// dimension values with wildcards
+	{
+		map[string]string{
+			"namespace":            "AWS/SQS",
+			"dimensionName":        "QueueName",
+			"dimensionValue":       "orders-*",
+			"metricName":           "ApproximateNumberOfMessagesVisible",
+			"targetMetricValue":    "5",
+			"minMetricValue":       "0",
+			"dimensionAggregation": "max",
+			"awsRegion":            "us-west-2",
+		},
+		testAWSAuthentication,
+		false,
+		"dimension value with wildcard",
+	},
+	{
+		map[string]string{
+			"expression":           "SELECT MIN(MessageCount) FROM \"AWS/AmazonMQ\"",
+			"targetMetricValue":    "5",
+			"minMetricValue":       "0",
+			"dimensionAggregation": "max",
+			"awsRegion":            "us-west-2",
+		},
+		testAWSAuthentication,
+		true,
+		"dimensionAggregation with expression",
+	},
 }
 
 var awsCloudwatchMetricIdentifiers = []awsCloudwatchMetricIdentifier{
@@ -604,6 +696,32 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 type mockCloudwatch struct {
 }
 
+func (m *mockCloudwatch) ListMetrics(_ context.Context, input *cloudwatch.ListMetricsInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.ListMetricsOutput, error) {
+	metric := func(dimensions ...string) types.Metric {
+		result := types.Metric{Namespace: input.Namespace, MetricName: input.MetricName}
+		for i := 0; i < len(dimensions); i += 2 {
+			result.Dimensions = append(result.Dimensions, types.Dimension{Name: aws.String(dimensions[i]), Value: aws.String(dimensions[i+1])})
+		}
+		return result
+	}
+
+	if input.NextToken == nil {
+		return &cloudwatch.ListMetricsOutput{
+			Metrics: []types.Metric{
+				metric("QueueName", "orders-eu", "Region", "eu-west-1"),
+				metric("QueueName", "payments", "Region", "eu-west-1"),
+			},
+			NextToken: aws.String("page2"),
+		}, nil
+	}
+	return &cloudwatch.ListMetricsOutput{
+		Metrics: []types.Metric{
+			metric("QueueName", "orders-us", "Region", "eu-west-1"),
+			metric("QueueName", "orders-us", "Region", "eu-west-1", "Priority", "high"),
+		},
+	}, nil
+}
+
 func (m *mockCloudwatch) GetMetricData(_ context.Context, input *cloudwatch.GetMetricDataInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
 	if input.MetricDataQueries[0].MetricStat != nil {
 		switch *input.MetricDataQueries[0].MetricStat.Metric.MetricName {
@@ -715,6 +833,105 @@ func TestAWSCloudwatchScalerGetMetrics(t *testing.T) {
 	}
 }
 
+func TestAWSCloudwatchScalerDimensionWildcards(t *testing.T) {
+	meta := &awsCloudwatchMetadata{
+		Namespace:            "AWS/SQS",
+		MetricsName:          "ApproximateNumberOfMessagesVisible",
+		DimensionName:        []string{"QueueName", "Region"},
+		DimensionValue:       []string{"orders-*", "eu-west-1"},
+		DimensionAggregation: "max",
+		MetricStat:           "Average",
+		MetricStatPeriod:     60,
+	}
+	mockAWSCloudwatchScaler := awsCloudwatchScaler{"", meta, &mockCloudwatch{}, logr.Discard()}
+
+	queries, valueQueryID, err := mockAWSCloudwatchScaler.getMetricDataQueries(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, awsCloudwatchValueQueryID, valueQueryID)
+	if assert.Len(t, queries, 3) {
+		assert.Equal(t, "m0", aws.ToString(queries[0].Id))
+		assert.Equal(t, "orders-eu", aws.ToString(queries[0].MetricStat.Metric.Dimensions[0].Value))
+		assert.Equal(t, "m1", aws.ToString(queries[1].Id))
+		assert.Equal(t, "orders-us", aws.ToString(queries[1].MetricStat.Metric.Dimensions[0].Value))
+		assert.False(t, aws.ToBool(queries[1].ReturnData))
+		assert.Equal(t, `MAX(METRICS("m"))`, aws.ToString(queries[2].Expression))
+		assert.True(t, aws.ToBool(queries[2].ReturnData))
+	}
+
+	meta.DimensionValue = []string{"refunds-*", "eu-west-1"}
+	queries, _, err = mockAWSCloudwatchScaler.getMetricDataQueries(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, queries)
+}
+
+type mockCloudwatchAnomalyDetection struct {
+	mockCloudwatch
+	value float64
+	band  []float64
+}
+
+func (m *mockCloudwatchAnomalyDetection) GetMetricData(_ context.Context, input *cloudwatch.GetMetricDataInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	band := input.MetricDataQueries[len(input.MetricDataQueries)-1]
+	if aws.ToString(band.Expression) != "ANOMALY_DETECTION_BAND(c1, 2)" {
+		return nil, errors.New("unexpected anomaly detection band expression")
+	}
+
+	output := &cloudwatch.GetMetricDataOutput{
+		MetricDataResults: []types.MetricDataResult{
+			{Id: aws.String(awsCloudwatchValueQueryID), Values: []float64{m.value}},
+		},
+	}
+	for _, bound := range m.band {
+		output.MetricDataResults = append(output.MetricDataResults, types.MetricDataResult{Id: aws.String(awsCloudwatchBandQueryID), Values: []float64{bound}})
+	}
+	return output, nil
+}
+
+func TestAWSCloudwatchScalerAnomalyDetection(t *testing.T) {
+	testCases := []struct {
+		deviation        string
+		value            float64
+		band             []float64
+		ignoreNullValues bool
+		expected         float64
+		isError          bool
+	}{
+		{"", 10, []float64{2, 6}, true, 4, false},
+		{"upper", 4, []float64{6, 2}, true, 0, false},
+		{"lower", 10, []float64{12, 20}, true, 2, false},
+		{"lower", 10, []float64{2, 6}, true, 0, false},
+		{"both", 25, []float64{12, 20}, true, 5, false},
+		{"both", 9, []float64{12, 20}, true, 3, false},
+		{"both", 10, nil, true, 1, false},
+		{"both", 10, nil, false, 0, true},
+	}
+
+	for _, tc := range testCases {
+		meta := &awsCloudwatchMetadata{
+			Namespace:                 "AWS/SQS",
+			MetricsName:               "ApproximateNumberOfMessagesVisible",
+			DimensionName:             []string{"QueueName"},
+			DimensionValue:            []string{"keda"},
+			MinMetricValue:            1,
+			IgnoreNullValues:          tc.ignoreNullValues,
+			MetricStat:                "Average",
+			MetricStatPeriod:          60,
+			MetricCollectionTime:      300,
+			AnomalyDetectionBandWidth: 2,
+			AnomalyDetectionDeviation: tc.deviation,
+		}
+		mockAWSCloudwatchScaler := awsCloudwatchScaler{"", meta, &mockCloudwatchAnomalyDetection{value: tc.value, band: tc.band}, logr.Discard()}
+
+		value, err := mockAWSCloudwatchScaler.GetCloudwatchMetrics(context.Background())
+		if tc.isError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, value, "deviation %q of %v from %v", tc.deviation, tc.value, tc.band)
+	}
+}
+
 type computeQueryWindowTestArgs struct {
 	name                    string
 	current                 string
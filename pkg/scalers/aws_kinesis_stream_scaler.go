@@ -3,8 +3,11 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/kinesis"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -18,12 +21,19 @@ import (
 const (
 	targetShardCountDefault           = 2
 	activationTargetShardCountDefault = 0
+
+	awsKinesisMetricIteratorAge = "iteratorAge"
+
+	// awsKinesisIteratorAgeWindow is how far back the latest iterator age is looked for, as Kinesis publishes
+	// its metrics to CloudWatch every minute
+	awsKinesisIteratorAgeWindow = 5 * time.Minute
 )
 
 type awsKinesisStreamScaler struct {
 	metricType           v2.MetricTargetType
 	metadata             *awsKinesisStreamMetadata
 	kinesisWrapperClient KinesisWrapperClient
+	cwClient             cloudwatch.GetMetricDataAPIClient
 	logger               logr.Logger
 }
 
@@ -45,8 +55,29 @@ type awsKinesisStreamMetadata struct {
 	StreamName                 string `keda:"name=streamName, order=triggerMetadata"`
 	AwsRegion                  string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
 	AwsEndpoint                string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
-	awsAuthorization           awsutils.AuthorizationMetadata
-	triggerIndex               int
+	// Metric iteratorAge scales on how far behind the latest record the consumer is, in milliseconds, instead of
+	// on the open shards
+	Metric                string `keda:"name=metric, order=triggerMetadata, enum=shardCount;iteratorAge, optional"`
+	TargetIteratorAge     int64  `keda:"name=iteratorAge, order=triggerMetadata, optional"`
+	ActivationIteratorAge int64  `keda:"name=activationIteratorAge, order=triggerMetadata, optional"`
+	// ConsumerName is the enhanced fan-out consumer whose iterator age is scaled on, otherwise the iterator age
+	// is the one of the GetRecords of the shared throughput consumers
+	ConsumerName     string `keda:"name=consumerName, order=triggerMetadata, optional"`
+	awsAuthorization awsutils.AuthorizationMetadata
+	triggerIndex     int
+}
+
+func (m *awsKinesisStreamMetadata) Validate() error {
+	if m.Metric != awsKinesisMetricIteratorAge {
+		if m.ConsumerName != "" {
+			return fmt.Errorf("consumerName is only supported with metric %s", awsKinesisMetricIteratorAge)
+		}
+		return nil
+	}
+	if m.TargetIteratorAge <= 0 {
+		return fmt.Errorf("iteratorAge must be greater than 0")
+	}
+	return nil
 }
 
 // NewAwsKinesisStreamScaler creates a new awsKinesisStreamScaler
@@ -67,13 +98,22 @@ func NewAwsKinesisStreamScaler(ctx context.Context, config *scalersconfig.Scaler
 		return nil, fmt.Errorf("error creating kinesis client: %w", err)
 	}
 
+	var cwClient cloudwatch.GetMetricDataAPIClient
+	if meta.Metric == awsKinesisMetricIteratorAge {
+		cwClient, err = createKinesisCloudwatchClient(ctx, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cloudwatch client: %w", err)
+		}
+	}
+
 	return &awsKinesisStreamScaler{
 		metricType: metricType,
 		metadata:   meta,
 		kinesisWrapperClient: &kinesisWrapperClient{
 			kinesisClient: awsKinesisClient,
 		},
-		logger: logger,
+		cwClient: cwClient,
+		logger:   logger,
 	}, nil
 }
 
@@ -107,17 +147,35 @@ func createKinesisClient(ctx context.Context, metadata *awsKinesisStreamMetadata
 	}), nil
 }
 
+// createKinesisCloudwatchClient returns the client of the CloudWatch metrics of the stream
+func createKinesisCloudwatchClient(ctx context.Context, metadata *awsKinesisStreamMetadata) (*cloudwatch.Client, error) {
+	cfg, err := awsutils.GetAwsConfig(ctx, metadata.awsAuthorization)
+	if err != nil {
+		return nil, err
+	}
+	return cloudwatch.NewFromConfig(*cfg), nil
+}
+
 func (s *awsKinesisStreamScaler) Close(context.Context) error {
 	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
 	return nil
 }
 
 func (s *awsKinesisStreamScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("aws-kinesis-%s", s.metadata.StreamName)
+	target := s.metadata.TargetShardCount
+	if s.metadata.Metric == awsKinesisMetricIteratorAge {
+		metricName += "-iterator-age"
+		if s.metadata.ConsumerName != "" {
+			metricName += "-" + s.metadata.ConsumerName
+		}
+		target = s.metadata.TargetIteratorAge
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-kinesis-%s", s.metadata.StreamName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.TargetShardCount),
+		Target: GetMetricTarget(s.metricType, target),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -125,6 +183,18 @@ func (s *awsKinesisStreamScaler) GetMetricSpecForScaling(context.Context) []v2.M
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *awsKinesisStreamScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.Metric == awsKinesisMetricIteratorAge {
+		iteratorAge, err := s.getAwsKinesisIteratorAge(ctx)
+		if err != nil {
+			s.logger.Error(err, "Error getting iterator age")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+
+		metric := GenerateMetricInMili(metricName, iteratorAge)
+
+		return []external_metrics.ExternalMetricValue{metric}, iteratorAge > float64(s.metadata.ActivationIteratorAge), nil
+	}
+
 	shardCount, err := s.GetAwsKinesisOpenShardCount(ctx)
 
 	if err != nil {
@@ -150,3 +220,42 @@ func (s *awsKinesisStreamScaler) GetAwsKinesisOpenShardCount(ctx context.Context
 
 	return int64(*output.StreamDescriptionSummary.OpenShardCount), nil
 }
+
+// getAwsKinesisIteratorAge returns the latest maximum iterator age in milliseconds of the enhanced fan-out consumer,
+// which is its SubscribeToShardEvent.MillisBehindLatest, or the GetRecords.IteratorAgeMilliseconds of the stream.
+// A stream without datapoint has no record being consumed.
+func (s *awsKinesisStreamScaler) getAwsKinesisIteratorAge(ctx context.Context) (float64, error) {
+	metricName := "GetRecords.IteratorAgeMilliseconds"
+	dimensions := []cloudwatchtypes.Dimension{{Name: aws.String("StreamName"), Value: aws.String(s.metadata.StreamName)}}
+	if s.metadata.ConsumerName != "" {
+		metricName = "SubscribeToShardEvent.MillisBehindLatest"
+		dimensions = append(dimensions, cloudwatchtypes.Dimension{Name: aws.String("ConsumerName"), Value: aws.String(s.metadata.ConsumerName)})
+	}
+
+	endTime := time.Now()
+	output, err := s.cwClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(endTime.Add(-awsKinesisIteratorAgeWindow)),
+		EndTime:   aws.Time(endTime),
+		ScanBy:    cloudwatchtypes.ScanByTimestampDescending,
+		MetricDataQueries: []cloudwatchtypes.MetricDataQuery{{
+			Id: aws.String("c1"),
+			MetricStat: &cloudwatchtypes.MetricStat{
+				Metric: &cloudwatchtypes.Metric{
+					Namespace:  aws.String("AWS/Kinesis"),
+					MetricName: aws.String(metricName),
+					Dimensions: dimensions,
+				},
+				Period: aws.Int32(60),
+				Stat:   aws.String("Maximum"),
+			},
+		}},
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	if len(output.MetricDataResults) == 0 || len(output.MetricDataResults[0].Values) == 0 {
+		return 0, nil
+	}
+	return output.MetricDataResults[0].Values[0], nil
+}
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/kinesis"
 	"github.com/aws/aws-sdk-go-v2/service/kinesis/types"
 	"github.com/go-logr/logr"
@@ -62,6 +64,21 @@ func (m *mockKinesis) DescribeStreamSummary(_ context.Context, input *kinesis.De
 	}, nil
 }
 
+// mockKinesisCloudwatch returns the iterator ages of the metric, latest first
+type mockKinesisCloudwatch struct {
+	ages map[string][]float64
+}
+
+func (m *mockKinesisCloudwatch) GetMetricData(_ context.Context, input *cloudwatch.GetMetricDataInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	metric := input.MetricDataQueries[0].MetricStat.Metric
+	if *metric.Dimensions[0].Value == testAWSKinesisErrorStream {
+		return nil, errors.New("some error")
+	}
+	return &cloudwatch.GetMetricDataOutput{
+		MetricDataResults: []cloudwatchtypes.MetricDataResult{{Id: input.MetricDataQueries[0].Id, Values: m.ages[*metric.MetricName]}},
+	}, nil
+}
+
 var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 	{
 		metadata:   map[string]string{},
@@ -302,11 +319,61 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 		comment:      "with AWS Role assigned on KEDA operator itself",
 		triggerIndex: 8,
 	},
+	{metadata: map[string]string{
+		"streamName":   testAWSKinesisStreamName,
+		"awsRegion":    testAWSRegion,
+		"metric":       "iteratorAge",
+		"iteratorAge":  "30000",
+		"consumerName": "processor"},
+		authParams: testAWSKinesisAuthentication,
+		expected: &awsKinesisStreamMetadata{
+			TargetShardCount:  2,
+			StreamName:        testAWSKinesisStreamName,
+			AwsRegion:         testAWSRegion,
+			Metric:            "iteratorAge",
+			TargetIteratorAge: 30000,
+			ConsumerName:      "processor",
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSKinesisAccessKeyID,
+				AwsSecretAccessKey: testAWSKinesisSecretAccessKey,
+				PodIdentityOwner:   true,
+				AwsRegion:          testAWSRegion,
+			},
+			triggerIndex: 0,
+		},
+		isError: false,
+		comment: "iterator age of enhanced fan-out consumer",
+	},
+	{metadata: map[string]string{
+		"streamName": testAWSKinesisStreamName,
+		"awsRegion":  testAWSRegion,
+		"metric":     "iteratorAge"},
+		authParams: testAWSKinesisAuthentication,
+		isError:    true,
+		comment:    "iterator age without iteratorAge",
+	},
+	{metadata: map[string]string{
+		"streamName":   testAWSKinesisStreamName,
+		"awsRegion":    testAWSRegion,
+		"consumerName": "processor"},
+		authParams: testAWSKinesisAuthentication,
+		isError:    true,
+		comment:    "consumer name without iterator age",
+	},
+	{metadata: map[string]string{
+		"streamName": testAWSKinesisStreamName,
+		"awsRegion":  testAWSRegion,
+		"metric":     "records"},
+		authParams: testAWSKinesisAuthentication,
+		isError:    true,
+		comment:    "invalid metric",
+	},
 }
 
 var awsKinesisMetricIdentifiers = []awsKinesisMetricIdentifier{
 	{&testAWSKinesisMetadata[1], 0, "s0-aws-kinesis-test"},
 	{&testAWSKinesisMetadata[1], 1, "s1-aws-kinesis-test"},
+	{&testAWSKinesisMetadata[14], 2, "s2-aws-kinesis-test-iterator-age-processor"},
 }
 
 var awsKinesisGetMetricTestData = []*awsKinesisStreamMetadata{
@@ -339,7 +406,7 @@ func TestAWSKinesisGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAWSKinesisStreamScaler := awsKinesisStreamScaler{"", meta, &mockKinesis{}, logr.Discard()}
+		mockAWSKinesisStreamScaler := awsKinesisStreamScaler{"", meta, &mockKinesis{}, nil, logr.Discard()}
 
 		metricSpec := mockAWSKinesisStreamScaler.GetMetricSpecForScaling(ctx)
 		metricName := metricSpec[0].External.Metric.Name
@@ -351,7 +418,7 @@ func TestAWSKinesisGetMetricSpecForScaling(t *testing.T) {
 
 func TestAWSKinesisStreamScalerGetMetrics(t *testing.T) {
 	for _, meta := range awsKinesisGetMetricTestData {
-		scaler := awsKinesisStreamScaler{"", meta, &mockKinesis{}, logr.Discard()}
+		scaler := awsKinesisStreamScaler{"", meta, &mockKinesis{}, nil, logr.Discard()}
 		value, _, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
 		switch meta.StreamName {
 		case testAWSKinesisErrorStream:
@@ -361,3 +428,36 @@ func TestAWSKinesisStreamScalerGetMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSKinesisStreamScalerGetMetricsIteratorAge(t *testing.T) {
+	cwClient := &mockKinesisCloudwatch{ages: map[string][]float64{
+		"GetRecords.IteratorAgeMilliseconds":       {45000, 1000},
+		"SubscribeToShardEvent.MillisBehindLatest": {500},
+	}}
+
+	testCases := []struct {
+		name          string
+		meta          *awsKinesisStreamMetadata
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{"shared throughput consumers", &awsKinesisStreamMetadata{StreamName: "Good", Metric: "iteratorAge"}, 45000, true, false},
+		{"enhanced fan-out consumer", &awsKinesisStreamMetadata{StreamName: "Good", Metric: "iteratorAge", ConsumerName: "processor", ActivationIteratorAge: 1000}, 500, false, false},
+		{"error", &awsKinesisStreamMetadata{StreamName: testAWSKinesisErrorStream, Metric: "iteratorAge"}, 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaler := awsKinesisStreamScaler{"", tc.meta, &mockKinesis{}, cwClient, logr.Discard()}
+			value, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedValue, value[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
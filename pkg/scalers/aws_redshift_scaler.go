@@ -0,0 +1,285 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// awsRedshiftQueuedQueries counts the queries waiting in the WLM queues of the users, the service classes
+	// up to 5 being the ones of the system and of the superusers
+	awsRedshiftQueuedQueries = "SELECT COUNT(*) FROM stv_wlm_query_state WHERE state LIKE 'Queued%' AND service_class > 5"
+	// awsRedshiftQueueFilter restricts the queries to the ones of the WLM queue named after the parameter
+	awsRedshiftQueueFilter = " AND service_class IN (SELECT service_class FROM stv_wlm_service_class_config WHERE TRIM(name) = :queue)"
+
+	awsRedshiftStatusFinished = "FINISHED"
+	awsRedshiftStatusFailed   = "FAILED"
+	awsRedshiftStatusAborted  = "ABORTED"
+
+	awsRedshiftDefaultPollInterval = 250 * time.Millisecond
+)
+
+type awsRedshiftScaler struct {
+	metricType   v2.MetricTargetType
+	metadata     *awsRedshiftMetadata
+	awsConfig    *aws.Config
+	signer       *v4.Signer
+	httpClient   *http.Client
+	logger       logr.Logger
+	pollInterval time.Duration
+}
+
+type awsRedshiftMetadata struct {
+	triggerIndex     int
+	awsAuthorization awsutils.AuthorizationMetadata
+
+	ClusterIdentifier string `keda:"name=clusterIdentifier, order=triggerMetadata"`
+	Database          string `keda:"name=database,          order=triggerMetadata"`
+	// DbUser or SecretArn are the database user the statements are run as, which is otherwise mapped from the IAM identity
+	DbUser      string `keda:"name=dbUser,      order=triggerMetadata;authParams, optional"`
+	SecretArn   string `keda:"name=secretArn,   order=triggerMetadata;authParams, optional"`
+	AwsRegion   string `keda:"name=awsRegion,   order=triggerMetadata;authParams"`
+	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+	// QueueName restricts the queued queries to the ones of a WLM queue, which are otherwise the ones of all the queues
+	QueueName                     string  `keda:"name=queueName,                     order=triggerMetadata, optional"`
+	TargetQueuedQueries           float64 `keda:"name=targetQueuedQueries,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetQueuedQueries float64 `keda:"name=activationTargetQueuedQueries, order=triggerMetadata, optional"`
+}
+
+func (m *awsRedshiftMetadata) Validate() error {
+	if m.TargetQueuedQueries <= 0 {
+		return errors.New("targetQueuedQueries must be greater than 0")
+	}
+	if m.DbUser != "" && m.SecretArn != "" {
+		return errors.New("only one of dbUser or secretArn can be provided")
+	}
+	if m.AwsEndpoint == "" {
+		m.AwsEndpoint = fmt.Sprintf("https://redshift-data.%s.amazonaws.com", m.AwsRegion)
+	}
+	m.AwsEndpoint = strings.TrimSuffix(m.AwsEndpoint, "/")
+	return nil
+}
+
+// awsRedshiftSQLParameter is a named parameter of a statement
+type awsRedshiftSQLParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// awsRedshiftExecuteStatementInput is the request of ExecuteStatement
+type awsRedshiftExecuteStatementInput struct {
+	ClusterIdentifier string                    `json:"ClusterIdentifier"`
+	Database          string                    `json:"Database"`
+	DbUser            string                    `json:"DbUser,omitempty"`
+	SecretArn         string                    `json:"SecretArn,omitempty"`
+	SQL               string                    `json:"Sql"`
+	Parameters        []awsRedshiftSQLParameter `json:"Parameters,omitempty"`
+}
+
+// awsRedshiftField is a field of a record of the result of a statement
+type awsRedshiftField struct {
+	LongValue *int64 `json:"longValue"`
+}
+
+// awsRedshiftError is the body of the responses of the Data API to the failed requests
+type awsRedshiftError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// NewAwsRedshiftScaler creates a new awsRedshiftScaler
+func NewAwsRedshiftScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAwsRedshiftMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redshift metadata: %w", err)
+	}
+
+	awsConfig, err := awsutils.GetAwsConfig(ctx, meta.awsAuthorization)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating aws config: %w", err)
+	}
+
+	return &awsRedshiftScaler{
+		metricType:   metricType,
+		metadata:     meta,
+		awsConfig:    awsConfig,
+		signer:       v4.NewSigner(),
+		httpClient:   kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:       InitializeLogger(config, "aws_redshift_scaler"),
+		pollInterval: awsRedshiftDefaultPollInterval,
+	}, nil
+}
+
+func parseAwsRedshiftMetadata(config *scalersconfig.ScalerConfig) (*awsRedshiftMetadata, error) {
+	meta := &awsRedshiftMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.awsAuthorization = auth
+	return meta, nil
+}
+
+func (s *awsRedshiftScaler) Close(context.Context) error {
+	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *awsRedshiftScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("aws-redshift-%s", s.metadata.ClusterIdentifier)
+	if s.metadata.QueueName != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.QueueName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueuedQueries),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of queued queries
+func (s *awsRedshiftScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getQueuedQueries(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting queued queries of cluster %s: %w", s.metadata.ClusterIdentifier, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, float64(count) > s.metadata.ActivationTargetQueuedQueries, nil
+}
+
+// getQueuedQueries runs the statement counting the queued queries and waits for its result, the Data API running
+// the statements asynchronously
+func (s *awsRedshiftScaler) getQueuedQueries(ctx context.Context) (int64, error) {
+	input := awsRedshiftExecuteStatementInput{
+		ClusterIdentifier: s.metadata.ClusterIdentifier,
+		Database:          s.metadata.Database,
+		DbUser:            s.metadata.DbUser,
+		SecretArn:         s.metadata.SecretArn,
+		SQL:               awsRedshiftQueuedQueries,
+	}
+	if s.metadata.QueueName != "" {
+		input.SQL += awsRedshiftQueueFilter
+		input.Parameters = []awsRedshiftSQLParameter{{Name: "queue", Value: s.metadata.QueueName}}
+	}
+
+	var statement struct {
+		ID string `json:"Id"`
+	}
+	if err := s.do(ctx, "ExecuteStatement", input, &statement); err != nil {
+		return 0, err
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		var description struct {
+			Status string `json:"Status"`
+			Error  string `json:"Error"`
+		}
+		if err := s.do(ctx, "DescribeStatement", statement, &description); err != nil {
+			return 0, err
+		}
+		if description.Status == awsRedshiftStatusFinished {
+			break
+		}
+		if description.Status == awsRedshiftStatusFailed || description.Status == awsRedshiftStatusAborted {
+			return 0, fmt.Errorf("statement %s %s: %s", statement.ID, strings.ToLower(description.Status), description.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	var result struct {
+		Records [][]awsRedshiftField `json:"Records"`
+	}
+	if err := s.do(ctx, "GetStatementResult", statement, &result); err != nil {
+		return 0, err
+	}
+	if len(result.Records) != 1 || len(result.Records[0]) != 1 || result.Records[0][0].LongValue == nil {
+		return 0, errors.New("unexpected result of the statement")
+	}
+	return *result.Records[0][0].LongValue, nil
+}
+
+// do sends a signed request for the action of the Data API and decodes its response into out
+func (s *awsRedshiftScaler) do(ctx context.Context, action string, input, out interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.metadata.AwsEndpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "RedshiftData."+action)
+
+	credentials, err := s.awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := s.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "redshift-data", s.metadata.AwsRegion, time.Now()); err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var redshiftError awsRedshiftError
+		if err := json.Unmarshal(respBody, &redshiftError); err == nil && redshiftError.Type != "" {
+			// the type may be prefixed with the namespace of the service, e.g. com.amazonaws.redshiftdata#ValidationException
+			errorType := redshiftError.Type[strings.LastIndex(redshiftError.Type, "#")+1:]
+			return fmt.Errorf("unexpected status code %d: %s: %s", resp.StatusCode, errorType, redshiftError.Message)
+		}
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return json.Unmarshal(respBody, out)
+}
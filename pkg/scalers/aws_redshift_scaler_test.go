@@ -0,0 +1,181 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testAwsRedshiftAuthentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseAwsRedshiftMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type awsRedshiftMetricIdentifier struct {
+	metadataTestData *parseAwsRedshiftMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAwsRedshiftMetadata = []parseAwsRedshiftMetadataTestData{
+	{map[string]string{}, testAwsRedshiftAuthentication, true, "nothing passed"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1"}, testAwsRedshiftAuthentication, false, "cluster only"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1", "dbUser": "keda", "queueName": "etl", "targetQueuedQueries": "2", "activationTargetQueuedQueries": "1"}, testAwsRedshiftAuthentication, false, "queue with db user"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1"}, map[string]string{"secretArn": "arn:aws:secretsmanager:eu-west-1:123456789012:secret:keda", "awsAccessKeyId": "none", "awsSecretAccessKey": "none"}, false, "secret arn"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1", "dbUser": "keda", "secretArn": "arn:aws:secretsmanager:eu-west-1:123456789012:secret:keda"}, testAwsRedshiftAuthentication, true, "db user and secret arn"},
+	{map[string]string{"clusterIdentifier": "analytics", "awsRegion": "eu-west-1"}, testAwsRedshiftAuthentication, true, "no database"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev"}, testAwsRedshiftAuthentication, true, "no region"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1", "targetQueuedQueries": "0"}, testAwsRedshiftAuthentication, true, "zero targetQueuedQueries"},
+	{map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1"}, map[string]string{}, true, "no credentials"},
+}
+
+var awsRedshiftMetricIdentifiers = []awsRedshiftMetricIdentifier{
+	{&testAwsRedshiftMetadata[1], 0, "s0-aws-redshift-analytics"},
+	{&testAwsRedshiftMetadata[2], 1, "s1-aws-redshift-analytics-etl"},
+}
+
+func TestAwsRedshiftParseMetadata(t *testing.T) {
+	for _, testData := range testAwsRedshiftMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseAwsRedshiftMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestAwsRedshiftGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range awsRedshiftMetricIdentifiers {
+		meta, err := parseAwsRedshiftMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAwsRedshiftScaler := awsRedshiftScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAwsRedshiftScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestAwsRedshiftGetMetricsAndActivity(t *testing.T) {
+	var describes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/redshift-data/aws4_request")
+
+		var input map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		switch r.Header.Get("X-Amz-Target") {
+		case "RedshiftData.ExecuteStatement":
+			assert.Equal(t, "analytics", input["ClusterIdentifier"])
+			assert.Equal(t, "dev", input["Database"])
+			sql := input["Sql"].(string)
+			assert.True(t, strings.HasPrefix(sql, awsRedshiftQueuedQueries))
+			if input["Parameters"] != nil {
+				assert.Equal(t, []interface{}{map[string]interface{}{"name": "queue", "value": "etl"}}, input["Parameters"])
+				_, _ = w.Write([]byte(`{"Id":"etl-statement"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Id":"statement"}`))
+		case "RedshiftData.DescribeStatement":
+			// the statement is still running the first time it's described
+			if describes.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"Id":"statement","Status":"STARTED"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Id":"statement","Status":"FINISHED"}`))
+		case "RedshiftData.GetStatementResult":
+			if input["Id"] == "etl-statement" {
+				_, _ = w.Write([]byte(`{"Records":[[{"longValue":1}]],"TotalNumRows":1}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Records":[[{"longValue":4}]],"TotalNumRows":1}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"all queues", map[string]string{}, 4, true},
+		{"etl queue", map[string]string{"queueName": "etl", "activationTargetQueuedQueries": "1"}, 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["clusterIdentifier"] = "analytics"
+			tc.metadata["database"] = "dev"
+			tc.metadata["awsRegion"] = "eu-west-1"
+			tc.metadata["awsEndpoint"] = server.URL
+			s, err := NewAwsRedshiftScaler(context.Background(), &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAwsRedshiftAuthentication})
+			require.NoError(t, err)
+			s.(*awsRedshiftScaler).pollInterval = time.Millisecond
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "aws-redshift")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestAwsRedshiftGetMetricsAndActivityFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("X-Amz-Target") {
+		case "RedshiftData.ExecuteStatement":
+			_, _ = w.Write([]byte(`{"Id":"statement"}`))
+		case "RedshiftData.DescribeStatement":
+			_, _ = w.Write([]byte(`{"Id":"statement","Status":"FAILED","Error":"ERROR: permission denied for relation stv_wlm_query_state"}`))
+		}
+	}))
+	defer server.Close()
+
+	s, err := NewAwsRedshiftScaler(context.Background(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"clusterIdentifier": "analytics", "database": "dev", "awsRegion": "eu-west-1", "awsEndpoint": server.URL},
+		AuthParams:      testAwsRedshiftAuthentication,
+	})
+	require.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "aws-redshift")
+	assert.ErrorContains(t, err, "statement statement failed: ERROR: permission denied")
+
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"com.amazonaws.redshiftdata#ValidationException","message":"Cluster analytics not found"}`))
+	}))
+	defer errorServer.Close()
+	s.(*awsRedshiftScaler).metadata.AwsEndpoint = errorServer.URL
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "aws-redshift")
+	assert.ErrorContains(t, err, "ValidationException: Cluster analytics not found")
+}
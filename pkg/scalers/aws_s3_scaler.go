@@ -0,0 +1,427 @@
+package scalers
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	awsS3SourceListObjects = "listObjects"
+	awsS3SourceInventory   = "inventory"
+
+	// awsS3InventoryDateLayout is the layout of the folders holding the manifest of each inventory
+	awsS3InventoryDateLayout = "2006-01-02T15-04Z"
+)
+
+// awsS3EmptyPayloadHash is the sha256 of an empty payload, which the requests are signed with
+var awsS3EmptyPayloadHash = func() string {
+	hash := sha256.Sum256(nil)
+	return hex.EncodeToString(hash[:])
+}()
+
+type awsS3Scaler struct {
+	metricType v2.MetricTargetType
+	metadata   *awsS3Metadata
+	awsConfig  *aws.Config
+	signer     *v4.Signer
+	httpClient *http.Client
+	logger     logr.Logger
+	now        func() time.Time
+}
+
+type awsS3Metadata struct {
+	triggerIndex     int
+	awsAuthorization awsutils.AuthorizationMetadata
+
+	BucketName  string `keda:"name=bucketName,  order=triggerMetadata"`
+	AwsRegion   string `keda:"name=awsRegion,   order=triggerMetadata;authParams"`
+	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+	Prefix      string `keda:"name=prefix,      order=triggerMetadata, optional"`
+	// OlderThanSeconds only counts the objects which haven't been modified for the duration
+	OlderThanSeconds int    `keda:"name=olderThanSeconds, order=triggerMetadata, optional"`
+	Source           string `keda:"name=source,           order=triggerMetadata, enum=listObjects;inventory, optional, default=listObjects"`
+	// InventoryBucket and InventoryPrefix locate the inventories of the bucket, the prefix being
+	// <destination prefix>/<source bucket>/<inventory configuration id>
+	InventoryBucket             string  `keda:"name=inventoryBucket,             order=triggerMetadata, optional"`
+	InventoryPrefix             string  `keda:"name=inventoryPrefix,             order=triggerMetadata, optional"`
+	TargetObjectCount           float64 `keda:"name=targetObjectCount,           order=triggerMetadata, optional, default=10"`
+	ActivationTargetObjectCount float64 `keda:"name=activationTargetObjectCount, order=triggerMetadata, optional"`
+}
+
+func (m *awsS3Metadata) Validate() error {
+	if m.TargetObjectCount <= 0 {
+		return errors.New("targetObjectCount must be greater than 0")
+	}
+	if m.OlderThanSeconds < 0 {
+		return errors.New("olderThanSeconds must not be negative")
+	}
+
+	if m.Source == awsS3SourceInventory {
+		if m.InventoryPrefix == "" {
+			return fmt.Errorf("inventoryPrefix is required with the %s source", awsS3SourceInventory)
+		}
+		if m.InventoryBucket == "" {
+			m.InventoryBucket = m.BucketName
+		}
+		m.InventoryPrefix = strings.Trim(m.InventoryPrefix, "/")
+	} else if m.InventoryBucket != "" || m.InventoryPrefix != "" {
+		return fmt.Errorf("inventoryBucket and inventoryPrefix are only supported by the %s source", awsS3SourceInventory)
+	}
+	return nil
+}
+
+// awsS3ListBucketResult is the response of ListObjectsV2
+type awsS3ListBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// awsS3Error is the body of the responses of S3 to the failed requests
+type awsS3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// awsS3InventoryManifest is the manifest.json describing the files of an inventory
+type awsS3InventoryManifest struct {
+	FileFormat string `json:"fileFormat"`
+	FileSchema string `json:"fileSchema"`
+	Files      []struct {
+		Key string `json:"key"`
+	} `json:"files"`
+}
+
+// NewAwsS3Scaler creates a new awsS3Scaler
+func NewAwsS3Scaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAwsS3Metadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing S3 metadata: %w", err)
+	}
+
+	awsConfig, err := awsutils.GetAwsConfig(ctx, meta.awsAuthorization)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating aws config: %w", err)
+	}
+
+	return &awsS3Scaler{
+		metricType: metricType,
+		metadata:   meta,
+		awsConfig:  awsConfig,
+		// S3 doesn't escape the escaped path of the request again to sign it
+		signer:     v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true }),
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "aws_s3_scaler"),
+		now:        time.Now,
+	}, nil
+}
+
+func parseAwsS3Metadata(config *scalersconfig.ScalerConfig) (*awsS3Metadata, error) {
+	meta := &awsS3Metadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.awsAuthorization = auth
+	return meta, nil
+}
+
+func (s *awsS3Scaler) Close(context.Context) error {
+	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *awsS3Scaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("aws-s3-%s-%s", s.metadata.BucketName, s.metadata.Prefix))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetObjectCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of objects under the prefix
+func (s *awsS3Scaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var count int64
+	var err error
+	if s.metadata.Source == awsS3SourceInventory {
+		count, err = s.getInventoryObjectCount(ctx)
+	} else {
+		count, err = s.getObjectCount(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error counting objects of bucket %s: %w", s.metadata.BucketName, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, float64(count) > s.metadata.ActivationTargetObjectCount, nil
+}
+
+// getObjectCount lists the objects under the prefix, following the pages of ListObjectsV2
+func (s *awsS3Scaler) getObjectCount(ctx context.Context) (int64, error) {
+	olderThan := s.now().Add(-time.Duration(s.metadata.OlderThanSeconds) * time.Second)
+	var count int64
+	err := s.listObjects(ctx, s.metadata.BucketName, s.metadata.Prefix, "", func(result *awsS3ListBucketResult) {
+		for _, object := range result.Contents {
+			// the folders created from the console are empty objects ending with a slash
+			if strings.HasSuffix(object.Key, "/") {
+				continue
+			}
+			if s.metadata.OlderThanSeconds > 0 && object.LastModified.After(olderThan) {
+				continue
+			}
+			count++
+		}
+	})
+	return count, err
+}
+
+// getInventoryObjectCount counts the objects under the prefix in the latest inventory of the bucket,
+// which is cheaper than listing the objects of a large bucket but is only refreshed daily or weekly
+func (s *awsS3Scaler) getInventoryObjectCount(ctx context.Context) (int64, error) {
+	var latest string
+	err := s.listObjects(ctx, s.metadata.InventoryBucket, s.metadata.InventoryPrefix+"/", "/", func(result *awsS3ListBucketResult) {
+		for _, commonPrefix := range result.CommonPrefixes {
+			folder := strings.TrimSuffix(strings.TrimPrefix(commonPrefix.Prefix, s.metadata.InventoryPrefix+"/"), "/")
+			// the data and hive folders are siblings of the dated folders
+			if _, err := time.Parse(awsS3InventoryDateLayout, folder); err == nil && folder > latest {
+				latest = folder
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	if latest == "" {
+		return 0, fmt.Errorf("no inventory found under %s/%s", s.metadata.InventoryBucket, s.metadata.InventoryPrefix)
+	}
+
+	body, err := s.getObject(ctx, s.metadata.InventoryBucket, s.metadata.InventoryPrefix+"/"+latest+"/manifest.json")
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	var manifest awsS3InventoryManifest
+	if err := json.NewDecoder(body).Decode(&manifest); err != nil {
+		return 0, fmt.Errorf("error decoding inventory manifest: %w", err)
+	}
+	if manifest.FileFormat != "CSV" {
+		return 0, fmt.Errorf("inventories in %s format aren't supported, only CSV is", manifest.FileFormat)
+	}
+
+	columns := strings.Split(manifest.FileSchema, ",")
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+	keyColumn := slices.Index(columns, "Key")
+	if keyColumn < 0 {
+		return 0, fmt.Errorf("inventory schema %q has no Key", manifest.FileSchema)
+	}
+	lastModifiedColumn := slices.Index(columns, "LastModifiedDate")
+	if s.metadata.OlderThanSeconds > 0 && lastModifiedColumn < 0 {
+		return 0, fmt.Errorf("inventory schema %q has no LastModifiedDate to filter the objects by age", manifest.FileSchema)
+	}
+
+	var count int64
+	for _, file := range manifest.Files {
+		fileCount, err := s.countInventoryFile(ctx, file.Key, columns, keyColumn, lastModifiedColumn)
+		if err != nil {
+			return 0, fmt.Errorf("error reading inventory file %s: %w", file.Key, err)
+		}
+		count += fileCount
+	}
+	return count, nil
+}
+
+// countInventoryFile counts the current versions of the objects under the prefix listed in a gzipped
+// CSV file of an inventory, whose keys are URL encoded
+func (s *awsS3Scaler) countInventoryFile(ctx context.Context, key string, columns []string, keyColumn, lastModifiedColumn int) (int64, error) {
+	body, err := s.getObject(ctx, s.metadata.InventoryBucket, key)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	gzipReader, err := gzip.NewReader(body)
+	if err != nil {
+		return 0, err
+	}
+	reader := csv.NewReader(gzipReader)
+	reader.FieldsPerRecord = len(columns)
+
+	// the inventories including all the versions also list the previous versions and the delete markers
+	isLatestColumn := slices.Index(columns, "IsLatest")
+	isDeleteMarkerColumn := slices.Index(columns, "IsDeleteMarker")
+	olderThan := s.now().Add(-time.Duration(s.metadata.OlderThanSeconds) * time.Second)
+
+	var count int64
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if isLatestColumn >= 0 && record[isLatestColumn] != "true" {
+			continue
+		}
+		if isDeleteMarkerColumn >= 0 && record[isDeleteMarkerColumn] == "true" {
+			continue
+		}
+		objectKey, err := url.QueryUnescape(record[keyColumn])
+		if err != nil {
+			objectKey = record[keyColumn]
+		}
+		if !strings.HasPrefix(objectKey, s.metadata.Prefix) || strings.HasSuffix(objectKey, "/") {
+			continue
+		}
+		if s.metadata.OlderThanSeconds > 0 {
+			lastModified, err := time.Parse(time.RFC3339, record[lastModifiedColumn])
+			if err != nil || lastModified.After(olderThan) {
+				continue
+			}
+		}
+		count++
+	}
+}
+
+// listObjects calls ListObjectsV2 until the listing isn't truncated anymore
+func (s *awsS3Scaler) listObjects(ctx context.Context, bucket, prefix, delimiter string, page func(*awsS3ListBucketResult)) error {
+	continuationToken := ""
+	for {
+		params := url.Values{}
+		params.Set("list-type", "2")
+		if prefix != "" {
+			params.Set("prefix", prefix)
+		}
+		if delimiter != "" {
+			params.Set("delimiter", delimiter)
+		}
+		if continuationToken != "" {
+			params.Set("continuation-token", continuationToken)
+		}
+
+		body, err := s.do(ctx, bucket, "", params)
+		if err != nil {
+			return err
+		}
+		var result awsS3ListBucketResult
+		err = xml.NewDecoder(body).Decode(&result)
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("error decoding list of objects: %w", err)
+		}
+		page(&result)
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (s *awsS3Scaler) getObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return s.do(ctx, bucket, key, nil)
+}
+
+// do sends a signed GET request for the key of the bucket and returns its body. The bucket is addressed
+// with its virtual host on AWS, and with its path on a custom endpoint, e.g. MinIO or LocalStack.
+func (s *awsS3Scaler) do(ctx context.Context, bucket, key string, params url.Values) (io.ReadCloser, error) {
+	var requestURL string
+	if s.metadata.AwsEndpoint != "" {
+		requestURL = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(s.metadata.AwsEndpoint, "/"), awsS3EscapePath(bucket), awsS3EscapePath(key))
+	} else {
+		requestURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, s.metadata.AwsRegion, awsS3EscapePath(key))
+	}
+	if len(params) > 0 {
+		// the spaces must be escaped as %20 rather than + in the canonical query of the signature
+		requestURL += "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Amz-Content-Sha256", awsS3EmptyPayloadHash)
+	credentials, err := s.awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+	if err := s.signer.SignHTTP(ctx, credentials, req, awsS3EmptyPayloadHash, "s3", s.metadata.AwsRegion, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var s3Error awsS3Error
+		if err := xml.NewDecoder(resp.Body).Decode(&s3Error); err == nil && s3Error.Code != "" {
+			return nil, fmt.Errorf("unexpected status code %d: %s: %s", resp.StatusCode, s3Error.Code, s3Error.Message)
+		}
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// awsS3EscapePath escapes the key as the canonical URI of the signature, all the bytes but the
+// unreserved characters and the slashes being escaped
+func awsS3EscapePath(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,218 @@
+package scalers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testAwsS3Authentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseAwsS3MetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type awsS3MetricIdentifier struct {
+	metadataTestData *parseAwsS3MetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAwsS3Metadata = []parseAwsS3MetadataTestData{
+	{map[string]string{}, testAwsS3Authentication, true, "nothing passed"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1"}, testAwsS3Authentication, false, "bucket only"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "prefix": "incoming/", "olderThanSeconds": "60", "targetObjectCount": "5", "activationTargetObjectCount": "1"}, testAwsS3Authentication, false, "prefix with age filter"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "source": "inventory", "inventoryBucket": "inventories", "inventoryPrefix": "/uploads/daily/"}, testAwsS3Authentication, false, "inventory"},
+	{map[string]string{"bucketName": "uploads"}, testAwsS3Authentication, true, "no region"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1"}, map[string]string{}, true, "no credentials"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "source": "inventory"}, testAwsS3Authentication, true, "inventory without prefix"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "inventoryPrefix": "uploads/daily"}, testAwsS3Authentication, true, "inventory prefix with listObjects"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "source": "cloudwatch"}, testAwsS3Authentication, true, "unknown source"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "olderThanSeconds": "-1"}, testAwsS3Authentication, true, "negative olderThanSeconds"},
+	{map[string]string{"bucketName": "uploads", "awsRegion": "eu-west-1", "targetObjectCount": "0"}, testAwsS3Authentication, true, "zero targetObjectCount"},
+}
+
+var awsS3MetricIdentifiers = []awsS3MetricIdentifier{
+	{&testAwsS3Metadata[1], 0, "s0-aws-s3-uploads-"},
+	{&testAwsS3Metadata[2], 1, "s1-aws-s3-uploads-incoming-"},
+}
+
+func TestAwsS3ParseMetadata(t *testing.T) {
+	for _, testData := range testAwsS3Metadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseAwsS3Metadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestAwsS3GetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range awsS3MetricIdentifiers {
+		meta, err := parseAwsS3Metadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAwsS3Scaler := awsS3Scaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAwsS3Scaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestAwsS3EscapePath(t *testing.T) {
+	assert.Equal(t, "incoming/dt%3D2024-05-01/report%20%2B1.csv", awsS3EscapePath("incoming/dt=2024-05-01/report +1.csv"))
+}
+
+func TestAwsS3GetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/uploads/", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("list-type"))
+		assert.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=none/")
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Content-Sha256"))
+
+		switch r.URL.Query().Get("continuation-token") {
+		case "":
+			assert.Equal(t, "incoming/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(`<ListBucketResult><IsTruncated>true</IsTruncated><NextContinuationToken>page 2</NextContinuationToken>
+<Contents><Key>incoming/</Key><LastModified>2024-05-01T10:00:00.000Z</LastModified></Contents>
+<Contents><Key>incoming/a.csv</Key><LastModified>2024-05-01T10:00:00.000Z</LastModified></Contents>
+<Contents><Key>incoming/b.csv</Key><LastModified>2024-05-01T11:59:30.000Z</LastModified></Contents>
+</ListBucketResult>`))
+		case "page 2":
+			_, _ = w.Write([]byte(`<ListBucketResult><IsTruncated>false</IsTruncated>
+<Contents><Key>incoming/c.csv</Key><LastModified>2024-05-01T11:00:00.000Z</LastModified></Contents>
+</ListBucketResult>`))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"all objects", map[string]string{}, 3, true},
+		{"objects older than a minute", map[string]string{"olderThanSeconds": "60", "activationTargetObjectCount": "2"}, 2, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["bucketName"] = "uploads"
+			tc.metadata["awsRegion"] = "eu-west-1"
+			tc.metadata["awsEndpoint"] = server.URL
+			tc.metadata["prefix"] = "incoming/"
+			s, err := NewAwsS3Scaler(context.Background(), &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAwsS3Authentication})
+			require.NoError(t, err)
+			s.(*awsS3Scaler).now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "aws-s3")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestAwsS3GetMetricsAndActivityInventory(t *testing.T) {
+	var inventory bytes.Buffer
+	gzipWriter := gzip.NewWriter(&inventory)
+	_, _ = gzipWriter.Write([]byte(`"uploads","incoming%2Fa.csv","true","false","2024-05-01T10:00:00.000Z"
+"uploads","incoming%2Fa.csv","false","false","2024-04-30T10:00:00.000Z"
+"uploads","incoming%2Fb+1.csv","true","false","2024-05-01T11:59:30.000Z"
+"uploads","incoming%2Fc.csv","true","true","2024-05-01T11:00:00.000Z"
+"uploads","archive%2Fd.csv","true","false","2024-05-01T10:00:00.000Z"
+`))
+	gzipWriter.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/inventories/":
+			assert.Equal(t, "uploads/daily/", r.URL.Query().Get("prefix"))
+			assert.Equal(t, "/", r.URL.Query().Get("delimiter"))
+			_, _ = w.Write([]byte(`<ListBucketResult><IsTruncated>false</IsTruncated>
+<CommonPrefixes><Prefix>uploads/daily/2024-04-30T01-00Z/</Prefix></CommonPrefixes>
+<CommonPrefixes><Prefix>uploads/daily/2024-05-01T01-00Z/</Prefix></CommonPrefixes>
+<CommonPrefixes><Prefix>uploads/daily/data/</Prefix></CommonPrefixes>
+<CommonPrefixes><Prefix>uploads/daily/hive/</Prefix></CommonPrefixes>
+</ListBucketResult>`))
+		case "/inventories/uploads/daily/2024-05-01T01-00Z/manifest.json":
+			_, _ = w.Write([]byte(`{"sourceBucket":"uploads","fileFormat":"CSV","fileSchema":"Bucket, Key, IsLatest, IsDeleteMarker, LastModifiedDate","files":[{"key":"uploads/daily/data/0a1b.csv.gz"}]}`))
+		case "/inventories/uploads/daily/data/0a1b.csv.gz":
+			_, _ = w.Write(inventory.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+	}{
+		{"latest versions under the prefix", map[string]string{}, 2},
+		{"latest versions older than a minute", map[string]string{"olderThanSeconds": "60"}, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["bucketName"] = "uploads"
+			tc.metadata["awsRegion"] = "eu-west-1"
+			tc.metadata["awsEndpoint"] = server.URL
+			tc.metadata["prefix"] = "incoming/"
+			tc.metadata["source"] = "inventory"
+			tc.metadata["inventoryBucket"] = "inventories"
+			tc.metadata["inventoryPrefix"] = "uploads/daily"
+			s, err := NewAwsS3Scaler(context.Background(), &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAwsS3Authentication})
+			require.NoError(t, err)
+			s.(*awsS3Scaler).now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+			metrics, _, err := s.GetMetricsAndActivity(context.Background(), "aws-s3")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
+
+func TestAwsS3GetMetricsAndActivityError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`))
+	}))
+	defer server.Close()
+
+	s, err := NewAwsS3Scaler(context.Background(), &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"bucketName": "missing", "awsRegion": "eu-west-1", "awsEndpoint": server.URL},
+		AuthParams:      testAwsS3Authentication,
+	})
+	require.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "aws-s3")
+	assert.ErrorContains(t, err, "NoSuchBucket")
+}
@@ -2,12 +2,16 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/go-logr/logr"
@@ -19,25 +23,53 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	awsSqsMetricQueueLength      = "queueLength"
+	awsSqsMetricOldestMessageAge = "oldestMessageAge"
+
+	awsSqsAggregationMax = "max"
+
+	// awsSqsOldestMessageAgeWindow is how far back the latest ApproximateAgeOfOldestMessage is looked for, as
+	// SQS publishes its metrics to CloudWatch every minute
+	awsSqsOldestMessageAgeWindow = 5 * time.Minute
+)
+
 type awsSqsQueueScaler struct {
 	metricType       v2.MetricTargetType
 	metadata         *awsSqsQueueMetadata
 	sqsWrapperClient SqsWrapperClient
+	cwClient         cloudwatch.GetMetricDataAPIClient
 	logger           logr.Logger
 }
 
 type awsSqsQueueMetadata struct {
 	TargetQueueLength           int64  `keda:"name=queueLength, order=triggerMetadata, default=5"`
 	ActivationTargetQueueLength int64  `keda:"name=activationQueueLength, order=triggerMetadata, default=0"`
-	QueueURL                    string `keda:"name=queueURL;queueURLFromEnv, order=triggerMetadata;resolvedEnv"`
-	queueName                   string
-	AwsRegion                   string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
-	AwsEndpoint                 string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
-	awsAuthorization            awsutils.AuthorizationMetadata
-	triggerIndex                int
-	ScaleOnInFlight             bool `keda:"name=scaleOnInFlight, order=triggerMetadata, default=true"`
-	ScaleOnDelayed              bool `keda:"name=scaleOnDelayed, order=triggerMetadata, default=false"`
-	awsSqsQueueMetricNames      []types.QueueAttributeName
+	QueueURL                    string `keda:"name=queueURL;queueURLFromEnv, order=triggerMetadata;resolvedEnv, optional"`
+	// QueueURLs are the queues whose metrics are aggregated, a queue ending with * being the prefix of the names
+	// of the queues
+	QueueURLs   []string `keda:"name=queueURLs, order=triggerMetadata, optional"`
+	Aggregation string   `keda:"name=aggregation, order=triggerMetadata, enum=sum;max, default=sum"`
+	// Metric oldestMessageAge scales on the ApproximateAgeOfOldestMessage of the queues in CloudWatch, in seconds
+	Metric                     string `keda:"name=metric, order=triggerMetadata, enum=queueLength;oldestMessageAge, default=queueLength"`
+	OldestMessageAge           int64  `keda:"name=oldestMessageAge, order=triggerMetadata, default=60"`
+	ActivationOldestMessageAge int64  `keda:"name=activationOldestMessageAge, order=triggerMetadata, default=0"`
+	queueName                  string
+	AwsRegion                  string `keda:"name=awsRegion, order=triggerMetadata;authParams"`
+	AwsEndpoint                string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+	awsAuthorization           awsutils.AuthorizationMetadata
+	triggerIndex               int
+	ScaleOnInFlight            bool `keda:"name=scaleOnInFlight, order=triggerMetadata, default=true"`
+	ScaleOnDelayed             bool `keda:"name=scaleOnDelayed, order=triggerMetadata, default=false"`
+	awsSqsQueueMetricNames     []types.QueueAttributeName
+	DeadLetter                 deadLetterMetadata `keda:""`
+	// DeadLetterQueueURL is the dead-letter queue, which is otherwise the target of the redrive policy of the queue
+	DeadLetterQueueURL string `keda:"name=deadLetterQueueURL, order=triggerMetadata, optional"`
+}
+
+// awsSqsRedrivePolicy is the RedrivePolicy attribute of the queues with a dead-letter queue
+type awsSqsRedrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
 }
 
 // NewAwsSqsQueueScaler creates a new awsSqsQueueScaler
@@ -57,18 +89,27 @@ func NewAwsSqsQueueScaler(ctx context.Context, config *scalersconfig.ScalerConfi
 	if err != nil {
 		return nil, fmt.Errorf("error when creating sqs client: %w", err)
 	}
+	var cwClient cloudwatch.GetMetricDataAPIClient
+	if meta.Metric == awsSqsMetricOldestMessageAge {
+		cwClient, err = createSqsCloudwatchClient(ctx, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error when creating cloudwatch client: %w", err)
+		}
+	}
 	return &awsSqsQueueScaler{
 		metricType: metricType,
 		metadata:   meta,
 		sqsWrapperClient: &sqsWrapperClient{
 			sqsClient: awsSqsClient,
 		},
-		logger: logger,
+		cwClient: cwClient,
+		logger:   logger,
 	}, nil
 }
 
 type SqsWrapperClient interface {
 	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error)
 }
 
 type sqsWrapperClient struct {
@@ -79,6 +120,10 @@ func (w sqsWrapperClient) GetQueueAttributes(ctx context.Context, params *sqs.Ge
 	return w.sqsClient.GetQueueAttributes(ctx, params, optFns...)
 }
 
+func (w sqsWrapperClient) ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	return w.sqsClient.ListQueues(ctx, params, optFns...)
+}
+
 func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig) (*awsSqsQueueMetadata, error) {
 	meta := &awsSqsQueueMetadata{}
 
@@ -95,18 +140,39 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig) (*awsSqsQueueM
 		meta.awsSqsQueueMetricNames = append(meta.awsSqsQueueMetricNames, types.QueueAttributeNameApproximateNumberOfMessagesDelayed)
 	}
 
-	queueURL, err := url.ParseRequestURI(meta.QueueURL)
-	if err != nil {
-		// queueURL is not a valid URL, using it as queueName
-		meta.queueName = meta.QueueURL
-	} else {
-		queueURLPath := queueURL.Path
-		queueURLPathParts := strings.Split(queueURLPath, "/")
-		if len(queueURLPathParts) != 3 || len(queueURLPathParts[2]) == 0 {
-			return nil, fmt.Errorf("cannot get queueName from queueURL")
+	_, hasQueueURL := config.TriggerMetadata["queueURL"]
+	_, hasQueueURLFromEnv := config.TriggerMetadata["queueURLFromEnv"]
+	switch {
+	case (hasQueueURL || hasQueueURLFromEnv) && len(meta.QueueURLs) > 0:
+		return nil, fmt.Errorf("queueURL and queueURLs can't be set at the same time")
+	case hasQueueURL || hasQueueURLFromEnv:
+		queueName, err := getAwsSqsQueueName(meta.QueueURL)
+		if err != nil {
+			return nil, err
+		}
+		meta.queueName = queueName
+	case len(meta.QueueURLs) > 0:
+		for _, queueURL := range meta.QueueURLs {
+			if _, err := getAwsSqsQueueName(strings.TrimSuffix(queueURL, "*")); err != nil {
+				return nil, err
+			}
 		}
+		if meta.DeadLetter.enabled() || meta.DeadLetterQueueURL != "" {
+			return nil, fmt.Errorf("dlqBehavior and deadLetterQueueURL aren't supported with queueURLs")
+		}
+		// the metric is named after the first of the queues
+		meta.queueName, _ = getAwsSqsQueueName(strings.TrimSuffix(meta.QueueURLs[0], "*"))
+	default:
+		return nil, fmt.Errorf("no queueURL or queueURLs given")
+	}
 
-		meta.queueName = queueURLPathParts[2]
+	if meta.Metric == awsSqsMetricOldestMessageAge {
+		if meta.DeadLetter.enabled() {
+			return nil, fmt.Errorf("dlqBehavior isn't supported with metric %s", awsSqsMetricOldestMessageAge)
+		}
+		if meta.OldestMessageAge <= 0 {
+			return nil, fmt.Errorf("oldestMessageAge must be greater than 0")
+		}
 	}
 
 	auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
@@ -121,6 +187,21 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig) (*awsSqsQueueM
 	return meta, nil
 }
 
+// getAwsSqsQueueName returns the name of the queue of the URL, or the queue URL when it's a name
+func getAwsSqsQueueName(queueURL string) (string, error) {
+	parsedURL, err := url.ParseRequestURI(queueURL)
+	if err != nil {
+		// queueURL is not a valid URL, using it as queueName
+		return queueURL, nil
+	}
+
+	queueURLPathParts := strings.Split(parsedURL.Path, "/")
+	if len(queueURLPathParts) != 3 || len(queueURLPathParts[2]) == 0 {
+		return "", fmt.Errorf("cannot get queueName from queueURL")
+	}
+	return queueURLPathParts[2], nil
+}
+
 func createSqsClient(ctx context.Context, metadata *awsSqsQueueMetadata) (*sqs.Client, error) {
 	cfg, err := awsutils.GetAwsConfig(ctx, metadata.awsAuthorization)
 	if err != nil {
@@ -133,17 +214,32 @@ func createSqsClient(ctx context.Context, metadata *awsSqsQueueMetadata) (*sqs.C
 	}), nil
 }
 
+// createSqsCloudwatchClient returns the client of the CloudWatch metrics of the queues
+func createSqsCloudwatchClient(ctx context.Context, metadata *awsSqsQueueMetadata) (*cloudwatch.Client, error) {
+	cfg, err := awsutils.GetAwsConfig(ctx, metadata.awsAuthorization)
+	if err != nil {
+		return nil, err
+	}
+	return cloudwatch.NewFromConfig(*cfg), nil
+}
+
 func (s *awsSqsQueueScaler) Close(context.Context) error {
 	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
 	return nil
 }
 
 func (s *awsSqsQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("aws-sqs-%s", s.metadata.queueName)
+	target := s.metadata.TargetQueueLength
+	if s.metadata.Metric == awsSqsMetricOldestMessageAge {
+		metricName += "-age"
+		target = s.metadata.OldestMessageAge
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-sqs-%s", s.metadata.queueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueueLength),
+		Target: GetMetricTarget(s.metricType, target),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -151,23 +247,159 @@ func (s *awsSqsQueueScaler) GetMetricSpecForScaling(context.Context) []v2.Metric
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *awsSqsQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	queuelen, err := s.getAwsSqsQueueLength(ctx)
+	if s.metadata.Metric == awsSqsMetricOldestMessageAge {
+		age, err := s.getAwsSqsOldestMessageAge(ctx)
+		if err != nil {
+			s.logger.Error(err, "Error getting age of oldest message")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		metric := GenerateMetricInMili(metricName, age)
+		return []external_metrics.ExternalMetricValue{metric}, age > float64(s.metadata.ActivationOldestMessageAge), nil
+	}
+
+	queuelen, err := s.getAwsSqsQueuesLength(ctx)
 
 	if err != nil {
 		s.logger.Error(err, "Error getting queue length")
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
+	if s.metadata.DeadLetter.enabled() {
+		deadLetterLen, err := s.getAwsSqsDeadLetterQueueLength(ctx)
+		if err != nil {
+			s.logger.Error(err, "Error getting dead-letter queue length")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		value, isActive := s.metadata.DeadLetter.apply(float64(queuelen), float64(deadLetterLen), float64(s.metadata.ActivationTargetQueueLength))
+		return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, value)}, isActive, nil
+	}
+
 	metric := GenerateMetricInMili(metricName, float64(queuelen))
 
 	return []external_metrics.ExternalMetricValue{metric}, queuelen > s.metadata.ActivationTargetQueueLength, nil
 }
 
+// getAwsSqsQueueURLs returns the queue, or the queues of the queueURLs with the queues of their prefixes
+func (s *awsSqsQueueScaler) getAwsSqsQueueURLs(ctx context.Context) ([]string, error) {
+	if len(s.metadata.QueueURLs) == 0 {
+		return []string{s.metadata.QueueURL}, nil
+	}
+
+	var queueURLs []string
+	for _, queueURL := range s.metadata.QueueURLs {
+		prefix, isPrefix := strings.CutSuffix(queueURL, "*")
+		if !isPrefix {
+			queueURLs = append(queueURLs, queueURL)
+			continue
+		}
+		queueNamePrefix, _ := getAwsSqsQueueName(prefix)
+		input := &sqs.ListQueuesInput{QueueNamePrefix: aws.String(queueNamePrefix)}
+		for {
+			output, err := s.sqsWrapperClient.ListQueues(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			queueURLs = append(queueURLs, output.QueueUrls...)
+			if output.NextToken == nil {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return queueURLs, nil
+}
+
+// getAwsSqsQueuesLength returns the length of the queue, or the sum or the max of the lengths of the queues
+func (s *awsSqsQueueScaler) getAwsSqsQueuesLength(ctx context.Context) (int64, error) {
+	queueURLs, err := s.getAwsSqsQueueURLs(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	lengths := make([]float64, 0, len(queueURLs))
+	for _, queueURL := range queueURLs {
+		length, err := s.getAwsSqsQueueLength(ctx, queueURL)
+		if err != nil {
+			return -1, err
+		}
+		lengths = append(lengths, float64(length))
+	}
+	return int64(s.metadata.aggregate(lengths)), nil
+}
+
+// getAwsSqsOldestMessageAge returns the latest ApproximateAgeOfOldestMessage of the queue, or the sum or the max
+// of the queues, a queue without datapoint having no message
+func (s *awsSqsQueueScaler) getAwsSqsOldestMessageAge(ctx context.Context) (float64, error) {
+	queueURLs, err := s.getAwsSqsQueueURLs(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if len(queueURLs) == 0 {
+		return 0, nil
+	}
+
+	queries := make([]cloudwatchtypes.MetricDataQuery, 0, len(queueURLs))
+	for i, queueURL := range queueURLs {
+		queueName, err := getAwsSqsQueueName(queueURL)
+		if err != nil {
+			return -1, err
+		}
+		queries = append(queries, cloudwatchtypes.MetricDataQuery{
+			Id: aws.String(fmt.Sprintf("q%d", i)),
+			MetricStat: &cloudwatchtypes.MetricStat{
+				Metric: &cloudwatchtypes.Metric{
+					Namespace:  aws.String("AWS/SQS"),
+					MetricName: aws.String("ApproximateAgeOfOldestMessage"),
+					Dimensions: []cloudwatchtypes.Dimension{{Name: aws.String("QueueName"), Value: aws.String(queueName)}},
+				},
+				Period: aws.Int32(60),
+				Stat:   aws.String("Maximum"),
+			},
+		})
+	}
+
+	endTime := time.Now()
+	input := &cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(endTime.Add(-awsSqsOldestMessageAgeWindow)),
+		EndTime:           aws.Time(endTime),
+		ScanBy:            cloudwatchtypes.ScanByTimestampDescending,
+		MetricDataQueries: queries,
+	}
+
+	ages := make([]float64, 0, len(queueURLs))
+	paginator := cloudwatch.NewGetMetricDataPaginator(s.cwClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return -1, err
+		}
+		for _, result := range output.MetricDataResults {
+			if len(result.Values) > 0 {
+				ages = append(ages, result.Values[0])
+			}
+		}
+	}
+	return s.metadata.aggregate(ages), nil
+}
+
+// aggregate returns the sum or the max of the values of the queues
+func (m *awsSqsQueueMetadata) aggregate(values []float64) float64 {
+	var result float64
+	for _, value := range values {
+		if m.Aggregation == awsSqsAggregationMax {
+			result = max(result, value)
+		} else {
+			result += value
+		}
+	}
+	return result
+}
+
 // Get SQS Queue Length
-func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context) (int64, error) {
+func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context, queueURL string) (int64, error) {
 	input := &sqs.GetQueueAttributesInput{
 		AttributeNames: s.metadata.awsSqsQueueMetricNames,
-		QueueUrl:       aws.String(s.metadata.QueueURL),
+		QueueUrl:       aws.String(queueURL),
 	}
 
 	output, err := s.sqsWrapperClient.GetQueueAttributes(ctx, input)
@@ -178,6 +410,59 @@ func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context) (int64, er
 	return s.processQueueLengthFromSqsQueueAttributesOutput(output)
 }
 
+// getAwsSqsDeadLetterQueueLength returns the visible messages of the dead-letter queue, which is read
+// from the redrive policy of the queue unless it's set in the metadata
+func (s *awsSqsQueueScaler) getAwsSqsDeadLetterQueueLength(ctx context.Context) (int64, error) {
+	deadLetterQueueURL := s.metadata.DeadLetterQueueURL
+	if deadLetterQueueURL == "" {
+		output, err := s.sqsWrapperClient.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameRedrivePolicy},
+			QueueUrl:       aws.String(s.metadata.QueueURL),
+		})
+		if err != nil {
+			return -1, err
+		}
+		if deadLetterQueueURL, err = s.getAwsSqsDeadLetterQueueURL(output.Attributes[string(types.QueueAttributeNameRedrivePolicy)]); err != nil {
+			return -1, err
+		}
+	}
+
+	output, err := s.sqsWrapperClient.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+		QueueUrl:       aws.String(deadLetterQueueURL),
+	})
+	if err != nil {
+		return -1, err
+	}
+	length, err := strconv.ParseInt(output.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)], 10, 64)
+	if err != nil {
+		return -1, fmt.Errorf("error parsing length of dead-letter queue %s: %w", deadLetterQueueURL, err)
+	}
+	return length, nil
+}
+
+// getAwsSqsDeadLetterQueueURL returns the URL of the dead-letter queue of the redrive policy, built from its
+// ARN arn:aws:sqs:<region>:<account id>:<queue name>
+func (s *awsSqsQueueScaler) getAwsSqsDeadLetterQueueURL(redrivePolicy string) (string, error) {
+	if redrivePolicy == "" {
+		return "", fmt.Errorf("queue %s has no dead-letter queue", s.metadata.queueName)
+	}
+	var policy awsSqsRedrivePolicy
+	if err := json.Unmarshal([]byte(redrivePolicy), &policy); err != nil {
+		return "", fmt.Errorf("error parsing redrive policy of queue %s: %w", s.metadata.queueName, err)
+	}
+	arnParts := strings.Split(policy.DeadLetterTargetArn, ":")
+	if len(arnParts) != 6 {
+		return "", fmt.Errorf("invalid dead-letter queue arn %q", policy.DeadLetterTargetArn)
+	}
+
+	endpoint := fmt.Sprintf("https://sqs.%s.amazonaws.com", arnParts[3])
+	if s.metadata.AwsEndpoint != "" {
+		endpoint = strings.TrimSuffix(s.metadata.AwsEndpoint, "/")
+	}
+	return fmt.Sprintf("%s/%s/%s", endpoint, arnParts[4], arnParts[5]), nil
+}
+
 func (s *awsSqsQueueScaler) processQueueLengthFromSqsQueueAttributesOutput(output *sqs.GetQueueAttributesOutput) (int64, error) {
 	var approximateNumberOfMessages int64
 
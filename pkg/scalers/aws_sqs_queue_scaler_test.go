@@ -6,6 +6,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/go-logr/logr"
@@ -28,9 +31,13 @@ const (
 	testAWSSQSErrorQueueURL   = "https://sqs.eu-west-1.amazonaws.com/account_id/Error"
 	testAWSSQSBadDataQueueURL = "https://sqs.eu-west-1.amazonaws.com/account_id/BadData"
 
+	testAWSSQSDeadLetterQueueURL = "https://sqs.eu-west-1.amazonaws.com/account_id/DeleteArtifactDLQ"
+	testAWSSQSSecondQueueURL     = "https://sqs.eu-west-1.amazonaws.com/account_id/DeleteArtifactQ2"
+
 	testAWSSQSApproximateNumberOfMessagesVisible    = 200
 	testAWSSQSApproximateNumberOfMessagesNotVisible = 100
 	testAWSSQSApproximateNumberOfMessagesDelayed    = 50
+	testAWSSQSDeadLetterMessages                    = 7
 )
 
 var testAWSSQSEmptyResolvedEnv = map[string]string{}
@@ -72,6 +79,22 @@ func (m *mockSqs) GetQueueAttributes(_ context.Context, input *sqs.GetQueueAttri
 				"ApproximateNumberOfMessagesNotVisible": "NotInt",
 			},
 		}, nil
+	case testAWSSQSDeadLetterQueueURL:
+		return &sqs.GetQueueAttributesOutput{
+			Attributes: map[string]string{
+				"ApproximateNumberOfMessages": strconv.Itoa(testAWSSQSDeadLetterMessages),
+			},
+		}, nil
+	}
+	if len(input.AttributeNames) == 1 && input.AttributeNames[0] == types.QueueAttributeNameRedrivePolicy {
+		if *input.QueueUrl != testAWSSQSProperQueueURL {
+			return &sqs.GetQueueAttributesOutput{Attributes: map[string]string{}}, nil
+		}
+		return &sqs.GetQueueAttributesOutput{
+			Attributes: map[string]string{
+				"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:eu-west-1:account_id:DeleteArtifactDLQ","maxReceiveCount":5}`,
+			},
+		}, nil
 	}
 
 	return &sqs.GetQueueAttributesOutput{
@@ -83,6 +106,31 @@ func (m *mockSqs) GetQueueAttributes(_ context.Context, input *sqs.GetQueueAttri
 	}, nil
 }
 
+// ListQueues returns the queues of the prefix DeleteArtifactQ, one page at a time
+func (m *mockSqs) ListQueues(_ context.Context, input *sqs.ListQueuesInput, _ ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	if *input.QueueNamePrefix != "DeleteArtifactQ" {
+		return &sqs.ListQueuesOutput{}, nil
+	}
+	if input.NextToken == nil {
+		return &sqs.ListQueuesOutput{QueueUrls: []string{testAWSSQSProperQueueURL}, NextToken: aws.String("next")}, nil
+	}
+	return &sqs.ListQueuesOutput{QueueUrls: []string{testAWSSQSSecondQueueURL}}, nil
+}
+
+// mockSqsCloudwatch returns the ApproximateAgeOfOldestMessage of the queues by name, latest first
+type mockSqsCloudwatch struct {
+	ages map[string][]float64
+}
+
+func (m *mockSqsCloudwatch) GetMetricData(_ context.Context, input *cloudwatch.GetMetricDataInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	output := &cloudwatch.GetMetricDataOutput{}
+	for _, query := range input.MetricDataQueries {
+		queueName := *query.MetricStat.Metric.Dimensions[0].Value
+		output.MetricDataResults = append(output.MetricDataResults, cloudwatchtypes.MetricDataResult{Id: query.Id, Values: m.ages[queueName]})
+	}
+	return output, nil
+}
+
 var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 	{map[string]string{},
 		testAWSSQSAuthentication,
@@ -306,11 +354,94 @@ var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 		},
 		false,
 		"empty QUEUE_URL env value"},
+	{map[string]string{
+		"queueURL":           testAWSSQSProperQueueURL,
+		"awsRegion":          "eu-west-1",
+		"dlqBehavior":        "block",
+		"deadLetterQueueURL": testAWSSQSDeadLetterQueueURL},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"dead-letter queue blocking scale to zero"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL,
+		"awsRegion":   "eu-west-1",
+		"dlqBehavior": "subtract"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid dlqBehavior"},
+	{map[string]string{
+		"queueURLs":   testAWSSQSProperQueueURL + "," + testAWSSimpleQueueURL + ",DeleteArtifact*",
+		"aggregation": "max",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"several queues"},
+	{map[string]string{
+		"queueURL":  testAWSSQSProperQueueURL,
+		"queueURLs": testAWSSimpleQueueURL,
+		"awsRegion": "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"both queueURL and queueURLs"},
+	{map[string]string{
+		"queueURLs":   testAWSSQSProperQueueURL + "," + testAWSSimpleQueueURL,
+		"aggregation": "avg",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid aggregation"},
+	{map[string]string{
+		"queueURLs":   testAWSSQSProperQueueURL + "," + testAWSSimpleQueueURL,
+		"dlqBehavior": "add",
+		"awsRegion":   "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"dead-letter queue with several queues"},
+	{map[string]string{
+		"queueURLs": testAWSSQSImproperQueueURL1 + "," + testAWSSimpleQueueURL,
+		"awsRegion": "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"improper queue url of several queues"},
+	{map[string]string{
+		"queueURL":         testAWSSQSProperQueueURL,
+		"metric":           "oldestMessageAge",
+		"oldestMessageAge": "120",
+		"awsRegion":        "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"oldest message age"},
+	{map[string]string{
+		"queueURL":         testAWSSQSProperQueueURL,
+		"metric":           "oldestMessageAge",
+		"oldestMessageAge": "0",
+		"awsRegion":        "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"oldest message age not positive"},
+	{map[string]string{
+		"queueURL":  testAWSSQSProperQueueURL,
+		"metric":    "messageCount",
+		"awsRegion": "eu-west-1"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid metric"},
 }
 
 var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
 	{&testAWSSQSMetadata[1], 0, "s0-aws-sqs-DeleteArtifactQ"},
 	{&testAWSSQSMetadata[1], 1, "s1-aws-sqs-DeleteArtifactQ"},
+	{&testAWSSQSMetadata[31], 2, "s2-aws-sqs-DeleteArtifactQ-age"},
 }
 
 var awsSQSGetMetricTestData = []*parseAWSSQSMetadataTestData{
@@ -409,7 +540,7 @@ func TestAWSSQSGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAWSSQSScaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+		mockAWSSQSScaler := awsSqsQueueScaler{"", meta, &mockSqs{}, nil, logr.Discard()}
 
 		metricSpec := mockAWSSQSScaler.GetMetricSpecForScaling(ctx)
 		metricName := metricSpec[0].External.Metric.Name
@@ -425,7 +556,7 @@ func TestAWSSQSScalerGetMetrics(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+		scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, nil, logr.Discard()}
 
 		value, _, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
 		switch meta.QueueURL {
@@ -446,6 +577,106 @@ func TestAWSSQSScalerGetMetrics(t *testing.T) {
 	}
 }
 
+func TestAWSSQSScalerGetMetricsDeadLetter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{"dead-letter queue ignored", map[string]string{"queueURL": testAWSSQSProperQueueURL, "activationQueueLength": "300"}, 300, false, false},
+		{"dead-letter queue added", map[string]string{"queueURL": testAWSSQSProperQueueURL, "dlqBehavior": "add", "activationQueueLength": "300"}, 307, true, false},
+		{"dead-letter queue blocking scale to zero", map[string]string{"queueURL": testAWSSQSProperQueueURL, "dlqBehavior": "block", "activationQueueLength": "300"}, 300, true, false},
+		{"dead-letter queue url", map[string]string{"queueURL": testAWSSimpleQueueURL, "dlqBehavior": "add", "deadLetterQueueURL": testAWSSQSDeadLetterQueueURL}, 307, true, false},
+		{"no redrive policy", map[string]string{"queueURL": testAWSSimpleQueueURL, "dlqBehavior": "add"}, 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["awsRegion"] = "eu-west-1"
+			meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAWSSQSAuthentication})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, nil, logr.Discard()}
+
+			value, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.isError {
+				assert.ErrorContains(t, err, "has no dead-letter queue")
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedValue, value[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestAWSSQSScalerGetMetricsSeveralQueues(t *testing.T) {
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+	}{
+		// the first queue is also listed by the prefix
+		{"sum", map[string]string{"queueURLs": testAWSSQSProperQueueURL + ",DeleteArtifactQ*", "scaleOnInFlight": "false"}, 600},
+		{"max", map[string]string{"queueURLs": testAWSSQSProperQueueURL + ",DeleteArtifactQ*", "scaleOnInFlight": "false", "aggregation": "max"}, 200},
+		{"no queue of the prefix", map[string]string{"queueURLs": "Missing*"}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["awsRegion"] = "eu-west-1"
+			meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAWSSQSAuthentication})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, nil, logr.Discard()}
+
+			value, _, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedValue, value[0].Value.Value())
+		})
+	}
+}
+
+func TestAWSSQSScalerGetMetricsOldestMessageAge(t *testing.T) {
+	cwClient := &mockSqsCloudwatch{ages: map[string][]float64{
+		"DeleteArtifactQ":  {90, 30},
+		"DeleteArtifactQ2": {40},
+	}}
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"latest age", map[string]string{"queueURL": testAWSSQSProperQueueURL}, 90, true},
+		{"no datapoint", map[string]string{"queueURL": testAWSSimpleQueueURL}, 0, false},
+		{"max of the queues", map[string]string{"queueURLs": "DeleteArtifactQ*", "aggregation": "max"}, 90, true},
+		{"activation", map[string]string{"queueURL": testAWSSQSSecondQueueURL, "activationOldestMessageAge": "60"}, 40, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["awsRegion"] = "eu-west-1"
+			tc.metadata["metric"] = "oldestMessageAge"
+			meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAWSSQSAuthentication})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, cwClient, logr.Discard()}
+
+			value, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedValue, value[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
 func TestProcessQueueLengthFromSqsQueueAttributesOutput(t *testing.T) {
 	scalerCreationFunc := func() *awsSqsQueueScaler {
 		return &awsSqsQueueScaler{
@@ -18,6 +18,8 @@ package azure
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
@@ -35,16 +37,30 @@ type BlobMetadata struct {
 	EndpointSuffix            string
 	TriggerIndex              int
 	GlobPattern               *glob.Glob
+	// BlobTagFilter is the expression on the index tags of the blobs to count, e.g. "status = 'pending'"
+	BlobTagFilter string
+	// BlobMetadataFilter is the metadata the blobs to count have
+	BlobMetadataFilter map[string]string
+	// MinBlobAge and MaxBlobAge bound the time since the last modification of the blobs to count
+	MinBlobAge time.Duration
+	MaxBlobAge time.Duration
 }
 
 // GetAzureBlobListLength returns the count of the blobs in blob container in int
 func GetAzureBlobListLength(ctx context.Context, blobClient *azblob.Client, meta *BlobMetadata) (int64, error) {
 	containerClient := blobClient.ServiceClient().NewContainerClient(meta.BlobContainerName)
+	if meta.BlobTagFilter != "" {
+		return getAzureBlobTagFilterLength(ctx, containerClient, meta)
+	}
+
+	include := container.ListBlobsInclude{Metadata: len(meta.BlobMetadataFilter) > 0}
+	now := time.Now()
 	if meta.GlobPattern != nil {
 		globPattern := *meta.GlobPattern
 		var count int64
 		flatPager := containerClient.NewListBlobsFlatPager(&azblob.ListBlobsFlatOptions{
-			Prefix: meta.BlobPrefix,
+			Prefix:  meta.BlobPrefix,
+			Include: include,
 		})
 		for flatPager.More() {
 			resp, err := flatPager.NextPage(ctx)
@@ -52,7 +68,7 @@ func GetAzureBlobListLength(ctx context.Context, blobClient *azblob.Client, meta
 				return -1, err
 			}
 			for _, blobItem := range resp.Segment.BlobItems {
-				if blobItem.Name != nil && globPattern.Match(*blobItem.Name) {
+				if blobItem.Name != nil && globPattern.Match(*blobItem.Name) && meta.matchesFilters(blobItem, now) {
 					count++
 				}
 			}
@@ -60,7 +76,8 @@ func GetAzureBlobListLength(ctx context.Context, blobClient *azblob.Client, meta
 		return count, nil
 	}
 	hierarchyPager := containerClient.NewListBlobsHierarchyPager(meta.BlobDelimiter, &container.ListBlobsHierarchyOptions{
-		Prefix: meta.BlobPrefix,
+		Prefix:  meta.BlobPrefix,
+		Include: include,
 	})
 	var count int64
 	for hierarchyPager.More() {
@@ -68,7 +85,80 @@ func GetAzureBlobListLength(ctx context.Context, blobClient *azblob.Client, meta
 		if err != nil {
 			return -1, err
 		}
-		count += int64(len(resp.Segment.BlobItems))
+		for _, blobItem := range resp.Segment.BlobItems {
+			if meta.matchesFilters(blobItem, now) {
+				count++
+			}
+		}
 	}
 	return count, nil
 }
+
+// getAzureBlobTagFilterLength returns the count of the blobs of the container found by their index tags, whose
+// names match the prefix, and the glob pattern or the delimiter
+func getAzureBlobTagFilterLength(ctx context.Context, containerClient *container.Client, meta *BlobMetadata) (int64, error) {
+	var count int64
+	var marker *string
+	for {
+		resp, err := containerClient.FilterBlobs(ctx, meta.BlobTagFilter, &container.FilterBlobsOptions{Marker: marker})
+		if err != nil {
+			return -1, err
+		}
+		for _, blob := range resp.Blobs {
+			if blob.Name != nil && meta.matchesName(*blob.Name) {
+				count++
+			}
+		}
+		if resp.NextMarker == nil || *resp.NextMarker == "" {
+			return count, nil
+		}
+		marker = resp.NextMarker
+	}
+}
+
+// matchesName returns whether the name of a blob found by its index tags is one of the blobs the listing of the
+// container would return
+func (meta *BlobMetadata) matchesName(name string) bool {
+	prefix := ""
+	if meta.BlobPrefix != nil {
+		prefix = *meta.BlobPrefix
+	}
+	rest, found := strings.CutPrefix(name, prefix)
+	if !found {
+		return false
+	}
+	if meta.GlobPattern != nil {
+		return (*meta.GlobPattern).Match(name)
+	}
+	return meta.BlobDelimiter == "" || !strings.Contains(rest, meta.BlobDelimiter)
+}
+
+// matchesFilters returns whether the blob has the metadata of the filter and was last modified within the ages
+func (meta *BlobMetadata) matchesFilters(blobItem *container.BlobItem, now time.Time) bool {
+	for key, value := range meta.BlobMetadataFilter {
+		if !hasBlobMetadata(blobItem.Metadata, key, value) {
+			return false
+		}
+	}
+
+	if meta.MinBlobAge > 0 || meta.MaxBlobAge > 0 {
+		if blobItem.Properties == nil || blobItem.Properties.LastModified == nil {
+			return false
+		}
+		age := now.Sub(*blobItem.Properties.LastModified)
+		if age < meta.MinBlobAge || (meta.MaxBlobAge > 0 && age > meta.MaxBlobAge) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasBlobMetadata returns whether the metadata has the value for the key, whose case is ignored as by the service
+func hasBlobMetadata(metadata map[string]*string, key, value string) bool {
+	for k, v := range metadata {
+		if strings.EqualFold(k, key) && v != nil && *v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,8 +3,11 @@ package azure
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/gobwas/glob"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +20,47 @@ func TestGetBlobLength(t *testing.T) {
 	assert.Equal(t, int64(-1), length)
 	assert.Error(t, err)
 }
+
+func TestBlobMatchesName(t *testing.T) {
+	prefix := "input/"
+	meta := BlobMetadata{BlobDelimiter: "/", BlobPrefix: &prefix}
+	assert.True(t, meta.matchesName("input/a.csv"))
+	assert.False(t, meta.matchesName("input/archive/a.csv"))
+	assert.False(t, meta.matchesName("output/a.csv"))
+
+	meta.BlobDelimiter = ""
+	assert.True(t, meta.matchesName("input/archive/a.csv"))
+
+	globPattern := glob.MustCompile("input/**.csv")
+	meta.GlobPattern = &globPattern
+	assert.True(t, meta.matchesName("input/archive/a.csv"))
+	assert.False(t, meta.matchesName("input/archive/a.json"))
+}
+
+func TestBlobMatchesFilters(t *testing.T) {
+	now := time.Now()
+	lastModified := now.Add(-10 * time.Minute)
+	pending, done := "pending", "done"
+
+	testCases := []struct {
+		name     string
+		meta     BlobMetadata
+		blobItem *container.BlobItem
+		matches  bool
+	}{
+		{"no filter", BlobMetadata{}, &container.BlobItem{}, true},
+		{"metadata", BlobMetadata{BlobMetadataFilter: map[string]string{"status": "pending"}}, &container.BlobItem{Metadata: map[string]*string{"Status": &pending}}, true},
+		{"other metadata value", BlobMetadata{BlobMetadataFilter: map[string]string{"status": "pending"}}, &container.BlobItem{Metadata: map[string]*string{"status": &done}}, false},
+		{"missing metadata", BlobMetadata{BlobMetadataFilter: map[string]string{"status": "pending"}}, &container.BlobItem{}, false},
+		{"within ages", BlobMetadata{MinBlobAge: 5 * time.Minute, MaxBlobAge: time.Hour}, &container.BlobItem{Properties: &container.BlobProperties{LastModified: &lastModified}}, true},
+		{"too recent", BlobMetadata{MinBlobAge: 15 * time.Minute}, &container.BlobItem{Properties: &container.BlobProperties{LastModified: &lastModified}}, false},
+		{"too old", BlobMetadata{MaxBlobAge: 5 * time.Minute}, &container.BlobItem{Properties: &container.BlobProperties{LastModified: &lastModified}}, false},
+		{"unknown last modification", BlobMetadata{MinBlobAge: 5 * time.Minute}, &container.BlobItem{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.matches, tc.meta.matchesFilters(tc.blobItem, now))
+		})
+	}
+}
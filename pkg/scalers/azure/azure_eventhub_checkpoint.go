@@ -64,6 +64,13 @@ type blobMetadataCheckpointer struct {
 	containerName string
 }
 
+// blobCheckpointStoreCheckpointer reads the checkpoints of the BlobCheckpointStore of the Event Hubs SDKs, whose
+// paths are lowercase
+type blobCheckpointStoreCheckpointer struct {
+	partitionID   string
+	containerName string
+}
+
 type goSdkCheckpointer struct {
 	partitionID   string
 	containerName string
@@ -106,6 +113,11 @@ func newCheckpointer(info EventHubInfo, partitionID string) checkpointer {
 			containerName: info.BlobContainer,
 			partitionID:   partitionID,
 		}
+	case info.CheckpointStrategy == "blobCheckpointStore":
+		return &blobCheckpointStoreCheckpointer{
+			containerName: info.BlobContainer,
+			partitionID:   partitionID,
+		}
 	case info.CheckpointStrategy == "azureFunction" || info.BlobContainer == "":
 		return &azureFunctionCheckpointer{
 			containerName: "azure-webjobs-eventhub",
@@ -163,6 +175,25 @@ func (checkpointer *blobMetadataCheckpointer) extractCheckpoint(get *azblob.Down
 	return getCheckpointFromStorageMetadata(get, checkpointer.partitionID)
 }
 
+// resolve path for blobCheckpointStoreCheckpointer
+func (checkpointer *blobCheckpointStoreCheckpointer) resolvePath(info EventHubInfo) (string, string, error) {
+	eventHubNamespace, eventHubName, err := getHubAndNamespace(info)
+	if err != nil {
+		return "", "", err
+	}
+
+	path := strings.ToLower(fmt.Sprintf("%s/%s/%s/checkpoint/%s", eventHubNamespace, eventHubName, info.EventHubConsumerGroup, checkpointer.partitionID))
+	if _, err := url.Parse(path); err != nil {
+		return "", "", err
+	}
+	return checkpointer.containerName, path, nil
+}
+
+// extract checkpoint for blobCheckpointStoreCheckpointer
+func (checkpointer *blobCheckpointStoreCheckpointer) extractCheckpoint(get *azblob.DownloadStreamResponse) (Checkpoint, error) {
+	return getCheckpointFromStorageMetadata(get, checkpointer.partitionID)
+}
+
 // resolve path for goSdkCheckpointer
 func (checkpointer *goSdkCheckpointer) resolvePath(info EventHubInfo) (string, string, error) {
 	return info.BlobContainer, checkpointer.partitionID, nil
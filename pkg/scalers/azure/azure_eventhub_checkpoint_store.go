@@ -0,0 +1,166 @@
+package azure
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/azure/checkpointprovider"
+)
+
+// DefaultRedisCheckpointKeyFormat is the key of the checkpoint of a partition in Redis, the same as the path of the
+// blob of the checkpoint in the BlobCheckpointStore of the Event Hubs SDKs
+const DefaultRedisCheckpointKeyFormat = "{namespace}/{eventHubName}/{consumerGroup}/checkpoint/{partitionId}"
+
+// ErrCheckpointNotFound is returned by a checkpoint store when the partition has no checkpoint yet
+var ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+// CheckpointStore reads the checkpoints that the consumers of an event hub store outside of a blob storage
+type CheckpointStore interface {
+	GetCheckpoint(ctx context.Context, info EventHubInfo, partitionID string) (Checkpoint, error)
+	Close() error
+}
+
+type redisCheckpointStore struct {
+	client    redis.UniversalClient
+	keyFormat string
+}
+
+// NewRedisCheckpointStore returns a store of the checkpoints kept in Redis under the keys of the format, either as
+// a value holding the sequence number or a JSON checkpoint, or as a hash with a sequencenumber field
+func NewRedisCheckpointStore(client redis.UniversalClient, keyFormat string) CheckpointStore {
+	if keyFormat == "" {
+		keyFormat = DefaultRedisCheckpointKeyFormat
+	}
+	return &redisCheckpointStore{client: client, keyFormat: keyFormat}
+}
+
+func (s *redisCheckpointStore) GetCheckpoint(ctx context.Context, info EventHubInfo, partitionID string) (Checkpoint, error) {
+	eventHubNamespace, eventHubName, err := getHubAndNamespace(info)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	key := strings.NewReplacer(
+		"{namespace}", eventHubNamespace,
+		"{eventHubName}", eventHubName,
+		"{consumerGroup}", info.EventHubConsumerGroup,
+		"{partitionId}", partitionID,
+	).Replace(s.keyFormat)
+
+	keyType, err := s.client.Type(ctx, key).Result()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	var value string
+	switch keyType {
+	case "none":
+		return Checkpoint{}, ErrCheckpointNotFound
+	case "hash":
+		value, err = s.client.HGet(ctx, key, "sequencenumber").Result()
+	default:
+		value, err = s.client.Get(ctx, key).Result()
+	}
+	if errors.Is(err, redis.Nil) {
+		return Checkpoint{}, ErrCheckpointNotFound
+	}
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	sequenceNumber, err := parseSequenceNumber(value)
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("invalid checkpoint %s: %w", key, err)
+	}
+	return Checkpoint{PartitionID: partitionID, SequenceNumber: sequenceNumber}, nil
+}
+
+func (s *redisCheckpointStore) Close() error {
+	return s.client.Close()
+}
+
+// parseSequenceNumber parses the sequence number of a checkpoint, or the sequence number of a checkpoint in one of
+// the JSON formats of the blob checkpoints
+func parseSequenceNumber(value string) (int64, error) {
+	if sequenceNumber, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+		return sequenceNumber, nil
+	}
+
+	var checkpoint struct {
+		SequenceNumber       *int64 `json:"sequenceNumber"`
+		PythonSequenceNumber *int64 `json:"sequence_number"`
+		Checkpoint           *struct {
+			SequenceNumber int64 `json:"sequenceNumber"`
+		} `json:"checkpoint"`
+	}
+	if err := json.Unmarshal([]byte(value), &checkpoint); err != nil {
+		return 0, err
+	}
+	switch {
+	case checkpoint.SequenceNumber != nil:
+		return *checkpoint.SequenceNumber, nil
+	case checkpoint.PythonSequenceNumber != nil:
+		return *checkpoint.PythonSequenceNumber, nil
+	case checkpoint.Checkpoint != nil:
+		return checkpoint.Checkpoint.SequenceNumber, nil
+	default:
+		return 0, errors.New("no sequence number")
+	}
+}
+
+type grpcCheckpointStore struct {
+	conn   *grpc.ClientConn
+	client checkpointprovider.CheckpointProviderClient
+}
+
+// NewGRPCCheckpointStore returns a store of the checkpoints served by a checkpoint provider implementing the
+// CheckpointProvider service, over TLS when the config is set. The provider answers NotFound when the partition
+// has no checkpoint yet.
+func NewGRPCCheckpointStore(address string, tlsConfig *tls.Config) (CheckpointStore, error) {
+	transportCredentials := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+	// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	return &grpcCheckpointStore{conn: conn, client: checkpointprovider.NewCheckpointProviderClient(conn)}, nil
+}
+
+func (s *grpcCheckpointStore) GetCheckpoint(ctx context.Context, info EventHubInfo, partitionID string) (Checkpoint, error) {
+	eventHubNamespace, eventHubName, err := getHubAndNamespace(info)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	checkpoint, err := s.client.GetCheckpoint(ctx, &checkpointprovider.GetCheckpointRequest{
+		Namespace:     eventHubNamespace,
+		EventHubName:  eventHubName,
+		ConsumerGroup: info.EventHubConsumerGroup,
+		PartitionId:   partitionID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return Checkpoint{}, ErrCheckpointNotFound
+	}
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	return Checkpoint{PartitionID: partitionID, SequenceNumber: checkpoint.SequenceNumber}, nil
+}
+
+func (s *grpcCheckpointStore) Close() error {
+	return s.conn.Close()
+}
@@ -390,6 +390,46 @@ func TestShouldParseCheckpointForBlobMetadataWithPodIdentity(t *testing.T) {
 	assert.Equal(t, path, "eventhubnamespace.servicebus.windows.net/hub-test/$default/checkpoint/0")
 }
 
+func TestShouldParseCheckpointForBlobCheckpointStore(t *testing.T) {
+	eventHubInfo := EventHubInfo{
+		EventHubConnection:    "Endpoint=sb://EventHubNamespace.servicebus.windows.net/;EntityPath=Hub-Test",
+		EventHubConsumerGroup: "$Default",
+		BlobContainer:         "containername",
+		CheckpointStrategy:    "blobCheckpointStore",
+	}
+
+	cp := newCheckpointer(eventHubInfo, "0")
+	container, path, _ := cp.resolvePath(eventHubInfo)
+
+	assert.Equal(t, container, eventHubInfo.BlobContainer)
+	assert.Equal(t, path, "eventhubnamespace.servicebus.windows.net/hub-test/$default/checkpoint/0")
+}
+
+func TestParseSequenceNumber(t *testing.T) {
+	testCases := []struct {
+		value          string
+		sequenceNumber int64
+		isError        bool
+	}{
+		{"42", 42, false},
+		{`{"sequenceNumber":42,"offset":"1024"}`, 42, false},
+		{`{"sequence_number":42}`, 42, false},
+		{`{"checkpoint":{"sequenceNumber":42}}`, 42, false},
+		{`{"offset":"1024"}`, 0, true},
+		{"invalid", 0, true},
+	}
+
+	for _, tc := range testCases {
+		sequenceNumber, err := parseSequenceNumber(tc.value)
+		if tc.isError {
+			assert.Error(t, err, tc.value)
+			continue
+		}
+		assert.NoError(t, err, tc.value)
+		assert.Equal(t, tc.sequenceNumber, sequenceNumber, tc.value)
+	}
+}
+
 func TestShouldParseCheckpointForGoSdk(t *testing.T) {
 	eventHubInfo := EventHubInfo{
 		EventHubConnection:    "Endpoint=sb://eventhubnamespace.servicebus.windows.net/;EntityPath=hub-test",
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/go-logr/logr"
@@ -137,6 +138,40 @@ func parseAzureBlobMetadata(config *scalersconfig.ScalerConfig, logger logr.Logg
 		meta.BlobPrefix = &prefix
 	}
 
+	if val, ok := config.TriggerMetadata["blobTagFilter"]; ok && val != "" {
+		meta.BlobTagFilter = val
+	}
+
+	if val, ok := config.TriggerMetadata["blobMetadataFilter"]; ok && val != "" {
+		metadataFilter, err := kedautil.ParseStringList(val)
+		if err != nil {
+			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("invalid blobMetadataFilter - %w", err)
+		}
+		meta.BlobMetadataFilter = metadataFilter
+	}
+
+	for name, age := range map[string]*time.Duration{"minBlobAge": &meta.MinBlobAge, "maxBlobAge": &meta.MaxBlobAge} {
+		if val, ok := config.TriggerMetadata[name]; ok && val != "" {
+			duration, err := time.ParseDuration(val)
+			if err != nil {
+				return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("error parsing azure blob metadata %s: %w", name, err)
+			}
+			if duration < 0 {
+				return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("%s must not be negative", name)
+			}
+			*age = duration
+		}
+	}
+
+	if meta.MaxBlobAge > 0 && meta.MinBlobAge > meta.MaxBlobAge {
+		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("minBlobAge must not be greater than maxBlobAge")
+	}
+
+	// the blobs found by their index tags have neither their metadata nor their last modification
+	if meta.BlobTagFilter != "" && (len(meta.BlobMetadataFilter) > 0 || meta.MinBlobAge > 0 || meta.MaxBlobAge > 0) {
+		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("blobTagFilter can't be combined with blobMetadataFilter, minBlobAge or maxBlobAge")
+	}
+
 	endpointSuffix, err := azure.ParseAzureStorageEndpointSuffix(config.TriggerMetadata, azure.BlobEndpoint)
 	if err != nil {
 		return nil, kedav1alpha1.AuthPodIdentity{}, err
@@ -83,6 +83,18 @@ var testAzBlobMetadata = []parseAzBlobMetadataTestData{
 	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobCount": "5", "recursive": "invalid"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
 	// with invalid glob pattern
 	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobCount": "5", "globPattern": "[\\]"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with blob tag filter
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobTagFilter": "status = 'pending'"}, false, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with blob metadata filter and ages
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobMetadataFilter": "status=pending,owner=etl", "minBlobAge": "1m", "maxBlobAge": "24h"}, false, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with invalid blob metadata filter
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobMetadataFilter": "status"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with invalid minBlobAge
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "minBlobAge": "AA"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with minBlobAge greater than maxBlobAge
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "minBlobAge": "2h", "maxBlobAge": "1h"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
+	// with blob tag filter and blob metadata filter
+	{map[string]string{"connectionFromEnv": "CONNECTION", "blobContainerName": "sample", "blobTagFilter": "status = 'pending'", "blobMetadataFilter": "status=pending"}, true, testAzBlobResolvedEnv, map[string]string{}, ""},
 }
 
 var azBlobMetricIdentifiers = []azBlobMetricIdentifier{
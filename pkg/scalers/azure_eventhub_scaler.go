@@ -18,6 +18,7 @@ limitations under the License.
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -46,6 +48,10 @@ const (
 	defaultBlobContainer               = ""
 	defaultCheckpointStrategy          = ""
 	defaultStalePartitionInfoThreshold = 10000
+
+	// the checkpoint strategies of the consumers storing their checkpoints outside of a blob storage
+	redisCheckpointStrategy = "redis"
+	grpcCheckpointStrategy  = "grpc"
 )
 
 type azureEventHubScaler struct {
@@ -53,7 +59,9 @@ type azureEventHubScaler struct {
 	metadata          *eventHubMetadata
 	eventHubClient    *azeventhubs.ProducerClient
 	blobStorageClient *azblob.Client
-	logger            logr.Logger
+	// checkpointStore reads the checkpoints stored outside of a blob storage
+	checkpointStore azure.CheckpointStore
+	logger          logr.Logger
 }
 
 type eventHubMetadata struct {
@@ -62,6 +70,30 @@ type eventHubMetadata struct {
 	activationThreshold         int64
 	stalePartitionInfoThreshold int64
 	triggerIndex                int
+	checkpointStore             eventHubCheckpointStoreMetadata
+}
+
+// eventHubCheckpointStoreMetadata is the connection to the Redis or the gRPC checkpoint provider of the redis and
+// grpc checkpoint strategies
+type eventHubCheckpointStoreMetadata struct {
+	RedisAddress             string `keda:"name=redisAddress,              order=triggerMetadata;authParams;resolvedEnv, optional"`
+	RedisUsername            string `keda:"name=redisUsername,             order=authParams;resolvedEnv, optional"`
+	RedisPassword            string `keda:"name=redisPassword,             order=authParams;resolvedEnv, optional"`
+	RedisEnableTLS           bool   `keda:"name=redisEnableTLS,            order=triggerMetadata, optional"`
+	RedisDatabaseIndex       int    `keda:"name=redisDatabaseIndex,        order=triggerMetadata, optional"`
+	RedisCheckpointKeyFormat string `keda:"name=redisCheckpointKeyFormat,  order=triggerMetadata, optional"`
+
+	CheckpointProviderAddress string `keda:"name=checkpointProviderAddress, order=triggerMetadata;authParams, optional"`
+	CaCert                    string `keda:"name=caCert,                    order=authParams, optional"`
+	TLSClientCert             string `keda:"name=tlsClientCert,             order=authParams, optional"`
+	TLSClientKey              string `keda:"name=tlsClientKey,              order=authParams, optional"`
+	UnsafeSsl                 bool   `keda:"name=unsafeSsl,                 order=triggerMetadata, optional"`
+}
+
+// usesCheckpointStore returns whether the checkpoints aren't stored in a blob storage
+func (m *eventHubMetadata) usesCheckpointStore() bool {
+	strategy := m.eventHubInfo.CheckpointStrategy
+	return strategy == redisCheckpointStrategy || strategy == grpcCheckpointStrategy
 }
 
 // NewAzureEventHubScaler creates a new scaler for eventHub
@@ -83,6 +115,21 @@ func NewAzureEventHubScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 		return nil, fmt.Errorf("unable to get eventhub client: %w", err)
 	}
 
+	if parsedMetadata.usesCheckpointStore() {
+		checkpointStore, err := getEventHubCheckpointStore(parsedMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get eventhub checkpoint store: %w", err)
+		}
+
+		return &azureEventHubScaler{
+			metricType:      metricType,
+			metadata:        parsedMetadata,
+			eventHubClient:  eventHubClient,
+			checkpointStore: checkpointStore,
+			logger:          logger,
+		}, nil
+	}
+
 	blobStorageClient, err := azure.GetStorageBlobClient(logger, config.PodIdentity, parsedMetadata.eventHubInfo.StorageConnection, parsedMetadata.eventHubInfo.StorageAccountName, parsedMetadata.eventHubInfo.BlobStorageEndpoint, config.GlobalHTTPTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get eventhub client: %w", err)
@@ -97,6 +144,36 @@ func NewAzureEventHubScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 	}, nil
 }
 
+// getEventHubCheckpointStore returns the store of the checkpoints of the redis or grpc checkpoint strategy
+func getEventHubCheckpointStore(meta *eventHubMetadata) (azure.CheckpointStore, error) {
+	storeMeta := meta.checkpointStore
+	if meta.eventHubInfo.CheckpointStrategy == redisCheckpointStrategy {
+		options := &redis.Options{
+			Addr:     storeMeta.RedisAddress,
+			Username: storeMeta.RedisUsername,
+			Password: storeMeta.RedisPassword,
+			DB:       storeMeta.RedisDatabaseIndex,
+		}
+		if storeMeta.RedisEnableTLS {
+			tlsConfig, err := kedautil.NewTLSConfig(storeMeta.TLSClientCert, storeMeta.TLSClientKey, storeMeta.CaCert, storeMeta.UnsafeSsl)
+			if err != nil {
+				return nil, err
+			}
+			options.TLSConfig = tlsConfig
+		}
+		return azure.NewRedisCheckpointStore(redis.NewClient(options), storeMeta.RedisCheckpointKeyFormat), nil
+	}
+
+	tlsConfig, err := kedautil.NewTLSConfig(storeMeta.TLSClientCert, storeMeta.TLSClientKey, storeMeta.CaCert, storeMeta.UnsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	if len(tlsConfig.Certificates) == 0 && storeMeta.CaCert == "" {
+		tlsConfig = nil
+	}
+	return azure.NewGRPCCheckpointStore(storeMeta.CheckpointProviderAddress, tlsConfig)
+}
+
 // parseAzureEventHubMetadata parses metadata
 func parseAzureEventHubMetadata(logger logr.Logger, config *scalersconfig.ScalerConfig) (*eventHubMetadata, error) {
 	meta := eventHubMetadata{
@@ -154,6 +231,20 @@ func parseCommonAzureEventHubMetadata(config *scalersconfig.ScalerConfig, meta *
 		meta.eventHubInfo.CheckpointStrategy = val
 	}
 
+	if err := config.TypedConfig(&meta.checkpointStore); err != nil {
+		return err
+	}
+	switch meta.eventHubInfo.CheckpointStrategy {
+	case redisCheckpointStrategy:
+		if meta.checkpointStore.RedisAddress == "" {
+			return errors.New("no redisAddress given for the redis checkpoint strategy")
+		}
+	case grpcCheckpointStrategy:
+		if meta.checkpointStore.CheckpointProviderAddress == "" {
+			return errors.New("no checkpointProviderAddress given for the grpc checkpoint strategy")
+		}
+	}
+
 	meta.eventHubInfo.BlobContainer = defaultBlobContainer
 	if val, ok := config.TriggerMetadata["blobContainer"]; ok {
 		meta.eventHubInfo.BlobContainer = val
@@ -187,7 +278,7 @@ func parseAzureEventHubAuthenticationMetadata(logger logr.Logger, config *scaler
 
 	switch config.PodIdentity.Provider {
 	case "", v1alpha1.PodIdentityProviderNone:
-		if len(meta.eventHubInfo.StorageConnection) == 0 {
+		if len(meta.eventHubInfo.StorageConnection) == 0 && !meta.usesCheckpointStore() {
 			return fmt.Errorf("no storage connection string given")
 		}
 
@@ -237,7 +328,7 @@ func parseAzureEventHubAuthenticationMetadata(logger logr.Logger, config *scaler
 			meta.eventHubInfo.BlobStorageEndpoint = "blob." + storageEndpointSuffix
 		}
 
-		if len(meta.eventHubInfo.StorageConnection) == 0 && len(meta.eventHubInfo.StorageAccountName) == 0 {
+		if len(meta.eventHubInfo.StorageConnection) == 0 && len(meta.eventHubInfo.StorageAccountName) == 0 && !meta.usesCheckpointStore() {
 			return fmt.Errorf("no storage connection string or storage account name for pod identity based authentication given")
 		}
 
@@ -272,10 +363,14 @@ func (s *azureEventHubScaler) GetUnprocessedEventCountInPartition(ctx context.Co
 		return 0, azure.Checkpoint{}, nil
 	}
 
-	checkpoint, err = azure.GetCheckpointFromBlobStorage(ctx, s.blobStorageClient, s.metadata.eventHubInfo, partitionInfo.PartitionID)
+	if s.checkpointStore != nil {
+		checkpoint, err = s.checkpointStore.GetCheckpoint(ctx, s.metadata.eventHubInfo, partitionInfo.PartitionID)
+	} else {
+		checkpoint, err = azure.GetCheckpointFromBlobStorage(ctx, s.blobStorageClient, s.metadata.eventHubInfo, partitionInfo.PartitionID)
+	}
 	if err != nil {
 		// if blob not found return the total partition event count
-		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) || errors.Is(err, azure.ErrCheckpointNotFound) {
 			s.logger.V(1).Error(err, fmt.Sprintf("Blob container : %s not found to use checkpoint strategy, getting unprocessed event count without checkpoint", s.metadata.eventHubInfo.BlobContainer))
 			return GetUnprocessedEventCountWithoutCheckpoint(partitionInfo), azure.Checkpoint{}, nil
 		}
@@ -355,6 +450,14 @@ func (s *azureEventHubScaler) Close(ctx context.Context) error {
 		}
 	}
 
+	if s.checkpointStore != nil {
+		err := s.checkpointStore.Close()
+		if err != nil {
+			s.logger.Error(err, "error closing azure event hub checkpoint store")
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -109,6 +109,36 @@ var parseEventHubMetadataDataset = []parseEventHubMetadataTestData{
 		resolvedEnv: map[string]string{eventHubConnectionSetting: "Endpoint=sb://testEventHubNamespace.servicebus.windows.net/;SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=testKey;", storageConnectionSetting: "none"},
 		isError:     false,
 	},
+	// redis checkpoint strategy without storage connection setting
+	{
+		metadata:    map[string]string{"consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "checkpointStrategy": "redis", "redisAddress": "redis:6379"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     false,
+	},
+	// redis checkpoint strategy without redis address
+	{
+		metadata:    map[string]string{"consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "checkpointStrategy": "redis"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
+	// redis checkpoint strategy with invalid database index
+	{
+		metadata:    map[string]string{"consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "checkpointStrategy": "redis", "redisAddress": "redis:6379", "redisDatabaseIndex": "AA"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
+	// grpc checkpoint strategy without storage connection setting
+	{
+		metadata:    map[string]string{"consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "checkpointStrategy": "grpc", "checkpointProviderAddress": "checkpoint-provider:50051"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     false,
+	},
+	// grpc checkpoint strategy without checkpoint provider address
+	{
+		metadata:    map[string]string{"consumerGroup": eventHubConsumerGroup, "connectionFromEnv": eventHubConnectionSetting, "checkpointStrategy": "grpc"},
+		resolvedEnv: sampleEventHubResolvedEnv,
+		isError:     true,
+	},
 }
 
 var parseEventHubMetadataDatasetWithPodIdentity = []parseEventHubMetadataTestData{
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
 	"github.com/go-logr/logr"
@@ -35,6 +36,12 @@ import (
 const (
 	externalMetricType             = "External"
 	queueLengthStrategyVisibleOnly = "visibleonly"
+
+	azureQueueModeQueueLength      = "queueLength"
+	azureQueueModeOldestMessageAge = "oldestMessageAge"
+
+	// azureQueueInvisibleMetricSuffix is the suffix of the metric of the invisible messages
+	azureQueueInvisibleMetricSuffix = "invisible"
 )
 
 var maxPeekMessages int32 = 32
@@ -54,9 +61,29 @@ type azureQueueMetadata struct {
 	AccountName           string `keda:"name=accountName,           order=triggerMetadata, optional"`
 	EndpointSuffix        string `keda:"name=endpointSuffix,        order=triggerMetadata, optional"`
 	QueueLengthStrategy   string `keda:"name=queueLengthStrategy,   order=triggerMetadata, enum=all;visibleonly, default=all"`
+	// Mode oldestMessageAge scales on the age in seconds of the oldest visible message of the queue
+	Mode                 string `keda:"name=mode,                 order=triggerMetadata, enum=queueLength;oldestMessageAge, default=queueLength"`
+	MessageAge           int64  `keda:"name=messageAge,           order=triggerMetadata, default=60"`
+	ActivationMessageAge int64  `keda:"name=activationMessageAge, order=triggerMetadata, default=0"`
+	// InvisibleMessageCount adds a metric of the messages being processed, which are invisible, to the metric of
+	// the age, targeting the queueLength
+	InvisibleMessageCount bool `keda:"name=invisibleMessageCount, order=triggerMetadata, default=false"`
 	TriggerIndex          int
 }
 
+func (m *azureQueueMetadata) Validate() error {
+	if m.Mode != azureQueueModeOldestMessageAge {
+		if m.InvisibleMessageCount {
+			return fmt.Errorf("invisibleMessageCount is only supported in %s mode", azureQueueModeOldestMessageAge)
+		}
+		return nil
+	}
+	if m.MessageAge <= 0 {
+		return fmt.Errorf("messageAge must be greater than 0")
+	}
+	return nil
+}
+
 func NewAzureQueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -124,6 +151,29 @@ func (s *azureQueueScaler) Close(context.Context) error {
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	if s.metadata.Mode == azureQueueModeOldestMessageAge {
+		metricName := kedautil.NormalizeString(fmt.Sprintf("azure-queue-%s-age", s.metadata.QueueName))
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
+				Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, metricName),
+			},
+			Target: GetMetricTarget(s.metricType, s.metadata.MessageAge),
+		}
+		metricSpecs := []v2.MetricSpec{{External: externalMetric, Type: externalMetricType}}
+
+		if s.metadata.InvisibleMessageCount {
+			metricName := kedautil.NormalizeString(fmt.Sprintf("azure-queue-%s-%s", s.metadata.QueueName, azureQueueInvisibleMetricSuffix))
+			externalMetric := &v2.ExternalMetricSource{
+				Metric: v2.MetricIdentifier{
+					Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, metricName),
+				},
+				Target: GetMetricTarget(s.metricType, s.metadata.QueueLength),
+			}
+			metricSpecs = append(metricSpecs, v2.MetricSpec{External: externalMetric, Type: externalMetricType})
+		}
+		return metricSpecs
+	}
+
 	metricName := kedautil.NormalizeString(fmt.Sprintf("azure-queue-%s", s.metadata.QueueName))
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
@@ -136,6 +186,10 @@ func (s *azureQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 }
 
 func (s *azureQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.Mode == azureQueueModeOldestMessageAge {
+		return s.getOldestMessageAgeMetrics(ctx, metricName)
+	}
+
 	queuelen, err := s.getMessageCount(ctx)
 	if err != nil {
 		s.logger.Error(err, "error getting queue length")
@@ -167,3 +221,49 @@ func (s *azureQueueScaler) getMessageCount(ctx context.Context) (int64, error) {
 	}
 	return int64(*props.ApproximateMessagesCount), nil
 }
+
+// getOldestMessageAgeMetrics returns the age of the oldest visible message, or the count of the invisible messages
+// for the metric of the invisible messages
+func (s *azureQueueScaler) getOldestMessageAgeMetrics(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	numberOfMessages := int32(1)
+	if strings.HasSuffix(metricName, azureQueueInvisibleMetricSuffix) {
+		numberOfMessages = maxPeekMessages
+	}
+	queue, err := s.queueClient.PeekMessages(ctx, &azqueue.PeekMessagesOptions{NumberOfMessages: &numberOfMessages})
+	if err != nil {
+		s.logger.Error(err, "error peeking queue messages")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	if strings.HasSuffix(metricName, azureQueueInvisibleMetricSuffix) {
+		props, err := s.queueClient.GetProperties(ctx, nil)
+		if err != nil {
+			s.logger.Error(err, "error getting queue length")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		invisibleMessageCount := getInvisibleMessageCount(int64(*props.ApproximateMessagesCount), len(queue.Messages))
+
+		metric := GenerateMetricInMili(metricName, float64(invisibleMessageCount))
+		return []external_metrics.ExternalMetricValue{metric}, invisibleMessageCount > s.metadata.ActivationQueueLength, nil
+	}
+
+	age := getOldestMessageAge(queue.Messages, time.Now())
+	metric := GenerateMetricInMili(metricName, age)
+	return []external_metrics.ExternalMetricValue{metric}, age > float64(s.metadata.ActivationMessageAge), nil
+}
+
+// getOldestMessageAge returns the age in seconds of the first of the peeked messages, which is the oldest visible
+// message of the queue, or 0 when no message is visible
+func getOldestMessageAge(messages []*azqueue.PeekedMessage, now time.Time) float64 {
+	if len(messages) == 0 || messages[0].InsertionTime == nil {
+		return 0
+	}
+	return max(now.Sub(*messages[0].InsertionTime).Seconds(), 0)
+}
+
+// getInvisibleMessageCount returns the approximate count of the messages being processed, which are the messages
+// of the queue beyond the visible ones. As at most 32 messages can be peeked, the visible messages of a longer
+// queue are counted as 32.
+func getInvisibleMessageCount(approximateMessageCount int64, visibleMessageCount int) int64 {
+	return max(approximateMessageCount-int64(visibleMessageCount), 0)
+}
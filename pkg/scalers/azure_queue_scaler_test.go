@@ -19,7 +19,9 @@ package scalers
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azqueue"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -185,6 +187,46 @@ var testAzQueueMetadata = []parseAzQueueMetadataTestData{
 		authParams:  map[string]string{},
 		podIdentity: "",
 	},
+	{
+		name:        "oldestMessageAge mode",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "mode": "oldestMessageAge", "messageAge": "30"},
+		isError:     false,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "oldestMessageAge mode with invisible message count",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "mode": "oldestMessageAge", "invisibleMessageCount": "true"},
+		isError:     false,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "invalid mode",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "mode": "invalid"},
+		isError:     true,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "oldestMessageAge mode with messageAge not positive",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "mode": "oldestMessageAge", "messageAge": "0"},
+		isError:     true,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
+	{
+		name:        "invisible message count in queueLength mode",
+		metadata:    map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "invisibleMessageCount": "true"},
+		isError:     true,
+		resolvedEnv: testAzQueueResolvedEnv,
+		authParams:  map[string]string{},
+		podIdentity: "",
+	},
 }
 
 var azQueueMetricIdentifiers = []azQueueMetricIdentifier{
@@ -217,6 +259,51 @@ func (m *mockAzureQueueClient) getMessageCount(visibleOnly bool) int64 {
 	return int64(m.totalMessages)
 }
 
+func TestAzQueueGetOldestMessageAgeMetricSpecs(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: testAzQueueMetadata[18].metadata,
+		ResolvedEnv:     testAzQueueResolvedEnv,
+		AuthParams:      map[string]string{},
+		TriggerIndex:    2,
+	}
+
+	meta, _, err := parseAzureQueueMetadata(config)
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	mockAzQueueScaler := azureQueueScaler{
+		metadata:   meta,
+		logger:     logr.Discard(),
+		metricType: v2.AverageValueMetricType,
+	}
+
+	metricSpec := mockAzQueueScaler.GetMetricSpecForScaling(context.Background())
+	assert.Len(t, metricSpec, 2)
+	assert.Equal(t, "s2-azure-queue-sample-age", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(60), metricSpec[0].External.Target.AverageValue.Value())
+	assert.Equal(t, "s2-azure-queue-sample-invisible", metricSpec[1].External.Metric.Name)
+	assert.Equal(t, int64(5), metricSpec[1].External.Target.AverageValue.Value())
+}
+
+func TestAzQueueGetOldestMessageAge(t *testing.T) {
+	now := time.Now()
+	insertionTime := now.Add(-90 * time.Second)
+	futureInsertionTime := now.Add(time.Second)
+
+	assert.Equal(t, float64(0), getOldestMessageAge(nil, now))
+	assert.Equal(t, float64(90), getOldestMessageAge([]*azqueue.PeekedMessage{{InsertionTime: &insertionTime}}, now))
+	// clock skew between the storage account and the cluster
+	assert.Equal(t, float64(0), getOldestMessageAge([]*azqueue.PeekedMessage{{InsertionTime: &futureInsertionTime}}, now))
+}
+
+func TestAzQueueGetInvisibleMessageCount(t *testing.T) {
+	assert.Equal(t, int64(90), getInvisibleMessageCount(100, 10))
+	assert.Equal(t, int64(68), getInvisibleMessageCount(100, 32))
+	// the approximate count lags behind the peeked messages
+	assert.Equal(t, int64(0), getInvisibleMessageCount(5, 10))
+}
+
 func TestAzQueueParseMetadata(t *testing.T) {
 	for _, testData := range testAzQueueMetadata {
 		t.Run(testData.name, func(t *testing.T) {
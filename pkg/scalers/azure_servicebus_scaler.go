@@ -18,12 +18,14 @@ limitations under the License.
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
@@ -45,6 +47,15 @@ const (
 	messageCountMetricName                      = "messageCount"
 	activationMessageCountMetricName            = "activationMessageCount"
 	defaultTargetMessageCount                   = 5
+
+	serviceBusModeMessageCount = "messageCount"
+	serviceBusModeSessionCount = "sessionCount"
+
+	defaultTargetSessionCount = 1
+	defaultMaxSessionCount    = 100
+	// serviceBusSessionAcceptTimeout is how long an available session is waited for, the service only answering
+	// when there is one or the request times out
+	serviceBusSessionAcceptTimeout = 2 * time.Second
 )
 
 type azureServiceBusScaler struct {
@@ -53,7 +64,9 @@ type azureServiceBusScaler struct {
 	metadata    *azureServiceBusMetadata
 	podIdentity kedav1alpha1.AuthPodIdentity
 	client      *admin.Client
-	logger      logr.Logger
+	// sessionClient accepts the sessions of the entity in the sessionCount mode
+	sessionClient *azservicebus.Client
+	logger        logr.Logger
 }
 
 type azureServiceBusMetadata struct {
@@ -70,6 +83,13 @@ type azureServiceBusMetadata struct {
 	operation               string
 	triggerIndex            int
 	timeout                 time.Duration
+	deadLetter              deadLetterMetadata
+	// mode is whether the consumers are scaled on the messages of the entity, or on its sessions as the
+	// consumers of a session-enabled entity process a limited number of sessions concurrently
+	mode                   string
+	targetSessionCount     int64
+	activationSessionCount int64
+	maxSessionCount        int64
 }
 
 // NewAzureServiceBusScaler creates a new AzureServiceBusScaler
@@ -191,6 +211,14 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 		return nil, fmt.Errorf("no service bus entity type set")
 	}
 
+	if err := config.TypedConfig(&meta.deadLetter); err != nil {
+		return nil, err
+	}
+
+	if err := parseAzureServiceBusSessionMetadata(config, &meta); err != nil {
+		return nil, err
+	}
+
 	switch config.PodIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
 		// get servicebus connection string
@@ -227,8 +255,59 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 	return &meta, nil
 }
 
-// Close - nothing to close for SB
-func (s *azureServiceBusScaler) Close(context.Context) error {
+// parseAzureServiceBusSessionMetadata parses the mode and the metadata of the sessionCount mode
+func parseAzureServiceBusSessionMetadata(config *scalersconfig.ScalerConfig, meta *azureServiceBusMetadata) error {
+	meta.mode = serviceBusModeMessageCount
+	if val, ok := config.TriggerMetadata["mode"]; ok && val != "" {
+		if val != serviceBusModeMessageCount && val != serviceBusModeSessionCount {
+			return fmt.Errorf("mode must be %s or %s", serviceBusModeMessageCount, serviceBusModeSessionCount)
+		}
+		meta.mode = val
+	}
+	if meta.mode != serviceBusModeSessionCount {
+		return nil
+	}
+
+	if meta.useRegex {
+		return errors.New("useRegex isn't supported with the sessionCount mode")
+	}
+	if meta.deadLetter.enabled() {
+		return errors.New("dlqBehavior isn't supported with the sessionCount mode")
+	}
+
+	values := []struct {
+		name         string
+		value        *int64
+		defaultValue int64
+	}{
+		{"sessionCount", &meta.targetSessionCount, defaultTargetSessionCount},
+		{"activationSessionCount", &meta.activationSessionCount, 0},
+		{"maxSessionCount", &meta.maxSessionCount, defaultMaxSessionCount},
+	}
+	for _, v := range values {
+		*v.value = v.defaultValue
+		if val, ok := config.TriggerMetadata[v.name]; ok {
+			parsed, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", v.name, err)
+			}
+			*v.value = parsed
+		}
+	}
+	if meta.targetSessionCount <= 0 {
+		return errors.New("sessionCount must be greater than 0")
+	}
+	if meta.maxSessionCount <= 0 {
+		return errors.New("maxSessionCount must be greater than 0")
+	}
+	return nil
+}
+
+// Close closes the client of the sessions, the admin client having nothing to close
+func (s *azureServiceBusScaler) Close(ctx context.Context) error {
+	if s.sessionClient != nil {
+		return s.sessionClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -249,11 +328,17 @@ func (s *azureServiceBusScaler) GetMetricSpecForScaling(context.Context) []v2.Me
 		metricName = fmt.Sprintf("%s-regex", entityType)
 	}
 
+	target := s.metadata.targetLength
+	if s.metadata.mode == serviceBusModeSessionCount {
+		metricName = fmt.Sprintf("%s-sessions", metricName)
+		target = s.metadata.targetSessionCount
+	}
+
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-servicebus-%s", metricName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetLength),
+		Target: GetMetricTarget(s.metricType, target),
 	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2.MetricSpec{metricSpec}
@@ -261,24 +346,36 @@ func (s *azureServiceBusScaler) GetMetricSpecForScaling(context.Context) []v2.Me
 
 // GetMetricsAndActivity returns the current metrics to be served to the HPA
 func (s *azureServiceBusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	queuelen, err := s.getAzureServiceBusLength(ctx)
+	if s.metadata.mode == serviceBusModeSessionCount {
+		sessionCount, err := s.getAzureServiceBusSessionCount(ctx)
+		if err != nil {
+			s.logger.Error(err, "error getting service bus entity sessions")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+
+		metric := GenerateMetricInMili(metricName, float64(sessionCount))
+		return []external_metrics.ExternalMetricValue{metric}, sessionCount > s.metadata.activationSessionCount, nil
+	}
+
+	queuelen, deadLetterLen, err := s.getAzureServiceBusLength(ctx)
 
 	if err != nil {
 		s.logger.Error(err, "error getting service bus entity length")
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(queuelen))
+	value, isActive := s.metadata.deadLetter.apply(float64(queuelen), float64(deadLetterLen), float64(s.metadata.activationTargetLength))
+	metric := GenerateMetricInMili(metricName, value)
 
-	return []external_metrics.ExternalMetricValue{metric}, queuelen > s.metadata.activationTargetLength, nil
+	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
 }
 
-// Returns the length of the queue or subscription
-func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (int64, error) {
+// Returns the length of the queue or subscription and the length of its dead-letter subqueue
+func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (int64, int64, error) {
 	// get adminClient
 	adminClient, err := s.getServiceBusAdminClient()
 	if err != nil {
-		return -1, err
+		return -1, -1, err
 	}
 	// switch case for queue vs topic here
 	switch s.metadata.entityType {
@@ -287,8 +384,87 @@ func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (i
 	case subscription:
 		return getSubscriptionLength(ctx, adminClient, s.metadata)
 	default:
-		return -1, fmt.Errorf("no entity type")
+		return -1, -1, fmt.Errorf("no entity type")
+	}
+}
+
+// serviceBusSession is an accepted session, whose lock is released when it is closed
+type serviceBusSession interface {
+	Close(ctx context.Context) error
+}
+
+// getAzureServiceBusSessionCount returns the number of sessions with messages that no receiver has accepted, i.e.
+// the sessions waiting for a consumer. The sessions are accepted one after the other, without receiving any of
+// their messages, until none is available, then released. An entity without messages has no session to accept.
+func (s *azureServiceBusScaler) getAzureServiceBusSessionCount(ctx context.Context) (int64, error) {
+	length, _, err := s.getAzureServiceBusLength(ctx)
+	if err != nil || length == 0 {
+		return 0, err
+	}
+
+	client, err := s.getServiceBusSessionClient()
+	if err != nil {
+		return -1, err
+	}
+
+	acceptNextSession := func(ctx context.Context) (serviceBusSession, error) {
+		if s.metadata.entityType == queue {
+			return client.AcceptNextSessionForQueue(ctx, s.metadata.queueName, nil)
+		}
+		return client.AcceptNextSessionForSubscription(ctx, s.metadata.topicName, s.metadata.subscriptionName, nil)
+	}
+	return countAvailableServiceBusSessions(ctx, acceptNextSession, s.metadata.maxSessionCount)
+}
+
+// countAvailableServiceBusSessions accepts the available sessions, up to the maximum, and releases them
+func countAvailableServiceBusSessions(ctx context.Context, acceptNextSession func(context.Context) (serviceBusSession, error), maxSessionCount int64) (int64, error) {
+	var sessions []serviceBusSession
+	defer func() {
+		for _, session := range sessions {
+			_ = session.Close(ctx)
+		}
+	}()
+
+	for int64(len(sessions)) < maxSessionCount {
+		acceptCtx, cancel := context.WithTimeout(ctx, serviceBusSessionAcceptTimeout)
+		session, err := acceptNextSession(acceptCtx)
+		cancel()
+
+		var sbErr *azservicebus.Error
+		if (errors.As(err, &sbErr) && sbErr.Code == azservicebus.CodeTimeout) || (errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil) {
+			break
+		}
+		if err != nil {
+			return -1, err
+		}
+		sessions = append(sessions, session)
+	}
+	return int64(len(sessions)), nil
+}
+
+// Returns the client of the sessions of the service bus namespace
+func (s *azureServiceBusScaler) getServiceBusSessionClient() (*azservicebus.Client, error) {
+	if s.sessionClient != nil {
+		return s.sessionClient, nil
+	}
+	var err error
+	var client *azservicebus.Client
+
+	switch s.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		client, err = azservicebus.NewClientFromConnectionString(s.metadata.connection, nil)
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		creds, chainedErr := azure.NewChainedCredential(s.logger, s.podIdentity)
+		if chainedErr != nil {
+			return nil, chainedErr
+		}
+		client, err = azservicebus.NewClient(s.metadata.fullyQualifiedNamespace, creds, nil)
+	default:
+		err = fmt.Errorf("incorrect podIdentity type")
 	}
+
+	s.sessionClient = client
+	return client, err
 }
 
 // Returns service bus namespace object
@@ -321,69 +497,73 @@ func (s *azureServiceBusScaler) getServiceBusAdminClient() (*admin.Client, error
 	return client, err
 }
 
-func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, error) {
+func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, int64, error) {
 	if !meta.useRegex {
 		queueEntity, err := adminClient.GetQueueRuntimeProperties(ctx, meta.queueName, &admin.GetQueueRuntimePropertiesOptions{})
 		if err != nil {
-			return -1, err
+			return -1, -1, err
 		}
 		if queueEntity == nil {
-			return -1, fmt.Errorf("queue %s doesn't exist", meta.queueName)
+			return -1, -1, fmt.Errorf("queue %s doesn't exist", meta.queueName)
 		}
 
-		return int64(queueEntity.ActiveMessageCount), nil
+		return int64(queueEntity.ActiveMessageCount), int64(queueEntity.DeadLetterMessageCount), nil
 	}
 
 	messageCounts := make([]int64, 0)
+	deadLetterCounts := make([]int64, 0)
 
 	queuePager := adminClient.NewListQueuesRuntimePropertiesPager(nil)
 	for queuePager.More() {
 		page, err := queuePager.NextPage(ctx)
 		if err != nil {
-			return -1, err
+			return -1, -1, err
 		}
 
 		for _, queue := range page.QueueRuntimeProperties {
 			if meta.entityNameRegex.FindString(queue.QueueName) == queue.QueueName {
 				messageCounts = append(messageCounts, int64(queue.ActiveMessageCount))
+				deadLetterCounts = append(deadLetterCounts, int64(queue.DeadLetterMessageCount))
 			}
 		}
 	}
 
-	return performOperation(messageCounts, meta.operation), nil
+	return performOperation(messageCounts, meta.operation), performOperation(deadLetterCounts, meta.operation), nil
 }
 
-func getSubscriptionLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, error) {
+func getSubscriptionLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, int64, error) {
 	if !meta.useRegex {
 		subscriptionEntity, err := adminClient.GetSubscriptionRuntimeProperties(ctx, meta.topicName, meta.subscriptionName,
 			&admin.GetSubscriptionRuntimePropertiesOptions{})
 		if err != nil {
-			return -1, err
+			return -1, -1, err
 		}
 		if subscriptionEntity == nil {
-			return -1, fmt.Errorf("subscription %s doesn't exist in topic %s", meta.subscriptionName, meta.topicName)
+			return -1, -1, fmt.Errorf("subscription %s doesn't exist in topic %s", meta.subscriptionName, meta.topicName)
 		}
 
-		return int64(subscriptionEntity.ActiveMessageCount), nil
+		return int64(subscriptionEntity.ActiveMessageCount), int64(subscriptionEntity.DeadLetterMessageCount), nil
 	}
 
 	messageCounts := make([]int64, 0)
+	deadLetterCounts := make([]int64, 0)
 
 	subscriptionPager := adminClient.NewListSubscriptionsRuntimePropertiesPager(meta.topicName, nil)
 	for subscriptionPager.More() {
 		page, err := subscriptionPager.NextPage(ctx)
 		if err != nil {
-			return -1, err
+			return -1, -1, err
 		}
 
 		for _, subscription := range page.SubscriptionRuntimeProperties {
 			if meta.entityNameRegex.FindString(subscription.SubscriptionName) == subscription.SubscriptionName {
 				messageCounts = append(messageCounts, int64(subscription.ActiveMessageCount))
+				deadLetterCounts = append(deadLetterCounts, int64(subscription.DeadLetterMessageCount))
 			}
 		}
 	}
 
-	return performOperation(messageCounts, meta.operation), nil
+	return performOperation(messageCounts, meta.operation), performOperation(deadLetterCounts, meta.operation), nil
 }
 
 func performOperation(messageCounts []int64, operation string) int64 {
@@ -18,10 +18,12 @@ package scalers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
@@ -123,11 +125,28 @@ var parseServiceBusMetadataDataset = []parseServiceBusMetadataTestData{
 	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "random"}, true, subscription, defaultSuffix, map[string]string{}, ""},
 	// subscription with invalid regex string
 	{map[string]string{"topicName": topicName, "subscriptionName": "*", "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "avg"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+	// queue with its dead-letter subqueue added
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "dlqBehavior": "add"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// subscription with invalid dlqBehavior
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "dlqBehavior": "subtract"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+	// queue scaled on its sessions
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "mode": "sessionCount", "sessionCount": "4"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// subscription scaled on its sessions
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "mode": "sessionCount", "maxSessionCount": "50"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// invalid mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "mode": "lockedMessageCount"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// sessions with an invalid session count
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "mode": "sessionCount", "sessionCount": "0"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// sessions of queues matching a regex
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "mode": "sessionCount", "useRegex": "true"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// sessions with the dead-letter subqueue added
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "mode": "sessionCount", "dlqBehavior": "add"}, true, queue, defaultSuffix, map[string]string{}, ""},
 }
 
 var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
 	{&parseServiceBusMetadataDataset[1], 0, "s0-azure-servicebus-testqueue"},
 	{&parseServiceBusMetadataDataset[3], 1, "s1-azure-servicebus-testtopic"},
+	{&parseServiceBusMetadataDataset[35], 2, "s2-azure-servicebus-testqueue-sessions"},
 }
 
 var getServiceBusLengthTestScalers = []azureServiceBusScaler{
@@ -209,7 +228,7 @@ func TestGetServiceBusLength(t *testing.T) {
 		if connectionString != "" {
 			// Can actually test that numbers return
 			scaler.metadata.connection = connectionString
-			length, err := scaler.getAzureServiceBusLength(context.TODO())
+			length, _, err := scaler.getAzureServiceBusLength(context.TODO())
 
 			if err != nil {
 				t.Errorf("Expected success but got error: %s", err)
@@ -220,7 +239,7 @@ func TestGetServiceBusLength(t *testing.T) {
 			}
 		} else {
 			// Just test error message
-			length, err := scaler.getAzureServiceBusLength(context.TODO())
+			length, _, err := scaler.getAzureServiceBusLength(context.TODO())
 
 			if length != -1 || err == nil {
 				t.Errorf("Expected error but got success")
@@ -250,3 +269,49 @@ func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+type testServiceBusSession struct {
+	closed *int
+}
+
+func (s testServiceBusSession) Close(context.Context) error {
+	*s.closed++
+	return nil
+}
+
+func TestCountAvailableServiceBusSessions(t *testing.T) {
+	testCases := []struct {
+		comment         string
+		available       int
+		maxSessionCount int64
+		err             error
+		count           int64
+	}{
+		{"no available session", 0, 10, nil, 0},
+		{"available sessions", 3, 10, nil, 3},
+		{"available sessions beyond the maximum", 30, 10, nil, 10},
+		{"error accepting a session", 3, 10, errors.New("unauthorized"), -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.comment, func(t *testing.T) {
+			accepted, closed := 0, 0
+			acceptNextSession := func(context.Context) (serviceBusSession, error) {
+				if accepted == tc.available {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					// the service times out when no session is available
+					return nil, &azservicebus.Error{Code: azservicebus.CodeTimeout}
+				}
+				accepted++
+				return testServiceBusSession{&closed}, nil
+			}
+
+			count, err := countAvailableServiceBusSessions(context.Background(), acceptNextSession, tc.maxSessionCount)
+			assert.Equal(t, tc.count, count)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, accepted, closed, "the accepted sessions must be released")
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	beanstalk "github.com/beanstalkd/go-beanstalk"
@@ -29,17 +30,17 @@ type BeanstalkdScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *BeanstalkdMetadata
 	connection *beanstalk.Conn
-	tube       *beanstalk.Tube
+	tubes      []*beanstalk.Tube
 	logger     logr.Logger
 }
 
 type BeanstalkdMetadata struct {
-	Server          string  `keda:"name=server, order=triggerMetadata"`
-	Tube            string  `keda:"name=tube, order=triggerMetadata"`
-	Value           float64 `keda:"name=value, order=triggerMetadata"`
-	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional"`
-	IncludeDelayed  bool    `keda:"name=includeDelayed, order=triggerMetadata, optional"`
-	Timeout         uint    `keda:"name=timeout, order=triggerMetadata, optional, default=30"`
+	Server          string   `keda:"name=server, order=triggerMetadata"`
+	Tubes           []string `keda:"name=tube;tubes, order=triggerMetadata"`
+	Value           float64  `keda:"name=value, order=triggerMetadata"`
+	ActivationValue float64  `keda:"name=activationValue, order=triggerMetadata, optional"`
+	IncludeDelayed  bool     `keda:"name=includeDelayed, order=triggerMetadata, optional"`
+	Timeout         uint     `keda:"name=timeout, order=triggerMetadata, optional, default=30"`
 	TriggerIndex    int
 }
 
@@ -79,7 +80,9 @@ func NewBeanstalkdScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	s.connection = conn
 
-	s.tube = beanstalk.NewTube(s.connection, meta.Tube)
+	for _, tube := range meta.Tubes {
+		s.tubes = append(s.tubes, beanstalk.NewTube(s.connection, tube))
+	}
 
 	return s, nil
 }
@@ -95,20 +98,40 @@ func parseBeanstalkdMetadata(config *scalersconfig.ScalerConfig) (*BeanstalkdMet
 	return meta, nil
 }
 
+// getTubeStats returns the sum of the statistics of the tubes
 func (s *BeanstalkdScaler) getTubeStats(ctx context.Context) (*tubeStats, error) {
-	errCh := make(chan error)
-	statsCh := make(chan *tubeStats)
+	total := &tubeStats{}
+	for _, tube := range s.tubes {
+		stats, err := s.getSingleTubeStats(ctx, tube)
+		if err != nil {
+			return nil, err
+		}
+		total.TotalJobs += stats.TotalJobs
+		total.JobsReady += stats.JobsReady
+		total.JobsReserved += stats.JobsReserved
+		total.JobsUrgent += stats.JobsUrgent
+		total.JobsBuried += stats.JobsBuried
+		total.JobsDelayed += stats.JobsDelayed
+	}
+	return total, nil
+}
+
+func (s *BeanstalkdScaler) getSingleTubeStats(ctx context.Context, tube *beanstalk.Tube) (*tubeStats, error) {
+	errCh := make(chan error, 1)
+	statsCh := make(chan *tubeStats, 1)
 
 	go func() {
-		rawStats, err := s.tube.Stats()
+		rawStats, err := tube.Stats()
 		if err != nil {
 			errCh <- fmt.Errorf("error retrieving stats from beanstalkd: %w", err)
+			return
 		}
 
 		var stats tubeStats
 		err = mapstructure.WeakDecode(rawStats, &stats)
 		if err != nil {
 			errCh <- fmt.Errorf("error decoding stats from beanstalkd: %w", err)
+			return
 		}
 
 		statsCh <- &stats
@@ -117,15 +140,8 @@ func (s *BeanstalkdScaler) getTubeStats(ctx context.Context) (*tubeStats, error)
 	select {
 	case err := <-errCh:
 		if errors.Is(err, beanstalk.ErrNotFound) {
-			s.logger.Info("tube not found, setting stats to 0")
-			return &tubeStats{
-				TotalJobs:    0,
-				JobsReady:    0,
-				JobsDelayed:  0,
-				JobsReserved: 0,
-				JobsUrgent:   0,
-				JobsBuried:   0,
-			}, nil
+			s.logger.Info("tube not found, setting stats to 0", "tube", tube.Name)
+			return &tubeStats{}, nil
 		}
 		return nil, err
 	case tubeStats := <-statsCh:
@@ -156,7 +172,7 @@ func (s *BeanstalkdScaler) GetMetricsAndActivity(ctx context.Context, metricName
 func (s *BeanstalkdScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, util.NormalizeString(fmt.Sprintf("beanstalkd-%s", url.QueryEscape(s.metadata.Tube)))),
+			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, util.NormalizeString(fmt.Sprintf("beanstalkd-%s", url.QueryEscape(strings.Join(s.metadata.Tubes, "-"))))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
 	}
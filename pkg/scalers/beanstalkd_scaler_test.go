@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net"
@@ -58,11 +59,14 @@ var testBeanstalkdMetadata = []parseBeanstalkdMetadataTestData{
 	{map[string]string{"server": beanstalkdServer, "tube": "stats-tube", "value": "1", "activationValue": "10"}, false},
 	// invalid activationValue passed
 	{map[string]string{"server": beanstalkdServer, "tube": "stats-tube", "value": "1", "activationValue": "AA"}, true},
+	// multiple tubes
+	{map[string]string{"server": beanstalkdServer, "tubes": "emails,notifications", "value": "1"}, false},
 }
 
 var beanstalkdMetricIdentifiers = []beanstalkdMetricIdentifier{
 	{&testBeanstalkdMetadata[2], 0, "s0-beanstalkd-no-delayed"},
 	{&testBeanstalkdMetadata[1], 1, "s1-beanstalkd-delayed"},
+	{&testBeanstalkdMetadata[11], 2, "s2-beanstalkd-emails-notifications"},
 }
 
 var testTubeStatsTestData = []tubeStatsTestData{
@@ -179,7 +183,7 @@ func TestBeanstalkdGetMetricSpecForScaling(t *testing.T) {
 		mockBeanstalkdScaler := BeanstalkdScaler{
 			metadata:   meta,
 			connection: nil,
-			tube:       nil,
+			tubes:      nil,
 		}
 
 		metricSpec := mockBeanstalkdScaler.GetMetricSpecForScaling(context.Background())
@@ -238,6 +242,62 @@ func TestGetTubeStatsNotFound(t *testing.T) {
 	assert.False(t, active)
 }
 
+func TestGetTubeStatsMultipleTubes(t *testing.T) {
+	yamlData, err := yaml.Marshal(map[string]interface{}{
+		"current-jobs-delayed":  1,
+		"current-jobs-ready":    2,
+		"current-jobs-reserved": 0,
+		"name":                  "emails",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first tube has stats, the second tube doesn't exist
+	response := []byte(fmt.Sprintf("OK %d\r\n", len(yamlData)))
+	response = append(response, yamlData...)
+	response = append(response, []byte("\r\n")...)
+	createTestServerWithResponses(t, response, []byte("NOT_FOUND\r\n"))
+
+	s, err := NewBeanstalkdScaler(
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata:   map[string]string{"server": beanstalkdServer, "tubes": "emails,notifications", "value": "2", "includeDelayed": "true"},
+			GlobalHTTPTimeout: 1000 * time.Millisecond,
+		},
+	)
+	assert.NoError(t, err)
+
+	metrics, active, err := s.GetMetricsAndActivity(context.Background(), "Metric")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, int64(3), metrics[0].Value.Value())
+}
+
+// createTestServerWithResponses answers each command received with the next response
+func createTestServerWithResponses(t *testing.T, responses ...[]byte) {
+	list, err := net.Listen("tcp", "localhost:3000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		defer list.Close()
+		conn, err := list.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		for _, response := range responses {
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+			_, err = conn.Write(response)
+			assert.NoError(t, err)
+		}
+	}()
+}
+
 func createTestServer(t *testing.T, response []byte) {
 	list, err := net.Listen("tcp", "localhost:3000")
 	if err != nil {
@@ -0,0 +1,166 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type buildkiteScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *buildkiteMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type buildkiteMetadata struct {
+	triggerIndex int
+
+	AgentEndpoint            string `keda:"name=agentEndpoint,            order=triggerMetadata, optional, default=https://agent.buildkite.com/v3"`
+	Queue                    string `keda:"name=queue,                    order=triggerMetadata, optional"`
+	TargetJobCount           int64  `keda:"name=targetJobCount,           order=triggerMetadata, optional, default=1"`
+	ActivationTargetJobCount int64  `keda:"name=activationTargetJobCount, order=triggerMetadata, optional"`
+	IncludeRunning           bool   `keda:"name=includeRunning,           order=triggerMetadata, optional, default=true"`
+	IncludeWaiting           bool   `keda:"name=includeWaiting,           order=triggerMetadata, optional"`
+
+	AgentToken string `keda:"name=agentToken, order=authParams;resolvedEnv"`
+}
+
+// buildkiteJobMetrics are the jobs of the organization, or of the queue. Waiting jobs are
+// waiting on other jobs to finish, so they can't be assigned to an agent yet.
+type buildkiteJobMetrics struct {
+	Scheduled int64 `json:"scheduled"`
+	Running   int64 `json:"running"`
+	Waiting   int64 `json:"waiting"`
+}
+
+type buildkiteMetrics struct {
+	Jobs buildkiteJobMetrics `json:"jobs"`
+}
+
+func (m *buildkiteMetadata) Validate() error {
+	if m.TargetJobCount <= 0 {
+		return errors.New("targetJobCount must be greater than 0")
+	}
+	m.AgentEndpoint = strings.TrimSuffix(m.AgentEndpoint, "/")
+	return nil
+}
+
+// NewBuildkiteScaler creates a new buildkiteScaler
+func NewBuildkiteScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseBuildkiteMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing buildkite metadata: %w", err)
+	}
+
+	return &buildkiteScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "buildkite_scaler"),
+	}, nil
+}
+
+func parseBuildkiteMetadata(config *scalersconfig.ScalerConfig) (*buildkiteMetadata, error) {
+	meta := &buildkiteMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *buildkiteScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *buildkiteScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := "buildkite"
+	if s.metadata.Queue != "" {
+		name += "-" + s.metadata.Queue
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetJobCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of scheduled jobs, including the running and waiting ones if enabled
+func (s *buildkiteScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	metrics, err := s.getMetrics(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting buildkite agent metrics: %w", err)
+	}
+
+	jobs := metrics.Jobs.Scheduled
+	if s.metadata.IncludeRunning {
+		jobs += metrics.Jobs.Running
+	}
+	if s.metadata.IncludeWaiting {
+		jobs += metrics.Jobs.Waiting
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(jobs))
+	return []external_metrics.ExternalMetricValue{metric}, jobs > s.metadata.ActivationTargetJobCount, nil
+}
+
+// getMetrics returns the metrics of the queue, or of the whole organization when no queue is provided
+func (s *buildkiteScaler) getMetrics(ctx context.Context) (*buildkiteMetrics, error) {
+	metricsURL := s.metadata.AgentEndpoint + "/metrics"
+	if s.metadata.Queue != "" {
+		metricsURL += "/queue?name=" + url.QueryEscape(s.metadata.Queue)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Token "+s.metadata.AgentToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from buildkite: %s", resp.StatusCode, string(body))
+	}
+
+	var metrics buildkiteMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return nil, fmt.Errorf("error parsing buildkite response: %w", err)
+	}
+	return &metrics, nil
+}
@@ -0,0 +1,112 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseBuildkiteMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type buildkiteMetricIdentifier struct {
+	metadataTestData *parseBuildkiteMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testBuildkiteMetadata = []parseBuildkiteMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{}, map[string]string{"agentToken": "token"}, false, "agent token only"},
+	{map[string]string{"agentEndpoint": "https://agent.example.com/v3/", "queue": "linux", "targetJobCount": "2", "activationTargetJobCount": "1", "includeRunning": "false", "includeWaiting": "true"}, map[string]string{"agentToken": "token"}, false, "all metadata"},
+	{map[string]string{"targetJobCount": "0"}, map[string]string{"agentToken": "token"}, true, "zero targetJobCount"},
+	{map[string]string{"includeRunning": "maybe"}, map[string]string{"agentToken": "token"}, true, "invalid includeRunning"},
+}
+
+var buildkiteMetricIdentifiers = []buildkiteMetricIdentifier{
+	{&testBuildkiteMetadata[1], 0, "s0-buildkite"},
+	{&testBuildkiteMetadata[2], 1, "s1-buildkite-linux"},
+}
+
+func TestBuildkiteParseMetadata(t *testing.T) {
+	for _, testData := range testBuildkiteMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseBuildkiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestBuildkiteGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range buildkiteMetricIdentifiers {
+		meta, err := parseBuildkiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockBuildkiteScaler := buildkiteScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockBuildkiteScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestBuildkiteGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v3/metrics":
+			_, _ = w.Write([]byte(`{"agents":{"idle":1,"busy":4,"total":5},"jobs":{"scheduled":6,"running":4,"waiting":2,"total":12},"organization":{"slug":"acme"}}`))
+		case "/v3/metrics/queue":
+			assert.Equal(t, "linux", r.URL.Query().Get("name"))
+			_, _ = w.Write([]byte(`{"agents":{"idle":0,"busy":3,"total":3},"jobs":{"scheduled":2,"running":3,"waiting":1,"total":6},"organization":{"slug":"acme"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"scheduled and running jobs of the organization", map[string]string{}, 10, true},
+		{"scheduled and running jobs of the queue", map[string]string{"queue": "linux", "activationTargetJobCount": "5"}, 5, false},
+		{"scheduled and waiting jobs of the queue", map[string]string{"queue": "linux", "includeRunning": "false", "includeWaiting": "true"}, 3, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["agentEndpoint"] = server.URL + "/v3"
+			s, err := NewBuildkiteScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"agentToken": "token"},
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "buildkite")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// bullMQDelayedScoreShift is the number of bits BullMQ shifts the timestamp by in the score of the delayed jobs,
+// the lower bits hold a counter keeping the jobs delayed to the same millisecond ordered
+const bullMQDelayedScoreShift = 12
+
+type bullMQScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *bullMQMetadata
+	client     redis.UniversalClient
+	logger     logr.Logger
+}
+
+type bullMQMetadata struct {
+	QueueName                string              `keda:"name=queueName,                order=triggerMetadata"`
+	Prefix                   string              `keda:"name=prefix,                   order=triggerMetadata, optional, default=bull"`
+	TargetJobCount           int64               `keda:"name=targetJobCount,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetJobCount int64               `keda:"name=activationTargetJobCount, order=triggerMetadata, optional"`
+	DatabaseIndex            int                 `keda:"name=databaseIndex,            order=triggerMetadata, optional"`
+	MetadataEnableTLS        string              `keda:"name=enableTLS,                order=triggerMetadata, optional"`
+	AuthParamEnableTLS       string              `keda:"name=tls,                      order=authParams, optional"`
+	ConnectionInfo           redisConnectionInfo `keda:"optional"`
+	triggerIndex             int
+}
+
+func (m *bullMQMetadata) Validate() error {
+	if m.TargetJobCount <= 0 {
+		return errors.New("targetJobCount must be greater than 0")
+	}
+
+	if err := validateRedisAddress(&m.ConnectionInfo); err != nil {
+		return err
+	}
+
+	err := m.ConnectionInfo.SetEnableTLS(m.MetadataEnableTLS, m.AuthParamEnableTLS)
+	if err == nil {
+		m.MetadataEnableTLS, m.AuthParamEnableTLS = "", ""
+	}
+	return err
+}
+
+// NewBullMQScaler creates a new bullMQScaler
+func NewBullMQScaler(ctx context.Context, isClustered, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseBullMQMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bullmq metadata: %w", err)
+	}
+
+	var client redis.UniversalClient
+	switch {
+	case isClustered:
+		client, err = getRedisClusterClient(ctx, meta.ConnectionInfo)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis cluster failed: %w", err)
+		}
+	case isSentinel:
+		client, err = getRedisSentinelClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis sentinel failed: %w", err)
+		}
+	default:
+		client, err = getRedisClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis failed: %w", err)
+		}
+	}
+
+	return &bullMQScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "bullmq_scaler"),
+	}, nil
+}
+
+func parseBullMQMetadata(config *scalersconfig.ScalerConfig) (*bullMQMetadata, error) {
+	meta := &bullMQMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing bullmq metadata: %w", err)
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *bullMQScaler) Close(context.Context) error {
+	if err := s.client.Close(); err != nil {
+		s.logger.Error(err, "error closing redis client")
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *bullMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := util.NormalizeString(fmt.Sprintf("bullmq-%s", s.metadata.QueueName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetJobCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity connects to Redis and counts the pending jobs of the queue
+func (s *bullMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	pendingJobs, err := getBullMQPendingJobCount(ctx, s.client, s.metadata.Prefix, s.metadata.QueueName, time.Now())
+	if err != nil {
+		s.logger.Error(err, "error getting pending job count")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(pendingJobs))
+
+	return []external_metrics.ExternalMetricValue{metric}, pendingJobs > s.metadata.ActivationTargetJobCount, nil
+}
+
+// getBullMQPendingJobCount counts the jobs ready to be processed: the waiting and prioritized jobs,
+// and the delayed jobs whose delay has expired. The commands are pipelined instead of run in a
+// script because the keys of a queue only share a hash slot when the prefix has a hash tag.
+func getBullMQPendingJobCount(ctx context.Context, client redis.Cmdable, prefix, queueName string, now time.Time) (int64, error) {
+	keyPrefix := prefix + ":" + queueName + ":"
+
+	var waiting, prioritized, delayedDue *redis.IntCmd
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		waiting = pipe.LLen(ctx, keyPrefix+"wait")
+		prioritized = pipe.ZCard(ctx, keyPrefix+"prioritized")
+		delayedDue = pipe.ZCount(ctx, keyPrefix+"delayed", "-inf", strconv.FormatInt(bullMQMaxDueDelayedScore(now), 10))
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return waiting.Val() + prioritized.Val() + delayedDue.Val(), nil
+}
+
+// bullMQMaxDueDelayedScore returns the highest score of a delayed job which is due at the given time
+func bullMQMaxDueDelayedScore(now time.Time) int64 {
+	return (now.UnixMilli()+1)<<bullMQDelayedScoreShift - 1
+}
@@ -0,0 +1,90 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseBullMQMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type bullMQMetricIdentifier struct {
+	metadataTestData *parseBullMQMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testBullMQMetadata = []parseBullMQMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"queueName": "emails", "address": "localhost:6379"}, map[string]string{}, false, "properly formed queueName"},
+	{map[string]string{"queueName": "emails", "prefix": "{bull}", "targetJobCount": "10", "activationTargetJobCount": "2", "databaseIndex": "1", "hostFromEnv": "REDIS_HOST", "portFromEnv": "REDIS_PORT", "passwordFromEnv": "REDIS_PASSWORD"}, map[string]string{}, false, "all metadata"},
+	{map[string]string{"address": "localhost:6379"}, map[string]string{}, true, "missing queueName"},
+	{map[string]string{"queueName": "emails"}, map[string]string{}, true, "missing address"},
+	{map[string]string{"queueName": "emails", "address": "localhost:6379", "targetJobCount": "0"}, map[string]string{}, true, "zero targetJobCount"},
+	{map[string]string{"queueName": "emails", "address": "localhost:6379", "targetJobCount": "AA"}, map[string]string{}, true, "improperly formed targetJobCount"},
+	{map[string]string{"queueName": "emails"}, map[string]string{"addresses": "node1:6379,node2:6379", "password": "secret"}, false, "cluster addresses in authParams"},
+	{map[string]string{"queueName": "emails"}, map[string]string{"addresses": "sentinel:26379", "sentinelMaster": "mymaster", "sentinelPassword": "secret"}, false, "sentinel in authParams"},
+	{map[string]string{"queueName": "emails", "enableTLS": "true"}, map[string]string{"address": "localhost:6379", "tls": "disable"}, true, "enableTLS in both authParams and metadata"},
+}
+
+var bullMQMetricIdentifiers = []bullMQMetricIdentifier{
+	{&testBullMQMetadata[1], 0, "s0-bullmq-emails"},
+	{&testBullMQMetadata[1], 1, "s1-bullmq-emails"},
+}
+
+func TestBullMQParseMetadata(t *testing.T) {
+	for _, testData := range testBullMQMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseBullMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testRedisResolvedEnv})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestBullMQParseMetadataDefaults(t *testing.T) {
+	meta, err := parseBullMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testBullMQMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, "bull", meta.Prefix)
+	assert.Equal(t, int64(5), meta.TargetJobCount)
+	assert.Equal(t, int64(0), meta.ActivationTargetJobCount)
+}
+
+func TestBullMQGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range bullMQMetricIdentifiers {
+		meta, err := parseBullMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockBullMQScaler := bullMQScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockBullMQScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestBullMQMaxDueDelayedScore(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	maxScore := bullMQMaxDueDelayedScore(now)
+
+	// BullMQ scores the delayed jobs with timestamp * 0x1000 + counter
+	assert.GreaterOrEqual(t, maxScore, now.UnixMilli()*0x1000+0xfff)
+	assert.Less(t, maxScore, (now.UnixMilli()+1)*0x1000)
+}
@@ -0,0 +1,307 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// celeryPrioritySeparator separates the queue name from the priority step in the
+	// name of the lists created by the Redis transport of Celery for the priorities
+	celeryPrioritySeparator = "\x06\x16"
+	// celeryUnackedKey is the hash in which the Redis transport of Celery keeps the messages
+	// delivered to the workers, the tasks scheduled with an ETA stay there until they're due
+	celeryUnackedKey = "unacked"
+	// celeryUnackedScanCount is the number of hash fields scanned at once in the unacked hash
+	celeryUnackedScanCount = 1000
+)
+
+// celeryDefaultPriorityQueueSteps are the priority steps of the Redis transport of Celery
+var celeryDefaultPriorityQueueSteps = []int{0, 3, 6, 9}
+
+type celeryScaler struct {
+	metricType     v2.MetricTargetType
+	metadata       *celeryMetadata
+	getBacklogFn   func(context.Context) (int64, error)
+	closeFn        func() error
+	logger         logr.Logger
+	connectionName string
+}
+
+type celeryMetadata struct {
+	triggerIndex int
+
+	// BrokerURL is the broker_url of Celery, redis://, rediss://, amqp:// and amqps:// are supported
+	BrokerURL                   string `keda:"name=brokerURL,                   order=authParams;triggerMetadata;resolvedEnv"`
+	QueueName                   string `keda:"name=queueName,                   order=triggerMetadata, optional, default=celery"`
+	PriorityQueueSteps          []int  `keda:"name=priorityQueueSteps,          order=triggerMetadata, optional"`
+	TargetQueueLength           int64  `keda:"name=targetQueueLength,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetQueueLength int64  `keda:"name=activationTargetQueueLength, order=triggerMetadata, optional"`
+	UnsafeSsl                   bool   `keda:"name=unsafeSsl,                   order=triggerMetadata, optional"`
+
+	// TLS
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	Ca          string `keda:"name=ca,          order=authParams, optional"`
+
+	brokerScheme string
+}
+
+// celeryMessage is the subset of the message envelope of Celery used by the scaler
+type celeryMessage struct {
+	Headers struct {
+		ETA *time.Time `json:"eta"`
+	} `json:"headers"`
+	Properties struct {
+		DeliveryInfo struct {
+			RoutingKey string `json:"routing_key"`
+		} `json:"delivery_info"`
+	} `json:"properties"`
+}
+
+func (m *celeryMetadata) Validate() error {
+	if m.TargetQueueLength <= 0 {
+		return errors.New("targetQueueLength must be greater than 0")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+
+	brokerURL, err := url.Parse(m.BrokerURL)
+	if err != nil {
+		return fmt.Errorf("error parsing brokerURL: %w", err)
+	}
+	switch brokerURL.Scheme {
+	case "redis", "rediss", "amqp", "amqps":
+		m.brokerScheme = brokerURL.Scheme
+	default:
+		return fmt.Errorf("unsupported broker scheme %q, must be one of redis, rediss, amqp or amqps", brokerURL.Scheme)
+	}
+
+	if len(m.PriorityQueueSteps) == 0 {
+		m.PriorityQueueSteps = celeryDefaultPriorityQueueSteps
+	}
+	return nil
+}
+
+func (m *celeryMetadata) isRedisBroker() bool {
+	return m.brokerScheme == "redis" || m.brokerScheme == "rediss"
+}
+
+// NewCeleryScaler creates a new celeryScaler
+func NewCeleryScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseCeleryMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing celery metadata: %w", err)
+	}
+
+	s := &celeryScaler{
+		metricType:     metricType,
+		metadata:       meta,
+		logger:         InitializeLogger(config, "celery_scaler"),
+		connectionName: connectionName(config),
+	}
+	if meta.isRedisBroker() {
+		err = s.connectRedis(ctx)
+	} else {
+		err = s.connectAMQP()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func parseCeleryMetadata(config *scalersconfig.ScalerConfig) (*celeryMetadata, error) {
+	meta := &celeryMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *celeryScaler) tlsEnabled() bool {
+	return s.metadata.brokerScheme == "rediss" || s.metadata.brokerScheme == "amqps"
+}
+
+func (s *celeryScaler) connectRedis(ctx context.Context) error {
+	options, err := redis.ParseURL(s.metadata.BrokerURL)
+	if err != nil {
+		return fmt.Errorf("error parsing brokerURL: %w", err)
+	}
+	if s.tlsEnabled() {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(s.metadata.Cert, s.metadata.Key, s.metadata.KeyPassword, s.metadata.Ca, s.metadata.UnsafeSsl)
+		if err != nil {
+			return err
+		}
+		options.TLSConfig = tlsConfig
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("connection to redis failed: %w", err)
+	}
+
+	s.closeFn = client.Close
+	s.getBacklogFn = func(ctx context.Context) (int64, error) {
+		return getCeleryRedisBacklog(ctx, client, s.metadata.QueueName, s.metadata.PriorityQueueSteps, time.Now())
+	}
+	return nil
+}
+
+func (s *celeryScaler) connectAMQP() error {
+	amqpConfig := amqp.Config{
+		Properties: amqp.Table{
+			"connection_name": s.connectionName,
+		},
+	}
+	if s.tlsEnabled() {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(s.metadata.Cert, s.metadata.Key, s.metadata.KeyPassword, s.metadata.Ca, s.metadata.UnsafeSsl)
+		if err != nil {
+			return err
+		}
+		amqpConfig.TLSClientConfig = tlsConfig
+	}
+
+	conn, err := amqp.DialConfig(s.metadata.BrokerURL, amqpConfig)
+	if err != nil {
+		return fmt.Errorf("connection to rabbitmq failed: %s", rabbitMQAnonymizePattern.ReplaceAllString(err.Error(), "user:password@"))
+	}
+
+	s.closeFn = conn.Close
+	s.getBacklogFn = func(context.Context) (int64, error) {
+		// a channel is closed by the broker on error (e.g. unknown queue), so each query gets its own
+		channel, err := conn.Channel()
+		if err != nil {
+			return -1, err
+		}
+		defer channel.Close()
+
+		// the priorities of Celery are native on RabbitMQ, so there's a single queue, and the tasks scheduled
+		// with an ETA are held unacknowledged by the workers, they're excluded from the ready messages
+		queue, err := channel.QueueDeclarePassive(s.metadata.QueueName, false, false, false, false, amqp.Table{})
+		if err != nil {
+			return -1, err
+		}
+		return int64(queue.Messages), nil
+	}
+	return nil
+}
+
+// getCeleryRedisBacklog counts the tasks of the queue waiting in the lists of all the priorities,
+// and the tasks scheduled with an ETA which is due but are still held by the workers
+func getCeleryRedisBacklog(ctx context.Context, client redis.Cmdable, queueName string, prioritySteps []int, now time.Time) (int64, error) {
+	lengths := make([]*redis.IntCmd, 0, len(prioritySteps))
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, step := range prioritySteps {
+			lengths = append(lengths, pipe.LLen(ctx, celeryPriorityQueueName(queueName, step)))
+		}
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	var backlog int64
+	for _, length := range lengths {
+		backlog += length.Val()
+	}
+
+	var cursor uint64
+	for {
+		var fields []string
+		fields, cursor, err = client.HScan(ctx, celeryUnackedKey, cursor, "", celeryUnackedScanCount).Result()
+		if err != nil {
+			return -1, err
+		}
+		// the fields are alternately the delivery tags and the messages
+		for i := 1; i < len(fields); i += 2 {
+			if isDueCeleryScheduledTask(fields[i], queueName, now) {
+				backlog++
+			}
+		}
+		if cursor == 0 {
+			return backlog, nil
+		}
+	}
+}
+
+// celeryPriorityQueueName returns the name of the list of the queue holding the tasks of the priority step
+func celeryPriorityQueueName(queueName string, step int) string {
+	if step == 0 {
+		return queueName
+	}
+	return queueName + celeryPrioritySeparator + strconv.Itoa(step)
+}
+
+// isDueCeleryScheduledTask returns whether the unacked entry, a JSON array of the message, the exchange
+// and the routing key, is a task of the queue whose ETA is due
+func isDueCeleryScheduledTask(entry, queueName string, now time.Time) bool {
+	var unacked []json.RawMessage
+	if err := json.Unmarshal([]byte(entry), &unacked); err != nil || len(unacked) == 0 {
+		return false
+	}
+	var message celeryMessage
+	if err := json.Unmarshal(unacked[0], &message); err != nil {
+		return false
+	}
+	return message.Properties.DeliveryInfo.RoutingKey == queueName &&
+		message.Headers.ETA != nil && !message.Headers.ETA.After(now)
+}
+
+func (s *celeryScaler) Close(context.Context) error {
+	if s.closeFn == nil {
+		return nil
+	}
+	if err := s.closeFn(); err != nil {
+		s.logger.Error(err, "error closing celery broker connection")
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *celeryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("celery-%s", s.metadata.QueueName))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueueLength),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the backlog of tasks of the queue
+func (s *celeryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	backlog, err := s.getBacklogFn(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting celery task backlog: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(backlog))
+	return []external_metrics.ExternalMetricValue{metric}, backlog > s.metadata.ActivationTargetQueueLength, nil
+}
@@ -0,0 +1,157 @@
+package clickhouse
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// protocols of the interfaces of ClickHouse
+const (
+	ProtocolNative = "native"
+	ProtocolHTTP   = "http"
+)
+
+// httpNull is how NULL is written by the TabSeparated format
+const httpNull = `\N`
+
+// Config contains the information required to query a ClickHouse server or the replicas of a cluster.
+type Config struct {
+	// Hosts are the addresses of the replicas to query, in the order they are tried
+	Hosts    []string
+	Protocol string
+	Database string
+	Username string
+	Password string
+	// TLSConfig enables TLS when it isn't nil
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// Client runs queries returning a single number over the native TCP or the HTTP interface of ClickHouse.
+type Client struct {
+	*Config
+	httpClient *http.Client
+}
+
+// NewClient returns a new ClickHouse client.
+func NewClient(c *Config) (*Client, error) {
+	if len(c.Hosts) == 0 {
+		return nil, errors.New("no host was set")
+	}
+	client := &Client{Config: c}
+	switch c.Protocol {
+	case ProtocolNative:
+	case ProtocolHTTP:
+		client.httpClient = &http.Client{
+			Timeout:   c.Timeout,
+			Transport: &http.Transport{TLSClientConfig: c.TLSConfig, Proxy: http.ProxyFromEnvironment},
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", c.Protocol)
+	}
+	return client, nil
+}
+
+// QueryFloat64 runs the query and returns the first column of its first row, NULL being returned as 0.
+// The hosts are tried in order, the next one being only tried when the previous one can't be reached.
+func (c *Client) QueryFloat64(ctx context.Context, query string) (float64, error) {
+	var errs []error
+	for _, host := range c.Hosts {
+		var value float64
+		var err error
+		if c.Protocol == ProtocolHTTP {
+			value, err = c.queryHTTP(ctx, host, query)
+		} else {
+			value, err = c.queryNative(ctx, host, query)
+		}
+
+		var unreachable *unreachableError
+		if !errors.As(err, &unreachable) {
+			return value, err
+		}
+		errs = append(errs, err)
+	}
+	return 0, errors.Join(errs...)
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+}
+
+// unreachableError is returned when a host can't be reached, so that the query is sent to the next host
+type unreachableError struct {
+	host string
+	err  error
+}
+
+func (e *unreachableError) Error() string {
+	return fmt.Sprintf("error connecting to %s: %s", e.host, e.err)
+}
+
+func (e *unreachableError) Unwrap() error {
+	return e.err
+}
+
+func (c *Client) queryHTTP(ctx context.Context, host, query string) (float64, error) {
+	scheme := "http"
+	if c.TLSConfig != nil {
+		scheme = "https"
+	}
+	params := url.Values{}
+	params.Set("database", c.Database)
+	params.Set("default_format", "TabSeparated")
+	queryURL := url.URL{Scheme: scheme, Host: host, Path: "/", RawQuery: params.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, queryURL.String(), strings.NewReader(query))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-ClickHouse-User", c.Username)
+	if c.Password != "" {
+		req.Header.Set("X-ClickHouse-Key", c.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && !netErr.Timeout() {
+			return 0, &unreachableError{host: host, err: err}
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, host, strings.TrimSpace(string(body)))
+	}
+
+	row, _, _ := strings.Cut(string(body), "\n")
+	if row == "" {
+		return 0, errors.New("query returned no row")
+	}
+	value, _, _ := strings.Cut(row, "\t")
+	if value == httpNull {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query returned %q instead of a number: %w", value, err)
+	}
+	return number, nil
+}
@@ -0,0 +1,230 @@
+package clickhouse
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testColumn struct {
+	name       string
+	columnType string
+	data       []byte
+}
+
+// appendBlock appends a packet holding a block made of the columns, the data of each column holding all the rows
+func appendBlock(buf []byte, packet uint64, rows uint64, columns ...testColumn) []byte {
+	buf = binary.AppendUvarint(buf, packet)
+	buf = appendString(buf, "")
+	buf = binary.AppendUvarint(buf, blockInfoIsOverflows)
+	buf = append(buf, 0)
+	buf = binary.AppendUvarint(buf, blockInfoBucketNum)
+	buf = binary.LittleEndian.AppendUint32(buf, math.MaxUint32)
+	buf = binary.AppendUvarint(buf, blockInfoEnd)
+	buf = binary.AppendUvarint(buf, uint64(len(columns)))
+	buf = binary.AppendUvarint(buf, rows)
+	for _, column := range columns {
+		buf = appendString(buf, column.name)
+		buf = appendString(buf, column.columnType)
+		if rows > 0 {
+			buf = append(buf, column.data...)
+		}
+	}
+	return buf
+}
+
+// readTestHello reads the hello sent by the client and returns its credentials
+func readTestHello(r *bufio.Reader) string {
+	_, _ = binary.ReadUvarint(r)
+	_, _ = readString(r)
+	_ = skipUvarints(r, 3)
+	_, _ = readString(r)
+	user, _ := readString(r)
+	password, _ := readString(r)
+	return user + ":" + password
+}
+
+// readTestQuery reads the query sent by the client and the empty block following it
+func readTestQuery(r *bufio.Reader) string {
+	_, _ = binary.ReadUvarint(r)
+	_, _ = readString(r)
+	// client info
+	_, _ = r.ReadByte()
+	for i := 0; i < 3; i++ {
+		_, _ = readString(r)
+	}
+	_, _ = r.ReadByte()
+	for i := 0; i < 3; i++ {
+		_, _ = readString(r)
+	}
+	_ = skipUvarints(r, 3)
+	_, _ = readString(r)
+	// end of the settings, stage and compression
+	_, _ = readString(r)
+	_ = skipUvarints(r, 2)
+	query, _ := readString(r)
+
+	_, _ = binary.ReadUvarint(r)
+	_, _ = readString(r)
+	_ = skipBlockInfo(r)
+	_ = skipUvarints(r, 2)
+	return query
+}
+
+func startNativeServer(t *testing.T, response func(query string) []byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				assert.Equal(t, "keda:secret", readTestHello(r))
+
+				var hello []byte
+				hello = binary.AppendUvarint(hello, serverHello)
+				hello = appendString(hello, "ClickHouse")
+				hello = binary.AppendUvarint(hello, 24)
+				hello = binary.AppendUvarint(hello, 8)
+				hello = binary.AppendUvarint(hello, 54472)
+				hello = appendString(hello, "UTC")
+				_, _ = conn.Write(hello)
+
+				_, _ = conn.Write(response(readTestQuery(r)))
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestQueryFloat64Native(t *testing.T) {
+	addr := startNativeServer(t, func(query string) []byte {
+		var buf []byte
+		switch query {
+		case "SELECT count() FROM jobs":
+			buf = appendBlock(buf, serverData, 0, testColumn{"count()", "UInt64", nil})
+			buf = binary.AppendUvarint(buf, serverProgress)
+			buf = append(buf, 1, 8, 1)
+			buf = appendBlock(buf, serverData, 1, testColumn{"count()", "UInt64", binary.LittleEndian.AppendUint64(nil, 42)})
+			buf = binary.AppendUvarint(buf, serverProfileInfo)
+			buf = append(buf, 1, 1, 8, 0, 0, 0)
+		case "SELECT avg(lag), 'topic' FROM lags":
+			buf = appendBlock(buf, serverData, 1,
+				testColumn{"avg(lag)", "Float64", binary.LittleEndian.AppendUint64(nil, math.Float64bits(12.5))},
+				testColumn{"'topic'", "String", appendString(nil, "topic")})
+		case "SELECT max(lag) FROM lags":
+			buf = appendBlock(buf, serverData, 1, testColumn{"max(lag)", "Nullable(Int32)", []byte{1, 0, 0, 0, 0}})
+		case "SELECT 'topic'":
+			buf = appendBlock(buf, serverData, 1, testColumn{"'topic'", "String", appendString(nil, "topic")})
+		default:
+			buf = binary.AppendUvarint(buf, serverException)
+			buf = binary.LittleEndian.AppendUint32(buf, 60)
+			buf = appendString(buf, "DB::Exception")
+			buf = appendString(buf, "Table default.missing doesn't exist")
+			buf = appendString(buf, "")
+			buf = append(buf, 0)
+			return buf
+		}
+		return binary.AppendUvarint(buf, serverEndOfStream)
+	})
+
+	client, err := NewClient(&Config{Hosts: []string{addr}, Protocol: ProtocolNative, Database: "default", Username: "keda", Password: "secret", Timeout: time.Second})
+	require.NoError(t, err)
+
+	value, err := client.QueryFloat64(context.Background(), "SELECT count() FROM jobs")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+
+	value, err = client.QueryFloat64(context.Background(), "SELECT avg(lag), 'topic' FROM lags")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, value)
+
+	value, err = client.QueryFloat64(context.Background(), "SELECT max(lag) FROM lags")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), value)
+
+	_, err = client.QueryFloat64(context.Background(), "SELECT 'topic'")
+	assert.ErrorContains(t, err, "instead of a number")
+
+	_, err = client.QueryFloat64(context.Background(), "SELECT count() FROM missing")
+	var exception *Exception
+	require.ErrorAs(t, err, &exception)
+	assert.Equal(t, int32(60), exception.Code)
+}
+
+func TestQueryFloat64Failover(t *testing.T) {
+	addr := startNativeServer(t, func(string) []byte {
+		buf := appendBlock(nil, serverData, 1, testColumn{"count()", "UInt64", binary.LittleEndian.AppendUint64(nil, 7)})
+		return binary.AppendUvarint(buf, serverEndOfStream)
+	})
+
+	// nothing listens on the port of the closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	client, err := NewClient(&Config{Hosts: []string{unreachable, addr}, Protocol: ProtocolNative, Username: "keda", Password: "secret", Timeout: time.Second})
+	require.NoError(t, err)
+	value, err := client.QueryFloat64(context.Background(), "SELECT count() FROM jobs")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+
+	client, err = NewClient(&Config{Hosts: []string{unreachable}, Protocol: ProtocolNative, Username: "keda", Password: "secret", Timeout: time.Second})
+	require.NoError(t, err)
+	_, err = client.QueryFloat64(context.Background(), "SELECT count() FROM jobs")
+	assert.ErrorContains(t, err, "error connecting to "+unreachable)
+}
+
+func TestQueryFloat64HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "analytics", r.URL.Query().Get("database"))
+		assert.Equal(t, "TabSeparated", r.URL.Query().Get("default_format"))
+		assert.Equal(t, "keda", r.Header.Get("X-ClickHouse-User"))
+		assert.Equal(t, "secret", r.Header.Get("X-ClickHouse-Key"))
+
+		body, _ := io.ReadAll(r.Body)
+		switch string(body) {
+		case "SELECT count(), 'jobs' FROM jobs":
+			_, _ = w.Write([]byte("42\tjobs\n"))
+		case "SELECT max(lag) FROM lags":
+			_, _ = w.Write([]byte("\\N\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("Code: 60. DB::Exception: Table analytics.missing doesn't exist. (UNKNOWN_TABLE)\n"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{Hosts: []string{strings.TrimPrefix(server.URL, "http://")}, Protocol: ProtocolHTTP, Database: "analytics", Username: "keda", Password: "secret"})
+	require.NoError(t, err)
+
+	value, err := client.QueryFloat64(context.Background(), "SELECT count(), 'jobs' FROM jobs")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+
+	value, err = client.QueryFloat64(context.Background(), "SELECT max(lag) FROM lags")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), value)
+
+	_, err = client.QueryFloat64(context.Background(), "SELECT count() FROM missing")
+	assert.ErrorContains(t, err, "UNKNOWN_TABLE")
+}
@@ -0,0 +1,420 @@
+package clickhouse
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	clientName = "KEDA"
+	// clientRevision is the revision of the native protocol spoken by the client, the fields
+	// added by the following revisions are neither sent nor expected by the server
+	clientRevision = 54213
+
+	// maxStringLength protects against reading garbage as the length of a string
+	maxStringLength = 16 * 1024 * 1024
+)
+
+// packets sent by the client
+const (
+	clientHello = 0
+	clientQuery = 1
+	clientData  = 2
+)
+
+// packets sent by the server
+const (
+	serverHello       = 0
+	serverData        = 1
+	serverException   = 2
+	serverProgress    = 3
+	serverEndOfStream = 5
+	serverProfileInfo = 6
+	serverTotals      = 7
+	serverExtremes    = 8
+)
+
+const (
+	queryKindInitial     = 1
+	interfaceTCP         = 1
+	stageComplete        = 2
+	compressionDisabled  = 0
+	blockInfoEnd         = 0
+	blockInfoIsOverflows = 1
+	blockInfoBucketNum   = 2
+)
+
+// fixedSizeTypes are the numeric types of ClickHouse and their size, Bool being written as an UInt8
+var fixedSizeTypes = map[string]int{
+	"UInt8": 1, "UInt16": 2, "UInt32": 4, "UInt64": 8,
+	"Int8": 1, "Int16": 2, "Int32": 4, "Int64": 8,
+	"Float32": 4, "Float64": 8, "Bool": 1,
+}
+
+// Exception is an error returned by the server.
+type Exception struct {
+	Code    int32
+	Name    string
+	Message string
+}
+
+func (e *Exception) Error() string {
+	return fmt.Sprintf("code %d, %s: %s", e.Code, e.Name, e.Message)
+}
+
+func (c *Client) queryNative(ctx context.Context, host, query string) (float64, error) {
+	conn, err := c.dial(ctx, host)
+	if err != nil {
+		return 0, &unreachableError{host: host, err: err}
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	if err := c.hello(conn, r); err != nil {
+		return 0, err
+	}
+
+	if _, err := conn.Write(encodeQuery(query)); err != nil {
+		return 0, err
+	}
+
+	var value *float64
+	for {
+		packet, err := binary.ReadUvarint(r)
+		if err != nil {
+			return 0, err
+		}
+		switch packet {
+		case serverData:
+			rowValue, err := readBlock(r)
+			if err != nil {
+				return 0, err
+			}
+			// the first block only holds the structure of the result
+			if value == nil {
+				value = rowValue
+			}
+		case serverTotals, serverExtremes:
+			if _, err := readBlock(r); err != nil {
+				return 0, err
+			}
+		case serverProgress:
+			// rows, bytes and total rows
+			if err := skipUvarints(r, 3); err != nil {
+				return 0, err
+			}
+		case serverProfileInfo:
+			// rows, blocks, bytes, applied limit, rows before limit and calculated rows before limit
+			if err := skipUvarints(r, 6); err != nil {
+				return 0, err
+			}
+		case serverException:
+			return 0, readException(r)
+		case serverEndOfStream:
+			if value == nil {
+				return 0, errors.New("query returned no row")
+			}
+			return *value, nil
+		default:
+			return 0, fmt.Errorf("unexpected packet %d from %s", packet, host)
+		}
+	}
+}
+
+func (c *Client) dial(ctx context.Context, host string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	var conn net.Conn
+	var err error
+	if c.TLSConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: c.TLSConfig}).DialContext(ctx, "tcp", host)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if c.Timeout > 0 {
+		deadline = time.Now().Add(c.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *Client) hello(conn net.Conn, r *bufio.Reader) error {
+	var buf []byte
+	buf = binary.AppendUvarint(buf, clientHello)
+	buf = appendString(buf, clientName)
+	buf = binary.AppendUvarint(buf, 1)
+	buf = binary.AppendUvarint(buf, 0)
+	buf = binary.AppendUvarint(buf, clientRevision)
+	buf = appendString(buf, c.Database)
+	buf = appendString(buf, c.Username)
+	buf = appendString(buf, c.Password)
+	if _, err := conn.Write(buf); err != nil {
+		return err
+	}
+
+	packet, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	switch packet {
+	case serverHello:
+	case serverException:
+		return readException(r)
+	default:
+		return fmt.Errorf("unexpected packet %d instead of hello", packet)
+	}
+
+	// name, major and minor version, revision and timezone of the server
+	if _, err := readString(r); err != nil {
+		return err
+	}
+	if err := skipUvarints(r, 3); err != nil {
+		return err
+	}
+	_, err = readString(r)
+	return err
+}
+
+// encodeQuery encodes the query followed by the empty block marking the end of the data sent with it
+func encodeQuery(query string) []byte {
+	hostname, _ := os.Hostname()
+
+	var buf []byte
+	buf = binary.AppendUvarint(buf, clientQuery)
+	buf = appendString(buf, "")
+
+	// client info
+	buf = append(buf, queryKindInitial)
+	buf = appendString(buf, "")
+	buf = appendString(buf, "")
+	buf = appendString(buf, "0.0.0.0:0")
+	buf = append(buf, interfaceTCP)
+	buf = appendString(buf, hostname)
+	buf = appendString(buf, hostname)
+	buf = appendString(buf, clientName)
+	buf = binary.AppendUvarint(buf, 1)
+	buf = binary.AppendUvarint(buf, 0)
+	buf = binary.AppendUvarint(buf, clientRevision)
+	buf = appendString(buf, "")
+
+	// no settings, an empty name ends the settings
+	buf = appendString(buf, "")
+	buf = binary.AppendUvarint(buf, stageComplete)
+	buf = binary.AppendUvarint(buf, compressionDisabled)
+	buf = appendString(buf, query)
+
+	buf = binary.AppendUvarint(buf, clientData)
+	buf = appendString(buf, "")
+	buf = binary.AppendUvarint(buf, blockInfoIsOverflows)
+	buf = append(buf, 0)
+	buf = binary.AppendUvarint(buf, blockInfoBucketNum)
+	buf = binary.LittleEndian.AppendUint32(buf, math.MaxUint32)
+	buf = binary.AppendUvarint(buf, blockInfoEnd)
+	buf = binary.AppendUvarint(buf, 0)
+	buf = binary.AppendUvarint(buf, 0)
+	return buf
+}
+
+// readBlock reads a block of data and returns the first column of its first row, nil if the block is empty
+func readBlock(r *bufio.Reader) (*float64, error) {
+	// name of the temporary table
+	if _, err := readString(r); err != nil {
+		return nil, err
+	}
+	if err := skipBlockInfo(r); err != nil {
+		return nil, err
+	}
+	columns, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var value *float64
+	for column := uint64(0); column < columns; column++ {
+		if _, err := readString(r); err != nil {
+			return nil, err
+		}
+		columnType, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+		columnValue, err := readColumn(r, columnType, rows)
+		if err != nil {
+			return nil, fmt.Errorf("error reading column of type %s: %w", columnType, err)
+		}
+		if column == 0 && rows > 0 {
+			if columnValue == nil {
+				return nil, fmt.Errorf("query returned a %s instead of a number, cast the result with e.g. toFloat64", columnType)
+			}
+			value = columnValue
+		}
+	}
+	return value, nil
+}
+
+// readColumn reads the values of a column and returns its first value, nil if the column
+// is empty or isn't numeric. NULL is returned as 0.
+func readColumn(r *bufio.Reader, columnType string, rows uint64) (*float64, error) {
+	if innerType, found := strings.CutPrefix(columnType, "Nullable("); found {
+		nulls := make([]byte, rows)
+		if _, err := io.ReadFull(r, nulls); err != nil {
+			return nil, err
+		}
+		value, err := readColumn(r, strings.TrimSuffix(innerType, ")"), rows)
+		if err != nil || value == nil || nulls[0] == 0 {
+			return value, err
+		}
+		return new(float64), nil
+	}
+
+	if columnType == "String" {
+		for row := uint64(0); row < rows; row++ {
+			if _, err := readString(r); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	size, found := fixedSizeTypes[columnType]
+	if !found {
+		return nil, errors.New("unsupported type, cast the result with e.g. toFloat64")
+	}
+	data := make([]byte, size*int(rows))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+	value := decodeNumber(columnType, data[:size])
+	return &value, nil
+}
+
+func decodeNumber(columnType string, data []byte) float64 {
+	switch columnType {
+	case "UInt8", "Bool":
+		return float64(data[0])
+	case "UInt16":
+		return float64(binary.LittleEndian.Uint16(data))
+	case "UInt32":
+		return float64(binary.LittleEndian.Uint32(data))
+	case "UInt64":
+		return float64(binary.LittleEndian.Uint64(data))
+	case "Int8":
+		return float64(int8(data[0]))
+	case "Int16":
+		return float64(int16(binary.LittleEndian.Uint16(data)))
+	case "Int32":
+		return float64(int32(binary.LittleEndian.Uint32(data)))
+	case "Int64":
+		return float64(int64(binary.LittleEndian.Uint64(data)))
+	case "Float32":
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+	default:
+		return math.Float64frombits(binary.LittleEndian.Uint64(data))
+	}
+}
+
+func skipBlockInfo(r *bufio.Reader) error {
+	for {
+		field, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		switch field {
+		case blockInfoEnd:
+			return nil
+		case blockInfoIsOverflows:
+			_, err = r.Discard(1)
+		case blockInfoBucketNum:
+			_, err = r.Discard(4)
+		default:
+			return fmt.Errorf("unknown block info field %d", field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readException reads the exception and its nested exceptions, only the outermost one is returned
+func readException(r *bufio.Reader) error {
+	var exception *Exception
+	for {
+		var code [4]byte
+		if _, err := io.ReadFull(r, code[:]); err != nil {
+			return err
+		}
+		var fields [3]string
+		for i := range fields {
+			var err error
+			if fields[i], err = readString(r); err != nil {
+				return err
+			}
+		}
+		if exception == nil {
+			exception = &Exception{Code: int32(binary.LittleEndian.Uint32(code[:])), Name: fields[0], Message: fields[1]}
+		}
+
+		hasNested, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if hasNested == 0 {
+			return exception
+		}
+	}
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func readString(r *bufio.Reader) (string, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	if length > maxStringLength {
+		return "", fmt.Errorf("string of %d bytes is too long", length)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func skipUvarints(r *bufio.Reader, count int) error {
+	for i := 0; i < count; i++ {
+		if _, err := binary.ReadUvarint(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/clickhouse"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// clickHouseDefaultPorts are the default ports of the interfaces of ClickHouse, without and with TLS
+var clickHouseDefaultPorts = map[string][2]string{
+	clickhouse.ProtocolNative: {"9000", "9440"},
+	clickhouse.ProtocolHTTP:   {"8123", "8443"},
+}
+
+type clickHouseScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *clickHouseMetadata
+	client     *clickhouse.Client
+	logger     logr.Logger
+}
+
+type clickHouseMetadata struct {
+	triggerIndex int
+
+	// Hosts are the replicas to query, the next one being queried when the previous one can't be reached
+	Hosts                      []string `keda:"name=host;hosts,               order=triggerMetadata;authParams"`
+	Protocol                   string   `keda:"name=protocol,                   order=triggerMetadata, enum=native;http, optional, default=native"`
+	Database                   string   `keda:"name=database,                   order=triggerMetadata;authParams, optional, default=default"`
+	Query                      string   `keda:"name=query,                      order=triggerMetadata"`
+	TargetQueryValue           float64  `keda:"name=targetQueryValue,           order=triggerMetadata"`
+	ActivationTargetQueryValue float64  `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=triggerMetadata;authParams;resolvedEnv, optional, default=default"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+
+	EnableTLS bool   `keda:"name=enableTLS, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+	Cert      string `keda:"name=cert,      order=authParams, optional"`
+	Key       string `keda:"name=key,       order=authParams, optional"`
+}
+
+func (m *clickHouseMetadata) Validate() error {
+	if m.TargetQueryValue <= 0 {
+		return errors.New("targetQueryValue must be greater than 0")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	if !m.EnableTLS && (m.Ca != "" || m.Cert != "") {
+		return errors.New("enableTLS must be true to use ca, cert and key")
+	}
+
+	defaultPort := clickHouseDefaultPorts[m.Protocol][0]
+	if m.EnableTLS {
+		defaultPort = clickHouseDefaultPorts[m.Protocol][1]
+	}
+	for i, host := range m.Hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			m.Hosts[i] = net.JoinHostPort(host, defaultPort)
+		}
+	}
+	return nil
+}
+
+// NewClickHouseScaler creates a new clickHouseScaler
+func NewClickHouseScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseClickHouseMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing clickhouse metadata: %w", err)
+	}
+
+	clientConfig := &clickhouse.Config{
+		Hosts:    meta.Hosts,
+		Protocol: meta.Protocol,
+		Database: meta.Database,
+		Username: meta.Username,
+		Password: meta.Password,
+		Timeout:  config.GlobalHTTPTimeout,
+	}
+	if meta.EnableTLS {
+		if clientConfig.TLSConfig, err = kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+	client, err := clickhouse.NewClient(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating clickhouse client: %w", err)
+	}
+
+	return &clickHouseScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "clickhouse_scaler"),
+	}, nil
+}
+
+func parseClickHouseMetadata(config *scalersconfig.ScalerConfig) (*clickHouseMetadata, error) {
+	meta := &clickHouseMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the idle connections of the clickhouse client.
+func (s *clickHouseScaler) Close(context.Context) error {
+	if s.client != nil {
+		s.client.Close()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *clickHouseScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("clickhouse-%s", s.metadata.Database))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the result of the query
+func (s *clickHouseScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.client.QueryFloat64(ctx, s.metadata.Query)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting clickhouse: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetQueryValue, nil
+}
@@ -0,0 +1,112 @@
+package scalers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseClickHouseMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type clickHouseMetricIdentifier struct {
+	metadataTestData *parseClickHouseMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testClickHouseMetadata = []parseClickHouseMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"host": "clickhouse", "query": "SELECT count() FROM jobs", "targetQueryValue": "10"}, map[string]string{}, false, "host only"},
+	{map[string]string{"hosts": "replica-1:9440,replica-2:9440", "protocol": "native", "database": "analytics", "query": "SELECT count() FROM jobs", "targetQueryValue": "10", "activationTargetQueryValue": "1", "enableTLS": "true"}, map[string]string{"username": "keda", "password": "secret", "ca": "caaa"}, false, "replicas with tls"},
+	{map[string]string{"host": "clickhouse:8123", "protocol": "http", "query": "SELECT count() FROM jobs", "targetQueryValue": "10"}, map[string]string{"username": "keda", "password": "secret"}, false, "http protocol"},
+	{map[string]string{"host": "clickhouse", "protocol": "grpc", "query": "SELECT count() FROM jobs", "targetQueryValue": "10"}, map[string]string{}, true, "unknown protocol"},
+	{map[string]string{"host": "clickhouse", "targetQueryValue": "10"}, map[string]string{}, true, "missing query"},
+	{map[string]string{"host": "clickhouse", "query": "SELECT count() FROM jobs"}, map[string]string{}, true, "missing targetQueryValue"},
+	{map[string]string{"host": "clickhouse", "query": "SELECT count() FROM jobs", "targetQueryValue": "10"}, map[string]string{"ca": "caaa"}, true, "ca without enableTLS"},
+	{map[string]string{"host": "clickhouse", "query": "SELECT count() FROM jobs", "targetQueryValue": "10", "enableTLS": "true"}, map[string]string{"cert": "ceert"}, true, "cert without key"},
+}
+
+var clickHouseMetricIdentifiers = []clickHouseMetricIdentifier{
+	{&testClickHouseMetadata[1], 0, "s0-clickhouse-default"},
+	{&testClickHouseMetadata[2], 1, "s1-clickhouse-analytics"},
+}
+
+func TestClickHouseParseMetadata(t *testing.T) {
+	for _, testData := range testClickHouseMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestClickHouseParseMetadataDefaultPorts(t *testing.T) {
+	meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"clickhouse:9000"}, meta.Hosts)
+
+	meta, err = parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"hosts": "replica-1,replica-2:8444", "protocol": "http", "enableTLS": "true", "query": "SELECT 1", "targetQueryValue": "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"replica-1:8443", "replica-2:8444"}, meta.Hosts)
+}
+
+func TestClickHouseGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range clickHouseMetricIdentifiers {
+		meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockClickHouseScaler := clickHouseScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockClickHouseScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestClickHouseGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "keda", r.Header.Get("X-ClickHouse-User"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "SELECT count() FROM jobs WHERE status = 'pending'", string(body))
+		_, _ = w.Write([]byte("12\n"))
+	}))
+	defer server.Close()
+
+	s, err := NewClickHouseScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"host":                       strings.TrimPrefix(server.URL, "http://"),
+			"protocol":                   "http",
+			"query":                      "SELECT count() FROM jobs WHERE status = 'pending'",
+			"targetQueryValue":           "5",
+			"activationTargetQueryValue": "10",
+		},
+		AuthParams: map[string]string{"username": "keda"},
+	})
+	assert.NoError(t, err)
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "clickhouse")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(12), metrics[0].Value.Value())
+}
@@ -0,0 +1,258 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/cloudeventsink"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	cloudEventsMetricRate      = "rate"
+	cloudEventsMetricExtension = "extension"
+)
+
+// cloudEventsScaler scales on the CloudEvents delivered to the endpoint of the ScaledObject exposed by keda-operator,
+// /api/v1/cloudevents/<namespace>/<name>, either on their rate or on the latest value of a numeric extension attribute
+type cloudEventsScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *cloudEventsMetadata
+	unsubscribe func()
+	logger      logr.Logger
+
+	mu sync.Mutex
+	// counts are the numbers of events received by second, within the rate window
+	counts map[int64]int64
+	// extensionValue is the value of the extension attribute of the latest event
+	extensionValue    float64
+	extensionReceived time.Time
+	// received is notified of the events matching the filter, to report the activity as soon as they're received
+	received chan struct{}
+}
+
+type cloudEventsMetadata struct {
+	// Token authenticates the deliveries as a bearer token
+	Token string `keda:"name=token,               order=authParams"`
+	// EventType and EventSource filter the events, a trailing * matching any suffix
+	EventType   string `keda:"name=eventType,           order=triggerMetadata, optional"`
+	EventSource string `keda:"name=eventSource,         order=triggerMetadata, optional"`
+	// Metric is either the rate of the events, by second, or the value of an extension attribute of the events
+	Metric            string `keda:"name=metric,              order=triggerMetadata, enum=rate;extension, default=rate"`
+	RateWindowSeconds int    `keda:"name=rateWindowSeconds,   order=triggerMetadata, default=60"`
+	ExtensionName     string `keda:"name=extensionName,       order=triggerMetadata, optional"`
+	// StaleAfterSeconds forgets the value of the extension attribute when no event is received for this long
+	StaleAfterSeconds   int     `keda:"name=staleAfterSeconds,   order=triggerMetadata, default=300"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+
+	path         string
+	triggerIndex int
+}
+
+func (m *cloudEventsMetadata) Validate() error {
+	if m.RateWindowSeconds <= 0 {
+		return errors.New("rateWindowSeconds must be greater than 0")
+	}
+	if m.StaleAfterSeconds <= 0 {
+		return errors.New("staleAfterSeconds must be greater than 0")
+	}
+	switch {
+	case m.Metric == cloudEventsMetricExtension && m.ExtensionName == "":
+		return errors.New("extensionName is required when metric is extension")
+	case m.Metric == cloudEventsMetricRate && m.ExtensionName != "":
+		return errors.New("extensionName can only be used when metric is extension")
+	}
+	// the names of the extension attributes are lowercase alphanumeric
+	if strings.ToLower(m.ExtensionName) != m.ExtensionName {
+		return fmt.Errorf("extensionName %s must be lowercase", m.ExtensionName)
+	}
+	return nil
+}
+
+// NewCloudEventsScaler creates a new cloudEventsScaler, receiving the events from the default receiver of
+// keda-operator
+func NewCloudEventsScaler(config *scalersconfig.ScalerConfig) (PushScaler, error) {
+	return newCloudEventsScaler(config, cloudeventsink.DefaultReceiver)
+}
+
+func newCloudEventsScaler(config *scalersconfig.ScalerConfig, receiver *cloudeventsink.Receiver) (*cloudEventsScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseCloudEventsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cloudevents metadata: %w", err)
+	}
+
+	s := &cloudEventsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "cloudevents_scaler"),
+		counts:     map[int64]int64{},
+		received:   make(chan struct{}, 1),
+	}
+	s.unsubscribe = receiver.Subscribe(meta.path, meta.Token, s.receive)
+	return s, nil
+}
+
+func parseCloudEventsMetadata(config *scalersconfig.ScalerConfig) (*cloudEventsMetadata, error) {
+	meta := &cloudEventsMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing cloudevents metadata: %w", err)
+	}
+	if config.AsMetricSource {
+		meta.Threshold = 0
+	}
+	meta.path = cloudeventsink.Path(config.ScalableObjectNamespace, config.ScalableObjectName)
+	return meta, nil
+}
+
+// matchesFilter returns whether the value matches the filter, empty filters matching any value
+func matchesFilter(filter, value string) bool {
+	if prefix, ok := strings.CutSuffix(filter, "*"); ok {
+		return strings.HasPrefix(value, prefix)
+	}
+	return filter == "" || filter == value
+}
+
+func (s *cloudEventsScaler) receive(events []event.Event) {
+	now := time.Now()
+	matched := false
+
+	s.mu.Lock()
+	for _, e := range events {
+		if !matchesFilter(s.metadata.EventType, e.Type()) || !matchesFilter(s.metadata.EventSource, e.Source()) {
+			continue
+		}
+
+		if s.metadata.Metric == cloudEventsMetricExtension {
+			value, err := extensionValue(e, s.metadata.ExtensionName)
+			if err != nil {
+				s.logger.V(1).Info("ignoring event", "id", e.ID(), "reason", err.Error())
+				continue
+			}
+			s.extensionValue = value
+			s.extensionReceived = now
+		} else {
+			s.counts[now.Unix()]++
+		}
+		matched = true
+	}
+	s.mu.Unlock()
+
+	if matched {
+		select {
+		case s.received <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// extensionValue returns the value of the extension attribute of the event, as a number
+func extensionValue(e event.Event, name string) (float64, error) {
+	attribute, ok := e.Extensions()[name]
+	if !ok {
+		return 0, fmt.Errorf("extension attribute %s not found", name)
+	}
+	// the extension attributes are strings when delivered in binary mode, whatever the type set by the source, and
+	// integers when set as numbers in structured mode
+	str, err := types.Format(attribute)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("extension attribute %s is not a number: %q", name, str)
+	}
+	return value, nil
+}
+
+// getValue returns the rate of the events by second over the window, or the latest value of the extension attribute
+func (s *cloudEventsScaler) getValue() float64 {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.metadata.Metric == cloudEventsMetricExtension {
+		if now.Sub(s.extensionReceived) > time.Duration(s.metadata.StaleAfterSeconds)*time.Second {
+			return 0
+		}
+		return s.extensionValue
+	}
+
+	var total int64
+	windowStart := now.Unix() - int64(s.metadata.RateWindowSeconds)
+	for second, count := range s.counts {
+		if second <= windowStart {
+			delete(s.counts, second)
+			continue
+		}
+		total += count
+	}
+	return float64(total) / float64(s.metadata.RateWindowSeconds)
+}
+
+func (s *cloudEventsScaler) Close(context.Context) error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *cloudEventsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := "cloudevents-rate"
+	if s.metadata.Metric == cloudEventsMetricExtension {
+		metricName = fmt.Sprintf("cloudevents-%s", s.metadata.ExtensionName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the rate of the events, or the value of their extension attribute
+func (s *cloudEventsScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value := s.getValue()
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationThreshold, nil
+}
+
+// Run reports the activity each time events matching the filter are received
+func (s *cloudEventsScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.received:
+			select {
+			case active <- s.getValue() > s.metadata.ActivationThreshold:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
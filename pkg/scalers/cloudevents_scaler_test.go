@@ -0,0 +1,170 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/cloudeventsink"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseCloudEventsMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type cloudEventsMetricIdentifier struct {
+	metadataTestData *parseCloudEventsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testCloudEventsMetadata = []parseCloudEventsMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"threshold": "10"}, map[string]string{"token": "secret"}, false, "properly formed"},
+	{map[string]string{"threshold": "10"}, map[string]string{}, true, "missing token"},
+	{map[string]string{"threshold": "10", "eventType": "com.example.order.*", "eventSource": "/orders", "rateWindowSeconds": "30", "activationThreshold": "1"}, map[string]string{"token": "secret"}, false, "rate with filter"},
+	{map[string]string{"threshold": "10", "metric": "extension", "extensionName": "queuedepth", "staleAfterSeconds": "60"}, map[string]string{"token": "secret"}, false, "extension"},
+	{map[string]string{"threshold": "10", "metric": "extension"}, map[string]string{"token": "secret"}, true, "extension without extensionName"},
+	{map[string]string{"threshold": "10", "extensionName": "queuedepth"}, map[string]string{"token": "secret"}, true, "extensionName with rate"},
+	{map[string]string{"threshold": "10", "metric": "extension", "extensionName": "queueDepth"}, map[string]string{"token": "secret"}, true, "extensionName not lowercase"},
+	{map[string]string{"threshold": "10", "metric": "latency"}, map[string]string{"token": "secret"}, true, "unknown metric"},
+	{map[string]string{"threshold": "10", "rateWindowSeconds": "0"}, map[string]string{"token": "secret"}, true, "rateWindowSeconds not positive"},
+}
+
+var cloudEventsMetricIdentifiers = []cloudEventsMetricIdentifier{
+	{&testCloudEventsMetadata[1], 0, "s0-cloudevents-rate"},
+	{&testCloudEventsMetadata[4], 1, "s1-cloudevents-queuedepth"},
+}
+
+func TestCloudEventsParseMetadata(t *testing.T) {
+	for _, testData := range testCloudEventsMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseCloudEventsMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata:         testData.metadata,
+				AuthParams:              testData.authParams,
+				ScalableObjectNamespace: "default",
+				ScalableObjectName:      "consumer",
+			})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCloudEventsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range cloudEventsMetricIdentifiers {
+		meta, err := parseCloudEventsMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams:      testData.metadataTestData.authParams,
+			TriggerIndex:    testData.triggerIndex,
+		})
+		require.NoError(t, err)
+
+		scaler := cloudEventsScaler{metadata: meta}
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func newTestCloudEventsScaler(t *testing.T, receiver *cloudeventsink.Receiver, metadata map[string]string) *cloudEventsScaler {
+	scaler, err := newCloudEventsScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         metadata,
+		AuthParams:              map[string]string{"token": "secret"},
+		ScalableObjectNamespace: "default",
+		ScalableObjectName:      "consumer",
+	}, receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = scaler.Close(context.Background()) })
+	return scaler
+}
+
+func newTestCloudEvent(eventType, source string, extensions map[string]any) event.Event {
+	e := event.New()
+	e.SetID("1")
+	e.SetType(eventType)
+	e.SetSource(source)
+	for name, value := range extensions {
+		e.SetExtension(name, value)
+	}
+	return e
+}
+
+func TestCloudEventsRate(t *testing.T) {
+	scaler := newTestCloudEventsScaler(t, cloudeventsink.NewReceiver(), map[string]string{
+		"threshold": "10", "eventType": "com.example.order.*", "eventSource": "/orders", "rateWindowSeconds": "10",
+	})
+
+	var events []event.Event
+	for i := 0; i < 20; i++ {
+		events = append(events, newTestCloudEvent("com.example.order.created", "/orders", nil))
+	}
+	events = append(events,
+		newTestCloudEvent("com.example.invoice.created", "/orders", nil),
+		newTestCloudEvent("com.example.order.created", "/invoices", nil),
+	)
+	scaler.receive(events)
+	// events received before the window are forgotten
+	scaler.counts[time.Now().Unix()-10] = 100
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-cloudevents-rate")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, float64(2), metrics[0].Value.AsApproximateFloat64())
+	assert.Len(t, scaler.counts, 1)
+}
+
+func TestCloudEventsExtension(t *testing.T) {
+	scaler := newTestCloudEventsScaler(t, cloudeventsink.NewReceiver(), map[string]string{
+		"threshold": "10", "metric": "extension", "extensionName": "queuedepth", "activationThreshold": "5",
+	})
+
+	scaler.receive([]event.Event{
+		newTestCloudEvent("com.example.queue.depth", "/orders", map[string]any{"queuedepth": 12}),
+		// ignored, without the extension or with a value which isn't a number
+		newTestCloudEvent("com.example.queue.depth", "/orders", nil),
+		newTestCloudEvent("com.example.queue.depth", "/orders", map[string]any{"queuedepth": "many"}),
+	})
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-cloudevents-queuedepth")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, float64(12), metrics[0].Value.AsApproximateFloat64())
+
+	scaler.receive([]event.Event{newTestCloudEvent("com.example.queue.depth", "/orders", map[string]any{"queuedepth": "2.5"})})
+	metrics, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-cloudevents-queuedepth")
+	require.NoError(t, err)
+	assert.False(t, active)
+	assert.Equal(t, 2.5, metrics[0].Value.AsApproximateFloat64())
+
+	scaler.extensionReceived = time.Now().Add(-10 * time.Minute)
+	metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), "s0-cloudevents-queuedepth")
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestCloudEventsRun(t *testing.T) {
+	scaler := newTestCloudEventsScaler(t, cloudeventsink.NewReceiver(), map[string]string{
+		"threshold": "10", "eventType": "com.example.order.created",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go scaler.Run(ctx, active)
+
+	scaler.receive([]event.Event{newTestCloudEvent("com.example.order.created", "/orders", nil)})
+	assert.True(t, <-active)
+
+	cancel()
+	_, ok := <-active
+	assert.False(t, ok)
+}
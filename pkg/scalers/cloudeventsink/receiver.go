@@ -0,0 +1,60 @@
+package cloudeventsink
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/pushreceiver"
+)
+
+const (
+	// PathPrefix is the prefix of the paths of the ScaledObjects, /api/v1/cloudevents/<namespace>/<name>
+	PathPrefix = "/api/v1/cloudevents/"
+
+	maxRequestSize = 4 * 1024 * 1024
+)
+
+// DefaultReceiver is the receiver served by keda-operator when the CloudEvents endpoint is enabled
+var DefaultReceiver = NewReceiver()
+
+// Receiver receives the CloudEvents delivered with the HTTP binding, in binary, structured or batched mode, and
+// passes them to the subscriptions of the path of the request whose token authenticates the request
+type Receiver = pushreceiver.Receiver[[]event.Event]
+
+// NewReceiver returns a receiver without subscriptions
+func NewReceiver() *Receiver {
+	return pushreceiver.NewReceiver(maxRequestSize, http.StatusAccepted, decodeEvents)
+}
+
+// Path returns the path of the ScaledObject
+func Path(namespace, name string) string {
+	return PathPrefix + namespace + "/" + name
+}
+
+// decodeEvents decodes the event of the request in binary or structured mode, or the events in batched mode
+func decodeEvents(req *http.Request) ([]event.Event, error) {
+	var events []event.Event
+	if strings.HasPrefix(req.Header.Get("Content-Type"), event.ApplicationCloudEventsBatchJSON) {
+		batch, err := cehttp.NewEventsFromHTTPRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		events = batch
+	} else {
+		e, err := cehttp.NewEventFromHTTPRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		events = []event.Event{*e}
+	}
+
+	for _, e := range events {
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,78 @@
+package cloudeventsink
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequest(method, path, token, body string, headers map[string]string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+var binaryHeaders = map[string]string{
+	"Ce-Specversion": "1.0",
+	"Ce-Id":          "1",
+	"Ce-Type":        "com.example.order.created",
+	"Ce-Source":      "/orders",
+	"Ce-Queuedepth":  "12",
+	"Content-Type":   "application/json",
+}
+
+const structuredEvent = `{"specversion": "1.0", "id": "2", "type": "com.example.order.created", "source": "/orders", "queuedepth": 7}`
+
+func TestReceiver(t *testing.T) {
+	receiver := NewReceiver()
+	var received []event.Event
+	unsubscribe := receiver.Subscribe(Path("default", "consumer"), "secret", func(events []event.Event) {
+		received = append(received, events...)
+	})
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"binary mode", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", `{}`, binaryHeaders), http.StatusAccepted},
+		{"structured mode", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", structuredEvent,
+			map[string]string{"Content-Type": "application/cloudevents+json"}), http.StatusAccepted},
+		{"batched mode", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", "["+structuredEvent+","+structuredEvent+"]",
+			map[string]string{"Content-Type": "application/cloudevents-batch+json"}), http.StatusAccepted},
+		{"wrong token", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "wrong", `{}`, binaryHeaders), http.StatusUnauthorized},
+		{"without token", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "", `{}`, binaryHeaders), http.StatusUnauthorized},
+		{"unknown scaled object", newRequest(http.MethodPost, "/api/v1/cloudevents/default/producer", "secret", `{}`, binaryHeaders), http.StatusNotFound},
+		{"not a post", newRequest(http.MethodGet, "/api/v1/cloudevents/default/consumer", "secret", "", nil), http.StatusMethodNotAllowed},
+		{"not a cloudevent", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", `{"queuedepth": 7}`,
+			map[string]string{"Content-Type": "application/json"}), http.StatusBadRequest},
+		{"invalid cloudevent", newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", `{"specversion": "1.0", "id": "3"}`,
+			map[string]string{"Content-Type": "application/cloudevents+json"}), http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, test.req)
+			assert.Equal(t, test.status, w.Code, w.Body.String())
+		})
+	}
+	if assert.Len(t, received, 4) {
+		assert.Equal(t, "com.example.order.created", received[0].Type())
+		assert.Equal(t, "12", received[0].Extensions()["queuedepth"])
+		assert.Equal(t, "2", received[1].ID())
+	}
+
+	unsubscribe()
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/cloudevents/default/consumer", "secret", `{}`, binaryHeaders))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,244 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	cockroachDBMetricQuery         = "query"
+	cockroachDBMetricChangefeedLag = "changefeedLag"
+
+	cockroachDBSslModeDisable = "disable"
+	cockroachDBSslModeRequire = "require"
+
+	// cockroachDBChangefeedLagQuery returns the seconds the high-water mark of the running changefeeds is behind the
+	// clock of the cluster, the changefeeds still running their initial scan lagging since they've started
+	cockroachDBChangefeedLagQuery = `SELECT COALESCE(MAX(EXTRACT(EPOCH FROM now() - COALESCE(readable_high_water_timestamptz, started::TIMESTAMPTZ))), 0) ` +
+		`FROM [SHOW CHANGEFEED JOBS] WHERE status = 'running'`
+)
+
+type cockroachDBScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *cockroachDBMetadata
+	connection *sql.DB
+	logger     logr.Logger
+}
+
+type cockroachDBMetadata struct {
+	triggerIndex int
+
+	// Connection is a connection string, which takes precedence over the host and the credentials
+	Connection string `keda:"name=connection, order=authParams;resolvedEnv, optional"`
+	Host       string `keda:"name=host,       order=authParams;triggerMetadata, optional"`
+	Port       int    `keda:"name=port,       order=authParams;triggerMetadata, optional, default=26257"`
+	Database   string `keda:"name=database,   order=authParams;triggerMetadata, optional, default=defaultdb"`
+	UserName   string `keda:"name=userName,   order=authParams;triggerMetadata, optional"`
+	Password   string `keda:"name=password,   order=authParams;resolvedEnv, optional"`
+	// SslMode is the TLS of the connection, verify-full checking the certificate of the nodes against the CA
+	SslMode     string `keda:"name=sslmode,     order=authParams;triggerMetadata, enum=disable;require;verify-full, optional, default=verify-full"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+
+	Metric                     string  `keda:"name=metric,                     order=triggerMetadata, enum=query;changefeedLag, optional, default=query"`
+	Query                      string  `keda:"name=query,                      order=triggerMetadata, optional"`
+	TargetQueryValue           float64 `keda:"name=targetQueryValue,           order=triggerMetadata, optional"`
+	ActivationTargetQueryValue float64 `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
+	// ChangefeedJobID restricts the lag to the one of a changefeed, which is otherwise the highest of the running changefeeds
+	ChangefeedJobID            int64   `keda:"name=changefeedJobId,            order=triggerMetadata, optional"`
+	TargetLagSeconds           float64 `keda:"name=targetLagSeconds,           order=triggerMetadata, optional, default=60"`
+	ActivationTargetLagSeconds float64 `keda:"name=activationTargetLagSeconds, order=triggerMetadata, optional"`
+}
+
+func (m *cockroachDBMetadata) Validate() error {
+	if m.Connection == "" {
+		if m.Host == "" {
+			return errors.New("no host given")
+		}
+		if m.UserName == "" {
+			return errors.New("no userName given")
+		}
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("cert and key must be provided together")
+	}
+
+	switch m.Metric {
+	case cockroachDBMetricChangefeedLag:
+		if m.TargetLagSeconds <= 0 {
+			return errors.New("targetLagSeconds must be greater than 0")
+		}
+	default:
+		if m.Query == "" {
+			return errors.New("no query given")
+		}
+	}
+	return nil
+}
+
+// NewCockroachDBScaler creates a new cockroachDB scaler
+func NewCockroachDBScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "cockroachdb_scaler")
+
+	meta, err := parseCockroachDBMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cockroachDB metadata: %w", err)
+	}
+
+	conn, err := getCockroachDBConnection(ctx, meta)
+	if err != nil {
+		return nil, fmt.Errorf("error establishing cockroachDB connection: %w", err)
+	}
+	return &cockroachDBScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: conn,
+		logger:     logger,
+	}, nil
+}
+
+func parseCockroachDBMetadata(config *scalersconfig.ScalerConfig) (*cockroachDBMetadata, error) {
+	meta := &cockroachDBMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if !config.AsMetricSource && meta.Metric == cockroachDBMetricQuery && meta.TargetQueryValue == 0 {
+		return nil, errors.New("no targetQueryValue given")
+	}
+	return meta, nil
+}
+
+// getCockroachDBConnectionConfig returns the config of the connection, the TLS of which is built from the certificates
+// of the authentication rather than from files as with the sslrootcert, sslcert and sslkey of the connection strings
+func getCockroachDBConnectionConfig(meta *cockroachDBMetadata) (*pgx.ConnConfig, error) {
+	if meta.Connection != "" {
+		return pgx.ParseConfig(meta.Connection)
+	}
+
+	params := []string{
+		"host=" + escapePostgreConnectionParameter(meta.Host),
+		"port=" + strconv.Itoa(meta.Port),
+		"user=" + escapePostgreConnectionParameter(meta.UserName),
+		"dbname=" + escapePostgreConnectionParameter(meta.Database),
+		"sslmode=disable",
+	}
+	if meta.Password != "" {
+		params = append(params, "password="+escapePostgreConnectionParameter(meta.Password))
+	}
+	connConfig, err := pgx.ParseConfig(strings.Join(params, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.SslMode != cockroachDBSslModeDisable {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.SslMode == cockroachDBSslModeRequire)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName = meta.Host
+		connConfig.TLSConfig = tlsConfig
+	}
+	return connConfig, nil
+}
+
+func getCockroachDBConnection(ctx context.Context, meta *cockroachDBMetadata) (*sql.DB, error) {
+	connConfig, err := getCockroachDBConnectionConfig(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	db := stdlib.OpenDB(*connConfig)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Close disposes of cockroachDB connections
+func (s *cockroachDBScaler) Close(context.Context) error {
+	err := s.connection.Close()
+	if err != nil {
+		s.logger.Error(err, "Error closing cockroachDB connection")
+		return err
+	}
+	return nil
+}
+
+// buildQuery returns the query of the metric and its arguments
+func (m *cockroachDBMetadata) buildQuery() (string, []interface{}) {
+	if m.Metric != cockroachDBMetricChangefeedLag {
+		return m.Query, nil
+	}
+	if m.ChangefeedJobID != 0 {
+		return cockroachDBChangefeedLagQuery + " AND job_id = $1", []interface{}{m.ChangefeedJobID}
+	}
+	return cockroachDBChangefeedLagQuery, nil
+}
+
+func (s *cockroachDBScaler) getValue(ctx context.Context) (float64, error) {
+	var value float64
+	query, args := s.metadata.buildQuery()
+	if err := s.connection.QueryRowContext(ctx, query, args...).Scan(&value); err != nil {
+		return 0, fmt.Errorf("could not query cockroachDB: %w", err)
+	}
+	return value, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *cockroachDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := "cockroachdb"
+	target := s.metadata.TargetQueryValue
+	if s.metadata.Metric == cockroachDBMetricChangefeedLag {
+		metricName = "cockroachdb-changefeed-lag"
+		if s.metadata.ChangefeedJobID != 0 {
+			metricName = fmt.Sprintf("%s-%d", metricName, s.metadata.ChangefeedJobID)
+		}
+		target = s.metadata.TargetLagSeconds
+	}
+
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, target),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the result of the query or the lag of the changefeeds in seconds
+func (s *cockroachDBScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting cockroachDB: %w", err)
+	}
+
+	activation := s.metadata.ActivationTargetQueryValue
+	if s.metadata.Metric == cockroachDBMetricChangefeedLag {
+		activation = s.metadata.ActivationTargetLagSeconds
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > activation, nil
+}
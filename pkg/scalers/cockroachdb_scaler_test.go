@@ -0,0 +1,115 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseCockroachDBMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type cockroachDBMetricIdentifier struct {
+	metadataTestData *parseCockroachDBMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testCockroachDBMetadata = []parseCockroachDBMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "targetQueryValue": "5"}, map[string]string{"connection": "postgresql://root@localhost:26257/defaultdb?sslmode=disable"}, false, "connection"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "targetQueryValue": "5", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false, "cert auth"},
+	{map[string]string{"metric": "changefeedLag", "host": "cockroachdb-public", "userName": "keda", "sslmode": "require"}, map[string]string{"password": "secret"}, false, "changefeed lag"},
+	{map[string]string{"metric": "changefeedLag", "changefeedJobId": "1001", "targetLagSeconds": "30", "activationTargetLagSeconds": "5", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false, "changefeed lag of a job"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{}, true, "no targetQueryValue"},
+	{map[string]string{"targetQueryValue": "5", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{}, true, "no query"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "targetQueryValue": "5", "host": "cockroachdb-public"}, map[string]string{}, true, "no userName"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "targetQueryValue": "5", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+	{map[string]string{"metric": "changefeedLag", "targetLagSeconds": "0", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{}, true, "zero targetLagSeconds"},
+	{map[string]string{"metric": "lag", "host": "cockroachdb-public", "userName": "keda"}, map[string]string{}, true, "unknown metric"},
+	{map[string]string{"query": "SELECT count(*) FROM jobs", "targetQueryValue": "5", "host": "cockroachdb-public", "userName": "keda", "sslmode": "prefer"}, map[string]string{}, true, "unknown sslmode"},
+}
+
+var cockroachDBMetricIdentifiers = []cockroachDBMetricIdentifier{
+	{&testCockroachDBMetadata[1], 0, "s0-cockroachdb"},
+	{&testCockroachDBMetadata[3], 1, "s1-cockroachdb-changefeed-lag"},
+	{&testCockroachDBMetadata[4], 2, "s2-cockroachdb-changefeed-lag-1001"},
+}
+
+func TestCockroachDBParseMetadata(t *testing.T) {
+	for _, testData := range testCockroachDBMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestCockroachDBGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range cockroachDBMetricIdentifiers {
+		meta, err := parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockCockroachDBScaler := cockroachDBScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockCockroachDBScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestCockroachDBBuildQuery(t *testing.T) {
+	meta, err := parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCockroachDBMetadata[1].metadata, AuthParams: testCockroachDBMetadata[1].authParams})
+	require.NoError(t, err)
+	query, args := meta.buildQuery()
+	assert.Equal(t, "SELECT count(*) FROM jobs", query)
+	assert.Empty(t, args)
+
+	meta, err = parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCockroachDBMetadata[3].metadata, AuthParams: testCockroachDBMetadata[3].authParams})
+	require.NoError(t, err)
+	query, args = meta.buildQuery()
+	assert.Equal(t, cockroachDBChangefeedLagQuery, query)
+	assert.Empty(t, args)
+
+	meta, err = parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCockroachDBMetadata[4].metadata, AuthParams: testCockroachDBMetadata[4].authParams})
+	require.NoError(t, err)
+	query, args = meta.buildQuery()
+	assert.Equal(t, cockroachDBChangefeedLagQuery+" AND job_id = $1", query)
+	assert.Equal(t, []interface{}{int64(1001)}, args)
+}
+
+func TestCockroachDBConnectionConfig(t *testing.T) {
+	meta, err := parseCockroachDBMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCockroachDBMetadata[3].metadata, AuthParams: testCockroachDBMetadata[3].authParams})
+	require.NoError(t, err)
+	connConfig, err := getCockroachDBConnectionConfig(meta)
+	require.NoError(t, err)
+	assert.Equal(t, "cockroachdb-public", connConfig.Host)
+	assert.Equal(t, uint16(26257), connConfig.Port)
+	assert.Equal(t, "keda", connConfig.User)
+	assert.Equal(t, "secret", connConfig.Password)
+	assert.Equal(t, "defaultdb", connConfig.Database)
+	require.NotNil(t, connConfig.TLSConfig)
+	assert.True(t, connConfig.TLSConfig.InsecureSkipVerify)
+
+	meta.SslMode = cockroachDBSslModeDisable
+	connConfig, err = getCockroachDBConnectionConfig(meta)
+	require.NoError(t, err)
+	assert.Nil(t, connConfig.TLSConfig)
+}
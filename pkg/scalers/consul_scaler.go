@@ -0,0 +1,283 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	consulModeKV            = "kv"
+	consulModeServiceHealth = "serviceHealth"
+	consulModeSessions      = "sessions"
+
+	consulHealthCritical = "critical"
+	consulHealthWarning  = "warning"
+)
+
+type consulScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *consulMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type consulMetadata struct {
+	triggerIndex int
+
+	Address    string `keda:"name=address,    order=triggerMetadata;resolvedEnv"`
+	Datacenter string `keda:"name=datacenter, order=triggerMetadata, optional"`
+	Namespace  string `keda:"name=namespace,  order=triggerMetadata, optional"`
+	Mode       string `keda:"name=mode,       order=triggerMetadata, enum=kv;serviceHealth;sessions"`
+	// KVKey is the key holding the value in kv mode
+	KVKey string `keda:"name=kvKey, order=triggerMetadata, optional"`
+	// Service is the service whose instances failing health checks are counted in serviceHealth mode,
+	// instances with a warning check being counted as well with IncludeWarning
+	Service        string `keda:"name=service,        order=triggerMetadata, optional"`
+	IncludeWarning bool   `keda:"name=includeWarning, order=triggerMetadata, optional"`
+	// SessionName and Node filter the sessions counted in sessions mode
+	SessionName string `keda:"name=sessionName, order=triggerMetadata, optional"`
+	Node        string `keda:"name=node,        order=triggerMetadata, optional"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Token string `keda:"name=token, order=authParams;resolvedEnv, optional"`
+	Ca    string `keda:"name=ca,    order=authParams, optional"`
+	Cert  string `keda:"name=cert,  order=authParams, optional"`
+	Key   string `keda:"name=key,   order=authParams, optional"`
+}
+
+// consulServiceEntry is an instance of a service with the checks of its node and its own checks
+type consulServiceEntry struct {
+	Checks []struct {
+		Status string `json:"Status"`
+	} `json:"Checks"`
+}
+
+type consulSession struct {
+	Name string `json:"Name"`
+}
+
+func (m *consulMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	switch m.Mode {
+	case consulModeKV:
+		if m.KVKey == "" {
+			return fmt.Errorf("kvKey must be provided in %s mode", consulModeKV)
+		}
+	case consulModeServiceHealth:
+		if m.Service == "" {
+			return fmt.Errorf("service must be provided in %s mode", consulModeServiceHealth)
+		}
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	m.Address = strings.TrimSuffix(m.Address, "/")
+	return nil
+}
+
+// NewConsulScaler creates a new consulScaler
+func NewConsulScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseConsulMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing consul metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Ca != "" || meta.Cert != "" {
+		tlsConfig, err := kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	return &consulScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "consul_scaler"),
+	}, nil
+}
+
+func parseConsulMetadata(config *scalersconfig.ScalerConfig) (*consulMetadata, error) {
+	meta := &consulMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *consulScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *consulScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	var name string
+	switch s.metadata.Mode {
+	case consulModeKV:
+		name = s.metadata.KVKey
+	case consulModeServiceHealth:
+		name = s.metadata.Service
+	default:
+		name = s.metadata.SessionName
+	}
+	metricName := kedautil.NormalizeString(strings.TrimSuffix(fmt.Sprintf("consul-%s-%s", s.metadata.Mode, name), "-"))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the key, the number of failing instances or the number of sessions
+func (s *consulScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var value float64
+	var err error
+	switch s.metadata.Mode {
+	case consulModeKV:
+		value, err = s.getKVValue(ctx)
+	case consulModeServiceHealth:
+		value, err = s.getFailingInstances(ctx)
+	default:
+		value, err = s.getSessions(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting consul %s metric: %w", s.metadata.Mode, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *consulScaler) getKVValue(ctx context.Context) (float64, error) {
+	body, err := s.get(ctx, "/v1/kv/"+strings.TrimPrefix(s.metadata.KVKey, "/"), url.Values{"raw": {""}})
+	if err != nil {
+		return -1, err
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64)
+	if err != nil {
+		return -1, fmt.Errorf("value of key %s isn't a number: %w", s.metadata.KVKey, err)
+	}
+	return value, nil
+}
+
+// getFailingInstances returns the number of instances of the service with a critical check, either one of
+// the instance or one of its node, the instances in maintenance having a critical maintenance check
+func (s *consulScaler) getFailingInstances(ctx context.Context) (float64, error) {
+	body, err := s.get(ctx, "/v1/health/service/"+url.PathEscape(s.metadata.Service), nil)
+	if err != nil {
+		return -1, err
+	}
+	var entries []consulServiceEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return -1, fmt.Errorf("error parsing service health: %w", err)
+	}
+
+	var failing float64
+	for _, entry := range entries {
+		for _, check := range entry.Checks {
+			if check.Status == consulHealthCritical || (s.metadata.IncludeWarning && check.Status == consulHealthWarning) {
+				failing++
+				break
+			}
+		}
+	}
+	return failing, nil
+}
+
+// getSessions returns the number of sessions, e.g. the sessions waiting on a lock or a semaphore
+func (s *consulScaler) getSessions(ctx context.Context) (float64, error) {
+	path := "/v1/session/list"
+	if s.metadata.Node != "" {
+		path = "/v1/session/node/" + url.PathEscape(s.metadata.Node)
+	}
+	body, err := s.get(ctx, path, nil)
+	if err != nil {
+		return -1, err
+	}
+	var sessions []consulSession
+	if err := json.Unmarshal(body, &sessions); err != nil {
+		return -1, fmt.Errorf("error parsing sessions: %w", err)
+	}
+
+	var count float64
+	for _, session := range sessions {
+		if s.metadata.SessionName == "" || session.Name == s.metadata.SessionName {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *consulScaler) get(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if s.metadata.Datacenter != "" {
+		query.Set("dc", s.metadata.Datacenter)
+	}
+	if s.metadata.Namespace != "" {
+		query.Set("ns", s.metadata.Namespace)
+	}
+	requestURL := s.metadata.Address + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.Token != "" {
+		req.Header.Set("X-Consul-Token", s.metadata.Token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
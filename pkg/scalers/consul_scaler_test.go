@@ -0,0 +1,131 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseConsulMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type consulMetricIdentifier struct {
+	metadataTestData *parseConsulMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testConsulMetadata = []parseConsulMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"address": "http://consul:8500", "mode": "kv", "kvKey": "jobs/pending"}, map[string]string{}, false, "kv mode"},
+	{map[string]string{"address": "https://consul:8501", "mode": "serviceHealth", "service": "payments", "includeWarning": "true", "datacenter": "dc1", "targetValue": "2", "activationTargetValue": "1"}, map[string]string{"token": "secret", "ca": "ca", "cert": "cert", "key": "key"}, false, "service health mode with acl token and mtls"},
+	{map[string]string{"address": "http://consul:8500", "mode": "sessions", "sessionName": "worker-lock", "node": "node-1"}, map[string]string{}, false, "sessions mode"},
+	{map[string]string{"address": "http://consul:8500", "mode": "kv"}, map[string]string{}, true, "kv mode without kvKey"},
+	{map[string]string{"address": "http://consul:8500", "mode": "serviceHealth"}, map[string]string{}, true, "service health mode without service"},
+	{map[string]string{"address": "http://consul:8500", "mode": "catalog"}, map[string]string{}, true, "unknown mode"},
+	{map[string]string{"mode": "kv", "kvKey": "jobs/pending"}, map[string]string{}, true, "missing address"},
+	{map[string]string{"address": "http://consul:8500", "mode": "kv", "kvKey": "jobs/pending", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"address": "http://consul:8500", "mode": "kv", "kvKey": "jobs/pending"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+}
+
+var consulMetricIdentifiers = []consulMetricIdentifier{
+	{&testConsulMetadata[1], 0, "s0-consul-kv-jobs-pending"},
+	{&testConsulMetadata[2], 1, "s1-consul-serviceHealth-payments"},
+	{&testConsulMetadata[3], 2, "s2-consul-sessions-worker-lock"},
+}
+
+func TestConsulParseMetadata(t *testing.T) {
+	for _, testData := range testConsulMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseConsulMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestConsulGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range consulMetricIdentifiers {
+		meta, err := parseConsulMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockConsulScaler := consulScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockConsulScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestConsulGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Consul-Token"))
+		assert.Equal(t, "dc1", r.URL.Query().Get("dc"))
+		switch r.URL.Path {
+		case "/v1/kv/jobs/pending":
+			assert.True(t, r.URL.Query().Has("raw"))
+			_, _ = w.Write([]byte("42\n"))
+		case "/v1/kv/jobs/name":
+			_, _ = w.Write([]byte("nightly"))
+		case "/v1/health/service/payments":
+			_, _ = w.Write([]byte(`[
+				{"Checks":[{"Status":"passing"},{"Status":"passing"}]},
+				{"Checks":[{"Status":"passing"},{"Status":"critical"}]},
+				{"Checks":[{"Status":"critical"},{"Status":"critical"}]},
+				{"Checks":[{"Status":"warning"},{"Status":"passing"}]}
+			]`))
+		case "/v1/session/list":
+			_, _ = w.Write([]byte(`[{"Name":"worker-lock"},{"Name":"worker-lock"},{"Name":"other"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		metadata map[string]string
+		value    int64
+		isActive bool
+		isError  bool
+	}{
+		{map[string]string{"mode": "kv", "kvKey": "jobs/pending"}, 42, true, false},
+		{map[string]string{"mode": "kv", "kvKey": "jobs/name"}, 0, false, true},
+		{map[string]string{"mode": "kv", "kvKey": "jobs/missing"}, 0, false, true},
+		{map[string]string{"mode": "serviceHealth", "service": "payments"}, 2, true, false},
+		{map[string]string{"mode": "serviceHealth", "service": "payments", "includeWarning": "true", "activationTargetValue": "3"}, 3, false, false},
+		{map[string]string{"mode": "sessions", "sessionName": "worker-lock"}, 2, true, false},
+		{map[string]string{"mode": "sessions"}, 3, true, false},
+	}
+	for _, test := range tests {
+		test.metadata["address"] = server.URL
+		test.metadata["datacenter"] = "dc1"
+		s, err := NewConsulScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, AuthParams: map[string]string{"token": "secret"}})
+		assert.NoError(t, err)
+
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "consul")
+		if test.isError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.isActive, isActive)
+		assert.Equal(t, test.value, metrics[0].Value.Value())
+	}
+}
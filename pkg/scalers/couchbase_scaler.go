@@ -0,0 +1,288 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	couchbaseModeDCPLag  = "dcpLag"
+	couchbaseModeXDCRLag = "xdcrLag"
+	couchbaseModeQuery   = "query"
+
+	// couchbaseDCPItemsRemaining is the number of items the DCP streams of a bucket still have to send,
+	// couchbaseXDCRChangesLeft the number of mutations the replications from a bucket still have to replicate
+	couchbaseDCPItemsRemaining = "kv_dcp_items_remaining"
+	couchbaseXDCRChangesLeft   = "xdcr_changes_left_total"
+	// couchbaseStatsWindow is the number of seconds of the samples requested, only the latest one being used
+	couchbaseStatsWindow = "-60"
+)
+
+type couchbaseScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *couchbaseMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type couchbaseMetadata struct {
+	triggerIndex int
+
+	// URL is the endpoint of the cluster management API, QueryURL the one of the query service
+	URL      string `keda:"name=url,      order=triggerMetadata;resolvedEnv, optional"`
+	QueryURL string `keda:"name=queryURL, order=triggerMetadata;resolvedEnv, optional"`
+	Mode     string `keda:"name=mode,     order=triggerMetadata, enum=dcpLag;xdcrLag;query"`
+	Bucket   string `keda:"name=bucket,   order=triggerMetadata, optional"`
+	// ConnectionType is the type of the DCP connections whose backlog is measured, e.g. replication,
+	// eventing or other for external consumers like the Kafka connector
+	ConnectionType        string  `keda:"name=connectionType,        order=triggerMetadata, optional, default=replication"`
+	Query                 string  `keda:"name=query,                 order=triggerMetadata, optional"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=1000"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+	Ca       string `keda:"name=ca,       order=authParams, optional"`
+	Cert     string `keda:"name=cert,     order=authParams, optional"`
+	Key      string `keda:"name=key,      order=authParams, optional"`
+}
+
+// couchbaseStatsRange is the response of the statistics API, each series holding [timestamp, value] samples
+type couchbaseStatsRange struct {
+	Data []struct {
+		Values [][]interface{} `json:"values"`
+	} `json:"data"`
+	Errors []interface{} `json:"errors"`
+}
+
+type couchbaseQueryResponse struct {
+	Status  string            `json:"status"`
+	Results []json.RawMessage `json:"results"`
+	Errors  []struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"errors"`
+}
+
+func (m *couchbaseMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if m.Mode == couchbaseModeQuery {
+		if m.QueryURL == "" || m.Query == "" {
+			return fmt.Errorf("queryURL and query must be provided in %s mode", couchbaseModeQuery)
+		}
+	} else if m.URL == "" || m.Bucket == "" {
+		return fmt.Errorf("url and bucket must be provided in %s mode", m.Mode)
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	m.QueryURL = strings.TrimSuffix(m.QueryURL, "/")
+	return nil
+}
+
+// NewCouchbaseScaler creates a new couchbaseScaler
+func NewCouchbaseScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseCouchbaseMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing couchbase metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Ca != "" || meta.Cert != "" {
+		tlsConfig, err := kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	return &couchbaseScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "couchbase_scaler"),
+	}, nil
+}
+
+func parseCouchbaseMetadata(config *scalersconfig.ScalerConfig) (*couchbaseMetadata, error) {
+	meta := &couchbaseMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *couchbaseScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *couchbaseScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("couchbase-%s-%s", s.metadata.Mode, s.metadata.Bucket)
+	if s.metadata.Mode == couchbaseModeQuery {
+		metricName = "couchbase-query"
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the DCP or XDCR backlog of the bucket, or the result of the query
+func (s *couchbaseScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var value float64
+	var err error
+	switch s.metadata.Mode {
+	case couchbaseModeDCPLag:
+		value, err = s.getStat(ctx, couchbaseDCPItemsRemaining, url.Values{"bucket": {s.metadata.Bucket}, "connection_type": {s.metadata.ConnectionType}})
+	case couchbaseModeXDCRLag:
+		value, err = s.getStat(ctx, couchbaseXDCRChangesLeft, url.Values{"sourceBucketName": {s.metadata.Bucket}})
+	default:
+		value, err = s.runQuery(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting couchbase %s metric: %w", s.metadata.Mode, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getStat returns the latest value of the statistic summed up over the nodes and the series matching the labels
+func (s *couchbaseScaler) getStat(ctx context.Context, stat string, labels url.Values) (float64, error) {
+	labels.Set("start", couchbaseStatsWindow)
+	labels.Set("nodesAggregation", "sum")
+	path := "/pools/default/stats/range/" + stat
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+path+"?"+labels.Encode(), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	body, err := s.do(req, path)
+	if err != nil {
+		return -1, err
+	}
+	var stats couchbaseStatsRange
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return -1, fmt.Errorf("error parsing statistics: %w", err)
+	}
+	if len(stats.Errors) > 0 {
+		return -1, fmt.Errorf("error getting statistic %s: %v", stat, stats.Errors)
+	}
+
+	var total float64
+	for _, series := range stats.Data {
+		if len(series.Values) == 0 {
+			continue
+		}
+		// samples are [timestamp, "value"], the last one being the latest
+		sample := series.Values[len(series.Values)-1]
+		if len(sample) != 2 {
+			continue
+		}
+		raw, ok := sample[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			s.logger.V(1).Info("ignoring sample which isn't a number", "stat", stat, "value", raw)
+			continue
+		}
+		total += value
+	}
+	return total, nil
+}
+
+// runQuery runs the N1QL query and returns its first result, either a number or an object with a single number
+func (s *couchbaseScaler) runQuery(ctx context.Context) (float64, error) {
+	path := "/query/service"
+	form := url.Values{"statement": {s.metadata.Query}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.metadata.QueryURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := s.do(req, path)
+	var response couchbaseQueryResponse
+	if jsonErr := json.Unmarshal(body, &response); jsonErr == nil && len(response.Errors) > 0 {
+		return -1, fmt.Errorf("query failed with code %d: %s", response.Errors[0].Code, response.Errors[0].Msg)
+	}
+	if err != nil {
+		return -1, err
+	}
+	if len(response.Results) == 0 {
+		return -1, errors.New("query returned no result")
+	}
+
+	var value float64
+	if err := json.Unmarshal(response.Results[0], &value); err == nil {
+		return value, nil
+	}
+	var row map[string]float64
+	if err := json.Unmarshal(response.Results[0], &row); err != nil || len(row) != 1 {
+		return -1, fmt.Errorf("query returned %s instead of a number or an object with a single number", response.Results[0])
+	}
+	for _, value := range row {
+		return value, nil
+	}
+	return -1, nil
+}
+
+// do sends the request and returns the body of the response, the body being returned with the error of a failed request
+func (s *couchbaseScaler) do(req *http.Request, path string) ([]byte, error) {
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return body, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseCouchbaseMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type couchbaseMetricIdentifier struct {
+	metadataTestData *parseCouchbaseMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testCouchbaseMetadata = []parseCouchbaseMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "dcpLag", "bucket": "orders"}, map[string]string{"username": "keda", "password": "secret"}, false, "dcp lag"},
+	{map[string]string{"url": "https://couchbase:18091", "mode": "xdcrLag", "bucket": "orders", "targetValue": "500", "activationTargetValue": "10"}, map[string]string{"username": "keda", "password": "secret", "ca": "caaa"}, false, "xdcr lag with tls"},
+	{map[string]string{"queryURL": "http://couchbase:8093", "mode": "query", "query": "SELECT RAW COUNT(*) FROM orders WHERE status = 'pending'"}, map[string]string{}, false, "query"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "dcpLag"}, map[string]string{}, true, "dcp lag without bucket"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "query", "query": "SELECT 1"}, map[string]string{}, true, "query without queryURL"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "lag", "bucket": "orders"}, map[string]string{}, true, "unknown mode"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "dcpLag", "bucket": "orders", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "dcpLag", "bucket": "orders"}, map[string]string{"username": "keda"}, true, "username without password"},
+	{map[string]string{"url": "http://couchbase:8091", "mode": "dcpLag", "bucket": "orders"}, map[string]string{"cert": "ceert"}, true, "cert without key"},
+}
+
+var couchbaseMetricIdentifiers = []couchbaseMetricIdentifier{
+	{&testCouchbaseMetadata[1], 0, "s0-couchbase-dcpLag-orders"},
+	{&testCouchbaseMetadata[2], 1, "s1-couchbase-xdcrLag-orders"},
+	{&testCouchbaseMetadata[3], 2, "s2-couchbase-query"},
+}
+
+func TestCouchbaseParseMetadata(t *testing.T) {
+	for _, testData := range testCouchbaseMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseCouchbaseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestCouchbaseGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range couchbaseMetricIdentifiers {
+		meta, err := parseCouchbaseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockCouchbaseScaler := couchbaseScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockCouchbaseScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestCouchbaseGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "keda:secret", user+":"+password)
+		switch r.URL.Path {
+		case "/pools/default/stats/range/kv_dcp_items_remaining":
+			assert.Equal(t, "orders", r.URL.Query().Get("bucket"))
+			assert.Equal(t, "other", r.URL.Query().Get("connection_type"))
+			assert.Equal(t, "sum", r.URL.Query().Get("nodesAggregation"))
+			_, _ = w.Write([]byte(`{"data":[{"metric":{"nodes":["a","b"]},"values":[[1700000000,"12"],[1700000010,"30"]]},{"metric":{"nodes":["a","b"]},"values":[[1700000010,"5"]]}],"errors":[]}`))
+		case "/pools/default/stats/range/xdcr_changes_left_total":
+			assert.Equal(t, "orders", r.URL.Query().Get("sourceBucketName"))
+			_, _ = w.Write([]byte(`{"data":[],"errors":[]}`))
+		case "/query/service":
+			assert.Equal(t, http.MethodPost, r.Method)
+			switch r.FormValue("statement") {
+			case "SELECT RAW COUNT(*) FROM orders":
+				_, _ = w.Write([]byte(`{"results":[42],"status":"success"}`))
+			case "SELECT COUNT(*) AS pending FROM orders":
+				_, _ = w.Write([]byte(`{"results":[{"pending":7}],"status":"success"}`))
+			case "SELECT name FROM orders":
+				_, _ = w.Write([]byte(`{"results":[{"name":"order"}],"status":"success"}`))
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"results":[],"status":"fatal","errors":[{"code":12003,"msg":"Keyspace not found in CB datastore: default:missing"}]}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		metadata map[string]string
+		value    int64
+		isActive bool
+		err      string
+	}{
+		{map[string]string{"mode": "dcpLag", "bucket": "orders", "connectionType": "other", "activationTargetValue": "10"}, 35, true, ""},
+		{map[string]string{"mode": "xdcrLag", "bucket": "orders"}, 0, false, ""},
+		{map[string]string{"mode": "query", "query": "SELECT RAW COUNT(*) FROM orders"}, 42, true, ""},
+		{map[string]string{"mode": "query", "query": "SELECT COUNT(*) AS pending FROM orders", "activationTargetValue": "10"}, 7, false, ""},
+		{map[string]string{"mode": "query", "query": "SELECT name FROM orders"}, 0, false, "instead of a number"},
+		{map[string]string{"mode": "query", "query": "SELECT RAW COUNT(*) FROM missing"}, 0, false, "code 12003"},
+	}
+	for _, test := range tests {
+		test.metadata["url"] = server.URL
+		test.metadata["queryURL"] = server.URL
+		s, err := NewCouchbaseScaler(&scalersconfig.ScalerConfig{
+			TriggerMetadata: test.metadata,
+			AuthParams:      map[string]string{"username": "keda", "password": "secret"},
+		})
+		assert.NoError(t, err)
+
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "couchbase")
+		if test.err != "" {
+			assert.ErrorContains(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.isActive, isActive)
+		assert.Equal(t, test.value, metrics[0].Value.Value())
+	}
+}
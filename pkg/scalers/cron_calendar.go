@@ -0,0 +1,228 @@
+package scalers
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// holidayCalendar is the set of events of an iCalendar, during which the windows of the cron scaler don't apply
+type holidayCalendar struct {
+	events []calendarEvent
+	// skipped are the errors of the events skipped because their recurrence rule isn't supported
+	skipped []error
+}
+
+type calendarEvent struct {
+	start, end time.Time
+	// yearly events repeat every year on the same date, e.g. public holidays
+	yearly bool
+	// count is the number of occurrences of the yearly events, unlimited when 0
+	count int
+	// until is the start of the last occurrence of the yearly events, unlimited when zero
+	until time.Time
+	// exdates are the starts of the excluded occurrences
+	exdates []time.Time
+}
+
+// parseHolidayCalendar parses the VEVENTs of an iCalendar (RFC 5545). The all-day events and the times without
+// timezone are in the location. Only the yearly recurrence rules are supported, the events with other rules being
+// skipped.
+func parseHolidayCalendar(ical string, location *time.Location) (*holidayCalendar, error) {
+	lines, err := unfoldCalendarLines(ical)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("holiday calendar must be an iCalendar starting with BEGIN:VCALENDAR")
+	}
+
+	calendar := &holidayCalendar{}
+	var event *calendarEvent
+	var allDay bool
+	var ruleErr error
+	for _, line := range lines {
+		nameAndParams, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(nameAndParams, ";")
+		switch name = strings.ToUpper(name); {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &calendarEvent{}
+			allDay = false
+			ruleErr = nil
+		case event == nil:
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.start.IsZero() {
+				return nil, fmt.Errorf("holiday calendar event without DTSTART")
+			}
+			if ruleErr != nil {
+				calendar.skipped = append(calendar.skipped, fmt.Errorf("skipping holiday calendar event starting %s: %w", event.start.Format(time.RFC3339), ruleErr))
+				event = nil
+				continue
+			}
+			if event.end.IsZero() {
+				// the events without end last a day when they're all-day events, and are instants otherwise
+				event.end = event.start
+				if allDay {
+					event.end = event.start.AddDate(0, 0, 1)
+				}
+			}
+			calendar.events = append(calendar.events, *event)
+			event = nil
+		case name == "DTSTART" || name == "DTEND":
+			t, date, err := parseCalendarTime(value, params, location)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s of holiday calendar event: %w", name, err)
+			}
+			if name == "DTSTART" {
+				event.start = t
+				allDay = date
+			} else {
+				event.end = t
+			}
+		case name == "RRULE":
+			event.count, event.until, ruleErr = parseYearlyRule(value, location)
+			event.yearly = ruleErr == nil
+		case name == "EXDATE":
+			for _, exdate := range strings.Split(value, ",") {
+				t, _, err := parseCalendarTime(exdate, params, location)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing EXDATE of holiday calendar event: %w", err)
+				}
+				event.exdates = append(event.exdates, t)
+			}
+		}
+	}
+	return calendar, nil
+}
+
+// unfoldCalendarLines joins the lines folded on several lines, starting with a space or a tab
+func unfoldCalendarLines(ical string) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(ical))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseCalendarTime parses a DATE, or a DATE-TIME in UTC, in the timezone of the TZID parameter or in the location.
+// It returns whether the value is a date.
+func parseCalendarTime(value, params string, location *time.Location) (time.Time, bool, error) {
+	for _, param := range strings.Split(params, ";") {
+		name, paramValue, _ := strings.Cut(param, "=")
+		if strings.EqualFold(name, "TZID") {
+			tz, err := time.LoadLocation(strings.Trim(paramValue, `"`))
+			if err != nil {
+				return time.Time{}, false, err
+			}
+			location = tz
+		}
+	}
+
+	switch {
+	case len(value) == len("20060102"):
+		t, err := time.ParseInLocation("20060102", value, location)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	default:
+		t, err := time.ParseInLocation("20060102T150405", value, location)
+		return t, false, err
+	}
+}
+
+// parseYearlyRule parses a yearly recurrence rule, returning its COUNT and its UNTIL
+func parseYearlyRule(rule string, location *time.Location) (int, time.Time, error) {
+	var count int
+	var until time.Time
+	yearly := false
+	for _, part := range strings.Split(rule, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			yearly = strings.EqualFold(value, "YEARLY")
+		case "INTERVAL":
+			if value != "1" {
+				return 0, time.Time{}, fmt.Errorf("unsupported recurrence rule %s, only yearly rules without interval are supported", rule)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return 0, time.Time{}, fmt.Errorf("invalid COUNT of recurrence rule %s", rule)
+			}
+			count = n
+		case "UNTIL":
+			t, _, err := parseCalendarTime(value, "", location)
+			if err != nil {
+				return 0, time.Time{}, fmt.Errorf("invalid UNTIL of recurrence rule %s: %w", rule, err)
+			}
+			until = t
+		case "BYMONTH", "BYMONTHDAY", "WKST":
+			// the date of DTSTART
+		default:
+			return 0, time.Time{}, fmt.Errorf("unsupported recurrence rule %s, only FREQ=YEARLY with COUNT or UNTIL is supported", rule)
+		}
+	}
+	if !yearly {
+		return 0, time.Time{}, fmt.Errorf("unsupported recurrence rule %s, only FREQ=YEARLY is supported", rule)
+	}
+	return count, until, nil
+}
+
+// contains returns whether the time is during an event of the calendar
+func (c *holidayCalendar) contains(t time.Time) bool {
+	for _, event := range c.events {
+		if !event.yearly {
+			if event.occurs(0, t) {
+				return true
+			}
+			continue
+		}
+		if t.Before(event.start) {
+			continue
+		}
+		// the occurrences of the previous and the current year, the events may span the new year
+		for _, years := range []int{t.Year() - event.start.Year() - 1, t.Year() - event.start.Year()} {
+			if event.occurs(years, t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// occurs returns whether the time is during the occurrence of the event the years after its start, unless the
+// occurrence is beyond the COUNT or the UNTIL of the event, or excluded
+func (e *calendarEvent) occurs(years int, t time.Time) bool {
+	if years < 0 || (e.count > 0 && years >= e.count) {
+		return false
+	}
+	start, end := e.start.AddDate(years, 0, 0), e.end.AddDate(years, 0, 0)
+	if t.Before(start) || !t.Before(end) {
+		return false
+	}
+	if !e.until.IsZero() && start.After(e.until) {
+		return false
+	}
+	for _, exdate := range e.exdates {
+		if start.Equal(exdate) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,11 +3,15 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -20,42 +24,139 @@ const (
 )
 
 type cronScaler struct {
-	metricType    v2.MetricTargetType
-	metadata      cronMetadata
-	logger        logr.Logger
-	startSchedule cron.Schedule
-	endSchedule   cron.Schedule
+	metricType v2.MetricTargetType
+	metadata   cronMetadata
+	logger     logr.Logger
+	httpClient *http.Client
+
+	mu sync.Mutex
+	// calendar is the holiday calendar downloaded from holidayCalendarURL, refreshed after calendarExpiry
+	calendar       *holidayCalendar
+	calendarExpiry time.Time
 }
 
 type cronMetadata struct {
-	Start           string `keda:"name=start,           order=triggerMetadata"`
-	End             string `keda:"name=end,             order=triggerMetadata"`
+	Start           string `keda:"name=start,           order=triggerMetadata, optional"`
+	End             string `keda:"name=end,             order=triggerMetadata, optional"`
 	Timezone        string `keda:"name=timezone,        order=triggerMetadata"`
-	DesiredReplicas int64  `keda:"name=desiredReplicas, order=triggerMetadata"`
-	TriggerIndex    int
+	DesiredReplicas int64  `keda:"name=desiredReplicas, order=triggerMetadata, optional"`
+	// Windows are the schedule windows with their own desiredReplicas, as a YAML or JSON list, instead of start, end
+	// and desiredReplicas
+	Windows string `keda:"name=windows,         order=triggerMetadata, optional"`
+	// ExcludeDates are the dates, YYYY-MM-DD in the timezone, when the windows don't apply
+	ExcludeDates []string `keda:"name=excludeDates,    order=triggerMetadata, optional"`
+	// HolidayCalendar is an iCalendar whose events the windows don't apply during, inline or downloaded from
+	// holidayCalendarURL every holidayCalendarRefreshSeconds
+	HolidayCalendar               string `keda:"name=holidayCalendar,               order=triggerMetadata, optional"`
+	HolidayCalendarURL            string `keda:"name=holidayCalendarURL,            order=triggerMetadata, optional"`
+	HolidayCalendarRefreshSeconds int    `keda:"name=holidayCalendarRefreshSeconds, order=triggerMetadata, default=3600"`
+	TriggerIndex                  int
+
+	location     *time.Location
+	windows      []cronWindow
+	excludeDates map[string]bool
+	calendar     *holidayCalendar
 }
 
-func (m *cronMetadata) Validate() error {
+// cronWindow is a window between the start and the end schedules, when desiredReplicas are scaled to
+type cronWindow struct {
+	Start           string `yaml:"start"`
+	End             string `yaml:"end"`
+	DesiredReplicas int64  `yaml:"desiredReplicas"`
+
+	startSchedule cron.Schedule
+	endSchedule   cron.Schedule
+}
+
+func (w *cronWindow) parse() error {
 	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	if _, err := parser.Parse(m.Start); err != nil {
+	var err error
+	if w.startSchedule, err = parser.Parse(w.Start); err != nil {
 		return fmt.Errorf("error parsing start schedule: %w", err)
 	}
 
-	if _, err := parser.Parse(m.End); err != nil {
+	if w.endSchedule, err = parser.Parse(w.End); err != nil {
 		return fmt.Errorf("error parsing end schedule: %w", err)
 	}
 
-	if m.Start == m.End {
+	if w.Start == w.End {
 		return fmt.Errorf("start and end can not have exactly same time input")
 	}
 
-	if m.DesiredReplicas == 0 {
+	if w.DesiredReplicas == 0 {
 		return fmt.Errorf("no desiredReplicas specified")
 	}
 
 	return nil
 }
 
+// isActive returns whether the time is within the window, i.e. the window ends before it starts again.
+// The schedules are evaluated on the wall clock of the location of the time, so that a start or an end in the hour
+// skipped when the clocks go forward happens when the clocks jump, instead of being postponed to the next day.
+func (w *cronWindow) isActive(t time.Time) bool {
+	wall := wallClock(t)
+	return !w.startSchedule.Next(wall).Before(w.endSchedule.Next(wall))
+}
+
+// wallClock returns the time read on the wall clock of the location of t, as UTC which doesn't change for DST
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func (m *cronMetadata) Validate() error {
+	location, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return fmt.Errorf("unable to load timezone: %w", err)
+	}
+	m.location = location
+
+	if m.Windows != "" {
+		if m.Start != "" || m.End != "" || m.DesiredReplicas != 0 {
+			return fmt.Errorf("start, end and desiredReplicas can't be used with windows")
+		}
+		decoder := yaml.NewDecoder(strings.NewReader(m.Windows))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&m.windows); err != nil {
+			return fmt.Errorf("error parsing windows: %w", err)
+		}
+		if len(m.windows) == 0 {
+			return fmt.Errorf("no window specified")
+		}
+	} else {
+		m.windows = []cronWindow{{Start: m.Start, End: m.End, DesiredReplicas: m.DesiredReplicas}}
+	}
+	for i := range m.windows {
+		if err := m.windows[i].parse(); err != nil {
+			if m.Windows != "" {
+				return fmt.Errorf("window %d: %w", i, err)
+			}
+			return err
+		}
+	}
+
+	m.excludeDates = map[string]bool{}
+	for _, date := range m.ExcludeDates {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("excludeDates must be dates in the format YYYY-MM-DD, got %s", date)
+		}
+		m.excludeDates[date] = true
+	}
+
+	switch {
+	case m.HolidayCalendar != "" && m.HolidayCalendarURL != "":
+		return fmt.Errorf("holidayCalendar and holidayCalendarURL can't be used together")
+	case m.HolidayCalendar != "":
+		if m.calendar, err = parseHolidayCalendar(m.HolidayCalendar, location); err != nil {
+			return err
+		}
+	}
+	if m.HolidayCalendarRefreshSeconds <= 0 {
+		return fmt.Errorf("holidayCalendarRefreshSeconds must be greater than 0")
+	}
+
+	return nil
+}
+
 func NewCronScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -67,23 +168,14 @@ func NewCronScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing cron metadata: %w", err)
 	}
 
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-
-	startSchedule, _ := parser.Parse(meta.Start)
-	endSchedule, _ := parser.Parse(meta.End)
-
-	return &cronScaler{
-		metricType:    metricType,
-		metadata:      meta,
-		logger:        InitializeLogger(config, "cron_scaler"),
-		startSchedule: startSchedule,
-		endSchedule:   endSchedule,
-	}, nil
-}
-
-func getCronTime(location *time.Location, schedule cron.Schedule) time.Time {
-	// Use the pre-parsed cron schedule directly to get the next time
-	return schedule.Next(time.Now().In(location))
+	s := &cronScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "cron_scaler"),
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+	}
+	s.logSkippedEvents(meta.calendar)
+	return s, nil
 }
 
 func parseCronMetadata(config *scalersconfig.ScalerConfig) (cronMetadata, error) {
@@ -95,6 +187,9 @@ func parseCronMetadata(config *scalersconfig.ScalerConfig) (cronMetadata, error)
 }
 
 func (s *cronScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -109,9 +204,13 @@ func parseCronTimeFormat(s string) string {
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *cronScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var specReplicas int64 = 1
+	metricName := fmt.Sprintf("cron-%s-%s-%s", s.metadata.Timezone, parseCronTimeFormat(s.metadata.Start), parseCronTimeFormat(s.metadata.End))
+	if s.metadata.Windows != "" {
+		metricName = fmt.Sprintf("cron-%s-windows", s.metadata.Timezone)
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("cron-%s-%s-%s", s.metadata.Timezone, parseCronTimeFormat(s.metadata.Start), parseCronTimeFormat(s.metadata.End)))),
+			Name: GenerateMetricNameWithIndex(s.metadata.TriggerIndex, kedautil.NormalizeString(metricName)),
 		},
 		Target: GetMetricTarget(s.metricType, specReplicas),
 	}
@@ -119,31 +218,94 @@ func (s *cronScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	return []v2.MetricSpec{metricSpec}
 }
 
-func (s *cronScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	location, err := time.LoadLocation(s.metadata.Timezone)
+// getHolidayCalendar returns the inline holiday calendar, or the one downloaded from holidayCalendarURL. The previous
+// calendar is kept when it can't be downloaded again.
+func (s *cronScaler) getHolidayCalendar(ctx context.Context) (*holidayCalendar, error) {
+	if s.metadata.HolidayCalendarURL == "" {
+		return s.metadata.calendar, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calendar != nil && time.Now().Before(s.calendarExpiry) {
+		return s.calendar, nil
+	}
+
+	calendar, err := s.downloadHolidayCalendar(ctx)
 	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("unable to load timezone: %w", err)
+		if s.calendar == nil {
+			return nil, err
+		}
+		s.logger.Error(err, "error downloading holiday calendar, keeping the previous one")
+		return s.calendar, nil
+	}
+	s.logSkippedEvents(calendar)
+	s.calendar = calendar
+	s.calendarExpiry = time.Now().Add(time.Duration(s.metadata.HolidayCalendarRefreshSeconds) * time.Second)
+	return s.calendar, nil
+}
+
+// logSkippedEvents warns about the events of the holiday calendar skipped because their recurrence rule isn't supported
+func (s *cronScaler) logSkippedEvents(calendar *holidayCalendar) {
+	if calendar == nil {
+		return
+	}
+	for _, err := range calendar.skipped {
+		s.logger.Info("Warning: holiday calendar event not supported", "error", err.Error())
 	}
+}
 
-	currentTime := time.Now().In(location)
+func (s *cronScaler) downloadHolidayCalendar(ctx context.Context) (*holidayCalendar, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.HolidayCalendarURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading holiday calendar: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading holiday calendar, status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("error downloading holiday calendar: %w", err)
+	}
+	return parseHolidayCalendar(string(body), s.metadata.location)
+}
 
-	// Use the pre-parsed schedules to get the next start and end times
-	nextStartTime := getCronTime(location, s.startSchedule)
-	nextEndTime := getCronTime(location, s.endSchedule)
+// getDesiredReplicas returns the highest desiredReplicas of the windows the time is within, unless the date is
+// excluded
+func (s *cronScaler) getDesiredReplicas(t time.Time, calendar *holidayCalendar) (int64, bool) {
+	t = t.In(s.metadata.location)
+	if s.metadata.excludeDates[t.Format(time.DateOnly)] || (calendar != nil && calendar.contains(t)) {
+		return 0, false
+	}
 
+	var desiredReplicas int64
 	isWithinInterval := false
+	for i := range s.metadata.windows {
+		window := &s.metadata.windows[i]
+		if window.isActive(t) {
+			isWithinInterval = true
+			desiredReplicas = max(desiredReplicas, window.DesiredReplicas)
+		}
+	}
+	return desiredReplicas, isWithinInterval
+}
 
-	if nextStartTime.Before(nextEndTime) {
-		// Interval within the same day
-		isWithinInterval = currentTime.After(nextStartTime) && currentTime.Before(nextEndTime)
-	} else {
-		// Interval spans midnight
-		isWithinInterval = currentTime.After(nextStartTime) || currentTime.Before(nextEndTime)
+func (s *cronScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	calendar, err := s.getHolidayCalendar(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
+	desiredReplicas, isWithinInterval := s.getDesiredReplicas(time.Now(), calendar)
+
 	metricValue := float64(1)
 	if isWithinInterval {
-		metricValue = float64(s.metadata.DesiredReplicas)
+		metricValue = float64(desiredReplicas)
 	}
 
 	metric := GenerateMetricInMili(metricName, metricValue)
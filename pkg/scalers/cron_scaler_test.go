@@ -2,11 +2,12 @@ package scalers
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -51,11 +52,59 @@ var testCronMetadata = []parseCronMetadataTestData{
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "-50 * * * *", "desiredReplicas": "10"}, true},
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "50 * * -3 *", "desiredReplicas": "10"}, true},
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "30 * * * *", "desiredReplicas": "10"}, true},
+	{map[string]string{"timezone": "Mars/Olympus", "start": "30 * * * *", "end": "45 * * * *", "desiredReplicas": "10"}, true},
+	{validCronWindowsMetadata, false},
+	{map[string]string{"timezone": "Etc/UTC", "windows": validCronWindowsMetadata["windows"], "desiredReplicas": "10"}, true},
+	{map[string]string{"timezone": "Etc/UTC", "windows": "[]"}, true},
+	{map[string]string{"timezone": "Etc/UTC", "windows": `[{"start": "0 8 * * *", "end": "0 18 * * *"}]`}, true},
+	{map[string]string{"timezone": "Etc/UTC", "windows": `[{"start": "0 8 * * *", "end": "0 18 * * *", "replicas": 3}]`}, true},
+	{map[string]string{"timezone": "Etc/UTC", "windows": `[{"start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": 3}]`, "excludeDates": "2026-12-25,2027-01-01"}, false},
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "3", "excludeDates": "25/12/2026"}, true},
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "3", "holidayCalendar": testHolidayCalendar}, false},
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "3", "holidayCalendar": "not a calendar"}, true},
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "3", "holidayCalendar": testHolidayCalendar, "holidayCalendarURL": "https://example.com/holidays.ics"}, true},
 }
 
+// Business hours on weekdays, overlapping peak hours, and a few replicas on weekends
+var validCronWindowsMetadata = map[string]string{
+	"timezone": "Europe/Berlin",
+	"windows": `
+- start: 0 8 * * 1-5
+  end: 0 18 * * 1-5
+  desiredReplicas: 10
+- start: 0 11 * * 1-5
+  end: 0 14 * * 1-5
+  desiredReplicas: 20
+- start: 0 10 * * 0,6
+  end: 0 16 * * 0,6
+  desiredReplicas: 3
+`,
+}
+
+const testHolidayCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"DTSTART;VALUE=DATE:20201225\r\n" +
+	"DTEND;VALUE=DATE:20201227\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Company\r\n" +
+	"  offsite\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20260318T120000\r\n" +
+	"DTEND:20260318T150000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Founding day\r\n" +
+	"DTSTART;VALUE=DATE:20260601\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
 var cronMetricIdentifiers = []cronMetricIdentifier{
 	{&testCronMetadata[1], 0, "s0-cron-Etc-UTC-00xxThu-5923xxThu"},
 	{&testCronMetadata[2], 1, "s1-cron-Etc-UTC-0xSl2xxx-01-23Sl2xxx"},
+	{&testCronMetadata[12], 2, "s2-cron-Europe-Berlin-windows"},
 }
 
 var tz, _ = time.LoadLocation(validCronMetadata2["timezone"])
@@ -123,16 +172,10 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 			t.Fatal("Could not parse metadata:", err)
 		}
 
-		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-		startSchedule, _ := parser.Parse(meta.Start)
-		endSchedule, _ := parser.Parse(meta.End)
-
 		mockCronScaler := cronScaler{
-			metricType:    "",
-			metadata:      meta,
-			logger:        logr.Discard(),
-			startSchedule: startSchedule,
-			endSchedule:   endSchedule,
+			metricType: "",
+			metadata:   meta,
+			logger:     logr.Discard(),
 		}
 
 		metricSpec := mockCronScaler.GetMetricSpecForScaling(context.Background())
@@ -142,3 +185,193 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func newTestCronScaler(t *testing.T, metadata map[string]string) *cronScaler {
+	scaler, err := NewCronScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata})
+	if err != nil {
+		t.Fatal("Could not create cron scaler:", err)
+	}
+	return scaler.(*cronScaler)
+}
+
+func TestCronWindows(t *testing.T) {
+	scaler := newTestCronScaler(t, validCronWindowsMetadata)
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	tests := []struct {
+		comment         string
+		time            time.Time
+		desiredReplicas int64
+		isActive        bool
+	}{
+		{"weekday night", time.Date(2026, 10, 14, 6, 0, 0, 0, berlin), 0, false},
+		{"weekday business hours", time.Date(2026, 10, 14, 9, 0, 0, 0, berlin), 10, true},
+		{"weekday peak hours", time.Date(2026, 10, 14, 12, 0, 0, 0, berlin), 20, true},
+		{"weekday evening", time.Date(2026, 10, 14, 19, 0, 0, 0, berlin), 0, false},
+		{"weekend", time.Date(2026, 10, 17, 12, 0, 0, 0, berlin), 3, true},
+		{"in another timezone", time.Date(2026, 10, 14, 7, 30, 0, 0, time.UTC), 10, true},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			desiredReplicas, isActive := scaler.getDesiredReplicas(test.time, nil)
+			assert.Equal(t, test.isActive, isActive)
+			assert.Equal(t, test.desiredReplicas, desiredReplicas)
+		})
+	}
+}
+
+func TestCronDST(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	scaler := newTestCronScaler(t, map[string]string{
+		"timezone": "America/New_York",
+		"windows": `
+- start: 30 2 * * *
+  end: 0 6 * * *
+  desiredReplicas: 5
+- start: 0 22 * * *
+  end: 30 2 * * *
+  desiredReplicas: 8
+- start: 0 9 * * *
+  end: 0 17 * * *
+  desiredReplicas: 10
+`,
+	})
+
+	tests := []struct {
+		comment         string
+		time            time.Time
+		desiredReplicas int64
+		isActive        bool
+	}{
+		// the clocks go forward from 2:00 to 3:00 on 2026-03-08
+		{"the evening before the clocks go forward", time.Date(2026, 3, 7, 19, 0, 0, 0, newYork), 0, false},
+		{"before the clocks go forward", time.Date(2026, 3, 8, 1, 30, 0, 0, newYork), 8, true},
+		{"window starting in the skipped hour", time.Date(2026, 3, 8, 3, 0, 0, 0, newYork), 5, true},
+		{"window ending in the skipped hour", time.Date(2026, 3, 8, 3, 30, 0, 0, newYork), 5, true},
+		{"business hours after the clocks go forward", time.Date(2026, 3, 8, 9, 30, 0, 0, newYork), 10, true},
+		{"before business hours after the clocks go forward", time.Date(2026, 3, 8, 8, 30, 0, 0, newYork), 0, false},
+		// the clocks go back from 2:00 to 1:00 on 2026-11-01
+		{"business hours after the clocks go back", time.Date(2026, 11, 1, 16, 30, 0, 0, newYork), 10, true},
+		{"after business hours after the clocks go back", time.Date(2026, 11, 1, 17, 30, 0, 0, newYork), 0, false},
+		{"night window after the clocks go back", time.Date(2026, 11, 1, 3, 0, 0, 0, newYork), 5, true},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			desiredReplicas, isActive := scaler.getDesiredReplicas(test.time, nil)
+			assert.Equal(t, test.isActive, isActive)
+			assert.Equal(t, test.desiredReplicas, desiredReplicas)
+		})
+	}
+}
+
+func TestCronExclusions(t *testing.T) {
+	metadata := map[string]string{
+		"timezone":        "Europe/Berlin",
+		"start":           "0 0 * * *",
+		"end":             "59 23 * * *",
+		"desiredReplicas": "10",
+		"excludeDates":    "2026-10-14",
+		"holidayCalendar": testHolidayCalendar,
+	}
+	scaler := newTestCronScaler(t, metadata)
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	tests := []struct {
+		comment  string
+		time     time.Time
+		isActive bool
+	}{
+		{"working day", time.Date(2026, 10, 15, 12, 0, 0, 0, berlin), true},
+		{"excluded date", time.Date(2026, 10, 14, 12, 0, 0, 0, berlin), false},
+		{"excluded date in the timezone", time.Date(2026, 10, 13, 23, 30, 0, 0, time.UTC), false},
+		{"yearly holiday", time.Date(2026, 12, 26, 12, 0, 0, 0, berlin), false},
+		{"after the yearly holiday", time.Date(2026, 12, 27, 12, 0, 0, 0, berlin), true},
+		{"timed event", time.Date(2026, 3, 18, 14, 0, 0, 0, berlin), false},
+		{"after the timed event", time.Date(2026, 3, 18, 16, 30, 0, 0, berlin), true},
+		{"all-day event without end", time.Date(2026, 6, 1, 20, 0, 0, 0, berlin), false},
+		{"next year of an event without recurrence", time.Date(2027, 6, 1, 20, 0, 0, 0, berlin), true},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			_, isActive := scaler.getDesiredReplicas(test.time, scaler.metadata.calendar)
+			assert.Equal(t, test.isActive, isActive)
+		})
+	}
+}
+
+func TestCronHolidayCalendarRecurrence(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	calendar, err := parseHolidayCalendar("BEGIN:VCALENDAR\n"+
+		// weekly events aren't supported, the event is skipped
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20260105\nRRULE:FREQ=WEEKLY;BYDAY=MO\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20250501\nRRULE:FREQ=YEARLY;COUNT=2\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20241003\nRRULE:FREQ=YEARLY;UNTIL=20261003\nEND:VEVENT\n"+
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20241225\nRRULE:FREQ=YEARLY\nEXDATE;VALUE=DATE:20251225,20261225\nEND:VEVENT\n"+
+		"END:VCALENDAR", berlin)
+	assert.NoError(t, err)
+	assert.Len(t, calendar.events, 3)
+	assert.Len(t, calendar.skipped, 1)
+
+	tests := []struct {
+		comment  string
+		time     time.Time
+		contains bool
+	}{
+		{"skipped weekly event", time.Date(2026, 1, 12, 12, 0, 0, 0, berlin), false},
+		{"occurrence within COUNT", time.Date(2026, 5, 1, 12, 0, 0, 0, berlin), true},
+		{"occurrence beyond COUNT", time.Date(2027, 5, 1, 12, 0, 0, 0, berlin), false},
+		{"occurrence on UNTIL", time.Date(2026, 10, 3, 12, 0, 0, 0, berlin), true},
+		{"occurrence after UNTIL", time.Date(2027, 10, 3, 12, 0, 0, 0, berlin), false},
+		{"occurrence not excluded", time.Date(2024, 12, 25, 12, 0, 0, 0, berlin), true},
+		{"excluded occurrence", time.Date(2026, 12, 25, 12, 0, 0, 0, berlin), false},
+		{"occurrence after the excluded ones", time.Date(2027, 12, 25, 12, 0, 0, 0, berlin), true},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			assert.Equal(t, test.contains, calendar.contains(test.time))
+		})
+	}
+}
+
+func TestCronHolidayCalendarURL(t *testing.T) {
+	requests := 0
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testHolidayCalendar))
+	}))
+	defer server.Close()
+
+	scaler := newTestCronScaler(t, map[string]string{
+		"timezone":           "Europe/Berlin",
+		"start":              "0 0 * * *",
+		"end":                "59 23 * * *",
+		"desiredReplicas":    "10",
+		"holidayCalendarURL": server.URL,
+	})
+
+	calendar, err := scaler.getHolidayCalendar(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, calendar.events, 3)
+
+	// the calendar is cached
+	_, err = scaler.getHolidayCalendar(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	// the previous calendar is kept when it can't be downloaded again
+	available = false
+	scaler.calendarExpiry = time.Now()
+	calendar, err = scaler.getHolidayCalendar(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, calendar.events, 3)
+	assert.Equal(t, 2, requests)
+
+	scaler.calendar = nil
+	_, _, err = scaler.GetMetricsAndActivity(context.Background(), "ReplicaCount")
+	assert.Error(t, err)
+}
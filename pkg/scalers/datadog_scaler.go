@@ -56,6 +56,11 @@ type datadogMetadata struct {
 
 	// TriggerMetadata Datadog API
 	query                    string
+	monitorName              string
+	monitorStatuses          []string
+	sloID                    string
+	sloTimeWindow            int
+	sloTarget                float64
 	queryAggegrator          string
 	activationQueryValue     float64
 	age                      int
@@ -73,8 +78,14 @@ type datadogMetadata struct {
 const maxString = "max"
 const avgString = "average"
 
+// defaultDatadogMonitorStatuses are the statuses of the groups of a monitor counted by default, the ones paging on-call
+var defaultDatadogMonitorStatuses = []string{"Alert", "Warn"}
+
 var filter *regexp.Regexp
 
+// datadogMonitorNameMetacharacters are the characters of the names of the monitors not allowed in metric names
+var datadogMonitorNameMetacharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
 func init() {
 	filter = regexp.MustCompile(`.*\{.*\}.*`)
 }
@@ -189,6 +200,9 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 		meta.lastAvailablePointOffset = 0 // Default use the last point
 	}
 
+	// the scaler follows a metric query, the status of a monitor or the burn rate of a SLO
+	meta.monitorName = config.TriggerMetadata["monitorName"]
+	meta.sloID = config.TriggerMetadata["sloId"]
 	if val, ok := config.TriggerMetadata["query"]; ok {
 		_, err := parseDatadogQuery(val)
 
@@ -196,9 +210,46 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 			return nil, fmt.Errorf("error in query: %w", err)
 		}
 		meta.query = val
-	} else {
+	} else if meta.monitorName == "" && meta.sloID == "" {
 		return nil, fmt.Errorf("no query given")
 	}
+	if (meta.query != "" && meta.monitorName != "") || (meta.query != "" && meta.sloID != "") || (meta.monitorName != "" && meta.sloID != "") {
+		return nil, fmt.Errorf("only one of query, monitorName and sloId can be given")
+	}
+
+	meta.monitorStatuses = defaultDatadogMonitorStatuses
+	if val, ok := config.TriggerMetadata["monitorStatuses"]; ok && val != "" {
+		if meta.monitorName == "" {
+			return nil, fmt.Errorf("monitorStatuses can only be given with monitorName")
+		}
+		meta.monitorStatuses = strings.Split(val, ",")
+		for i := range meta.monitorStatuses {
+			meta.monitorStatuses[i] = strings.TrimSpace(meta.monitorStatuses[i])
+		}
+	}
+
+	meta.sloTimeWindow = 3600 // Default burn rate over the last hour
+	if val, ok := config.TriggerMetadata["sloTimeWindow"]; ok {
+		sloTimeWindow, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("sloTimeWindow parsing error %w", err)
+		}
+		if sloTimeWindow < 60 {
+			return nil, fmt.Errorf("sloTimeWindow should not be smaller than 60 seconds")
+		}
+		meta.sloTimeWindow = sloTimeWindow
+	}
+
+	if val, ok := config.TriggerMetadata["sloTarget"]; ok {
+		sloTarget, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sloTarget parsing error %w", err)
+		}
+		if sloTarget <= 0 || sloTarget >= 100 {
+			return nil, fmt.Errorf("sloTarget should be between 0 and 100")
+		}
+		meta.sloTarget = sloTarget
+	}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
 		targetValue, err := strconv.ParseFloat(val, 64)
@@ -292,7 +343,15 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 
 	meta.datadogSite = siteVal
 
-	hpaMetricName := meta.query[0:strings.Index(meta.query, "{")]
+	var hpaMetricName string
+	switch {
+	case meta.monitorName != "":
+		hpaMetricName = fmt.Sprintf("monitor-%s", strings.Trim(datadogMonitorNameMetacharacters.ReplaceAllString(meta.monitorName, "-"), "-"))
+	case meta.sloID != "":
+		hpaMetricName = fmt.Sprintf("slo-%s", meta.sloID)
+	default:
+		hpaMetricName = meta.query[0:strings.Index(meta.query, "{")]
+	}
 	meta.hpaMetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", hpaMetricName)))
 
 	return &meta, nil
@@ -447,6 +506,9 @@ func newDatadogAPIConnection(ctx context.Context, meta *datadogMetadata, config
 
 	configuration := datadog.NewConfiguration()
 	configuration.HTTPClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	if meta.sloID != "" {
+		configuration.SetUnstableOperationEnabled("GetSLOHistory", true)
+	}
 	apiClient := datadog.NewAPIClient(configuration)
 
 	_, _, err := apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
@@ -465,8 +527,8 @@ func (s *datadogScaler) Close(context.Context) error {
 	return nil
 }
 
-// getQueryResult returns result of the scaler query
-func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
+// apiContext returns the context of the requests to the Datadog API, holding the keys and the site
+func (s *datadogScaler) apiContext(ctx context.Context) context.Context {
 	ctx = context.WithValue(
 		ctx,
 		datadog.ContextAPIKeys,
@@ -480,35 +542,52 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 		},
 	)
 
-	ctx = context.WithValue(ctx,
+	return context.WithValue(ctx,
 		datadog.ContextServerVariables,
 		map[string]string{
 			"site": s.metadata.datadogSite,
 		})
+}
 
-	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
-	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
-	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(ctx, timeWindowFrom, timeWindowTo, s.metadata.query) //nolint:bodyclose
-
+// checkDatadogResponse returns the error of a request to the Datadog API retrieving the resource, e.g. metrics
+func checkDatadogResponse(r *http.Response, err error, resource string) error {
 	if r != nil {
 		if r.StatusCode == 429 {
 			rateLimit := r.Header.Get("X-Ratelimit-Limit")
 			rateLimitReset := r.Header.Get("X-Ratelimit-Reset")
 			rateLimitPeriod := r.Header.Get("X-Ratelimit-Period")
 
-			return -1, fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
+			return fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
 		}
 
 		if r.StatusCode != 200 {
 			if err != nil {
-				return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+				return fmt.Errorf("error when retrieving Datadog %s: %w", resource, err)
 			}
-			return -1, fmt.Errorf("error when retrieving Datadog metrics")
+			return fmt.Errorf("error when retrieving Datadog %s", resource)
 		}
 	}
 
 	if err != nil {
-		return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+		return fmt.Errorf("error when retrieving Datadog %s: %w", resource, err)
+	}
+	return nil
+}
+
+// getQueryResult returns result of the scaler query
+func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
+	switch {
+	case s.metadata.monitorName != "":
+		return s.getMonitorStatusCount(ctx)
+	case s.metadata.sloID != "":
+		return s.getSLOBurnRate(ctx)
+	}
+
+	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
+	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
+	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(s.apiContext(ctx), timeWindowFrom, timeWindowTo, s.metadata.query) //nolint:bodyclose
+	if err := checkDatadogResponse(r, err, "metrics"); err != nil {
+		return -1, err
 	}
 
 	if resp.GetStatus() == "error" {
@@ -568,6 +647,86 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 	}
 }
 
+// getMonitorStatusCount returns the number of groups of the monitors named after monitorName in one of the
+// monitorStatuses, e.g. the groups alerting or warning
+func (s *datadogScaler) getMonitorStatusCount(ctx context.Context) (float64, error) {
+	params := datadog.NewSearchMonitorGroupsOptionalParameters().
+		WithQuery(fmt.Sprintf("title:%q", s.metadata.monitorName)).
+		WithPerPage(1)
+	resp, r, err := s.apiClient.MonitorsApi.SearchMonitorGroups(s.apiContext(ctx), *params) //nolint:bodyclose
+	if err := checkDatadogResponse(r, err, "monitors"); err != nil {
+		return -1, err
+	}
+
+	metadata := resp.GetMetadata()
+	if metadata.TotalCount != nil && *metadata.TotalCount == 0 {
+		if !s.metadata.useFiller {
+			return 0, fmt.Errorf("no Datadog monitor named %s", s.metadata.monitorName)
+		}
+		return s.metadata.fillValue, nil
+	}
+	counts := resp.GetCounts()
+	return countMonitorStatuses(counts.GetStatus(), s.metadata.monitorStatuses), nil
+}
+
+// countMonitorStatuses returns the number of groups in one of the statuses, compared regardless of the case
+func countMonitorStatuses(items []datadog.MonitorSearchCountItem, statuses []string) float64 {
+	var count int64
+	for _, item := range items {
+		name, ok := item.GetName().(string)
+		if !ok {
+			continue
+		}
+		for _, status := range statuses {
+			if strings.EqualFold(name, status) {
+				count += item.GetCount()
+				break
+			}
+		}
+	}
+	return float64(count)
+}
+
+// getSLOBurnRate returns the rate at which the error budget of the SLO is consumed over the sloTimeWindow, 1 when
+// it's consumed exactly by the end of the timeframe of the SLO
+func (s *datadogScaler) getSLOBurnRate(ctx context.Context) (float64, error) {
+	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
+	timeWindowFrom := timeWindowTo - int64(s.metadata.sloTimeWindow)
+	resp, r, err := s.apiClient.ServiceLevelObjectivesApi.GetSLOHistory(s.apiContext(ctx), s.metadata.sloID, timeWindowFrom, timeWindowTo) //nolint:bodyclose
+	if err := checkDatadogResponse(r, err, "SLO history"); err != nil {
+		return -1, err
+	}
+
+	burnRate, err := sloBurnRate(resp.GetData(), s.metadata.sloTarget)
+	if err != nil {
+		if !s.metadata.useFiller {
+			return 0, err
+		}
+		return s.metadata.fillValue, nil
+	}
+	return burnRate, nil
+}
+
+// sloBurnRate returns the burn rate of the SLI of the history, relative to the target or to the strictest target
+// of the thresholds of the SLO when no target is given
+func sloBurnRate(data datadog.SLOHistoryResponseData, target float64) (float64, error) {
+	if target == 0 {
+		for _, threshold := range data.GetThresholds() {
+			target = max(target, threshold.Target)
+		}
+	}
+	if target <= 0 || target >= 100 {
+		return 0, fmt.Errorf("no target of the SLO between 0 and 100")
+	}
+
+	overall := data.GetOverall()
+	sli, ok := overall.GetSliValueOk()
+	if !ok || sli == nil {
+		return 0, fmt.Errorf("no Datadog SLI returned for the given time window")
+	}
+	return (100 - *sli) / (100 - target), nil
+}
+
 func (s *datadogScaler) getDatadogMetricValue(req *http.Request) (float64, error) {
 	resp, err := s.httpClient.Do(req)
 
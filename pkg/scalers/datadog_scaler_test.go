@@ -2,8 +2,10 @@ package scalers
 
 import (
 	"context"
+	"math"
 	"testing"
 
+	datadog "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 
@@ -163,6 +165,22 @@ var testDatadogAPIMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey"}, true},
 	// invalid query missing {
 	{"", map[string]string{"query": "sum:trace.redis.command.hits.as_count()", "queryValue": "7"}, map[string]string{}, true},
+	// monitor status
+	{"", map[string]string{"monitorName": "Checkout latency", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// monitor status with statuses
+	{"", map[string]string{"monitorName": "Checkout latency", "monitorStatuses": "Alert, No Data", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// SLO burn rate
+	{"", map[string]string{"sloId": "abc123", "sloTimeWindow": "1800", "sloTarget": "99.9", "queryValue": "2"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// query and monitor
+	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "monitorName": "Checkout latency", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// monitor and SLO
+	{"", map[string]string{"monitorName": "Checkout latency", "sloId": "abc123", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// monitorStatuses without monitor
+	{"", map[string]string{"sloId": "abc123", "monitorStatuses": "Alert", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// too short sloTimeWindow
+	{"", map[string]string{"sloId": "abc123", "sloTimeWindow": "30", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// sloTarget out of range
+	{"", map[string]string{"sloId": "abc123", "sloTarget": "100", "queryValue": "1"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
 }
 
 func TestDatadogScalerAPIAuthParams(t *testing.T) {
@@ -195,6 +213,8 @@ var datadogMetricIdentifiers = []datadogMetricIdentifier{
 	{&testDatadogAPIMetadata[1], apiType, 0, "s0-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogAPIMetadata[1], apiType, 1, "s1-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogClusterAgentMetadata[1], clusterAgentType, 0, "datadogmetric@default:nginx-hits"},
+	{&testDatadogAPIMetadata[21], apiType, 0, "s0-datadog-monitor-Checkout-latency"},
+	{&testDatadogAPIMetadata[23], apiType, 2, "s2-datadog-slo-abc123"},
 }
 
 func TestDatadogGetMetricSpecForScaling(t *testing.T) {
@@ -240,3 +260,54 @@ func TestBuildMetricURL(t *testing.T) {
 		t.Error("Expected https://localhost:8080/apis/datadoghq.com/v1alpha1/namespaces/datadogMetricNamespace/datadogMetricName, got ", url)
 	}
 }
+
+func TestCountMonitorStatuses(t *testing.T) {
+	item := func(name interface{}, count int64) datadog.MonitorSearchCountItem {
+		return datadog.MonitorSearchCountItem{Name: name, Count: &count}
+	}
+	items := []datadog.MonitorSearchCountItem{item("Alert", 3), item("Warn", 2), item("OK", 10), item("No Data", 1), item(nil, 4)}
+
+	assertEqual(t, countMonitorStatuses(items, defaultDatadogMonitorStatuses), float64(5))
+	assertEqual(t, countMonitorStatuses(items, []string{"alert", "no data"}), float64(4))
+	assertEqual(t, countMonitorStatuses(nil, defaultDatadogMonitorStatuses), float64(0))
+}
+
+func TestSLOBurnRate(t *testing.T) {
+	sli := func(value float64) *datadog.SLOHistorySLIData {
+		return &datadog.SLOHistorySLIData{SliValue: &value}
+	}
+	thresholds := map[string]datadog.SLOThreshold{
+		"7d":  {Target: 99, Timeframe: datadog.SLOTIMEFRAME_SEVEN_DAYS},
+		"30d": {Target: 99.5, Timeframe: datadog.SLOTIMEFRAME_THIRTY_DAYS},
+	}
+
+	testCases := []struct {
+		name     string
+		data     datadog.SLOHistoryResponseData
+		target   float64
+		expected float64
+		isError  bool
+	}{
+		{"strictest threshold", datadog.SLOHistoryResponseData{Overall: sli(99), Thresholds: thresholds}, 0, 2, false},
+		{"given target", datadog.SLOHistoryResponseData{Overall: sli(99.9), Thresholds: thresholds}, 99.8, 0.5, false},
+		{"no error", datadog.SLOHistoryResponseData{Overall: sli(100), Thresholds: thresholds}, 0, 0, false},
+		{"no SLI", datadog.SLOHistoryResponseData{Thresholds: thresholds}, 0, 0, true},
+		{"no target", datadog.SLOHistoryResponseData{Overall: sli(99)}, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		burnRate, err := sloBurnRate(tc.data, tc.target)
+		if tc.isError {
+			if err == nil {
+				t.Errorf("%s: expected error but got success", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", tc.name, err)
+		}
+		if math.Abs(burnRate-tc.expected) > 1e-9 {
+			t.Errorf("%s: expected burn rate %v, got %v", tc.name, tc.expected, burnRate)
+		}
+	}
+}
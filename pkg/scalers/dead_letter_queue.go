@@ -0,0 +1,31 @@
+package scalers
+
+const (
+	deadLetterBehaviorAdd    = "add"
+	deadLetterBehaviorBlock  = "block"
+	deadLetterBehaviorIgnore = "ignore"
+)
+
+// deadLetterMetadata is how the scalers of the queues with a dead-letter queue take its depth into account:
+// added to the value of the metric, blocking the scaling to zero while it isn't empty, or ignored
+type deadLetterMetadata struct {
+	DLQBehavior string `keda:"name=dlqBehavior, order=triggerMetadata, enum=add;block;ignore, optional, default=ignore"`
+}
+
+// enabled returns whether the depth of the dead-letter queue must be read
+func (m deadLetterMetadata) enabled() bool {
+	return m.DLQBehavior != "" && m.DLQBehavior != deadLetterBehaviorIgnore
+}
+
+// apply returns the value of the metric and the activity of the scaler with the depth of the dead-letter queue
+func (m deadLetterMetadata) apply(value, deadLetterDepth, activationValue float64) (float64, bool) {
+	switch m.DLQBehavior {
+	case deadLetterBehaviorAdd:
+		value += deadLetterDepth
+		return value, value > activationValue
+	case deadLetterBehaviorBlock:
+		return value, value > activationValue || deadLetterDepth > 0
+	default:
+		return value, value > activationValue
+	}
+}
@@ -0,0 +1,33 @@
+package scalers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterMetadataApply(t *testing.T) {
+	testCases := []struct {
+		behavior        string
+		value           float64
+		deadLetterDepth float64
+		expectedValue   float64
+		isActive        bool
+	}{
+		{"", 0, 3, 0, false},
+		{deadLetterBehaviorIgnore, 0, 3, 0, false},
+		{deadLetterBehaviorIgnore, 2, 3, 2, true},
+		{deadLetterBehaviorAdd, 0, 3, 3, true},
+		{deadLetterBehaviorAdd, 0, 1, 1, false},
+		{deadLetterBehaviorBlock, 0, 3, 0, true},
+		{deadLetterBehaviorBlock, 0, 0, 0, false},
+	}
+
+	for _, tc := range testCases {
+		meta := deadLetterMetadata{DLQBehavior: tc.behavior}
+		value, isActive := meta.apply(tc.value, tc.deadLetterDepth, 1)
+		assert.Equal(t, tc.expectedValue, value, tc.behavior)
+		assert.Equal(t, tc.isActive, isActive, tc.behavior)
+		assert.Equal(t, tc.behavior == deadLetterBehaviorAdd || tc.behavior == deadLetterBehaviorBlock, meta.enabled())
+	}
+}
@@ -0,0 +1,222 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	druidMetricLag          = "lag"
+	druidMetricPendingTasks = "pendingTasks"
+
+	druidSupervisorsPath = "/druid/indexer/v1/supervisor"
+	druidTasksPath       = "/druid/indexer/v1/tasks"
+)
+
+// druidDefaultTargetValues are the default targets of the metrics: the records a supervisor hasn't
+// ingested yet, or the milliseconds it's behind for Kinesis, and the tasks waiting for a worker slot
+var druidDefaultTargetValues = map[string]float64{
+	druidMetricLag:          10000,
+	druidMetricPendingTasks: 1,
+}
+
+type druidScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *druidMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type druidMetadata struct {
+	triggerIndex int
+
+	// URL is the address of the overlord, or of a router proxying to it
+	URL                   string   `keda:"name=url,                   order=triggerMetadata;resolvedEnv"`
+	Metric                string   `keda:"name=metric,                order=triggerMetadata, enum=lag;pendingTasks, optional, default=lag"`
+	SupervisorIDs         []string `keda:"name=supervisorIDs,         order=triggerMetadata, optional"`
+	DataSource            string   `keda:"name=dataSource,            order=triggerMetadata, optional"`
+	TargetValue           float64  `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64  `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool     `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+}
+
+type druidSupervisorStatus struct {
+	Payload struct {
+		AggregateLag  *int64 `json:"aggregateLag"`
+		DetailedState string `json:"detailedState"`
+	} `json:"payload"`
+}
+
+func (m *druidMetadata) Validate() error {
+	if m.Metric == druidMetricPendingTasks && len(m.SupervisorIDs) > 0 {
+		return errors.New("supervisorIDs can only be used to scale on lag")
+	}
+	if m.Metric == druidMetricLag && m.DataSource != "" {
+		return errors.New("dataSource can only be used to scale on pendingTasks")
+	}
+	if m.TargetValue == 0 {
+		m.TargetValue = druidDefaultTargetValues[m.Metric]
+	}
+	if m.TargetValue < 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	return nil
+}
+
+// NewDruidScaler creates a new druidScaler
+func NewDruidScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseDruidMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing druid metadata: %w", err)
+	}
+
+	return &druidScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "druid_scaler"),
+	}, nil
+}
+
+func parseDruidMetadata(config *scalersconfig.ScalerConfig) (*druidMetadata, error) {
+	meta := &druidMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *druidScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *druidScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := "druid-" + s.metadata.Metric
+	if len(s.metadata.SupervisorIDs) > 0 {
+		name += "-" + strings.Join(s.metadata.SupervisorIDs, "-")
+	}
+	if s.metadata.DataSource != "" {
+		name += "-" + s.metadata.DataSource
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the ingestion lag of the supervisors or the number of pending tasks
+func (s *druidScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var value float64
+	var err error
+	if s.metadata.Metric == druidMetricLag {
+		value, err = s.getLag(ctx)
+	} else {
+		value, err = s.getPendingTaskCount(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting druid %s: %w", s.metadata.Metric, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getLag sums up the aggregate lag of the supervisors, of all the supervisors when none is provided
+func (s *druidScaler) getLag(ctx context.Context) (float64, error) {
+	supervisorIDs := s.metadata.SupervisorIDs
+	if len(supervisorIDs) == 0 {
+		if err := s.getJSON(ctx, druidSupervisorsPath, &supervisorIDs); err != nil {
+			return -1, err
+		}
+	}
+
+	var lag float64
+	for _, supervisorID := range supervisorIDs {
+		var status druidSupervisorStatus
+		if err := s.getJSON(ctx, fmt.Sprintf("%s/%s/status", druidSupervisorsPath, url.PathEscape(supervisorID)), &status); err != nil {
+			return -1, fmt.Errorf("error getting status of supervisor %s: %w", supervisorID, err)
+		}
+		// the lag isn't reported while the supervisor is suspended or hasn't fetched the offsets yet
+		if status.Payload.AggregateLag == nil {
+			s.logger.V(1).Info("no lag reported by supervisor", "supervisor", supervisorID, "state", status.Payload.DetailedState)
+			continue
+		}
+		lag += float64(*status.Payload.AggregateLag)
+	}
+	return lag, nil
+}
+
+func (s *druidScaler) getPendingTaskCount(ctx context.Context) (float64, error) {
+	query := url.Values{}
+	query.Set("state", "pending")
+	if s.metadata.DataSource != "" {
+		query.Set("datasource", s.metadata.DataSource)
+	}
+
+	var tasks []json.RawMessage
+	if err := s.getJSON(ctx, druidTasksPath+"?"+query.Encode(), &tasks); err != nil {
+		return -1, err
+	}
+	return float64(len(tasks)), nil
+}
+
+func (s *druidScaler) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, string(body))
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,134 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseDruidMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type druidMetricIdentifier struct {
+	metadataTestData *parseDruidMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testDruidMetadata = []parseDruidMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://druid-overlord:8090"}, map[string]string{}, false, "url only"},
+	{map[string]string{"url": "http://druid-router:8888", "supervisorIDs": "wikipedia,clicks", "targetValue": "5000", "activationTargetValue": "100"}, map[string]string{"username": "admin", "password": "secret"}, false, "lag of supervisors with basic auth"},
+	{map[string]string{"url": "http://druid-router:8888", "metric": "pendingTasks", "dataSource": "wikipedia"}, map[string]string{}, false, "pending tasks of a data source"},
+	{map[string]string{"url": "http://druid-router:8888", "metric": "segments"}, map[string]string{}, true, "unknown metric"},
+	{map[string]string{"url": "http://druid-router:8888", "metric": "pendingTasks", "supervisorIDs": "wikipedia"}, map[string]string{}, true, "supervisors with pending tasks"},
+	{map[string]string{"url": "http://druid-router:8888", "dataSource": "wikipedia"}, map[string]string{}, true, "data source with lag"},
+	{map[string]string{"url": "http://druid-router:8888", "targetValue": "-1"}, map[string]string{}, true, "negative targetValue"},
+	{map[string]string{"url": "http://druid-router:8888"}, map[string]string{"username": "admin"}, true, "username without password"},
+}
+
+var druidMetricIdentifiers = []druidMetricIdentifier{
+	{&testDruidMetadata[1], 0, "s0-druid-lag"},
+	{&testDruidMetadata[2], 1, "s1-druid-lag-wikipedia-clicks"},
+	{&testDruidMetadata[3], 2, "s2-druid-pendingTasks-wikipedia"},
+}
+
+func TestDruidParseMetadata(t *testing.T) {
+	for _, testData := range testDruidMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseDruidMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestDruidParseMetadataDefaultTargetValue(t *testing.T) {
+	meta, err := parseDruidMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testDruidMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10000), meta.TargetValue)
+
+	meta, err = parseDruidMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testDruidMetadata[3].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), meta.TargetValue)
+}
+
+func TestDruidGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range druidMetricIdentifiers {
+		meta, err := parseDruidMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockDruidScaler := druidScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockDruidScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestDruidGetMetricsAndActivity(t *testing.T) {
+	responses := map[string]string{
+		"/druid/indexer/v1/supervisor":                               `["wikipedia","clicks","suspended"]`,
+		"/druid/indexer/v1/supervisor/wikipedia/status":              `{"id":"wikipedia","payload":{"dataSource":"wikipedia","state":"RUNNING","detailedState":"RUNNING","aggregateLag":1500}}`,
+		"/druid/indexer/v1/supervisor/clicks/status":                 `{"id":"clicks","payload":{"dataSource":"clicks","state":"RUNNING","detailedState":"RUNNING","aggregateLag":250}}`,
+		"/druid/indexer/v1/supervisor/suspended/status":              `{"id":"suspended","payload":{"dataSource":"suspended","state":"SUSPENDED","detailedState":"SUSPENDED","suspended":true}}`,
+		"/druid/indexer/v1/tasks?state=pending":                      `[{"id":"index_kafka_wikipedia_1","dataSource":"wikipedia"},{"id":"index_kafka_clicks_1","dataSource":"clicks"}]`,
+		"/druid/indexer/v1/tasks?datasource=wikipedia&state=pending": `[{"id":"index_kafka_wikipedia_1","dataSource":"wikipedia"}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, found := responses[r.URL.RequestURI()]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isError       bool
+	}{
+		{"lag of all the supervisors", map[string]string{}, 1750, false},
+		{"lag of the supervisor", map[string]string{"supervisorIDs": "wikipedia"}, 1500, false},
+		{"unknown supervisor", map[string]string{"supervisorIDs": "orders"}, 0, true},
+		{"pending tasks", map[string]string{"metric": "pendingTasks"}, 2, false},
+		{"pending tasks of the data source", map[string]string{"metric": "pendingTasks", "dataSource": "wikipedia"}, 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["url"] = server.URL
+			s, err := NewDruidScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata})
+			assert.NoError(t, err)
+
+			metrics, _, err := s.GetMetricsAndActivity(context.Background(), "druid")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 
 const (
 	dynatraceMetricDataPointsAPI = "api/v2/metrics/query"
+	dynatraceProblemsAPI         = "api/v2/problems"
+	dynatraceEventsAPI           = "api/v2/events"
 )
 
 type dynatraceScaler struct {
@@ -32,11 +34,33 @@ type dynatraceScaler struct {
 type dynatraceMetadata struct {
 	Host                string  `keda:"name=host, order=triggerMetadata;authParams"`
 	Token               string  `keda:"name=token, order=authParams"`
-	MetricSelector      string  `keda:"name=metricSelector, order=triggerMetadata"`
+	MetricSelector      string  `keda:"name=metricSelector, order=triggerMetadata, optional"`
+	ProblemSelector     string  `keda:"name=problemSelector, order=triggerMetadata, optional"`
+	EventSelector       string  `keda:"name=eventSelector, order=triggerMetadata, optional"`
+	EntitySelector      string  `keda:"name=entitySelector, order=triggerMetadata, optional"`
 	FromTimestamp       string  `keda:"name=from, order=triggerMetadata, default=now-2h, optional"`
 	Threshold           float64 `keda:"name=threshold, order=triggerMetadata"`
 	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
-	TriggerIndex        int
+	// SeverityWeights are the weights of the open problems by severity level, or of the events by event type,
+	// in the value the scaler scales on. The problems and events not listed weigh 1.
+	SeverityWeights map[string]float64 `keda:"name=severityWeights, order=triggerMetadata, optional"`
+	TriggerIndex    int
+}
+
+func (m *dynatraceMetadata) Validate() error {
+	selectors := 0
+	for _, selector := range []string{m.MetricSelector, m.ProblemSelector, m.EventSelector} {
+		if selector != "" {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return errors.New("exactly one of metricSelector, problemSelector and eventSelector must be provided")
+	}
+	if m.MetricSelector != "" && (m.EntitySelector != "" || len(m.SeverityWeights) > 0) {
+		return errors.New("entitySelector and severityWeights can only be used with problemSelector or eventSelector")
+	}
+	return nil
 }
 
 // Model of relevant part of Dynatrace's Metric Data Points API Response
@@ -92,6 +116,25 @@ func (s *dynatraceScaler) Close(context.Context) error {
 	return nil
 }
 
+// Model of relevant part of Dynatrace's Problems API Response
+// as per https://docs.dynatrace.com/docs/dynatrace-api/environment-api/problems-v2/problems/get-problems-list
+type dynatraceProblemsResponse struct {
+	NextPageKey string `json:"nextPageKey"`
+	Problems    []struct {
+		Status        string `json:"status"`
+		SeverityLevel string `json:"severityLevel"`
+	} `json:"problems"`
+}
+
+// Model of relevant part of Dynatrace's Events API Response
+// as per https://docs.dynatrace.com/docs/dynatrace-api/environment-api/events-v2/get-events-list
+type dynatraceEventsResponse struct {
+	NextPageKey string `json:"nextPageKey"`
+	Events      []struct {
+		EventType string `json:"eventType"`
+	} `json:"events"`
+}
+
 // Validate that response object contains the minimum expected structure
 // as per https://docs.dynatrace.com/docs/dynatrace-api/environment-api/metric-v2/get-data-points#definition--MetricData
 func validateDynatraceResponse(response *dynatraceResponse) error {
@@ -108,64 +151,134 @@ func validateDynatraceResponse(response *dynatraceResponse) error {
 }
 
 func (s *dynatraceScaler) GetMetricValue(ctx context.Context) (float64, error) {
-	/*
-	 * Build request
-	 */
-	var req *http.Request
-	var err error
+	switch {
+	case s.metadata.ProblemSelector != "":
+		return s.getProblemsValue(ctx)
+	case s.metadata.EventSelector != "":
+		return s.getEventsValue(ctx)
+	}
+
+	queryString := neturl.Values{}
+	queryString.Set("metricSelector", s.metadata.MetricSelector)
+	queryString.Set("from", s.metadata.FromTimestamp)
+
+	var dynatraceResponse *dynatraceResponse
+	if err := s.executeRequest(ctx, dynatraceMetricDataPointsAPI, queryString, &dynatraceResponse); err != nil {
+		return 0, err
+	}
+
+	err := validateDynatraceResponse(dynatraceResponse)
+	if err != nil {
+		return 0, err
+	}
+
+	return dynatraceResponse.Result[0].Data[0].Values[0], nil
+}
+
+// getProblemsValue returns the weighted count of the open Davis problems matching the problem and entity selectors
+func (s *dynatraceScaler) getProblemsValue(ctx context.Context) (float64, error) {
+	queryString := neturl.Values{}
+	queryString.Set("problemSelector", s.metadata.ProblemSelector)
+	if s.metadata.EntitySelector != "" {
+		queryString.Set("entitySelector", s.metadata.EntitySelector)
+	}
+	queryString.Set("from", s.metadata.FromTimestamp)
+	queryString.Set("fields", "+severityLevel")
+	queryString.Set("pageSize", "500")
+
+	var value float64
+	for {
+		var response dynatraceProblemsResponse
+		if err := s.executeRequest(ctx, dynatraceProblemsAPI, queryString, &response); err != nil {
+			return 0, err
+		}
+		for _, problem := range response.Problems {
+			if strings.EqualFold(problem.Status, "OPEN") {
+				value += s.metadata.severityWeight(problem.SeverityLevel)
+			}
+		}
+		if response.NextPageKey == "" {
+			return value, nil
+		}
+		// the key of the next page replaces all the other parameters
+		queryString = neturl.Values{"nextPageKey": {response.NextPageKey}}
+	}
+}
+
+// getEventsValue returns the weighted count of the events matching the event and entity selectors, e.g. custom
+// events sent by the pipelines or the monitoring tools
+func (s *dynatraceScaler) getEventsValue(ctx context.Context) (float64, error) {
+	queryString := neturl.Values{}
+	queryString.Set("eventSelector", s.metadata.EventSelector)
+	if s.metadata.EntitySelector != "" {
+		queryString.Set("entitySelector", s.metadata.EntitySelector)
+	}
+	queryString.Set("from", s.metadata.FromTimestamp)
+	queryString.Set("pageSize", "1000")
+
+	var value float64
+	for {
+		var response dynatraceEventsResponse
+		if err := s.executeRequest(ctx, dynatraceEventsAPI, queryString, &response); err != nil {
+			return 0, err
+		}
+		for _, event := range response.Events {
+			value += s.metadata.severityWeight(event.EventType)
+		}
+		if response.NextPageKey == "" {
+			return value, nil
+		}
+		queryString = neturl.Values{"nextPageKey": {response.NextPageKey}}
+	}
+}
+
+func (m *dynatraceMetadata) severityWeight(severity string) float64 {
+	if weight, ok := m.SeverityWeights[severity]; ok {
+		return weight
+	}
+	return 1
+}
 
+// executeRequest sends the query to the API of the environment and decodes its response
+func (s *dynatraceScaler) executeRequest(ctx context.Context, api string, queryString neturl.Values, response interface{}) error {
 	// Append host information to appropriate API endpoint
 	// Trailing slashes are removed from provided host information to avoid double slashes in the URL
-	dynatraceAPIURL := fmt.Sprintf("%s/%s", strings.TrimRight(s.metadata.Host, "/"), dynatraceMetricDataPointsAPI)
+	dynatraceAPIURL := fmt.Sprintf("%s/%s", strings.TrimRight(s.metadata.Host, "/"), api)
 
 	// Add query parameters to the URL
-	url, _ := neturl.Parse(dynatraceAPIURL)
-	queryString := url.Query()
-	queryString.Set("metricSelector", s.metadata.MetricSelector)
-	queryString.Set("from", s.metadata.FromTimestamp)
+	url, err := neturl.Parse(dynatraceAPIURL)
+	if err != nil {
+		return err
+	}
 	url.RawQuery = queryString.Encode()
 
-	req, err = http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
 	// Authentication header as per https://docs.dynatrace.com/docs/dynatrace-api/basics/dynatrace-api-authentication#authenticate
 	req.Header.Add("Authorization", fmt.Sprintf("Api-Token %s", s.metadata.Token))
 
-	/*
-	 * Execute request
-	 */
 	r, err := s.httpClient.Do(req)
 	if err != nil {
-		return 0, err
+		return err
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("%s: api returned %d", r.Request.URL.Path, r.StatusCode)
-		return 0, errors.New(msg)
+		return errors.New(msg)
 	}
 
-	/*
-	 * Parse response
-	 */
 	b, err := io.ReadAll(r.Body)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	var dynatraceResponse *dynatraceResponse
-	err = json.Unmarshal(b, &dynatraceResponse)
-	if err != nil {
-		return -1, fmt.Errorf("unable to parse Dynatrace Metric Data Points API response: %w", err)
+	if err := json.Unmarshal(b, response); err != nil {
+		return fmt.Errorf("unable to parse Dynatrace API response: %w", err)
 	}
-
-	err = validateDynatraceResponse(dynatraceResponse)
-	if err != nil {
-		return 0, err
-	}
-
-	return dynatraceResponse.Result[0].Data[0].Values[0], nil
+	return nil
 }
 
 func (s *dynatraceScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
@@ -3,8 +3,13 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
@@ -34,6 +39,16 @@ var testDynatraceMetadata = []dynatraceMetadataTestData{
 	{map[string]string{"threshold": "100"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
 	// missing token (must come from auth params)
 	{map[string]string{"token": "foo", "threshold": "100", "from": "now-3d", "metricSelector": "MyCustomEvent:filter(eq(\"someProperty\",\"someValue\")):count:splitBy(\"dt.entity.process_group\"):fold"}, map[string]string{"host": "http://dummy:1234"}, true},
+	// open problems with severity weights
+	{map[string]string{"threshold": "5", "problemSelector": "status(\"open\")", "entitySelector": "type(\"SERVICE\"),tag(\"checkout\")", "severityWeights": "AVAILABILITY=10,ERROR=5"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, false},
+	// custom events
+	{map[string]string{"threshold": "5", "eventSelector": "eventType(\"CUSTOM_ALERT\")", "from": "now-10m"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, false},
+	// metric and problem selectors
+	{map[string]string{"threshold": "5", "metricSelector": "builtin:service.errors.total.count", "problemSelector": "status(\"open\")"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
+	// entitySelector with metricSelector
+	{map[string]string{"threshold": "5", "metricSelector": "builtin:service.errors.total.count", "entitySelector": "type(\"SERVICE\")"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
+	// malformed severityWeights
+	{map[string]string{"threshold": "5", "problemSelector": "status(\"open\")", "severityWeights": "ERROR=high"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
 }
 
 var dynatraceMetricIdentifiers = []dynatraceMetricIdentifier{
@@ -72,3 +87,47 @@ func TestDynatraceGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestDynatraceProblemsAndEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Api-Token dummy", r.Header.Get("Authorization"))
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/api/v2/problems" && query.Get("nextPageKey") == "":
+			assert.Equal(t, `status("open")`, query.Get("problemSelector"))
+			assert.Equal(t, `type("SERVICE")`, query.Get("entitySelector"))
+			_, _ = w.Write([]byte(`{"totalCount":3,"nextPageKey":"page2","problems":[{"status":"OPEN","severityLevel":"AVAILABILITY"},{"status":"CLOSED","severityLevel":"ERROR"}]}`))
+		case r.URL.Path == "/api/v2/problems":
+			assert.Equal(t, "page2", query.Get("nextPageKey"))
+			assert.False(t, query.Has("problemSelector"))
+			_, _ = w.Write([]byte(`{"totalCount":3,"problems":[{"status":"OPEN","severityLevel":"PERFORMANCE"}]}`))
+		case r.URL.Path == "/api/v2/events":
+			assert.Equal(t, `eventType("CUSTOM_ALERT")`, query.Get("eventSelector"))
+			_, _ = w.Write([]byte(`{"totalCount":2,"events":[{"eventType":"CUSTOM_ALERT"},{"eventType":"CUSTOM_ALERT"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata map[string]string
+		value    float64
+	}{
+		{map[string]string{"threshold": "5", "problemSelector": `status("open")`, "entitySelector": `type("SERVICE")`}, 2},
+		{map[string]string{"threshold": "5", "problemSelector": `status("open")`, "entitySelector": `type("SERVICE")`, "severityWeights": "AVAILABILITY=10,ERROR=5"}, 11},
+		{map[string]string{"threshold": "5", "eventSelector": `eventType("CUSTOM_ALERT")`, "severityWeights": "CUSTOM_ALERT=2"}, 4},
+	}
+
+	for _, tc := range testCases {
+		meta, err := parseDynatraceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"host": server.URL + "/", "token": "dummy"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := dynatraceScaler{metadata: meta, httpClient: server.Client(), logger: logr.Discard()}
+
+		value, err := scaler.GetMetricValue(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, value, tc.metadata)
+	}
+}
@@ -28,20 +28,23 @@ type elasticsearchScaler struct {
 }
 
 type elasticsearchMetadata struct {
-	Addresses             []string `keda:"name=addresses,             order=authParams;triggerMetadata, optional"`
-	UnsafeSsl             bool     `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
-	Username              string   `keda:"name=username,              order=authParams;triggerMetadata, optional"`
-	Password              string   `keda:"name=password,              order=authParams;resolvedEnv;triggerMetadata, optional"`
-	CloudID               string   `keda:"name=cloudID,               order=authParams;triggerMetadata, optional"`
-	APIKey                string   `keda:"name=apiKey,                order=authParams;triggerMetadata, optional"`
-	Index                 []string `keda:"name=index,                 order=authParams;triggerMetadata, separator=;"`
-	SearchTemplateName    string   `keda:"name=searchTemplateName,    order=authParams;triggerMetadata, optional"`
-	Query                 string   `keda:"name=query,                 order=authParams;triggerMetadata, optional"`
-	Parameters            []string `keda:"name=parameters,            order=triggerMetadata, optional, separator=;"`
-	ValueLocation         string   `keda:"name=valueLocation,         order=authParams;triggerMetadata"`
-	TargetValue           float64  `keda:"name=targetValue,           order=authParams;triggerMetadata"`
-	ActivationTargetValue float64  `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
-	MetricName            string   `keda:"name=metricName,            order=triggerMetadata, optional"`
+	Addresses          []string `keda:"name=addresses,             order=authParams;triggerMetadata, optional"`
+	UnsafeSsl          bool     `keda:"name=unsafeSsl,             order=triggerMetadata, default=false"`
+	Username           string   `keda:"name=username,              order=authParams;triggerMetadata, optional"`
+	Password           string   `keda:"name=password,              order=authParams;resolvedEnv;triggerMetadata, optional"`
+	CloudID            string   `keda:"name=cloudID,               order=authParams;triggerMetadata, optional"`
+	APIKey             string   `keda:"name=apiKey,                order=authParams;triggerMetadata, optional"`
+	Index              []string `keda:"name=index,                 order=authParams;triggerMetadata, separator=;"`
+	SearchTemplateName string   `keda:"name=searchTemplateName,    order=authParams;triggerMetadata, optional"`
+	Query              string   `keda:"name=query,                 order=authParams;triggerMetadata, optional"`
+	Parameters         []string `keda:"name=parameters,            order=triggerMetadata, optional, separator=;"`
+	ValueLocation      string   `keda:"name=valueLocation,         order=authParams;triggerMetadata"`
+	// ValueAggregation combines the values when the value location matches several values, e.g. a value of each
+	// bucket of an aggregation with aggregations.by_shard.buckets.#.lag.value
+	ValueAggregation      string  `keda:"name=valueAggregation,      order=authParams;triggerMetadata, enum=max;min;sum;avg, optional"`
+	TargetValue           float64 `keda:"name=targetValue,           order=authParams;triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+	MetricName            string  `keda:"name=metricName,            order=triggerMetadata, optional"`
 
 	TriggerIndex int
 }
@@ -184,7 +187,10 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	if err != nil {
 		return 0, err
 	}
-	v, err := getValueFromSearch(b, s.metadata.ValueLocation)
+	if res.IsError() {
+		return 0, fmt.Errorf("elasticsearch search returned error. status: %d response: %s", res.StatusCode, string(b))
+	}
+	v, err := getAggregatedValueFromSearch(b, s.metadata.ValueLocation, s.metadata.ValueAggregation)
 	if err != nil {
 		return 0, err
 	}
@@ -195,7 +201,7 @@ func buildQuery(metadata *elasticsearchMetadata) map[string]interface{} {
 	parameters := map[string]interface{}{}
 	for _, p := range metadata.Parameters {
 		if p != "" {
-			kv := strings.Split(p, ":")
+			kv := strings.SplitN(p, ":", 2)
 			key := strings.TrimSpace(kv[0])
 			value := strings.TrimSpace(kv[1])
 			parameters[key] = value
@@ -226,6 +232,55 @@ func getValueFromSearch(body []byte, valueLocation string) (float64, error) {
 	return r.Num, nil
 }
 
+// getAggregatedValueFromSearch returns the value at the location, or combines the values at the location with the
+// aggregation when it matches an array of values, the null values of the empty buckets being skipped
+func getAggregatedValueFromSearch(body []byte, valueLocation, aggregation string) (float64, error) {
+	r := gjson.GetBytes(body, valueLocation)
+	if !r.IsArray() {
+		return getValueFromSearch(body, valueLocation)
+	}
+	if aggregation == "" {
+		return 0, fmt.Errorf("valueLocation matches %d values, valueAggregation must be provided to combine them", len(r.Array()))
+	}
+
+	var result float64
+	count := 0
+	for _, item := range r.Array() {
+		if item.Type == gjson.Null {
+			continue
+		}
+		var v float64
+		switch item.Type {
+		case gjson.Number:
+			v = item.Num
+		case gjson.String:
+			parsed, err := strconv.ParseFloat(item.String(), 64)
+			if err != nil {
+				return 0, fmt.Errorf("valueLocation must point to values of type number but got: '%s'", item.String())
+			}
+			v = parsed
+		default:
+			return 0, fmt.Errorf("valueLocation must point to values of type number but got: '%s'", item.Type.String())
+		}
+
+		switch {
+		case count == 0:
+			result = v
+		case aggregation == "max":
+			result = max(result, v)
+		case aggregation == "min":
+			result = min(result, v)
+		case aggregation == "sum", aggregation == "avg":
+			result += v
+		}
+		count++
+	}
+	if count > 0 && aggregation == "avg" {
+		result /= float64(count)
+	}
+	return result, nil
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *elasticsearchScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
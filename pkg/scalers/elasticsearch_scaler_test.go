@@ -496,6 +496,27 @@ func TestBuildQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "param values containing colons",
+			metadata: map[string]string{
+				"addresses":          "http://localhost:9200",
+				"index":              "index1",
+				"searchTemplateName": "myAwesomeSearch",
+				"parameters":         "since:2024-01-01T00:00:00Z",
+				"valueLocation":      "aggregations.lag.value",
+				"targetValue":        "12",
+			},
+			authParams: map[string]string{
+				"username": "admin",
+				"password": "password",
+			},
+			expectedQuery: map[string]interface{}{
+				"id": "myAwesomeSearch",
+				"params": map[string]interface{}{
+					"since": "2024-01-01T00:00:00Z",
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -535,3 +556,34 @@ func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 		assert.Equal(t, metricSpec[0].External.Metric.Name, testData.name)
 	}
 }
+
+func TestGetAggregatedValueFromSearch(t *testing.T) {
+	body := []byte(`{"hits":{"total":{"value":12}},"aggregations":{"lag":{"value":7},"by_shard":{"buckets":[{"key":"0","lag":{"value":4}},{"key":"1","lag":{"value":10}},{"key":"2","lag":{"value":null}},{"key":"3","lag":{"value":"1"}}]}}}`)
+
+	tests := []struct {
+		valueLocation    string
+		valueAggregation string
+		expectedValue    float64
+		isError          bool
+	}{
+		{"hits.total.value", "", 12, false},
+		{"aggregations.lag.value", "", 7, false},
+		{"aggregations.lag.value", "max", 7, false},
+		{"aggregations.by_shard.buckets.#.lag.value", "max", 10, false},
+		{"aggregations.by_shard.buckets.#.lag.value", "min", 1, false},
+		{"aggregations.by_shard.buckets.#.lag.value", "sum", 15, false},
+		{"aggregations.by_shard.buckets.#.lag.value", "avg", 5, false},
+		{"aggregations.by_shard.buckets.#.lag.value", "", 0, true},
+		{"aggregations.by_shard.buckets.#.key", "sum", 6, false},
+		{"aggregations.by_shard.buckets.#.lag", "sum", 0, true},
+	}
+	for _, test := range tests {
+		value, err := getAggregatedValueFromSearch(body, test.valueLocation, test.valueAggregation)
+		if test.isError {
+			assert.Error(t, err, test.valueLocation)
+			continue
+		}
+		assert.NoError(t, err, test.valueLocation)
+		assert.Equal(t, test.expectedValue, value, "%s %s", test.valueLocation, test.valueAggregation)
+	}
+}
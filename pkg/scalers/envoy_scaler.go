@@ -0,0 +1,158 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// envoyClusterStats are the gauges of an upstream cluster of Envoy for each metric
+var envoyClusterStats = map[string]string{
+	"activeConnections": "upstream_cx_active",
+	"activeRequests":    "upstream_rq_active",
+	"pendingRequests":   "upstream_rq_pending_active",
+}
+
+type envoyScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *envoyMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type envoyMetadata struct {
+	triggerIndex int
+
+	// AdminURL is the endpoint of the admin interface of Envoy, e.g. http://envoy:9901
+	AdminURL string `keda:"name=adminURL, order=triggerMetadata;resolvedEnv"`
+	// Cluster is the name of the upstream cluster
+	Cluster               string  `keda:"name=cluster,               order=triggerMetadata"`
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=activeConnections;activeRequests;pendingRequests, optional, default=activeConnections"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=100"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	stat string
+}
+
+// envoyStats is the response of /stats in JSON, only gauges and counters having a value
+type envoyStats struct {
+	Stats []struct {
+		Name  string   `json:"name"`
+		Value *float64 `json:"value"`
+	} `json:"stats"`
+}
+
+func (m *envoyMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	m.AdminURL = strings.TrimSuffix(m.AdminURL, "/")
+	m.stat = fmt.Sprintf("cluster.%s.%s", m.Cluster, envoyClusterStats[m.Metric])
+	return nil
+}
+
+// NewEnvoyScaler creates a new envoyScaler
+func NewEnvoyScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseEnvoyMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing envoy metadata: %w", err)
+	}
+
+	return &envoyScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "envoy_scaler"),
+	}, nil
+}
+
+func parseEnvoyMetadata(config *scalersconfig.ScalerConfig) (*envoyMetadata, error) {
+	meta := &envoyMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *envoyScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *envoyScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("envoy-%s-%s", s.metadata.Cluster, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the active connections, active requests or pending requests of the upstream cluster
+func (s *envoyScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getStat(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting envoy stat %s: %w", s.metadata.stat, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *envoyScaler) getStat(ctx context.Context) (float64, error) {
+	params := url.Values{"format": {"json"}, "filter": {"^" + regexp.QuoteMeta(s.metadata.stat) + "$"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.AdminURL+"/stats?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var stats envoyStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return 0, fmt.Errorf("error parsing stats: %w", err)
+	}
+	for _, stat := range stats.Stats {
+		if stat.Name == s.metadata.stat && stat.Value != nil {
+			return *stat.Value, nil
+		}
+	}
+	return 0, errors.New("stat not found, check the name of the cluster")
+}
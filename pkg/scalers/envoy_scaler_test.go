@@ -0,0 +1,112 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseEnvoyMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type envoyMetricIdentifier struct {
+	metadataTestData *parseEnvoyMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testEnvoyMetadata = []parseEnvoyMetadataTestData{
+	{map[string]string{}, true, "nothing passed"},
+	{map[string]string{"adminURL": "http://envoy:9901", "cluster": "backend"}, false, "active connections"},
+	{map[string]string{"adminURL": "http://envoy:9901", "cluster": "backend", "metric": "pendingRequests", "targetValue": "10", "activationTargetValue": "1"}, false, "pending requests"},
+	{map[string]string{"adminURL": "http://envoy:9901"}, true, "no cluster"},
+	{map[string]string{"cluster": "backend"}, true, "no adminURL"},
+	{map[string]string{"adminURL": "http://envoy:9901", "cluster": "backend", "metric": "connections"}, true, "unknown metric"},
+	{map[string]string{"adminURL": "http://envoy:9901", "cluster": "backend", "targetValue": "0"}, true, "zero targetValue"},
+}
+
+var envoyMetricIdentifiers = []envoyMetricIdentifier{
+	{&testEnvoyMetadata[1], 0, "s0-envoy-backend-activeConnections"},
+	{&testEnvoyMetadata[2], 1, "s1-envoy-backend-pendingRequests"},
+}
+
+func TestEnvoyParseMetadata(t *testing.T) {
+	for _, testData := range testEnvoyMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestEnvoyGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range envoyMetricIdentifiers {
+		meta, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockEnvoyScaler := envoyScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockEnvoyScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestEnvoyGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stats", r.URL.Path)
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		switch r.URL.Query().Get("filter") {
+		case `^cluster\.backend\.upstream_cx_active$`:
+			_, _ = w.Write([]byte(`{"stats":[{"name":"cluster.backend.upstream_cx_active","value":12},{"histograms":{"supported_quantiles":[0,50,100],"computed_quantiles":[]}}]}`))
+		case `^cluster\.backend\.upstream_rq_pending_active$`:
+			_, _ = w.Write([]byte(`{"stats":[{"name":"cluster.backend.upstream_rq_pending_active","value":0}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"stats":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		metadata map[string]string
+		value    int64
+		isActive bool
+		err      string
+	}{
+		{map[string]string{"cluster": "backend"}, 12, true, ""},
+		{map[string]string{"cluster": "backend", "metric": "pendingRequests"}, 0, false, ""},
+		{map[string]string{"cluster": "missing"}, 0, false, "stat not found"},
+	}
+	for _, test := range tests {
+		test.metadata["adminURL"] = server.URL
+		s, err := NewEnvoyScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata})
+		require.NoError(t, err)
+
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "envoy")
+		if test.err != "" {
+			assert.ErrorContains(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.isActive, isActive)
+		assert.Equal(t, test.value, metrics[0].Value.Value())
+	}
+}
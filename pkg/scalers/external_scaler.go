@@ -6,14 +6,18 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/mitchellh/hashstructure"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -26,11 +30,33 @@ type externalScaler struct {
 	metricType      v2.MetricTargetType
 	metadata        externalScalerMetadata
 	scaledObjectRef pb.ScaledObjectRef
+	metricsStream   *externalMetricsStream
 	logger          logr.Logger
 }
 
+// externalMetricsStream keeps the latest values pushed by the external scaler with StreamGetMetrics, by metric name
+type externalMetricsStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	started map[string]bool
+	values  map[string]streamedMetricValues
+	// unimplemented is set when the external scaler doesn't implement StreamGetMetrics, GetMetrics being called instead
+	unimplemented bool
+}
+
+// streamedMetricValues are the latest values of a metric pushed by the external scaler, and when they were received
+type streamedMetricValues struct {
+	values   []*pb.MetricValue
+	received time.Time
+}
+
 type externalPushScaler struct {
 	externalScaler
+
+	mu              sync.Mutex
+	connectionState PushScalerConnectionState
 }
 
 type externalScalerMetadata struct {
@@ -42,6 +68,21 @@ type externalScalerMetadata struct {
 	tlsClientCert    string
 	tlsClientKey     string
 	unsafeSsl        bool
+	streamGetMetrics bool
+
+	// reconnectInitialDelay is the delay before reconnecting to the external push scaler, doubling up to
+	// reconnectMaxDelay while the connection fails
+	reconnectInitialDelay time.Duration
+	reconnectMaxDelay     time.Duration
+	// keepAliveTime is the interval of the keepalive pings of the grpc connection, disabled when 0
+	keepAliveTime    time.Duration
+	keepAliveTimeout time.Duration
+	// maxSilence is the time after which the stream of IsActive is reestablished when the external push scaler
+	// didn't send anything, disabled when 0
+	maxSilence time.Duration
+	// streamStaleAfter is the time after which the values streamed with StreamGetMetrics are ignored when the
+	// external scaler didn't push new values, GetMetrics being called instead
+	streamStaleAfter time.Duration
 }
 
 type connectionGroup struct {
@@ -74,7 +115,8 @@ func NewExternalScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 			Namespace:      config.ScalableObjectNamespace,
 			ScalerMetadata: meta.originalMetadata,
 		},
-		logger: InitializeLogger(config, "external_scaler"),
+		metricsStream: newExternalMetricsStream(meta),
+		logger:        InitializeLogger(config, "external_scaler"),
 	}, nil
 }
 
@@ -91,7 +133,7 @@ func NewExternalPushScaler(config *scalersconfig.ScalerConfig) (PushScaler, erro
 	}
 
 	return &externalPushScaler{
-		externalScaler{
+		externalScaler: externalScaler{
 			metricType: metricType,
 			metadata:   meta,
 			scaledObjectRef: pb.ScaledObjectRef{
@@ -99,11 +141,26 @@ func NewExternalPushScaler(config *scalersconfig.ScalerConfig) (PushScaler, erro
 				Namespace:      config.ScalableObjectNamespace,
 				ScalerMetadata: meta.originalMetadata,
 			},
-			logger: InitializeLogger(config, "external_push_scaler"),
+			metricsStream: newExternalMetricsStream(meta),
+			logger:        InitializeLogger(config, "external_push_scaler"),
 		},
 	}, nil
 }
 
+// newExternalMetricsStream returns the stream of the metrics if enabled, nil otherwise
+func newExternalMetricsStream(meta externalScalerMetadata) *externalMetricsStream {
+	if !meta.streamGetMetrics {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &externalMetricsStream{
+		ctx:     ctx,
+		cancel:  cancel,
+		started: map[string]bool{},
+		values:  map[string]streamedMetricValues{},
+	}
+}
+
 func parseExternalScalerMetadata(config *scalersconfig.ScalerConfig) (externalScalerMetadata, error) {
 	meta := externalScalerMetadata{
 		originalMetadata: config.TriggerMetadata,
@@ -141,6 +198,47 @@ func parseExternalScalerMetadata(config *scalersconfig.ScalerConfig) (externalSc
 		}
 		meta.unsafeSsl = boolVal
 	}
+
+	// streamGetMetrics receives the metrics pushed by the external scaler instead of polling them
+	if val, ok := config.TriggerMetadata["streamGetMetrics"]; ok && val != "" {
+		boolVal, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("failed to parse streamGetMetrics value. Must be either true or false")
+		}
+		meta.streamGetMetrics = boolVal
+	}
+	durations := []struct {
+		name         string
+		value        *time.Duration
+		defaultValue time.Duration
+	}{
+		{"reconnectInitialDelaySeconds", &meta.reconnectInitialDelay, 2 * time.Second},
+		{"reconnectMaxDelaySeconds", &meta.reconnectMaxDelay, time.Minute},
+		{"keepAliveTimeSeconds", &meta.keepAliveTime, 0},
+		{"keepAliveTimeoutSeconds", &meta.keepAliveTimeout, 20 * time.Second},
+		{"maxSilenceSeconds", &meta.maxSilence, 0},
+		{"streamStaleAfterSeconds", &meta.streamStaleAfter, time.Minute},
+	}
+	for _, d := range durations {
+		*d.value = d.defaultValue
+		if val, ok := config.TriggerMetadata[d.name]; ok && val != "" {
+			seconds, err := strconv.Atoi(val)
+			if err != nil || seconds < 0 {
+				return meta, fmt.Errorf("failed to parse %s value. Must be a positive number of seconds", d.name)
+			}
+			*d.value = time.Duration(seconds) * time.Second
+		}
+	}
+	if meta.reconnectInitialDelay <= 0 {
+		return meta, fmt.Errorf("reconnectInitialDelaySeconds must be greater than 0")
+	}
+	if meta.reconnectMaxDelay < meta.reconnectInitialDelay {
+		return meta, fmt.Errorf("reconnectMaxDelaySeconds must be greater than or equal to reconnectInitialDelaySeconds")
+	}
+	if meta.streamStaleAfter <= 0 {
+		return meta, fmt.Errorf("streamStaleAfterSeconds must be greater than 0")
+	}
+
 	// Add elements to metadata
 	for key, value := range config.TriggerMetadata {
 		// Check if key is in resolved environment and resolve
@@ -157,6 +255,9 @@ func parseExternalScalerMetadata(config *scalersconfig.ScalerConfig) (externalSc
 }
 
 func (s *externalScaler) Close(context.Context) error {
+	if s.metricsStream != nil {
+		s.metricsStream.cancel()
+	}
 	return nil
 }
 
@@ -215,15 +316,26 @@ func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 		ScaledObjectRef: &s.scaledObjectRef,
 	}
 
-	metricsResponse, err := grpcClient.GetMetrics(ctx, request)
-	if err != nil {
-		s.logger.Error(err, "error")
-		return []external_metrics.ExternalMetricValue{}, false, err
+	metricValues, streamed := s.getStreamedMetrics(request)
+	if !streamed {
+		metricsResponse, err := grpcClient.GetMetrics(ctx, request)
+		if err != nil {
+			s.logger.Error(err, "error")
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		metricValues = metricsResponse.MetricValues
 	}
 
-	for _, metricResult := range metricsResponse.MetricValues {
+	active := false
+	for _, metricResult := range metricValues {
 		metric := GenerateMetricInMili(metricName, float64(metricResult.MetricValue))
 		metrics = append(metrics, metric)
+		active = active || metricResult.MetricValue > 0
+	}
+
+	// while the metric is streamed, the activity is derived from its values instead of polling IsActive
+	if streamed {
+		return metrics, active, nil
 	}
 
 	isActiveResponse, err := grpcClient.IsActive(ctx, &s.scaledObjectRef)
@@ -235,32 +347,128 @@ func (s *externalScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 	return metrics, isActiveResponse.Result, nil
 }
 
-// handleIsActiveStream is the only writer to the active channel and will close it on return.
+// getStreamedMetrics returns the latest values of the metric pushed by the external scaler, starting to stream them
+// on the first call. It returns false until values are received, when the latest values are stale, or when streaming
+// isn't enabled or implemented.
+func (s *externalScaler) getStreamedMetrics(request *pb.GetMetricsRequest) ([]*pb.MetricValue, bool) {
+	stream := s.metricsStream
+	if stream == nil {
+		return nil, false
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if stream.unimplemented {
+		return nil, false
+	}
+	if !stream.started[request.MetricName] {
+		stream.started[request.MetricName] = true
+		go s.runMetricsStream(request)
+	}
+	values, ok := stream.values[request.MetricName]
+	if !ok || time.Since(values.received) > s.metadata.streamStaleAfter {
+		return nil, false
+	}
+	return values.values, true
+}
+
+// runMetricsStream streams the values of the metric until the scaler is closed, reconnecting on error with a
+// backoff of 2 seconds doubling up to a minute
+func (s *externalScaler) runMetricsStream(request *pb.GetMetricsRequest) {
+	stream := s.metricsStream
+	retryDuration := time.Second * 2
+	for {
+		received, err := s.handleMetricsStream(request)
+		if received {
+			// the stream was healthy, the backoff starts over
+			retryDuration = time.Second * 2
+		}
+
+		stream.mu.Lock()
+		// the values aren't updated anymore, GetMetrics is called until the stream is established again
+		delete(stream.values, request.MetricName)
+		if status.Code(err) == codes.Unimplemented {
+			stream.unimplemented = true
+		}
+		unimplemented := stream.unimplemented
+		stream.mu.Unlock()
+
+		if unimplemented {
+			s.logger.V(1).Info("StreamGetMetrics not implemented by the external scaler, calling GetMetrics instead")
+			return
+		}
+		if stream.ctx.Err() != nil {
+			return
+		}
+		s.logger.Error(err, "error streaming metrics", "metricName", request.MetricName)
+
+		backoffTimer := time.NewTimer(retryDuration)
+		select {
+		case <-stream.ctx.Done():
+			backoffTimer.Stop()
+			return
+		case <-backoffTimer.C:
+		}
+		retryDuration = min(retryDuration*2, time.Minute)
+	}
+}
+
+// handleMetricsStream blocks on a stream call from the GRPC server. It'll only terminate on error, stream completion, or
+// when the scaler is closed, returning whether values were received.
+func (s *externalScaler) handleMetricsStream(request *pb.GetMetricsRequest) (bool, error) {
+	grpcClient, err := getClientForConnectionPool(s.metadata, s.logger)
+	if err != nil {
+		return false, err
+	}
+	metricsStream, err := grpcClient.StreamGetMetrics(s.metricsStream.ctx, request)
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		resp, err := metricsStream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		s.metricsStream.mu.Lock()
+		s.metricsStream.values[request.MetricName] = streamedMetricValues{values: resp.MetricValues, received: time.Now()}
+		s.metricsStream.mu.Unlock()
+	}
+}
+
+// Run is the only writer to the active channel and will close it on return.
 func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 	defer close(active)
+
+	retryDuration := s.metadata.reconnectInitialDelay
 	// It's possible for the connection to get terminated anytime, we need to run this in a retry loop
 	runWithLog := func() {
 		grpcClient, err := getClientForConnectionPool(s.metadata, s.logger)
 		if err != nil {
+			s.setDisconnected(err)
 			s.logger.Error(err, "error running internalRun")
 			return
 		}
-		if err := handleIsActiveStream(ctx, &s.scaledObjectRef, grpcClient, active); err != nil {
+		received, err := s.handleIsActiveStream(ctx, grpcClient, active)
+		if received {
+			// the connection was healthy, the backoff starts over
+			retryDuration = s.metadata.reconnectInitialDelay
+		}
+		s.setDisconnected(err)
+		if err != nil && ctx.Err() == nil {
 			s.logger.Error(err, "error running internalRun")
-			return
 		}
 	}
 
-	// retry on error from runWithLog() starting by 2 sec backing off * 2 with a max of 2 minute
-	retryDuration := time.Second * 2
+	// retry on error from runWithLog() backing off * 2 up to reconnectMaxDelay
 	// the caller of this function needs to ensure that they call Stop() on the resulting
 	// timer, to release background resources.
 	retryBackoff := func() *time.Timer {
 		tmr := time.NewTimer(retryDuration)
-		retryDuration *= 2
-		if retryDuration > time.Minute*1 {
-			retryDuration = time.Minute * 1
-		}
+		retryDuration = min(retryDuration*2, s.metadata.reconnectMaxDelay)
 		return tmr
 	}
 
@@ -280,20 +488,75 @@ func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 	}
 }
 
-// handleIsActiveStream calls blocks on a stream call from the GRPC server. It'll only terminate on error, stream completion, or ctx cancellation.
-func handleIsActiveStream(ctx context.Context, scaledObjectRef *pb.ScaledObjectRef, grpcClient pb.ExternalScalerClient, active chan<- bool) error {
-	stream, err := grpcClient.StreamIsActive(ctx, scaledObjectRef)
+// ConnectionState returns the state of the stream of IsActive
+func (s *externalPushScaler) ConnectionState() PushScalerConnectionState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connectionState
+}
+
+func (s *externalPushScaler) setConnected() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectionState.Connected = true
+	s.connectionState.Err = nil
+}
+
+func (s *externalPushScaler) setMessageReceived() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectionState.LastMessageTime = time.Now()
+}
+
+func (s *externalPushScaler) setDisconnected(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.connectionState.Connected = false
+	s.connectionState.Err = err
+}
+
+// handleIsActiveStream calls blocks on a stream call from the GRPC server. It'll only terminate on error, stream completion,
+// ctx cancellation or when nothing is received for maxSilence. It returns whether anything was received.
+func (s *externalPushScaler) handleIsActiveStream(ctx context.Context, grpcClient pb.ExternalScalerClient, active chan<- bool) (bool, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var silent atomic.Bool
+	var silenceTimer *time.Timer
+	if s.metadata.maxSilence > 0 {
+		silenceTimer = time.AfterFunc(s.metadata.maxSilence, func() {
+			silent.Store(true)
+			cancel()
+		})
+		defer silenceTimer.Stop()
+	}
+
+	stream, err := grpcClient.StreamIsActive(streamCtx, &s.scaledObjectRef)
 	if err != nil {
-		return err
+		return false, err
 	}
+	s.setConnected()
 
+	received := false
 	for {
 		resp, err := stream.Recv()
 		if err != nil {
-			return err
+			if silent.Load() {
+				return received, fmt.Errorf("nothing received from the external push scaler for %s", s.metadata.maxSilence)
+			}
+			return received, err
+		}
+		received = true
+		s.setMessageReceived()
+		if silenceTimer != nil {
+			silenceTimer.Reset(s.metadata.maxSilence)
 		}
 
-		active <- resp.Result
+		select {
+		case active <- resp.Result:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
 	}
 }
 
@@ -306,6 +569,15 @@ func getClientForConnectionPool(metadata externalScalerMetadata, logger logr.Log
 	defer connectionPoolMutex.Unlock()
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
+		opts := []grpc.DialOption{grpc.WithDefaultServiceConfig(grpcConfig)}
+		if metadata.keepAliveTime > 0 {
+			opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time:                metadata.keepAliveTime,
+				Timeout:             metadata.keepAliveTimeout,
+				PermitWithoutStream: true,
+			}))
+		}
+
 		// FIXME: DEPRECATED to be removed in v2.13 https://github.com/kedacore/keda/issues/4549
 		if metadata.tlsCertFile != "" {
 			logger.V(1).Info("tlsCertFile in ScaleObject metadata will be deprecated in v2.12. Please use" +
@@ -315,8 +587,7 @@ func getClientForConnectionPool(metadata externalScalerMetadata, logger logr.Log
 				return nil, err
 			}
 			return grpc.NewClient(metadata.scalerAddress,
-				grpc.WithDefaultServiceConfig(grpcConfig),
-				grpc.WithTransportCredentials(creds))
+				append(opts, grpc.WithTransportCredentials(creds))...)
 		}
 
 		tlsConfig, err := util.NewTLSConfig(metadata.tlsClientCert, metadata.tlsClientKey, metadata.caCert, metadata.unsafeSsl)
@@ -327,18 +598,20 @@ func getClientForConnectionPool(metadata externalScalerMetadata, logger logr.Log
 		if len(tlsConfig.Certificates) > 0 || metadata.caCert != "" {
 			// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
 			return grpc.NewClient(metadata.scalerAddress,
-				grpc.WithDefaultServiceConfig(grpcConfig),
-				grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+				append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))...)
 		}
 
 		return grpc.NewClient(metadata.scalerAddress,
-			grpc.WithDefaultServiceConfig(grpcConfig),
-			grpc.WithTransportCredentials(insecure.NewCredentials()))
+			append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
 	}
 
 	// create a unique key per-metadata. If scaledObjects share the same connection properties
 	// in the metadata, they will share the same grpc.ClientConn
-	key, err := hashstructure.Hash(metadata.scalerAddress, nil)
+	key, err := hashstructure.Hash(struct {
+		ScalerAddress    string
+		KeepAliveTime    time.Duration
+		KeepAliveTimeout time.Duration
+	}{metadata.scalerAddress, metadata.keepAliveTime, metadata.keepAliveTimeout}, nil)
 	if err != nil {
 		return nil, err
 	}
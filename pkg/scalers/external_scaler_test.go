@@ -67,6 +67,24 @@ type parseExternalScalerMetadataTestData struct {
 
 var testExternalScalerMetadata = []parseExternalScalerMetadataTestData{
 	{map[string]string{}, true, map[string]string{}},
+	// streaming the metrics
+	{map[string]string{"scalerAddress": "myservice", "streamGetMetrics": "true"}, false, map[string]string{}},
+	// streamed metrics ignored after a custom time
+	{map[string]string{"scalerAddress": "myservice", "streamGetMetrics": "true", "streamStaleAfterSeconds": "30"}, false, map[string]string{}},
+	// streamStaleAfterSeconds not positive
+	{map[string]string{"scalerAddress": "myservice", "streamGetMetrics": "true", "streamStaleAfterSeconds": "0"}, true, map[string]string{}},
+	// streamGetMetrics not a bool
+	{map[string]string{"scalerAddress": "myservice", "streamGetMetrics": "sometimes"}, true, map[string]string{}},
+	// reconnect and liveness settings
+	{map[string]string{"scalerAddress": "myservice", "reconnectInitialDelaySeconds": "1", "reconnectMaxDelaySeconds": "30", "keepAliveTimeSeconds": "10", "keepAliveTimeoutSeconds": "5", "maxSilenceSeconds": "120"}, false, map[string]string{}},
+	// reconnectInitialDelaySeconds not positive
+	{map[string]string{"scalerAddress": "myservice", "reconnectInitialDelaySeconds": "0"}, true, map[string]string{}},
+	// reconnectMaxDelaySeconds lower than reconnectInitialDelaySeconds
+	{map[string]string{"scalerAddress": "myservice", "reconnectInitialDelaySeconds": "10", "reconnectMaxDelaySeconds": "5"}, true, map[string]string{}},
+	// maxSilenceSeconds negative
+	{map[string]string{"scalerAddress": "myservice", "maxSilenceSeconds": "-1"}, true, map[string]string{}},
+	// keepAliveTimeSeconds not a number
+	{map[string]string{"scalerAddress": "myservice", "keepAliveTimeSeconds": "often"}, true, map[string]string{}},
 	// all properly formed
 	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS", "insecureSkipVerify": "true"}, false, map[string]string{"caCert": serverRootCA, "tlsClientCert": clientCert}},
 	// missing scalerAddress
@@ -222,6 +240,226 @@ func (e *testExternalScaler) GetMetrics(context.Context, *pb.GetMetricsRequest)
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
 
+type testMetricsExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+
+	stream   bool
+	metrics  chan int64
+	polls    atomic.Int64
+	isActive atomic.Int64
+}
+
+func (e *testMetricsExternalScaler) IsActive(context.Context, *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	e.isActive.Add(1)
+	return &pb.IsActiveResponse{Result: true}, nil
+}
+
+func (e *testMetricsExternalScaler) GetMetrics(_ context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	e.polls.Add(1)
+	return &pb.GetMetricsResponse{MetricValues: []*pb.MetricValue{{MetricName: request.MetricName, MetricValue: 1}}}, nil
+}
+
+func (e *testMetricsExternalScaler) StreamGetMetrics(request *pb.GetMetricsRequest, server pb.ExternalScaler_StreamGetMetricsServer) error {
+	if !e.stream {
+		return status.Errorf(codes.Unimplemented, "method StreamGetMetrics not implemented")
+	}
+	for {
+		select {
+		case <-server.Context().Done():
+			return nil
+		case value := <-e.metrics:
+			err := server.Send(&pb.GetMetricsResponse{MetricValues: []*pb.MetricValue{{MetricName: request.MetricName, MetricValue: value}}})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func TestExternalScalerStreamGetMetrics(t *testing.T) {
+	for _, stream := range []bool{true, false} {
+		t.Run(fmt.Sprintf("stream implemented %t", stream), func(t *testing.T) {
+			grpcServer := grpc.NewServer()
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := &testMetricsExternalScaler{stream: stream, metrics: make(chan int64)}
+			pb.RegisterExternalScalerServer(grpcServer, server)
+			go func() { _ = grpcServer.Serve(lis) }()
+			defer grpcServer.Stop()
+
+			scaler, err := NewExternalScaler(&scalersconfig.ScalerConfig{
+				ScalableObjectName:      "app",
+				ScalableObjectNamespace: "namespace",
+				TriggerMetadata:         map[string]string{"scalerAddress": lis.Addr().String(), "streamGetMetrics": "true"},
+				ResolvedEnv:             map[string]string{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer scaler.Close(context.Background())
+
+			// the metric is polled until the first value is streamed
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-queue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !active || metrics[0].Value.Value() != 1 {
+				t.Fatalf("expected the polled value 1, got %v", metrics[0].Value)
+			}
+
+			if stream {
+				server.metrics <- 5
+				// wait for the value to be received
+				server.metrics <- 7
+			} else {
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			expected := int64(1)
+			if stream {
+				expected = 7
+			}
+			for retries := 0; ; retries++ {
+				metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), "s0-queue")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if metrics[0].Value.Value() == expected {
+					break
+				}
+				if retries > 50 {
+					t.Fatalf("expected the value %d, got %v", expected, metrics[0].Value)
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			polls, isActiveCalls := server.polls.Load(), server.isActive.Load()
+			_, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-queue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !active {
+				t.Error("expected the scaler to be active")
+			}
+			if stream && (server.polls.Load() != polls || server.isActive.Load() != isActiveCalls) {
+				t.Error("GetMetrics or IsActive called while the metric is streamed")
+			}
+			if !stream && (server.polls.Load() != polls+1 || server.isActive.Load() != isActiveCalls+1) {
+				t.Error("GetMetrics or IsActive not called while StreamGetMetrics is unimplemented")
+			}
+			if !stream {
+				return
+			}
+
+			// the activity is derived from the streamed values
+			server.metrics <- 0
+			for retries := 0; ; retries++ {
+				_, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-queue")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !active {
+					break
+				}
+				if retries > 50 {
+					t.Fatal("expected the scaler to be inactive with the streamed value 0")
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			// the stale values are ignored, the metric being polled again
+			streams := scaler.(*externalScaler).metricsStream
+			streams.mu.Lock()
+			values := streams.values["queue"]
+			values.received = time.Now().Add(-2 * time.Minute)
+			streams.values["queue"] = values
+			streams.mu.Unlock()
+			metrics, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-queue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !active || metrics[0].Value.Value() != 1 || server.polls.Load() != polls+1 {
+				t.Errorf("expected the polled value 1 once the streamed values are stale, got %v", metrics[0].Value)
+			}
+		})
+	}
+}
+
+type testSilentExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+
+	streams atomic.Int64
+}
+
+// StreamIsActive sends a single response, then stays silent
+func (e *testSilentExternalScaler) StreamIsActive(_ *pb.ScaledObjectRef, epsServer pb.ExternalScaler_StreamIsActiveServer) error {
+	e.streams.Add(1)
+	if err := epsServer.Send(&pb.IsActiveResponse{Result: true}); err != nil {
+		return err
+	}
+	<-epsServer.Context().Done()
+	return nil
+}
+
+func TestExternalPushScalerMaxSilence(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &testSilentExternalScaler{}
+	pb.RegisterExternalScalerServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(lis) }()
+	defer grpcServer.Stop()
+
+	pushScaler, err := NewExternalPushScaler(&scalersconfig.ScalerConfig{
+		ScalableObjectName:      "app",
+		ScalableObjectNamespace: "namespace",
+		TriggerMetadata: map[string]string{
+			"scalerAddress":                lis.Addr().String(),
+			"maxSilenceSeconds":            "1",
+			"reconnectInitialDelaySeconds": "1",
+			"reconnectMaxDelaySeconds":     "1",
+		},
+		ResolvedEnv: map[string]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reporter := pushScaler.(ConnectionStateReporter)
+	if reporter.ConnectionState().Connected {
+		t.Error("expected the scaler not to be connected before running")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go pushScaler.Run(ctx, active)
+
+	if !<-active {
+		t.Error("expected the scaler to be active")
+	}
+	state := reporter.ConnectionState()
+	if !state.Connected || state.LastMessageTime.IsZero() {
+		t.Errorf("expected the scaler to be connected with a message received, got %+v", state)
+	}
+
+	// the silent stream is reestablished
+	select {
+	case <-active:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream wasn't reestablished after maxSilenceSeconds")
+	}
+	if server.streams.Load() < 2 {
+		t.Errorf("expected the stream to be reestablished, got %d streams", server.streams.Load())
+	}
+
+	cancel()
+	for range active {
+	}
+}
+
 func TestWaitForState(t *testing.T) {
 	grpcServer := grpc.NewServer()
 	address := fmt.Sprintf("127.0.0.1:%d", 15050)
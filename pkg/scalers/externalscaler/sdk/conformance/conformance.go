@@ -0,0 +1,189 @@
+// Package conformance checks that an external scaler answers the calls of KEDA as expected, in the tests of the
+// external scaler:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.RunScaler(t, &queueScaler{}, sdk.ScaledObject{Name: "consumer", Namespace: "default", Metadata: sdk.Metadata{"queue": "orders"}})
+//	}
+package conformance
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk"
+)
+
+// Options of the conformance checks
+type Options struct {
+	// Timeout of each call, 5s by default
+	Timeout time.Duration
+	// StreamTimeout is how long the streams are watched, 1s by default. The external scalers don't have to send
+	// anything on the streams within this time.
+	StreamTimeout time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Second
+	}
+	if o.StreamTimeout <= 0 {
+		o.StreamTimeout = time.Second
+	}
+	return o
+}
+
+// RunScaler serves the scaler on a local port and runs the conformance checks against it
+func RunScaler(t *testing.T, scaler sdk.Scaler, scaledObject sdk.ScaledObject, opts ...Options) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- sdk.Serve(ctx, lis, scaler) }()
+	defer func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("error serving the scaler: %v", err)
+		}
+	}()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("error connecting to the scaler: %v", err)
+	}
+	defer conn.Close()
+
+	Run(t, pb.NewExternalScalerClient(conn), scaledObject, opts...)
+}
+
+// Run runs the conformance checks against the external scaler served to the client
+func Run(t *testing.T, client pb.ExternalScalerClient, scaledObject sdk.ScaledObject, opts ...Options) {
+	t.Helper()
+
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o = o.withDefaults()
+	ref := scaledObject.Ref()
+
+	var specs []*pb.MetricSpec
+	t.Run("GetMetricSpec", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+		defer cancel()
+
+		response, err := client.GetMetricSpec(ctx, ref)
+		if err != nil {
+			t.Fatalf("GetMetricSpec failed: %v", err)
+		}
+		if len(response.MetricSpecs) == 0 {
+			t.Fatal("GetMetricSpec returned no metric, the HPA would have nothing to scale on")
+		}
+		names := map[string]bool{}
+		for _, spec := range response.MetricSpecs {
+			if spec.MetricName == "" {
+				t.Error("GetMetricSpec returned a metric without name")
+			}
+			if names[spec.MetricName] {
+				t.Errorf("GetMetricSpec returned the metric %s twice", spec.MetricName)
+			}
+			names[spec.MetricName] = true
+			if spec.TargetSize <= 0 {
+				t.Errorf("the target size of the metric %s must be greater than 0, got %d", spec.MetricName, spec.TargetSize)
+			}
+		}
+		specs = response.MetricSpecs
+	})
+
+	t.Run("GetMetrics", func(t *testing.T) {
+		if len(specs) == 0 {
+			t.Skip("no metric returned by GetMetricSpec")
+		}
+		for _, spec := range specs {
+			ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+			response, err := client.GetMetrics(ctx, &pb.GetMetricsRequest{ScaledObjectRef: ref, MetricName: spec.MetricName})
+			cancel()
+			if err != nil {
+				t.Errorf("GetMetrics of the metric %s failed: %v", spec.MetricName, err)
+				continue
+			}
+			checkMetricValues(t, spec.MetricName, response.MetricValues)
+		}
+	})
+
+	t.Run("IsActive", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+		defer cancel()
+
+		if _, err := client.IsActive(ctx, ref); err != nil {
+			t.Fatalf("IsActive failed: %v", err)
+		}
+	})
+
+	t.Run("StreamIsActive", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), o.StreamTimeout)
+		defer cancel()
+
+		stream, err := client.StreamIsActive(ctx, ref)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		checkStreamError(t, "StreamIsActive", err)
+	})
+
+	t.Run("StreamGetMetrics", func(t *testing.T) {
+		if len(specs) == 0 {
+			t.Skip("no metric returned by GetMetricSpec")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), o.StreamTimeout)
+		defer cancel()
+
+		stream, err := client.StreamGetMetrics(ctx, &pb.GetMetricsRequest{ScaledObjectRef: ref, MetricName: specs[0].MetricName})
+		var response *pb.GetMetricsResponse
+		if err == nil {
+			response, err = stream.Recv()
+		}
+		if err == nil {
+			checkMetricValues(t, specs[0].MetricName, response.MetricValues)
+			return
+		}
+		checkStreamError(t, "StreamGetMetrics", err)
+	})
+}
+
+func checkMetricValues(t *testing.T, metricName string, values []*pb.MetricValue) {
+	t.Helper()
+	if len(values) == 0 {
+		t.Errorf("no value returned for the metric %s", metricName)
+	}
+	for _, value := range values {
+		if value.MetricValue < 0 {
+			t.Errorf("the value of the metric %s must not be negative, got %d", metricName, value.MetricValue)
+		}
+	}
+}
+
+// checkStreamError accepts the streams that are unimplemented, or that didn't send anything before the timeout
+func checkStreamError(t *testing.T, method string, err error) {
+	t.Helper()
+	switch {
+	case err == nil, errors.Is(err, context.DeadlineExceeded):
+	case status.Code(err) == codes.Unimplemented:
+		t.Skipf("%s is not implemented", method)
+	case status.Code(err) == codes.DeadlineExceeded:
+	default:
+		t.Errorf("%s failed: %v", method, err)
+	}
+}
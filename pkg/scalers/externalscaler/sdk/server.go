@@ -0,0 +1,187 @@
+// Package sdk helps building external scalers for the external and external-push triggers of KEDA in Go.
+//
+// An external scaler implements Scaler, and PushScaler or MetricsStreamer to push the activity or the metrics
+// to KEDA, and is served with ListenAndServe:
+//
+//	err := sdk.ListenAndServe(ctx, ":6000", &queueScaler{}, sdk.WithTLS("tls.crt", "tls.key", "ca.crt"))
+//
+// The conformance package checks that an external scaler answers the calls of KEDA as expected.
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// Scaler is implemented by the external scalers
+type Scaler interface {
+	// IsActive returns whether the scale target must be scaled from zero, or to zero
+	IsActive(ctx context.Context, scaledObject ScaledObject) (bool, error)
+	// GetMetricSpec returns the metrics exposed to the HPA, with their target values
+	GetMetricSpec(ctx context.Context, scaledObject ScaledObject) ([]MetricSpec, error)
+	// GetMetrics returns the current values of the metric, one of the metrics of GetMetricSpec
+	GetMetrics(ctx context.Context, scaledObject ScaledObject, metricName string) ([]MetricValue, error)
+}
+
+// PushScaler is implemented by the external scalers pushing the activity to the external-push trigger
+type PushScaler interface {
+	// StreamIsActive calls send each time the activity changes, until ctx is done
+	StreamIsActive(ctx context.Context, scaledObject ScaledObject, send func(active bool) error) error
+}
+
+// MetricsStreamer is implemented by the external scalers pushing the values of the metrics to the triggers enabling
+// streamGetMetrics
+type MetricsStreamer interface {
+	// StreamGetMetrics calls send each time the values of the metric change, until ctx is done
+	StreamGetMetrics(ctx context.Context, scaledObject ScaledObject, metricName string, send func(values []MetricValue) error) error
+}
+
+// server serves the calls of KEDA with the Scaler, answering Unimplemented to the streams the scaler doesn't support
+type server struct {
+	pb.UnimplementedExternalScalerServer
+
+	scaler Scaler
+}
+
+// NewServer returns the ExternalScalerServer of the scaler, to register on a grpc.Server built by the caller
+func NewServer(scaler Scaler) pb.ExternalScalerServer {
+	return &server{scaler: scaler}
+}
+
+func (s *server) IsActive(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.IsActiveResponse, error) {
+	active, err := s.scaler.IsActive(ctx, ScaledObjectFromRef(ref))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsActiveResponse{Result: active}, nil
+}
+
+func (s *server) StreamIsActive(ref *pb.ScaledObjectRef, stream pb.ExternalScaler_StreamIsActiveServer) error {
+	pushScaler, ok := s.scaler.(PushScaler)
+	if !ok {
+		return status.Error(codes.Unimplemented, "method StreamIsActive not implemented")
+	}
+	return pushScaler.StreamIsActive(stream.Context(), ScaledObjectFromRef(ref), func(active bool) error {
+		return stream.Send(&pb.IsActiveResponse{Result: active})
+	})
+}
+
+func (s *server) GetMetricSpec(ctx context.Context, ref *pb.ScaledObjectRef) (*pb.GetMetricSpecResponse, error) {
+	specs, err := s.scaler.GetMetricSpec(ctx, ScaledObjectFromRef(ref))
+	if err != nil {
+		return nil, err
+	}
+	return toMetricSpecsResponse(specs), nil
+}
+
+func (s *server) GetMetrics(ctx context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	if request.GetMetricName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "metricName is required")
+	}
+	values, err := s.scaler.GetMetrics(ctx, ScaledObjectFromRef(request.GetScaledObjectRef()), request.GetMetricName())
+	if err != nil {
+		return nil, err
+	}
+	return toMetricsResponse(values), nil
+}
+
+func (s *server) StreamGetMetrics(request *pb.GetMetricsRequest, stream pb.ExternalScaler_StreamGetMetricsServer) error {
+	streamer, ok := s.scaler.(MetricsStreamer)
+	if !ok {
+		// KEDA polls GetMetrics instead
+		return status.Error(codes.Unimplemented, "method StreamGetMetrics not implemented")
+	}
+	if request.GetMetricName() == "" {
+		return status.Error(codes.InvalidArgument, "metricName is required")
+	}
+	return streamer.StreamGetMetrics(stream.Context(), ScaledObjectFromRef(request.GetScaledObjectRef()), request.GetMetricName(), func(values []MetricValue) error {
+		return stream.Send(toMetricsResponse(values))
+	})
+}
+
+type options struct {
+	certFile, keyFile, clientCAFile string
+	serverOptions                   []grpc.ServerOption
+}
+
+// Option configures the server of ListenAndServe and Serve
+type Option func(*options)
+
+// WithTLS serves over TLS with the certificate and key in PEM format. When clientCAFile is set, the clients must
+// present a certificate signed by this CA, the tlsClientCert of the trigger.
+func WithTLS(certFile, keyFile, clientCAFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+		o.clientCAFile = clientCAFile
+	}
+}
+
+// WithServerOptions adds options to the grpc.Server, e.g. interceptors or keepalive enforcement
+func WithServerOptions(serverOptions ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.serverOptions = append(o.serverOptions, serverOptions...)
+	}
+}
+
+// ListenAndServe listens on the TCP address and serves the scaler until ctx is done
+func ListenAndServe(ctx context.Context, address string, scaler Scaler, opts ...Option) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", address, err)
+	}
+	return Serve(ctx, lis, scaler, opts...)
+}
+
+// Serve serves the scaler on the listener until ctx is done, then stops gracefully
+func Serve(ctx context.Context, lis net.Listener, scaler Scaler, opts ...Option) error {
+	grpcServer, err := newGRPCServer(scaler, opts...)
+	if err != nil {
+		_ = lis.Close()
+		return err
+	}
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			grpcServer.GracefulStop()
+		case <-stopped:
+		}
+	}()
+
+	if err := grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+func newGRPCServer(scaler Scaler, opts ...Option) (*grpc.Server, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	serverOptions := o.serverOptions
+	if o.certFile != "" || o.keyFile != "" {
+		tlsConfig, err := NewServerTLSConfig(o.certFile, o.keyFile, o.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
+	pb.RegisterExternalScalerServer(grpcServer, NewServer(scaler))
+	return grpcServer, nil
+}
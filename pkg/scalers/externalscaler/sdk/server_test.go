@@ -0,0 +1,243 @@
+package sdk_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk"
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler/sdk/conformance"
+)
+
+type queueScaler struct {
+	lengths map[string]int64
+}
+
+func (s *queueScaler) length(scaledObject sdk.ScaledObject) (int64, error) {
+	queue, err := scaledObject.Metadata.String("queue")
+	if err != nil {
+		return 0, err
+	}
+	return s.lengths[queue], nil
+}
+
+func (s *queueScaler) IsActive(_ context.Context, scaledObject sdk.ScaledObject) (bool, error) {
+	length, err := s.length(scaledObject)
+	return length > 0, err
+}
+
+func (s *queueScaler) GetMetricSpec(_ context.Context, scaledObject sdk.ScaledObject) ([]sdk.MetricSpec, error) {
+	target, err := scaledObject.Metadata.Int64("targetLength", 10)
+	if err != nil {
+		return nil, err
+	}
+	return []sdk.MetricSpec{{MetricName: "queue-length", TargetSize: target}}, nil
+}
+
+func (s *queueScaler) GetMetrics(_ context.Context, scaledObject sdk.ScaledObject, metricName string) ([]sdk.MetricValue, error) {
+	length, err := s.length(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+	return []sdk.MetricValue{{MetricName: metricName, MetricValue: length}}, nil
+}
+
+// pushQueueScaler pushes the activity and the metrics once, then waits for the end of the streams
+type pushQueueScaler struct {
+	queueScaler
+}
+
+func (s *pushQueueScaler) StreamIsActive(ctx context.Context, scaledObject sdk.ScaledObject, send func(bool) error) error {
+	active, err := s.IsActive(ctx, scaledObject)
+	if err != nil {
+		return err
+	}
+	if err := send(active); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *pushQueueScaler) StreamGetMetrics(ctx context.Context, scaledObject sdk.ScaledObject, metricName string, send func([]sdk.MetricValue) error) error {
+	values, err := s.GetMetrics(ctx, scaledObject, metricName)
+	if err != nil {
+		return err
+	}
+	if err := send(values); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+var testScaledObject = sdk.ScaledObject{Name: "consumer", Namespace: "default", Metadata: sdk.Metadata{"queue": "orders"}}
+
+func TestConformance(t *testing.T) {
+	lengths := map[string]int64{"orders": 3}
+	t.Run("scaler", func(t *testing.T) {
+		conformance.RunScaler(t, &queueScaler{lengths: lengths}, testScaledObject, conformance.Options{StreamTimeout: 100 * time.Millisecond})
+	})
+	t.Run("push scaler", func(t *testing.T) {
+		conformance.RunScaler(t, &pushQueueScaler{queueScaler{lengths: lengths}}, testScaledObject)
+	})
+}
+
+func startServer(t *testing.T, scaler sdk.Scaler, opts ...sdk.Option) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- sdk.Serve(ctx, lis, scaler, opts...) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-served)
+	})
+	return lis.Addr().String()
+}
+
+func TestServer(t *testing.T) {
+	address := startServer(t, &queueScaler{lengths: map[string]int64{"orders": 3}})
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewExternalScalerClient(conn)
+
+	spec, err := client.GetMetricSpec(context.Background(), testScaledObject.Ref())
+	require.NoError(t, err)
+	require.Len(t, spec.MetricSpecs, 1)
+	assert.Equal(t, "queue-length", spec.MetricSpecs[0].MetricName)
+	assert.Equal(t, int64(10), spec.MetricSpecs[0].TargetSize)
+
+	metrics, err := client.GetMetrics(context.Background(), &pb.GetMetricsRequest{ScaledObjectRef: testScaledObject.Ref(), MetricName: "queue-length"})
+	require.NoError(t, err)
+	require.Len(t, metrics.MetricValues, 1)
+	assert.Equal(t, int64(3), metrics.MetricValues[0].MetricValue)
+
+	_, err = client.GetMetrics(context.Background(), &pb.GetMetricsRequest{ScaledObjectRef: testScaledObject.Ref()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the metadata errors are returned as invalid arguments
+	_, err = client.IsActive(context.Background(), &pb.ScaledObjectRef{Name: "consumer", Namespace: "default"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the streams aren't implemented by the scaler
+	stream, err := client.StreamIsActive(context.Background(), testScaledObject.Ref())
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestMetadata(t *testing.T) {
+	metadata := sdk.Metadata{"queue": " orders ", "target": "5", "ratio": "0.5", "enabled": "true", "invalid": "some"}
+
+	queue, err := metadata.String("queue")
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", queue)
+	_, err = metadata.String("missing")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "default", metadata.StringOrDefault("missing", "default"))
+
+	target, err := metadata.Int64("target", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), target)
+	target, err = metadata.Int64("missing", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), target)
+	_, err = metadata.Int64("invalid", 10)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	ratio, err := metadata.Float64("ratio", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, ratio)
+	_, err = metadata.Float64("invalid", 1)
+	assert.Error(t, err)
+
+	enabled, err := metadata.Bool("enabled", false)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	_, err = metadata.Bool("invalid", false)
+	assert.Error(t, err)
+}
+
+// writeCertificate writes a self-signed certificate, used as CA too, and its key
+func writeCertificate(t *testing.T, dir, name string) (certFile, keyFile string, certificate *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile, certificate
+}
+
+func TestServerTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey, serverCertificate := writeCertificate(t, dir, "server")
+	clientCert, clientKey, _ := writeCertificate(t, dir, "client")
+
+	address := startServer(t, &queueScaler{}, sdk.WithTLS(serverCert, serverKey, clientCert))
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCertificate)
+	isActive := func(certificates []tls.Certificate) error {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			RootCAs:      rootCAs,
+			Certificates: certificates,
+		})))
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = pb.NewExternalScalerClient(conn).IsActive(context.Background(), testScaledObject.Ref())
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	assert.NoError(t, isActive([]tls.Certificate{certificate}))
+	// the client certificate is required
+	assert.Equal(t, codes.Unavailable, status.Code(isActive(nil)))
+
+	_, err = sdk.NewServerTLSConfig(serverCert, "", "")
+	assert.Error(t, err)
+	_, err = sdk.NewServerTLSConfig(serverCert, serverKey, filepath.Join(dir, "missing.crt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// NewServerTLSConfig returns the TLS config of a server with the certificate and key in PEM format, requiring the
+// clients to present a certificate signed by the CA of clientCAFile when set.
+// The certificate is read again when its file changes, e.g. renewed by cert-manager.
+func NewServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both the certificate and the key are required")
+	}
+	loader := &certificateLoader{certFile: certFile, keyFile: keyFile}
+	if _, err := loader.getCertificate(nil); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: loader.getCertificate,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// certificateLoader loads the certificate again when its file is modified
+type certificateLoader struct {
+	certFile, keyFile string
+
+	mu          sync.Mutex
+	certificate *tls.Certificate
+	modTime     int64
+}
+
+func (l *certificateLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	info, err := os.Stat(l.certFile)
+	if err != nil {
+		if l.certificate != nil {
+			// the file is being replaced, keep serving the previous certificate
+			return l.certificate, nil
+		}
+		return nil, fmt.Errorf("error reading the certificate: %w", err)
+	}
+	if l.certificate != nil && info.ModTime().UnixNano() == l.modTime {
+		return l.certificate, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		if l.certificate != nil {
+			return l.certificate, nil
+		}
+		return nil, fmt.Errorf("error loading the certificate: %w", err)
+	}
+	l.certificate = &certificate
+	l.modTime = info.ModTime().UnixNano()
+	return l.certificate, nil
+}
@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// ScaledObject is the ScaledObject, or ScaledJob, the external scaler is called for
+type ScaledObject struct {
+	Name      string
+	Namespace string
+	// Metadata is the metadata of the trigger, with the values resolved from the environment of the scale target
+	Metadata Metadata
+}
+
+// MetricSpec is a metric exposed to the HPA, with its target value
+type MetricSpec struct {
+	MetricName string
+	TargetSize int64
+}
+
+// MetricValue is the current value of a metric
+type MetricValue struct {
+	MetricName  string
+	MetricValue int64
+}
+
+// Metadata is the metadata of the trigger
+type Metadata map[string]string
+
+// String returns the value of the key, or an InvalidArgument error when it's missing
+func (m Metadata) String(key string) (string, error) {
+	value := strings.TrimSpace(m[key])
+	if value == "" {
+		return "", status.Errorf(codes.InvalidArgument, "metadata %s is required", key)
+	}
+	return value, nil
+}
+
+// StringOrDefault returns the value of the key, or the default value when it's missing
+func (m Metadata) StringOrDefault(key, defaultValue string) string {
+	if value := strings.TrimSpace(m[key]); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Int64 returns the value of the key parsed as an integer, or the default value when it's missing
+func (m Metadata) Int64(key string, defaultValue int64) (int64, error) {
+	value := strings.TrimSpace(m[key])
+	if value == "" {
+		return defaultValue, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "metadata %s must be an integer, got %q", key, value)
+	}
+	return i, nil
+}
+
+// Float64 returns the value of the key parsed as a number, or the default value when it's missing
+func (m Metadata) Float64(key string, defaultValue float64) (float64, error) {
+	value := strings.TrimSpace(m[key])
+	if value == "" {
+		return defaultValue, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "metadata %s must be a number, got %q", key, value)
+	}
+	return f, nil
+}
+
+// Bool returns the value of the key parsed as a boolean, or the default value when it's missing
+func (m Metadata) Bool(key string, defaultValue bool) (bool, error) {
+	value := strings.TrimSpace(m[key])
+	if value == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "metadata %s must be a boolean, got %q", key, value)
+	}
+	return b, nil
+}
+
+// ScaledObjectFromRef returns the ScaledObject of the request of KEDA
+func ScaledObjectFromRef(ref *pb.ScaledObjectRef) ScaledObject {
+	return ScaledObject{
+		Name:      ref.GetName(),
+		Namespace: ref.GetNamespace(),
+		Metadata:  Metadata(ref.GetScalerMetadata()),
+	}
+}
+
+// Ref returns the ScaledObjectRef sent by KEDA for the ScaledObject
+func (s ScaledObject) Ref() *pb.ScaledObjectRef {
+	return &pb.ScaledObjectRef{
+		Name:           s.Name,
+		Namespace:      s.Namespace,
+		ScalerMetadata: s.Metadata,
+	}
+}
+
+func toMetricSpecsResponse(specs []MetricSpec) *pb.GetMetricSpecResponse {
+	response := &pb.GetMetricSpecResponse{MetricSpecs: make([]*pb.MetricSpec, 0, len(specs))}
+	for _, spec := range specs {
+		response.MetricSpecs = append(response.MetricSpecs, &pb.MetricSpec{MetricName: spec.MetricName, TargetSize: spec.TargetSize})
+	}
+	return response
+}
+
+func toMetricsResponse(values []MetricValue) *pb.GetMetricsResponse {
+	response := &pb.GetMetricsResponse{MetricValues: make([]*pb.MetricValue, 0, len(values))}
+	for _, value := range values {
+		response.MetricValues = append(response.MetricValues, &pb.MetricValue{MetricName: value.MetricName, MetricValue: value.MetricValue})
+	}
+	return response
+}
@@ -0,0 +1,288 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	faktoryProtocolVersion = 2
+	faktoryDefaultTimeout  = 5 * time.Second
+)
+
+type faktoryScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *faktoryMetadata
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+	logger     logr.Logger
+}
+
+type faktoryMetadata struct {
+	triggerIndex int
+
+	Address                  string   `keda:"name=address,                  order=triggerMetadata;authParams;resolvedEnv"`
+	Queues                   []string `keda:"name=queues,                   order=triggerMetadata, optional, default=default"`
+	TargetJobCount           int64    `keda:"name=targetJobCount,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetJobCount int64    `keda:"name=activationTargetJobCount, order=triggerMetadata, optional"`
+	IncludeBusy              bool     `keda:"name=includeBusy,              order=triggerMetadata, optional, default=true"`
+	EnableTLS                bool     `keda:"name=enableTLS,                order=triggerMetadata, optional"`
+	UnsafeSsl                bool     `keda:"name=unsafeSsl,                order=triggerMetadata, optional"`
+
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+	Ca       string `keda:"name=ca,       order=authParams, optional"`
+}
+
+// faktoryHi is the greeting of the server, the salt and the iterations are only sent when a password is required
+type faktoryHi struct {
+	Version    int    `json:"v"`
+	Salt       string `json:"s"`
+	Iterations int    `json:"i"`
+}
+
+type faktoryHello struct {
+	Hostname     string `json:"hostname"`
+	PID          int    `json:"pid"`
+	Version      int    `json:"v"`
+	PasswordHash string `json:"pwdhash,omitempty"`
+}
+
+// faktoryInfo is the subset of the response to the INFO command used by the scaler
+type faktoryInfo struct {
+	Faktory struct {
+		Queues map[string]int64 `json:"queues"`
+		Tasks  struct {
+			Busy struct {
+				Size int64 `json:"size"`
+			} `json:"Busy"`
+		} `json:"tasks"`
+	} `json:"faktory"`
+}
+
+func (m *faktoryMetadata) Validate() error {
+	if m.TargetJobCount <= 0 {
+		return errors.New("targetJobCount must be greater than 0")
+	}
+	if _, _, err := net.SplitHostPort(m.Address); err != nil {
+		return fmt.Errorf("address must be of the form host:port: %w", err)
+	}
+	return nil
+}
+
+// NewFaktoryScaler creates a new faktoryScaler
+func NewFaktoryScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseFaktoryMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing faktory metadata: %w", err)
+	}
+
+	s := &faktoryScaler{
+		metricType: metricType,
+		metadata:   meta,
+		timeout:    config.GlobalHTTPTimeout,
+		logger:     InitializeLogger(config, "faktory_scaler"),
+	}
+	if s.timeout == 0 {
+		s.timeout = faktoryDefaultTimeout
+	}
+	if meta.EnableTLS {
+		if s.tlsConfig, err = kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func parseFaktoryMetadata(config *scalersconfig.ScalerConfig) (*faktoryMetadata, error) {
+	meta := &faktoryMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close is a no-op, a connection to Faktory is opened for each query
+func (s *faktoryScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *faktoryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("faktory-%s", strings.Join(s.metadata.Queues, "-")))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetJobCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the jobs enqueued in the queues and, if included, the jobs being processed
+func (s *faktoryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	info, err := s.getInfo(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting faktory info: %w", err)
+	}
+
+	var jobs int64
+	for _, queue := range s.metadata.Queues {
+		jobs += info.Faktory.Queues[queue]
+	}
+	if s.metadata.IncludeBusy {
+		// Faktory only reports the number of busy jobs across all the queues
+		jobs += info.Faktory.Tasks.Busy.Size
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(jobs))
+	return []external_metrics.ExternalMetricValue{metric}, jobs > s.metadata.ActivationTargetJobCount, nil
+}
+
+// getInfo connects to Faktory as a client, authenticates with the HELLO command and runs the INFO command
+func (s *faktoryScaler) getInfo(ctx context.Context) (*faktoryInfo, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	var conn net.Conn
+	var err error
+	if s.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.metadata.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.metadata.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	greeting, err := readFaktoryResponse(reader)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(greeting, "HI ") {
+		return nil, fmt.Errorf("unexpected greeting from faktory: %s", greeting)
+	}
+	var hi faktoryHi
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(greeting, "HI ")), &hi); err != nil {
+		return nil, fmt.Errorf("error parsing faktory greeting: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	hello := faktoryHello{Hostname: hostname, PID: os.Getpid(), Version: faktoryProtocolVersion}
+	if hi.Salt != "" {
+		if s.metadata.Password == "" {
+			return nil, errors.New("faktory requires a password")
+		}
+		hello.PasswordHash = faktoryPasswordHash(s.metadata.Password, hi.Salt, hi.Iterations)
+	}
+	helloJSON, err := json.Marshal(hello)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFaktoryCommand(conn, "HELLO "+string(helloJSON)); err != nil {
+		return nil, err
+	}
+	if response, err := readFaktoryResponse(reader); err != nil {
+		return nil, err
+	} else if response != "OK" {
+		return nil, fmt.Errorf("unexpected response to HELLO: %s", response)
+	}
+
+	if err := writeFaktoryCommand(conn, "INFO"); err != nil {
+		return nil, err
+	}
+	response, err := readFaktoryResponse(reader)
+	if err != nil {
+		return nil, err
+	}
+	info := &faktoryInfo{}
+	if err := json.Unmarshal([]byte(response), info); err != nil {
+		return nil, fmt.Errorf("error parsing faktory info: %w", err)
+	}
+
+	// the server closes the connection anyway, the error is irrelevant
+	_ = writeFaktoryCommand(conn, "END")
+	return info, nil
+}
+
+// faktoryPasswordHash returns the hex encoded SHA256 of the password followed by the salt, hashed again iterations-1 times
+func faktoryPasswordHash(password, salt string, iterations int) string {
+	hash := sha256.Sum256([]byte(password + salt))
+	for i := 1; i < iterations; i++ {
+		hash = sha256.Sum256(hash[:])
+	}
+	return hex.EncodeToString(hash[:])
+}
+
+func writeFaktoryCommand(w io.Writer, command string) error {
+	_, err := io.WriteString(w, command+"\r\n")
+	return err
+}
+
+// readFaktoryResponse reads a simple string, a bulk string or an error of the RESP protocol used by Faktory
+func readFaktoryResponse(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", errors.New("empty response from faktory")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("faktory error: %s", line[1:])
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid bulk string length %q", line[1:])
+		}
+		if length < 0 {
+			return "", nil
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return "", err
+		}
+		return string(data[:length]), nil
+	default:
+		return "", fmt.Errorf("unexpected response from faktory: %s", line)
+	}
+}
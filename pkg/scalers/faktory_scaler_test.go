@@ -0,0 +1,199 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseFaktoryMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type faktoryMetricIdentifier struct {
+	metadataTestData *parseFaktoryMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testFaktoryMetadata = []parseFaktoryMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"address": "faktory:7419"}, map[string]string{}, false, "default queue"},
+	{map[string]string{"address": "faktory:7419", "queues": "critical,default", "targetJobCount": "10", "activationTargetJobCount": "1", "includeBusy": "false"}, map[string]string{"password": "secret"}, false, "all metadata"},
+	{map[string]string{"queues": "default"}, map[string]string{"address": "faktory:7419", "password": "secret"}, false, "address in authParams"},
+	{map[string]string{"address": "faktory"}, map[string]string{}, true, "address without port"},
+	{map[string]string{"address": "faktory:7419", "targetJobCount": "0"}, map[string]string{}, true, "zero targetJobCount"},
+	{map[string]string{"address": "faktory:7419", "enableTLS": "true"}, map[string]string{"ca": "caaa"}, false, "TLS"},
+}
+
+var faktoryMetricIdentifiers = []faktoryMetricIdentifier{
+	{&testFaktoryMetadata[1], 0, "s0-faktory-default"},
+	{&testFaktoryMetadata[2], 1, "s1-faktory-critical-default"},
+}
+
+func TestFaktoryParseMetadata(t *testing.T) {
+	for _, testData := range testFaktoryMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseFaktoryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestFaktoryGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range faktoryMetricIdentifiers {
+		meta, err := parseFaktoryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockFaktoryScaler := faktoryScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockFaktoryScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestFaktoryPasswordHash(t *testing.T) {
+	assert.Equal(t, "e51234001955fec3fe2d74b2944333447bf1f5cc68adc858e22a5c7f394e8076", faktoryPasswordHash("secret", "abcdef", 3))
+}
+
+// createFaktoryTestServer answers a client connection like Faktory, requiring a password if a salt is given
+func createFaktoryTestServer(t *testing.T, salt string, info string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		if salt != "" {
+			fmt.Fprintf(conn, "+HI {\"v\":2,\"s\":%q,\"i\":3}\r\n", salt)
+		} else {
+			fmt.Fprint(conn, "+HI {\"v\":2}\r\n")
+		}
+
+		line, err := reader.ReadString('\n')
+		if err != nil || !strings.HasPrefix(line, "HELLO ") {
+			return
+		}
+		var hello faktoryHello
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "HELLO ")), &hello); err != nil {
+			return
+		}
+		if salt != "" && hello.PasswordHash != faktoryPasswordHash("secret", salt, 3) {
+			fmt.Fprint(conn, "-ERR Invalid password\r\n")
+			return
+		}
+		fmt.Fprint(conn, "+OK\r\n")
+
+		line, err = reader.ReadString('\n')
+		if err != nil || line != "INFO\r\n" {
+			return
+		}
+		fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(info), info)
+	}()
+	return listener.Addr().String()
+}
+
+func TestFaktoryGetMetricsAndActivity(t *testing.T) {
+	info := `{"faktory":{"total_enqueued":15,"queues":{"critical":3,"default":10,"low":2},"tasks":{"Busy":{"size":4},"Retries":{"size":1}}},"server":{"faktory_version":"1.9.0"}}`
+
+	testCases := []struct {
+		name             string
+		salt             string
+		metadata         map[string]string
+		password         string
+		expectedJobs     int64
+		expectedIsActive bool
+		isError          bool
+	}{
+		{
+			name:             "queues and busy jobs",
+			metadata:         map[string]string{"queues": "critical,default"},
+			expectedJobs:     17,
+			expectedIsActive: true,
+		},
+		{
+			name:             "queues only",
+			metadata:         map[string]string{"queues": "critical", "includeBusy": "false", "activationTargetJobCount": "3"},
+			expectedJobs:     3,
+			expectedIsActive: false,
+		},
+		{
+			name:             "unknown queue",
+			metadata:         map[string]string{"queues": "unknown", "includeBusy": "false"},
+			expectedJobs:     0,
+			expectedIsActive: false,
+		},
+		{
+			name:             "password authentication",
+			salt:             "abcdef",
+			metadata:         map[string]string{"queues": "default", "includeBusy": "false"},
+			password:         "secret",
+			expectedJobs:     10,
+			expectedIsActive: true,
+		},
+		{
+			name:     "wrong password",
+			salt:     "abcdef",
+			metadata: map[string]string{"queues": "default"},
+			password: "wrong",
+			isError:  true,
+		},
+		{
+			name:     "missing password",
+			salt:     "abcdef",
+			metadata: map[string]string{"queues": "default"},
+			isError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["address"] = createFaktoryTestServer(t, tc.salt, info)
+			s, err := NewFaktoryScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata:   tc.metadata,
+				AuthParams:        map[string]string{"password": tc.password},
+				GlobalHTTPTimeout: time.Second,
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "faktory")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIsActive, isActive)
+			assert.Equal(t, tc.expectedJobs, metrics[0].Value.Value())
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/filetransfer"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type fileTransferScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *fileTransferMetadata
+	config     *filetransfer.Config
+	logger     logr.Logger
+	now        func() time.Time
+}
+
+type fileTransferMetadata struct {
+	triggerIndex int
+
+	Protocol  string `keda:"name=protocol,  order=triggerMetadata, enum=sftp;ftp;ftps, optional, default=sftp"`
+	Host      string `keda:"name=host,      order=triggerMetadata;resolvedEnv"`
+	Port      int    `keda:"name=port,      order=triggerMetadata, optional"`
+	Directory string `keda:"name=directory, order=triggerMetadata"`
+	// Pattern is the glob the names of the files must match, as of path.Match
+	Pattern string `keda:"name=pattern, order=triggerMetadata, optional, default=*"`
+	// OlderThanSeconds only counts the files which haven't been modified for the duration, e.g. to skip uploads in progress
+	OlderThanSeconds          int     `keda:"name=olderThanSeconds,          order=triggerMetadata, optional"`
+	TargetFileCount           float64 `keda:"name=targetFileCount,           order=triggerMetadata, optional, default=10"`
+	ActivationTargetFileCount float64 `keda:"name=activationTargetFileCount, order=triggerMetadata, optional"`
+	// UnsafeSsl skips the verification of the certificate of the FTPS server, or of the host key of the SFTP server
+	UnsafeSsl bool `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+
+	Username             string `keda:"name=username,             order=authParams;resolvedEnv"`
+	Password             string `keda:"name=password,             order=authParams;resolvedEnv, optional"`
+	PrivateKey           string `keda:"name=privateKey,           order=authParams, optional"`
+	PrivateKeyPassphrase string `keda:"name=privateKeyPassphrase, order=authParams, optional"`
+	HostKey              string `keda:"name=hostKey,              order=triggerMetadata;authParams, optional"`
+	Ca                   string `keda:"name=ca,                   order=authParams, optional"`
+}
+
+func (m *fileTransferMetadata) Validate() error {
+	if m.TargetFileCount <= 0 {
+		return errors.New("targetFileCount must be greater than 0")
+	}
+	if m.OlderThanSeconds < 0 {
+		return errors.New("olderThanSeconds must not be negative")
+	}
+	if _, err := path.Match(m.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", m.Pattern, err)
+	}
+
+	if m.Protocol == filetransfer.ProtocolSFTP {
+		if m.Password == "" && m.PrivateKey == "" {
+			return errors.New("password or privateKey must be provided")
+		}
+		if m.HostKey == "" && !m.UnsafeSsl {
+			return errors.New("hostKey must be provided to verify the server, unless unsafeSsl is set")
+		}
+	} else if m.PrivateKey != "" || m.HostKey != "" {
+		return fmt.Errorf("privateKey and hostKey are only supported by %s", filetransfer.ProtocolSFTP)
+	}
+
+	if m.Port == 0 {
+		m.Port = 21
+		if m.Protocol == filetransfer.ProtocolSFTP {
+			m.Port = 22
+		}
+	}
+	return nil
+}
+
+// NewFileTransferScaler creates a new fileTransferScaler
+func NewFileTransferScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseFileTransferMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing file transfer metadata: %w", err)
+	}
+
+	transferConfig := &filetransfer.Config{
+		Protocol:              meta.Protocol,
+		Address:               net.JoinHostPort(meta.Host, strconv.Itoa(meta.Port)),
+		Username:              meta.Username,
+		Password:              meta.Password,
+		PrivateKey:            meta.PrivateKey,
+		PrivateKeyPassphrase:  meta.PrivateKeyPassphrase,
+		HostKey:               meta.HostKey,
+		InsecureIgnoreHostKey: meta.UnsafeSsl && meta.HostKey == "",
+		Timeout:               config.GlobalHTTPTimeout,
+	}
+	if meta.Protocol == filetransfer.ProtocolFTPS {
+		if transferConfig.TLSConfig, err = kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fileTransferScaler{
+		metricType: metricType,
+		metadata:   meta,
+		config:     transferConfig,
+		logger:     InitializeLogger(config, "file_transfer_scaler"),
+		now:        time.Now,
+	}, nil
+}
+
+func parseFileTransferMetadata(config *scalersconfig.ScalerConfig) (*fileTransferMetadata, error) {
+	meta := &fileTransferMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close does nothing as a connection is opened for each poll
+func (s *fileTransferScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *fileTransferScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("%s-%s", s.metadata.Protocol, s.metadata.Directory))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetFileCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of files of the directory matching the pattern
+func (s *fileTransferScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getFileCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error listing directory %s over %s: %w", s.metadata.Directory, s.metadata.Protocol, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, float64(count) > s.metadata.ActivationTargetFileCount, nil
+}
+
+// getFileCount counts the plain files matching the pattern. When their age is filtered, the files
+// whose modification time isn't reported by the server aren't counted.
+func (s *fileTransferScaler) getFileCount(ctx context.Context) (int64, error) {
+	files, err := filetransfer.ListFiles(ctx, s.config, s.metadata.Directory)
+	if err != nil {
+		return 0, err
+	}
+
+	olderThan := s.now().Add(-time.Duration(s.metadata.OlderThanSeconds) * time.Second)
+	var count int64
+	for _, file := range files {
+		if file.IsDir {
+			continue
+		}
+		if matched, _ := path.Match(s.metadata.Pattern, file.Name); !matched {
+			continue
+		}
+		if s.metadata.OlderThanSeconds > 0 && (file.ModTime.IsZero() || file.ModTime.After(olderThan)) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
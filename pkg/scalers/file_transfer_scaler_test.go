@@ -0,0 +1,171 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseFileTransferMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type fileTransferMetricIdentifier struct {
+	metadataTestData *parseFileTransferMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testFileTransferMetadata = []parseFileTransferMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"host": "sftp.example.com", "directory": "/upload", "hostKey": "ssh-ed25519 AAAA"}, map[string]string{"username": "keda", "password": "secret"}, false, "sftp with password"},
+	{map[string]string{"protocol": "ftps", "host": "ftp.example.com", "port": "2121", "directory": "/upload", "pattern": "*.csv", "olderThanSeconds": "60", "targetFileCount": "5", "activationTargetFileCount": "1"}, map[string]string{"username": "keda", "password": "secret", "ca": "caaa"}, false, "ftps"},
+	{map[string]string{"host": "sftp.example.com", "directory": "/upload", "unsafeSsl": "true"}, map[string]string{"username": "keda", "privateKey": "key"}, false, "sftp with private key without host key"},
+	{map[string]string{"host": "sftp.example.com", "directory": "/upload"}, map[string]string{"username": "keda", "password": "secret"}, true, "sftp without host key"},
+	{map[string]string{"host": "sftp.example.com", "directory": "/upload", "hostKey": "ssh-ed25519 AAAA"}, map[string]string{"username": "keda"}, true, "sftp without password or private key"},
+	{map[string]string{"protocol": "ftp", "host": "ftp.example.com", "directory": "/upload"}, map[string]string{"username": "keda", "privateKey": "key"}, true, "ftp with private key"},
+	{map[string]string{"protocol": "smb", "host": "ftp.example.com", "directory": "/upload"}, map[string]string{"username": "keda"}, true, "unknown protocol"},
+	{map[string]string{"protocol": "ftp", "host": "ftp.example.com"}, map[string]string{"username": "keda"}, true, "no directory"},
+	{map[string]string{"protocol": "ftp", "host": "ftp.example.com", "directory": "/upload", "pattern": "[*.csv"}, map[string]string{"username": "keda"}, true, "invalid pattern"},
+	{map[string]string{"protocol": "ftp", "host": "ftp.example.com", "directory": "/upload", "olderThanSeconds": "-1"}, map[string]string{"username": "keda"}, true, "negative olderThanSeconds"},
+	{map[string]string{"protocol": "ftp", "host": "ftp.example.com", "directory": "/upload", "targetFileCount": "0"}, map[string]string{"username": "keda"}, true, "zero targetFileCount"},
+}
+
+var fileTransferMetricIdentifiers = []fileTransferMetricIdentifier{
+	{&testFileTransferMetadata[1], 0, "s0-sftp--upload"},
+	{&testFileTransferMetadata[2], 1, "s1-ftps--upload"},
+}
+
+func TestFileTransferParseMetadata(t *testing.T) {
+	for _, testData := range testFileTransferMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseFileTransferMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestFileTransferGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range fileTransferMetricIdentifiers {
+		meta, err := parseFileTransferMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockFileTransferScaler := fileTransferScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockFileTransferScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// startFileTransferFTPServer starts an FTP server listing the entries of /upload with MLSD
+func startFileTransferFTPServer(t *testing.T, entries []string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reply := func(format string, args ...interface{}) {
+					_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+				}
+				reply("220 ready")
+				var data net.Listener
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					command, _, _ := strings.Cut(scanner.Text(), " ")
+					switch command {
+					case "USER":
+						reply("230 Login successful")
+					case "EPSV":
+						data, _ = net.Listen("tcp", "127.0.0.1:0")
+						reply("229 Entering Extended Passive Mode (|||%d|)", data.Addr().(*net.TCPAddr).Port)
+					case "MLSD":
+						reply("150 Here comes the listing")
+						dataConn, err := data.Accept()
+						if err != nil {
+							return
+						}
+						for _, entry := range entries {
+							_, _ = fmt.Fprintf(dataConn, "%s\r\n", entry)
+						}
+						dataConn.Close()
+						data.Close()
+						reply("226 Transfer complete")
+					default:
+						reply("221 Goodbye")
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestFileTransferGetMetricsAndActivity(t *testing.T) {
+	address := startFileTransferFTPServer(t, []string{
+		"type=file;modify=20240501115930; orders-1.csv",
+		"type=file;modify=20240501110000; orders-2.csv",
+		"type=file; orders-3.csv",
+		"type=file;modify=20240501110000; orders.lock",
+		"type=dir;modify=20240501110000; archive.csv",
+	})
+	host, port, _ := net.SplitHostPort(address)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"all files", map[string]string{}, 4, true},
+		{"files matching the pattern", map[string]string{"pattern": "*.csv", "activationTargetFileCount": "3"}, 3, false},
+		{"files older than a minute", map[string]string{"pattern": "orders-*", "olderThanSeconds": "60"}, 1, true},
+		{"no matching file", map[string]string{"pattern": "*.xml"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["protocol"] = "ftp"
+			tc.metadata["host"] = host
+			tc.metadata["port"] = port
+			tc.metadata["directory"] = "/upload"
+			s, err := NewFileTransferScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"username": "keda"}, GlobalHTTPTimeout: time.Second})
+			require.NoError(t, err)
+			s.(*fileTransferScaler).now = func() time.Time { return now }
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "file-transfer")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
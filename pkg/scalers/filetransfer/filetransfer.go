@@ -0,0 +1,76 @@
+package filetransfer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+// protocols the files can be listed over
+const (
+	ProtocolSFTP = "sftp"
+	ProtocolFTP  = "ftp"
+	// ProtocolFTPS is FTP upgraded to TLS with AUTH TLS, the data connections being protected too
+	ProtocolFTPS = "ftps"
+)
+
+// Config contains the information required to list the files of a directory of a server.
+type Config struct {
+	Protocol string
+	// Address is the host:port of the server
+	Address  string
+	Username string
+	Password string
+	// PrivateKey authenticates with a key over SFTP, in PEM or OpenSSH format, optionally encrypted with the passphrase
+	PrivateKey           string
+	PrivateKeyPassphrase string
+	// HostKey is the public key of the SFTP server, in authorized_keys format, InsecureIgnoreHostKey skipping its verification
+	HostKey               string
+	InsecureIgnoreHostKey bool
+	// TLSConfig is used by FTPS
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// File is an entry of a directory.
+type File struct {
+	Name string
+	// ModTime is zero when the server doesn't report it
+	ModTime time.Time
+	IsDir   bool
+}
+
+// ListFiles returns the entries of the directory, but . and ..
+func ListFiles(ctx context.Context, c *Config, directory string) ([]File, error) {
+	switch c.Protocol {
+	case ProtocolSFTP:
+		return listSFTP(ctx, c, directory)
+	case ProtocolFTP, ProtocolFTPS:
+		return listFTP(ctx, c, directory)
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", c.Protocol)
+	}
+}
+
+// dial connects to the address with a deadline set from the timeout or the one of the context
+func (c *Config) dial(ctx context.Context, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if c.Timeout > 0 {
+		deadline = time.Now().Add(c.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,302 @@
+package filetransfer
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ftpTimeLayout is the layout of the modification times of MLSD and MDTM, in UTC
+const ftpTimeLayout = "20060102150405"
+
+// ReplyError is an unexpected reply of the FTP server.
+type ReplyError struct {
+	Code    int
+	Message string
+}
+
+func (e *ReplyError) Error() string {
+	return fmt.Sprintf("ftp reply %d: %s", e.Code, e.Message)
+}
+
+type ftpSession struct {
+	c    *Config
+	host string
+	conn net.Conn
+	text *textproto.Conn
+	// tlsConfig is shared by the control and the data connections of FTPS
+	tlsConfig *tls.Config
+}
+
+func listFTP(ctx context.Context, c *Config, directory string) ([]File, error) {
+	host, _, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.dial(ctx, c.Address)
+	if err != nil {
+		return nil, err
+	}
+	s := &ftpSession{c: c, host: host, conn: conn, text: textproto.NewConn(conn)}
+	defer func() { s.text.Close() }()
+
+	if _, err := s.reply(220); err != nil {
+		return nil, err
+	}
+	if err := s.login(); err != nil {
+		return nil, err
+	}
+
+	files, err := s.mlsd(ctx, directory)
+	var reply *ReplyError
+	if errors.As(err, &reply) && (reply.Code == 500 || reply.Code == 502 || reply.Code == 504) {
+		// the server doesn't support MLSD, e.g. vsftpd
+		files, err = s.nlst(ctx, directory)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, _ = s.command("QUIT")
+	return files, nil
+}
+
+// login upgrades the connection to TLS for FTPS and logs in, the data connections being protected too for FTPS
+func (s *ftpSession) login() error {
+	if s.c.Protocol == ProtocolFTPS {
+		if _, _, err := s.command("AUTH TLS", 234); err != nil {
+			return err
+		}
+		s.tlsConfig = s.newTLSConfig()
+		tlsConn := tls.Client(s.conn, s.tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return fmt.Errorf("error upgrading to tls: %w", err)
+		}
+		s.conn = tlsConn
+		s.text = textproto.NewConn(tlsConn)
+	}
+
+	code, _, err := s.command("USER "+s.c.Username, 230, 331)
+	if err != nil {
+		return err
+	}
+	if code == 331 {
+		if _, _, err := s.command("PASS "+s.c.Password, 230, 202); err != nil {
+			return err
+		}
+	}
+
+	if s.c.Protocol == ProtocolFTPS {
+		if _, _, err := s.command("PBSZ 0", 200); err != nil {
+			return err
+		}
+		if _, _, err := s.command("PROT P", 200); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTLSConfig returns the configuration of the TLS connections, with a session cache as servers
+// commonly require the data connections to resume the session of the control connection
+func (s *ftpSession) newTLSConfig() *tls.Config {
+	var config *tls.Config
+	if s.c.TLSConfig != nil {
+		config = s.c.TLSConfig.Clone()
+	} else {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if config.ServerName == "" {
+		config.ServerName = s.host
+	}
+	if config.ClientSessionCache == nil {
+		config.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+	}
+	return config
+}
+
+// mlsd lists the entries of the directory with their facts, e.g. type=file;modify=20240501120000; name
+func (s *ftpSession) mlsd(ctx context.Context, directory string) ([]File, error) {
+	lines, err := s.transfer(ctx, "MLSD "+directory)
+	if err != nil {
+		return nil, err
+	}
+	var files []File
+	for _, line := range lines {
+		facts, name, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		file := File{Name: path.Base(name)}
+		for _, fact := range strings.Split(facts, ";") {
+			key, value, _ := strings.Cut(fact, "=")
+			switch strings.ToLower(key) {
+			case "type":
+				value = strings.ToLower(value)
+				if value == "cdir" || value == "pdir" {
+					file.Name = ""
+				}
+				file.IsDir = value == "dir" || value == "cdir" || value == "pdir"
+			case "modify":
+				file.ModTime, _ = parseFTPTime(value)
+			}
+		}
+		if file.Name != "" && file.Name != "." && file.Name != ".." {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// nlst lists the names of the entries of the directory and gets their modification time with MDTM,
+// which only reports plain files
+func (s *ftpSession) nlst(ctx context.Context, directory string) ([]File, error) {
+	names, err := s.transfer(ctx, "NLST "+directory)
+	if err != nil {
+		return nil, err
+	}
+	var files []File
+	for _, name := range names {
+		file := File{Name: path.Base(name)}
+		if file.Name == "." || file.Name == ".." {
+			continue
+		}
+		if !strings.Contains(name, "/") {
+			name = path.Join(directory, name)
+		}
+		_, message, err := s.command("MDTM "+name, 213)
+		var reply *ReplyError
+		switch {
+		case errors.As(err, &reply) && reply.Code == 550:
+			file.IsDir = true
+		case err != nil:
+			return nil, err
+		default:
+			file.ModTime, _ = parseFTPTime(message)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// transfer opens a passive data connection, sends the command and returns the lines received over the data connection
+func (s *ftpSession) transfer(ctx context.Context, command string) ([]string, error) {
+	address, err := s.passive()
+	if err != nil {
+		return nil, err
+	}
+	dataConn, err := s.c.dial(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("error opening data connection: %w", err)
+	}
+	defer dataConn.Close()
+
+	if _, _, err := s.command(command, 125, 150); err != nil {
+		return nil, err
+	}
+	var reader io.Reader = dataConn
+	if s.c.Protocol == ProtocolFTPS {
+		tlsConn := tls.Client(dataConn, s.tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, fmt.Errorf("error upgrading data connection to tls: %w", err)
+		}
+		reader = tlsConn
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	dataConn.Close()
+	if _, err := s.reply(226, 250); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// passive returns the address of the data connection, from EPSV or PASV whose host is ignored
+// as it's commonly the private address of a server behind NAT
+func (s *ftpSession) passive() (string, error) {
+	_, message, err := s.command("EPSV", 229)
+	if err == nil {
+		// Entering Extended Passive Mode (|||6446|)
+		start, end := strings.Index(message, "(|||"), strings.LastIndex(message, "|)")
+		if start < 0 || end < start+4 {
+			return "", fmt.Errorf("unexpected reply to EPSV: %s", message)
+		}
+		return net.JoinHostPort(s.host, message[start+4:end]), nil
+	}
+
+	_, message, err = s.command("PASV", 227)
+	if err != nil {
+		return "", err
+	}
+	// Entering Passive Mode (h1,h2,h3,h4,p1,p2)
+	start, end := strings.Index(message, "("), strings.LastIndex(message, ")")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("unexpected reply to PASV: %s", message)
+	}
+	fields := strings.Split(message[start+1:end], ",")
+	if len(fields) != 6 {
+		return "", fmt.Errorf("unexpected reply to PASV: %s", message)
+	}
+	high, err1 := strconv.Atoi(fields[4])
+	low, err2 := strconv.Atoi(fields[5])
+	if err1 != nil || err2 != nil {
+		return "", fmt.Errorf("unexpected reply to PASV: %s", message)
+	}
+	return net.JoinHostPort(s.host, strconv.Itoa(high<<8|low)), nil
+}
+
+// command sends the command and reads its reply, which must have one of the codes
+func (s *ftpSession) command(command string, codes ...int) (int, string, error) {
+	if err := s.text.PrintfLine("%s", command); err != nil {
+		return 0, "", err
+	}
+	code, message, err := s.readReply()
+	if err != nil {
+		return 0, "", err
+	}
+	if len(codes) > 0 && !slices.Contains(codes, code) {
+		return code, message, &ReplyError{Code: code, Message: message}
+	}
+	return code, message, nil
+}
+
+func (s *ftpSession) reply(codes ...int) (string, error) {
+	code, message, err := s.readReply()
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(codes, code) {
+		return message, &ReplyError{Code: code, Message: message}
+	}
+	return message, nil
+}
+
+// readReply reads a reply, which may span several lines, without checking its code
+func (s *ftpSession) readReply() (int, string, error) {
+	return s.text.ReadResponse(0)
+}
+
+func parseFTPTime(value string) (time.Time, error) {
+	// the fractions of seconds are optional
+	value, _, _ = strings.Cut(strings.TrimSpace(value), ".")
+	return time.Parse(ftpTimeLayout, value)
+}
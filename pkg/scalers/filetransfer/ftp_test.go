@@ -0,0 +1,186 @@
+package filetransfer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFTPServer struct {
+	// mlsd holds the lines of MLSD by directory, the server not supporting MLSD when it's nil
+	mlsd map[string][]string
+	// nlst holds the lines of NLST by directory and mdtm the modification time of the plain files
+	nlst map[string][]string
+	mdtm map[string]string
+	// noEPSV makes the server only support PASV
+	noEPSV bool
+}
+
+func startFTPServer(t *testing.T, server *testFTPServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(t, conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (s *testFTPServer) serve(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	reply := func(format string, args ...interface{}) {
+		_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+	reply("220-Welcome\r\n220 ready")
+
+	var data net.Listener
+	defer func() {
+		if data != nil {
+			data.Close()
+		}
+	}()
+	sendData := func(lines []string) {
+		reply("150 Here comes the listing")
+		dataConn, err := data.Accept()
+		if err != nil {
+			return
+		}
+		for _, line := range lines {
+			_, _ = fmt.Fprintf(dataConn, "%s\r\n", line)
+		}
+		dataConn.Close()
+		reply("226 Transfer complete")
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		command, argument, _ := strings.Cut(scanner.Text(), " ")
+		switch command {
+		case "USER":
+			reply("331 Please specify the password")
+		case "PASS":
+			if argument != "secret" {
+				reply("530 Login incorrect")
+				continue
+			}
+			reply("230 Login successful")
+		case "EPSV", "PASV":
+			if command == "EPSV" && s.noEPSV {
+				reply("500 Unknown command")
+				continue
+			}
+			var err error
+			data, err = net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			port := data.Addr().(*net.TCPAddr).Port
+			if command == "EPSV" {
+				reply("229 Entering Extended Passive Mode (|||%d|)", port)
+			} else {
+				// the private address of the server is ignored by the client
+				reply("227 Entering Passive Mode (10,0,0,1,%d,%d)", port>>8, port&0xff)
+			}
+		case "MLSD":
+			lines, found := s.mlsd[argument]
+			switch {
+			case s.mlsd == nil:
+				reply("500 Unknown command")
+			case !found:
+				reply("550 Failed to open directory")
+			default:
+				sendData(lines)
+			}
+		case "NLST":
+			lines, found := s.nlst[argument]
+			if !found {
+				reply("550 Failed to open directory")
+				continue
+			}
+			sendData(lines)
+		case "MDTM":
+			modTime, found := s.mdtm[argument]
+			if !found {
+				reply("550 Could not get file modification time")
+				continue
+			}
+			reply("213 %s", modTime)
+		case "QUIT":
+			reply("221 Goodbye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestListFilesFTPMLSD(t *testing.T) {
+	address := startFTPServer(t, &testFTPServer{mlsd: map[string][]string{
+		"/upload": {
+			"type=cdir;modify=20240501120000; /upload",
+			"type=pdir;modify=20240501120000; /",
+			"type=file;size=42;modify=20240501113000.123; orders.csv",
+			"Type=dir;Modify=20240501100000; archive",
+		},
+	}})
+
+	files, err := ListFiles(context.Background(), &Config{Protocol: ProtocolFTP, Address: address, Username: "keda", Password: "secret", Timeout: time.Second}, "/upload")
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Name: "orders.csv", ModTime: time.Date(2024, 5, 1, 11, 30, 0, 0, time.UTC)},
+		{Name: "archive", ModTime: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), IsDir: true},
+	}, files)
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolFTP, Address: address, Username: "keda", Password: "secret", Timeout: time.Second}, "/missing")
+	var reply *ReplyError
+	require.ErrorAs(t, err, &reply)
+	assert.Equal(t, 550, reply.Code)
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolFTP, Address: address, Username: "keda", Password: "wrong", Timeout: time.Second}, "/upload")
+	require.ErrorAs(t, err, &reply)
+	assert.Equal(t, 530, reply.Code)
+}
+
+func TestListFilesFTPNLST(t *testing.T) {
+	address := startFTPServer(t, &testFTPServer{
+		nlst: map[string][]string{"/upload": {"orders.csv", "archive", "/upload/invoices.csv"}},
+		mdtm: map[string]string{
+			"/upload/orders.csv":   "20240501113000",
+			"/upload/invoices.csv": "20240501114500",
+		},
+		noEPSV: true,
+	})
+
+	files, err := ListFiles(context.Background(), &Config{Protocol: ProtocolFTP, Address: address, Username: "keda", Password: "secret", Timeout: time.Second}, "/upload")
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Name: "orders.csv", ModTime: time.Date(2024, 5, 1, 11, 30, 0, 0, time.UTC)},
+		{Name: "archive", IsDir: true},
+		{Name: "invoices.csv", ModTime: time.Date(2024, 5, 1, 11, 45, 0, 0, time.UTC)},
+	}, files)
+}
+
+func TestListFilesUnreachable(t *testing.T) {
+	// nothing listens on the port of the closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolFTP, Address: address, Timeout: time.Second}, "/upload")
+	assert.ErrorContains(t, err, "connection refused")
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: "smb", Address: address}, "/upload")
+	assert.ErrorContains(t, err, "unknown protocol")
+}
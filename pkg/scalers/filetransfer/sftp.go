@@ -0,0 +1,305 @@
+package filetransfer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// version 3 of the SSH File Transfer Protocol, the one implemented by OpenSSH
+const sftpProtocolVersion = 3
+
+// packets of the protocol
+const (
+	sftpInit    = 1
+	sftpVersion = 2
+	sftpClose   = 4
+	sftpOpenDir = 11
+	sftpReadDir = 12
+	sftpStatus  = 101
+	sftpHandle  = 102
+	sftpName    = 104
+)
+
+const (
+	sftpStatusOK  = 0
+	sftpStatusEOF = 1
+
+	sftpAttrSize        = 0x00000001
+	sftpAttrUIDGID      = 0x00000002
+	sftpAttrPermissions = 0x00000004
+	sftpAttrACModTime   = 0x00000008
+	sftpAttrExtended    = 0x80000000
+
+	sftpFileTypeMask = 0170000
+	sftpFileTypeDir  = 0040000
+
+	// sftpMaxPacket protects against reading garbage as the length of a packet
+	sftpMaxPacket = 1024 * 1024
+)
+
+// StatusError is an error status returned by the SFTP server.
+type StatusError struct {
+	Code    uint32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sftp status %d: %s", e.Code, e.Message)
+}
+
+func listSFTP(ctx context.Context, c *Config, directory string) ([]File, error) {
+	sshConfig, err := c.sshConfig()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.dial(ctx, c.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, c.Address, sshConfig)
+	if err != nil {
+		return nil, err
+	}
+	client := ssh.NewClient(sshConn, channels, requests)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+	w, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		return nil, fmt.Errorf("error requesting the sftp subsystem: %w", err)
+	}
+
+	s := &sftpSession{w: w, r: r}
+	return s.readDir(directory)
+}
+
+func (c *Config) sshConfig() (*ssh.ClientConfig, error) {
+	config := &ssh.ClientConfig{User: c.Username, Timeout: c.Timeout}
+	if c.PrivateKey != "" {
+		var signer ssh.Signer
+		var err error
+		if c.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(c.PrivateKey), []byte(c.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key: %w", err)
+		}
+		config.Auth = append(config.Auth, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		config.Auth = append(config.Auth, ssh.Password(c.Password))
+	}
+
+	switch {
+	case c.HostKey != "":
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(c.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing host key: %w", err)
+		}
+		config.HostKeyCallback = ssh.FixedHostKey(hostKey)
+		config.HostKeyAlgorithms = []string{hostKey.Type()}
+	case c.InsecureIgnoreHostKey:
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	default:
+		return nil, errors.New("the host key of the server is required to verify it")
+	}
+	return config, nil
+}
+
+type sftpSession struct {
+	w  io.Writer
+	r  io.Reader
+	id uint32
+}
+
+// readDir opens the directory, reads its entries until the end of the directory is reported and closes it
+func (s *sftpSession) readDir(directory string) ([]File, error) {
+	if err := s.send(sftpInit, binary.BigEndian.AppendUint32(nil, sftpProtocolVersion)); err != nil {
+		return nil, err
+	}
+	packetType, _, err := s.receive()
+	if err != nil {
+		return nil, err
+	}
+	if packetType != sftpVersion {
+		return nil, fmt.Errorf("unexpected packet %d instead of the version", packetType)
+	}
+
+	payload, err := s.request(sftpOpenDir, appendSFTPString(nil, directory), sftpHandle)
+	if err != nil {
+		return nil, fmt.Errorf("error opening directory %s: %w", directory, err)
+	}
+	handle := (&sftpReader{buf: payload}).string()
+
+	var files []File
+	for {
+		payload, err := s.request(sftpReadDir, appendSFTPString(nil, handle), sftpName)
+		var status *StatusError
+		if errors.As(err, &status) && status.Code == sftpStatusEOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading directory %s: %w", directory, err)
+		}
+
+		r := &sftpReader{buf: payload}
+		count := r.uint32()
+		for i := uint32(0); i < count && r.err == nil; i++ {
+			name := r.string()
+			// the long name is the line of ls -l
+			r.string()
+			file := r.attributes()
+			file.Name = name
+			if name != "." && name != ".." {
+				files = append(files, file)
+			}
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("error parsing the entries of directory %s: %w", directory, r.err)
+		}
+	}
+
+	if _, err := s.request(sftpClose, appendSFTPString(nil, handle), sftpStatus); err != nil {
+		return nil, fmt.Errorf("error closing directory %s: %w", directory, err)
+	}
+	return files, nil
+}
+
+// request sends the request and returns the payload of the response, after its id, if it's of the type.
+// A status is returned as an error but for an OK status expected as the response.
+func (s *sftpSession) request(packetType byte, payload []byte, responseType byte) ([]byte, error) {
+	s.id++
+	if err := s.send(packetType, append(binary.BigEndian.AppendUint32(nil, s.id), payload...)); err != nil {
+		return nil, err
+	}
+	receivedType, response, err := s.receive()
+	if err != nil {
+		return nil, err
+	}
+	r := &sftpReader{buf: response}
+	if id := r.uint32(); r.err == nil && id != s.id {
+		return nil, fmt.Errorf("unexpected response to request %d instead of %d", id, s.id)
+	}
+	if receivedType == sftpStatus {
+		code := r.uint32()
+		message := r.string()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if code == sftpStatusOK && responseType == sftpStatus {
+			return nil, nil
+		}
+		return nil, &StatusError{Code: code, Message: message}
+	}
+	if receivedType != responseType {
+		return nil, fmt.Errorf("unexpected packet %d instead of %d", receivedType, responseType)
+	}
+	return r.buf, r.err
+}
+
+func (s *sftpSession) send(packetType byte, payload []byte) error {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+1))
+	buf = append(buf, packetType)
+	_, err := s.w.Write(append(buf, payload...))
+	return err
+}
+
+func (s *sftpSession) receive() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(s.r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length < 1 || length > sftpMaxPacket {
+		return 0, nil, fmt.Errorf("invalid packet length %d", length)
+	}
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(s.r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[4], payload, nil
+}
+
+// sftpReader reads the fields of a payload, keeping the first error
+type sftpReader struct {
+	buf []byte
+	err error
+}
+
+func (r *sftpReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *sftpReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *sftpReader) string() string {
+	return string(r.next(int(r.uint32())))
+}
+
+// attributes reads the attributes of a file, only keeping its type and modification time
+func (r *sftpReader) attributes() File {
+	var file File
+	flags := r.uint32()
+	if flags&sftpAttrSize != 0 {
+		r.next(8)
+	}
+	if flags&sftpAttrUIDGID != 0 {
+		r.next(8)
+	}
+	if flags&sftpAttrPermissions != 0 {
+		file.IsDir = r.uint32()&sftpFileTypeMask == sftpFileTypeDir
+	}
+	if flags&sftpAttrACModTime != 0 {
+		r.uint32()
+		file.ModTime = time.Unix(int64(r.uint32()), 0)
+	}
+	if flags&sftpAttrExtended != 0 {
+		count := r.uint32()
+		for i := uint32(0); i < count && r.err == nil; i++ {
+			r.string()
+			r.string()
+		}
+	}
+	return file
+}
+
+func appendSFTPString(buf []byte, s string) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(s)))
+	return append(buf, s...)
+}
@@ -0,0 +1,191 @@
+package filetransfer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+type testSFTPEntry struct {
+	name        string
+	permissions uint32
+	modTime     uint32
+}
+
+// startSFTPServer starts an SSH server accepting keda:secret and serving the directories over the sftp subsystem.
+// It returns its address and its host key in authorized_keys format.
+func startSFTPServer(t *testing.T, directories map[string][]testSFTPEntry) (string, string) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "keda" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, config, directories)
+		}
+	}()
+	return listener.Addr().String(), string(ssh.MarshalAuthorizedKey(hostSigner.PublicKey()))
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig, directories map[string][]testSFTPEntry) {
+	defer conn.Close()
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for request := range channelRequests {
+				// the name of the subsystem is a string prefixed with its length
+				isSFTP := request.Type == "subsystem" && string(request.Payload[4:]) == "sftp"
+				_ = request.Reply(isSFTP, nil)
+				if isSFTP {
+					go serveSFTP(channel, directories)
+				}
+			}
+		}()
+	}
+}
+
+func serveSFTP(channel ssh.Channel, directories map[string][]testSFTPEntry) {
+	defer channel.Close()
+	s := &sftpSession{w: channel, r: channel}
+	sendStatus := func(id, code uint32) {
+		payload := binary.BigEndian.AppendUint32(nil, id)
+		payload = binary.BigEndian.AppendUint32(payload, code)
+		payload = appendSFTPString(payload, "status")
+		payload = appendSFTPString(payload, "")
+		_ = s.send(sftpStatus, payload)
+	}
+
+	read := map[string]bool{}
+	for {
+		packetType, payload, err := s.receive()
+		if err != nil {
+			return
+		}
+		if packetType == sftpInit {
+			_ = s.send(sftpVersion, binary.BigEndian.AppendUint32(nil, sftpProtocolVersion))
+			continue
+		}
+		r := &sftpReader{buf: payload}
+		id := r.uint32()
+		argument := r.string()
+		switch packetType {
+		case sftpOpenDir:
+			if _, found := directories[argument]; !found {
+				// no such file
+				sendStatus(id, 2)
+				continue
+			}
+			_ = s.send(sftpHandle, appendSFTPString(binary.BigEndian.AppendUint32(nil, id), argument))
+		case sftpReadDir:
+			if read[argument] {
+				sendStatus(id, sftpStatusEOF)
+				continue
+			}
+			read[argument] = true
+			entries := directories[argument]
+			response := binary.BigEndian.AppendUint32(nil, id)
+			response = binary.BigEndian.AppendUint32(response, uint32(len(entries)))
+			for _, entry := range entries {
+				response = appendSFTPString(response, entry.name)
+				response = appendSFTPString(response, "-rw-r--r-- 1 keda keda 0 "+entry.name)
+				response = binary.BigEndian.AppendUint32(response, sftpAttrSize|sftpAttrPermissions|sftpAttrACModTime|sftpAttrExtended)
+				response = binary.BigEndian.AppendUint64(response, 42)
+				response = binary.BigEndian.AppendUint32(response, entry.permissions)
+				response = binary.BigEndian.AppendUint32(response, entry.modTime)
+				response = binary.BigEndian.AppendUint32(response, entry.modTime)
+				response = binary.BigEndian.AppendUint32(response, 1)
+				response = appendSFTPString(response, "name@example.com")
+				response = appendSFTPString(response, "value")
+			}
+			_ = s.send(sftpName, response)
+		case sftpClose:
+			sendStatus(id, sftpStatusOK)
+		}
+	}
+}
+
+func TestListFilesSFTP(t *testing.T) {
+	modTime := uint32(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix())
+	address, hostKey := startSFTPServer(t, map[string][]testSFTPEntry{
+		"/upload": {
+			{".", 0040755, modTime},
+			{"..", 0040755, modTime},
+			{"orders.csv", 0100644, modTime},
+			{"archive", 0040755, modTime},
+		},
+	})
+
+	files, err := ListFiles(context.Background(), &Config{Protocol: ProtocolSFTP, Address: address, Username: "keda", Password: "secret", HostKey: hostKey, Timeout: time.Second}, "/upload")
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Name: "orders.csv", ModTime: time.Unix(int64(modTime), 0)},
+		{Name: "archive", ModTime: time.Unix(int64(modTime), 0), IsDir: true},
+	}, files)
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolSFTP, Address: address, Username: "keda", Password: "secret", InsecureIgnoreHostKey: true, Timeout: time.Second}, "/missing")
+	var status *StatusError
+	require.ErrorAs(t, err, &status)
+	assert.Equal(t, uint32(2), status.Code)
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolSFTP, Address: address, Username: "keda", Password: "wrong", HostKey: hostKey, Timeout: time.Second}, "/upload")
+	assert.ErrorContains(t, err, "unable to authenticate")
+
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherSigner, _ := ssh.NewSignerFromKey(otherKey)
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolSFTP, Address: address, Username: "keda", Password: "secret", HostKey: string(ssh.MarshalAuthorizedKey(otherSigner.PublicKey())), Timeout: time.Second}, "/upload")
+	assert.ErrorContains(t, err, "host key mismatch")
+
+	_, err = ListFiles(context.Background(), &Config{Protocol: ProtocolSFTP, Address: address, Username: "keda", Password: "secret", Timeout: time.Second}, "/upload")
+	assert.ErrorContains(t, err, "host key of the server is required")
+}
+
+func TestSFTPReaderAttributes(t *testing.T) {
+	// only the size is reported
+	buf := binary.BigEndian.AppendUint32(nil, sftpAttrSize)
+	buf = binary.BigEndian.AppendUint64(buf, 10)
+	r := &sftpReader{buf: buf}
+	assert.Equal(t, File{}, r.attributes())
+	assert.NoError(t, r.err)
+
+	r = &sftpReader{buf: binary.BigEndian.AppendUint32(nil, sftpAttrACModTime)}
+	r.attributes()
+	assert.ErrorIs(t, r.err, io.ErrUnexpectedEOF)
+}
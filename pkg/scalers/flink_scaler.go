@@ -0,0 +1,332 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	flinkMetricBackpressure   = "backpressure"
+	flinkMetricBusyTime       = "busyTime"
+	flinkMetricPendingRecords = "pendingRecords"
+
+	flinkJobStateRunning = "RUNNING"
+)
+
+// flinkDefaultTargetValues are the default targets of the metrics: the ratio of time a vertex is back pressured,
+// the milliseconds per second a vertex is busy and the records a source operator hasn't read yet
+var flinkDefaultTargetValues = map[string]float64{
+	flinkMetricBackpressure:   0.5,
+	flinkMetricBusyTime:       800,
+	flinkMetricPendingRecords: 1000,
+}
+
+type flinkScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *flinkMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type flinkMetadata struct {
+	triggerIndex int
+
+	RestURL               string  `keda:"name=restURL,               order=triggerMetadata;resolvedEnv"`
+	JobName               string  `keda:"name=jobName,               order=triggerMetadata, optional"`
+	JobID                 string  `keda:"name=jobID,                 order=triggerMetadata, optional"`
+	OperatorName          string  `keda:"name=operatorName,          order=triggerMetadata, optional"`
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=backpressure;busyTime;pendingRecords, optional, default=backpressure"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+}
+
+type flinkJobsOverview struct {
+	Jobs []struct {
+		ID    string `json:"jid"`
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"jobs"`
+}
+
+type flinkJobDetails struct {
+	Vertices []flinkVertex `json:"vertices"`
+}
+
+type flinkVertex struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type flinkBackpressure struct {
+	Status   string `json:"status"`
+	Subtasks []struct {
+		Ratio float64 `json:"ratio"`
+	} `json:"subtasks"`
+}
+
+type flinkAggregatedMetric struct {
+	ID  string   `json:"id"`
+	Max *float64 `json:"max"`
+	Sum *float64 `json:"sum"`
+}
+
+func (m *flinkMetadata) Validate() error {
+	if (m.JobName == "") == (m.JobID == "") {
+		return errors.New("exactly one of jobName or jobID must be provided")
+	}
+	if m.Metric == flinkMetricPendingRecords && m.OperatorName == "" {
+		return errors.New("operatorName must be provided to scale on pendingRecords")
+	}
+	if m.TargetValue == 0 {
+		m.TargetValue = flinkDefaultTargetValues[m.Metric]
+	}
+	if m.TargetValue < 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	m.RestURL = strings.TrimSuffix(m.RestURL, "/")
+	return nil
+}
+
+// NewFlinkScaler creates a new flinkScaler
+func NewFlinkScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseFlinkMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing flink metadata: %w", err)
+	}
+
+	return &flinkScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "flink_scaler"),
+	}, nil
+}
+
+func parseFlinkMetadata(config *scalersconfig.ScalerConfig) (*flinkMetadata, error) {
+	meta := &flinkMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *flinkScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *flinkScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	job := s.metadata.JobName
+	if job == "" {
+		job = s.metadata.JobID
+	}
+	metricName := kedautil.NormalizeString(fmt.Sprintf("flink-%s-%s", job, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the metric of the job
+func (s *flinkScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting flink %s: %w", s.metadata.Metric, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getMetricValue returns the highest back pressure ratio or busy time of the vertices of the job, or the pending
+// records of the operator. Only the vertices containing the operator are considered when it's provided.
+func (s *flinkScaler) getMetricValue(ctx context.Context) (float64, error) {
+	jobID, err := s.getJobID(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if jobID == "" {
+		// the job isn't running, e.g. it's restarting, so there's nothing to scale on
+		return 0, nil
+	}
+
+	var job flinkJobDetails
+	if err := s.getJSON(ctx, "/jobs/"+url.PathEscape(jobID), &job); err != nil {
+		return -1, err
+	}
+
+	vertices := job.Vertices
+	if s.metadata.OperatorName != "" {
+		vertices = nil
+		for _, vertex := range job.Vertices {
+			if strings.Contains(vertex.Name, s.metadata.OperatorName) {
+				vertices = append(vertices, vertex)
+			}
+		}
+		if len(vertices) == 0 {
+			return -1, fmt.Errorf("no vertex of job %s contains operator %s", jobID, s.metadata.OperatorName)
+		}
+	}
+
+	var value float64
+	for _, vertex := range vertices {
+		vertexPath := fmt.Sprintf("/jobs/%s/vertices/%s", url.PathEscape(jobID), url.PathEscape(vertex.ID))
+		var vertexValue float64
+		switch s.metadata.Metric {
+		case flinkMetricBackpressure:
+			vertexValue, err = s.getBackpressureRatio(ctx, vertexPath)
+		case flinkMetricBusyTime:
+			vertexValue, err = s.getBusyTime(ctx, vertexPath)
+		case flinkMetricPendingRecords:
+			vertexValue, err = s.getPendingRecords(ctx, vertexPath)
+		}
+		if err != nil {
+			return -1, fmt.Errorf("error getting metric of vertex %s: %w", vertex.Name, err)
+		}
+
+		if s.metadata.Metric == flinkMetricPendingRecords {
+			value += vertexValue
+		} else {
+			value = math.Max(value, vertexValue)
+		}
+	}
+	return value, nil
+}
+
+// getJobID returns the id of the job, the running job with the name if only the name is provided
+func (s *flinkScaler) getJobID(ctx context.Context) (string, error) {
+	if s.metadata.JobID != "" {
+		return s.metadata.JobID, nil
+	}
+
+	var overview flinkJobsOverview
+	if err := s.getJSON(ctx, "/jobs/overview", &overview); err != nil {
+		return "", err
+	}
+	for _, job := range overview.Jobs {
+		if job.Name == s.metadata.JobName && job.State == flinkJobStateRunning {
+			return job.ID, nil
+		}
+	}
+	s.logger.V(1).Info("no running flink job found", "jobName", s.metadata.JobName)
+	return "", nil
+}
+
+func (s *flinkScaler) getBackpressureRatio(ctx context.Context, vertexPath string) (float64, error) {
+	var backpressure flinkBackpressure
+	if err := s.getJSON(ctx, vertexPath+"/backpressure", &backpressure); err != nil {
+		return -1, err
+	}
+	// the back pressure is only sampled on request by older versions of Flink
+	if backpressure.Status != "ok" {
+		return 0, nil
+	}
+
+	var ratio float64
+	for _, subtask := range backpressure.Subtasks {
+		ratio = math.Max(ratio, subtask.Ratio)
+	}
+	return ratio, nil
+}
+
+func (s *flinkScaler) getBusyTime(ctx context.Context, vertexPath string) (float64, error) {
+	var metrics []flinkAggregatedMetric
+	if err := s.getJSON(ctx, vertexPath+"/subtasks/metrics?get=busyTimeMsPerSecond&agg=max", &metrics); err != nil {
+		return -1, err
+	}
+	if len(metrics) == 0 || metrics[0].Max == nil {
+		return 0, nil
+	}
+	return *metrics[0].Max, nil
+}
+
+// getPendingRecords sums up the pendingRecords metrics of the operator, the metrics of the
+// chained operators of a vertex are prefixed with the name of each operator
+func (s *flinkScaler) getPendingRecords(ctx context.Context, vertexPath string) (float64, error) {
+	var available []flinkAggregatedMetric
+	if err := s.getJSON(ctx, vertexPath+"/subtasks/metrics", &available); err != nil {
+		return -1, err
+	}
+	var ids []string
+	for _, metric := range available {
+		if strings.HasSuffix(metric.ID, "."+flinkMetricPendingRecords) {
+			ids = append(ids, metric.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var metrics []flinkAggregatedMetric
+	if err := s.getJSON(ctx, fmt.Sprintf("%s/subtasks/metrics?get=%s&agg=sum", vertexPath, url.QueryEscape(strings.Join(ids, ","))), &metrics); err != nil {
+		return -1, err
+	}
+	var pending float64
+	for _, metric := range metrics {
+		if metric.Sum != nil {
+			pending += *metric.Sum
+		}
+	}
+	return pending, nil
+}
+
+func (s *flinkScaler) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.RestURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, string(body))
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,148 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseFlinkMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type flinkMetricIdentifier struct {
+	metadataTestData *parseFlinkMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testFlinkMetadata = []parseFlinkMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders"}, map[string]string{}, false, "job name with default metric"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobID": "a1b2", "metric": "busyTime", "targetValue": "700"}, map[string]string{}, false, "job id with busy time"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "metric": "pendingRecords", "operatorName": "Source: Kafka"}, map[string]string{"username": "flink", "password": "secret"}, false, "pending records with basic auth"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081"}, map[string]string{}, true, "missing job"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "jobID": "a1b2"}, map[string]string{}, true, "both job name and job id"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "metric": "pendingRecords"}, map[string]string{}, true, "pending records without operator"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "metric": "checkpoints"}, map[string]string{}, true, "unknown metric"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders", "targetValue": "-1"}, map[string]string{}, true, "negative targetValue"},
+	{map[string]string{"restURL": "http://flink-jobmanager:8081", "jobName": "orders"}, map[string]string{"username": "flink"}, true, "username without password"},
+}
+
+var flinkMetricIdentifiers = []flinkMetricIdentifier{
+	{&testFlinkMetadata[1], 0, "s0-flink-orders-backpressure"},
+	{&testFlinkMetadata[2], 1, "s1-flink-a1b2-busyTime"},
+}
+
+func TestFlinkParseMetadata(t *testing.T) {
+	for _, testData := range testFlinkMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseFlinkMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestFlinkParseMetadataDefaultTargetValue(t *testing.T) {
+	meta, err := parseFlinkMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testFlinkMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, meta.TargetValue)
+
+	meta, err = parseFlinkMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testFlinkMetadata[3].metadata, AuthParams: testFlinkMetadata[3].authParams})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1000), meta.TargetValue)
+}
+
+func TestFlinkGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range flinkMetricIdentifiers {
+		meta, err := parseFlinkMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockFlinkScaler := flinkScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockFlinkScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestFlinkGetMetricsAndActivity(t *testing.T) {
+	responses := map[string]string{
+		"/jobs/overview": `{"jobs":[{"jid":"old","name":"orders","state":"CANCELED"},{"jid":"j1","name":"orders","state":"RUNNING"}]}`,
+		"/jobs/j1":       `{"jid":"j1","name":"orders","vertices":[{"id":"v1","name":"Source: Kafka -> Map"},{"id":"v2","name":"Sink: Print"}]}`,
+
+		"/jobs/j1/vertices/v1/backpressure": `{"status":"ok","backpressureLevel":"high","subtasks":[{"subtask":0,"ratio":0.2},{"subtask":1,"ratio":0.7}]}`,
+		"/jobs/j1/vertices/v2/backpressure": `{"status":"ok","backpressureLevel":"low","subtasks":[{"subtask":0,"ratio":0.1}]}`,
+
+		"/jobs/j1/vertices/v1/subtasks/metrics?get=busyTimeMsPerSecond&agg=max": `[{"id":"busyTimeMsPerSecond","max":350.0}]`,
+		"/jobs/j1/vertices/v2/subtasks/metrics?get=busyTimeMsPerSecond&agg=max": `[{"id":"busyTimeMsPerSecond","max":910.0}]`,
+
+		"/jobs/j1/vertices/v1/subtasks/metrics":                                          `[{"id":"numRecordsIn"},{"id":"Source__Kafka.pendingRecords"}]`,
+		"/jobs/j1/vertices/v1/subtasks/metrics?get=Source__Kafka.pendingRecords&agg=sum": `[{"id":"Source__Kafka.pendingRecords","sum":4200.0}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "flink", username)
+		assert.Equal(t, "secret", password)
+
+		response, found := responses[r.URL.RequestURI()]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isError       bool
+	}{
+		{"highest back pressure of the vertices", map[string]string{"jobName": "orders"}, 700, false},
+		{"back pressure of the operator", map[string]string{"jobName": "orders", "operatorName": "Sink"}, 100, false},
+		{"highest busy time of the vertices", map[string]string{"jobID": "j1", "metric": "busyTime"}, 910000, false},
+		{"pending records of the operator", map[string]string{"jobName": "orders", "metric": "pendingRecords", "operatorName": "Source: Kafka"}, 4200000, false},
+		{"job not running", map[string]string{"jobName": "payments"}, 0, false},
+		{"unknown operator", map[string]string{"jobName": "orders", "operatorName": "Filter"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["restURL"] = server.URL
+			s, err := NewFlinkScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"username": "flink", "password": "secret"},
+			})
+			assert.NoError(t, err)
+
+			metrics, _, err := s.GetMetricsAndActivity(context.Background(), "flink")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.MilliValue())
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+const (
+	gatewayAPIEnvoyGateway = "envoy-gateway"
+	gatewayAPIIstio        = "istio"
+
+	gatewayAPIMetricInFlightRequests = "inFlightRequests"
+)
+
+// gatewayAPIScaler resolves the rules and the backends of an HTTPRoute and runs the query of the metrics
+// reported for them by the implementation of the Gateway API with the prometheus scaler
+type gatewayAPIScaler struct {
+	metadata   *gatewayAPIMetadata
+	kubeClient client.Client
+	prometheus *prometheusScaler
+	logger     logr.Logger
+}
+
+type gatewayAPIMetadata struct {
+	triggerIndex int
+
+	ServerAddress string `keda:"name=serverAddress, order=triggerMetadata"`
+	HTTPRoute     string `keda:"name=httpRoute,     order=triggerMetadata"`
+	// RouteNamespace is the namespace of the route, the one of the scaled object by default
+	RouteNamespace string `keda:"name=routeNamespace, order=triggerMetadata, optional"`
+	// BackendService restricts the requests to the ones of the rules forwarding them to the service
+	BackendService string `keda:"name=backendService, order=triggerMetadata, optional"`
+	// Implementation is the implementation of the Gateway API, which names the metrics and their labels
+	Implementation      string  `keda:"name=implementation,      order=triggerMetadata, enum=envoy-gateway;istio"`
+	Metric              string  `keda:"name=metric,              order=triggerMetadata, enum=requestRate;inFlightRequests, optional, default=requestRate"`
+	Window              string  `keda:"name=window,              order=triggerMetadata, optional, default=1m"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
+}
+
+// gatewayAPIRef is a reference of the route to a Gateway or to a Service
+type gatewayAPIRef struct {
+	name      string
+	namespace string
+}
+
+// gatewayAPIRoute holds what the query is built from: the indexes of the matching rules, their backends and the parent gateways
+type gatewayAPIRoute struct {
+	rules    []int
+	backends []gatewayAPIRef
+	gateways []gatewayAPIRef
+}
+
+func (m *gatewayAPIMetadata) Validate() error {
+	if window, err := time.ParseDuration(m.Window); err != nil || window <= 0 {
+		return fmt.Errorf("window must be a positive duration, e.g. 1m, got %q", m.Window)
+	}
+	if m.Threshold <= 0 {
+		return errors.New("threshold must be greater than 0")
+	}
+	// the telemetry of istio doesn't report the requests in flight, nor do gateways export the gauges of envoy by default
+	if m.Implementation == gatewayAPIIstio && m.Metric == gatewayAPIMetricInFlightRequests {
+		return fmt.Errorf("%s isn't supported with %s", gatewayAPIMetricInFlightRequests, gatewayAPIIstio)
+	}
+	return nil
+}
+
+// NewGatewayAPIScaler creates a new gatewayAPIScaler
+func NewGatewayAPIScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	meta, err := parseGatewayAPIMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing gateway api metadata: %w", err)
+	}
+
+	// the remaining trigger metadata and the authentication are handed over to the prometheus scaler as is,
+	// its query is never run as the one of the route is built on each poll
+	prometheusConfig := *config
+	prometheusConfig.TriggerMetadata = maps.Clone(config.TriggerMetadata)
+	prometheusConfig.TriggerMetadata["query"] = meta.HTTPRoute
+	prometheusConfig.TriggerMetadata["threshold"] = strconv.FormatFloat(meta.Threshold, 'f', -1, 64)
+	prometheus, err := NewPrometheusScaler(&prometheusConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gatewayAPIScaler{
+		metadata:   meta,
+		kubeClient: kubeClient,
+		prometheus: prometheus.(*prometheusScaler),
+		logger:     InitializeLogger(config, "gateway_api_scaler"),
+	}, nil
+}
+
+func parseGatewayAPIMetadata(config *scalersconfig.ScalerConfig) (*gatewayAPIMetadata, error) {
+	meta := &gatewayAPIMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if meta.RouteNamespace == "" {
+		meta.RouteNamespace = config.ScalableObjectNamespace
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection of the prometheus scaler
+func (s *gatewayAPIScaler) Close(ctx context.Context) error {
+	return s.prometheus.Close(ctx)
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *gatewayAPIScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("gateway-api-%s-%s", s.metadata.HTTPRoute, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.prometheus.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the request rate or the requests in flight of the route
+func (s *gatewayAPIScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	route, err := s.resolveRoute(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error resolving httproute %s/%s: %w", s.metadata.RouteNamespace, s.metadata.HTTPRoute, err)
+	}
+
+	query := s.buildQuery(route)
+	value, err := s.prometheus.executeQuery(ctx, query)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error executing query %s: %w", query, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationThreshold, nil
+}
+
+// resolveRoute reads the route and returns its rules forwarding requests to the backend service, all of them if it isn't set
+func (s *gatewayAPIScaler) resolveRoute(ctx context.Context) (*gatewayAPIRoute, error) {
+	httpRoute := &unstructured.Unstructured{}
+	httpRoute.SetGroupVersionKind(httpRouteGVK)
+	if err := s.kubeClient.Get(ctx, client.ObjectKey{Namespace: s.metadata.RouteNamespace, Name: s.metadata.HTTPRoute}, httpRoute); err != nil {
+		return nil, err
+	}
+
+	route := &gatewayAPIRoute{}
+	parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+	for _, parentRef := range parentRefs {
+		if ref, ok := s.objectRef(parentRef, "Gateway"); ok {
+			route.gateways = append(route.gateways, ref)
+		}
+	}
+
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		backendRefs, _, _ := unstructured.NestedSlice(ruleMap, "backendRefs")
+		var backends []gatewayAPIRef
+		for _, backendRef := range backendRefs {
+			if backend, ok := s.objectRef(backendRef, "Service"); ok {
+				backends = append(backends, backend)
+			}
+		}
+		if s.metadata.BackendService != "" {
+			backends = slices.DeleteFunc(backends, func(backend gatewayAPIRef) bool {
+				return backend.name != s.metadata.BackendService
+			})
+		}
+		if len(backends) == 0 {
+			continue
+		}
+		route.rules = append(route.rules, i)
+		for _, backend := range backends {
+			if !slices.Contains(route.backends, backend) {
+				route.backends = append(route.backends, backend)
+			}
+		}
+	}
+
+	if len(route.rules) == 0 {
+		if s.metadata.BackendService != "" {
+			return nil, fmt.Errorf("no rule forwards requests to service %s", s.metadata.BackendService)
+		}
+		return nil, errors.New("no rule forwards requests to a service")
+	}
+	return route, nil
+}
+
+// objectRef returns the name and the namespace of the reference if it's of the kind, in the core group
+// for a Service or the Gateway API one for a Gateway. The namespace defaults to the one of the route.
+func (s *gatewayAPIScaler) objectRef(ref interface{}, kind string) (gatewayAPIRef, bool) {
+	refMap, ok := ref.(map[string]interface{})
+	if !ok {
+		return gatewayAPIRef{}, false
+	}
+	refKind, found, _ := unstructured.NestedString(refMap, "kind")
+	if !found {
+		refKind = kind
+	}
+	group, found, _ := unstructured.NestedString(refMap, "group")
+	if !found && kind == "Gateway" {
+		group = httpRouteGVK.Group
+	}
+	if refKind != kind || (kind == "Service" && group != "") || (kind == "Gateway" && group != httpRouteGVK.Group) {
+		return gatewayAPIRef{}, false
+	}
+	name, _, _ := unstructured.NestedString(refMap, "name")
+	namespace, found, _ := unstructured.NestedString(refMap, "namespace")
+	if !found || namespace == "" {
+		namespace = s.metadata.RouteNamespace
+	}
+	return gatewayAPIRef{name: name, namespace: namespace}, name != ""
+}
+
+// buildQuery returns the query of the metric for the route:
+//   - envoy gateway names the clusters of envoy after the rules of the routes, httproute/<namespace>/<name>/rule/<index>
+//   - istio reports the requests the gateways, deployed as <gateway>-istio, send to the backends
+func (s *gatewayAPIScaler) buildQuery(route *gatewayAPIRoute) string {
+	if s.metadata.Implementation == gatewayAPIIstio {
+		var gateways, gatewayNamespaces, backends, backendNamespaces []string
+		for _, gateway := range route.gateways {
+			gateways = append(gateways, regexp.QuoteMeta(gateway.name+"-istio"))
+			gatewayNamespaces = append(gatewayNamespaces, regexp.QuoteMeta(gateway.namespace))
+		}
+		for _, backend := range route.backends {
+			backends = append(backends, regexp.QuoteMeta(backend.name))
+			backendNamespaces = append(backendNamespaces, regexp.QuoteMeta(backend.namespace))
+		}
+		for _, values := range []*[]string{&gateways, &gatewayNamespaces, &backends, &backendNamespaces} {
+			slices.Sort(*values)
+			*values = slices.Compact(*values)
+		}
+		labels := []string{`reporter="source"`}
+		if len(gateways) > 0 {
+			labels = append(labels,
+				fmt.Sprintf("source_workload=~%q", strings.Join(gateways, "|")),
+				fmt.Sprintf("source_workload_namespace=~%q", strings.Join(gatewayNamespaces, "|")))
+		}
+		labels = append(labels,
+			fmt.Sprintf("destination_service_name=~%q", strings.Join(backends, "|")),
+			fmt.Sprintf("destination_service_namespace=~%q", strings.Join(backendNamespaces, "|")))
+		return fmt.Sprintf("sum(rate(istio_requests_total{%s}[%s]))", strings.Join(labels, ","), s.metadata.Window)
+	}
+
+	rules := make([]string, len(route.rules))
+	for i, rule := range route.rules {
+		rules[i] = strconv.Itoa(rule)
+	}
+	cluster := fmt.Sprintf("%s/(%s)", regexp.QuoteMeta(fmt.Sprintf("httproute/%s/%s/rule", s.metadata.RouteNamespace, s.metadata.HTTPRoute)), strings.Join(rules, "|"))
+	if s.metadata.Metric == gatewayAPIMetricInFlightRequests {
+		return fmt.Sprintf("sum(envoy_cluster_upstream_rq_active{envoy_cluster_name=~%q})", cluster)
+	}
+	return fmt.Sprintf("sum(rate(envoy_cluster_upstream_rq_total{envoy_cluster_name=~%q}[%s]))", cluster, s.metadata.Window)
+}
@@ -0,0 +1,173 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseGatewayAPIMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type gatewayAPIMetricIdentifier struct {
+	metadataTestData *parseGatewayAPIMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGatewayAPIMetadata = []parseGatewayAPIMetadataTestData{
+	{map[string]string{}, true, "nothing passed"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "envoy-gateway", "threshold": "100"}, false, "envoy gateway"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "routeNamespace": "web", "backendService": "cart", "implementation": "envoy-gateway", "metric": "inFlightRequests", "threshold": "10"}, false, "in flight requests of a backend"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "istio", "window": "5m", "threshold": "100"}, false, "istio"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "implementation": "istio", "threshold": "100"}, true, "no route"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "threshold": "100"}, true, "no implementation"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "contour", "threshold": "100"}, true, "unknown implementation"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "istio", "metric": "inFlightRequests", "threshold": "10"}, true, "in flight requests with istio"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "istio", "window": "0s", "threshold": "100"}, true, "zero window"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "httpRoute": "shop", "implementation": "istio"}, true, "no threshold"},
+}
+
+var gatewayAPIMetricIdentifiers = []gatewayAPIMetricIdentifier{
+	{&testGatewayAPIMetadata[1], 0, "s0-gateway-api-shop-requestRate"},
+	{&testGatewayAPIMetadata[2], 1, "s1-gateway-api-shop-inFlightRequests"},
+}
+
+func TestGatewayAPIParseMetadata(t *testing.T) {
+	for _, testData := range testGatewayAPIMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseGatewayAPIMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestGatewayAPIGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gatewayAPIMetricIdentifiers {
+		s, err := NewGatewayAPIScaler(nil, &scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "default", TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not create scaler:", err)
+		}
+
+		metricSpec := s.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func createHTTPRoute(name, namespace string, parentRefs []interface{}, rules ...[]interface{}) client.Object {
+	ruleList := make([]interface{}, len(rules))
+	for i, backendRefs := range rules {
+		ruleList[i] = map[string]interface{}{"backendRefs": backendRefs}
+	}
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"parentRefs": parentRefs, "rules": ruleList},
+	}}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(name)
+	route.SetNamespace(namespace)
+	return route
+}
+
+func TestGatewayAPIGetMetricsAndActivity(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createHTTPRoute("shop", "default",
+			[]interface{}{
+				map[string]interface{}{"name": "public", "namespace": "gateways"},
+				map[string]interface{}{"name": "mesh", "kind": "Service", "group": ""},
+			},
+			[]interface{}{map[string]interface{}{"name": "catalog", "port": int64(80)}},
+			[]interface{}{
+				map[string]interface{}{"name": "cart", "port": int64(8080), "weight": int64(90)},
+				map[string]interface{}{"name": "cart-canary", "port": int64(8080), "weight": int64(10)},
+			},
+			[]interface{}{map[string]interface{}{"name": "static", "kind": "Bucket", "group": "storage.example.com"}},
+		),
+	).Build()
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"12"]}]}}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		query    string
+		err      string
+	}{
+		{
+			"request rate of the route with envoy gateway",
+			map[string]string{"implementation": "envoy-gateway"},
+			`sum(rate(envoy_cluster_upstream_rq_total{envoy_cluster_name=~"httproute/default/shop/rule/(0|1)"}[1m]))`,
+			"",
+		},
+		{
+			"in flight requests of a backend with envoy gateway",
+			map[string]string{"implementation": "envoy-gateway", "backendService": "cart", "metric": "inFlightRequests"},
+			`sum(envoy_cluster_upstream_rq_active{envoy_cluster_name=~"httproute/default/shop/rule/(1)"})`,
+			"",
+		},
+		{
+			"request rate of the route with istio",
+			map[string]string{"implementation": "istio", "window": "5m"},
+			`sum(rate(istio_requests_total{reporter="source",source_workload=~"public-istio",source_workload_namespace=~"gateways",destination_service_name=~"cart|cart-canary|catalog",destination_service_namespace=~"default"}[5m]))`,
+			"",
+		},
+		{
+			"backend without rule",
+			map[string]string{"implementation": "envoy-gateway", "backendService": "static"},
+			"",
+			"no rule forwards requests to service static",
+		},
+		{
+			"missing route",
+			map[string]string{"implementation": "envoy-gateway", "httpRoute": "missing"},
+			"",
+			"not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["serverAddress"] = server.URL
+			tc.metadata["threshold"] = "10"
+			if tc.metadata["httpRoute"] == "" {
+				tc.metadata["httpRoute"] = "shop"
+			}
+			s, err := NewGatewayAPIScaler(kubeClient, &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, ScalableObjectNamespace: "default"})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "gateway-api")
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.query, query)
+			assert.True(t, isActive)
+			assert.Equal(t, int64(12), metrics[0].Value.Value())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+const (
+	bigQueryEndpoint = "https://bigquery.googleapis.com/bigquery/v2"
+	// bigQueryWaitTimeoutMs is how long BigQuery waits for the query to complete before responding
+	bigQueryWaitTimeoutMs = 10000
+)
+
+// BigQueryClient runs queries with the REST API of BigQuery
+type BigQueryClient struct {
+	httpClient *http.Client
+	endpoint   string
+	projectID  string
+	location   string
+}
+
+type bigQueryParameter struct {
+	Name          string `json:"name"`
+	ParameterType struct {
+		Type string `json:"type"`
+	} `json:"parameterType"`
+	ParameterValue struct {
+		Value string `json:"value"`
+	} `json:"parameterValue"`
+}
+
+type bigQueryQueryRequest struct {
+	Query           string              `json:"query"`
+	UseLegacySQL    bool                `json:"useLegacySql"`
+	Location        string              `json:"location,omitempty"`
+	ParameterMode   string              `json:"parameterMode,omitempty"`
+	QueryParameters []bigQueryParameter `json:"queryParameters,omitempty"`
+	MaxResults      int                 `json:"maxResults"`
+	TimeoutMs       int                 `json:"timeoutMs"`
+}
+
+type bigQueryQueryResponse struct {
+	JobComplete  bool `json:"jobComplete"`
+	JobReference struct {
+		JobID    string `json:"jobId"`
+		Location string `json:"location"`
+	} `json:"jobReference"`
+	Rows []struct {
+		F []struct {
+			V *string `json:"v"`
+		} `json:"f"`
+	} `json:"rows"`
+}
+
+type bigQueryErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewBigQueryClient creates a new BigQuery client running the queries in the project and location,
+// the http client is expected to authenticate the requests
+func NewBigQueryClient(httpClient *http.Client, projectID, location string) *BigQueryClient {
+	return &BigQueryClient{
+		httpClient: httpClient,
+		endpoint:   bigQueryEndpoint,
+		projectID:  projectID,
+		location:   location,
+	}
+}
+
+// QueryFloat64 runs the GoogleSQL query with the named string parameters and returns the first column of its
+// first row, NULL being returned as 0. The results are polled until the query completes or the context is done.
+func (c *BigQueryClient) QueryFloat64(ctx context.Context, query string, parameters map[string]string) (float64, error) {
+	request := bigQueryQueryRequest{
+		Query:      query,
+		Location:   c.location,
+		MaxResults: 1,
+		TimeoutMs:  bigQueryWaitTimeoutMs,
+	}
+	if len(parameters) > 0 {
+		request.ParameterMode = "NAMED"
+		names := make([]string, 0, len(parameters))
+		for name := range parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			parameter := bigQueryParameter{Name: name}
+			parameter.ParameterType.Type = "STRING"
+			parameter.ParameterValue.Value = parameters[name]
+			request.QueryParameters = append(request.QueryParameters, parameter)
+		}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+
+	response := &bigQueryQueryResponse{}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/queries", url.PathEscape(c.projectID)), body, response); err != nil {
+		return 0, err
+	}
+	for !response.JobComplete {
+		params := url.Values{}
+		params.Set("location", response.JobReference.Location)
+		params.Set("maxResults", "1")
+		params.Set("timeoutMs", strconv.Itoa(bigQueryWaitTimeoutMs))
+		path := fmt.Sprintf("/projects/%s/queries/%s?%s", url.PathEscape(c.projectID), url.PathEscape(response.JobReference.JobID), params.Encode())
+		response = &bigQueryQueryResponse{}
+		if err := c.do(ctx, http.MethodGet, path, nil, response); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(response.Rows) == 0 || len(response.Rows[0].F) == 0 {
+		return 0, errors.New("query returned no row")
+	}
+	value := response.Rows[0].F[0].V
+	if value == nil {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query returned %q instead of a number: %w", *value, err)
+	}
+	return number, nil
+}
+
+func (c *BigQueryClient) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse bigQueryErrorResponse
+		if json.Unmarshal(respBody, &errorResponse) == nil && errorResponse.Error.Message != "" {
+			return fmt.Errorf("unexpected status code %d from bigquery: %s", resp.StatusCode, errorResponse.Error.Message)
+		}
+		return fmt.Errorf("unexpected status code %d from bigquery: %s", resp.StatusCode, string(respBody))
+	}
+	return json.Unmarshal(respBody, v)
+}
+
+// Close closes the idle connections of the client
+func (c *BigQueryClient) Close() {
+	c.httpClient.CloseIdleConnections()
+}
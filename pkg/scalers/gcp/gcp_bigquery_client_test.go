@@ -0,0 +1,57 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigQueryQueryFloat64(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/my-project/queries":
+			var request bigQueryQueryRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "EU", request.Location)
+			assert.False(t, request.UseLegacySQL)
+			switch request.Query {
+			case "SELECT COUNT(*) FROM tasks WHERE state = @state":
+				assert.Equal(t, "NAMED", request.ParameterMode)
+				assert.Len(t, request.QueryParameters, 1)
+				assert.Equal(t, "state", request.QueryParameters[0].Name)
+				assert.Equal(t, "pending", request.QueryParameters[0].ParameterValue.Value)
+				_, _ = w.Write([]byte(`{"jobComplete":false,"jobReference":{"projectId":"my-project","jobId":"job_1","location":"EU"}}`))
+			case "SELECT MAX(lag) FROM lags":
+				_, _ = w.Write([]byte(`{"jobComplete":true,"jobReference":{"jobId":"job_2","location":"EU"},"rows":[{"f":[{"v":null}]}]}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"code":400,"message":"Unrecognized name: missing at [1:8]","status":"INVALID_ARGUMENT"}}`))
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/my-project/queries/job_1":
+			assert.Equal(t, "EU", r.URL.Query().Get("location"))
+			_, _ = w.Write([]byte(`{"jobComplete":true,"jobReference":{"jobId":"job_1","location":"EU"},"rows":[{"f":[{"v":"23"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewBigQueryClient(server.Client(), "my-project", "EU")
+	client.endpoint = server.URL
+
+	value, err := client.QueryFloat64(context.Background(), "SELECT COUNT(*) FROM tasks WHERE state = @state", map[string]string{"state": "pending"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(23), value)
+
+	value, err = client.QueryFloat64(context.Background(), "SELECT MAX(lag) FROM lags", nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), value)
+
+	_, err = client.QueryFloat64(context.Background(), "SELECT missing", nil)
+	assert.ErrorContains(t, err, "Unrecognized name")
+}
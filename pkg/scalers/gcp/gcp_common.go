@@ -15,6 +15,7 @@ import (
 
 var (
 	GcpScopeMonitoringRead = "https://www.googleapis.com/auth/monitoring.read"
+	GcpScopeBigQuery       = "https://www.googleapis.com/auth/bigquery"
 
 	ErrGoogleApplicationCrendentialsNotFound = errors.New("google application credentials not found")
 )
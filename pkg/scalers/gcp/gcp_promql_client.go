@@ -0,0 +1,122 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// defaultMonitoringEndpoint is the endpoint of the Cloud Monitoring API serving the Prometheus HTTP API
+const defaultMonitoringEndpoint = "https://monitoring.googleapis.com"
+
+// PromQLClient queries Cloud Monitoring with PromQL, through the Prometheus HTTP API of the Monitoring API
+type PromQLClient struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+type promQLQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// NewPromQLClient creates a new PromQL client sending the queries with the http client, which is expected to
+// authenticate them with the monitoring.read scope. The endpoint defaults to the one of the Monitoring API.
+func NewPromQLClient(httpClient *http.Client, endpoint string) *PromQLClient {
+	if endpoint == "" {
+		endpoint = defaultMonitoringEndpoint
+	}
+	return &PromQLClient{httpClient: httpClient, endpoint: endpoint}
+}
+
+// QueryMetrics evaluates the PromQL query at the current time in the project. The query must return a scalar or a
+// single time series, ratios and aggregations of the metrics being written in the query itself.
+func (c *PromQLClient) QueryMetrics(ctx context.Context, projectID, query string, valueIfNull *float64) (float64, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", time.Now().UTC().Format(time.RFC3339))
+	u := fmt.Sprintf("%s/v1/projects/%s/location/global/prometheus/api/v1/query?%s", c.endpoint, url.PathEscape(projectID), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return -1, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return -1, err
+	}
+
+	var response promQLQueryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return -1, fmt.Errorf("promql query api returned error. status: %d response: %s", resp.StatusCode, string(body))
+		}
+		return -1, err
+	}
+	if response.Status != "success" {
+		return -1, fmt.Errorf("promql query api returned error. status: %d error: %s %s", resp.StatusCode, response.ErrorType, response.Error)
+	}
+
+	var sample []interface{}
+	switch response.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(response.Data.Result, &sample); err != nil {
+			return -1, err
+		}
+	case "vector":
+		var series []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(response.Data.Result, &series); err != nil {
+			return -1, err
+		}
+		if len(series) > 1 {
+			return -1, fmt.Errorf("promql query %s returned %d time series instead of one", query, len(series))
+		}
+		if len(series) == 1 {
+			sample = series[0].Value
+		}
+	default:
+		return -1, fmt.Errorf("promql query %s returned a %s, only a scalar or an instant vector is supported", query, response.Data.ResultType)
+	}
+
+	if len(sample) < 2 {
+		if valueIfNull == nil {
+			return -1, fmt.Errorf("could not find stackdriver metric with promql query %s", query)
+		}
+		return *valueIfNull, nil
+	}
+
+	str, ok := sample[1].(string)
+	if !ok {
+		return -1, fmt.Errorf("promql query %s returned an invalid value %v", query, sample[1])
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return -1, err
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		if valueIfNull == nil {
+			return -1, fmt.Errorf("promql query %s returned %s", query, str)
+		}
+		return *valueIfNull, nil
+	}
+	return value, nil
+}
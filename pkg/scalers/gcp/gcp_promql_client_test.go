@@ -0,0 +1,53 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromQLClientQueryMetrics(t *testing.T) {
+	valueIfNull := 3.0
+	for _, tc := range []struct {
+		name        string
+		status      int
+		response    string
+		valueIfNull *float64
+
+		expected float64
+		isError  bool
+	}{
+		{"single time series", http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.25"]}]}}`, nil, 0.25, false},
+		{"scalar", http.StatusOK, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"42"]}}`, nil, 42, false},
+		{"no time series", http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`, nil, 0, true},
+		{"no time series with valueIfNull", http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`, &valueIfNull, 3, false},
+		{"NaN ratio with valueIfNull", http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"NaN"]}]}}`, &valueIfNull, 3, false},
+		{"several time series", http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"a":"1"},"value":[1700000000,"1"]},{"metric":{"a":"2"},"value":[1700000000,"2"]}]}}`, nil, 0, true},
+		{"range vector", http.StatusOK, `{"status":"success","data":{"resultType":"matrix","result":[]}}`, nil, 0, true},
+		{"invalid query", http.StatusBadRequest, `{"status":"error","errorType":"bad_data","error":"parse error"}`, nil, 0, true},
+		{"forbidden", http.StatusForbidden, `forbidden`, nil, 0, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/projects/myproject/location/global/prometheus/api/v1/query", r.URL.Path)
+				assert.Equal(t, "sum(up)", r.URL.Query().Get("query"))
+				assert.True(t, r.URL.Query().Has("time"))
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			client := NewPromQLClient(server.Client(), server.URL)
+			value, err := client.QueryMetrics(context.Background(), "myproject", "sum(up)", tc.valueIfNull)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
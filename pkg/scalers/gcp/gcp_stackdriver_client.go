@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
@@ -16,6 +17,7 @@ import (
 	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
 	"google.golang.org/api/iterator"
 	option "google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/api/distribution"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -48,6 +50,10 @@ const (
 	aggregationSum        = "sum"
 	aggregationCount      = "count"
 	aggregationPercentile = "percentile"
+
+	// CombinationSum and CombinationMax combine the values of the resources whose names match a pattern
+	CombinationSum = "sum"
+	CombinationMax = "max"
 )
 
 // StackDriverClient is a generic client to fetch metrics from Stackdriver. Can be used
@@ -114,6 +120,26 @@ func NewStackDriverClientPodIdentity(ctx context.Context) (*StackDriverClient, e
 	}, nil
 }
 
+// NewStackDriverClientWithOptions creates a new stackdriver client using the given client options,
+// it's useful when the endpoint or the authentication has to be overridden
+func NewStackDriverClientWithOptions(ctx context.Context, projectID string, opts ...option.ClientOption) (*StackDriverClient, error) {
+	metricsClient, err := monitoring.NewMetricClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	queryClient, err := monitoring.NewQueryClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StackDriverClient{
+		metricsClient: metricsClient,
+		queryClient:   queryClient,
+		projectID:     projectID,
+	}, nil
+}
+
 func NewStackdriverAggregator(period int64, aligner string, reducer string) (*monitoringpb.Aggregation, error) {
 	sdAggregation := monitoringpb.Aggregation{
 		AlignmentPeriod: &durationpb.Duration{
@@ -226,31 +252,7 @@ func (s StackDriverClient) GetMetrics(
 	aggregation *monitoringpb.Aggregation,
 	valueIfNull *float64,
 	filterDuration int64) (float64, error) {
-	// Set the start time (default 2 minute ago)
-	if filterDuration <= 0 {
-		filterDuration = 2
-	}
-	startTime := time.Now().UTC().Add(time.Minute * -time.Duration(filterDuration))
-
-	// Set the end time to now
-	endTime := time.Now().UTC()
-
-	// Create a request with the filter and the GCP project ID
-	var req = &monitoringpb.ListTimeSeriesRequest{
-		Interval: &monitoringpb.TimeInterval{
-			StartTime: &timestamppb.Timestamp{Seconds: startTime.Unix()},
-			EndTime:   &timestamppb.Timestamp{Seconds: endTime.Unix()},
-		},
-		Aggregation: aggregation,
-	}
-
-	// Set project to perform request in and update filter with project_id
-	pid := getActualProjectID(&s, projectID)
-	req.Name = "projects/" + pid
-	filter += ` AND resource.labels.project_id="` + pid + `"`
-
-	// Set filter on request
-	req.Filter = filter
+	req := s.newListTimeSeriesRequest(filter, projectID, aggregation, filterDuration)
 
 	// Get an iterator with the list of time series
 	it := s.metricsClient.ListTimeSeries(ctx, req)
@@ -262,7 +264,7 @@ func (s StackDriverClient) GetMetrics(
 
 	if err == iterator.Done {
 		if valueIfNull == nil {
-			return value, fmt.Errorf("could not find stackdriver metric with filter %s", filter)
+			return value, fmt.Errorf("could not find stackdriver metric with filter %s", req.Filter)
 		}
 		return *valueIfNull, nil
 	}
@@ -283,6 +285,65 @@ func (s StackDriverClient) GetMetrics(
 	return value, nil
 }
 
+// GetDistribution fetches the latest point of a distribution metric from stackdriver for a specific filter,
+// it's returned with the unit of the metric
+func (s StackDriverClient) GetDistribution(
+	ctx context.Context,
+	filter string,
+	projectID string,
+	aggregation *monitoringpb.Aggregation,
+	filterDuration int64) (*distribution.Distribution, string, error) {
+	req := s.newListTimeSeriesRequest(filter, projectID, aggregation, filterDuration)
+
+	resp, err := s.metricsClient.ListTimeSeries(ctx, req).Next()
+	if err == iterator.Done {
+		return nil, "", fmt.Errorf("could not find stackdriver metric with filter %s", req.Filter)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(resp.GetPoints()) == 0 {
+		return nil, "", fmt.Errorf("no points found for stackdriver metric with filter %s", req.Filter)
+	}
+	dist := resp.GetPoints()[0].GetValue().GetDistributionValue()
+	if dist == nil {
+		return nil, "", fmt.Errorf("stackdriver metric with filter %s is not a distribution", req.Filter)
+	}
+	return dist, resp.GetUnit(), nil
+}
+
+// newListTimeSeriesRequest creates a request listing the time series matching the filter
+// in the project for the last filterDuration minutes (default 2 minutes)
+func (s StackDriverClient) newListTimeSeriesRequest(filter string, projectID string, aggregation *monitoringpb.Aggregation, filterDuration int64) *monitoringpb.ListTimeSeriesRequest {
+	// Set the start time (default 2 minute ago)
+	if filterDuration <= 0 {
+		filterDuration = 2
+	}
+	startTime := time.Now().UTC().Add(time.Minute * -time.Duration(filterDuration))
+
+	// Set the end time to now
+	endTime := time.Now().UTC()
+
+	// Create a request with the filter and the GCP project ID
+	var req = &monitoringpb.ListTimeSeriesRequest{
+		Interval: &monitoringpb.TimeInterval{
+			StartTime: &timestamppb.Timestamp{Seconds: startTime.Unix()},
+			EndTime:   &timestamppb.Timestamp{Seconds: endTime.Unix()},
+		},
+		Aggregation: aggregation,
+	}
+
+	// Set project to perform request in and update filter with project_id
+	pid := getActualProjectID(&s, projectID)
+	req.Name = "projects/" + pid
+	filter += ` AND resource.labels.project_id="` + pid + `"`
+
+	// Set filter on request
+	req.Filter = filter
+	return req
+}
+
 // QueryMetrics fetches metrics from the Cloud Monitoring API
 // for a specific Monitoring Query Language (MQL) query
 //
@@ -347,6 +408,41 @@ func getActualProjectID(s *StackDriverClient, projectID string) string {
 // | every 3m
 // | group_by [], count(value)
 func (s StackDriverClient) BuildMQLQuery(projectID, resourceType, metric, resourceName, aggregation, timeHorizon string) (string, error) {
+	filter := fmt.Sprintf("resource.%s_id == '%s'", resourceType, resourceName)
+	return s.buildMQLQuery(projectID, resourceType, metric, filter, aggregation, timeHorizon)
+}
+
+// BuildMQLPatternQuery builds a Monitoring Query Language (MQL) query like BuildMQLQuery for the resources whose
+// names match the regular expression, the values of which are combined with the sum or the max when there's no
+// aggregation
+//
+// example:
+// fetch pubsub_subscription
+// | metric 'pubsub.googleapis.com/subscription/num_undelivered_messages'
+// | filter (resource.project_id == 'myproject' && resource.subscription_id =~ 'orders-.*')
+// | within 2m
+// | group_by [], sum(val())
+func (s StackDriverClient) BuildMQLPatternQuery(projectID, resourceType, metric, resourceNamePattern, aggregation, timeHorizon, combination string) (string, error) {
+	if strings.Contains(resourceNamePattern, "'") {
+		return "", fmt.Errorf("invalid resource name pattern: %s", resourceNamePattern)
+	}
+	if combination != CombinationSum && combination != CombinationMax {
+		return "", fmt.Errorf("unsupported combination function: %s", combination)
+	}
+
+	filter := fmt.Sprintf("resource.%s_id =~ '%s'", resourceType, resourceNamePattern)
+	q, err := s.buildMQLQuery(projectID, resourceType, metric, filter, aggregation, timeHorizon)
+	if err != nil {
+		return "", err
+	}
+	if aggregation == "" {
+		q += fmt.Sprintf(" | group_by [], %s(val())", combination)
+	}
+	return q, nil
+}
+
+// buildMQLQuery builds the query of the metric of the resources of the filter
+func (s StackDriverClient) buildMQLQuery(projectID, resourceType, metric, filter, aggregation, timeHorizon string) (string, error) {
 	th := timeHorizon
 	if th == "" {
 		th = defaultTimeHorizon
@@ -357,8 +453,8 @@ func (s StackDriverClient) BuildMQLQuery(projectID, resourceType, metric, resour
 
 	pid := getActualProjectID(&s, projectID)
 	q := fmt.Sprintf(
-		"fetch pubsub_%s | metric '%s' | filter (resource.project_id == '%s' && resource.%s_id == '%s') | within %s",
-		resourceType, metric, pid, resourceType, resourceName, th,
+		"fetch pubsub_%s | metric '%s' | filter (resource.project_id == '%s' && %s) | within %s",
+		resourceType, metric, pid, filter, th,
 	)
 	if aggregation != "" {
 		agg, err := buildAggregation(aggregation)
@@ -440,6 +536,75 @@ func extractValueFromPointData(point *monitoringpb.TimeSeriesData_PointData) (fl
 	return -1, fmt.Errorf("could not extract value from metric of type %T", typedValue)
 }
 
+// DistributionPercentile estimates the percentile (between 0 and 100) of the values of a distribution
+// by linear interpolation within the bucket holding it
+func DistributionPercentile(dist *distribution.Distribution, percentile float64) (float64, error) {
+	if percentile < 0 || percentile > 100 {
+		return -1, fmt.Errorf("invalid percentile value: %v", percentile)
+	}
+	if dist.GetCount() == 0 {
+		return 0, nil
+	}
+
+	rank := percentile / 100 * float64(dist.GetCount())
+	var cumulative int64
+	for i, count := range dist.GetBucketCounts() {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+		lower, upper, err := bucketBounds(dist.GetBucketOptions(), i)
+		if err != nil {
+			return -1, err
+		}
+		// the underflow and overflow buckets are unbounded, their finite bound is the best estimate
+		switch {
+		case math.IsInf(lower, -1):
+			return upper, nil
+		case math.IsInf(upper, 1):
+			return lower, nil
+		}
+		fraction := (rank - float64(cumulative)) / float64(count)
+		return lower + (upper-lower)*fraction, nil
+	}
+	return -1, fmt.Errorf("bucket counts of the distribution don't add up to its count %d", dist.GetCount())
+}
+
+// bucketBounds returns the lower and upper bounds of the bucket at index i, index 0 is the underflow bucket
+// and index numFiniteBuckets+1 is the overflow bucket
+func bucketBounds(options *distribution.Distribution_BucketOptions, i int) (float64, float64, error) {
+	var bound func(int) float64
+	var numFiniteBuckets int
+	switch {
+	case options.GetLinearBuckets() != nil:
+		linear := options.GetLinearBuckets()
+		numFiniteBuckets = int(linear.GetNumFiniteBuckets())
+		bound = func(j int) float64 { return linear.GetOffset() + linear.GetWidth()*float64(j) }
+	case options.GetExponentialBuckets() != nil:
+		exponential := options.GetExponentialBuckets()
+		numFiniteBuckets = int(exponential.GetNumFiniteBuckets())
+		bound = func(j int) float64 {
+			return exponential.GetScale() * math.Pow(exponential.GetGrowthFactor(), float64(j))
+		}
+	case options.GetExplicitBuckets() != nil:
+		bounds := options.GetExplicitBuckets().GetBounds()
+		numFiniteBuckets = len(bounds) - 1
+		bound = func(j int) float64 { return bounds[j] }
+	default:
+		return 0, 0, fmt.Errorf("distribution doesn't have bucket options")
+	}
+
+	switch {
+	case i > numFiniteBuckets+1:
+		return 0, 0, fmt.Errorf("bucket %d is out of the %d buckets of the distribution", i, numFiniteBuckets+2)
+	case i == 0:
+		return math.Inf(-1), bound(0), nil
+	case i == numFiniteBuckets+1:
+		return bound(numFiniteBuckets), math.Inf(1), nil
+	}
+	return bound(i - 1), bound(i), nil
+}
+
 // GoogleApplicationCredentials is a struct representing the format of a service account
 // credentials file
 type GoogleApplicationCredentials struct {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/api/distribution"
 )
 
 func TestBuildMQLQuery(t *testing.T) {
@@ -73,6 +74,64 @@ func TestBuildMQLQuery(t *testing.T) {
 	}
 }
 
+func TestBuildMQLPatternQuery(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		pattern     string
+		aggregation string
+		combination string
+
+		expected string
+		isError  bool
+	}{
+		{
+			"sum of subscriptions",
+			"orders-.*", "", "sum",
+			"fetch pubsub_subscription | metric 'pubsub.googleapis.com/subscription/x' | filter (resource.project_id == 'myproject' && resource.subscription_id =~ 'orders-.*')" +
+				" | within 2m | group_by [], sum(val())",
+			false,
+		},
+		{
+			"max of subscriptions",
+			"orders-.*", "", "max",
+			"fetch pubsub_subscription | metric 'pubsub.googleapis.com/subscription/x' | filter (resource.project_id == 'myproject' && resource.subscription_id =~ 'orders-.*')" +
+				" | within 2m | group_by [], max(val())",
+			false,
+		},
+		{
+			"subscriptions with aggregation",
+			"orders-.*", "count", "sum",
+			"fetch pubsub_subscription | metric 'pubsub.googleapis.com/subscription/x' | filter (resource.project_id == 'myproject' && resource.subscription_id =~ 'orders-.*')" +
+				" | within 5m | align delta(3m) | every 3m | group_by [], count(value)",
+			false,
+		},
+		{
+			"quote in pattern",
+			"orders' || true", "", "sum",
+			"invalid resource name pattern: orders' || true",
+			true,
+		},
+		{
+			"unsupported combination function",
+			"orders-.*", "", "mean",
+			"unsupported combination function: mean",
+			true,
+		},
+	} {
+		s := &StackDriverClient{}
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := s.BuildMQLPatternQuery("myproject", "subscription", "pubsub.googleapis.com/subscription/x", tc.pattern, tc.aggregation, "", tc.combination)
+			if tc.isError {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expected, err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, q)
+			}
+		})
+	}
+}
+
 func TestGetActualProjectID(t *testing.T) {
 	// There are three ways to get projectID
 	// This is ordered from highest priority to lowest priority
@@ -158,3 +217,43 @@ func TestGetActualProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestDistributionPercentile(t *testing.T) {
+	linear := &distribution.Distribution_BucketOptions{
+		Options: &distribution.Distribution_BucketOptions_LinearBuckets{
+			LinearBuckets: &distribution.Distribution_BucketOptions_Linear{NumFiniteBuckets: 4, Width: 10, Offset: 0},
+		},
+	}
+	exponential := &distribution.Distribution_BucketOptions{
+		Options: &distribution.Distribution_BucketOptions_ExponentialBuckets{
+			ExponentialBuckets: &distribution.Distribution_BucketOptions_Exponential{NumFiniteBuckets: 3, GrowthFactor: 2, Scale: 1},
+		},
+	}
+
+	for _, tc := range []struct {
+		name         string
+		distribution *distribution.Distribution
+		percentile   float64
+
+		expected float64
+		isError  bool
+	}{
+		{"linear median", &distribution.Distribution{Count: 10, BucketOptions: linear, BucketCounts: []int64{0, 2, 6, 2}}, 50, 15, false},
+		{"linear p90", &distribution.Distribution{Count: 10, BucketOptions: linear, BucketCounts: []int64{0, 2, 6, 2}}, 90, 25, false},
+		{"overflow bucket", &distribution.Distribution{Count: 10, BucketOptions: linear, BucketCounts: []int64{0, 0, 0, 0, 0, 10}}, 99, 40, false},
+		{"exponential p75", &distribution.Distribution{Count: 4, BucketOptions: exponential, BucketCounts: []int64{0, 1, 1, 2}}, 75, 6, false},
+		{"empty distribution", &distribution.Distribution{BucketOptions: linear}, 99, 0, false},
+		{"counts don't add up", &distribution.Distribution{Count: 10, BucketOptions: linear, BucketCounts: []int64{0, 2}}, 99, 0, true},
+		{"invalid percentile", &distribution.Distribution{Count: 10, BucketOptions: linear, BucketCounts: []int64{0, 10}}, 101, 0, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := DistributionPercentile(tc.distribution, tc.percentile)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expected, value, 0.001)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	bigQueryModeQuery       = "query"
+	bigQueryModePendingJobs = "pendingJobs"
+
+	// bigQueryPendingJobsQuery counts the pending jobs of the project, only the jobs created the last day
+	// are scanned as the jobs views are partitioned by creation time
+	bigQueryPendingJobsQuery = "SELECT COUNT(*) FROM `%s`.`region-%s`.INFORMATION_SCHEMA.JOBS_BY_PROJECT " +
+		"WHERE state = 'PENDING' AND creation_time > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 1 DAY)"
+	bigQueryReservationFilter = " AND reservation_id = @reservation"
+)
+
+type gcpBigQueryScaler struct {
+	client     *gcp.BigQueryClient
+	metricType v2.MetricTargetType
+	metadata   *gcpBigQueryMetadata
+	logger     logr.Logger
+}
+
+type gcpBigQueryMetadata struct {
+	ProjectID       string            `keda:"name=projectID,       order=triggerMetadata"`
+	Location        string            `keda:"name=location,        order=triggerMetadata, optional, default=US"`
+	Mode            string            `keda:"name=mode,            order=triggerMetadata, enum=query;pendingJobs, optional, default=query"`
+	Query           string            `keda:"name=query,           order=triggerMetadata, optional"`
+	QueryParameters map[string]string `keda:"name=queryParameters, order=triggerMetadata, optional"`
+	// Reservation is the id of the reservation of the pending jobs, e.g. my-project:US.etl
+	Reservation     string  `keda:"name=reservation,     order=triggerMetadata, optional"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional"`
+
+	triggerIndex int
+}
+
+func (m *gcpBigQueryMetadata) Validate() error {
+	if m.Value <= 0 {
+		return errors.New("value must be greater than 0")
+	}
+	switch m.Mode {
+	case bigQueryModeQuery:
+		if m.Query == "" {
+			return errors.New("query must be provided in query mode")
+		}
+		if m.Reservation != "" {
+			return errors.New("reservation can only be used in pendingJobs mode")
+		}
+	case bigQueryModePendingJobs:
+		if m.Query != "" || len(m.QueryParameters) > 0 {
+			return errors.New("query and queryParameters can't be used in pendingJobs mode")
+		}
+		m.Query = fmt.Sprintf(bigQueryPendingJobsQuery, m.ProjectID, strings.ToLower(m.Location))
+		if m.Reservation != "" {
+			m.Query += bigQueryReservationFilter
+			m.QueryParameters = map[string]string{"reservation": m.Reservation}
+		}
+	}
+	return nil
+}
+
+// NewGcpBigQueryScaler creates a new gcpBigQueryScaler
+func NewGcpBigQueryScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGcpBigQueryMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing BigQuery metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	if httpClient.Transport, err = gcp.GetGCPOAuth2HTTPTransport(config, httpClient.Transport, gcp.GcpScopeBigQuery); err != nil {
+		return nil, fmt.Errorf("error getting GCP authorization: %w", err)
+	}
+
+	return &gcpBigQueryScaler{
+		client:     gcp.NewBigQueryClient(httpClient, meta.ProjectID, meta.Location),
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "gcp_bigquery_scaler"),
+	}, nil
+}
+
+func parseGcpBigQueryMetadata(config *scalersconfig.ScalerConfig) (*gcpBigQueryMetadata, error) {
+	meta := &gcpBigQueryMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *gcpBigQueryScaler) Close(context.Context) error {
+	if s.client != nil {
+		s.client.Close()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *gcpBigQueryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-bigquery-%s-%s", s.metadata.ProjectID, s.metadata.Mode))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity runs the query, or counts the pending jobs, and returns its result
+func (s *gcpBigQueryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.client.QueryFloat64(ctx, s.metadata.Query, s.metadata.QueryParameters)
+	if err != nil {
+		s.logger.Error(err, "error running BigQuery query", "mode", s.metadata.Mode)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
@@ -0,0 +1,87 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testGcpBigQueryResolvedEnv = map[string]string{
+	"SAMPLE_CREDS": "{}",
+}
+
+type parseGcpBigQueryMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type gcpBigQueryMetricIdentifier struct {
+	metadataTestData *parseGcpBigQueryMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGcpBigQueryMetadata = []parseGcpBigQueryMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"projectID": "my-project", "query": "SELECT COUNT(*) FROM etl.tasks WHERE state = @state", "queryParameters": "state=pending", "value": "10", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, false, "parameterized query"},
+	{map[string]string{"projectID": "my-project", "location": "EU", "mode": "pendingJobs", "reservation": "my-project:EU.etl", "value": "5", "activationValue": "1"}, map[string]string{"GoogleApplicationCredentials": "{}"}, false, "pending jobs of a reservation"},
+	{map[string]string{"projectID": "my-project", "value": "10"}, map[string]string{}, true, "missing query"},
+	{map[string]string{"projectID": "my-project", "query": "SELECT 1"}, map[string]string{}, true, "missing value"},
+	{map[string]string{"projectID": "my-project", "mode": "pendingJobs", "query": "SELECT 1", "value": "10"}, map[string]string{}, true, "query in pendingJobs mode"},
+	{map[string]string{"projectID": "my-project", "query": "SELECT 1", "reservation": "my-project:US.etl", "value": "10"}, map[string]string{}, true, "reservation in query mode"},
+	{map[string]string{"projectID": "my-project", "mode": "slots", "value": "10"}, map[string]string{}, true, "unknown mode"},
+}
+
+var gcpBigQueryMetricIdentifiers = []gcpBigQueryMetricIdentifier{
+	{&testGcpBigQueryMetadata[1], 0, "s0-gcp-bigquery-my-project-query"},
+	{&testGcpBigQueryMetadata[2], 1, "s1-gcp-bigquery-my-project-pendingJobs"},
+}
+
+func TestGcpBigQueryParseMetadata(t *testing.T) {
+	for _, testData := range testGcpBigQueryMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseGcpBigQueryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testGcpBigQueryResolvedEnv})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestGcpBigQueryParseMetadataPendingJobsQuery(t *testing.T) {
+	meta, err := parseGcpBigQueryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testGcpBigQueryMetadata[2].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT COUNT(*) FROM `my-project`.`region-eu`.INFORMATION_SCHEMA.JOBS_BY_PROJECT "+
+		"WHERE state = 'PENDING' AND creation_time > TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 1 DAY) AND reservation_id = @reservation", meta.Query)
+	assert.Equal(t, map[string]string{"reservation": "my-project:EU.etl"}, meta.QueryParameters)
+}
+
+func TestGcpBigQueryGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gcpBigQueryMetricIdentifiers {
+		meta, err := parseGcpBigQueryMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ResolvedEnv: testGcpBigQueryResolvedEnv, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGcpBigQueryScaler := gcpBigQueryScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockGcpBigQueryScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestGcpBigQueryMissingCredentials(t *testing.T) {
+	_, err := NewGcpBigQueryScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testGcpBigQueryMetadata[1].metadata})
+	assert.ErrorContains(t, err, "google application credentials not found")
+}
@@ -0,0 +1,193 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	pubSubLiteModeBacklogMessageCount = "BacklogMessageCount"
+	pubSubLiteModeBacklogBytes        = "BacklogBytes"
+)
+
+// pubSubLiteMetricTypes are the metrics of the partitions of a Lite subscription for each mode
+var pubSubLiteMetricTypes = map[string]string{
+	pubSubLiteModeBacklogMessageCount: "pubsublite.googleapis.com/subscription/backlog_message_count",
+	pubSubLiteModeBacklogBytes:        "pubsublite.googleapis.com/subscription/backlog_quota_bytes",
+}
+
+type pubSubLiteScaler struct {
+	client     *gcp.StackDriverClient
+	metricType v2.MetricTargetType
+	metadata   *pubSubLiteMetadata
+	logger     logr.Logger
+}
+
+type pubSubLiteMetadata struct {
+	// SubscriptionName is either the ID of the subscription or its full path projects/{project}/locations/{location}/subscriptions/{id}
+	SubscriptionName string `keda:"name=subscriptionName, order=triggerMetadata;resolvedEnv"`
+	ProjectID        string `keda:"name=projectID,        order=triggerMetadata, optional"`
+	Location         string `keda:"name=location,         order=triggerMetadata, optional"`
+	Mode             string `keda:"name=mode,             order=triggerMetadata, enum=BacklogMessageCount;BacklogBytes, optional, default=BacklogMessageCount"`
+	// Aggregation is how the backlogs of the partitions are combined, summed up or the largest one
+	Aggregation     string  `keda:"name=aggregation,     order=triggerMetadata, enum=sum;max, optional, default=sum"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, optional, default=10"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional, default=0"`
+	FilterDuration  int64   `keda:"name=filterDuration,  order=triggerMetadata, optional"`
+
+	subscriptionID   string
+	gcpAuthorization *gcp.AuthorizationMetadata
+	triggerIndex     int
+}
+
+func (m *pubSubLiteMetadata) Validate() error {
+	if m.Value <= 0 {
+		return fmt.Errorf("value must be greater than 0")
+	}
+
+	m.subscriptionID = m.SubscriptionName
+	if strings.HasPrefix(m.SubscriptionName, "projects/") {
+		parts := strings.Split(m.SubscriptionName, "/")
+		if len(parts) != 6 || parts[2] != "locations" || parts[4] != "subscriptions" {
+			return fmt.Errorf("subscriptionName must be of the form projects/{project}/locations/{location}/subscriptions/{id}, got %q", m.SubscriptionName)
+		}
+		if (m.ProjectID != "" && m.ProjectID != parts[1]) || (m.Location != "" && m.Location != parts[3]) {
+			return fmt.Errorf("projectID and location don't match the ones of subscriptionName %q", m.SubscriptionName)
+		}
+		m.ProjectID, m.Location, m.subscriptionID = parts[1], parts[3], parts[5]
+	}
+	return nil
+}
+
+// NewPubSubLiteScaler creates a new pubSubLiteScaler
+func NewPubSubLiteScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parsePubSubLiteMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Pub/Sub Lite metadata: %w", err)
+	}
+
+	return &pubSubLiteScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "gcp_pub_sub_lite_scaler"),
+	}, nil
+}
+
+func parsePubSubLiteMetadata(config *scalersconfig.ScalerConfig) (*pubSubLiteMetadata, error) {
+	meta := &pubSubLiteMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing Pub/Sub Lite metadata: %w", err)
+	}
+
+	auth, err := gcp.GetGCPAuthorization(config)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.gcpAuthorization = auth
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *pubSubLiteScaler) Close(context.Context) error {
+	if s.client != nil {
+		err := s.client.Close()
+		s.client = nil
+		if err != nil {
+			s.logger.Error(err, "error closing StackDriver client")
+		}
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *pubSubLiteScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-psl-%s", s.metadata.subscriptionID))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+
+	// Create the metric spec for the HPA
+	metricSpec := v2.MetricSpec{
+		External: externalMetric,
+		Type:     externalMetricType,
+	}
+
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity connects to Stack Driver and finds the backlog of the partitions of the subscription
+func (s *pubSubLiteScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	metricType := pubSubLiteMetricTypes[s.metadata.Mode]
+
+	value, err := s.getMetrics(ctx, metricType)
+	if err != nil {
+		s.logger.Error(err, "error getting metric", "metricType", metricType)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationValue, nil
+}
+
+func (s *pubSubLiteScaler) setStackdriverClient(ctx context.Context) error {
+	var client *gcp.StackDriverClient
+	var err error
+	if s.metadata.gcpAuthorization.PodIdentityProviderEnabled {
+		client, err = gcp.NewStackDriverClientPodIdentity(ctx)
+	} else {
+		client, err = gcp.NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
+	}
+
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+// buildFilter builds the filter of the time series of the partitions of the subscription
+func (s *pubSubLiteScaler) buildFilter(metricType string) string {
+	filter := `metric.type="` + metricType + `" AND resource.type="pubsublite_subscription_partition" AND resource.labels.subscription_id="` + s.metadata.subscriptionID + `"`
+	if s.metadata.Location != "" {
+		filter += ` AND resource.labels.location="` + s.metadata.Location + `"`
+	}
+	return filter
+}
+
+// getMetrics gets the backlog of the partitions from stackdriver api, the latest value
+// of each partition being combined with the aggregation
+func (s *pubSubLiteScaler) getMetrics(ctx context.Context, metricType string) (float64, error) {
+	if s.client == nil {
+		err := s.setStackdriverClient(ctx)
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	aggregation, err := gcp.NewStackdriverAggregator(60, "max", s.metadata.Aggregation)
+	if err != nil {
+		return -1, err
+	}
+
+	// Pub/Sub Lite metrics are sampled every 60 seconds, a subscription without backlog may not report any point
+	valueIfNull := 0.0
+	return s.client.GetMetrics(ctx, s.buildFilter(metricType), s.metadata.ProjectID, aggregation, &valueIfNull, s.metadata.FilterDuration)
+}
@@ -0,0 +1,91 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testPubSubLiteResolvedEnv = map[string]string{
+	"SAMPLE_CREDS":        "{}",
+	"SAMPLE_SUBSCRIPTION": "projects/myproject/locations/us-central1-a/subscriptions/mysubscription",
+}
+
+type parsePubSubLiteMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type pubSubLiteMetricIdentifier struct {
+	metadataTestData *parsePubSubLiteMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testPubSubLiteMetadata = []parsePubSubLiteMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"subscriptionName": "mysubscription", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, false, "subscription id only"},
+	{map[string]string{"subscriptionName": "mysubscription", "projectID": "myproject", "location": "us-central1-a", "mode": "BacklogBytes", "aggregation": "max", "value": "1048576", "activationValue": "1024", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, false, "all metadata"},
+	{map[string]string{"subscriptionNameFromEnv": "SAMPLE_SUBSCRIPTION", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, false, "subscription path from env"},
+	{map[string]string{"subscriptionName": "mysubscription"}, map[string]string{"GoogleApplicationCredentials": "Creds"}, false, "credentials in auth params"},
+	{map[string]string{"subscriptionName": "mysubscription"}, map[string]string{}, true, "missing credentials"},
+	{map[string]string{"subscriptionName": "mysubscription", "mode": "OldestUnackedMessageAge", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, true, "unknown mode"},
+	{map[string]string{"subscriptionName": "mysubscription", "aggregation": "avg", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, true, "unknown aggregation"},
+	{map[string]string{"subscriptionName": "mysubscription", "value": "0", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, true, "zero value"},
+	{map[string]string{"subscriptionName": "projects/myproject/subscriptions/mysubscription", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, true, "subscription path without location"},
+	{map[string]string{"subscriptionName": "projects/myproject/locations/us-central1-a/subscriptions/mysubscription", "projectID": "other", "credentialsFromEnv": "SAMPLE_CREDS"}, map[string]string{}, true, "projectID not matching the subscription path"},
+}
+
+var pubSubLiteMetricIdentifiers = []pubSubLiteMetricIdentifier{
+	{&testPubSubLiteMetadata[1], 0, "s0-gcp-psl-mysubscription"},
+	{&testPubSubLiteMetadata[3], 1, "s1-gcp-psl-mysubscription"},
+}
+
+func TestPubSubLiteParseMetadata(t *testing.T) {
+	for _, testData := range testPubSubLiteMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parsePubSubLiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testPubSubLiteResolvedEnv})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestPubSubLiteSubscriptionPath(t *testing.T) {
+	meta, err := parsePubSubLiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testPubSubLiteMetadata[3].metadata, ResolvedEnv: testPubSubLiteResolvedEnv})
+	assert.NoError(t, err)
+	assert.Equal(t, "myproject", meta.ProjectID)
+	assert.Equal(t, "us-central1-a", meta.Location)
+	assert.Equal(t, "mysubscription", meta.subscriptionID)
+
+	s := pubSubLiteScaler{metadata: meta}
+	assert.Equal(t, `metric.type="pubsublite.googleapis.com/subscription/backlog_message_count" AND resource.type="pubsublite_subscription_partition"`+
+		` AND resource.labels.subscription_id="mysubscription" AND resource.labels.location="us-central1-a"`,
+		s.buildFilter(pubSubLiteMetricTypes[meta.Mode]))
+}
+
+func TestPubSubLiteGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range pubSubLiteMetricIdentifiers {
+		meta, err := parsePubSubLiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ResolvedEnv: testPubSubLiteResolvedEnv, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockPubSubLiteScaler := pubSubLiteScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockPubSubLiteScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
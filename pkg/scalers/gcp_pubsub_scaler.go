@@ -24,11 +24,21 @@ const (
 	resourceTypePubSubSubscription = "subscription"
 	resourceTypePubSubTopic        = "topic"
 
-	pubSubModeSubscriptionSize = "SubscriptionSize"
-	pubSubDefaultValue         = 10
+	pubSubModeSubscriptionSize       = "SubscriptionSize"
+	pubSubModeNumUndeliveredMessages = "NumUndeliveredMessages"
+	// pubSubModeDeadLetterMessageCount scales on the messages forwarded to the dead-letter topic of the subscription
+	// after their max delivery attempts, which is how poison messages show up
+	pubSubModeDeadLetterMessageCount = "DeadLetterMessageCount"
+	// pubSubModeDeadLetterBacklog scales on the undelivered messages of the subscription of the dead-letter topic
+	pubSubModeDeadLetterBacklog = "DeadLetterBacklog"
+	pubSubDefaultValue          = 10
 )
 
-var regexpCompositeSubscriptionIDPrefix = regexp.MustCompile(compositeSubscriptionIDPrefix)
+var (
+	regexpCompositeSubscriptionIDPrefix = regexp.MustCompile(compositeSubscriptionIDPrefix)
+
+	pubSubPatternMetacharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+)
 
 type pubsubScaler struct {
 	client     *gcp.StackDriverClient
@@ -43,13 +53,22 @@ type pubsubMetadata struct {
 	activationValue float64
 
 	// a resource is one of subscription or topic
-	resourceType     string
-	resourceName     string
-	gcpAuthorization *gcp.AuthorizationMetadata
-	triggerIndex     int
-	aggregation      string
-	timeHorizon      string
-	valueIfNull      *float64
+	resourceType string
+	resourceName string
+	// the resource name is a regular expression of the ids of the subscriptions, the values of which are
+	// combined with the sum or the max
+	resourceNameIsPattern   bool
+	subscriptionCombination string
+	gcpAuthorization        *gcp.AuthorizationMetadata
+	triggerIndex            int
+	aggregation             string
+	timeHorizon             string
+	valueIfNull             *float64
+
+	// the subscription of the dead-letter topic of the subscription, the undelivered messages of which
+	// are taken into account as of the dlqBehavior
+	deadLetter                 deadLetterMetadata
+	deadLetterSubscriptionName string
 }
 
 // NewPubSubScaler creates a new pubsubScaler
@@ -76,10 +95,11 @@ func NewPubSubScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 func parsePubSubResourceConfig(config *scalersconfig.ScalerConfig, meta *pubsubMetadata) error {
 	sub, subPresent := config.TriggerMetadata["subscriptionName"]
 	subFromEnv, subFromEnvPresent := config.TriggerMetadata["subscriptionNameFromEnv"]
-	if subPresent && subFromEnvPresent {
-		return fmt.Errorf("exactly one of subscriptionName or subscriptionNameFromEnv is allowed")
+	subPattern, subPatternPresent := config.TriggerMetadata["subscriptionNamePattern"]
+	if (subPresent && subFromEnvPresent) || (subPatternPresent && (subPresent || subFromEnvPresent)) {
+		return fmt.Errorf("exactly one of subscriptionName, subscriptionNameFromEnv or subscriptionNamePattern is allowed")
 	}
-	hasSub := subPresent || subFromEnvPresent
+	hasSub := subPresent || subFromEnvPresent || subPatternPresent
 
 	topic, topicPresent := config.TriggerMetadata["topicName"]
 	topicFromEnv, topicFromEnvPresent := config.TriggerMetadata["topicNameFromEnv"]
@@ -93,13 +113,29 @@ func parsePubSubResourceConfig(config *scalersconfig.ScalerConfig, meta *pubsubM
 	}
 
 	if hasSub {
-		if subPresent {
+		switch {
+		case subPatternPresent:
+			if subPattern == "" {
+				return fmt.Errorf("no subscription name pattern given")
+			}
+
+			patternID, _ := splitPubSubResourceName(subPattern)
+			if _, err := regexp.Compile(patternID); err != nil {
+				return fmt.Errorf("invalid subscriptionNamePattern: %w", err)
+			}
+			if strings.Contains(patternID, "'") {
+				return fmt.Errorf("invalid subscriptionNamePattern: %s", subPattern)
+			}
+
+			meta.resourceName = subPattern
+			meta.resourceNameIsPattern = true
+		case subPresent:
 			if sub == "" {
 				return fmt.Errorf("no subscription name given")
 			}
 
 			meta.resourceName = sub
-		} else {
+		default:
 			if subFromEnv == "" {
 				return fmt.Errorf("no environment variable name given for resolving subscription name")
 			}
@@ -206,6 +242,43 @@ func parsePubSubMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		meta.activationValue = activationValue
 	}
 
+	meta.subscriptionCombination = gcp.CombinationSum
+	if val, ok := config.TriggerMetadata["subscriptionCombination"]; ok && val != "" {
+		if !meta.resourceNameIsPattern {
+			return nil, errors.New("subscriptionCombination is only supported with subscriptionNamePattern")
+		}
+		if val != gcp.CombinationSum && val != gcp.CombinationMax {
+			return nil, fmt.Errorf("subscriptionCombination must be one of %s or %s", gcp.CombinationSum, gcp.CombinationMax)
+		}
+		meta.subscriptionCombination = val
+	}
+
+	if meta.mode == pubSubModeDeadLetterMessageCount && meta.resourceType != resourceTypePubSubSubscription {
+		return nil, fmt.Errorf("mode %s is only supported with a subscription", pubSubModeDeadLetterMessageCount)
+	}
+
+	if err := config.TypedConfig(&meta.deadLetter); err != nil {
+		return nil, err
+	}
+	meta.deadLetterSubscriptionName = config.TriggerMetadata["deadLetterSubscriptionName"]
+	if meta.mode == pubSubModeDeadLetterBacklog {
+		if meta.deadLetterSubscriptionName == "" {
+			return nil, fmt.Errorf("deadLetterSubscriptionName must be given with mode %s", pubSubModeDeadLetterBacklog)
+		}
+		if meta.deadLetter.enabled() {
+			return nil, fmt.Errorf("dlqBehavior isn't supported with mode %s", pubSubModeDeadLetterBacklog)
+		}
+	}
+	if meta.deadLetter.enabled() {
+		if meta.deadLetterSubscriptionName == "" {
+			return nil, fmt.Errorf("deadLetterSubscriptionName must be given with dlqBehavior %s", meta.deadLetter.DLQBehavior)
+		}
+		// the undelivered messages of the dead-letter subscription can only be added to a number of messages
+		if meta.deadLetter.DLQBehavior == deadLetterBehaviorAdd && meta.mode != pubSubModeSubscriptionSize && meta.mode != pubSubModeNumUndeliveredMessages {
+			return nil, fmt.Errorf("dlqBehavior %s is only supported with mode %s or %s", deadLetterBehaviorAdd, pubSubModeSubscriptionSize, pubSubModeNumUndeliveredMessages)
+		}
+	}
+
 	auth, err := gcp.GetGCPAuthorization(config)
 	if err != nil {
 		return nil, err
@@ -228,9 +301,13 @@ func (s *pubsubScaler) Close(context.Context) error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *pubsubScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	resourceName := s.metadata.resourceName
+	if s.metadata.resourceNameIsPattern {
+		resourceName = strings.Trim(pubSubPatternMetacharacters.ReplaceAllString(resourceName, "-"), "-")
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-ps-%s", s.metadata.resourceName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-ps-%s", resourceName))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
 	}
@@ -251,17 +328,34 @@ func (s *pubsubScaler) GetMetricsAndActivity(ctx context.Context, metricName str
 	// SubscriptionSize is actually NumUndeliveredMessages in GCP PubSub.
 	// Considering backward compatibility, fallback "SubscriptionSize" to "NumUndeliveredMessages"
 	if mode == pubSubModeSubscriptionSize {
-		mode = "NumUndeliveredMessages"
+		mode = pubSubModeNumUndeliveredMessages
 	}
 
-	prefix := prefixPubSubResource + s.metadata.resourceType + "/"
-	metricType := prefix + snakeCase(mode)
-	value, err := s.getMetrics(ctx, metricType)
+	var value float64
+	var err error
+	metricType := prefixPubSubResource + s.metadata.resourceType + "/" + snakeCase(mode)
+	if mode == pubSubModeDeadLetterBacklog {
+		metricType = prefixPubSubResource + resourceTypePubSubSubscription + "/" + snakeCase(pubSubModeNumUndeliveredMessages)
+		value, err = s.getResourceMetrics(ctx, resourceTypePubSubSubscription, s.metadata.deadLetterSubscriptionName, metricType)
+	} else {
+		value, err = s.getMetrics(ctx, metricType)
+	}
 	if err != nil {
 		s.logger.Error(err, "error getting metric", "metricType", metricType)
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
+	if s.metadata.deadLetter.enabled() {
+		deadLetterMetricType := prefixPubSubResource + resourceTypePubSubSubscription + "/" + snakeCase(pubSubModeNumUndeliveredMessages)
+		deadLetterValue, err := s.getResourceMetrics(ctx, resourceTypePubSubSubscription, s.metadata.deadLetterSubscriptionName, deadLetterMetricType)
+		if err != nil {
+			s.logger.Error(err, "error getting dead-letter metric", "metricType", deadLetterMetricType)
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		value, isActive := s.metadata.deadLetter.apply(value, deadLetterValue, s.metadata.activationValue)
+		return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, value)}, isActive, nil
+	}
+
 	metric := GenerateMetricInMili(metricName, value)
 
 	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.activationValue, nil
@@ -285,15 +379,34 @@ func (s *pubsubScaler) setStackdriverClient(ctx context.Context) error {
 
 // getMetrics gets metric type value from stackdriver api
 func (s *pubsubScaler) getMetrics(ctx context.Context, metricType string) (float64, error) {
+	if s.metadata.resourceNameIsPattern {
+		return s.queryMetrics(ctx, s.metadata.resourceName, func(projectID, resourceID string) (string, error) {
+			return s.client.BuildMQLPatternQuery(
+				projectID, s.metadata.resourceType, metricType, resourceID, s.metadata.aggregation, s.metadata.timeHorizon, s.metadata.subscriptionCombination,
+			)
+		})
+	}
+	return s.getResourceMetrics(ctx, s.metadata.resourceType, s.metadata.resourceName, metricType)
+}
+
+// getResourceMetrics gets metric type value of the subscription or topic from stackdriver api
+func (s *pubsubScaler) getResourceMetrics(ctx context.Context, resourceType, resourceName, metricType string) (float64, error) {
+	return s.queryMetrics(ctx, resourceName, func(projectID, resourceID string) (string, error) {
+		return s.client.BuildMQLQuery(
+			projectID, resourceType, metricType, resourceID, s.metadata.aggregation, s.metadata.timeHorizon,
+		)
+	})
+}
+
+// queryMetrics gets the value of the query built for the resource from stackdriver api
+func (s *pubsubScaler) queryMetrics(ctx context.Context, resourceName string, buildQuery func(projectID, resourceID string) (string, error)) (float64, error) {
 	if s.client == nil {
 		if err := s.setStackdriverClient(ctx); err != nil {
 			return -1, err
 		}
 	}
-	resourceID, projectID := getResourceData(s)
-	query, err := s.client.BuildMQLQuery(
-		projectID, s.metadata.resourceType, metricType, resourceID, s.metadata.aggregation, s.metadata.timeHorizon,
-	)
+	resourceID, projectID := splitPubSubResourceName(resourceName)
+	query, err := buildQuery(projectID, resourceID)
 	if err != nil {
 		return -1, err
 	}
@@ -304,14 +417,20 @@ func (s *pubsubScaler) getMetrics(ctx context.Context, metricType string) (float
 }
 
 func getResourceData(s *pubsubScaler) (string, string) {
+	return splitPubSubResourceName(s.metadata.resourceName)
+}
+
+// splitPubSubResourceName returns the id of the resource and its project, which is only
+// known if the name is the composite one projects/<project>/(subscriptions|topics)/<id>
+func splitPubSubResourceName(resourceName string) (string, string) {
 	var resourceID string
 	var projectID string
 
-	if regexpCompositeSubscriptionIDPrefix.MatchString(s.metadata.resourceName) {
-		resourceID = strings.Split(s.metadata.resourceName, "/")[3]
-		projectID = strings.Split(s.metadata.resourceName, "/")[1]
+	if regexpCompositeSubscriptionIDPrefix.MatchString(resourceName) {
+		resourceID = strings.Split(resourceName, "/")[3]
+		projectID = strings.Split(resourceName, "/")[1]
 	} else {
-		resourceID = s.metadata.resourceName
+		resourceID = resourceName
 	}
 	return resourceID, projectID
 }
@@ -86,6 +86,38 @@ var testPubSubMetadata = []parsePubSubMetadataTestData{
 	{nil, map[string]string{"subscriptionNameFromEnv": "MY_ENV_SUBSCRIPTION", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
 	// topicNameFromEnv present
 	{nil, map[string]string{"topicNameFromEnv": "MY_ENV_TOPIC", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// dead-letter subscription added
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "dlqBehavior": "add", "deadLetterSubscriptionName": "mydeadletter"}, false},
+	// dead-letter subscription missing
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "dlqBehavior": "block"}, true},
+	// dead-letter subscription added to the age of the oldest message
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "OldestUnackedMessageAge", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "dlqBehavior": "add", "deadLetterSubscriptionName": "mydeadletter"}, true},
+	// dead-letter subscription blocking the scaling to zero with the age of the oldest message
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "OldestUnackedMessageAge", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "dlqBehavior": "block", "deadLetterSubscriptionName": "mydeadletter"}, false},
+	// malformed dlqBehavior
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "dlqBehavior": "AA", "deadLetterSubscriptionName": "mydeadletter"}, true},
+	// dead-lettered messages
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "DeadLetterMessageCount", "value": "1", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// dead-lettered messages of a topic
+	{nil, map[string]string{"topicName": "mytopic", "mode": "DeadLetterMessageCount", "value": "1", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// dead-letter backlog
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "DeadLetterBacklog", "value": "5", "credentialsFromEnv": "SAMPLE_CREDS", "deadLetterSubscriptionName": "mydeadletter"}, false},
+	// dead-letter backlog without dead-letter subscription
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "DeadLetterBacklog", "value": "5", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// dead-letter backlog with dlqBehavior
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "DeadLetterBacklog", "value": "5", "credentialsFromEnv": "SAMPLE_CREDS", "deadLetterSubscriptionName": "mydeadletter", "dlqBehavior": "block"}, true},
+	// subscription name pattern
+	{nil, map[string]string{"subscriptionNamePattern": "projects/myproject/subscriptions/orders-.*", "subscriptionCombination": "max", "value": "5", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// subscription name pattern with subscription name
+	{nil, map[string]string{"subscriptionNamePattern": "orders-.*", "subscriptionName": "mysubscription", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// invalid subscription name pattern
+	{nil, map[string]string{"subscriptionNamePattern": "orders-(", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// subscription name pattern with quote
+	{nil, map[string]string{"subscriptionNamePattern": "orders' || true", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// invalid subscription combination
+	{nil, map[string]string{"subscriptionNamePattern": "orders-.*", "subscriptionCombination": "mean", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// subscription combination without subscription name pattern
+	{nil, map[string]string{"subscriptionName": "mysubscription", "subscriptionCombination": "max", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
 }
 
 var gcpPubSubMetricIdentifiers = []gcpPubSubMetricIdentifier{
@@ -93,6 +125,7 @@ var gcpPubSubMetricIdentifiers = []gcpPubSubMetricIdentifier{
 	{&testPubSubMetadata[1], 1, "s1-gcp-ps-mysubscription"},
 	{&testPubSubMetadata[16], 0, "s0-gcp-ps-mytopic"},
 	{&testPubSubMetadata[16], 1, "s1-gcp-ps-mytopic"},
+	{&testPubSubMetadata[36], 2, "s2-gcp-ps-projects-myproject-subscriptions-orders"},
 }
 
 var gcpResourceNameTests = []gcpPubSubSubscription{
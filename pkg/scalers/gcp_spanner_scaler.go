@@ -0,0 +1,292 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/genproto/googleapis/api/distribution"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	spannerResourceType = "spanner_instance"
+
+	spannerDefaultMetricName        = "spanner.googleapis.com/instance/processing_units"
+	spannerDefaultLatencyMetricName = "spanner.googleapis.com/api/request_latencies"
+
+	// Cloud Monitoring returns ResourceExhausted (HTTP 429) when the read quota is exceeded,
+	// those errors are transient so we retry them with an exponential backoff
+	spannerQuotaMaxRetries          = 3
+	spannerQuotaDefaultRetryBackoff = time.Second
+)
+
+type spannerScaler struct {
+	client       *gcp.StackDriverClient
+	metricType   v2.MetricTargetType
+	metadata     *spannerMetadata
+	retryBackoff time.Duration
+	logger       logr.Logger
+}
+
+type spannerMetadata struct {
+	ProjectID             string  `keda:"name=projectID, order=triggerMetadata"`
+	InstanceID            string  `keda:"name=instanceID, order=triggerMetadata"`
+	Database              string  `keda:"name=database, order=triggerMetadata, optional"`
+	MetricName            string  `keda:"name=metricName, order=triggerMetadata, optional"`
+	ResourceFilter        string  `keda:"name=resourceFilter, order=triggerMetadata, optional"`
+	TargetValue           float64 `keda:"name=targetValue, order=triggerMetadata, optional"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional, default=0"`
+	FilterDuration        int64   `keda:"name=filterDuration, order=triggerMetadata, optional"`
+	LatencyPercentile     string  `keda:"name=latencyPercentile, order=triggerMetadata, optional"`
+	TargetLatencyMs       float64 `keda:"name=targetLatencyMs, order=triggerMetadata, optional"`
+
+	gcpAuthorization *gcp.AuthorizationMetadata
+	triggerIndex     int
+	percentile       float64
+}
+
+func (m *spannerMetadata) Validate() error {
+	if m.LatencyPercentile == "" {
+		if m.TargetValue == 0 {
+			return fmt.Errorf("no targetValue given")
+		}
+		if m.MetricName == "" {
+			m.MetricName = spannerDefaultMetricName
+		}
+		return nil
+	}
+
+	percentile, err := strconv.ParseFloat(strings.TrimPrefix(m.LatencyPercentile, "p"), 64)
+	if err != nil || percentile <= 0 || percentile > 100 {
+		return fmt.Errorf("invalid latencyPercentile %q, it must be of the form p99", m.LatencyPercentile)
+	}
+	m.percentile = percentile
+	if m.TargetLatencyMs <= 0 {
+		return fmt.Errorf("targetLatencyMs must be greater than 0 when latencyPercentile is set")
+	}
+	if m.MetricName == "" {
+		m.MetricName = spannerDefaultLatencyMetricName
+	}
+	return nil
+}
+
+// targetValue returns the target of the metric, in milliseconds when the latency is scaled on
+func (m *spannerMetadata) targetValue() float64 {
+	if m.LatencyPercentile != "" {
+		return m.TargetLatencyMs
+	}
+	return m.TargetValue
+}
+
+// NewSpannerScaler creates a new spannerScaler
+func NewSpannerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "gcp_spanner_scaler")
+
+	meta, err := parseSpannerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Spanner metadata: %w", err)
+	}
+
+	return &spannerScaler{
+		metricType:   metricType,
+		metadata:     meta,
+		retryBackoff: spannerQuotaDefaultRetryBackoff,
+		logger:       logger,
+	}, nil
+}
+
+func parseSpannerMetadata(config *scalersconfig.ScalerConfig) (*spannerMetadata, error) {
+	meta := &spannerMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing gcp spanner metadata: %w", err)
+	}
+
+	auth, err := gcp.GetGCPAuthorization(config)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.gcpAuthorization = auth
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *spannerScaler) Close(context.Context) error {
+	if s.client != nil {
+		err := s.client.Close()
+		s.client = nil
+		if err != nil {
+			s.logger.Error(err, "error closing StackDriver client")
+		}
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *spannerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := fmt.Sprintf("gcp-spanner-%s", s.metadata.InstanceID)
+	if s.metadata.Database != "" {
+		name = fmt.Sprintf("%s-%s", name, s.metadata.Database)
+	}
+
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue()),
+	}
+
+	// Create the metric spec for the HPA
+	metricSpec := v2.MetricSpec{
+		External: externalMetric,
+		Type:     externalMetricType,
+	}
+
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity connects to Stack Driver and retrieves the Spanner metric,
+// or the latency percentile in milliseconds if latencyPercentile is set
+func (s *spannerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	getMetrics := s.getMetrics
+	if s.metadata.LatencyPercentile != "" {
+		getMetrics = s.getLatency
+	}
+	value, err := getMetrics(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting metric", "metricType", s.metadata.MetricName)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *spannerScaler) setStackdriverClient(ctx context.Context) error {
+	var client *gcp.StackDriverClient
+	var err error
+	if s.metadata.gcpAuthorization.PodIdentityProviderEnabled {
+		client, err = gcp.NewStackDriverClientPodIdentity(ctx)
+	} else {
+		client, err = gcp.NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
+	}
+
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+// buildFilter builds the Cloud Monitoring filter for the configured Spanner instance
+func (s *spannerScaler) buildFilter() string {
+	filter := `metric.type="` + s.metadata.MetricName + `" AND resource.type="` + spannerResourceType + `" AND resource.labels.instance_id="` + s.metadata.InstanceID + `"`
+	if s.metadata.Database != "" {
+		filter += ` AND metric.labels.database="` + s.metadata.Database + `"`
+	}
+	if s.metadata.ResourceFilter != "" {
+		filter += ` AND ` + s.metadata.ResourceFilter
+	}
+	return filter
+}
+
+// getMetrics gets metric type value from stackdriver api
+func (s *spannerScaler) getMetrics(ctx context.Context) (float64, error) {
+	if s.client == nil {
+		err := s.setStackdriverClient(ctx)
+		if err != nil {
+			return -1, err
+		}
+	}
+	filter := s.buildFilter()
+
+	var value float64
+	err := s.retryOnQuotaExhausted(ctx, func() error {
+		var err error
+		value, err = s.client.GetMetrics(ctx, filter, s.metadata.ProjectID, nil, nil, s.metadata.FilterDuration)
+		return err
+	})
+	if err != nil {
+		return -1, err
+	}
+	return value, nil
+}
+
+// getLatency gets the latency percentile in milliseconds from the latency distribution of stackdriver api,
+// the distributions of all the matching time series are summed up
+func (s *spannerScaler) getLatency(ctx context.Context) (float64, error) {
+	if s.client == nil {
+		err := s.setStackdriverClient(ctx)
+		if err != nil {
+			return -1, err
+		}
+	}
+	filter := s.buildFilter()
+	aggregation, err := gcp.NewStackdriverAggregator(60, "delta", "sum")
+	if err != nil {
+		return -1, err
+	}
+
+	var dist *distribution.Distribution
+	var unit string
+	err = s.retryOnQuotaExhausted(ctx, func() error {
+		var err error
+		dist, unit, err = s.client.GetDistribution(ctx, filter, s.metadata.ProjectID, aggregation, s.metadata.FilterDuration)
+		return err
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	latency, err := gcp.DistributionPercentile(dist, s.metadata.percentile)
+	if err != nil {
+		return -1, err
+	}
+
+	switch unit {
+	case "s":
+		return latency * 1000, nil
+	case "ms":
+		return latency, nil
+	case "us":
+		return latency / 1000, nil
+	default:
+		return -1, fmt.Errorf("unsupported unit %q of latency metric %s", unit, s.metadata.MetricName)
+	}
+}
+
+// retryOnQuotaExhausted calls f until it succeeds, retrying with an exponential backoff when the monitoring quota is exhausted
+func (s *spannerScaler) retryOnQuotaExhausted(ctx context.Context, f func() error) error {
+	backoff := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := f()
+		if err == nil || status.Code(err) != codes.ResourceExhausted || attempt >= spannerQuotaMaxRetries {
+			return err
+		}
+
+		s.logger.V(1).Info("monitoring quota exhausted, retrying", "attempt", attempt+1, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
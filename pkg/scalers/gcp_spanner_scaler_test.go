@@ -0,0 +1,273 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	monitoringpb "cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/api/distribution"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testSpannerResolvedEnv = map[string]string{
+	"SAMPLE_CREDS": "{}",
+}
+
+type parseSpannerMetadataTestData struct {
+	authParams map[string]string
+	metadata   map[string]string
+	isError    bool
+	comment    string
+}
+
+type spannerMetricIdentifier struct {
+	metadataTestData *parseSpannerMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSpannerMetadata = []parseSpannerMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "all required properly formed"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "database": "mydb", "metricName": "spanner.googleapis.com/api/request_count", "targetValue": "100", "activationTargetValue": "10", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "all properly formed"},
+	{nil, map[string]string{"instanceID": "myinstance", "targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "missing projectID"},
+	{nil, map[string]string{"projectID": "myproject", "targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "missing instanceID"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "missing targetValue"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "a", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "malformed targetValue"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "100", "activationTargetValue": "a", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "malformed activationTargetValue"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "100"}, true, "missing credentials"},
+	{map[string]string{"GoogleApplicationCredentials": "Creds"}, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "100"}, false, "credentials from AuthParams"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "p99", "targetLatencyMs": "50", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "latency percentile"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "p99", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "latency percentile without targetLatencyMs"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "p101", "targetLatencyMs": "50", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "out of range latency percentile"},
+	{nil, map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "high", "targetLatencyMs": "50", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "malformed latency percentile"},
+}
+
+var spannerMetricIdentifiers = []spannerMetricIdentifier{
+	{&testSpannerMetadata[1], 0, "s0-gcp-spanner-myinstance"},
+	{&testSpannerMetadata[2], 1, "s1-gcp-spanner-myinstance-mydb"},
+}
+
+func TestSpannerParseMetadata(t *testing.T) {
+	for _, testData := range testSpannerMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{AuthParams: testData.authParams, TriggerMetadata: testData.metadata, ResolvedEnv: testSpannerResolvedEnv})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestSpannerGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range spannerMetricIdentifiers {
+		meta, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testSpannerResolvedEnv, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSpannerScaler := spannerScaler{nil, "", meta, 0, logr.Discard()}
+
+		metricSpec := mockSpannerScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSpannerBuildFilter(t *testing.T) {
+	meta, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"projectID": "myproject", "instanceID": "myinstance", "database": "mydb", "resourceFilter": `resource.labels.location="us-central1"`, "targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"},
+		ResolvedEnv:     testSpannerResolvedEnv,
+	})
+	assert.NoError(t, err)
+
+	s := spannerScaler{metadata: meta}
+	assert.Equal(t, `metric.type="spanner.googleapis.com/instance/processing_units" AND resource.type="spanner_instance" AND resource.labels.instance_id="myinstance" AND metric.labels.database="mydb" AND resource.labels.location="us-central1"`, s.buildFilter())
+}
+
+func TestSpannerLatencyMetadataDefaults(t *testing.T) {
+	meta, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "p95", "targetLatencyMs": "50", "credentialsFromEnv": "SAMPLE_CREDS"},
+		ResolvedEnv:     testSpannerResolvedEnv,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "spanner.googleapis.com/api/request_latencies", meta.MetricName)
+	assert.Equal(t, float64(95), meta.percentile)
+
+	s := spannerScaler{metadata: meta, metricType: "AverageValue"}
+	metricSpec := s.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, int64(50000), metricSpec[0].External.Target.AverageValue.MilliValue())
+}
+
+// mockSpannerMetricServer is a fake Cloud Monitoring server which fails with
+// ResourceExhausted for the first quotaErrors calls. It returns the distribution
+// if it is set, otherwise an int64 point
+type mockSpannerMetricServer struct {
+	monitoringpb.UnimplementedMetricServiceServer
+
+	mu           sync.Mutex
+	quotaErrors  int
+	calls        int
+	filters      []string
+	aggregations []*monitoringpb.Aggregation
+	distribution *distribution.Distribution
+}
+
+func (m *mockSpannerMetricServer) ListTimeSeries(_ context.Context, req *monitoringpb.ListTimeSeriesRequest) (*monitoringpb.ListTimeSeriesResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	m.filters = append(m.filters, req.Filter)
+	m.aggregations = append(m.aggregations, req.Aggregation)
+	if m.calls <= m.quotaErrors {
+		return nil, status.Error(codes.ResourceExhausted, "quota exceeded")
+	}
+	if m.distribution != nil {
+		return &monitoringpb.ListTimeSeriesResponse{
+			TimeSeries: []*monitoringpb.TimeSeries{
+				{
+					Unit: "s",
+					Points: []*monitoringpb.Point{
+						{Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DistributionValue{DistributionValue: m.distribution}}},
+					},
+				},
+			},
+		}, nil
+	}
+	return &monitoringpb.ListTimeSeriesResponse{
+		TimeSeries: []*monitoringpb.TimeSeries{
+			{
+				Points: []*monitoringpb.Point{
+					{Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: 42}}},
+				},
+			},
+		},
+	}, nil
+}
+
+func startMockSpannerMetricServer(t *testing.T, server *mockSpannerMetricServer) *gcp.StackDriverClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Could not start listener:", err)
+	}
+	grpcServer := grpc.NewServer()
+	monitoringpb.RegisterMetricServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	client, err := gcp.NewStackDriverClientWithOptions(context.Background(), "myproject",
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatal("Could not create stackdriver client:", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestSpannerGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		quotaErrors   int
+		expectedCalls int
+		isError       bool
+	}{
+		{"no quota errors", 0, 1, false},
+		{"recovers from quota errors", 2, 3, false},
+		{"gives up after max retries", spannerQuotaMaxRetries + 1, spannerQuotaMaxRetries + 1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &mockSpannerMetricServer{quotaErrors: tc.quotaErrors}
+			client := startMockSpannerMetricServer(t, server)
+
+			meta, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"projectID": "myproject", "instanceID": "myinstance", "targetValue": "100", "activationTargetValue": "40", "credentialsFromEnv": "SAMPLE_CREDS"},
+				ResolvedEnv:     testSpannerResolvedEnv,
+			})
+			assert.NoError(t, err)
+
+			s := spannerScaler{
+				client:       client,
+				metricType:   "AverageValue",
+				metadata:     meta,
+				retryBackoff: time.Millisecond,
+				logger:       logr.Discard(),
+			}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-gcp-spanner-myinstance")
+			assert.Equal(t, tc.expectedCalls, server.calls)
+			if tc.isError {
+				assert.Error(t, err)
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, isActive)
+			assert.Equal(t, int64(42), metrics[0].Value.Value())
+			assert.Contains(t, server.filters[0], `resource.labels.instance_id="myinstance"`)
+		})
+	}
+}
+
+func TestSpannerGetLatency(t *testing.T) {
+	// 100 requests: 50 under 10ms, 40 between 10ms and 20ms, 10 between 20ms and 40ms
+	server := &mockSpannerMetricServer{
+		quotaErrors: 1,
+		distribution: &distribution.Distribution{
+			Count: 100,
+			BucketOptions: &distribution.Distribution_BucketOptions{
+				Options: &distribution.Distribution_BucketOptions_ExplicitBuckets{
+					ExplicitBuckets: &distribution.Distribution_BucketOptions_Explicit{Bounds: []float64{0, 0.01, 0.02, 0.04}},
+				},
+			},
+			BucketCounts: []int64{0, 50, 40, 10},
+		},
+	}
+	client := startMockSpannerMetricServer(t, server)
+
+	meta, err := parseSpannerMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"projectID": "myproject", "instanceID": "myinstance", "latencyPercentile": "p95", "targetLatencyMs": "25", "activationTargetValue": "20", "credentialsFromEnv": "SAMPLE_CREDS"},
+		ResolvedEnv:     testSpannerResolvedEnv,
+	})
+	assert.NoError(t, err)
+
+	s := spannerScaler{
+		client:       client,
+		metricType:   "AverageValue",
+		metadata:     meta,
+		retryBackoff: time.Millisecond,
+		logger:       logr.Discard(),
+	}
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-gcp-spanner-myinstance")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, server.calls)
+	// the 95th request is halfway through the bucket between 20ms and 40ms
+	assert.InDelta(t, 30, metrics[0].Value.AsApproximateFloat64(), 0.001)
+	assert.True(t, isActive)
+	assert.Contains(t, server.filters[1], `metric.type="spanner.googleapis.com/api/request_latencies"`)
+	assert.Equal(t, monitoringpb.Aggregation_ALIGN_DELTA, server.aggregations[1].PerSeriesAligner)
+	assert.Equal(t, monitoringpb.Aggregation_REDUCE_SUM, server.aggregations[1].CrossSeriesReducer)
+}
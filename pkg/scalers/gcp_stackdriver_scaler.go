@@ -20,15 +20,18 @@ const (
 )
 
 type stackdriverScaler struct {
-	client     *gcp.StackDriverClient
-	metricType v2.MetricTargetType
-	metadata   *stackdriverMetadata
-	logger     logr.Logger
+	client       *gcp.StackDriverClient
+	promQLClient *gcp.PromQLClient
+	metricType   v2.MetricTargetType
+	metadata     *stackdriverMetadata
+	logger       logr.Logger
 }
 
 type stackdriverMetadata struct {
 	projectID             string
 	filter                string
+	mqlQuery              string
+	promQLQuery           string
 	targetValue           float64
 	activationTargetValue float64
 	metricName            string
@@ -53,6 +56,23 @@ func NewStackdriverScaler(ctx context.Context, config *scalersconfig.ScalerConfi
 		return nil, fmt.Errorf("error parsing Stackdriver metadata: %w", err)
 	}
 
+	if meta.promQLQuery != "" {
+		httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+		transport, err := gcp.GetGCPOAuth2HTTPTransport(config, httpClient.Transport, gcp.GcpScopeMonitoringRead)
+		if err != nil {
+			logger.Error(err, "Failed to create the http transport of the PromQL client")
+			return nil, err
+		}
+		httpClient.Transport = transport
+
+		return &stackdriverScaler{
+			metricType:   metricType,
+			metadata:     meta,
+			promQLClient: gcp.NewPromQLClient(httpClient, ""),
+			logger:       logger,
+		}, nil
+	}
+
 	client, err := initializeStackdriverClient(ctx, meta.gcpAuthorization, logger)
 	if err != nil {
 		logger.Error(err, "Failed to create stack driver client")
@@ -81,14 +101,15 @@ func parseStackdriverMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 		return nil, fmt.Errorf("no projectId name given")
 	}
 
-	if val, ok := config.TriggerMetadata["filter"]; ok {
-		if val == "" {
-			return nil, fmt.Errorf("no filter given")
-		}
-
-		meta.filter = val
-	} else {
+	// the time series are selected with a filter, or computed by a MQL or PromQL query
+	meta.filter = config.TriggerMetadata["filter"]
+	meta.mqlQuery = config.TriggerMetadata["mqlQuery"]
+	meta.promQLQuery = config.TriggerMetadata["promQLQuery"]
+	switch queries := countNonEmpty(meta.filter, meta.mqlQuery, meta.promQLQuery); {
+	case queries == 0:
 		return nil, fmt.Errorf("no filter given")
+	case queries > 1:
+		return nil, fmt.Errorf("only one of filter, mqlQuery and promQLQuery can be given")
 	}
 
 	name := kedautil.NormalizeString(fmt.Sprintf("gcp-stackdriver-%s", meta.projectID))
@@ -139,8 +160,15 @@ func parseStackdriverMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 	if err != nil {
 		return nil, err
 	}
+	if aggregation != nil && meta.filter == "" {
+		return nil, fmt.Errorf("alignmentPeriodSeconds can only be used with filter, the aggregation being part of the query otherwise")
+	}
 	meta.aggregation = aggregation
 
+	if meta.filterDuration != 0 && meta.filter == "" {
+		return nil, fmt.Errorf("filterDuration can only be used with filter")
+	}
+
 	return &meta, nil
 }
 
@@ -226,6 +254,21 @@ func (s *stackdriverScaler) GetMetricsAndActivity(ctx context.Context, metricNam
 
 // getMetrics gets metric type value from stackdriver api
 func (s *stackdriverScaler) getMetrics(ctx context.Context) (float64, error) {
+	switch {
+	case s.metadata.promQLQuery != "":
+		val, err := s.promQLClient.QueryMetrics(ctx, s.metadata.projectID, s.metadata.promQLQuery, s.metadata.valueIfNull)
+		if err == nil {
+			s.logger.V(1).Info(fmt.Sprintf("Getting metrics for project %s and PromQL query %s. Result: %f", s.metadata.projectID, s.metadata.promQLQuery, val))
+		}
+		return val, err
+	case s.metadata.mqlQuery != "":
+		val, err := s.client.QueryMetrics(ctx, s.metadata.projectID, s.metadata.mqlQuery, s.metadata.valueIfNull)
+		if err == nil {
+			s.logger.V(1).Info(fmt.Sprintf("Getting metrics for project %s and MQL query %s. Result: %f", s.metadata.projectID, s.metadata.mqlQuery, val))
+		}
+		return val, err
+	}
+
 	val, err := s.client.GetMetrics(ctx, s.metadata.filter, s.metadata.projectID, s.metadata.aggregation, s.metadata.valueIfNull, s.metadata.filterDuration)
 	if err == nil {
 		s.logger.V(1).Info(
@@ -238,3 +281,13 @@ func (s *stackdriverScaler) getMetrics(ctx context.Context) (float64, error) {
 
 	return val, err
 }
+
+func countNonEmpty(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -27,6 +27,10 @@ type gcpStackdriverMetricIdentifier struct {
 
 var sdFilter = "metric.type=\"storage.googleapis.com/storage/object_count\" resource.type=\"gcs_bucket\""
 
+var sdMQLQuery = "fetch gcs_bucket | metric 'storage.googleapis.com/storage/object_count' | within 5m | group_by [], sum(val())"
+
+var sdPromQLQuery = "sum(rate(loadbalancing_googleapis_com:https_request_count{monitored_resource=\"https_lb_rule\",response_code_class=\"500\"}[5m])) / sum(rate(loadbalancing_googleapis_com:https_request_count{monitored_resource=\"https_lb_rule\"}[5m]))"
+
 var testStackdriverMetadata = []parseStackdriverMetadataTestData{
 	{map[string]string{}, map[string]string{}, true},
 	// all properly formed
@@ -61,6 +65,18 @@ var testStackdriverMetadata = []parseStackdriverMetadataTestData{
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "targetValue": "1.1", "activationTargetValue": "2.1", "valueIfNull": "1.0"}, false},
 	// With bad valueIfNull
 	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "credentialsFromEnv": "SAMPLE_CREDS", "targetValue": "1.1", "activationTargetValue": "2.1", "valueIfNull": "toto"}, true},
+	// MQL query
+	{nil, map[string]string{"projectId": "myProject", "mqlQuery": sdMQLQuery, "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// PromQL query
+	{nil, map[string]string{"projectId": "myProject", "promQLQuery": sdPromQLQuery, "credentialsFromEnv": "SAMPLE_CREDS", "valueIfNull": "0"}, false},
+	// filter and PromQL query
+	{nil, map[string]string{"projectId": "myProject", "filter": sdFilter, "promQLQuery": sdPromQLQuery, "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// MQL and PromQL queries
+	{nil, map[string]string{"projectId": "myProject", "mqlQuery": sdMQLQuery, "promQLQuery": sdPromQLQuery, "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// MQL query with aggregation info
+	{nil, map[string]string{"projectId": "myProject", "mqlQuery": sdMQLQuery, "credentialsFromEnv": "SAMPLE_CREDS", "alignmentPeriodSeconds": "120"}, true},
+	// PromQL query with filterDuration
+	{nil, map[string]string{"projectId": "myProject", "promQLQuery": sdPromQLQuery, "credentialsFromEnv": "SAMPLE_CREDS", "filterDuration": "5"}, true},
 }
 
 var gcpStackdriverMetricIdentifiers = []gcpStackdriverMetricIdentifier{
@@ -86,7 +102,7 @@ func TestGcpStackdriverGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockGcpStackdriverScaler := stackdriverScaler{nil, "", meta, logr.Discard()}
+		mockGcpStackdriverScaler := stackdriverScaler{nil, nil, "", meta, logr.Discard()}
 
 		metricSpec := mockGcpStackdriverScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
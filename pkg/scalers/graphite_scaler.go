@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	url_pkg "net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -23,8 +25,22 @@ const (
 	graphiteThreshold                  = "threshold"
 	graphiteActivationThreshold        = "activationThreshold"
 	graphiteQueryTime                  = "queryTime"
+	graphiteTargets                    = "targets"
+	graphiteConsolidation              = "consolidation"
+	graphiteSeriesAggregation          = "seriesAggregation"
 	defaultGraphiteThreshold           = 100
 	defaultGraphiteActivationThreshold = 0
+
+	graphiteConsolidationLast = "last"
+	graphiteAggregationMax    = "max"
+	graphiteAggregationMin    = "min"
+	graphiteAggregationAvg    = "avg"
+	graphiteAggregationSum    = "sum"
+)
+
+var (
+	graphiteConsolidations     = []string{graphiteConsolidationLast, graphiteAggregationMax, graphiteAggregationMin, graphiteAggregationAvg}
+	graphiteSeriesAggregations = []string{graphiteAggregationSum, graphiteAggregationMax, graphiteAggregationMin, graphiteAggregationAvg}
 )
 
 type graphiteScaler struct {
@@ -35,8 +51,14 @@ type graphiteScaler struct {
 }
 
 type graphiteMetadata struct {
-	serverAddress       string
-	query               string
+	serverAddress string
+	query         string
+	// targets are the targets rendered along with the query, which may use any graphite function
+	targets []string
+	// consolidation is how the non-null datapoints of the window of a series are reduced to a single value
+	consolidation string
+	// seriesAggregation is how the values of the series are combined when several series are returned
+	seriesAggregation   string
 	threshold           float64
 	activationThreshold float64
 	from                string
@@ -85,12 +107,34 @@ func parseGraphiteMetadata(config *scalersconfig.ScalerConfig) (*graphiteMetadat
 		return nil, fmt.Errorf("no %s given", graphiteServerAddress)
 	}
 
-	if val, ok := config.TriggerMetadata[graphiteQuery]; ok && val != "" {
-		meta.query = val
-	} else {
+	meta.query = config.TriggerMetadata[graphiteQuery]
+	if val, ok := config.TriggerMetadata[graphiteTargets]; ok && val != "" {
+		// the targets are separated by semicolons, as commas separate the arguments of the functions
+		for _, target := range strings.Split(val, ";") {
+			if target = strings.TrimSpace(target); target != "" {
+				meta.targets = append(meta.targets, target)
+			}
+		}
+	}
+	if meta.query == "" && len(meta.targets) == 0 {
 		return nil, fmt.Errorf("no %s given", graphiteQuery)
 	}
 
+	meta.consolidation = graphiteConsolidationLast
+	if val, ok := config.TriggerMetadata[graphiteConsolidation]; ok && val != "" {
+		if !slices.Contains(graphiteConsolidations, val) {
+			return nil, fmt.Errorf("%s must be one of %s", graphiteConsolidation, strings.Join(graphiteConsolidations, ", "))
+		}
+		meta.consolidation = val
+	}
+
+	if val, ok := config.TriggerMetadata[graphiteSeriesAggregation]; ok && val != "" {
+		if !slices.Contains(graphiteSeriesAggregations, val) {
+			return nil, fmt.Errorf("%s must be one of %s", graphiteSeriesAggregation, strings.Join(graphiteSeriesAggregations, ", "))
+		}
+		meta.seriesAggregation = val
+	}
+
 	if val, ok := config.TriggerMetadata[graphiteQueryTime]; ok && val != "" {
 		meta.from = val
 	} else {
@@ -161,9 +205,22 @@ func (s *graphiteScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 	return []v2.MetricSpec{metricSpec}
 }
 
+// queryTargets returns the query and the targets, as rendered together
+func (m *graphiteMetadata) queryTargets() []string {
+	if m.query == "" {
+		return m.targets
+	}
+	return append([]string{m.query}, m.targets...)
+}
+
 func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error) {
-	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
-	url := fmt.Sprintf("%s/render?from=%s&target=%s&format=json", s.metadata.serverAddress, s.metadata.from, queryEscaped)
+	params := url_pkg.Values{}
+	params.Set("from", s.metadata.from)
+	params.Set("format", "json")
+	for _, target := range s.metadata.queryTargets() {
+		params.Add("target", target)
+	}
+	url := fmt.Sprintf("%s/render?%s", s.metadata.serverAddress, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return -1, err
@@ -190,23 +247,77 @@ func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error)
 
 	if len(result) == 0 {
 		return 0, nil
-	} else if len(result) > 1 {
-		return -1, fmt.Errorf("graphite query %s returned multiple series", s.metadata.query)
+	} else if len(result) > 1 && s.metadata.seriesAggregation == "" {
+		return -1, fmt.Errorf("graphite query %s returned multiple series, set %s to combine them", strings.Join(s.metadata.queryTargets(), ", "), graphiteSeriesAggregation)
 	}
 
 	// https://graphite-api.readthedocs.io/en/latest/api.html#json
-	if len(result[0].Datapoints) == 0 {
-		return 0, nil
+	var aggregated float64
+	found, empty := 0, true
+	for _, series := range result {
+		if len(series.Datapoints) == 0 {
+			continue
+		}
+		empty = false
+
+		val, ok := consolidateGraphiteDatapoints(series.Datapoints, s.metadata.consolidation)
+		if !ok {
+			continue
+		}
+		switch {
+		case found == 0:
+			aggregated = val
+		case s.metadata.seriesAggregation == graphiteAggregationMax:
+			aggregated = max(aggregated, val)
+		case s.metadata.seriesAggregation == graphiteAggregationMin:
+			aggregated = min(aggregated, val)
+		case s.metadata.seriesAggregation == graphiteAggregationSum, s.metadata.seriesAggregation == graphiteAggregationAvg:
+			aggregated += val
+		}
+		found++
 	}
 
-	// Return the most recent non-null datapoint
-	for i := len(result[0].Datapoints) - 1; i >= 0; i-- {
-		if datapoint := result[0].Datapoints[i][0]; datapoint != nil {
-			return *datapoint, nil
+	if found == 0 {
+		if empty {
+			return 0, nil
 		}
+		return -1, fmt.Errorf("no valid non-null response in query %s, try increasing your queryTime or check your query", strings.Join(s.metadata.queryTargets(), ", "))
 	}
+	if s.metadata.seriesAggregation == graphiteAggregationAvg {
+		aggregated /= float64(found)
+	}
+	return aggregated, nil
+}
 
-	return -1, fmt.Errorf("no valid non-null response in query %s, try increasing your queryTime or check your query", s.metadata.query)
+// consolidateGraphiteDatapoints reduces the non-null datapoints of a series to a single value, the most recent one
+// by default. It returns false when all the datapoints are null.
+func consolidateGraphiteDatapoints(datapoints [][]*float64, consolidation string) (float64, bool) {
+	var result float64
+	count := 0
+	for i := len(datapoints) - 1; i >= 0; i-- {
+		if len(datapoints[i]) == 0 || datapoints[i][0] == nil {
+			continue
+		}
+		datapoint := *datapoints[i][0]
+		switch {
+		case count == 0:
+			result = datapoint
+		case consolidation == graphiteAggregationMax:
+			result = max(result, datapoint)
+		case consolidation == graphiteAggregationMin:
+			result = min(result, datapoint)
+		case consolidation == graphiteAggregationAvg:
+			result += datapoint
+		}
+		count++
+		if consolidation == graphiteConsolidationLast || consolidation == "" {
+			break
+		}
+	}
+	if count > 0 && consolidation == graphiteAggregationAvg {
+		result /= float64(count)
+	}
+	return result, count > 0
 }
 
 func (s *graphiteScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -37,6 +38,12 @@ var testGrapMetadata = []parseGraphiteMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "", "queryTime": "-30Seconds", "disableScaleToZero": "true"}, true},
 	// missing queryTime
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": ""}, true},
+	// targets without query
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "targets": "sumSeries(stats.counters.http.*.request.count); movingAverage(stats.gauges.queue.depth,'5min')", "queryTime": "-5Minutes", "consolidation": "max", "seriesAggregation": "sum"}, false},
+	// invalid consolidation
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "consolidation": "first"}, true},
+	// invalid series aggregation
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "seriesAggregation": "last"}, true},
 }
 
 var graphiteMetricIdentifiers = []graphiteMetricIdentifier{
@@ -192,3 +199,36 @@ func TestGrapScalerExecuteGrapQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestGrapScalerConsolidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, []string{"sumSeries(requests.*)", "movingAverage(queue.depth,'5min')"}, request.URL.Query()["target"])
+		assert.Equal(t, "-5Minutes", request.URL.Query().Get("from"))
+		_, _ = writer.Write([]byte(`[{"target":"sumSeries(requests.*)","datapoints":[[4,1],[8,2],[null,3]]},{"target":"movingAverage(queue.depth,'5min')","datapoints":[[3,1],[1,2],[2,3]]},{"target":"empty","datapoints":[[null,1]]}]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		consolidation     string
+		seriesAggregation string
+		expectedValue     float64
+	}{
+		{"last", "sum", 10},
+		{"max", "sum", 11},
+		{"min", "max", 4},
+		{"avg", "avg", 4},
+		{"last", "min", 2},
+	}
+	for _, test := range tests {
+		meta, err := parseGraphiteMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+			"serverAddress": server.URL, "queryTime": "-5Minutes", "query": "sumSeries(requests.*)", "targets": "movingAverage(queue.depth,'5min')",
+			"consolidation": test.consolidation, "seriesAggregation": test.seriesAggregation,
+		}})
+		require.NoError(t, err)
+		scaler := graphiteScaler{metadata: meta, httpClient: http.DefaultClient}
+
+		value, err := scaler.executeGrapQuery(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, test.expectedValue, value, "%s %s", test.consolidation, test.seriesAggregation)
+	}
+}
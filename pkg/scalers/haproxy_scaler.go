@@ -0,0 +1,262 @@
+package scalers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// haproxyShowBackendStats asks the runtime API for the statistics of all the backends, in CSV
+	haproxyShowBackendStats = "show stat -1 2 -1\n"
+	// haproxyBackendServer is the name of the line holding the statistics of the whole backend
+	haproxyBackendServer   = "BACKEND"
+	haproxyNativeStatsPath = "/v2/services/haproxy/stats/native"
+)
+
+type haproxyScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *haproxyMetadata
+	httpClient *http.Client
+	timeout    time.Duration
+	logger     logr.Logger
+}
+
+type haproxyMetadata struct {
+	triggerIndex int
+
+	// SocketAddress is the host:port of a stats socket bound to TCP, DataPlaneAPIURL the endpoint of the Data Plane API
+	SocketAddress   string `keda:"name=socketAddress,   order=triggerMetadata;resolvedEnv, optional"`
+	DataPlaneAPIURL string `keda:"name=dataPlaneAPIURL, order=triggerMetadata;resolvedEnv, optional"`
+	Backend         string `keda:"name=backend,         order=triggerMetadata"`
+	// Metric is the statistic of the backend, the number of queued requests or of current sessions
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=qcur;scur, optional, default=qcur"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=10"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+	Ca       string `keda:"name=ca,       order=authParams, optional"`
+}
+
+// haproxyNativeStats is the response of the Data Plane API, one item for each runtime API of HAProxy
+type haproxyNativeStats []struct {
+	RuntimeAPI string `json:"runtimeAPI"`
+	Error      string `json:"error"`
+	Stats      []struct {
+		Name  string             `json:"name"`
+		Type  string             `json:"type"`
+		Stats map[string]float64 `json:"stats"`
+	} `json:"stats"`
+}
+
+func (m *haproxyMetadata) Validate() error {
+	if (m.SocketAddress == "") == (m.DataPlaneAPIURL == "") {
+		return errors.New("exactly one of socketAddress or dataPlaneAPIURL must be provided")
+	}
+	if m.SocketAddress != "" {
+		if _, _, err := net.SplitHostPort(m.SocketAddress); err != nil {
+			return fmt.Errorf("socketAddress must be of the form host:port: %w", err)
+		}
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	m.DataPlaneAPIURL = strings.TrimSuffix(m.DataPlaneAPIURL, "/")
+	return nil
+}
+
+// NewHAProxyScaler creates a new haproxyScaler
+func NewHAProxyScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseHAProxyMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing haproxy metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Ca != "" {
+		tlsConfig, err := kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	return &haproxyScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		timeout:    config.GlobalHTTPTimeout,
+		logger:     InitializeLogger(config, "haproxy_scaler"),
+	}, nil
+}
+
+func parseHAProxyMetadata(config *scalersconfig.ScalerConfig) (*haproxyMetadata, error) {
+	meta := &haproxyMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *haproxyScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *haproxyScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("haproxy-%s-%s", s.metadata.Backend, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of queued requests or of current sessions of the backend
+func (s *haproxyScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var value float64
+	var err error
+	if s.metadata.SocketAddress != "" {
+		value, err = s.getSocketStat(ctx)
+	} else {
+		value, err = s.getDataPlaneAPIStat(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting %s of haproxy backend %s: %w", s.metadata.Metric, s.metadata.Backend, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getSocketStat reads the statistic of the backend from the stats socket, which closes
+// the connection once the command has been answered
+func (s *haproxyScaler) getSocketStat(ctx context.Context) (float64, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.metadata.SocketAddress)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if s.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := conn.Write([]byte(haproxyShowBackendStats)); err != nil {
+		return 0, err
+	}
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return 0, err
+	}
+
+	// the header is the first line, commented out
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(response), "# ")))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil || len(records) == 0 {
+		return 0, fmt.Errorf("unexpected response %q", strings.TrimSpace(string(response)))
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	proxy, server, stat := columns["pxname"], columns["svname"], columns[s.metadata.Metric]
+	if stat == 0 {
+		return 0, fmt.Errorf("statistic %s not found", s.metadata.Metric)
+	}
+	for _, record := range records[1:] {
+		if len(record) <= stat || record[proxy] != s.metadata.Backend || record[server] != haproxyBackendServer {
+			continue
+		}
+		if record[stat] == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(record[stat], 64)
+	}
+	return 0, errors.New("backend not found")
+}
+
+// getDataPlaneAPIStat reads the statistic of the backend from the Data Plane API, summing it
+// up over the runtime APIs of the processes of HAProxy
+func (s *haproxyScaler) getDataPlaneAPIStat(ctx context.Context) (float64, error) {
+	params := url.Values{"type": {"backend"}, "name": {s.metadata.Backend}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.DataPlaneAPIURL+haproxyNativeStatsPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, haproxyNativeStatsPath, strings.TrimSpace(string(body)))
+	}
+
+	var nativeStats haproxyNativeStats
+	if err := json.Unmarshal(body, &nativeStats); err != nil {
+		return 0, fmt.Errorf("error parsing statistics: %w", err)
+	}
+	var total float64
+	found := false
+	for _, runtime := range nativeStats {
+		if runtime.Error != "" {
+			return 0, fmt.Errorf("error reading statistics of %s: %s", runtime.RuntimeAPI, runtime.Error)
+		}
+		for _, stat := range runtime.Stats {
+			if stat.Type == "backend" && stat.Name == s.metadata.Backend {
+				total += stat.Stats[s.metadata.Metric]
+				found = true
+			}
+		}
+	}
+	if !found {
+		return 0, errors.New("backend not found")
+	}
+	return total, nil
+}
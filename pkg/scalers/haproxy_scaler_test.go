@@ -0,0 +1,166 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseHAProxyMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type haproxyMetricIdentifier struct {
+	metadataTestData *parseHAProxyMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testHAProxyMetadata = []parseHAProxyMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"socketAddress": "haproxy:9999", "backend": "be_app"}, map[string]string{}, false, "stats socket"},
+	{map[string]string{"dataPlaneAPIURL": "https://haproxy:5555", "backend": "be_app", "metric": "scur", "targetValue": "100", "activationTargetValue": "1"}, map[string]string{"username": "admin", "password": "secret", "ca": "caaa"}, false, "data plane api"},
+	{map[string]string{"socketAddress": "haproxy:9999"}, map[string]string{}, true, "no backend"},
+	{map[string]string{"socketAddress": "haproxy", "backend": "be_app"}, map[string]string{}, true, "socketAddress without port"},
+	{map[string]string{"socketAddress": "haproxy:9999", "dataPlaneAPIURL": "http://haproxy:5555", "backend": "be_app"}, map[string]string{}, true, "socketAddress and dataPlaneAPIURL"},
+	{map[string]string{"socketAddress": "haproxy:9999", "backend": "be_app", "metric": "rate"}, map[string]string{}, true, "unknown metric"},
+	{map[string]string{"socketAddress": "haproxy:9999", "backend": "be_app", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"dataPlaneAPIURL": "http://haproxy:5555", "backend": "be_app"}, map[string]string{"username": "admin"}, true, "username without password"},
+}
+
+var haproxyMetricIdentifiers = []haproxyMetricIdentifier{
+	{&testHAProxyMetadata[1], 0, "s0-haproxy-be_app-qcur"},
+	{&testHAProxyMetadata[2], 1, "s1-haproxy-be_app-scur"},
+}
+
+const haproxyTestStats = `# pxname,svname,qcur,qmax,scur,smax,slim,stot,status
+fe_http,FRONTEND,,,12,20,2000,340,OPEN
+be_app,app1,2,4,5,8,,120,UP
+be_app,app2,1,3,4,7,,110,UP
+be_app,BACKEND,7,9,9,15,200,230,UP
+be_idle,BACKEND,,,0,1,200,3,UP
+
+`
+
+func TestHAProxyParseMetadata(t *testing.T) {
+	for _, testData := range testHAProxyMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseHAProxyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestHAProxyGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range haproxyMetricIdentifiers {
+		meta, err := parseHAProxyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockHAProxyScaler := haproxyScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockHAProxyScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestHAProxyGetMetricsAndActivitySocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			command, _ := bufio.NewReader(conn).ReadString('\n')
+			assert.Equal(t, "show stat -1 2 -1\n", command)
+			_, _ = conn.Write([]byte(haproxyTestStats))
+			conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		metadata map[string]string
+		value    int64
+		isActive bool
+		err      string
+	}{
+		{map[string]string{"backend": "be_app"}, 7, true, ""},
+		{map[string]string{"backend": "be_app", "metric": "scur", "activationTargetValue": "10"}, 9, false, ""},
+		{map[string]string{"backend": "be_idle"}, 0, false, ""},
+		{map[string]string{"backend": "be_missing"}, 0, false, "backend not found"},
+	}
+	for _, test := range tests {
+		test.metadata["socketAddress"] = listener.Addr().String()
+		s, err := NewHAProxyScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata})
+		require.NoError(t, err)
+
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "haproxy")
+		if test.err != "" {
+			assert.ErrorContains(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.isActive, isActive)
+		assert.Equal(t, test.value, metrics[0].Value.Value())
+	}
+}
+
+func TestHAProxyGetMetricsAndActivityDataPlaneAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"message":"invalid username/password"}`))
+			return
+		}
+		assert.Equal(t, "/v2/services/haproxy/stats/native", r.URL.Path)
+		assert.Equal(t, "backend", r.URL.Query().Get("type"))
+		assert.Equal(t, "be_app", r.URL.Query().Get("name"))
+		_, _ = w.Write([]byte(`[
+			{"runtimeAPI":"/var/run/haproxy-1.sock","stats":[{"name":"be_app","type":"backend","stats":{"qcur":3,"scur":10}}]},
+			{"runtimeAPI":"/var/run/haproxy-2.sock","stats":[{"name":"be_app","type":"backend","stats":{"qcur":2,"scur":6}}]}
+		]`))
+	}))
+	defer server.Close()
+
+	s, err := NewHAProxyScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"dataPlaneAPIURL": server.URL, "backend": "be_app"},
+		AuthParams:      map[string]string{"username": "admin", "password": "secret"},
+	})
+	require.NoError(t, err)
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "haproxy")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(5), metrics[0].Value.Value())
+
+	s, err = NewHAProxyScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"dataPlaneAPIURL": server.URL, "backend": "be_app"},
+		AuthParams:      map[string]string{"username": "admin", "password": "wrong"},
+	})
+	require.NoError(t, err)
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "haproxy")
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,363 @@
+package hazelcast
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// types of the messages of the client protocol, the type of a response being the one of its request plus one
+const (
+	messageError          = 0x000000
+	messageAuthentication = 0x000100
+	messageMapSize        = 0x012a00
+	messageQueueSize      = 0x030300
+)
+
+// flags of the frames of a message
+const (
+	flagUnfragmented = 1<<15 | 1<<14
+	flagIsFinal      = 1 << 13
+	flagBeginData    = 1 << 12
+	flagEndData      = 1 << 11
+	flagIsNull       = 1 << 10
+	flagIsEvent      = 1 << 9
+)
+
+const (
+	protocolHeader = "CP2"
+	clientType     = "GOO"
+	clientVersion  = "5.3.0"
+	clientName     = "keda"
+
+	frameHeaderSize = 6
+	// offsets of the fields of the initial frame of requests and responses
+	requestHeaderSize   = 16
+	responseHeaderSize  = 13
+	serializationV1     = 1
+	authenticated       = 0
+	credentialsFailed   = 1
+	murmurSeed          = 0x01000193
+	stringTypeID        = -11
+	maxFrameSize        = 16 * 1024 * 1024
+	partitionKeyDivider = "@"
+)
+
+// authenticationErrors are the reasons of the failed authentications
+var authenticationErrors = map[byte]string{
+	credentialsFailed: "invalid credentials",
+	2:                 "serialization version mismatch",
+	3:                 "client not allowed in cluster",
+}
+
+// Config contains the information required to connect to the members of a Hazelcast cluster.
+type Config struct {
+	// Addresses are the host:port of the members, in the order they are tried
+	Addresses   []string
+	ClusterName string
+	Username    string
+	Password    string
+	// TLSConfig enables TLS when it isn't nil
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+type frame struct {
+	flags   uint16
+	content []byte
+}
+
+type conn struct {
+	net.Conn
+	r              *bufio.Reader
+	correlationID  int64
+	partitionCount int32
+}
+
+// QueueSize returns the number of items of the queue.
+func QueueSize(ctx context.Context, config *Config, name string) (int32, error) {
+	return query(ctx, config, func(c *conn) (int32, error) {
+		return c.size(messageQueueSize, c.partitionID(name), name)
+	})
+}
+
+// MapSize returns the number of entries of the map.
+func MapSize(ctx context.Context, config *Config, name string) (int32, error) {
+	return query(ctx, config, func(c *conn) (int32, error) {
+		return c.size(messageMapSize, -1, name)
+	})
+}
+
+// query runs the query on the first member which can be connected to, a member forwarding the
+// operations of the partitions it doesn't own to their owner
+func query(ctx context.Context, config *Config, f func(c *conn) (int32, error)) (int32, error) {
+	var errs []error
+	for _, address := range config.Addresses {
+		c, err := dial(ctx, config, address)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error connecting to %s: %w", address, err))
+			continue
+		}
+		value, err := c.query(config, f)
+		c.Close()
+		return value, err
+	}
+	if len(errs) == 0 {
+		return 0, errors.New("no address was set")
+	}
+	return 0, errors.Join(errs...)
+}
+
+func dial(ctx context.Context, config *Config, address string) (*conn, error) {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	var netConn net.Conn
+	var err error
+	if config.TLSConfig != nil {
+		netConn, err = (&tls.Dialer{NetDialer: dialer, Config: config.TLSConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if config.Timeout > 0 {
+		deadline = time.Now().Add(config.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := netConn.SetDeadline(deadline); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return &conn{Conn: netConn, r: bufio.NewReader(netConn)}, nil
+}
+
+func (c *conn) query(config *Config, f func(c *conn) (int32, error)) (int32, error) {
+	if err := c.authenticate(config); err != nil {
+		return 0, err
+	}
+	return f(c)
+}
+
+func (c *conn) authenticate(config *Config) error {
+	if _, err := c.Write([]byte(protocolHeader)); err != nil {
+		return err
+	}
+
+	// null client uuid and serialization version
+	fixed := append([]byte{1}, make([]byte, 16)...)
+	fixed = append(fixed, serializationV1)
+	frames := []frame{
+		stringFrame(config.ClusterName),
+		nullableStringFrame(config.Username),
+		nullableStringFrame(config.Password),
+		stringFrame(clientType),
+		stringFrame(clientVersion),
+		stringFrame(clientName),
+		// no label
+		{flags: flagBeginData},
+		{flags: flagEndData},
+	}
+	response, err := c.request(messageAuthentication, -1, fixed, frames...)
+	if err != nil {
+		return err
+	}
+
+	// status, member uuid, serialization version and partition count
+	initial := response[0].content
+	if len(initial) < responseHeaderSize+1+17+1+4 {
+		return errors.New("authentication response is too short")
+	}
+	if status := initial[responseHeaderSize]; status != authenticated {
+		if reason, found := authenticationErrors[status]; found {
+			return fmt.Errorf("authentication failed: %s", reason)
+		}
+		return fmt.Errorf("authentication failed with status %d", status)
+	}
+	c.partitionCount = int32(binary.LittleEndian.Uint32(initial[responseHeaderSize+1+17+1:]))
+	return nil
+}
+
+func (c *conn) size(messageType int32, partitionID int32, name string) (int32, error) {
+	response, err := c.request(messageType, partitionID, nil, stringFrame(name))
+	if err != nil {
+		return 0, err
+	}
+	initial := response[0].content
+	if len(initial) < responseHeaderSize+4 {
+		return 0, errors.New("size response is too short")
+	}
+	return int32(binary.LittleEndian.Uint32(initial[responseHeaderSize:])), nil
+}
+
+// request sends the request and returns the frames of its response
+func (c *conn) request(messageType int32, partitionID int32, fixed []byte, frames ...frame) ([]frame, error) {
+	c.correlationID++
+	initial := binary.LittleEndian.AppendUint32(nil, uint32(messageType))
+	initial = binary.LittleEndian.AppendUint64(initial, uint64(c.correlationID))
+	initial = binary.LittleEndian.AppendUint32(initial, uint32(partitionID))
+	initial = append(initial, fixed...)
+
+	frames = append([]frame{{flags: flagUnfragmented, content: initial}}, frames...)
+	frames[len(frames)-1].flags |= flagIsFinal
+	var buf []byte
+	for _, f := range frames {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(f.content)+frameHeaderSize))
+		buf = binary.LittleEndian.AppendUint16(buf, f.flags)
+		buf = append(buf, f.content...)
+	}
+	if _, err := c.Write(buf); err != nil {
+		return nil, err
+	}
+
+	for {
+		response, err := c.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		initial := response[0].content
+		if len(initial) < responseHeaderSize {
+			return nil, errors.New("response is too short")
+		}
+		// events and responses to other requests are skipped
+		if response[0].flags&flagIsEvent != 0 || int64(binary.LittleEndian.Uint64(initial[4:])) != c.correlationID {
+			continue
+		}
+		if binary.LittleEndian.Uint32(initial) == messageError {
+			return nil, decodeError(response)
+		}
+		return response, nil
+	}
+}
+
+// readMessage reads the frames of a message up to its final frame
+func (c *conn) readMessage() ([]frame, error) {
+	var frames []frame
+	for {
+		var header [frameHeaderSize]byte
+		if _, err := io.ReadFull(c.r, header[:]); err != nil {
+			return nil, err
+		}
+		length := binary.LittleEndian.Uint32(header[:])
+		if length < frameHeaderSize || length > maxFrameSize {
+			return nil, fmt.Errorf("invalid frame length %d", length)
+		}
+		f := frame{flags: binary.LittleEndian.Uint16(header[4:]), content: make([]byte, length-frameHeaderSize)}
+		if _, err := io.ReadFull(c.r, f.content); err != nil {
+			return nil, err
+		}
+		frames = append(frames, f)
+		if f.flags&flagIsFinal != 0 {
+			return frames, nil
+		}
+	}
+}
+
+// partitionID returns the partition of the data structure, the part of its name following @ being its partition key
+func (c *conn) partitionID(name string) int32 {
+	if _, key, found := strings.Cut(name, partitionKeyDivider); found {
+		name = key
+	}
+	if c.partitionCount <= 0 {
+		return -1
+	}
+	return partitionID(name, c.partitionCount)
+}
+
+// partitionID hashes the serialized key, a string being serialized as its length followed by its UTF-8 bytes
+func partitionID(key string, partitionCount int32) int32 {
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(key)))
+	hash := murmur3(append(data, key...), murmurSeed)
+	if hash == -1<<31 {
+		return 0
+	}
+	if hash < 0 {
+		hash = -hash
+	}
+	return hash % partitionCount
+}
+
+// murmur3 is the 32 bits x86 variant of MurmurHash3
+func murmur3(data []byte, seed uint32) int32 {
+	const c1, c2 = 0xcc9e2d51, 0x1b873593
+	h := seed
+	blocks := len(data) / 4
+	for i := 0; i < blocks; i++ {
+		k := binary.LittleEndian.Uint32(data[i*4:])
+		k *= c1
+		k = k<<15 | k>>17
+		k *= c2
+		h ^= k
+		h = h<<13 | h>>19
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := data[blocks*4:]
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = k<<15 | k>>17
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return int32(h)
+}
+
+// decodeError returns the class name and message of the first error of the list of errors of the response,
+// each error being a data structure made of a frame holding its code, its class name, message and stack trace
+func decodeError(response []frame) error {
+	var fields []frame
+	for _, f := range response[1:] {
+		if f.flags&(flagBeginData|flagEndData) != 0 {
+			if len(fields) > 0 {
+				break
+			}
+			continue
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) < 2 {
+		return errors.New("unknown error")
+	}
+	className := string(fields[1].content)
+	if len(fields) < 3 || fields[2].flags&flagIsNull != 0 {
+		return errors.New(className)
+	}
+	return fmt.Errorf("%s: %s", className, fields[2].content)
+}
+
+func stringFrame(s string) frame {
+	return frame{content: []byte(s)}
+}
+
+func nullableStringFrame(s string) frame {
+	if s == "" {
+		return frame{flags: flagIsNull}
+	}
+	return stringFrame(s)
+}
@@ -0,0 +1,146 @@
+package hazelcast
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPartitionCount = 271
+
+func writeTestMessage(w io.Writer, frames ...frame) {
+	frames[len(frames)-1].flags |= flagIsFinal
+	var buf []byte
+	for _, f := range frames {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(f.content)+frameHeaderSize))
+		buf = binary.LittleEndian.AppendUint16(buf, f.flags)
+		buf = append(buf, f.content...)
+	}
+	_, _ = w.Write(buf)
+}
+
+// testResponse returns the initial frame of a response to the request followed by the fields
+func testResponse(messageType int32, request frame, fields ...byte) frame {
+	content := binary.LittleEndian.AppendUint32(nil, uint32(messageType))
+	content = append(content, request.content[4:12]...)
+	content = append(content, 0)
+	return frame{flags: flagUnfragmented, content: append(content, fields...)}
+}
+
+// startServer starts a member holding the sizes of the queues and maps, the queues being checked to be
+// requested on their partition
+func startServer(t *testing.T, queues, maps map[string]int32) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			netConn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(t, &conn{Conn: netConn, r: bufio.NewReader(netConn)}, queues, maps)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serve(t *testing.T, c *conn, queues, maps map[string]int32) {
+	defer c.Close()
+	header := make([]byte, len(protocolHeader))
+	if _, err := io.ReadFull(c.r, header); err != nil || string(header) != protocolHeader {
+		return
+	}
+	for {
+		request, err := c.readMessage()
+		if err != nil {
+			return
+		}
+		messageType := int32(binary.LittleEndian.Uint32(request[0].content))
+		partition := int32(binary.LittleEndian.Uint32(request[0].content[12:]))
+
+		// an event is sent before the response to check that it's skipped
+		writeTestMessage(c, frame{flags: flagUnfragmented | flagIsEvent, content: make([]byte, responseHeaderSize)})
+
+		switch messageType {
+		case messageAuthentication:
+			status := byte(authenticated)
+			if string(request[1].content) != "dev" || string(request[2].content) != "keda" || string(request[3].content) != "secret" {
+				status = credentialsFailed
+			}
+			fields := append([]byte{status}, make([]byte, 17+1)...)
+			fields = binary.LittleEndian.AppendUint32(fields, testPartitionCount)
+			fields = append(fields, make([]byte, 17+1)...)
+			writeTestMessage(c, testResponse(messageAuthentication+1, request[0], fields...), frame{flags: flagIsNull}, stringFrame("5.3.0"))
+		case messageQueueSize, messageMapSize:
+			name := string(request[1].content)
+			sizes := maps
+			if messageType == messageQueueSize {
+				sizes = queues
+				assert.Equal(t, partitionID(name, testPartitionCount), partition)
+			}
+			size, found := sizes[name]
+			if !found {
+				writeTestMessage(c, testResponse(messageError, request[0]),
+					frame{flags: flagBeginData}, frame{flags: flagBeginData}, frame{content: binary.LittleEndian.AppendUint32(nil, 1)},
+					stringFrame("com.hazelcast.core.HazelcastException"), stringFrame("not found"),
+					frame{flags: flagBeginData}, frame{flags: flagEndData}, frame{flags: flagEndData}, frame{flags: flagEndData})
+				continue
+			}
+			writeTestMessage(c, testResponse(messageType+1, request[0], binary.LittleEndian.AppendUint32(nil, uint32(size))...))
+		}
+	}
+}
+
+func TestSize(t *testing.T) {
+	addr := startServer(t, map[string]int32{"orders": 42}, map[string]int32{"sessions": 7})
+	config := &Config{Addresses: []string{addr}, ClusterName: "dev", Username: "keda", Password: "secret", Timeout: time.Second}
+
+	size, err := QueueSize(context.Background(), config, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), size)
+
+	size, err = MapSize(context.Background(), config, "sessions")
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), size)
+
+	_, err = QueueSize(context.Background(), config, "missing")
+	assert.EqualError(t, err, "com.hazelcast.core.HazelcastException: not found")
+
+	config.Password = "wrong"
+	_, err = QueueSize(context.Background(), config, "orders")
+	assert.EqualError(t, err, "authentication failed: invalid credentials")
+}
+
+func TestSizeFailover(t *testing.T) {
+	addr := startServer(t, map[string]int32{"orders": 3}, nil)
+
+	// nothing listens on the port of the closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	config := &Config{Addresses: []string{unreachable, addr}, ClusterName: "dev", Username: "keda", Password: "secret", Timeout: time.Second}
+	size, err := QueueSize(context.Background(), config, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), size)
+
+	config.Addresses = []string{unreachable}
+	_, err = QueueSize(context.Background(), config, "orders")
+	assert.ErrorContains(t, err, "error connecting to "+unreachable)
+}
+
+func TestMurmur3(t *testing.T) {
+	assert.Equal(t, int32(0), murmur3(nil, 0))
+	assert.Equal(t, int32(0x248bfa47), murmur3([]byte("hello"), 0))
+	assert.Equal(t, int32(0x2e4ff723), murmur3([]byte("The quick brown fox jumps over the lazy dog"), 0))
+}
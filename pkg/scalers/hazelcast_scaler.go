@@ -0,0 +1,162 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/hazelcast"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type hazelcastScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *hazelcastMetadata
+	config     *hazelcast.Config
+	logger     logr.Logger
+}
+
+type hazelcastMetadata struct {
+	triggerIndex int
+
+	// Addresses are the host:port of the members, ServiceDNS the headless service of the members in Kubernetes
+	// whose addresses are resolved on each poll
+	Addresses   []string `keda:"name=addresses,   order=triggerMetadata;resolvedEnv, optional"`
+	ServiceDNS  string   `keda:"name=serviceDNS,  order=triggerMetadata, optional"`
+	ServicePort int      `keda:"name=servicePort, order=triggerMetadata, optional, default=5701"`
+	ClusterName string   `keda:"name=clusterName, order=triggerMetadata, optional, default=dev"`
+	// QueueName is the IQueue whose size is returned, MapName the IMap whose entries are counted
+	QueueName             string  `keda:"name=queueName,             order=triggerMetadata, optional"`
+	MapName               string  `keda:"name=mapName,               order=triggerMetadata, optional"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+
+	Username  string `keda:"name=username,  order=authParams;resolvedEnv, optional"`
+	Password  string `keda:"name=password,  order=authParams;resolvedEnv, optional"`
+	EnableTLS bool   `keda:"name=enableTLS, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+	Cert      string `keda:"name=cert,      order=authParams, optional"`
+	Key       string `keda:"name=key,       order=authParams, optional"`
+}
+
+func (m *hazelcastMetadata) Validate() error {
+	if (len(m.Addresses) == 0) == (m.ServiceDNS == "") {
+		return errors.New("exactly one of addresses or serviceDNS must be provided")
+	}
+	if (m.QueueName == "") == (m.MapName == "") {
+		return errors.New("exactly one of queueName or mapName must be provided")
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	return nil
+}
+
+// NewHazelcastScaler creates a new hazelcastScaler
+func NewHazelcastScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseHazelcastMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing hazelcast metadata: %w", err)
+	}
+
+	clientConfig := &hazelcast.Config{
+		Addresses:   meta.Addresses,
+		ClusterName: meta.ClusterName,
+		Username:    meta.Username,
+		Password:    meta.Password,
+		Timeout:     config.GlobalHTTPTimeout,
+	}
+	if meta.EnableTLS {
+		if clientConfig.TLSConfig, err = kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+
+	return &hazelcastScaler{
+		metricType: metricType,
+		metadata:   meta,
+		config:     clientConfig,
+		logger:     InitializeLogger(config, "hazelcast_scaler"),
+	}, nil
+}
+
+func parseHazelcastMetadata(config *scalersconfig.ScalerConfig) (*hazelcastMetadata, error) {
+	meta := &hazelcastMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close does nothing as a connection is opened for each poll
+func (s *hazelcastScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *hazelcastScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("hazelcast-queue-%s", s.metadata.QueueName)
+	if s.metadata.MapName != "" {
+		metricName = fmt.Sprintf("hazelcast-map-%s", s.metadata.MapName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of items of the queue or entries of the map
+func (s *hazelcastScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	size, err := s.getSize(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting hazelcast size: %w", err)
+	}
+
+	value := float64(size)
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *hazelcastScaler) getSize(ctx context.Context) (int32, error) {
+	config := s.config
+	if s.metadata.ServiceDNS != "" {
+		// the headless service resolves to the addresses of the ready members
+		hosts, err := net.DefaultResolver.LookupHost(ctx, s.metadata.ServiceDNS)
+		if err != nil {
+			return 0, fmt.Errorf("error resolving members of %s: %w", s.metadata.ServiceDNS, err)
+		}
+		resolved := *s.config
+		resolved.Addresses = nil
+		for _, host := range hosts {
+			resolved.Addresses = append(resolved.Addresses, net.JoinHostPort(host, strconv.Itoa(s.metadata.ServicePort)))
+		}
+		config = &resolved
+	}
+
+	if s.metadata.MapName != "" {
+		return hazelcast.MapSize(ctx, config, s.metadata.MapName)
+	}
+	return hazelcast.QueueSize(ctx, config, s.metadata.QueueName)
+}
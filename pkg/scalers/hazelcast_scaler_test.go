@@ -0,0 +1,71 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseHazelcastMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type hazelcastMetricIdentifier struct {
+	metadataTestData *parseHazelcastMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testHazelcastMetadata = []parseHazelcastMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"addresses": "hazelcast-0:5701,hazelcast-1:5701", "queueName": "orders"}, map[string]string{}, false, "queue with addresses"},
+	{map[string]string{"serviceDNS": "hazelcast.default.svc.cluster.local", "servicePort": "5702", "clusterName": "prod", "mapName": "sessions", "targetValue": "100", "activationTargetValue": "10"}, map[string]string{"username": "keda", "password": "secret"}, false, "map with kubernetes discovery"},
+	{map[string]string{"addresses": "hazelcast:5701", "queueName": "orders", "enableTLS": "true"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false, "tls"},
+	{map[string]string{"queueName": "orders"}, map[string]string{}, true, "missing addresses and serviceDNS"},
+	{map[string]string{"addresses": "hazelcast:5701", "serviceDNS": "hazelcast", "queueName": "orders"}, map[string]string{}, true, "addresses and serviceDNS"},
+	{map[string]string{"addresses": "hazelcast:5701"}, map[string]string{}, true, "missing queueName and mapName"},
+	{map[string]string{"addresses": "hazelcast:5701", "queueName": "orders", "mapName": "sessions"}, map[string]string{}, true, "queueName and mapName"},
+	{map[string]string{"addresses": "hazelcast:5701", "queueName": "orders", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"addresses": "hazelcast:5701", "queueName": "orders"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+}
+
+var hazelcastMetricIdentifiers = []hazelcastMetricIdentifier{
+	{&testHazelcastMetadata[1], 0, "s0-hazelcast-queue-orders"},
+	{&testHazelcastMetadata[2], 1, "s1-hazelcast-map-sessions"},
+}
+
+func TestHazelcastParseMetadata(t *testing.T) {
+	for _, testData := range testHazelcastMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseHazelcastMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestHazelcastGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range hazelcastMetricIdentifiers {
+		meta, err := parseHazelcastMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockHazelcastScaler := hazelcastScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockHazelcastScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package imap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// how the connection to the server is protected
+const (
+	TLSImplicit = "implicit"
+	TLSStartTLS = "starttls"
+	TLSNone     = "none"
+)
+
+// maxLiteralLength protects against reading garbage as the length of a literal
+const maxLiteralLength = 1024 * 1024
+
+// Config contains the information required to search the messages of a mailbox.
+type Config struct {
+	// Address is the host:port of the server
+	Address  string
+	Username string
+	Password string
+	// AccessToken authenticates with SASL XOAUTH2 instead of the password, e.g. for Gmail or Microsoft 365
+	AccessToken string
+	TLS         string
+	TLSConfig   *tls.Config
+	Timeout     time.Duration
+}
+
+// ResponseError is a NO or BAD response of the server to a command.
+type ResponseError struct {
+	Status  string
+	Message string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("imap %s: %s", e.Status, e.Message)
+}
+
+type session struct {
+	c    *Config
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+}
+
+// CountMessages returns the number of messages of the mailbox matching the search criteria, e.g. UNSEEN.
+// The mailbox is opened read-only so that the flags of its messages aren't changed.
+func CountMessages(ctx context.Context, c *Config, mailbox, criteria string) (int64, error) {
+	if strings.ContainsAny(c.Username+c.Password+mailbox+criteria, "\r\n") {
+		return 0, errors.New("credentials, mailbox and search criteria must not contain line breaks")
+	}
+	s, err := c.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer s.conn.Close()
+
+	if err := s.login(); err != nil {
+		return 0, err
+	}
+	if _, err := s.command("EXAMINE " + quote(mailbox)); err != nil {
+		return 0, err
+	}
+	lines, err := s.command("SEARCH " + criteria)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, line := range lines {
+		// the identifiers of the matching messages, e.g. SEARCH 2 84 882
+		if ids, found := cutPrefixFold(line, "SEARCH"); found {
+			count += int64(len(strings.Fields(ids)))
+		}
+	}
+
+	_, _ = s.command("LOGOUT")
+	return count, nil
+}
+
+// connect opens the connection, protected with TLS when required, and reads the greeting of the server
+func (c *Config) connect(ctx context.Context) (*session, error) {
+	host, _, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if c.Timeout > 0 {
+		deadline = time.Now().Add(c.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	s := &session{c: c, conn: conn}
+	if c.TLS == TLSImplicit {
+		if err := s.upgrade(host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	s.r = bufio.NewReader(s.conn)
+
+	greeting, err := s.readLine()
+	if err != nil {
+		s.conn.Close()
+		return nil, err
+	}
+	if status, message, _ := strings.Cut(strings.TrimPrefix(greeting, "* "), " "); !strings.EqualFold(status, "OK") {
+		s.conn.Close()
+		return nil, &ResponseError{Status: strings.ToUpper(status), Message: message}
+	}
+
+	if c.TLS == TLSStartTLS {
+		if _, err := s.command("STARTTLS"); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+		if err := s.upgrade(host); err != nil {
+			s.conn.Close()
+			return nil, err
+		}
+		s.r = bufio.NewReader(s.conn)
+	}
+	return s, nil
+}
+
+func (s *session) upgrade(host string) error {
+	var config *tls.Config
+	if s.c.TLSConfig != nil {
+		config = s.c.TLSConfig.Clone()
+	} else {
+		config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+	tlsConn := tls.Client(s.conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("error upgrading to tls: %w", err)
+	}
+	s.conn = tlsConn
+	return nil
+}
+
+// login authenticates with XOAUTH2 when an access token is set, with LOGIN otherwise
+func (s *session) login() error {
+	if s.c.AccessToken != "" {
+		response := fmt.Sprintf("user=%s\x01auth=Bearer %s\x01\x01", s.c.Username, s.c.AccessToken)
+		_, err := s.command("AUTHENTICATE XOAUTH2 " + base64.StdEncoding.EncodeToString([]byte(response)))
+		return err
+	}
+	_, err := s.command("LOGIN " + quote(s.c.Username) + " " + quote(s.c.Password))
+	return err
+}
+
+// command sends the command and returns the untagged responses received until its completion
+func (s *session) command(command string) ([]string, error) {
+	s.tag++
+	tag := "a" + strconv.Itoa(s.tag)
+	if _, err := io.WriteString(s.conn, tag+" "+command+"\r\n"); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case strings.HasPrefix(line, "* "):
+			lines = append(lines, line[2:])
+		case strings.HasPrefix(line, "+"):
+			// a challenge, which XOAUTH2 sends with the details of a failure, is answered with an empty response
+			if _, err := io.WriteString(s.conn, "\r\n"); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, tag+" "):
+			status, message, _ := strings.Cut(line[len(tag)+1:], " ")
+			if !strings.EqualFold(status, "OK") {
+				return nil, &ResponseError{Status: strings.ToUpper(status), Message: message}
+			}
+			return lines, nil
+		}
+	}
+}
+
+// readLine reads a line of response, the literals it holds being inlined
+func (s *session) readLine() (string, error) {
+	var b strings.Builder
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		b.WriteString(line)
+
+		// a literal ends the line with its length, e.g. {42}, its data following the line break
+		if !strings.HasSuffix(line, "}") {
+			return b.String(), nil
+		}
+		start := strings.LastIndex(line, "{")
+		if start < 0 {
+			return b.String(), nil
+		}
+		length, err := strconv.Atoi(line[start+1 : len(line)-1])
+		if err != nil {
+			return b.String(), nil
+		}
+		if length > maxLiteralLength {
+			return "", fmt.Errorf("literal of %d bytes is too long", length)
+		}
+		literal := make([]byte, length)
+		if _, err := io.ReadFull(s.r, literal); err != nil {
+			return "", err
+		}
+		b.Write(literal)
+	}
+}
+
+// quote returns the string as a quoted string
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	rest := s[len(prefix):]
+	if rest != "" && rest[0] != ' ' {
+		return s, false
+	}
+	return rest, true
+}
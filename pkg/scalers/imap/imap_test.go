@@ -0,0 +1,149 @@
+package imap
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIMAPServer answers SEARCH with the identifiers of the messages by mailbox and criteria
+type testIMAPServer struct {
+	search map[string]map[string]string
+}
+
+func startIMAPServer(t *testing.T, server *testIMAPServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (s *testIMAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reply := func(format string, args ...interface{}) {
+		_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+	reply("* OK [CAPABILITY IMAP4rev1 AUTH=XOAUTH2] ready")
+
+	r := bufio.NewReader(conn)
+	var mailbox string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		tag, command, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		name, args, _ := strings.Cut(command, " ")
+		switch name {
+		case "LOGIN":
+			if args != `"keda" "pa\"ss"` {
+				reply("%s NO [AUTHENTICATIONFAILED] Invalid credentials", tag)
+				continue
+			}
+			reply("%s OK LOGIN completed", tag)
+		case "AUTHENTICATE":
+			response, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(args, "XOAUTH2 "))
+			if string(response) != "user=keda\x01auth=Bearer token\x01\x01" {
+				reply(`+ eyJzdGF0dXMiOiI0MDAifQ==`)
+				_, _ = r.ReadString('\n')
+				reply("%s NO [AUTHENTICATIONFAILED] Invalid credentials", tag)
+				continue
+			}
+			reply("%s OK AUTHENTICATE completed", tag)
+		case "EXAMINE":
+			mailbox = strings.Trim(args, `"`)
+			if _, found := s.search[mailbox]; !found {
+				reply("%s NO [NONEXISTENT] Unknown Mailbox", tag)
+				continue
+			}
+			reply("* FLAGS (\\Answered \\Flagged \\Deleted \\Seen \\Draft $Processed)")
+			reply("* 172 EXISTS")
+			reply("* OK [UIDVALIDITY 3857529045] UIDs valid")
+			reply("%s OK [READ-ONLY] EXAMINE completed", tag)
+		case "SEARCH":
+			reply("* SEARCH %s", s.search[mailbox][args])
+			reply("%s OK SEARCH completed", tag)
+		case "LOGOUT":
+			reply("* BYE logging out")
+			reply("%s OK LOGOUT completed", tag)
+			return
+		default:
+			reply("%s BAD Unknown command", tag)
+		}
+	}
+}
+
+func TestCountMessages(t *testing.T) {
+	addr := startIMAPServer(t, &testIMAPServer{search: map[string]map[string]string{
+		"INBOX":   {"UNSEEN": "2 84 882", "UNKEYWORD $Processed": "84"},
+		"Archive": {"UNSEEN": ""},
+	}})
+
+	c := &Config{Address: addr, Username: "keda", Password: `pa"ss`, TLS: TLSNone, Timeout: time.Second}
+	count, err := CountMessages(context.Background(), c, "INBOX", "UNSEEN")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	count, err = CountMessages(context.Background(), c, "INBOX", "UNKEYWORD $Processed")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = CountMessages(context.Background(), c, "Archive", "UNSEEN")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	_, err = CountMessages(context.Background(), c, "Missing", "UNSEEN")
+	var response *ResponseError
+	require.ErrorAs(t, err, &response)
+	assert.Equal(t, "NO", response.Status)
+
+	_, err = CountMessages(context.Background(), c, "INBOX", "UNSEEN\r\na2 LOGOUT")
+	assert.ErrorContains(t, err, "line breaks")
+
+	c = &Config{Address: addr, Username: "keda", Password: "wrong", TLS: TLSNone, Timeout: time.Second}
+	_, err = CountMessages(context.Background(), c, "INBOX", "UNSEEN")
+	assert.ErrorContains(t, err, "AUTHENTICATIONFAILED")
+}
+
+func TestCountMessagesXOAuth2(t *testing.T) {
+	addr := startIMAPServer(t, &testIMAPServer{search: map[string]map[string]string{
+		"INBOX": {"UNSEEN": "1 2"},
+	}})
+
+	c := &Config{Address: addr, Username: "keda", AccessToken: "token", TLS: TLSNone, Timeout: time.Second}
+	count, err := CountMessages(context.Background(), c, "INBOX", "UNSEEN")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	c.AccessToken = "expired"
+	_, err = CountMessages(context.Background(), c, "INBOX", "UNSEEN")
+	assert.ErrorContains(t, err, "AUTHENTICATIONFAILED")
+}
+
+func TestReadLineLiteral(t *testing.T) {
+	s := &session{r: bufio.NewReader(strings.NewReader("* LIST () \"/\" {12}\r\nFoo\r\nBar\"Baz\r\n* OK\r\n"))}
+	line, err := s.readLine()
+	assert.NoError(t, err)
+	assert.Equal(t, "* LIST () \"/\" {12}Foo\r\nBar\"Baz", line)
+
+	line, err = s.readLine()
+	assert.NoError(t, err)
+	assert.Equal(t, "* OK", line)
+}
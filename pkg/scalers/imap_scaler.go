@@ -0,0 +1,137 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/imap"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type imapScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *imapMetadata
+	config     *imap.Config
+	logger     logr.Logger
+}
+
+type imapMetadata struct {
+	triggerIndex int
+
+	Host    string `keda:"name=host,    order=triggerMetadata;resolvedEnv"`
+	Port    int    `keda:"name=port,    order=triggerMetadata, optional"`
+	TLS     string `keda:"name=tls,     order=triggerMetadata, enum=implicit;starttls;none, optional, default=implicit"`
+	Mailbox string `keda:"name=mailbox, order=triggerMetadata, optional, default=INBOX"`
+	// SearchCriteria selects the messages to count with the IMAP SEARCH syntax, e.g. UNKEYWORD $Processed
+	// for the messages the workers haven't flagged as processed yet
+	SearchCriteria               string  `keda:"name=searchCriteria,               order=triggerMetadata, optional, default=UNSEEN"`
+	TargetMessageCount           float64 `keda:"name=targetMessageCount,           order=triggerMetadata, optional, default=10"`
+	ActivationTargetMessageCount float64 `keda:"name=activationTargetMessageCount, order=triggerMetadata, optional"`
+	UnsafeSsl                    bool    `keda:"name=unsafeSsl,                    order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+	// AccessToken is an OAuth2 access token authenticating with XOAUTH2 instead of the password
+	AccessToken string `keda:"name=accessToken, order=authParams;resolvedEnv, optional"`
+	Ca          string `keda:"name=ca,          order=authParams, optional"`
+}
+
+func (m *imapMetadata) Validate() error {
+	if m.TargetMessageCount <= 0 {
+		return errors.New("targetMessageCount must be greater than 0")
+	}
+	if (m.Password == "") == (m.AccessToken == "") {
+		return errors.New("exactly one of password or accessToken must be provided")
+	}
+	if strings.ContainsAny(m.Mailbox+m.SearchCriteria, "\r\n") {
+		return errors.New("mailbox and searchCriteria must not contain line breaks")
+	}
+
+	if m.Port == 0 {
+		m.Port = 143
+		if m.TLS == imap.TLSImplicit {
+			m.Port = 993
+		}
+	}
+	return nil
+}
+
+// NewIMAPScaler creates a new imapScaler
+func NewIMAPScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseIMAPMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing imap metadata: %w", err)
+	}
+
+	imapConfig := &imap.Config{
+		Address:     net.JoinHostPort(meta.Host, strconv.Itoa(meta.Port)),
+		Username:    meta.Username,
+		Password:    meta.Password,
+		AccessToken: meta.AccessToken,
+		TLS:         meta.TLS,
+		Timeout:     config.GlobalHTTPTimeout,
+	}
+	if meta.TLS != imap.TLSNone {
+		if imapConfig.TLSConfig, err = kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+
+	return &imapScaler{
+		metricType: metricType,
+		metadata:   meta,
+		config:     imapConfig,
+		logger:     InitializeLogger(config, "imap_scaler"),
+	}, nil
+}
+
+func parseIMAPMetadata(config *scalersconfig.ScalerConfig) (*imapMetadata, error) {
+	meta := &imapMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close does nothing as a connection is opened for each poll
+func (s *imapScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *imapScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("imap-%s", s.metadata.Mailbox))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetMessageCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of messages of the mailbox matching the search criteria
+func (s *imapScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := imap.CountMessages(ctx, s.config, s.metadata.Mailbox, s.metadata.SearchCriteria)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error searching mailbox %s: %w", s.metadata.Mailbox, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, float64(count) > s.metadata.ActivationTargetMessageCount, nil
+}
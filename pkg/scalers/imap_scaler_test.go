@@ -0,0 +1,160 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseIMAPMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type imapMetricIdentifier struct {
+	metadataTestData *parseIMAPMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testIMAPMetadata = []parseIMAPMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"host": "imap.example.com"}, map[string]string{"username": "keda", "password": "secret"}, false, "password"},
+	{map[string]string{"host": "imap.example.com", "port": "1143", "tls": "starttls", "mailbox": "Orders/Incoming", "searchCriteria": "UNKEYWORD $Processed", "targetMessageCount": "5", "activationTargetMessageCount": "1"}, map[string]string{"username": "keda", "accessToken": "token", "ca": "caaa"}, false, "access token"},
+	{map[string]string{"host": "imap.example.com"}, map[string]string{"username": "keda"}, true, "no password or access token"},
+	{map[string]string{"host": "imap.example.com"}, map[string]string{"username": "keda", "password": "secret", "accessToken": "token"}, true, "password and access token"},
+	{map[string]string{"host": "imap.example.com"}, map[string]string{"password": "secret"}, true, "no username"},
+	{map[string]string{"host": "imap.example.com", "tls": "ssl"}, map[string]string{"username": "keda", "password": "secret"}, true, "unknown tls"},
+	{map[string]string{"host": "imap.example.com", "searchCriteria": "UNSEEN\r\na1 LOGOUT"}, map[string]string{"username": "keda", "password": "secret"}, true, "line break in searchCriteria"},
+	{map[string]string{"host": "imap.example.com", "targetMessageCount": "0"}, map[string]string{"username": "keda", "password": "secret"}, true, "zero targetMessageCount"},
+}
+
+var imapMetricIdentifiers = []imapMetricIdentifier{
+	{&testIMAPMetadata[1], 0, "s0-imap-INBOX"},
+	{&testIMAPMetadata[2], 1, "s1-imap-Orders-Incoming"},
+}
+
+func TestIMAPParseMetadata(t *testing.T) {
+	for _, testData := range testIMAPMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseIMAPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestIMAPDefaultPort(t *testing.T) {
+	meta, err := parseIMAPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testIMAPMetadata[1].metadata, AuthParams: testIMAPMetadata[1].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, 993, meta.Port)
+
+	meta, err = parseIMAPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"host": "imap.example.com", "tls": "starttls"}, AuthParams: testIMAPMetadata[1].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, 143, meta.Port)
+}
+
+func TestIMAPGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range imapMetricIdentifiers {
+		meta, err := parseIMAPMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockIMAPScaler := imapScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockIMAPScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+// startIMAPTestServer starts an IMAP server answering SEARCH with the identifiers by criteria
+func startIMAPTestServer(t *testing.T, search map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reply := func(format string, args ...interface{}) {
+					_, _ = fmt.Fprintf(conn, format+"\r\n", args...)
+				}
+				reply("* OK ready")
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					tag, command, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+					name, args, _ := strings.Cut(command, " ")
+					switch name {
+					case "LOGIN", "EXAMINE":
+						reply("%s OK %s completed", tag, name)
+					case "SEARCH":
+						reply("* SEARCH %s", search[args])
+						reply("%s OK SEARCH completed", tag)
+					default:
+						reply("%s OK %s completed", tag, name)
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestIMAPGetMetricsAndActivity(t *testing.T) {
+	address := startIMAPTestServer(t, map[string]string{"UNSEEN": "3 4 5 9", "UNKEYWORD $Processed": "9"})
+	host, port, _ := net.SplitHostPort(address)
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"unseen messages", map[string]string{}, 4, true},
+		{"unprocessed messages", map[string]string{"searchCriteria": "UNKEYWORD $Processed", "activationTargetMessageCount": "1"}, 1, false},
+		{"no matching message", map[string]string{"searchCriteria": "FLAGGED"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["host"] = host
+			tc.metadata["port"] = port
+			tc.metadata["tls"] = "none"
+			s, err := NewIMAPScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"username": "keda", "password": "secret"}, GlobalHTTPTimeout: time.Second})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "imap")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -1,8 +1,15 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 
 	"github.com/go-logr/logr"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -14,25 +21,62 @@ import (
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	influxDBQueryLanguageFlux     = "flux"
+	influxDBQueryLanguageSQL      = "sql"
+	influxDBQueryLanguageInfluxQL = "influxql"
+)
+
 type influxDBScaler struct {
 	client     influxdb2.Client
+	httpClient *http.Client
 	metricType v2.MetricTargetType
 	metadata   *influxDBMetadata
 	logger     logr.Logger
 }
 
 type influxDBMetadata struct {
-	AuthToken                string  `keda:"name=authToken, order=triggerMetadata;resolvedEnv;authParams"`
-	OrganizationName         string  `keda:"name=organizationName, order=triggerMetadata;resolvedEnv;authParams"`
-	Query                    string  `keda:"name=query, order=triggerMetadata"`
+	AuthToken        string `keda:"name=authToken, order=triggerMetadata;resolvedEnv;authParams"`
+	OrganizationName string `keda:"name=organizationName, order=triggerMetadata;resolvedEnv;authParams, optional"`
+	// Database is the database of InfluxDB 3.x the SQL and InfluxQL queries run against
+	Database string `keda:"name=database, order=triggerMetadata;resolvedEnv;authParams, optional"`
+	Query    string `keda:"name=query, order=triggerMetadata"`
+	// QueryLanguage is flux for InfluxDB 2.x, or sql or influxql for the query API of InfluxDB 3.x
+	QueryLanguage            string  `keda:"name=queryLanguage, order=triggerMetadata, enum=flux;sql;influxql, default=flux"`
 	ServerURL                string  `keda:"name=serverURL, order=triggerMetadata;authParams"`
 	UnsafeSsl                bool    `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
 	ThresholdValue           float64 `keda:"name=thresholdValue, order=triggerMetadata, optional"`
 	ActivationThresholdValue float64 `keda:"name=activationThresholdValue, order=triggerMetadata, optional"`
 
+	// TLS
+	CA          string `keda:"name=ca, order=authParams, optional"`
+	Cert        string `keda:"name=cert, order=authParams, optional"`
+	Key         string `keda:"name=key, order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+
 	triggerIndex int
 }
 
+func (m *influxDBMetadata) Validate() error {
+	if m.QueryLanguage == influxDBQueryLanguageFlux && m.OrganizationName == "" {
+		return errors.New("no organizationName given")
+	}
+	if m.QueryLanguage != influxDBQueryLanguageFlux && m.Database == "" {
+		return fmt.Errorf("no database given, it is required with the %s query language", m.QueryLanguage)
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	return nil
+}
+
+func (m *influxDBMetadata) tlsConfig() (*tls.Config, error) {
+	if m.Cert == "" && m.CA == "" {
+		return util.CreateTLSClientConfig(m.UnsafeSsl), nil
+	}
+	return util.NewTLSConfigWithPassword(m.Cert, m.Key, m.KeyPassword, m.CA, m.UnsafeSsl)
+}
+
 // NewInfluxDBScaler creates a new influx db scaler
 func NewInfluxDBScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -47,11 +91,27 @@ func NewInfluxDBScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing influxdb metadata: %w", err)
 	}
 
+	tlsConfig, err := meta.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error creating influxdb tls config: %w", err)
+	}
+
+	if meta.QueryLanguage != influxDBQueryLanguageFlux {
+		httpClient := util.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+		httpClient.Transport = util.CreateHTTPTransportWithTLSConfig(tlsConfig)
+		return &influxDBScaler{
+			httpClient: httpClient,
+			metricType: metricType,
+			metadata:   meta,
+			logger:     logger,
+		}, nil
+	}
+
 	logger.Info("starting up influxdb client")
 	client := influxdb2.NewClientWithOptions(
 		meta.ServerURL,
 		meta.AuthToken,
-		influxdb2.DefaultOptions().SetTLSConfig(util.CreateTLSClientConfig(meta.UnsafeSsl)))
+		influxdb2.DefaultOptions().SetTLSConfig(tlsConfig))
 
 	return &influxDBScaler{
 		client:     client,
@@ -78,7 +138,12 @@ func parseInfluxDBMetadata(config *scalersconfig.ScalerConfig) (*influxDBMetadat
 
 // Close closes the connection of the client to the server
 func (s *influxDBScaler) Close(context.Context) error {
-	s.client.Close()
+	if s.client != nil {
+		s.client.Close()
+	}
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -106,12 +171,85 @@ func queryInfluxDB(ctx context.Context, queryAPI api.QueryAPI, query string) (fl
 	}
 }
 
+// queryInfluxDBV3 runs the SQL or InfluxQL query with the query API of InfluxDB 3.x, the value of interest being the
+// first numeric column of the first row returned
+func (s *influxDBScaler) queryInfluxDBV3(ctx context.Context) (float64, error) {
+	body, err := json.Marshal(map[string]string{
+		"db":     s.metadata.Database,
+		"q":      s.metadata.Query,
+		"format": "json",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("%s/api/v3/query_%s", strings.TrimRight(s.metadata.ServerURL, "/"), s.metadata.QueryLanguage)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.metadata.AuthToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("influxdb query api returned error. status: %d response: %s", resp.StatusCode, string(respBody))
+	}
+
+	var rows []json.RawMessage
+	if err := json.Unmarshal(respBody, &rows); err != nil {
+		return 0, fmt.Errorf("error decoding influxdb query response: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, fmt.Errorf("no results found from query")
+	}
+	return firstNumericColumn(rows[0])
+}
+
+// firstNumericColumn returns the value of the first numeric column of the row, in the order of the columns of the
+// query
+func firstNumericColumn(row json.RawMessage) (float64, error) {
+	decoder := json.NewDecoder(bytes.NewReader(row))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return 0, fmt.Errorf("unexpected row %s in query response", string(row))
+	}
+	for decoder.More() {
+		// column name
+		if _, err := decoder.Token(); err != nil {
+			return 0, err
+		}
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return 0, err
+		}
+		if number, ok := value.(json.Number); ok {
+			return number.Float64()
+		}
+	}
+	return 0, fmt.Errorf("no numeric value found in row %s", string(row))
+}
+
 // GetMetricsAndActivity connects to influxdb via the client and returns a value based on the query
 func (s *influxDBScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	// Grab QueryAPI to make queries to influxdb instance
-	queryAPI := s.client.QueryAPI(s.metadata.OrganizationName)
-
-	value, err := queryInfluxDB(ctx, queryAPI, s.metadata.Query)
+	var value float64
+	var err error
+	if s.metadata.QueryLanguage == influxDBQueryLanguageSQL || s.metadata.QueryLanguage == influxDBQueryLanguageInfluxQL {
+		value, err = s.queryInfluxDBV3(ctx)
+	} else {
+		// Grab QueryAPI to make queries to influxdb instance
+		queryAPI := s.client.QueryAPI(s.metadata.OrganizationName)
+		value, err = queryInfluxDB(ctx, queryAPI, s.metadata.Query)
+	}
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
@@ -121,11 +259,19 @@ func (s *influxDBScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationThresholdValue, nil
 }
 
+// metricSource is the organization of the flux queries, or the database of the queries of InfluxDB 3.x
+func (s *influxDBScaler) metricSource() string {
+	if s.metadata.QueryLanguage == influxDBQueryLanguageSQL || s.metadata.QueryLanguage == influxDBQueryLanguageInfluxQL {
+		return s.metadata.Database
+	}
+	return s.metadata.OrganizationName
+}
+
 // GetMetricSpecForScaling returns the metric spec for the Horizontal Pod Autoscaler
 func (s *influxDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, util.NormalizeString(fmt.Sprintf("influxdb-%s", s.metadata.OrganizationName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, util.NormalizeString(fmt.Sprintf("influxdb-%s", s.metricSource()))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.ThresholdValue),
 	}
@@ -2,10 +2,15 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -50,11 +55,22 @@ var testInfluxDBMetadata = []parseInfluxDBMetadataTestData{
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
 	// 11 wrong activationThreshold valuequeryInfluxDB
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "activationThresholdValue": "aa", "authToken": "myToken", "unsafeSsl": "false"}, true, map[string]string{}},
+	// 12 sql query against a database of InfluxDB 3.x
+	{map[string]string{"serverURL": "https://influxdata.com", "database": "metrics", "queryLanguage": "sql", "query": "SELECT count(*) FROM requests", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
+	// 13 influxql query without database
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "query": "SELECT count(*) FROM requests", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
+	// 14 invalid query language
+	{map[string]string{"serverURL": "https://influxdata.com", "database": "metrics", "queryLanguage": "promql", "query": "up", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
+	// 15 client certificate without key
+	{map[string]string{"serverURL": "https://influxdata.com", "database": "metrics", "queryLanguage": "sql", "query": "SELECT count(*) FROM requests", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{"cert": "cert"}},
+	// 16 mTLS
+	{map[string]string{"serverURL": "https://influxdata.com", "database": "metrics", "queryLanguage": "sql", "query": "SELECT count(*) FROM requests", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}},
 }
 
 var influxDBMetricIdentifiers = []influxDBMetricIdentifier{
 	{&testInfluxDBMetadata[1], 0, "s0-influxdb-influx_org"},
 	{&testInfluxDBMetadata[2], 1, "s1-influxdb-influx_org"},
+	{&testInfluxDBMetadata[11], 2, "s2-influxdb-metrics"},
 }
 
 func TestInfluxDBParseMetadata(t *testing.T) {
@@ -77,7 +93,7 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), "", meta, logr.Discard()}
+		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), nil, "", meta, logr.Discard()}
 
 		metricSpec := mockInfluxDBScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -86,3 +102,26 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestInfluxDBQueryV3(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/api/v3/query_sql", request.URL.Path)
+		assert.Equal(t, "Bearer myToken", request.Header.Get("Authorization"))
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"db": "metrics", "q": "SELECT time, host, count(*) AS requests FROM requests", "format": "json"}, body)
+
+		_, _ = writer.Write([]byte(`[{"time":"2024-01-01T00:00:00","host":"a","requests":42,"other":1},{"time":"2024-01-01T00:01:00","host":"b","requests":3,"other":2}]`))
+	}))
+	defer server.Close()
+
+	scaler, err := NewInfluxDBScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverURL": server.URL, "database": "metrics", "queryLanguage": "sql", "query": "SELECT time, host, count(*) AS requests FROM requests", "thresholdValue": "10", "authToken": "myToken"},
+	})
+	require.NoError(t, err)
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-influxdb-metrics")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, int64(42), metrics[0].Value.Value())
+}
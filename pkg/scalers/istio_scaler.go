@@ -0,0 +1,158 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	istioMetricLatency = "latency"
+
+	istioRequestsTotal          = "istio_requests_total"
+	istioRequestDurationBuckets = "istio_request_duration_milliseconds_bucket"
+)
+
+// istioScaler builds the PromQL query of the standard metrics of Istio and runs it with the prometheus scaler,
+// which brings the authentication to the Prometheus server the telemetry of the mesh is scraped into
+type istioScaler struct {
+	metadata   *istioMetadata
+	prometheus *prometheusScaler
+}
+
+type istioMetadata struct {
+	triggerIndex int
+
+	ServerAddress string `keda:"name=serverAddress, order=triggerMetadata"`
+	// DestinationWorkload or DestinationService select the requests to the workload or the service
+	DestinationWorkload  string `keda:"name=destinationWorkload,  order=triggerMetadata, optional"`
+	DestinationService   string `keda:"name=destinationService,   order=triggerMetadata, optional"`
+	DestinationNamespace string `keda:"name=destinationNamespace, order=triggerMetadata, optional"`
+	SourceWorkload       string `keda:"name=sourceWorkload,       order=triggerMetadata, optional"`
+	// Reporter is the side of the requests reporting them, the destination proxy also counting the requests of sources outside of the mesh
+	Reporter string  `keda:"name=reporter, order=triggerMetadata, enum=destination;source, optional, default=destination"`
+	Metric   string  `keda:"name=metric,   order=triggerMetadata, enum=requestRate;latency, optional, default=requestRate"`
+	Quantile float64 `keda:"name=quantile, order=triggerMetadata, optional, default=0.99"`
+	// Window is the range over which the rate of the requests is computed
+	Window              string  `keda:"name=window,              order=triggerMetadata, optional, default=1m"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
+
+	query string
+}
+
+func (m *istioMetadata) Validate() error {
+	if (m.DestinationWorkload == "") == (m.DestinationService == "") {
+		return errors.New("exactly one of destinationWorkload or destinationService must be provided")
+	}
+	if m.Quantile <= 0 || m.Quantile >= 1 {
+		return errors.New("quantile must be between 0 and 1")
+	}
+	if window, err := time.ParseDuration(m.Window); err != nil || window <= 0 {
+		return fmt.Errorf("window must be a positive duration, e.g. 1m, got %q", m.Window)
+	}
+	if m.Threshold <= 0 {
+		return errors.New("threshold must be greater than 0")
+	}
+	return nil
+}
+
+// NewIstioScaler creates a new istioScaler
+func NewIstioScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	meta, err := parseIstioMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing istio metadata: %w", err)
+	}
+
+	// the remaining trigger metadata, e.g. unsafeSsl or customHeaders, and the authentication are handed over as is
+	prometheusConfig := *config
+	prometheusConfig.TriggerMetadata = maps.Clone(config.TriggerMetadata)
+	prometheusConfig.TriggerMetadata["query"] = meta.query
+	prometheusConfig.TriggerMetadata["threshold"] = strconv.FormatFloat(meta.Threshold, 'f', -1, 64)
+	prometheusConfig.TriggerMetadata["activationThreshold"] = strconv.FormatFloat(meta.ActivationThreshold, 'f', -1, 64)
+	prometheus, err := NewPrometheusScaler(&prometheusConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &istioScaler{
+		metadata:   meta,
+		prometheus: prometheus.(*prometheusScaler),
+	}, nil
+}
+
+func parseIstioMetadata(config *scalersconfig.ScalerConfig) (*istioMetadata, error) {
+	meta := &istioMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if meta.DestinationNamespace == "" {
+		meta.DestinationNamespace = config.ScalableObjectNamespace
+	}
+	meta.query = buildIstioQuery(meta)
+	return meta, nil
+}
+
+// buildIstioQuery returns the rate of the requests matching the labels, or the quantile of their duration in milliseconds.
+// The quantile is 0 rather than NaN when no request was received over the window.
+func buildIstioQuery(meta *istioMetadata) string {
+	labels := []string{fmt.Sprintf("reporter=%q", meta.Reporter)}
+	if meta.DestinationWorkload != "" {
+		labels = append(labels, fmt.Sprintf("destination_workload=%q", meta.DestinationWorkload))
+		if meta.DestinationNamespace != "" {
+			labels = append(labels, fmt.Sprintf("destination_workload_namespace=%q", meta.DestinationNamespace))
+		}
+	} else {
+		labels = append(labels, fmt.Sprintf("destination_service_name=%q", meta.DestinationService))
+		if meta.DestinationNamespace != "" {
+			labels = append(labels, fmt.Sprintf("destination_service_namespace=%q", meta.DestinationNamespace))
+		}
+	}
+	if meta.SourceWorkload != "" {
+		labels = append(labels, fmt.Sprintf("source_workload=%q", meta.SourceWorkload))
+	}
+	selector := strings.Join(labels, ",")
+
+	if meta.Metric == istioMetricLatency {
+		return fmt.Sprintf("(histogram_quantile(%s, sum by (le) (rate(%s{%s}[%s]))) >= 0) or vector(0)",
+			strconv.FormatFloat(meta.Quantile, 'f', -1, 64), istioRequestDurationBuckets, selector, meta.Window)
+	}
+	return fmt.Sprintf("sum(rate(%s{%s}[%s]))", istioRequestsTotal, selector, meta.Window)
+}
+
+// Close closes the http client connection of the prometheus scaler
+func (s *istioScaler) Close(ctx context.Context) error {
+	return s.prometheus.Close(ctx)
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *istioScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	destination := s.metadata.DestinationWorkload
+	if destination == "" {
+		destination = s.metadata.DestinationService
+	}
+	metricName := kedautil.NormalizeString(fmt.Sprintf("istio-%s-%s", destination, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.prometheus.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the request rate or the latency of the destination
+func (s *istioScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	return s.prometheus.GetMetricsAndActivity(ctx, metricName)
+}
@@ -0,0 +1,103 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseIstioMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type istioMetricIdentifier struct {
+	metadataTestData *parseIstioMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testIstioMetadata = []parseIstioMetadataTestData{
+	{map[string]string{}, true, "nothing passed"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1", "threshold": "100"}, false, "request rate of a workload"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationService": "reviews", "destinationNamespace": "bookinfo", "metric": "latency", "quantile": "0.95", "window": "5m", "threshold": "250"}, false, "latency of a service"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "threshold": "100"}, true, "no destination"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1", "destinationService": "reviews", "threshold": "100"}, true, "workload and service"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1"}, true, "no threshold"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1", "threshold": "100", "metric": "errors"}, true, "unknown metric"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1", "threshold": "100", "quantile": "99"}, true, "quantile out of range"},
+	{map[string]string{"serverAddress": "http://prometheus:9090", "destinationWorkload": "reviews-v1", "threshold": "100", "window": "minute"}, true, "invalid window"},
+}
+
+var istioMetricIdentifiers = []istioMetricIdentifier{
+	{&testIstioMetadata[1], 0, "s0-istio-reviews-v1-requestRate"},
+	{&testIstioMetadata[2], 1, "s1-istio-reviews-latency"},
+}
+
+func TestIstioParseMetadata(t *testing.T) {
+	for _, testData := range testIstioMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseIstioMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestIstioBuildQuery(t *testing.T) {
+	meta, err := parseIstioMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testIstioMetadata[1].metadata, ScalableObjectNamespace: "bookinfo"})
+	require.NoError(t, err)
+	assert.Equal(t, `sum(rate(istio_requests_total{reporter="destination",destination_workload="reviews-v1",destination_workload_namespace="bookinfo"}[1m]))`, meta.query)
+
+	meta, err = parseIstioMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testIstioMetadata[2].metadata, ScalableObjectNamespace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, `(histogram_quantile(0.95, sum by (le) (rate(istio_request_duration_milliseconds_bucket{reporter="destination",destination_service_name="reviews",destination_service_namespace="bookinfo"}[5m]))) >= 0) or vector(0)`, meta.query)
+}
+
+func TestIstioGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range istioMetricIdentifiers {
+		s, err := NewIstioScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not create scaler:", err)
+		}
+
+		metricSpec := s.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestIstioGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("query"), `destination_workload="reviews-v1"`)
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "keda:secret", user+":"+password)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"42.5"]}]}}`))
+	}))
+	defer server.Close()
+
+	s, err := NewIstioScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "destinationWorkload": "reviews-v1", "threshold": "100", "activationThreshold": "50", "authModes": "basic"},
+		AuthParams:      map[string]string{"username": "keda", "password": "secret"},
+	})
+	require.NoError(t, err)
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "istio")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(42500), metrics[0].Value.MilliValue())
+}
@@ -0,0 +1,230 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	jenkinsQueuePath = "/queue/api/json?tree=items[blocked,why]"
+	// jenkinsExecutorsTree only fetches the executor counts of a label or of all the computers
+	jenkinsExecutorsTree = "?tree=busyExecutors,totalExecutors"
+)
+
+// jenkinsWhyLabelRegex extracts the label of the reason an item is waiting for an executor, e.g.
+// "Waiting for next available executor on ‘linux && docker’" or "There are no nodes with the label ‘linux’"
+var jenkinsWhyLabelRegex = regexp.MustCompile(`label ‘(.+)’|executor on ‘(.+)’`)
+
+// jenkinsLabelOperatorsRegex matches the operators and spaces of a label expression, which aren't valid in metric names
+var jenkinsLabelOperatorsRegex = regexp.MustCompile(`[\s&|!<>=()]+`)
+
+type jenkinsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *jenkinsMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type jenkinsMetadata struct {
+	triggerIndex int
+
+	URL                   string  `keda:"name=url,                   order=triggerMetadata;resolvedEnv"`
+	LabelExpression       string  `keda:"name=labelExpression,       order=triggerMetadata, optional"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=1"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	IncludeBusyExecutors  bool    `keda:"name=includeBusyExecutors,  order=triggerMetadata, optional, default=true"`
+	IncludeBlocked        bool    `keda:"name=includeBlocked,        order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username    string `keda:"name=username,    order=authParams;resolvedEnv, optional"`
+	APIToken    string `keda:"name=apiToken,    order=authParams;resolvedEnv, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams;resolvedEnv, optional"`
+}
+
+type jenkinsQueue struct {
+	Items []struct {
+		Blocked bool   `json:"blocked"`
+		Why     string `json:"why"`
+	} `json:"items"`
+}
+
+type jenkinsExecutors struct {
+	BusyExecutors  int64 `json:"busyExecutors"`
+	TotalExecutors int64 `json:"totalExecutors"`
+}
+
+func (m *jenkinsMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.APIToken == "") {
+		return errors.New("both username and apiToken must be provided")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return errors.New("username and apiToken can't be used with bearerToken")
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	return nil
+}
+
+// NewJenkinsScaler creates a new jenkinsScaler
+func NewJenkinsScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseJenkinsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing jenkins metadata: %w", err)
+	}
+
+	return &jenkinsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "jenkins_scaler"),
+	}, nil
+}
+
+func parseJenkinsMetadata(config *scalersconfig.ScalerConfig) (*jenkinsMetadata, error) {
+	meta := &jenkinsMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *jenkinsScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *jenkinsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := "jenkins"
+	if s.metadata.LabelExpression != "" {
+		name += "-" + jenkinsLabelOperatorsRegex.ReplaceAllString(s.metadata.LabelExpression, "-")
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of queued builds, including the builds running on busy executors
+func (s *jenkinsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queued, err := s.getQueuedItemCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting jenkins build queue: %w", err)
+	}
+
+	builds := queued
+	if s.metadata.IncludeBusyExecutors {
+		executors, err := s.getExecutors(ctx)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting jenkins executors: %w", err)
+		}
+		builds += executors.BusyExecutors
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(builds))
+	return []external_metrics.ExternalMetricValue{metric}, float64(builds) > s.metadata.ActivationTargetValue, nil
+}
+
+// getQueuedItemCount returns the number of items of the queue waiting for an executor. The label an item is
+// assigned to isn't part of the JSON API, so it's matched with the label expression of the reason it's waiting.
+func (s *jenkinsScaler) getQueuedItemCount(ctx context.Context) (int64, error) {
+	var queue jenkinsQueue
+	if err := s.getJSON(ctx, jenkinsQueuePath, &queue); err != nil {
+		return -1, err
+	}
+
+	var count int64
+	for _, item := range queue.Items {
+		if item.Blocked && !s.metadata.IncludeBlocked {
+			continue
+		}
+		if s.metadata.LabelExpression != "" && jenkinsWhyLabel(item.Why) != s.metadata.LabelExpression {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// getExecutors returns the executors of the agents matching the label expression, or of all the agents
+func (s *jenkinsScaler) getExecutors(ctx context.Context) (*jenkinsExecutors, error) {
+	path := "/computer/api/json"
+	if s.metadata.LabelExpression != "" {
+		path = "/label/" + url.PathEscape(s.metadata.LabelExpression) + "/api/json"
+	}
+
+	var executors jenkinsExecutors
+	if err := s.getJSON(ctx, path+jenkinsExecutorsTree, &executors); err != nil {
+		return nil, err
+	}
+	return &executors, nil
+}
+
+func jenkinsWhyLabel(why string) string {
+	match := jenkinsWhyLabelRegex.FindStringSubmatch(why)
+	if match == nil {
+		return ""
+	}
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
+}
+
+func (s *jenkinsScaler) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+s.metadata.BearerToken)
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.APIToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, string(body))
+	}
+	return json.Unmarshal(body, v)
+}
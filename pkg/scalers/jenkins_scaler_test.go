@@ -0,0 +1,134 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseJenkinsMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type jenkinsMetricIdentifier struct {
+	metadataTestData *parseJenkinsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testJenkinsMetadata = []parseJenkinsMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://jenkins:8080"}, map[string]string{}, false, "url only"},
+	{map[string]string{"url": "http://jenkins:8080", "labelExpression": "linux && docker", "targetValue": "2", "activationTargetValue": "1", "includeBusyExecutors": "false", "includeBlocked": "true"}, map[string]string{"username": "admin", "apiToken": "token"}, false, "all metadata with api token"},
+	{map[string]string{"url": "http://jenkins:8080"}, map[string]string{"bearerToken": "token"}, false, "bearer token"},
+	{map[string]string{"url": "http://jenkins:8080", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"url": "http://jenkins:8080"}, map[string]string{"username": "admin"}, true, "username without api token"},
+	{map[string]string{"url": "http://jenkins:8080"}, map[string]string{"username": "admin", "apiToken": "token", "bearerToken": "token"}, true, "api token and bearer token"},
+}
+
+var jenkinsMetricIdentifiers = []jenkinsMetricIdentifier{
+	{&testJenkinsMetadata[1], 0, "s0-jenkins"},
+	{&testJenkinsMetadata[2], 1, "s1-jenkins-linux-docker"},
+}
+
+func TestJenkinsParseMetadata(t *testing.T) {
+	for _, testData := range testJenkinsMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseJenkinsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestJenkinsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range jenkinsMetricIdentifiers {
+		meta, err := parseJenkinsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockJenkinsScaler := jenkinsScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockJenkinsScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestJenkinsGetMetricsAndActivity(t *testing.T) {
+	responses := map[string]string{
+		"/queue/api/json": `{"items":[
+			{"blocked":false,"why":"Waiting for next available executor on ‘linux’"},
+			{"blocked":false,"why":"There are no nodes with the label ‘linux’"},
+			{"blocked":false,"why":"Waiting for next available executor on ‘windows’"},
+			{"blocked":true,"why":"Build #12 is already in progress (ETA: 1 min 3 sec)"}
+		]}`,
+		"/computer/api/json":    `{"busyExecutors":5,"totalExecutors":8}`,
+		"/label/linux/api/json": `{"busyExecutors":2,"totalExecutors":2}`,
+		"/label/macos/api/json": `{"busyExecutors":0,"totalExecutors":0}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, apiToken, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "token", apiToken)
+
+		response, found := responses[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"queued builds and busy executors", map[string]string{}, 8, true},
+		{"queued builds including the blocked ones", map[string]string{"includeBusyExecutors": "false", "includeBlocked": "true"}, 4, true},
+		{"queued builds and busy executors of the label", map[string]string{"labelExpression": "linux", "activationTargetValue": "4"}, 4, false},
+		{"no build for the label", map[string]string{"labelExpression": "macos"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["url"] = server.URL
+			s, err := NewJenkinsScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: tc.metadata,
+				AuthParams:      map[string]string{"username": "admin", "apiToken": "token"},
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "jenkins")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestJenkinsWhyLabel(t *testing.T) {
+	assert.Equal(t, "linux && docker", jenkinsWhyLabel("Waiting for next available executor on ‘linux && docker’"))
+	assert.Equal(t, "linux", jenkinsWhyLabel("All nodes of label ‘linux’ are offline"))
+	assert.Equal(t, "", jenkinsWhyLabel("In the quiet period. Expires in 4.9 sec"))
+}
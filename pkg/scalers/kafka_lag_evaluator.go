@@ -0,0 +1,84 @@
+package scalers
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	kafkaLagModeLag      = "lag"
+	kafkaLagModeLagRatio = "lagRatio"
+	kafkaLagModeLagTrend = "lagTrend"
+
+	defaultKafkaLagRatioThreshold          = 30
+	defaultKafkaLagEvaluationWindowSeconds = 300
+)
+
+// kafkaLagSample is the lag of the consumer group and the sum of the producer offsets of its partitions at a poll
+type kafkaLagSample struct {
+	time           time.Time
+	lag            int64
+	producerOffset int64
+}
+
+// kafkaLagEvaluator evaluates the lag of the consumer group over a sliding time window of its samples, as Burrow does,
+// for the scaling not to be distorted by the absolute lag of the topics with a high throughput. The window being
+// a duration, the evaluation doesn't depend on how often the metric is read.
+type kafkaLagEvaluator struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	samples []kafkaLagSample
+}
+
+func newKafkaLagEvaluator(window time.Duration) *kafkaLagEvaluator {
+	return &kafkaLagEvaluator{window: window}
+}
+
+// evaluate records the sample, drops the samples older than the window and returns the value of the metric of
+// the mode, which is the lag itself until the window has two samples
+func (e *kafkaLagEvaluator) evaluate(mode string, sample kafkaLagSample) float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.samples = append(e.samples, sample)
+	oldest := sample.time.Add(-e.window)
+	expired := 0
+	for expired < len(e.samples)-1 && e.samples[expired].time.Before(oldest) {
+		expired++
+	}
+	e.samples = e.samples[expired:]
+
+	switch mode {
+	case kafkaLagModeLagRatio:
+		return e.lagRatio()
+	case kafkaLagModeLagTrend:
+		return e.lagTrend()
+	default:
+		return float64(sample.lag)
+	}
+}
+
+// lagRatio returns the lag divided by the messages produced per second over the window, i.e. the seconds of
+// production the consumers are behind. A production rate below one message per second counts as one.
+func (e *kafkaLagEvaluator) lagRatio() float64 {
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	rate := 0.0
+	if elapsed := last.time.Sub(first.time).Seconds(); elapsed > 0 {
+		rate = float64(last.producerOffset-first.producerOffset) / elapsed
+	}
+	return float64(last.lag) / math.Max(rate, 1)
+}
+
+// lagTrend returns the lag the consumers will have a window later if it keeps increasing or decreasing at the rate
+// it did over the window, so that consumers falling behind are scaled out before their lag is high and consumers
+// catching up aren't scaled out on a lag they are draining
+func (e *kafkaLagEvaluator) lagTrend() float64 {
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return float64(last.lag)
+	}
+	rate := float64(last.lag-first.lag) / elapsed
+	return math.Max(float64(last.lag)+rate*e.window.Seconds(), 0)
+}
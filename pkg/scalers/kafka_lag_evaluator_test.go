@@ -0,0 +1,50 @@
+package scalers
+
+import (
+	"testing"
+	"time"
+)
+
+type kafkaLagEvaluatorTestData struct {
+	comment string
+	mode    string
+	window  int // seconds
+	samples []kafkaLagSample
+	value   float64
+}
+
+var kafkaLagEvaluatorStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func kafkaLagSampleAt(seconds int, lag, producerOffset int64) kafkaLagSample {
+	return kafkaLagSample{time: kafkaLagEvaluatorStart.Add(time.Duration(seconds) * time.Second), lag: lag, producerOffset: producerOffset}
+}
+
+var kafkaLagEvaluatorTestDataset = []kafkaLagEvaluatorTestData{
+	{"lag is returned as is", kafkaLagModeLag, 60, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100), kafkaLagSampleAt(10, 70, 200)}, 70},
+	{"ratio of a single sample is the lag", kafkaLagModeLagRatio, 60, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100)}, 50},
+	{"ratio of the lag to the production rate", kafkaLagModeLagRatio, 60, []kafkaLagSample{kafkaLagSampleAt(0, 50, 0), kafkaLagSampleAt(10, 2000, 1000)}, 20},
+	{"ratio with a production rate below one message per second", kafkaLagModeLagRatio, 60, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100), kafkaLagSampleAt(10, 50, 101)}, 50},
+	{"ratio over the window only", kafkaLagModeLagRatio, 15, []kafkaLagSample{kafkaLagSampleAt(0, 50, 0), kafkaLagSampleAt(10, 50, 10), kafkaLagSampleAt(20, 500, 1010)}, 5},
+	{"trend of a single sample is the lag", kafkaLagModeLagTrend, 60, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100)}, 50},
+	{"trend of an increasing lag", kafkaLagModeLagTrend, 20, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100), kafkaLagSampleAt(10, 80, 200), kafkaLagSampleAt(20, 100, 300)}, 150},
+	{"trend of a decreasing lag", kafkaLagModeLagTrend, 20, []kafkaLagSample{kafkaLagSampleAt(0, 100, 100), kafkaLagSampleAt(10, 80, 200), kafkaLagSampleAt(20, 60, 300)}, 20},
+	{"trend of a lag drained within a window", kafkaLagModeLagTrend, 10, []kafkaLagSample{kafkaLagSampleAt(0, 100, 100), kafkaLagSampleAt(10, 30, 200)}, 0},
+	{"trend over the window only", kafkaLagModeLagTrend, 10, []kafkaLagSample{kafkaLagSampleAt(0, 0, 100), kafkaLagSampleAt(10, 100, 200), kafkaLagSampleAt(20, 100, 300)}, 100},
+	{"trend of a lag read twice as often", kafkaLagModeLagTrend, 20, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100), kafkaLagSampleAt(5, 60, 150), kafkaLagSampleAt(10, 70, 200), kafkaLagSampleAt(15, 80, 250), kafkaLagSampleAt(20, 90, 300)}, 130},
+	{"trend of a lag read half as often", kafkaLagModeLagTrend, 20, []kafkaLagSample{kafkaLagSampleAt(0, 50, 100), kafkaLagSampleAt(20, 90, 300)}, 130},
+}
+
+func TestKafkaLagEvaluator(t *testing.T) {
+	for _, testData := range kafkaLagEvaluatorTestDataset {
+		t.Run(testData.comment, func(t *testing.T) {
+			evaluator := newKafkaLagEvaluator(time.Duration(testData.window) * time.Second)
+			var value float64
+			for _, sample := range testData.samples {
+				value = evaluator.evaluate(testData.mode, sample)
+			}
+			if value != testData.value {
+				t.Errorf("expected %v, got %v", testData.value, value)
+			}
+		})
+	}
+}
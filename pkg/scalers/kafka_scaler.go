@@ -23,10 +23,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
@@ -46,6 +49,7 @@ type kafkaScaler struct {
 	admin           sarama.ClusterAdmin
 	logger          logr.Logger
 	previousOffsets map[string]map[int32]int64
+	lagEvaluator    *kafkaLagEvaluator
 }
 
 const (
@@ -58,19 +62,37 @@ type kafkaMetadata struct {
 	bootstrapServers       []string
 	group                  string
 	topic                  string
+	metric                 string
 	partitionLimitation    []int32
 	lagThreshold           int64
 	activationLagThreshold int64
 	offsetResetPolicy      offsetResetPolicy
 	allowIdleConsumers     bool
 	excludePersistentLag   bool
-	version                sarama.KafkaVersion
+
+	// The lag thresholds and the weights of the topics of the consumer group, the lag of which is combined
+	// relative to the lagThreshold into a single metric
+	topicLagThresholds map[string]int64
+	topicWeights       map[string]float64
+	version            sarama.KafkaVersion
 
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
 	scaleToZeroOnInvalidOffset bool
 	limitToPartitionsWithLag   bool
 
+	// The lag the scaling is based on: the lag itself, the lag divided by the production rate or the trend of the lag,
+	// the last two being evaluated over the samples of the last lagEvaluationWindowSeconds
+	lagMode             string
+	lagRatioThreshold   float64
+	lagEvaluationWindow time.Duration
+
+	// In partitionCount metric, the partitions of the topic or of the topics matching the topicPattern are counted
+	// rather than the lag of the consumer group, for the consumers to track the partition expansions
+	topicPattern         string
+	topicRegexp          *regexp.Regexp
+	partitionsPerReplica int64
+
 	// SASL
 	saslType kafkaSaslType
 	username string
@@ -139,6 +161,9 @@ const (
 	defaultKafkaActivationLagThreshold = 0
 	defaultOffsetResetPolicy           = latest
 	invalidOffset                      = -1
+	defaultKafkaPartitionsPerReplica   = 1
+	kafkaMetricLag                     = "lag"
+	kafkaMetricPartitionCount          = "partitionCount"
 )
 
 // NewKafkaScaler creates a new kafkaScaler
@@ -169,6 +194,7 @@ func NewKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Sc
 		metadata:        kafkaMetadata,
 		logger:          logger,
 		previousOffsets: previousOffsets,
+		lagEvaluator:    newKafkaLagEvaluator(kafkaMetadata.lagEvaluationWindow),
 	}, nil
 }
 
@@ -476,11 +502,21 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		return meta, errors.New("no bootstrapServers given")
 	}
 
+	meta.metric = kafkaMetricLag
+	if val, ok := config.TriggerMetadata["metric"]; ok {
+		if val != kafkaMetricLag && val != kafkaMetricPartitionCount {
+			return meta, fmt.Errorf("err metric %q given", val)
+		}
+		meta.metric = val
+	}
+
 	switch {
 	case config.TriggerMetadata["consumerGroupFromEnv"] != "":
 		meta.group = config.ResolvedEnv[config.TriggerMetadata["consumerGroupFromEnv"]]
 	case config.TriggerMetadata["consumerGroup"] != "":
 		meta.group = config.TriggerMetadata["consumerGroup"]
+	case meta.metric == kafkaMetricPartitionCount:
+		// the partitions are counted regardless of the consumer group
 	default:
 		return meta, errors.New("no consumer group given")
 	}
@@ -561,6 +597,10 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		meta.allowIdleConsumers = t
 	}
 
+	if err := parseKafkaTopicLagThresholds(config, &meta); err != nil {
+		return meta, err
+	}
+
 	meta.excludePersistentLag = false
 	if val, ok := config.TriggerMetadata["excludePersistentLag"]; ok {
 		t, err := strconv.ParseBool(val)
@@ -595,6 +635,16 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		}
 	}
 
+	if err := parseKafkaLagMode(config, &meta); err != nil {
+		return meta, err
+	}
+
+	if meta.metric == kafkaMetricPartitionCount {
+		if err := parseKafkaPartitionCount(config, &meta); err != nil {
+			return meta, err
+		}
+	}
+
 	meta.version = sarama.V1_0_0_0
 	if val, ok := config.TriggerMetadata["version"]; ok {
 		val = strings.TrimSpace(val)
@@ -872,7 +922,138 @@ func (s *kafkaScaler) Close(context.Context) error {
 	return s.admin.Close()
 }
 
+func parseKafkaTopicLagThresholds(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
+	thresholds, err := kedautil.ParseStringList(config.TriggerMetadata["topicLagThresholds"])
+	if err != nil {
+		return fmt.Errorf("error parsing topicLagThresholds: %w", err)
+	}
+	meta.topicLagThresholds = make(map[string]int64, len(thresholds))
+	for topic, val := range thresholds {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing lagThreshold of topic %q: %w", topic, err)
+		}
+		if t <= 0 {
+			return fmt.Errorf("lagThreshold of topic %q must be positive number", topic)
+		}
+		meta.topicLagThresholds[topic] = t
+	}
+
+	weights, err := kedautil.ParseStringList(config.TriggerMetadata["topicWeights"])
+	if err != nil {
+		return fmt.Errorf("error parsing topicWeights: %w", err)
+	}
+	meta.topicWeights = make(map[string]float64, len(weights))
+	for topic, val := range weights {
+		w, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing weight of topic %q: %w", topic, err)
+		}
+		if w < 0 {
+			return fmt.Errorf("weight of topic %q must not be negative", topic)
+		}
+		meta.topicWeights[topic] = w
+	}
+	return nil
+}
+
+// getTopicLag returns the lag of the topic relative to the lagThreshold, as of the lag threshold and the weight of
+// the topic: with a lagThreshold of 10, a lag of 100 in a topic with a lag threshold of 50 and a weight of 2 is 40
+func (m *kafkaMetadata) getTopicLag(topic string, lag int64) int64 {
+	threshold, hasThreshold := m.topicLagThresholds[topic]
+	weight, hasWeight := m.topicWeights[topic]
+	if !hasThreshold && !hasWeight {
+		return lag
+	}
+	if !hasThreshold {
+		threshold = m.lagThreshold
+	}
+	if !hasWeight {
+		weight = 1
+	}
+	return int64(math.Round(float64(lag) * weight * float64(m.lagThreshold) / float64(threshold)))
+}
+
+func parseKafkaLagMode(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
+	meta.lagMode = kafkaLagModeLag
+	if val, ok := config.TriggerMetadata["lagMode"]; ok {
+		if val != kafkaLagModeLag && val != kafkaLagModeLagRatio && val != kafkaLagModeLagTrend {
+			return fmt.Errorf("err lagMode %q given", val)
+		}
+		meta.lagMode = val
+	}
+
+	meta.lagRatioThreshold = defaultKafkaLagRatioThreshold
+	if val, ok := config.TriggerMetadata["lagRatioThreshold"]; ok {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing lagRatioThreshold: %w", err)
+		}
+		if t <= 0 {
+			return fmt.Errorf("lagRatioThreshold must be positive number")
+		}
+		meta.lagRatioThreshold = t
+	}
+
+	meta.lagEvaluationWindow = defaultKafkaLagEvaluationWindowSeconds * time.Second
+	if val, ok := config.TriggerMetadata["lagEvaluationWindowSeconds"]; ok {
+		t, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("error parsing lagEvaluationWindowSeconds: %w", err)
+		}
+		if t <= 0 {
+			return fmt.Errorf("lagEvaluationWindowSeconds must be positive number")
+		}
+		meta.lagEvaluationWindow = time.Duration(t) * time.Second
+	}
+	return nil
+}
+
+var kafkaTopicPatternMetacharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+func parseKafkaPartitionCount(config *scalersconfig.ScalerConfig, meta *kafkaMetadata) error {
+	if val := config.TriggerMetadata["topicPattern"]; val != "" {
+		if meta.topic != "" {
+			return fmt.Errorf("topic and topicPattern cannot be set simultaneously")
+		}
+		// the pattern matches the whole name of the topics
+		pattern, err := regexp.Compile("^(?:" + val + ")$")
+		if err != nil {
+			return fmt.Errorf("error parsing topicPattern: %w", err)
+		}
+		meta.topicPattern = val
+		meta.topicRegexp = pattern
+	} else if meta.topic == "" {
+		return fmt.Errorf("topic or topicPattern must be specified when using %s metric", kafkaMetricPartitionCount)
+	}
+
+	meta.partitionsPerReplica = defaultKafkaPartitionsPerReplica
+	if val, ok := config.TriggerMetadata["partitionsPerReplica"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing partitionsPerReplica: %w", err)
+		}
+		if t <= 0 {
+			return fmt.Errorf("partitionsPerReplica must be positive number")
+		}
+		meta.partitionsPerReplica = t
+	}
+	return nil
+}
+
+// getLagTarget returns the target of the metric, which is the lagRatioThreshold in seconds in lagRatio mode
+func (m *kafkaMetadata) getLagTarget() float64 {
+	if m.lagMode == kafkaLagModeLagRatio {
+		return m.lagRatioThreshold
+	}
+	return float64(m.lagThreshold)
+}
+
 func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	if s.metadata.metric == kafkaMetricPartitionCount {
+		return s.getPartitionCountMetricSpec()
+	}
+
 	var metricName string
 	if s.metadata.topic != "" {
 		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
@@ -886,10 +1067,48 @@ func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
 	}
+	if s.metadata.lagMode == kafkaLagModeLagRatio {
+		externalMetric.Target = GetMetricTargetMili(s.metricType, s.metadata.lagRatioThreshold)
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *kafkaScaler) getPartitionCountMetricSpec() []v2.MetricSpec {
+	metricName := fmt.Sprintf("kafka-partitions-%s", s.metadata.topic)
+	if s.metadata.topicPattern != "" {
+		// the metacharacters of the pattern aren't valid in the name of a metric
+		pattern := strings.Trim(kafkaTopicPatternMetacharacters.ReplaceAllString(s.metadata.topicPattern, "-"), "-")
+		metricName = fmt.Sprintf("kafka-partitions-%s", pattern)
+	}
+
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.partitionsPerReplica),
+	}
 	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2.MetricSpec{metricSpec}
 }
 
+// getPartitionCount returns the number of partitions of the topic or of the topics matching the topicPattern
+func (s *kafkaScaler) getPartitionCount() (int64, error) {
+	topics, err := s.admin.ListTopics()
+	if err != nil {
+		return 0, fmt.Errorf("error listing topics: %w", err)
+	}
+
+	partitionCount := int64(0)
+	for name, detail := range topics {
+		if name == s.metadata.topic || (s.metadata.topicRegexp != nil && s.metadata.topicRegexp.MatchString(name)) {
+			partitionCount += int64(detail.NumPartitions)
+		}
+	}
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on partitionCount %v", partitionCount))
+	return partitionCount, nil
+}
+
 type consumerOffsetResult struct {
 	consumerOffsets *sarama.OffsetFetchResponse
 	err             error
@@ -928,11 +1147,21 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.metric == kafkaMetricPartitionCount {
+		partitionCount, err := s.getPartitionCount()
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		metric := GenerateMetricInMili(metricName, float64(partitionCount))
+
+		return []external_metrics.ExternalMetricValue{metric}, partitionCount > 0, nil
+	}
+
 	totalLag, totalLagWithPersistent, err := s.getTotalLag()
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
-	metric := GenerateMetricInMili(metricName, float64(totalLag))
+	metric := GenerateMetricInMili(metricName, totalLag)
 
 	return []external_metrics.ExternalMetricValue{metric}, totalLagWithPersistent > s.metadata.activationLagThreshold, nil
 }
@@ -940,7 +1169,8 @@ func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string
 // getTotalLag returns totalLag, totalLagWithPersistent, error
 // totalLag and totalLagWithPersistent are the summations of lag and lagWithPersistent returned by getLagForPartition function respectively.
 // totalLag maybe less than totalLagWithPersistent when excludePersistentLag is set to `true` due to some partitions deemed as having persistent lag
-func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
+// In lagRatio and lagTrend modes, totalLag is the ratio or the trend of the summation of lag rather than the summation itself
+func (s *kafkaScaler) getTotalLag() (float64, int64, error) {
 	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
 		return 0, 0, err
@@ -955,23 +1185,30 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
 	partitionsWithLag := int64(0)
+	totalProducerOffset := int64(0)
 
 	for topic, partitionsOffsets := range producerOffsets {
-		for partition := range partitionsOffsets {
+		topicLag := int64(0)
+		topicLagWithPersistent := int64(0)
+		for partition, producerOffset := range partitionsOffsets {
 			lag, lagWithPersistent, err := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets)
 			if err != nil {
 				return 0, 0, err
 			}
-			totalLag += lag
-			totalLagWithPersistent += lagWithPersistent
+			topicLag += lag
+			topicLagWithPersistent += lagWithPersistent
+			totalProducerOffset += producerOffset
 
 			if lag > 0 {
 				partitionsWithLag++
 			}
 		}
+		totalLag += s.metadata.getTopicLag(topic, topicLag)
+		totalLagWithPersistent += s.metadata.getTopicLag(topic, topicLagWithPersistent)
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
-	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
+	value := s.lagEvaluator.evaluate(s.metadata.lagMode, kafkaLagSample{time: time.Now(), lag: totalLag, producerOffset: totalProducerOffset})
+	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, %s %v, topicPartitions %v, threshold %v", totalLag, s.metadata.lagMode, value, len(topicPartitions), s.metadata.getLagTarget()))
 
 	if !s.metadata.allowIdleConsumers || s.metadata.limitToPartitionsWithLag {
 		// don't scale out beyond the number of topicPartitions or partitionsWithLag depending on settings
@@ -980,11 +1217,11 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 			upperBound = partitionsWithLag
 		}
 
-		if (totalLag / s.metadata.lagThreshold) > upperBound {
-			totalLag = upperBound * s.metadata.lagThreshold
+		if target := s.metadata.getLagTarget(); math.Floor(value/target) > float64(upperBound) {
+			value = float64(upperBound) * target
 		}
 	}
-	return totalLag, totalLagWithPersistent, nil
+	return value, totalLagWithPersistent, nil
 }
 
 type brokerOffsetResult struct {
@@ -138,6 +138,36 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true", "limitToPartitionsWithLag": "false"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), true, false, false},
 	// failure, topic must be specified when limitToPartitionsWithLag is true
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "limitToPartitionsWithLag": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, true},
+	// success, lagRatio mode
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagRatio", "lagRatioThreshold": "12.5"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, lagTrend mode
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagTrend", "lagEvaluationWindowSeconds": "60"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagMode is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "notvalid"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagRatioThreshold is not positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagRatio", "lagRatioThreshold": "0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagEvaluationWindowSeconds is not positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagMode": "lagTrend", "lagEvaluationWindowSeconds": "0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, partitionCount metric without consumer group
+	{map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, partitionCount metric with topicPattern
+	{map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topicPattern": "orders-.*", "partitionsPerReplica": "2"}, false, 1, []string{"foobar:9092"}, "", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, partitionCount metric without topic nor topicPattern
+	{map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount"}, true, 1, []string{"foobar:9092"}, "", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, partitionCount metric with both topic and topicPattern
+	{map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topic": "my-topic", "topicPattern": "orders-.*"}, true, 1, []string{"foobar:9092"}, "", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, topicPattern is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topicPattern": "orders-("}, true, 1, []string{"foobar:9092"}, "", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// success, lag thresholds and weights of the topics
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicLagThresholds": "orders=100, payments=10", "topicWeights": "orders=0.5"}, false, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, topicLagThresholds is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicLagThresholds": "orders"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lag threshold of a topic is not positive
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicLagThresholds": "orders=0"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, weight of a topic is negative
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topicWeights": "orders=-1"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, metric is malformed
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "metric": "notvalid"}, true, 1, []string{"foobar:9092"}, "", "", nil, offsetResetPolicy("latest"), false, false, false},
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -607,7 +637,7 @@ func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, logr.Discard(), make(map[string]map[int32]int64)}
+		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, logr.Discard(), make(map[string]map[int32]int64), newKafkaLagEvaluator(meta.lagEvaluationWindow)}
 
 		metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -635,7 +665,7 @@ func TestGetTopicPartitions(t *testing.T) {
 			if err != nil {
 				t.Fatal("Could not parse metadata:", err)
 			}
-			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds}, logr.Discard(), make(map[string]map[int32]int64)}
+			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds}, logr.Discard(), make(map[string]map[int32]int64), newKafkaLagEvaluator(meta.lagEvaluationWindow)}
 
 			partitions, err := mockKafkaScaler.getTopicPartitions()
 
@@ -650,15 +680,87 @@ func TestGetTopicPartitions(t *testing.T) {
 	}
 }
 
+func TestKafkaGetPartitionCount(t *testing.T) {
+	topics := map[string]sarama.TopicDetail{
+		"orders-eu": {NumPartitions: 3},
+		"orders-us": {NumPartitions: 6},
+		"payments":  {NumPartitions: 4},
+	}
+	testData := []struct {
+		name       string
+		metadata   map[string]string
+		exp        int64
+		metricName string
+	}{
+		{"topic", map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topic": "payments"}, 4, "s0-kafka-partitions-payments"},
+		{"topicPattern", map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topicPattern": "orders-.*"}, 9, "s0-kafka-partitions-orders"},
+		{"topicPattern matching the whole name", map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topicPattern": "orders"}, 0, "s0-kafka-partitions-orders"},
+		{"missing topic", map[string]string{"bootstrapServers": "foobar:9092", "metric": "partitionCount", "topic": "invoices"}, 0, "s0-kafka-partitions-invoices"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tt.metadata, AuthParams: validWithAuthParams}, logr.Discard())
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{topics: topics}, logr.Discard(), make(map[string]map[int32]int64), newKafkaLagEvaluator(meta.lagEvaluationWindow)}
+
+			partitionCount, err := mockKafkaScaler.getPartitionCount()
+			if err != nil {
+				t.Error("Expected success but got error", err)
+			}
+			if partitionCount != tt.exp {
+				t.Errorf("Expected %d partitions but got %d\n", tt.exp, partitionCount)
+			}
+
+			metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
+			if metricName := metricSpec[0].External.Metric.Name; metricName != tt.metricName {
+				t.Error("Wrong External metric source name:", metricName)
+			}
+		})
+	}
+}
+
+func TestKafkaGetTopicLag(t *testing.T) {
+	meta, err := parseKafkaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"bootstrapServers":   "foobar:9092",
+		"consumerGroup":      "my-group",
+		"lagThreshold":       "10",
+		"topicLagThresholds": "orders=50,payments=5",
+		"topicWeights":       "orders=2,audit=0",
+	}, AuthParams: validWithAuthParams}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	testData := []struct {
+		topic string
+		lag   int64
+		exp   int64
+	}{
+		{"orders", 100, 40},
+		{"payments", 100, 200},
+		{"audit", 100, 0},
+		{"other", 100, 100},
+	}
+	for _, tt := range testData {
+		if lag := meta.getTopicLag(tt.topic, tt.lag); lag != tt.exp {
+			t.Errorf("Expected lag %d for topic %s but got %d\n", tt.exp, tt.topic, lag)
+		}
+	}
+}
+
 type MockClusterAdmin struct {
 	partitionIds []int32
+	topics       map[string]sarama.TopicDetail
 }
 
 func (m *MockClusterAdmin) CreateTopic(_ string, _ *sarama.TopicDetail, _ bool) error {
 	return nil
 }
 func (m *MockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
-	return nil, nil
+	return m.topics, nil
 }
 
 func (m *MockClusterAdmin) DescribeTopics(topics []string) (metadata []*sarama.TopicMetadata, err error) {
@@ -0,0 +1,215 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type kubernetesEventsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *kubernetesEventsMetadata
+	kubeClient client.Client
+	logger     logr.Logger
+	now        func() time.Time
+}
+
+type kubernetesEventsMetadata struct {
+	LabelSelector string `keda:"name=labelSelector, order=triggerMetadata, optional"`
+	// FieldSelector is matched against the fields the API server allows to select events on, e.g. reason=FailedScheduling,type=Warning.
+	// It's matched by the scaler rather than the API server, so that the events can be read from the cache of the client.
+	FieldSelector string `keda:"name=fieldSelector, order=triggerMetadata, optional"`
+	Namespace     string `keda:"name=namespace,     order=triggerMetadata, optional"`
+	AllNamespaces bool   `keda:"name=allNamespaces, order=triggerMetadata, optional"`
+	// Window is the duration over which the events were last seen
+	Window string `keda:"name=window, order=triggerMetadata, optional, default=5m"`
+	// CountRepetitions counts the occurrences of the repeated events rather than each event once
+	CountRepetitions bool    `keda:"name=countRepetitions, order=triggerMetadata, optional"`
+	Value            float64 `keda:"name=value,            order=triggerMetadata, optional, default=5"`
+	ActivationValue  float64 `keda:"name=activationValue,  order=triggerMetadata, optional"`
+
+	triggerIndex  int
+	window        time.Duration
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
+}
+
+func (m *kubernetesEventsMetadata) Validate() error {
+	if m.Value <= 0 {
+		return errors.New("value must be a float greater than 0")
+	}
+	window, err := time.ParseDuration(m.Window)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("window must be a positive duration, e.g. 5m, got %q", m.Window)
+	}
+	m.window = window
+	if m.AllNamespaces && m.Namespace != "" {
+		return errors.New("namespace and allNamespaces can't be both set")
+	}
+
+	labelSelector, err := labels.Parse(m.LabelSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing label selector: %w", err)
+	}
+	m.labelSelector = labelSelector
+
+	fieldSelector, err := fields.ParseSelector(m.FieldSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing field selector: %w", err)
+	}
+	m.fieldSelector = fieldSelector
+	return nil
+}
+
+// NewKubernetesEventsScaler creates a new kubernetesEventsScaler
+func NewKubernetesEventsScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseKubernetesEventsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes events metadata: %w", err)
+	}
+
+	return &kubernetesEventsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		logger:     InitializeLogger(config, "kubernetes_events_scaler"),
+		now:        time.Now,
+	}, nil
+}
+
+func parseKubernetesEventsMetadata(config *scalersconfig.ScalerConfig) (*kubernetesEventsMetadata, error) {
+	meta := &kubernetesEventsMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if meta.Namespace == "" && !meta.AllNamespaces {
+		meta.Namespace = config.ScalableObjectNamespace
+	}
+	return meta, nil
+}
+
+func (s *kubernetesEventsScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *kubernetesEventsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	namespace := s.metadata.Namespace
+	if s.metadata.AllNamespaces {
+		namespace = "all"
+	}
+	metricName := kedautil.NormalizeString(fmt.Sprintf("kubernetes-events-%s", namespace))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of events matching the selectors seen over the window
+func (s *kubernetesEventsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	events, err := s.getEventCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error counting kubernetes events: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(events))
+	return []external_metrics.ExternalMetricValue{metric}, float64(events) > s.metadata.ActivationValue, nil
+}
+
+func (s *kubernetesEventsScaler) getEventCount(ctx context.Context) (int64, error) {
+	eventList := &corev1.EventList{}
+	listOptions := client.ListOptions{
+		LabelSelector: s.metadata.labelSelector,
+		Namespace:     s.metadata.Namespace,
+	}
+	if err := s.kubeClient.List(ctx, eventList, &listOptions); err != nil {
+		return 0, err
+	}
+
+	since := s.now().Add(-s.metadata.window)
+	var count int64
+	for i := range eventList.Items {
+		event := &eventList.Items[i]
+		if !s.metadata.fieldSelector.Matches(eventFields(event)) {
+			continue
+		}
+		if eventLastSeen(event).Before(since) {
+			continue
+		}
+		if s.metadata.CountRepetitions {
+			count += eventRepetitions(event)
+		} else {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// eventFields returns the fields the API server allows to select events on
+func eventFields(event *corev1.Event) fields.Set {
+	source := event.Source.Component
+	if source == "" {
+		source = event.ReportingController
+	}
+	return fields.Set{
+		"metadata.name":                  event.Name,
+		"metadata.namespace":             event.Namespace,
+		"involvedObject.kind":            event.InvolvedObject.Kind,
+		"involvedObject.namespace":       event.InvolvedObject.Namespace,
+		"involvedObject.name":            event.InvolvedObject.Name,
+		"involvedObject.uid":             string(event.InvolvedObject.UID),
+		"involvedObject.apiVersion":      event.InvolvedObject.APIVersion,
+		"involvedObject.resourceVersion": event.InvolvedObject.ResourceVersion,
+		"involvedObject.fieldPath":       event.InvolvedObject.FieldPath,
+		"reason":                         event.Reason,
+		"reportingComponent":             event.ReportingController,
+		"source":                         source,
+		"type":                           event.Type,
+	}
+}
+
+// eventLastSeen returns the last time the event occurred, events.k8s.io/v1 only setting the time of the series
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// eventRepetitions returns the number of occurrences of the event
+func eventRepetitions(event *corev1.Event) int64 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return int64(event.Series.Count)
+	}
+	if event.Count > 0 {
+		return int64(event.Count)
+	}
+	return 1
+}
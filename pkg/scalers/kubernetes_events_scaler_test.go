@@ -0,0 +1,128 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseKubernetesEventsMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type kubernetesEventsMetricIdentifier struct {
+	metadataTestData *parseKubernetesEventsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testKubernetesEventsMetadata = []parseKubernetesEventsMetadataTestData{
+	{map[string]string{}, false, "nothing passed"},
+	{map[string]string{"fieldSelector": "reason=FailedScheduling,type=Warning", "window": "10m", "value": "2"}, false, "field selector"},
+	{map[string]string{"allNamespaces": "true", "labelSelector": "app=batch", "countRepetitions": "true", "activationValue": "1"}, false, "all namespaces"},
+	{map[string]string{"labelSelector": "app in ("}, true, "invalid label selector"},
+	{map[string]string{"fieldSelector": "reason"}, true, "invalid field selector"},
+	{map[string]string{"window": "5"}, true, "window without unit"},
+	{map[string]string{"value": "0"}, true, "zero value"},
+	{map[string]string{"namespace": "jobs", "allNamespaces": "true"}, true, "namespace and all namespaces"},
+}
+
+var kubernetesEventsMetricIdentifiers = []kubernetesEventsMetricIdentifier{
+	{&testKubernetesEventsMetadata[1], 0, "s0-kubernetes-events-default"},
+	{&testKubernetesEventsMetadata[2], 1, "s1-kubernetes-events-all"},
+}
+
+func TestKubernetesEventsParseMetadata(t *testing.T) {
+	for _, testData := range testKubernetesEventsMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseKubernetesEventsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestKubernetesEventsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range kubernetesEventsMetricIdentifiers {
+		meta, err := parseKubernetesEventsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "default", TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockKubernetesEventsScaler := kubernetesEventsScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockKubernetesEventsScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func createKubernetesEvent(name, namespace, reason, eventType string, lastSeen time.Time, count int32, labels map[string]string) client.Object {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: name + "-pod"},
+		Reason:         reason,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: "default-scheduler"},
+		LastTimestamp:  metav1.NewTime(lastSeen),
+		Count:          count,
+	}
+}
+
+func TestKubernetesEventsGetMetricsAndActivity(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	series := createKubernetesEvent("series", "default", "FailedScheduling", "Warning", time.Time{}, 0, nil).(*corev1.Event)
+	series.Series = &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))}
+
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createKubernetesEvent("scheduling-1", "default", "FailedScheduling", "Warning", now.Add(-time.Minute), 3, map[string]string{"app": "batch"}),
+		createKubernetesEvent("scheduling-2", "default", "FailedScheduling", "Warning", now.Add(-8*time.Minute), 1, nil),
+		createKubernetesEvent("scheduling-3", "other", "FailedScheduling", "Warning", now.Add(-2*time.Minute), 2, map[string]string{"app": "batch"}),
+		createKubernetesEvent("scheduled", "default", "Scheduled", "Normal", now.Add(-time.Minute), 1, nil),
+		series,
+	).Build()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"events of the namespace over the window", map[string]string{}, 3, true},
+		{"events matching the field selector", map[string]string{"fieldSelector": "reason=FailedScheduling,source=default-scheduler"}, 2, true},
+		{"repetitions of the events", map[string]string{"fieldSelector": "type=Warning", "countRepetitions": "true"}, 7, true},
+		{"events over a longer window", map[string]string{"fieldSelector": "type!=Normal", "window": "10m", "activationValue": "3"}, 3, false},
+		{"events matching the label selector in all namespaces", map[string]string{"labelSelector": "app=batch", "allNamespaces": "true"}, 2, true},
+		{"events of another namespace", map[string]string{"namespace": "other", "fieldSelector": "involvedObject.name=scheduling-3-pod"}, 1, true},
+		{"no matching event", map[string]string{"fieldSelector": "reason=BackOff"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewKubernetesEventsScaler(kubeClient, &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, ScalableObjectNamespace: "default"})
+			assert.NoError(t, err)
+			s.(*kubernetesEventsScaler).now = func() time.Time { return now }
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "kubernetes-events")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -3,13 +3,17 @@ package scalers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -21,6 +25,14 @@ import (
 const (
 	defaultIgnoreNullValues = true
 	tenantNameHeaderKey     = "X-Scope-OrgID"
+
+	lokiQueryTypeRange = "range"
+
+	lokiRangeAggregationLast = "last"
+	lokiRangeAggregationMax  = "max"
+	lokiRangeAggregationMin  = "min"
+	lokiRangeAggregationAvg  = "avg"
+	lokiRangeAggregationSum  = "sum"
 )
 
 type lokiScaler struct {
@@ -35,11 +47,23 @@ type lokiMetadata struct {
 	Query               string  `keda:"name=query,order=triggerMetadata"`
 	Threshold           float64 `keda:"name=threshold,order=triggerMetadata"`
 	ActivationThreshold float64 `keda:"name=activationThreshold,order=triggerMetadata,default=0"`
-	TenantName          string  `keda:"name=tenantName,order=triggerMetadata,optional"`
-	IgnoreNullValues    bool    `keda:"name=ignoreNullValues,order=triggerMetadata,default=true"`
-	UnsafeSsl           bool    `keda:"name=unsafeSsl,order=triggerMetadata,default=false"`
-	TriggerIndex        int
-	Auth                *authentication.AuthMeta
+	// TenantName is sent as the X-Scope-OrgID header, several tenants separated by | being queried at once
+	TenantName       string `keda:"name=tenantName,order=triggerMetadata;authParams,optional"`
+	IgnoreNullValues bool   `keda:"name=ignoreNullValues,order=triggerMetadata,default=true"`
+	UnsafeSsl        bool   `keda:"name=unsafeSsl,order=triggerMetadata,default=false"`
+	// QueryType is instant, evaluating the query at a single time, or range, evaluating it at each step of the
+	// range before the time, the values being aggregated with the range aggregation
+	QueryType        string `keda:"name=queryType,order=triggerMetadata,enum=instant;range,default=instant"`
+	Range            string `keda:"name=range,order=triggerMetadata,default=5m"`
+	Step             string `keda:"name=step,order=triggerMetadata,optional"`
+	Offset           string `keda:"name=offset,order=triggerMetadata,optional"`
+	RangeAggregation string `keda:"name=rangeAggregation,order=triggerMetadata,enum=last;max;min;avg;sum,default=last"`
+	TriggerIndex     int
+	Auth             *authentication.AuthMeta
+
+	queryRange time.Duration
+	step       time.Duration
+	offset     time.Duration
 }
 
 type lokiQueryResult struct {
@@ -47,12 +71,43 @@ type lokiQueryResult struct {
 	Data   struct {
 		ResultType string `json:"resultType"`
 		Result     []struct {
-			Metric struct{}      `json:"metric"`
-			Value  []interface{} `json:"value"`
+			Metric struct{}        `json:"metric"`
+			Value  []interface{}   `json:"value"`
+			Values [][]interface{} `json:"values"`
 		} `json:"result"`
 	} `json:"data"`
 }
 
+func (m *lokiMetadata) Validate() error {
+	var err error
+	if m.queryRange, err = time.ParseDuration(m.Range); err != nil {
+		return fmt.Errorf("invalid range %s: %w", m.Range, err)
+	}
+	if m.queryRange <= 0 {
+		return errors.New("range must be greater than 0")
+	}
+	if m.Step != "" {
+		if m.step, err = time.ParseDuration(m.Step); err != nil {
+			return fmt.Errorf("invalid step %s: %w", m.Step, err)
+		}
+		if m.step <= 0 {
+			return errors.New("step must be greater than 0")
+		}
+	}
+	if m.Offset != "" {
+		if m.offset, err = time.ParseDuration(m.Offset); err != nil {
+			return fmt.Errorf("invalid offset %s: %w", m.Offset, err)
+		}
+		if m.offset < 0 {
+			return errors.New("offset must not be negative")
+		}
+	}
+	if m.QueryType != lokiQueryTypeRange && m.Step != "" {
+		return errors.New("step can only be set with the range query type")
+	}
+	return nil
+}
+
 func NewLokiScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -66,6 +121,22 @@ func NewLokiScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
 
+	if meta.Auth != nil && meta.Auth.EnableOAuth {
+		// the access tokens of the OIDC provider are requested with the client credentials grant
+		oauthConfig := clientcredentials.Config{
+			ClientID:       meta.Auth.ClientID,
+			ClientSecret:   meta.Auth.ClientSecret,
+			TokenURL:       meta.Auth.OauthTokenURI,
+			Scopes:         meta.Auth.Scopes,
+			EndpointParams: meta.Auth.EndpointParams,
+		}
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl))
+		httpClient.Transport = &oauth2.Transport{
+			Source: oauthConfig.TokenSource(tokenCtx),
+			Base:   httpClient.Transport,
+		}
+	}
+
 	return &lokiScaler{
 		metricType: metricType,
 		metadata:   meta,
@@ -89,6 +160,9 @@ func parseLokiMetadata(config *scalersconfig.ScalerConfig) (lokiMetadata, error)
 	if err != nil {
 		return meta, err
 	}
+	if auth != nil && auth.EnableOAuth && (auth.OauthTokenURI == "" || auth.ClientID == "" || auth.ClientSecret == "") {
+		return meta, errors.New("oauthTokenURI, clientID and clientSecret are required when oauth is enabled")
+	}
 	meta.Auth = auth
 	meta.TriggerIndex = config.TriggerIndex
 
@@ -118,8 +192,22 @@ func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
 	if err != nil {
 		return -1, err
 	}
-	u.Path = "/loki/api/v1/query"
-	u.RawQuery = url.Values{"query": []string{s.metadata.Query}}.Encode()
+	params := url.Values{"query": []string{s.metadata.Query}}
+	queryTime := time.Now().Add(-s.metadata.offset)
+	if s.metadata.QueryType == lokiQueryTypeRange {
+		u.Path = "/loki/api/v1/query_range"
+		params.Set("start", strconv.FormatInt(queryTime.Add(-s.metadata.queryRange).UnixNano(), 10))
+		params.Set("end", strconv.FormatInt(queryTime.UnixNano(), 10))
+		if s.metadata.step > 0 {
+			params.Set("step", strconv.FormatFloat(s.metadata.step.Seconds(), 'f', -1, 64))
+		}
+	} else {
+		u.Path = "/loki/api/v1/query"
+		if s.metadata.offset > 0 {
+			params.Set("time", strconv.FormatInt(queryTime.UnixNano(), 10))
+		}
+	}
+	u.RawQuery = params.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
@@ -173,6 +261,10 @@ func (s *lokiScaler) parseQueryResult(result lokiQueryResult) (float64, error) {
 		return -1, fmt.Errorf("loki query %s returned multiple elements", s.metadata.Query)
 	}
 
+	if result.Data.ResultType == "matrix" {
+		return s.parseRangeValues(result.Data.Result[0].Values)
+	}
+
 	values := result.Data.Result[0].Value
 	if len(values) == 0 {
 		if s.metadata.IgnoreNullValues {
@@ -185,11 +277,51 @@ func (s *lokiScaler) parseQueryResult(result lokiQueryResult) (float64, error) {
 		return -1, fmt.Errorf("loki query %s didn't return enough values", s.metadata.Query)
 	}
 
-	if values[1] == nil {
+	return parseLokiSampleValue(values[1])
+}
+
+// parseRangeValues aggregates the values of the series returned by a range query
+func (s *lokiScaler) parseRangeValues(samples [][]interface{}) (float64, error) {
+	if len(samples) == 0 {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("loki metrics may be lost, the value list is empty")
+	}
+
+	var result float64
+	for i, sample := range samples {
+		if len(sample) < 2 {
+			return -1, fmt.Errorf("loki query %s didn't return enough values", s.metadata.Query)
+		}
+		v, err := parseLokiSampleValue(sample[1])
+		if err != nil {
+			return -1, err
+		}
+		switch {
+		case i == 0, s.metadata.RangeAggregation == lokiRangeAggregationLast, s.metadata.RangeAggregation == "":
+			// the samples are ordered by time
+			result = v
+		case s.metadata.RangeAggregation == lokiRangeAggregationMax:
+			result = max(result, v)
+		case s.metadata.RangeAggregation == lokiRangeAggregationMin:
+			result = min(result, v)
+		case s.metadata.RangeAggregation == lokiRangeAggregationSum, s.metadata.RangeAggregation == lokiRangeAggregationAvg:
+			result += v
+		}
+	}
+	if s.metadata.RangeAggregation == lokiRangeAggregationAvg {
+		result /= float64(len(samples))
+	}
+	return result, nil
+}
+
+func parseLokiSampleValue(value interface{}) (float64, error) {
+	if value == nil {
 		return 0, nil
 	}
 
-	str, ok := values[1].(string)
+	str, ok := value.(string)
 	if !ok {
 		return -1, fmt.Errorf("failed to parse loki value as string")
 	}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -40,6 +43,20 @@ var testLokiMetadata = []parseLokiMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "ignoreNullValues": "xxxx"}, true},
 	// with unsafeSsl
 	{map[string]string{"serverAddress": "https://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "unsafeSsl": "true"}, false},
+	// range query with step and offset
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "queryType": "range", "range": "10m", "step": "1m", "offset": "30s", "rangeAggregation": "sum"}, false},
+	// invalid query type
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "queryType": "stream"}, true},
+	// malformed range
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "queryType": "range", "range": "ten"}, true},
+	// malformed step
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "queryType": "range", "step": "-1m"}, true},
+	// step with instant query
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "step": "1m"}, true},
+	// negative offset
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "offset": "-1m"}, true},
+	// invalid range aggregation
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "queryType": "range", "rangeAggregation": "median"}, true},
 }
 
 type lokiAuthMetadataTestData struct {
@@ -57,6 +74,10 @@ var testLokiAuthMetadata = []lokiAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "basic"}, map[string]string{"username": "user", "password": "pass"}, false},
 	// fail basicAuth with no username
 	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "basic"}, map[string]string{}, true},
+	// success oauth
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "oauth"}, map[string]string{"oauthTokenURI": "https://idp/token", "clientID": "keda", "clientSecret": "secret", "tenantName": "team-a"}, false},
+	// fail oauth with no client
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "1", "query": "sum(rate({filename=\"/var/log/syslog\"}[1m])) by (level)", "authModes": "oauth"}, map[string]string{"oauthTokenURI": "https://idp/token"}, true},
 }
 
 func TestLokiParseMetadata(t *testing.T) {
@@ -247,3 +268,62 @@ func TestLokiScalerTenantHeader(t *testing.T) {
 	_, err := scaler.ExecuteLokiQuery(context.TODO())
 	assert.NoError(t, err)
 }
+
+func TestLokiScalerRangeQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/loki/api/v1/query_range", request.URL.Path)
+		assert.Equal(t, "60", request.URL.Query().Get("step"))
+		start, err := strconv.ParseInt(request.URL.Query().Get("start"), 10, 64)
+		assert.NoError(t, err)
+		end, err := strconv.ParseInt(request.URL.Query().Get("end"), 10, 64)
+		assert.NoError(t, err)
+		assert.Equal(t, (10 * time.Minute).Nanoseconds(), end-start)
+		assert.WithinDuration(t, time.Now().Add(-time.Minute), time.Unix(0, end), 10*time.Second)
+		assert.Equal(t, "team-a|team-b", request.Header.Get(tenantNameHeaderKey))
+
+		_, _ = writer.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"values":[[1,"4"],[2,"10"],[3,"1"]]}]}}`))
+	}))
+	defer server.Close()
+
+	for aggregation, expected := range map[string]float64{"last": 1, "max": 10, "min": 1, "sum": 15, "avg": 5} {
+		meta, err := parseLokiMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+			"serverAddress": server.URL, "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "tenantName": "team-a|team-b",
+			"queryType": "range", "range": "10m", "step": "1m", "offset": "1m", "rangeAggregation": aggregation,
+		}})
+		require.NoError(t, err)
+		scaler := lokiScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.ExecuteLokiQuery(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, aggregation)
+	}
+}
+
+func TestLokiScalerOAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/token":
+			clientID, clientSecret, _ := request.BasicAuth()
+			assert.Equal(t, "keda", clientID)
+			assert.Equal(t, "secret", clientSecret)
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+		case "/loki/api/v1/query":
+			assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+			_, _ = writer.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"value":[1,"3"]}]}}`))
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	scaler, err := NewLokiScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "threshold": "1", "query": "sum(count_over_time({app=\"api\"}[1m]))", "authModes": "oauth"},
+		AuthParams:      map[string]string{"oauthTokenURI": server.URL + "/token", "clientID": "keda", "clientSecret": "secret"},
+	})
+	require.NoError(t, err)
+
+	value, err := scaler.(*lokiScaler).ExecuteLokiQuery(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+}
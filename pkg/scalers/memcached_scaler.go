@@ -0,0 +1,230 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// memcachedUptimeStat is the number of seconds since the server started, the counters being reset on restart
+const memcachedUptimeStat = "uptime"
+
+type memcachedScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *memcachedMetadata
+	tlsConfig  *tls.Config
+	timeout    time.Duration
+	logger     logr.Logger
+
+	// previous is the sample of the previous poll the rate of the counter is derived from
+	mutex    sync.Mutex
+	previous *memcachedSample
+}
+
+type memcachedMetadata struct {
+	triggerIndex int
+
+	Address string `keda:"name=address, order=triggerMetadata;resolvedEnv"`
+	// Stat is the name of the statistic, e.g. curr_connections, evictions or cmd_get
+	Stat string `keda:"name=stat, order=triggerMetadata"`
+	// Rate derives the per second rate of the counter over the polling interval
+	Rate                  bool    `keda:"name=rate,                  order=triggerMetadata, optional"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+
+	EnableTLS bool   `keda:"name=enableTLS, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+	Cert      string `keda:"name=cert,      order=authParams, optional"`
+	Key       string `keda:"name=key,       order=authParams, optional"`
+}
+
+// memcachedSample is the value of the statistic and the uptime of the server when it was read
+type memcachedSample struct {
+	value  float64
+	uptime float64
+	time   time.Time
+}
+
+func (m *memcachedMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if _, _, err := net.SplitHostPort(m.Address); err != nil {
+		return fmt.Errorf("address must be of the form host:port: %w", err)
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	return nil
+}
+
+// NewMemcachedScaler creates a new memcachedScaler
+func NewMemcachedScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseMemcachedMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing memcached metadata: %w", err)
+	}
+
+	s := &memcachedScaler{
+		metricType: metricType,
+		metadata:   meta,
+		timeout:    config.GlobalHTTPTimeout,
+		logger:     InitializeLogger(config, "memcached_scaler"),
+	}
+	if meta.EnableTLS {
+		if s.tlsConfig, err = kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func parseMemcachedMetadata(config *scalersconfig.ScalerConfig) (*memcachedMetadata, error) {
+	meta := &memcachedMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close does nothing as a connection is opened for each poll
+func (s *memcachedScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *memcachedScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("memcached-%s", s.metadata.Stat)
+	if s.metadata.Rate {
+		metricName += "-rate"
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the statistic or its rate
+func (s *memcachedScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	stats, err := s.getStats(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting memcached stats: %w", err)
+	}
+
+	value, err := parseMemcachedStat(stats, s.metadata.Stat)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+	if s.metadata.Rate {
+		uptime, err := parseMemcachedStat(stats, memcachedUptimeStat)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		value = s.rate(memcachedSample{value: value, uptime: uptime, time: time.Now()})
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// rate returns the per second rate of the counter since the previous poll. On the first poll, or when
+// the server restarted in between, the average rate since the server started is returned instead.
+func (s *memcachedScaler) rate(sample memcachedSample) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	previous := s.previous
+	s.previous = &sample
+
+	if previous != nil && sample.uptime >= previous.uptime && sample.value >= previous.value {
+		if elapsed := sample.time.Sub(previous.time).Seconds(); elapsed > 0 {
+			return (sample.value - previous.value) / elapsed
+		}
+	}
+	if sample.uptime <= 0 {
+		return 0
+	}
+	return sample.value / sample.uptime
+}
+
+// getStats returns the general-purpose statistics of the server
+func (s *memcachedScaler) getStats(ctx context.Context) (map[string]string, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	var conn net.Conn
+	var err error
+	if s.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.metadata.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.metadata.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if s.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := conn.Write([]byte("stats\r\n")); err != nil {
+		return nil, err
+	}
+
+	stats := map[string]string{}
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "END" {
+			// the connection is closed right after, sending quit is only a courtesy
+			_, _ = conn.Write([]byte("quit\r\n"))
+			return stats, nil
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 || fields[0] != "STAT" {
+			return nil, fmt.Errorf("unexpected response %q", line)
+		}
+		stats[fields[1]] = fields[2]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("connection closed before the end of the stats")
+}
+
+func parseMemcachedStat(stats map[string]string, name string) (float64, error) {
+	raw, found := stats[name]
+	if !found {
+		return -1, fmt.Errorf("stat %s not found", name)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return -1, fmt.Errorf("stat %s isn't a number: %q", name, raw)
+	}
+	return value, nil
+}
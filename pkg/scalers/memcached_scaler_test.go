@@ -0,0 +1,136 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseMemcachedMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type memcachedMetricIdentifier struct {
+	metadataTestData *parseMemcachedMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testMemcachedMetadata = []parseMemcachedMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"address": "memcached:11211", "stat": "curr_connections", "targetValue": "500"}, map[string]string{}, false, "gauge"},
+	{map[string]string{"address": "memcached:11211", "stat": "cmd_get", "rate": "true", "targetValue": "10000", "activationTargetValue": "10"}, map[string]string{}, false, "rate"},
+	{map[string]string{"address": "memcached:11211", "stat": "evictions", "rate": "true", "targetValue": "1", "enableTLS": "true", "unsafeSsl": "true"}, map[string]string{"ca": "ca", "cert": "cert", "key": "key"}, false, "tls"},
+	{map[string]string{"address": "memcached", "stat": "curr_connections", "targetValue": "500"}, map[string]string{}, true, "address without port"},
+	{map[string]string{"address": "memcached:11211", "targetValue": "500"}, map[string]string{}, true, "missing stat"},
+	{map[string]string{"address": "memcached:11211", "stat": "curr_connections"}, map[string]string{}, true, "missing targetValue"},
+	{map[string]string{"address": "memcached:11211", "stat": "curr_connections", "targetValue": "500"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+}
+
+var memcachedMetricIdentifiers = []memcachedMetricIdentifier{
+	{&testMemcachedMetadata[1], 0, "s0-memcached-curr_connections"},
+	{&testMemcachedMetadata[2], 1, "s1-memcached-cmd_get-rate"},
+}
+
+func TestMemcachedParseMetadata(t *testing.T) {
+	for _, testData := range testMemcachedMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseMemcachedMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestMemcachedGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range memcachedMetricIdentifiers {
+		meta, err := parseMemcachedMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMemcachedScaler := memcachedScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockMemcachedScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestMemcachedGetMetricsAndActivity(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				command, _ := bufio.NewReader(conn).ReadString('\n')
+				if command != "stats\r\n" {
+					_, _ = conn.Write([]byte("ERROR\r\n"))
+					return
+				}
+				_, _ = conn.Write([]byte("STAT pid 1\r\nSTAT uptime 100\r\nSTAT version 1.6.21\r\nSTAT curr_connections 42\r\nSTAT cmd_get 5000\r\nEND\r\n"))
+			}()
+		}
+	}()
+
+	s, err := NewMemcachedScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"address": listener.Addr().String(), "stat": "curr_connections", "targetValue": "100", "activationTargetValue": "50"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "memcached")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(42), metrics[0].Value.Value())
+
+	// without a previous poll, the rate is the average since the server started
+	s, err = NewMemcachedScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"address": listener.Addr().String(), "stat": "cmd_get", "rate": "true", "targetValue": "100"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	metrics, isActive, err = s.GetMetricsAndActivity(context.Background(), "memcached")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(50), metrics[0].Value.Value())
+
+	s, err = NewMemcachedScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:   map[string]string{"address": listener.Addr().String(), "stat": "missing", "targetValue": "100"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "memcached")
+	assert.ErrorContains(t, err, "stat missing not found")
+}
+
+func TestMemcachedRate(t *testing.T) {
+	s := &memcachedScaler{}
+	now := time.Now()
+
+	assert.Equal(t, float64(10), s.rate(memcachedSample{value: 1000, uptime: 100, time: now}))
+	assert.Equal(t, float64(50), s.rate(memcachedSample{value: 4000, uptime: 160, time: now.Add(time.Minute)}))
+	// the server restarted, its counters were reset
+	assert.Equal(t, float64(20), s.rate(memcachedSample{value: 200, uptime: 10, time: now.Add(2 * time.Minute)}))
+}
@@ -1,20 +1,25 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
-	"encoding/xml"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	neturl "net/url"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 	"gopkg.in/yaml.v3"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -38,8 +43,13 @@ type metricsAPIScalerMetadata struct {
 	url                   string
 	format                APIFormat
 	valueLocation         string
+	aggregation           string
 	unsafeSsl             bool
 
+	// graphQL
+	graphQLQuery     string
+	graphQLVariables map[string]interface{}
+
 	// apiKeyAuth
 	enableAPIKeyAuth bool
 	method           string // way of providing auth key, either "header" (default) or "query"
@@ -63,14 +73,33 @@ type metricsAPIScalerMetadata struct {
 	enableBearerAuth bool
 	bearerToken      string
 
+	// oauth, the access tokens being requested with the client credentials grant
+	enableOAuth    bool
+	oauthTokenURI  string
+	clientID       string
+	clientSecret   string
+	scopes         []string
+	endpointParams neturl.Values
+
 	triggerIndex int
 }
 
 const (
 	methodValueQuery           = "query"
 	valueLocationWrongErrorMsg = "valueLocation must point to value of type number or a string representing a Quantity got: '%s'"
+
+	metricsAPIAggregationSum = "sum"
+	metricsAPIAggregationAvg = "avg"
+	metricsAPIAggregationMax = "max"
+	metricsAPIAggregationMin = "min"
 )
 
+// graphQLRequest is the body of the POST request of a GraphQL query
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
 type APIFormat string
 
 // Options for APIFormat:
@@ -88,6 +117,12 @@ var (
 		XMLFormat,
 		YAMLFormat,
 	}
+	supportedAggregations = []string{
+		metricsAPIAggregationSum,
+		metricsAPIAggregationAvg,
+		metricsAPIAggregationMax,
+		metricsAPIAggregationMin,
+	}
 )
 
 // NewMetricsAPIScaler creates a new HTTP scaler
@@ -112,6 +147,21 @@ func NewMetricsAPIScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(config)
 	}
 
+	if meta.enableOAuth {
+		oauthConfig := clientcredentials.Config{
+			ClientID:       meta.clientID,
+			ClientSecret:   meta.clientSecret,
+			TokenURL:       meta.oauthTokenURI,
+			Scopes:         meta.scopes,
+			EndpointParams: meta.endpointParams,
+		}
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl))
+		httpClient.Transport = &oauth2.Transport{
+			Source: oauthConfig.TokenSource(tokenCtx),
+			Base:   httpClient.Transport,
+		}
+	}
+
 	return &metricsAPIScaler{
 		metricType: metricType,
 		metadata:   meta,
@@ -178,6 +228,30 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	// graphQLQuery is POSTed to the url with its variables, valueLocation pointing to the value in the JSON response
+	if val, ok := config.TriggerMetadata["graphQLQuery"]; ok && val != "" {
+		meta.graphQLQuery = val
+		if meta.format != JSONFormat {
+			return nil, fmt.Errorf("format %s not supported with graphQLQuery, the response being JSON", meta.format)
+		}
+		if vars, ok := config.TriggerMetadata["graphQLVariables"]; ok && vars != "" {
+			if err := json.Unmarshal([]byte(vars), &meta.graphQLVariables); err != nil {
+				return nil, fmt.Errorf("error parsing graphQLVariables, expected a JSON object: %w", err)
+			}
+		}
+	}
+
+	// aggregation combines all the values valueLocation points to, e.g. of the elements of an array
+	if val, ok := config.TriggerMetadata["aggregation"]; ok {
+		meta.aggregation = strings.TrimSpace(val)
+		if !kedautil.Contains(supportedAggregations, meta.aggregation) {
+			return nil, fmt.Errorf("aggregation %s not supported", meta.aggregation)
+		}
+		if meta.format == YAMLFormat {
+			return nil, fmt.Errorf("aggregation is not supported with format %s", meta.format)
+		}
+	}
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
@@ -236,6 +310,20 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 
 		meta.bearerToken = config.AuthParams["token"]
 		meta.enableBearerAuth = true
+	case authentication.OAuthType:
+		meta.oauthTokenURI = config.AuthParams["oauthTokenURI"]
+		meta.clientID = config.AuthParams["clientID"]
+		meta.clientSecret = config.AuthParams["clientSecret"]
+		if meta.oauthTokenURI == "" || meta.clientID == "" || meta.clientSecret == "" {
+			return nil, errors.New("oauthTokenURI, clientID and clientSecret are required when oauth is enabled")
+		}
+		meta.scopes = authentication.ParseScope(config.AuthParams["scopes"])
+		endpointParams, err := authentication.ParseEndpointParams(config.AuthParams["endpointParams"])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing endpointParams: %w", err)
+		}
+		meta.endpointParams = endpointParams
+		meta.enableOAuth = true
 	default:
 		return nil, fmt.Errorf("err incorrect value for authMode is given: %s", authMode)
 	}
@@ -262,76 +350,169 @@ func GetValueFromResponse(body []byte, valueLocation string, format APIFormat) (
 	return 0, fmt.Errorf("format %s not supported", format)
 }
 
+// GetAggregatedValueFromResponse uses provided valueLocation to access all the numeric values in provided body using
+// the format specified, e.g. of the elements of an array, and combines them with the aggregation.
+func GetAggregatedValueFromResponse(body []byte, valueLocation string, format APIFormat, aggregation string) (float64, error) {
+	var values []float64
+	var err error
+	switch format {
+	case PrometheusFormat:
+		values, err = getValuesFromPrometheusResponse(body, valueLocation)
+	case JSONFormat:
+		values, err = getValuesFromJSONResponse(body, valueLocation)
+	case XMLFormat:
+		values, err = getValuesFromXMLResponse(body, valueLocation)
+	default:
+		return 0, fmt.Errorf("aggregation is not supported with format %s", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var result float64
+	for i, value := range values {
+		switch {
+		case i == 0:
+			result = value
+		case aggregation == metricsAPIAggregationMax:
+			result = max(result, value)
+		case aggregation == metricsAPIAggregationMin:
+			result = min(result, value)
+		default:
+			result += value
+		}
+	}
+	if aggregation == metricsAPIAggregationAvg && len(values) > 0 {
+		result /= float64(len(values))
+	}
+	return result, nil
+}
+
 // getValueFromPrometheusResponse uses provided valueLocation to access the numeric value in provided body
 func getValueFromPrometheusResponse(body []byte, valueLocation string) (float64, error) {
-	matchers, err := parser.ParseMetricSelector(valueLocation)
+	values, err := getValuesFromPrometheusResponse(body, valueLocation)
 	if err != nil {
 		return 0, err
 	}
-	metricName := ""
-	for _, v := range matchers {
-		if v.Name == "__name__" {
-			metricName = v.Value
-		}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("value %s not found", valueLocation)
+	}
+	return values[0], nil
+}
+
+// getValuesFromPrometheusResponse returns the values of the series of provided body matching the selector of
+// valueLocation, e.g. backend_queue_size{queueName=~"orders-.*", instance!="test"}
+func getValuesFromPrometheusResponse(body []byte, valueLocation string) ([]float64, error) {
+	matchers, err := parser.ParseMetricSelector(valueLocation)
+	if err != nil {
+		return nil, err
 	}
 	// Ensure EOL
 	reader := strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n"))
 	familiesParser := expfmt.TextParser{}
 	families, err := familiesParser.TextToMetricFamilies(reader)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	family, ok := families[metricName]
-	if !ok {
-		return 0, fmt.Errorf("metric '%s' not found", metricName)
+
+	var names []string
+	for name := range families {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	metrics := family.GetMetric()
-	for _, metric := range metrics {
-		labels := metric.GetLabel()
-		match := true
-		for _, matcher := range matchers {
-			matcherFound := false
-			if matcher == nil {
-				continue
-			}
-			// The name has been already validated,
-			// so we can skip it and check the other labels
-			if matcher.Name == "__name__" {
-				continue
+	var values []float64
+	familyFound := false
+	for _, name := range names {
+		if !matchesPrometheusLabel(matchers, "__name__", name) {
+			continue
+		}
+		familyFound = true
+
+		for _, metric := range families[name].GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
 			}
-			for _, label := range labels {
-				if *label.Name == matcher.Name &&
-					*label.Value == matcher.Value {
-					matcherFound = true
+			match := true
+			for _, matcher := range matchers {
+				// The name has been already validated,
+				// so we can skip it and check the other labels
+				if matcher == nil || matcher.Name == "__name__" {
+					continue
+				}
+				if !matcher.Matches(labels[matcher.Name]) {
+					match = false
+					break
 				}
 			}
-			if !matcherFound {
-				match = false
+			if !match {
+				continue
 			}
-		}
-		if match {
+
 			untyped := metric.GetUntyped()
 			if untyped != nil && untyped.Value != nil {
-				return *untyped.Value, nil
+				values = append(values, *untyped.Value)
+				continue
 			}
 			counter := metric.GetCounter()
 			if counter != nil && counter.Value != nil {
-				return *counter.Value, nil
+				values = append(values, *counter.Value)
+				continue
 			}
 			gauge := metric.GetGauge()
 			if gauge != nil && gauge.Value != nil {
-				return *gauge.Value, nil
+				values = append(values, *gauge.Value)
 			}
 		}
 	}
+	if !familyFound {
+		return nil, fmt.Errorf("metric '%s' not found", valueLocation)
+	}
+	return values, nil
+}
 
-	return 0, fmt.Errorf("value %s not found", valueLocation)
+func matchesPrometheusLabel(matchers []*labels.Matcher, name, value string) bool {
+	for _, matcher := range matchers {
+		if matcher != nil && matcher.Name == name && !matcher.Matches(value) {
+			return false
+		}
+	}
+	return true
 }
 
 // getValueFromJSONResponse uses provided valueLocation to access the numeric value in provided body using GJSON
 func getValueFromJSONResponse(body []byte, valueLocation string) (float64, error) {
+	return getJSONResultValue(gjson.GetBytes(body, valueLocation))
+}
+
+// getValuesFromJSONResponse uses provided valueLocation to access the numeric values in provided body using GJSON,
+// the values of the elements being returned when it points to an array, e.g. components.#.tasks
+func getValuesFromJSONResponse(body []byte, valueLocation string) ([]float64, error) {
 	r := gjson.GetBytes(body, valueLocation)
+	if !r.Exists() {
+		return nil, fmt.Errorf("valueLocation %s not found", valueLocation)
+	}
+	results := []gjson.Result{r}
+	if r.IsArray() {
+		results = r.Array()
+	}
+
+	values := make([]float64, 0, len(results))
+	for _, result := range results {
+		if result.Type == gjson.Null {
+			continue
+		}
+		value, err := getJSONResultValue(result)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func getJSONResultValue(r gjson.Result) (float64, error) {
 	if r.Type == gjson.String {
 		v, err := resource.ParseQuantity(r.String())
 		if err != nil {
@@ -345,35 +526,35 @@ func getValueFromJSONResponse(body []byte, valueLocation string) (float64, error
 	return r.Num, nil
 }
 
-// getValueFromXMLResponse uses provided valueLocation to access the numeric value in provided body
+// getValueFromXMLResponse uses provided valueLocation, an XPath expression, to access the numeric value in provided body
 func getValueFromXMLResponse(body []byte, valueLocation string) (float64, error) {
-	var xmlMap map[string]interface{}
-	err := xml.Unmarshal(body, &xmlMap)
+	values, err := getValuesFromXMLResponse(body, valueLocation)
 	if err != nil {
 		return 0, err
 	}
+	return values[0], nil
+}
 
-	path, err := kedautil.GetValueByPath(xmlMap, valueLocation)
+// getValuesFromXMLResponse uses provided valueLocation, an XPath expression, to access the numeric values of all the
+// nodes it selects in provided body, e.g. //queue/@length
+func getValuesFromXMLResponse(body []byte, valueLocation string) ([]float64, error) {
+	nodes, err := kedautil.GetValuesByXPath(body, valueLocation)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("valueLocation %s not found", valueLocation)
 	}
 
-	switch v := path.(type) {
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case float64:
-		return v, nil
-	case string:
-		r, err := resource.ParseQuantity(v)
+	values := make([]float64, 0, len(nodes))
+	for _, node := range nodes {
+		v, err := resource.ParseQuantity(node)
 		if err != nil {
-			return 0, fmt.Errorf(valueLocationWrongErrorMsg, v)
+			return nil, fmt.Errorf(valueLocationWrongErrorMsg, node)
 		}
-		return r.AsApproximateFloat64(), nil
-	default:
-		return 0, fmt.Errorf(valueLocationWrongErrorMsg, v)
+		values = append(values, v.AsApproximateFloat64())
 	}
+	return values, nil
 }
 
 // getValueFromYAMLResponse uses provided valueLocation to access the numeric value in provided body
@@ -429,6 +610,14 @@ func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error)
 	if err != nil {
 		return 0, err
 	}
+	if s.metadata.graphQLQuery != "" {
+		if errs := gjson.GetBytes(b, "errors.#.message"); len(errs.Array()) > 0 {
+			return 0, fmt.Errorf("graphql query returned errors: %s", errs.Raw)
+		}
+	}
+	if s.metadata.aggregation != "" {
+		return GetAggregatedValueFromResponse(b, s.metadata.valueLocation, s.metadata.format, s.metadata.aggregation)
+	}
 	v, err := GetValueFromResponse(b, s.metadata.valueLocation, s.metadata.format)
 	if err != nil {
 		return 0, err
@@ -474,6 +663,22 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 	var req *http.Request
 	var err error
 
+	newRequest := func(url string) (*http.Request, error) {
+		if meta.graphQLQuery == "" {
+			return http.NewRequestWithContext(ctx, "GET", url, nil)
+		}
+		body, err := json.Marshal(graphQLRequest{Query: meta.graphQLQuery, Variables: meta.graphQLVariables})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+
 	switch {
 	case meta.enableAPIKeyAuth:
 		if meta.method == methodValueQuery {
@@ -486,13 +691,13 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 			}
 
 			url.RawQuery = queryString.Encode()
-			req, err = http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+			req, err = newRequest(url.String())
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			// default behaviour is to use header method
-			req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+			req, err = newRequest(meta.url)
 			if err != nil {
 				return nil, err
 			}
@@ -504,20 +709,20 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 			}
 		}
 	case meta.enableBaseAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = newRequest(meta.url)
 		if err != nil {
 			return nil, err
 		}
 
 		req.SetBasicAuth(meta.username, meta.password)
 	case meta.enableBearerAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = newRequest(meta.url)
 		if err != nil {
 			return nil, err
 		}
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", meta.bearerToken))
 	default:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
+		req, err = newRequest(meta.url)
 		if err != nil {
 			return nil, err
 		}
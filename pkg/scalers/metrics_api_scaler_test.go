@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -34,6 +35,18 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{"valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
 	// Missing targetValue
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric"}, raisesError: true},
+	// GraphQL
+	{metadata: map[string]string{"url": "http://dummy:1230/graphql", "valueLocation": "data.queue.depth", "targetValue": "42", "graphQLQuery": "query($name: String!) { queue(name: $name) { depth } }", "graphQLVariables": `{"name": "orders"}`}, raisesError: false},
+	// GraphQL with variables not a JSON object
+	{metadata: map[string]string{"url": "http://dummy:1230/graphql", "valueLocation": "data.queue.depth", "targetValue": "42", "graphQLQuery": "{ queue { depth } }", "graphQLVariables": "name=orders"}, raisesError: true},
+	// GraphQL not supported with XML
+	{metadata: map[string]string{"url": "http://dummy:1230/graphql", "valueLocation": "/data/queue/depth", "targetValue": "42", "format": "xml", "graphQLQuery": "{ queue { depth } }"}, raisesError: true},
+	// Aggregation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric.#.test", "targetValue": "42", "aggregation": "sum"}, raisesError: false},
+	// Unknown aggregation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric.#.test", "targetValue": "42", "aggregation": "median"}, raisesError: true},
+	// Aggregation not supported with YAML
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "format": "yaml", "aggregation": "max"}, raisesError: true},
 }
 
 type metricAPIAuthMetadataTestData struct {
@@ -43,6 +56,10 @@ type metricAPIAuthMetadataTestData struct {
 }
 
 var testMetricsAPIAuthMetadata = []metricAPIAuthMetadataTestData{
+	// success oauth
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "oauth"}, map[string]string{"oauthTokenURI": "http://dummy:1231/token", "clientID": "id", "clientSecret": "secret", "scopes": "metrics.read, queues.read", "endpointParams": "audience=metrics"}, false},
+	// fail oauth, clientSecret not given
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "oauth"}, map[string]string{"oauthTokenURI": "http://dummy:1231/token", "clientID": "id"}, true},
 	// success TLS
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "tls"}, map[string]string{"ca": "caaa", "cert": "ceert", "key": "keey"}, false},
 	// fail TLS, ca not given
@@ -135,6 +152,10 @@ func TestGetValueFromResponse(t *testing.T) {
 	# TYPE random_metric counter
 	random_metric 10
 	`)
+	inputXML := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+	<stats count="2.43">
+		<component id="82328e93e"><tasks>32</tasks><str>64</str><k>1k</k><wrong>NaN</wrong></component>
+	</stats>`)
 
 	testCases := []struct {
 		name      string
@@ -154,11 +175,19 @@ func TestGetValueFromResponse(t *testing.T) {
 		{name: "{}.[].{}", input: inputYAML, key: "components.0.tasks", format: YAMLFormat, expectVal: 32},
 		{name: "invalid data", input: inputYAML, key: "components.0.wrong", format: YAMLFormat, expectErr: true},
 
+		{name: "attribute", input: inputXML, key: "/stats/@count", format: XMLFormat, expectVal: 2.43},
+		{name: "element", input: inputXML, key: "/stats/component/tasks", format: XMLFormat, expectVal: 32},
+		{name: "predicate", input: inputXML, key: "//component[@id='82328e93e']/k", format: XMLFormat, expectVal: 1000},
+		{name: "not found", input: inputXML, key: "/stats/missing", format: XMLFormat, expectErr: true},
+		{name: "invalid data", input: inputXML, key: "/stats/component/wrong", format: XMLFormat, expectErr: true},
+
 		{name: "no labels", input: inputPrometheus, key: "random_metric", format: PrometheusFormat, expectVal: 10},
 		{name: "one label", input: inputPrometheus, key: "backend_queue_size{queueName=\"one\"}", format: PrometheusFormat, expectVal: 1},
 		{name: "multiple labels not queried", input: inputPrometheus, key: "backend_queue_size{queueName=\"two\"}", format: PrometheusFormat, expectVal: 2},
 		{name: "multiple labels queried", input: inputPrometheus, key: "backend_queue_size{queueName=\"two\", instance=\"zero\"}", format: PrometheusFormat, expectVal: 20},
 		{name: "invalid data", input: inputPrometheus, key: "backend_queue_size{invalid=test}", format: PrometheusFormat, expectErr: true},
+		{name: "regex label", input: inputPrometheus, key: "backend_queue_size{queueName=~\"t.*\", instance!=\"random\"}", format: PrometheusFormat, expectVal: 20},
+		{name: "no series matching", input: inputPrometheus, key: "backend_queue_size{queueName=\"three\"}", format: PrometheusFormat, expectErr: true},
 	}
 
 	for _, tc := range testCases {
@@ -174,6 +203,62 @@ func TestGetValueFromResponse(t *testing.T) {
 	}
 }
 
+func TestGetAggregatedValueFromResponse(t *testing.T) {
+	inputJSON := []byte(`{"workers":[{"id":"a","state":"busy","queue":4},{"id":"b","state":"idle","queue":"1k"},{"id":"c","state":"busy","queue":null},{"id":"d","state":"busy","queue":10}],"idle":[]}`)
+	inputXML := []byte(`<stats>
+		<queue name="orders" length="3"/>
+		<queue name="invoices" length="7"/>
+		<shard><queue name="refunds" length="2"/></shard>
+	</stats>`)
+	inputPrometheus := []byte(`# TYPE backend_queue_size gauge
+	backend_queue_size{queueName="zero",instance="a"} 0
+	backend_queue_size{queueName="one",instance="a"} 1
+	backend_queue_size{queueName="two",instance="b"} 2
+	backend_queue_size{queueName="three",instance="b"} 30
+	`)
+
+	testCases := []struct {
+		name        string
+		input       []byte
+		key         string
+		format      APIFormat
+		aggregation string
+		expectVal   float64
+		expectErr   bool
+	}{
+		{name: "sum of array", input: inputJSON, key: "workers.#.queue", format: JSONFormat, aggregation: "sum", expectVal: 1014},
+		{name: "max of filtered array", input: inputJSON, key: `workers.#(state=="busy")#.queue`, format: JSONFormat, aggregation: "max", expectVal: 10},
+		{name: "avg of filtered array skipping nulls", input: inputJSON, key: `workers.#(state=="busy")#.queue`, format: JSONFormat, aggregation: "avg", expectVal: 7},
+		{name: "single value", input: inputJSON, key: "workers.0.queue", format: JSONFormat, aggregation: "sum", expectVal: 4},
+		{name: "empty array", input: inputJSON, key: "idle", format: JSONFormat, aggregation: "sum", expectVal: 0},
+		{name: "not found", input: inputJSON, key: "missing", format: JSONFormat, aggregation: "sum", expectErr: true},
+		{name: "invalid data", input: inputJSON, key: "workers.#.state", format: JSONFormat, aggregation: "sum", expectErr: true},
+
+		{name: "sum of attributes", input: inputXML, key: "//queue/@length", format: XMLFormat, aggregation: "sum", expectVal: 12},
+		{name: "min of children", input: inputXML, key: "/stats/queue/@length", format: XMLFormat, aggregation: "min", expectVal: 3},
+
+		{name: "sum of series", input: inputPrometheus, key: `backend_queue_size{instance="b"}`, format: PrometheusFormat, aggregation: "sum", expectVal: 32},
+		{name: "avg of all series", input: inputPrometheus, key: "backend_queue_size", format: PrometheusFormat, aggregation: "avg", expectVal: 8.25},
+		{name: "max of regex", input: inputPrometheus, key: `backend_queue_size{queueName=~"one|two"}`, format: PrometheusFormat, aggregation: "max", expectVal: 2},
+		{name: "no series matching", input: inputPrometheus, key: `backend_queue_size{instance="c"}`, format: PrometheusFormat, aggregation: "sum", expectVal: 0},
+		{name: "metric not found", input: inputPrometheus, key: "random_metric", format: PrometheusFormat, aggregation: "sum", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format)+": "+tc.name, func(t *testing.T) {
+			v, err := GetAggregatedValueFromResponse(tc.input, tc.key, tc.format, tc.aggregation)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.EqualValues(t, tc.expectVal, v)
+		})
+	}
+}
+
 func TestMetricAPIScalerAuthParams(t *testing.T) {
 	for _, testData := range testMetricsAPIAuthMetadata {
 		meta, err := parseMetricsAPIMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
@@ -189,7 +274,8 @@ func TestMetricAPIScalerAuthParams(t *testing.T) {
 			if (meta.enableAPIKeyAuth && !(testData.metadata["authMode"] == "apiKey")) ||
 				(meta.enableBaseAuth && !(testData.metadata["authMode"] == "basic")) ||
 				(meta.enableTLS && !(testData.metadata["authMode"] == "tls")) ||
-				(meta.enableBearerAuth && !(testData.metadata["authMode"] == "bearer")) {
+				(meta.enableBearerAuth && !(testData.metadata["authMode"] == "bearer")) ||
+				(meta.enableOAuth && !(testData.metadata["authMode"] == "oauth")) {
 				t.Error("wrong auth mode detected")
 			}
 		}
@@ -239,6 +325,62 @@ func TestBearerAuth(t *testing.T) {
 	}
 }
 
+func TestGraphQLQueryWithOAuth(t *testing.T) {
+	var tokenStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "metrics.read", r.PostForm.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"oauth-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenStub.Close()
+
+	response := `{"data":{"queue":{"depth":12}}}`
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer oauth-token", r.Header.Get("Authorization"))
+
+		var request graphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "query($name: String!) { queue(name: $name) { depth } }", request.Query)
+		assert.Equal(t, map[string]interface{}{"name": "orders"}, request.Variables)
+
+		_, _ = w.Write([]byte(response))
+	}))
+	defer apiStub.Close()
+
+	s, err := NewMetricsAPIScaler(
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{
+				"url":              apiStub.URL,
+				"valueLocation":    "data.queue.depth",
+				"targetValue":      "10",
+				"graphQLQuery":     "query($name: String!) { queue(name: $name) { depth } }",
+				"graphQLVariables": `{"name": "orders"}`,
+				"authMode":         "oauth",
+			},
+			AuthParams: map[string]string{
+				"oauthTokenURI": tokenStub.URL,
+				"clientID":      "id",
+				"clientSecret":  "secret",
+				"scopes":        "metrics.read",
+			},
+			GlobalHTTPTimeout: 3000 * time.Millisecond,
+		},
+	)
+	assert.NoError(t, err)
+
+	metrics, active, err := s.GetMetricsAndActivity(context.TODO(), "test-metric")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, float64(12), metrics[0].Value.AsApproximateFloat64())
+
+	response = `{"data":{"queue":null},"errors":[{"message":"queue orders not found"}]}`
+	_, _, err = s.GetMetricsAndActivity(context.TODO(), "test-metric")
+	assert.ErrorContains(t, err, "queue orders not found")
+}
+
 type MockHTTPRoundTripper struct {
 	mock.Mock
 }
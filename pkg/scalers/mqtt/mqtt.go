@@ -0,0 +1,247 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// types of the control packets of MQTT 3.1.1, in the upper 4 bits of the fixed header
+const (
+	packetConnect    = 1
+	packetConnAck    = 2
+	packetPublish    = 3
+	packetSubscribe  = 8
+	packetSubAck     = 9
+	packetDisconnect = 14
+)
+
+const (
+	protocolLevel     = 4
+	flagCleanSession  = 0x02
+	flagPassword      = 0x40
+	flagUsername      = 0x80
+	keepAliveSeconds  = 30
+	subscribePacketID = 1
+	subscribeFailure  = 0x80
+)
+
+// connAckErrors are the reasons of the refused connections
+var connAckErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Config contains the information required to connect to an MQTT broker.
+type Config struct {
+	// Address is the host:port of the broker
+	Address  string
+	ClientID string
+	Username string
+	Password string
+	// TLSConfig enables TLS when it isn't nil
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// ReadFloat64 subscribes to the topic and returns the number published in the first message received,
+// usually the retained message of a topic the broker publishes its statistics to, like $SYS topics.
+func ReadFloat64(ctx context.Context, config *Config, topic string) (float64, error) {
+	conn, err := dial(ctx, config)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to %s: %w", config.Address, err)
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	if err := connect(conn, r, config); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Write(encodeSubscribe(topic)); err != nil {
+		return 0, err
+	}
+
+	// the retained message may be delivered before the SUBACK
+	for {
+		header, data, err := readPacket(r)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return 0, fmt.Errorf("no message received on topic %s", topic)
+			}
+			return 0, err
+		}
+
+		switch header >> 4 {
+		case packetSubAck:
+			if len(data) < 3 || data[2] == subscribeFailure {
+				return 0, fmt.Errorf("subscription to %s refused", topic)
+			}
+		case packetPublish:
+			payload, err := publishPayload(header, data)
+			if err != nil {
+				return 0, err
+			}
+			// the value is returned even if the disconnection fails
+			_, _ = conn.Write([]byte{packetDisconnect << 4, 0})
+
+			value, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+			if err != nil {
+				return 0, fmt.Errorf("message %q on topic %s isn't a number", payload, topic)
+			}
+			return value, nil
+		}
+	}
+}
+
+func dial(ctx context.Context, config *Config) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	var conn net.Conn
+	var err error
+	if config.TLSConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: config.TLSConfig}).DialContext(ctx, "tcp", config.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", config.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if config.Timeout > 0 {
+		deadline = time.Now().Add(config.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func connect(conn net.Conn, r *bufio.Reader, config *Config) error {
+	flags := byte(flagCleanSession)
+	var payload []byte
+	payload = appendString(payload, config.ClientID)
+	if config.Username != "" {
+		flags |= flagUsername
+		payload = appendString(payload, config.Username)
+		if config.Password != "" {
+			flags |= flagPassword
+			payload = appendString(payload, config.Password)
+		}
+	}
+
+	var data []byte
+	data = appendString(data, "MQTT")
+	data = append(data, protocolLevel, flags)
+	data = binary.BigEndian.AppendUint16(data, keepAliveSeconds)
+	data = append(data, payload...)
+	if _, err := conn.Write(encodePacket(packetConnect<<4, data)); err != nil {
+		return err
+	}
+
+	header, data, err := readPacket(r)
+	if err != nil {
+		return err
+	}
+	if header>>4 != packetConnAck || len(data) != 2 {
+		return fmt.Errorf("unexpected packet %d instead of CONNACK", header>>4)
+	}
+	if code := data[1]; code != 0 {
+		reason, found := connAckErrors[code]
+		if !found {
+			reason = fmt.Sprintf("return code %d", code)
+		}
+		return fmt.Errorf("connection refused: %s", reason)
+	}
+	return nil
+}
+
+// encodeSubscribe encodes the subscription to the topic with QoS 0
+func encodeSubscribe(topic string) []byte {
+	var data []byte
+	data = binary.BigEndian.AppendUint16(data, subscribePacketID)
+	data = appendString(data, topic)
+	data = append(data, 0)
+	// the flags of SUBSCRIBE are reserved and must be 0010
+	return encodePacket(packetSubscribe<<4|0x02, data)
+}
+
+// publishPayload returns the payload of a PUBLISH, skipping its topic and its packet identifier when its QoS is above 0
+func publishPayload(header byte, data []byte) ([]byte, error) {
+	if len(data) < 2 {
+		return nil, errors.New("malformed PUBLISH")
+	}
+	offset := 2 + int(binary.BigEndian.Uint16(data))
+	if qos := (header >> 1) & 0x03; qos > 0 {
+		offset += 2
+	}
+	if offset > len(data) {
+		return nil, errors.New("malformed PUBLISH")
+	}
+	return data[offset:], nil
+}
+
+func encodePacket(header byte, data []byte) []byte {
+	buf := []byte{header}
+	// the remaining length is encoded 7 bits at a time, the highest bit flagging the following byte
+	length := len(data)
+	for {
+		b := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if length == 0 {
+			break
+		}
+	}
+	return append(buf, data...)
+}
+
+// readPacket reads a control packet and returns its fixed header and the data following it
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	// the remaining length is encoded on at most 4 bytes
+	length := 0
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, nil, err
+	}
+	return header, data, nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(s)))
+	return append(buf, s...)
+}
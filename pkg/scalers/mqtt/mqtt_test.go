@@ -0,0 +1,118 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBroker starts a broker answering the subscription to a topic with the packets returned by respond
+func startBroker(t *testing.T, respond func(topic string) [][]byte) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+
+				header, data, err := readPacket(r)
+				if !assert.NoError(t, err) || !assert.Equal(t, byte(packetConnect<<4), header) {
+					return
+				}
+				// the credentials follow the protocol name, level, flags, keep alive and client identifier
+				offset := 10 + 2 + int(binary.BigEndian.Uint16(data[10:]))
+				user := string(data[offset+2 : offset+2+int(binary.BigEndian.Uint16(data[offset:]))])
+				code := byte(0)
+				if user != "keda" {
+					code = 5
+				}
+				_, _ = conn.Write([]byte{packetConnAck << 4, 2, 0, code})
+
+				header, data, err = readPacket(r)
+				if !assert.NoError(t, err) || !assert.Equal(t, byte(packetSubscribe<<4|0x02), header) {
+					return
+				}
+				topic := string(data[4 : 4+binary.BigEndian.Uint16(data[2:])])
+				for _, packet := range respond(topic) {
+					_, _ = conn.Write(packet)
+				}
+				_, _, _ = readPacket(r)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func encodePublish(topic, payload string, qos byte) []byte {
+	data := appendString(nil, topic)
+	if qos > 0 {
+		data = binary.BigEndian.AppendUint16(data, 7)
+	}
+	return encodePacket(packetPublish<<4|qos<<1|0x01, append(data, payload...))
+}
+
+func TestReadFloat64(t *testing.T) {
+	addr := startBroker(t, func(topic string) [][]byte {
+		subAck := encodePacket(packetSubAck<<4, []byte{0, subscribePacketID, 0})
+		switch topic {
+		case "$SYS/broker/store/messages/count":
+			return [][]byte{subAck, encodePublish(topic, "42", 0)}
+		case "stats/inflight":
+			// the retained message is delivered before the SUBACK
+			return [][]byte{encodePublish(topic, " 7.5\n", 1), subAck}
+		case "stats/name":
+			return [][]byte{subAck, encodePublish(topic, "mosquitto", 0)}
+		case "forbidden/#":
+			return [][]byte{encodePacket(packetSubAck<<4, []byte{0, subscribePacketID, subscribeFailure})}
+		default:
+			return [][]byte{subAck}
+		}
+	})
+
+	config := &Config{Address: addr, ClientID: "keda-test", Username: "keda", Password: "secret", Timeout: 500 * time.Millisecond}
+
+	value, err := ReadFloat64(context.Background(), config, "$SYS/broker/store/messages/count")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+
+	value, err = ReadFloat64(context.Background(), config, "stats/inflight")
+	assert.NoError(t, err)
+	assert.Equal(t, 7.5, value)
+
+	_, err = ReadFloat64(context.Background(), config, "stats/name")
+	assert.ErrorContains(t, err, "isn't a number")
+
+	_, err = ReadFloat64(context.Background(), config, "forbidden/#")
+	assert.ErrorContains(t, err, "refused")
+
+	_, err = ReadFloat64(context.Background(), config, "stats/empty")
+	assert.ErrorContains(t, err, "no message received")
+
+	config.Username = "anonymous"
+	_, err = ReadFloat64(context.Background(), config, "$SYS/broker/store/messages/count")
+	assert.ErrorContains(t, err, "not authorized")
+}
+
+func TestEncodePacket(t *testing.T) {
+	packet := encodePacket(packetPublish<<4, make([]byte, 321))
+	assert.Equal(t, []byte{packetPublish << 4, 0xc1, 0x02}, packet[:3])
+
+	header, data, err := readPacket(bufio.NewReader(bytes.NewReader(packet)))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(packetPublish<<4), header)
+	assert.Len(t, data, 321)
+}
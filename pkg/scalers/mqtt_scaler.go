@@ -0,0 +1,283 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/mqtt"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	mqttBrokerEMQX      = "emqx"
+	mqttBrokerMosquitto = "mosquitto"
+
+	// mqttMosquittoDefaultTopic holds the number of messages held by the store of Mosquitto, queued messages included
+	mqttMosquittoDefaultTopic = "$SYS/broker/store/messages/count"
+	mqttEMQXPageSize          = 100
+)
+
+// errEMQXNotFound is returned when a client disconnected between the listing of the subscriptions and its query
+var errEMQXNotFound = errors.New("not found")
+
+type mqttScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *mqttMetadata
+	httpClient *http.Client
+	mqttConfig *mqtt.Config
+	logger     logr.Logger
+}
+
+type mqttMetadata struct {
+	triggerIndex int
+
+	Broker string `keda:"name=broker, order=triggerMetadata, enum=emqx;mosquitto"`
+	// URL is the endpoint of the REST API of EMQX, Host the address of the MQTT listener of Mosquitto
+	URL  string `keda:"name=url,  order=triggerMetadata;resolvedEnv, optional"`
+	Host string `keda:"name=host, order=triggerMetadata;resolvedEnv, optional"`
+	// ShareGroup is the shared subscription group of the consumers, Topic filters its subscriptions on EMQX
+	// and is the topic Mosquitto publishes the number of messages to
+	ShareGroup                  string  `keda:"name=shareGroup,                  order=triggerMetadata, optional"`
+	Topic                       string  `keda:"name=topic,                       order=triggerMetadata, optional"`
+	IncludeInflight             bool    `keda:"name=includeInflight,             order=triggerMetadata, optional, default=true"`
+	TargetQueueLength           float64 `keda:"name=targetQueueLength,           order=triggerMetadata, optional, default=100"`
+	ActivationTargetQueueLength float64 `keda:"name=activationTargetQueueLength, order=triggerMetadata, optional"`
+
+	// Username and Password are the API key and secret of EMQX, the credentials of the MQTT client on Mosquitto
+	Username  string `keda:"name=username,  order=authParams;resolvedEnv, optional"`
+	Password  string `keda:"name=password,  order=authParams;resolvedEnv, optional"`
+	EnableTLS bool   `keda:"name=enableTLS, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+}
+
+type emqxSubscriptions struct {
+	Data []struct {
+		ClientID string `json:"clientid"`
+	} `json:"data"`
+	Meta struct {
+		HasNext bool `json:"hasnext"`
+	} `json:"meta"`
+}
+
+type emqxClient struct {
+	MqueueLen   int64 `json:"mqueue_len"`
+	InflightCnt int64 `json:"inflight_cnt"`
+}
+
+func (m *mqttMetadata) Validate() error {
+	if m.TargetQueueLength <= 0 {
+		return errors.New("targetQueueLength must be greater than 0")
+	}
+	switch m.Broker {
+	case mqttBrokerEMQX:
+		if (m.Username == "") != (m.Password == "") {
+			return errors.New("both username and password must be provided")
+		}
+		if m.URL == "" {
+			return errors.New("url must be provided for emqx")
+		}
+		if m.ShareGroup == "" {
+			return errors.New("shareGroup must be provided for emqx")
+		}
+		if m.EnableTLS || m.Ca != "" {
+			return errors.New("enableTLS and ca are only supported for mosquitto, use an https url for emqx")
+		}
+		m.URL = strings.TrimSuffix(m.URL, "/")
+	case mqttBrokerMosquitto:
+		if m.Host == "" {
+			return errors.New("host must be provided for mosquitto")
+		}
+		if m.ShareGroup != "" {
+			return errors.New("shareGroup is only supported for emqx, mosquitto doesn't publish statistics per subscription")
+		}
+		if !m.EnableTLS && m.Ca != "" {
+			return errors.New("enableTLS must be true to use ca")
+		}
+		if _, _, err := net.SplitHostPort(m.Host); err != nil {
+			port := "1883"
+			if m.EnableTLS {
+				port = "8883"
+			}
+			m.Host = net.JoinHostPort(m.Host, port)
+		}
+		if m.Topic == "" {
+			m.Topic = mqttMosquittoDefaultTopic
+		}
+	}
+	return nil
+}
+
+// NewMQTTScaler creates a new MQTT scaler
+func NewMQTTScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseMQTTMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mqtt metadata: %w", err)
+	}
+
+	s := &mqttScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "mqtt_scaler"),
+	}
+	if meta.Broker == mqttBrokerEMQX {
+		s.httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+		return s, nil
+	}
+
+	s.mqttConfig = &mqtt.Config{
+		Address:  meta.Host,
+		ClientID: fmt.Sprintf("keda-%s-%d", config.ScalableObjectName, config.TriggerIndex),
+		Username: meta.Username,
+		Password: meta.Password,
+		Timeout:  config.GlobalHTTPTimeout,
+	}
+	if meta.EnableTLS {
+		if s.mqttConfig.TLSConfig, err = kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl); err != nil {
+			return nil, fmt.Errorf("error creating tls config: %w", err)
+		}
+		s.mqttConfig.TLSConfig.ServerName, _, _ = net.SplitHostPort(meta.Host)
+	}
+	return s, nil
+}
+
+func parseMQTTMetadata(config *scalersconfig.ScalerConfig) (*mqttMetadata, error) {
+	meta := &mqttMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *mqttScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *mqttScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("mqtt-%s", s.metadata.Broker)
+	if s.metadata.ShareGroup != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.ShareGroup)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueueLength),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of messages queued for the consumers
+func (s *mqttScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var queueLength float64
+	var err error
+	if s.metadata.Broker == mqttBrokerEMQX {
+		queueLength, err = s.getEMQXQueueLength(ctx)
+	} else {
+		queueLength, err = mqtt.ReadFloat64(ctx, s.mqttConfig, s.metadata.Topic)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting mqtt queue length: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, queueLength)
+	return []external_metrics.ExternalMetricValue{metric}, queueLength > s.metadata.ActivationTargetQueueLength, nil
+}
+
+// getEMQXQueueLength returns the messages queued, and inflight when includeInflight is true,
+// for the clients subscribed with the share group
+func (s *mqttScaler) getEMQXQueueLength(ctx context.Context) (float64, error) {
+	clientIDs := map[string]bool{}
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("share_group", s.metadata.ShareGroup)
+		if s.metadata.Topic != "" {
+			params.Set("topic", s.metadata.Topic)
+		}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(mqttEMQXPageSize))
+
+		subscriptions := emqxSubscriptions{}
+		if err := s.getEMQX(ctx, "/api/v5/subscriptions?"+params.Encode(), &subscriptions); err != nil {
+			return 0, err
+		}
+		for _, subscription := range subscriptions.Data {
+			clientIDs[subscription.ClientID] = true
+		}
+		if !subscriptions.Meta.HasNext {
+			break
+		}
+	}
+
+	var queueLength int64
+	for clientID := range clientIDs {
+		client := emqxClient{}
+		err := s.getEMQX(ctx, "/api/v5/clients/"+url.PathEscape(clientID), &client)
+		if errors.Is(err, errEMQXNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		queueLength += client.MqueueLen
+		if s.metadata.IncludeInflight {
+			queueLength += client.InflightCnt
+		}
+	}
+	return float64(queueLength), nil
+}
+
+func (s *mqttScaler) getEMQX(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %w", path, errEMQXNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, string(body))
+	}
+	return json.Unmarshal(body, result)
+}
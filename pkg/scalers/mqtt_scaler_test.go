@@ -0,0 +1,136 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseMQTTMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type mqttMetricIdentifier struct {
+	metadataTestData *parseMQTTMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testMQTTMetadata = []parseMQTTMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"broker": "emqx", "url": "http://emqx:18083", "shareGroup": "workers", "topic": "jobs/#"}, map[string]string{"username": "key", "password": "secret"}, false, "emqx"},
+	{map[string]string{"broker": "mosquitto", "host": "mosquitto", "enableTLS": "true", "targetQueueLength": "50", "activationTargetQueueLength": "1"}, map[string]string{"username": "keda", "password": "secret", "ca": "ca"}, false, "mosquitto"},
+	{map[string]string{"broker": "hivemq", "url": "http://hivemq:8888"}, map[string]string{}, true, "unknown broker"},
+	{map[string]string{"broker": "emqx", "shareGroup": "workers"}, map[string]string{}, true, "emqx without url"},
+	{map[string]string{"broker": "emqx", "url": "http://emqx:18083"}, map[string]string{}, true, "emqx without shareGroup"},
+	{map[string]string{"broker": "emqx", "url": "http://emqx:18083", "shareGroup": "workers"}, map[string]string{"username": "key"}, true, "emqx username without password"},
+	{map[string]string{"broker": "emqx", "url": "http://emqx:18083", "shareGroup": "workers", "enableTLS": "true"}, map[string]string{}, true, "emqx with enableTLS"},
+	{map[string]string{"broker": "mosquitto"}, map[string]string{}, true, "mosquitto without host"},
+	{map[string]string{"broker": "mosquitto", "host": "mosquitto:1883", "shareGroup": "workers"}, map[string]string{}, true, "mosquitto with shareGroup"},
+	{map[string]string{"broker": "mosquitto", "host": "mosquitto:1883"}, map[string]string{"ca": "ca"}, true, "mosquitto ca without TLS"},
+	{map[string]string{"broker": "mosquitto", "host": "mosquitto:1883", "targetQueueLength": "0"}, map[string]string{}, true, "zero targetQueueLength"},
+}
+
+var mqttMetricIdentifiers = []mqttMetricIdentifier{
+	{&testMQTTMetadata[1], 0, "s0-mqtt-emqx-workers"},
+	{&testMQTTMetadata[2], 1, "s1-mqtt-mosquitto"},
+}
+
+func TestMQTTParseMetadata(t *testing.T) {
+	for _, testData := range testMQTTMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestMQTTParseMetadataMosquittoDefaults(t *testing.T) {
+	meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testMQTTMetadata[2].metadata, AuthParams: testMQTTMetadata[2].authParams})
+	require.NoError(t, err)
+	assert.Equal(t, "mosquitto:8883", meta.Host)
+	assert.Equal(t, "$SYS/broker/store/messages/count", meta.Topic)
+}
+
+func TestMQTTGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range mqttMetricIdentifiers {
+		meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMQTTScaler := mqttScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockMQTTScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestMQTTGetMetricsAndActivityEMQX(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		assert.Equal(t, "key", username)
+		assert.Equal(t, "secret", password)
+
+		switch r.URL.Path {
+		case "/api/v5/subscriptions":
+			assert.Equal(t, "workers", r.URL.Query().Get("share_group"))
+			assert.Equal(t, "jobs/#", r.URL.Query().Get("topic"))
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"data":[{"clientid":"worker-1","topic":"jobs/#","qos":1},{"clientid":"worker-2","topic":"jobs/#","qos":1}],"meta":{"page":1,"limit":100,"hasnext":true}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"clientid":"worker-1","topic":"jobs/+/high","qos":1},{"clientid":"worker-3","topic":"jobs/#","qos":1}],"meta":{"page":2,"limit":100,"hasnext":false}}`))
+		case "/api/v5/clients/worker-1":
+			_, _ = w.Write([]byte(`{"clientid":"worker-1","connected":true,"mqueue_len":10,"inflight_cnt":2}`))
+		case "/api/v5/clients/worker-2":
+			_, _ = w.Write([]byte(`{"clientid":"worker-2","connected":true,"mqueue_len":5,"inflight_cnt":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"CLIENTID_NOT_FOUND","message":"Client ID not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+	}{
+		{"queued and inflight messages", map[string]string{}, 18},
+		{"queued messages", map[string]string{"includeInflight": "false"}, 15},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"broker": "emqx", "url": server.URL, "shareGroup": "workers", "topic": "jobs/#"}
+			for key, value := range tc.metadata {
+				metadata[key] = value
+			}
+			s, err := NewMQTTScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"username": "key", "password": "secret"}})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "mqtt")
+			assert.NoError(t, err)
+			assert.True(t, isActive)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
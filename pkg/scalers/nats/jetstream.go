@@ -0,0 +1,323 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPayloadLength protects against reading garbage as the length of a message
+const maxPayloadLength = 8 * 1024 * 1024
+
+// Config contains the information required to request the JetStream API over the client protocol.
+type Config struct {
+	// Address is the host:port of the client port of the server, usually 4222
+	Address  string
+	Username string
+	Password string
+	Token    string
+	// TLSConfig protects the connection with TLS, which is also the case when the server requires it
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// ConsumerInfo is the state of a consumer as reported by $JS.API.CONSUMER.INFO.
+type ConsumerInfo struct {
+	Stream         string `json:"stream_name"`
+	Name           string `json:"name"`
+	NumAckPending  int64  `json:"num_ack_pending"`
+	NumRedelivered int64  `json:"num_redelivered"`
+	NumWaiting     int64  `json:"num_waiting"`
+	NumPending     int64  `json:"num_pending"`
+}
+
+// StreamInfo is the state of a stream as reported by $JS.API.STREAM.INFO.
+type StreamInfo struct {
+	State struct {
+		Messages     int64 `json:"messages"`
+		LastSequence int64 `json:"last_seq"`
+	} `json:"state"`
+}
+
+// APIError is an error returned by the JetStream API, e.g. 404 when the stream or consumer doesn't exist.
+type APIError struct {
+	Code        int    `json:"code"`
+	ErrCode     int    `json:"err_code"`
+	Description string `json:"description"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jetstream api error %d: %s", e.Code, e.Description)
+}
+
+// Conn is a connection to a server, subscribed to the replies of the requests it sends.
+type Conn struct {
+	conn  net.Conn
+	r     *bufio.Reader
+	inbox string
+	seq   int
+}
+
+type serverInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type connectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	Protocol    int    `json:"protocol"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+// Connect opens the connection, protected with TLS when required, authenticates and subscribes to the inbox
+// of the replies. The deadline of the context or the timeout applies to the whole use of the connection.
+func Connect(ctx context.Context, c *Config) (*Conn, error) {
+	host, _, err := net.SplitHostPort(c.Address)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if c.Timeout > 0 {
+		deadline = time.Now().Add(c.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	nc := &Conn{conn: conn, r: bufio.NewReader(conn)}
+	if err := nc.handshake(c, host); err != nil {
+		nc.conn.Close()
+		return nil, err
+	}
+	return nc, nil
+}
+
+// handshake reads the INFO of the server, sends the CONNECT and waits for the PONG answering the PING following
+// it, which is how the server tells that the connection is accepted
+func (nc *Conn) handshake(c *Config, host string) error {
+	line, err := nc.readLine()
+	if err != nil {
+		return err
+	}
+	payload, found := strings.CutPrefix(line, "INFO ")
+	if !found {
+		return fmt.Errorf("unexpected greeting of the server: %s", line)
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(payload), &info); err != nil {
+		return fmt.Errorf("error decoding the info of the server: %w", err)
+	}
+
+	secure := c.TLSConfig != nil || info.TLSRequired
+	if secure {
+		var config *tls.Config
+		if c.TLSConfig != nil {
+			config = c.TLSConfig.Clone()
+		} else {
+			config = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if config.ServerName == "" {
+			config.ServerName = host
+		}
+		tlsConn := tls.Client(nc.conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			return fmt.Errorf("error upgrading to tls: %w", err)
+		}
+		nc.conn = tlsConn
+		nc.r = bufio.NewReader(tlsConn)
+	}
+
+	options, err := json.Marshal(connectOptions{
+		TLSRequired: secure,
+		Name:        "keda",
+		Lang:        "go",
+		Version:     "2",
+		Protocol:    1,
+		User:        c.Username,
+		Pass:        c.Password,
+		AuthToken:   c.Token,
+	})
+	if err != nil {
+		return err
+	}
+
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	nc.inbox = "_INBOX." + hex.EncodeToString(id)
+
+	if _, err := fmt.Fprintf(nc.conn, "CONNECT %s\r\nPING\r\nSUB %s.* 1\r\n", options, nc.inbox); err != nil {
+		return err
+	}
+	for {
+		line, err := nc.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return serverError(line)
+		}
+	}
+}
+
+// Close closes the connection
+func (nc *Conn) Close() error {
+	return nc.conn.Close()
+}
+
+// StreamNames returns the names of the streams of the account
+func (nc *Conn) StreamNames() ([]string, error) {
+	return nc.names("$JS.API.STREAM.NAMES", func(r *namesResponse) []string { return r.Streams })
+}
+
+// ConsumerNames returns the names of the consumers of the stream
+func (nc *Conn) ConsumerNames(stream string) ([]string, error) {
+	return nc.names("$JS.API.CONSUMER.NAMES."+stream, func(r *namesResponse) []string { return r.Consumers })
+}
+
+// ConsumerInfo returns the state of the consumer of the stream, reported by the leader of the consumer
+func (nc *Conn) ConsumerInfo(stream, consumer string) (*ConsumerInfo, error) {
+	info := &ConsumerInfo{}
+	if err := nc.request(fmt.Sprintf("$JS.API.CONSUMER.INFO.%s.%s", stream, consumer), nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// StreamInfo returns the state of the stream
+func (nc *Conn) StreamInfo(stream string) (*StreamInfo, error) {
+	info := &StreamInfo{}
+	if err := nc.request("$JS.API.STREAM.INFO."+stream, nil, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+type namesResponse struct {
+	Total     int      `json:"total"`
+	Offset    int      `json:"offset"`
+	Streams   []string `json:"streams"`
+	Consumers []string `json:"consumers"`
+}
+
+// names returns the names of all the pages of the listing
+func (nc *Conn) names(subject string, page func(*namesResponse) []string) ([]string, error) {
+	var names []string
+	for {
+		request, err := json.Marshal(map[string]int{"offset": len(names)})
+		if err != nil {
+			return nil, err
+		}
+		response := &namesResponse{}
+		if err := nc.request(subject, request, response); err != nil {
+			return nil, err
+		}
+		items := page(response)
+		names = append(names, items...)
+		if len(items) == 0 || len(names) >= response.Total {
+			return names, nil
+		}
+	}
+}
+
+// request publishes the request to the subject of the API and decodes its reply into the response
+func (nc *Conn) request(subject string, request []byte, response interface{}) error {
+	if strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid subject %q", subject)
+	}
+	nc.seq++
+	reply := nc.inbox + "." + strconv.Itoa(nc.seq)
+	if _, err := fmt.Fprintf(nc.conn, "PUB %s %s %d\r\n%s\r\n", subject, reply, len(request), request); err != nil {
+		return err
+	}
+
+	for {
+		line, err := nc.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(nc.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return serverError(line)
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			if len(fields) < 4 {
+				return fmt.Errorf("invalid message: %s", line)
+			}
+			length, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || length < 0 || length > maxPayloadLength {
+				return fmt.Errorf("invalid message: %s", line)
+			}
+			payload := make([]byte, length+2)
+			if _, err := io.ReadFull(nc.r, payload); err != nil {
+				return err
+			}
+			// the reply of a previous request that timed out on the side of the server
+			if fields[1] != reply {
+				continue
+			}
+			return decodeResponse(payload[:length], response)
+		}
+	}
+}
+
+// decodeResponse decodes the reply of the API, returning the APIError it holds if any
+func decodeResponse(payload []byte, response interface{}) error {
+	var apiResponse struct {
+		Error *APIError `json:"error"`
+	}
+	if err := json.Unmarshal(payload, &apiResponse); err != nil {
+		return fmt.Errorf("error decoding the jetstream api response: %w", err)
+	}
+	if apiResponse.Error != nil {
+		return apiResponse.Error
+	}
+	return json.Unmarshal(payload, response)
+}
+
+func (nc *Conn) readLine() (string, error) {
+	line, err := nc.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func serverError(line string) error {
+	message := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")
+	return errors.New("nats server error: " + message)
+}
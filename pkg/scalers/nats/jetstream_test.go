@@ -0,0 +1,127 @@
+package nats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testJetStreamServer answers the requests of the JetStream API with the replies by subject
+type testJetStreamServer struct {
+	token   string
+	replies map[string][]string
+}
+
+func startJetStreamServer(t *testing.T, server *testJetStreamServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (s *testJetStreamServer) serve(conn net.Conn) {
+	defer conn.Close()
+	_, _ = io.WriteString(conn, "INFO {\"server_id\":\"test\",\"jetstream\":true}\r\n")
+
+	r := bufio.NewReader(conn)
+	pages := make(map[string]int)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch command {
+		case "CONNECT":
+			var options connectOptions
+			if err := json.Unmarshal([]byte(args), &options); err != nil || options.AuthToken != s.token {
+				_, _ = io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		case "PUB":
+			fields := strings.Fields(args)
+			length, _ := strconv.Atoi(fields[2])
+			if _, err := io.ReadFull(r, make([]byte, length+2)); err != nil {
+				return
+			}
+			// a PING from the server, which must be answered, and a stale reply before the reply
+			_, _ = io.WriteString(conn, "PING\r\nMSG "+fields[1]+".stale 1 2\r\n{}\r\n")
+			replies := s.replies[fields[0]]
+			reply := `{"error":{"code":404,"err_code":10014,"description":"not found"}}`
+			if page := pages[fields[0]]; page < len(replies) {
+				reply = replies[page]
+				pages[fields[0]]++
+			}
+			_, _ = fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", fields[1], len(reply), reply)
+		}
+	}
+}
+
+func TestJetStreamAPI(t *testing.T) {
+	address := startJetStreamServer(t, &testJetStreamServer{
+		token: "secret",
+		replies: map[string][]string{
+			"$JS.API.STREAM.NAMES": {
+				`{"total":3,"offset":0,"limit":2,"streams":["orders","payments"]}`,
+				`{"total":3,"offset":2,"limit":2,"streams":["events"]}`,
+			},
+			"$JS.API.CONSUMER.NAMES.orders":         {`{"total":1,"offset":0,"limit":1024,"consumers":["worker"]}`},
+			"$JS.API.CONSUMER.INFO.orders.worker":   {`{"stream_name":"orders","name":"worker","num_pending":12,"num_ack_pending":3,"num_waiting":2}`},
+			"$JS.API.STREAM.INFO.payments":          {`{"config":{"name":"payments"},"state":{"messages":40,"last_seq":42}}`},
+			"$JS.API.CONSUMER.INFO.payments.worker": {},
+		},
+	})
+
+	conn, err := Connect(context.Background(), &Config{Address: address, Token: "secret", Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	streams, err := conn.StreamNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments", "events"}, streams)
+
+	consumers, err := conn.ConsumerNames("orders")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"worker"}, consumers)
+
+	consumer, err := conn.ConsumerInfo("orders", "worker")
+	require.NoError(t, err)
+	assert.Equal(t, &ConsumerInfo{Stream: "orders", Name: "worker", NumPending: 12, NumAckPending: 3, NumWaiting: 2}, consumer)
+
+	stream, err := conn.StreamInfo("payments")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), stream.State.LastSequence)
+
+	_, err = conn.ConsumerInfo("payments", "worker")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 404, apiErr.Code)
+}
+
+func TestJetStreamConnectUnauthorized(t *testing.T) {
+	address := startJetStreamServer(t, &testJetStreamServer{token: "secret"})
+
+	_, err := Connect(context.Background(), &Config{Address: address, Token: "wrong", Timeout: 5 * time.Second})
+	assert.ErrorContains(t, err, "Authorization Violation")
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/nats"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -25,13 +27,21 @@ const (
 	natsHTTPProtocol                = "http"
 	natsHTTPSProtocol               = "https"
 	jetStreamLagThresholdMetricName = "lagThreshold"
+
+	jetStreamMetricLag           = "lag"
+	jetStreamMetricNumAckPending = "numAckPending"
+	jetStreamMetricNumWaiting    = "numWaiting"
 )
 
+// jetStreamPatternMetacharacters are the characters of a stream pattern that can't be part of a metric name
+var jetStreamPatternMetacharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
 type natsJetStreamScaler struct {
 	metricType v2.MetricTargetType
 	stream     *streamDetail
 	metadata   natsJetStreamMetadata
 	httpClient *http.Client
+	natsConfig *nats.Config
 	logger     logr.Logger
 }
 
@@ -46,6 +56,15 @@ type natsJetStreamMetadata struct {
 	activationLagThreshold int64
 	clusterSize            int
 	triggerIndex           int
+
+	// natsServerEndpoint is the client port of the server, the JetStream API being requested over the client
+	// protocol instead of the monitoring endpoint when it is set
+	natsServerEndpoint string
+	streamPattern      string
+	streamRegexp       *regexp.Regexp
+	consumerRegexp     *regexp.Regexp
+	// metric is the lag of the consumers, or the acknowledgements or pull requests they have pending
+	metric string
 }
 
 type jetStreamEndpointResponse struct {
@@ -124,11 +143,35 @@ func NewNATSJetStreamScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 		return nil, fmt.Errorf("error parsing NATS JetStream metadata: %w", err)
 	}
 
+	var natsConfig *nats.Config
+	if jsMetadata.natsServerEndpoint != "" {
+		natsConfig = &nats.Config{
+			Address:  jsMetadata.natsServerEndpoint,
+			Username: config.AuthParams["username"],
+			Password: config.AuthParams["password"],
+			Token:    config.AuthParams["token"],
+			Timeout:  config.GlobalHTTPTimeout,
+		}
+		if config.AuthParams["tls"] == "enable" {
+			unsafeSsl := false
+			if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
+				if unsafeSsl, err = strconv.ParseBool(val); err != nil {
+					return nil, fmt.Errorf("error parsing unsafeSsl: %w", err)
+				}
+			}
+			natsConfig.TLSConfig, err = kedautil.NewTLSConfig(config.AuthParams["cert"], config.AuthParams["key"], config.AuthParams["ca"], unsafeSsl)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return &natsJetStreamScaler{
 		metricType: metricType,
 		stream:     &streamDetail{},
 		metadata:   jsMetadata,
 		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		natsConfig: natsConfig,
 		logger:     InitializeLogger(config, "nats_jetstream_scaler"),
 	}, nil
 }
@@ -136,21 +179,29 @@ func NewNATSJetStreamScaler(config *scalersconfig.ScalerConfig) (Scaler, error)
 func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStreamMetadata, error) {
 	meta := natsJetStreamMetadata{}
 
+	// the client port is optional, the monitoring endpoint being used otherwise
+	meta.natsServerEndpoint, _ = GetFromAuthOrMeta(config, "natsServerEndpoint")
+
+	// the account of the client protocol is the one of its credentials
 	account, err := GetFromAuthOrMeta(config, "account")
-	if err != nil {
+	if err != nil && meta.natsServerEndpoint == "" {
 		return meta, err
 	}
 	meta.account = account
 
-	if config.TriggerMetadata["stream"] == "" {
-		return meta, errors.New("no stream name given")
+	if err := parseNATSJetStreamTargets(config, &meta); err != nil {
+		return meta, err
 	}
-	meta.stream = config.TriggerMetadata["stream"]
 
-	if config.TriggerMetadata["consumer"] == "" {
-		return meta, errors.New("no consumer name given")
+	meta.metric = jetStreamMetricLag
+	if val, ok := config.TriggerMetadata["metric"]; ok && val != "" {
+		switch val {
+		case jetStreamMetricLag, jetStreamMetricNumAckPending, jetStreamMetricNumWaiting:
+			meta.metric = val
+		default:
+			return meta, fmt.Errorf("metric must be one of %s, %s or %s", jetStreamMetricLag, jetStreamMetricNumAckPending, jetStreamMetricNumWaiting)
+		}
 	}
-	meta.consumer = config.TriggerMetadata["consumer"]
 
 	meta.lagThreshold = defaultJetStreamLagThreshold
 
@@ -174,6 +225,10 @@ func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStre
 
 	meta.triggerIndex = config.TriggerIndex
 
+	if meta.natsServerEndpoint != "" {
+		return meta, nil
+	}
+
 	natsServerEndpoint, err := GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
 	if err != nil {
 		return meta, err
@@ -190,6 +245,52 @@ func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStre
 	return meta, nil
 }
 
+// parseNATSJetStreamTargets parses the stream and consumer, or the patterns of the streams and consumers whose
+// values are aggregated, which are only supported over the client protocol as the monitoring endpoint of a node
+// only reports the consumers it is the leader of
+func parseNATSJetStreamTargets(config *scalersconfig.ScalerConfig, meta *natsJetStreamMetadata) error {
+	meta.stream = config.TriggerMetadata["stream"]
+	meta.streamPattern = config.TriggerMetadata["streamPattern"]
+	meta.consumer = config.TriggerMetadata["consumer"]
+	consumerPattern := config.TriggerMetadata["consumerPattern"]
+
+	if meta.stream == "" && meta.streamPattern == "" {
+		return errors.New("no stream name given")
+	}
+	if meta.stream != "" && meta.streamPattern != "" {
+		return errors.New("stream and streamPattern can't be set together")
+	}
+	if meta.consumer == "" && consumerPattern == "" {
+		return errors.New("no consumer name given")
+	}
+	if meta.consumer != "" && consumerPattern != "" {
+		return errors.New("consumer and consumerPattern can't be set together")
+	}
+	if strings.ContainsAny(meta.stream+meta.consumer, " \t\r\n.*>") {
+		return errors.New("stream and consumer must be valid names")
+	}
+
+	if meta.streamPattern == "" && consumerPattern == "" {
+		return nil
+	}
+	if meta.natsServerEndpoint == "" {
+		return errors.New("streamPattern and consumerPattern require natsServerEndpoint")
+	}
+
+	var err error
+	if meta.streamPattern != "" {
+		if meta.streamRegexp, err = regexp.Compile("^(?:" + meta.streamPattern + ")$"); err != nil {
+			return fmt.Errorf("error parsing streamPattern: %w", err)
+		}
+	}
+	if consumerPattern != "" {
+		if meta.consumerRegexp, err = regexp.Compile("^(?:" + consumerPattern + ")$"); err != nil {
+			return fmt.Errorf("error parsing consumerPattern: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *natsJetStreamScaler) getNATSJetstreamMonitoringData(ctx context.Context, natsJetStreamMonitoringURL string) error {
 	// save the leader URL, then we can check if it has changed
 	cachedConsumerLeader := s.metadata.consumerLeader
@@ -431,14 +532,101 @@ func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
 
 	for _, consumer := range s.stream.Consumers {
 		if consumer.Name == consumerName {
-			return int64(consumer.NumPending + consumer.NumAckPending)
+			return s.getConsumerValue(int64(consumer.NumPending), int64(consumer.NumAckPending), int64(consumer.NumWaiting))
 		}
 	}
-	return s.stream.State.LastSequence
+	// the consumer isn't created yet, all the messages of the stream are pending
+	if s.metadata.metric == jetStreamMetricLag {
+		return s.stream.State.LastSequence
+	}
+	return 0
+}
+
+// getConsumerValue returns the value of the metric for a consumer
+func (s *natsJetStreamScaler) getConsumerValue(numPending, numAckPending, numWaiting int64) int64 {
+	switch s.metadata.metric {
+	case jetStreamMetricNumAckPending:
+		return numAckPending
+	case jetStreamMetricNumWaiting:
+		return numWaiting
+	default:
+		return numPending + numAckPending
+	}
+}
+
+// getJetStreamAPIValue returns the value of the metric summed over the consumers, requested from the JetStream API
+// over the client protocol which answers from the leader of each consumer
+func (s *natsJetStreamScaler) getJetStreamAPIValue(ctx context.Context) (int64, error) {
+	conn, err := nats.Connect(ctx, s.natsConfig)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to NATS: %w", err)
+	}
+	defer conn.Close()
+
+	streams := []string{s.metadata.stream}
+	if s.metadata.streamRegexp != nil {
+		names, err := conn.StreamNames()
+		if err != nil {
+			return 0, fmt.Errorf("error listing the streams: %w", err)
+		}
+		streams = filterJetStreamNames(names, s.metadata.streamRegexp)
+	}
+
+	var value int64
+	for _, stream := range streams {
+		consumers := []string{s.metadata.consumer}
+		if s.metadata.consumerRegexp != nil {
+			names, err := conn.ConsumerNames(stream)
+			if err != nil {
+				return 0, fmt.Errorf("error listing the consumers of stream %s: %w", stream, err)
+			}
+			consumers = filterJetStreamNames(names, s.metadata.consumerRegexp)
+		}
+
+		for _, consumer := range consumers {
+			info, err := conn.ConsumerInfo(stream, consumer)
+			var apiErr *nats.APIError
+			if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound && s.metadata.consumerRegexp == nil {
+				// the consumer isn't created yet, all the messages of the stream are pending
+				if s.metadata.metric != jetStreamMetricLag {
+					continue
+				}
+				streamInfo, err := conn.StreamInfo(stream)
+				if err != nil {
+					return 0, fmt.Errorf("error getting stream %s: %w", stream, err)
+				}
+				value += streamInfo.State.LastSequence
+				continue
+			}
+			if err != nil {
+				return 0, fmt.Errorf("error getting consumer %s of stream %s: %w", consumer, stream, err)
+			}
+			value += s.getConsumerValue(info.NumPending, info.NumAckPending, info.NumWaiting)
+		}
+	}
+	return value, nil
+}
+
+func filterJetStreamNames(names []string, pattern *regexp.Regexp) []string {
+	var matches []string
+	for _, name := range names {
+		if pattern.MatchString(name) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
 }
 
 func (s *natsJetStreamScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("nats-jetstream-%s", s.metadata.stream))
+	stream := s.metadata.stream
+	if s.metadata.streamPattern != "" {
+		stream = strings.Trim(jetStreamPatternMetacharacters.ReplaceAllString(s.metadata.streamPattern, "-"), "-")
+	}
+	metricName := fmt.Sprintf("nats-jetstream-%s", stream)
+	if s.metadata.metric != jetStreamMetricLag {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.metric)
+	}
+	metricName = kedautil.NormalizeString(metricName)
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
@@ -453,16 +641,25 @@ func (s *natsJetStreamScaler) GetMetricSpecForScaling(context.Context) []v2.Metr
 }
 
 func (s *natsJetStreamScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	err := s.getNATSJetstreamMonitoringData(ctx, s.metadata.monitoringURL)
-	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, false, err
-	}
+	var totalLag int64
+	if s.metadata.natsServerEndpoint != "" {
+		value, err := s.getJetStreamAPIValue(ctx)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		totalLag = value
+	} else {
+		err := s.getNATSJetstreamMonitoringData(ctx, s.metadata.monitoringURL)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
 
-	if s.stream == nil {
-		return []external_metrics.ExternalMetricValue{}, false, errors.New("stream not found")
-	}
+		if s.stream == nil {
+			return []external_metrics.ExternalMetricValue{}, false, errors.New("stream not found")
+		}
 
-	totalLag := s.getMaxMsgLag()
+		totalLag = s.getMaxMsgLag()
+	}
 	s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
 
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
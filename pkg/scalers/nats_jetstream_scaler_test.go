@@ -69,11 +69,26 @@ var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"account": "$G", "natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
 	// Misconfigured account
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true},
+	// All good client protocol, without account nor monitoring endpoint
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, false},
+	// All good stream and consumer patterns + numWaiting
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "streamPattern": "orders-.*", "consumerPattern": "worker-[0-9]+", "metric": "numWaiting"}, map[string]string{}, false},
+	// Patterns over the monitoring endpoint, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "streamPattern": "orders-.*", "consumer": "pull_consumer"}, map[string]string{}, true},
+	// Stream and streamPattern together, should fail
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "stream": "mystream", "streamPattern": "orders-.*", "consumer": "pull_consumer"}, map[string]string{}, true},
+	// Invalid consumerPattern, should fail
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "stream": "mystream", "consumerPattern": "worker-("}, map[string]string{}, true},
+	// Unknown metric, should fail
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "stream": "mystream", "consumer": "pull_consumer", "metric": "numRedelivered"}, map[string]string{}, true},
+	// Consumer name with a subject token, should fail
+	{map[string]string{"natsServerEndpoint": "nats.nats:4222", "stream": "mystream", "consumer": "pull.*"}, map[string]string{}, true},
 }
 
 var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
 	{&testNATSJetStreamMetadata[0], 0, "s0-nats-jetstream-mystream"},
 	{&testNATSJetStreamMetadata[0], 1, "s1-nats-jetstream-mystream"},
+	{&testNATSJetStreamMetadata[17], 2, "s2-nats-jetstream-orders-numWaiting"},
 }
 
 func TestNATSJetStreamParseMetadata(t *testing.T) {
@@ -453,3 +468,31 @@ func TestNATSJetStreamClose(t *testing.T) {
 		t.Error("Expected success for NATS JetStream Scaler Close but got error", err)
 	}
 }
+
+func TestNATSJetStreamGetMaxMsgLagByMetric(t *testing.T) {
+	stream := &streamDetail{
+		Name:      "mystream",
+		State:     streamState{LastSequence: 100},
+		Consumers: []consumerDetail{{Name: "pull_consumer", NumPending: 12, NumAckPending: 3, NumWaiting: 2}},
+	}
+
+	testCases := []struct {
+		metric   string
+		consumer string
+		value    int64
+	}{
+		{jetStreamMetricLag, "pull_consumer", 15},
+		{jetStreamMetricNumAckPending, "pull_consumer", 3},
+		{jetStreamMetricNumWaiting, "pull_consumer", 2},
+		{jetStreamMetricLag, "missing_consumer", 100},
+		{jetStreamMetricNumWaiting, "missing_consumer", 0},
+	}
+
+	for _, tc := range testCases {
+		scaler := natsJetStreamScaler{
+			stream:   stream,
+			metadata: natsJetStreamMetadata{consumer: tc.consumer, metric: tc.metric},
+		}
+		assert.Equal(t, tc.value, scaler.getMaxMsgLag(), tc)
+	}
+}
@@ -2,7 +2,12 @@ package scalers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/newrelic/newrelic-client-go/v2/newrelic"
@@ -16,24 +21,47 @@ import (
 
 const (
 	scalerName = "new-relic"
+
+	newrelicFacetAggregationMax = "max"
+	newrelicFacetAggregationMin = "min"
+	newrelicFacetAggregationSum = "sum"
+
+	// newrelicBatchWindow is how long the first query of a batch waits for the queries of other triggers
+	newrelicBatchWindow = 100 * time.Millisecond
+	// newrelicMaxBatchSize bounds the number of NRQL queries sent in a single NerdGraph request
+	newrelicMaxBatchSize = 20
 )
 
+// newrelicNerdGraphClient sends raw GraphQL requests to NerdGraph
+type newrelicNerdGraphClient interface {
+	QueryWithResponseAndContext(ctx context.Context, query string, variables map[string]interface{}, respBody interface{}) error
+}
+
 type newrelicScaler struct {
-	metricType v2.MetricTargetType
-	metadata   newrelicMetadata
-	nrClient   *newrelic.NewRelic
-	logger     logr.Logger
+	metricType  v2.MetricTargetType
+	metadata    newrelicMetadata
+	nrClient    *newrelic.NewRelic
+	queryClient newrelicNerdGraphClient
+	batcher     *newrelicQueryBatcher
+	logger      logr.Logger
 }
 
 type newrelicMetadata struct {
-	Account             int     `keda:"name=account,             order=authParams;triggerMetadata"`
+	Account int `keda:"name=account,             order=authParams;triggerMetadata, optional"`
+	// Accounts are the accounts the NRQL query runs across, in addition to the account
+	Accounts            []int   `keda:"name=accounts,            order=authParams;triggerMetadata, optional"`
 	Region              string  `keda:"name=region,              order=authParams;triggerMetadata, default=US"`
 	QueryKey            string  `keda:"name=queryKey,            order=authParams;triggerMetadata"`
 	NoDataError         bool    `keda:"name=noDataError,         order=triggerMetadata, default=false"`
 	NRQL                string  `keda:"name=nrql,                order=triggerMetadata"`
 	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
 	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
-	TriggerIndex        int
+	// FacetAggregation combines the values of all the rows of a FACET query, only the first row being used otherwise
+	FacetAggregation string `keda:"name=facetAggregation,    order=triggerMetadata, enum=max;min;sum, optional"`
+	// BatchQueries sends the query along with the queries of the other triggers using the same query key in a
+	// single NerdGraph request
+	BatchQueries bool `keda:"name=batchQueries,        order=triggerMetadata, default=false"`
+	TriggerIndex int
 }
 
 func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
@@ -56,14 +84,19 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error initializing client: %w", err)
 	}
 
-	logger.Info(fmt.Sprintf("Initializing New Relic Scaler (account %d in region %s)", meta.Account, meta.Region))
+	logger.Info(fmt.Sprintf("Initializing New Relic Scaler (accounts %v in region %s)", meta.Accounts, meta.Region))
 
-	return &newrelicScaler{
-		metricType: metricType,
-		metadata:   meta,
-		nrClient:   nrClient,
-		logger:     logger,
-	}, nil
+	scaler := &newrelicScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		nrClient:    nrClient,
+		queryClient: &nrClient.NerdGraph,
+		logger:      logger,
+	}
+	if meta.BatchQueries {
+		scaler.batcher = acquireNewRelicQueryBatcher(meta.Region+"/"+meta.QueryKey, scaler.queryClient, config.GlobalHTTPTimeout)
+	}
+	return scaler, nil
 }
 
 func parseNewRelicMetadata(config *scalersconfig.ScalerConfig) (newrelicMetadata, error) {
@@ -73,6 +106,17 @@ func parseNewRelicMetadata(config *scalersconfig.ScalerConfig) (newrelicMetadata
 		return meta, fmt.Errorf("error parsing newrelic metadata: %w", err)
 	}
 
+	_, hasAccount := config.TriggerMetadata["account"]
+	if _, ok := config.AuthParams["account"]; ok {
+		hasAccount = true
+	}
+	if hasAccount && !slices.Contains(meta.Accounts, meta.Account) {
+		meta.Accounts = append([]int{meta.Account}, meta.Accounts...)
+	}
+	if len(meta.Accounts) == 0 {
+		return meta, errors.New("error parsing newrelic metadata: no account given, account or accounts is required")
+	}
+
 	if config.AsMetricSource {
 		meta.Threshold = 0
 	}
@@ -82,33 +126,234 @@ func parseNewRelicMetadata(config *scalersconfig.ScalerConfig) (newrelicMetadata
 }
 
 func (s *newrelicScaler) Close(context.Context) error {
+	if s.batcher != nil {
+		releaseNewRelicQueryBatcher(s.batcher)
+		s.batcher = nil
+	}
 	return nil
 }
 
 func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, error) {
-	nrdbQuery := nrdb.NRQL(s.metadata.NRQL)
-	resp, err := s.nrClient.Nrdb.QueryWithContext(ctx, s.metadata.Account, nrdbQuery)
-	if err != nil {
-		return 0, fmt.Errorf("error running NRQL %s: %w", s.metadata.NRQL, err)
+	var result newrelicQueryResult
+	if s.batcher != nil {
+		result = s.batcher.query(ctx, s.metadata.Accounts, s.metadata.NRQL)
+	} else {
+		result = runNewRelicQueries(ctx, s.queryClient, []newrelicQuery{{accounts: s.metadata.Accounts, nrql: s.metadata.NRQL}})[0]
+	}
+	if result.err != nil {
+		return 0, fmt.Errorf("error running NRQL %s: %w", s.metadata.NRQL, result.err)
 	}
 
-	if len(resp.Results) == 0 {
+	if len(result.Results) == 0 {
 		if s.metadata.NoDataError {
 			return 0, fmt.Errorf("query returned no results: %s", s.metadata.NRQL)
 		}
 		return 0, nil
 	}
-	// Only use the first result from the query, as the query should not be multi row
-	for _, v := range resp.Results[0] {
+
+	val, ok := aggregateNewRelicResults(result.Results, result.Metadata.Facets, s.metadata.FacetAggregation)
+	if !ok && s.metadata.NoDataError {
+		return 0, fmt.Errorf("query returned no numeric results: %s", s.metadata.NRQL)
+	}
+	return val, nil
+}
+
+// aggregateNewRelicResults returns the value of the first row of the results, as the query should not be multi
+// row, or combines the values of all the rows, one by facet, with the aggregation
+func aggregateNewRelicResults(results []nrdb.NRDBResult, facets []string, aggregation string) (float64, bool) {
+	if aggregation == "" {
+		return newrelicResultValue(results[0], facets)
+	}
+
+	var aggregated float64
+	found := false
+	for _, result := range results {
+		val, ok := newrelicResultValue(result, facets)
+		switch {
+		case !ok:
+			continue
+		case !found:
+			aggregated = val
+		case aggregation == newrelicFacetAggregationMax:
+			aggregated = max(aggregated, val)
+		case aggregation == newrelicFacetAggregationMin:
+			aggregated = min(aggregated, val)
+		case aggregation == newrelicFacetAggregationSum:
+			aggregated += val
+		}
+		found = true
+	}
+	return aggregated, found
+}
+
+// newrelicResultValue returns the numeric value of a row, skipping the values of the facets which may be numeric
+func newrelicResultValue(result nrdb.NRDBResult, facets []string) (float64, bool) {
+	for k, v := range result {
+		if k == "facet" || slices.Contains(facets, k) {
+			continue
+		}
 		if val, ok := v.(float64); ok {
-			return val, nil
+			return val, true
 		}
 	}
+	return 0, false
+}
 
-	if s.metadata.NoDataError {
-		return 0, fmt.Errorf("query returned no numeric results: %s", s.metadata.NRQL)
+type newrelicQuery struct {
+	accounts []int
+	nrql     string
+}
+
+type newrelicQueryResult struct {
+	nrdb.NRDBResultContainer
+	err error
+}
+
+// runNewRelicQueries runs the NRQL queries across their accounts in a single NerdGraph request, each query being
+// an aliased field of the request
+func runNewRelicQueries(ctx context.Context, client newrelicNerdGraphClient, queries []newrelicQuery) []newrelicQueryResult {
+	var params, fields strings.Builder
+	variables := make(map[string]interface{}, 2*len(queries))
+	for i, q := range queries {
+		if i > 0 {
+			params.WriteString(", ")
+		}
+		fmt.Fprintf(&params, "$accounts%d: [Int!]!, $query%d: Nrql!", i, i)
+		fmt.Fprintf(&fields, " q%d: nrql(accounts: $accounts%d, query: $query%d) { results metadata { facets } }", i, i, i)
+		variables[fmt.Sprintf("accounts%d", i)] = q.accounts
+		variables[fmt.Sprintf("query%d", i)] = nrdb.NRQL(q.nrql)
+	}
+	request := fmt.Sprintf("query (%s) { actor {%s } }", params.String(), fields.String())
+
+	var response struct {
+		Actor map[string]nrdb.NRDBResultContainer `json:"actor"`
+	}
+	err := client.QueryWithResponseAndContext(ctx, request, variables, &response)
+
+	results := make([]newrelicQueryResult, len(queries))
+	for i := range queries {
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		container, ok := response.Actor[fmt.Sprintf("q%d", i)]
+		if !ok {
+			results[i].err = errors.New("no result in the nerdgraph response")
+			continue
+		}
+		results[i].NRDBResultContainer = container
+	}
+	return results
+}
+
+// newrelicQueryBatcher coalesces the queries of the triggers using the same query key into single NerdGraph
+// requests, to stay under the rate limits of NerdGraph with many ScaledObjects
+type newrelicQueryBatcher struct {
+	key     string
+	client  newrelicNerdGraphClient
+	timeout time.Duration
+	refs    int
+
+	mu      sync.Mutex
+	pending []*newrelicBatchedQuery
+}
+
+type newrelicBatchedQuery struct {
+	newrelicQuery
+	result newrelicQueryResult
+	done   chan struct{}
+}
+
+var (
+	newrelicQueryBatchersLock sync.Mutex
+	newrelicQueryBatchers     = map[string]*newrelicQueryBatcher{}
+)
+
+// acquireNewRelicQueryBatcher returns the batcher of the key, shared by all the scalers using the key
+func acquireNewRelicQueryBatcher(key string, client newrelicNerdGraphClient, timeout time.Duration) *newrelicQueryBatcher {
+	newrelicQueryBatchersLock.Lock()
+	defer newrelicQueryBatchersLock.Unlock()
+
+	batcher, ok := newrelicQueryBatchers[key]
+	if !ok {
+		batcher = &newrelicQueryBatcher{key: key, client: client, timeout: timeout}
+		newrelicQueryBatchers[key] = batcher
+	}
+	batcher.refs++
+	return batcher
+}
+
+// releaseNewRelicQueryBatcher forgets the batcher once no scaler uses it anymore
+func releaseNewRelicQueryBatcher(batcher *newrelicQueryBatcher) {
+	newrelicQueryBatchersLock.Lock()
+	defer newrelicQueryBatchersLock.Unlock()
+
+	batcher.refs--
+	if batcher.refs <= 0 && newrelicQueryBatchers[batcher.key] == batcher {
+		delete(newrelicQueryBatchers, batcher.key)
+	}
+}
+
+// query queues the query in the current batch, which is sent once the batch window is over or the batch is full
+func (b *newrelicQueryBatcher) query(ctx context.Context, accounts []int, nrql string) newrelicQueryResult {
+	q := &newrelicBatchedQuery{newrelicQuery: newrelicQuery{accounts: accounts, nrql: nrql}, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, q)
+	switch len(b.pending) {
+	case newrelicMaxBatchSize:
+		batch := b.pending
+		b.pending = nil
+		go b.execute(batch)
+	case 1:
+		time.AfterFunc(newrelicBatchWindow, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return q.result
+	case <-ctx.Done():
+		return newrelicQueryResult{err: ctx.Err()}
+	}
+}
+
+func (b *newrelicQueryBatcher) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(batch) > 0 {
+		b.execute(batch)
+	}
+}
+
+// execute sends the batch, and runs each query on its own when the batch fails so that an invalid query doesn't
+// fail the queries of the other triggers
+func (b *newrelicQueryBatcher) execute(batch []*newrelicBatchedQuery) {
+	ctx := context.Background()
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	queries := make([]newrelicQuery, len(batch))
+	for i, q := range batch {
+		queries[i] = q.newrelicQuery
+	}
+	results := runNewRelicQueries(ctx, b.client, queries)
+	if len(batch) > 1 && results[0].err != nil {
+		for i, q := range queries {
+			results[i] = runNewRelicQueries(ctx, b.client, []newrelicQuery{q})[0]
+		}
+	}
+
+	for i, q := range batch {
+		q.result = results[i]
+		close(q.done)
 	}
-	return 0, nil
 }
 
 func (s *newrelicScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
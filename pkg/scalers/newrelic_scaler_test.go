@@ -2,10 +2,16 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/newrelic/newrelic-client-go/v2/pkg/nrdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v2 "k8s.io/api/autoscaling/v2"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -54,6 +60,16 @@ var testNewRelicMetadata = []parseNewRelicMetadataTestData{
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "false", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "0", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "1", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// accounts without account
+	{map[string]string{"accounts": "1,2", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample"}, map[string]string{}, false},
+	// account and accounts
+	{map[string]string{"account": "1", "accounts": "2,3", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample"}, map[string]string{}, false},
+	// malformed accounts
+	{map[string]string{"accounts": "1,abc", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample"}, map[string]string{}, true},
+	// facet aggregation and batching
+	{map[string]string{"account": "1", "threshold": "100", "queryKey": "somekey", "facetAggregation": "sum", "batchQueries": "true", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET podName"}, map[string]string{}, false},
+	// invalid facet aggregation
+	{map[string]string{"account": "1", "threshold": "100", "queryKey": "somekey", "facetAggregation": "avg", "nrql": "SELECT average(cpuUsedCores) FROM K8sContainerSample FACET podName"}, map[string]string{}, true},
 }
 
 var newrelicMetricIdentifiers = []newrelicMetricIdentifier{
@@ -104,3 +120,114 @@ func TestNewRelicGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRelicParseAccounts(t *testing.T) {
+	meta, err := parseNewRelicMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"account": "1", "accounts": "2,3", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT count(*) FROM Transaction"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, meta.Accounts)
+
+	meta, err = parseNewRelicMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"threshold": "100", "queryKey": "somekey", "nrql": "SELECT count(*) FROM Transaction"},
+		AuthParams:      map[string]string{"account": "0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, meta.Accounts)
+}
+
+func TestNewRelicAggregateResults(t *testing.T) {
+	results := []nrdb.NRDBResult{
+		{"facet": "pod-a", "podName": "pod-a", "average.cpuUsedCores": 2.0},
+		{"facet": 500.0, "http.statusCode": 500.0, "count": 4.0},
+		{"facet": "pod-c", "podName": "pod-c", "average.cpuUsedCores": 1.0},
+	}
+	facets := []string{"podName", "http.statusCode"}
+
+	tests := map[string]float64{"": 2, "max": 4, "min": 1, "sum": 7}
+	for aggregation, expected := range tests {
+		val, ok := aggregateNewRelicResults(results, facets, aggregation)
+		assert.True(t, ok, aggregation)
+		assert.Equal(t, expected, val, aggregation)
+	}
+
+	_, ok := aggregateNewRelicResults([]nrdb.NRDBResult{{"facet": "pod-a", "podName": "pod-a"}}, facets, "sum")
+	assert.False(t, ok)
+}
+
+// mockNerdGraphClient answers each aliased nrql field with the value of its query, failing the requests containing
+// an invalid query
+type mockNerdGraphClient struct {
+	mu       sync.Mutex
+	requests int
+	values   map[string]float64
+}
+
+func (c *mockNerdGraphClient) QueryWithResponseAndContext(_ context.Context, query string, variables map[string]interface{}, respBody interface{}) error {
+	c.mu.Lock()
+	c.requests++
+	c.mu.Unlock()
+
+	actor := map[string]interface{}{}
+	for i := 0; strings.Contains(query, fmt.Sprintf("q%d:", i)); i++ {
+		nrql := string(variables[fmt.Sprintf("query%d", i)].(nrdb.NRQL))
+		value, ok := c.values[nrql]
+		if !ok {
+			return fmt.Errorf("invalid query %s", nrql)
+		}
+		accounts := variables[fmt.Sprintf("accounts%d", i)].([]int)
+		actor[fmt.Sprintf("q%d", i)] = map[string]interface{}{
+			"results": []map[string]interface{}{{"value": value * float64(len(accounts))}},
+		}
+	}
+	body, err := json.Marshal(map[string]interface{}{"actor": actor})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, respBody)
+}
+
+func TestNewRelicBatchQueries(t *testing.T) {
+	client := &mockNerdGraphClient{values: map[string]float64{"SELECT 1": 1, "SELECT 2": 2}}
+	batcher := acquireNewRelicQueryBatcher(t.Name(), client, 0)
+	defer releaseNewRelicQueryBatcher(batcher)
+
+	queries := []newrelicQuery{
+		{accounts: []int{1}, nrql: "SELECT 1"},
+		{accounts: []int{1, 2}, nrql: "SELECT 2"},
+		{accounts: []int{1}, nrql: "SELECT 3"},
+	}
+	results := make([]newrelicQueryResult, len(queries))
+	var wg sync.WaitGroup
+	for i, q := range queries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = batcher.query(context.Background(), q.accounts, q.nrql)
+		}()
+	}
+	wg.Wait()
+
+	// the batch fails on the invalid query, each query then being sent on its own
+	assert.Equal(t, 4, client.requests)
+	require.NoError(t, results[0].err)
+	assert.Equal(t, 1.0, results[0].Results[0]["value"])
+	require.NoError(t, results[1].err)
+	assert.Equal(t, 4.0, results[1].Results[0]["value"])
+	assert.ErrorContains(t, results[2].err, "invalid query")
+
+	client.requests = 0
+	for i := range results {
+		results[i] = newrelicQueryResult{}
+	}
+	for i, q := range queries[:2] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = batcher.query(context.Background(), q.accounts, q.nrql)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, client.requests)
+	require.NoError(t, results[1].err)
+}
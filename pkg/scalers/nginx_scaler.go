@@ -0,0 +1,241 @@
+package scalers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	nginxAPIStubStatus = "stubStatus"
+	nginxAPIPlus       = "plus"
+
+	nginxMetricActiveConnections = "activeConnections"
+	nginxMetricPendingRequests   = "pendingRequests"
+)
+
+// nginxStubStatusMetrics are the metrics of stub_status, which only reports the connections of the whole server
+var nginxStubStatusMetrics = map[string]string{
+	nginxMetricActiveConnections: "Active connections",
+	"reading":                    "Reading",
+	"writing":                    "Writing",
+	"waiting":                    "Waiting",
+}
+
+type nginxScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *nginxMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type nginxMetadata struct {
+	triggerIndex int
+
+	// URL is the location of stub_status, or the versioned root of the NGINX Plus API, e.g. http://nginx:8080/api/9
+	URL string `keda:"name=url, order=triggerMetadata;resolvedEnv"`
+	API string `keda:"name=api, order=triggerMetadata, enum=stubStatus;plus, optional, default=stubStatus"`
+	// Upstream is the name of the upstream group, only reported by NGINX Plus
+	Upstream              string  `keda:"name=upstream,              order=triggerMetadata, optional"`
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=activeConnections;pendingRequests;reading;writing;waiting, optional, default=activeConnections"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=100"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+}
+
+// nginxPlusUpstream is the state of an upstream group, the queue being only set when queueing is enabled
+type nginxPlusUpstream struct {
+	Peers []struct {
+		Active float64 `json:"active"`
+	} `json:"peers"`
+	Queue *struct {
+		Size float64 `json:"size"`
+	} `json:"queue"`
+}
+
+func (m *nginxMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	switch m.API {
+	case nginxAPIStubStatus:
+		if _, ok := nginxStubStatusMetrics[m.Metric]; !ok {
+			return fmt.Errorf("metric %s isn't reported by stub_status", m.Metric)
+		}
+		if m.Upstream != "" {
+			return errors.New("upstream requires the plus api, stub_status only reports the whole server")
+		}
+	case nginxAPIPlus:
+		if m.Upstream == "" {
+			return errors.New("upstream must be provided with the plus api")
+		}
+		if m.Metric != nginxMetricActiveConnections && m.Metric != nginxMetricPendingRequests {
+			return fmt.Errorf("metric must be %s or %s with the plus api", nginxMetricActiveConnections, nginxMetricPendingRequests)
+		}
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	return nil
+}
+
+// NewNginxScaler creates a new nginxScaler
+func NewNginxScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseNginxMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nginx metadata: %w", err)
+	}
+
+	return &nginxScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "nginx_scaler"),
+	}, nil
+}
+
+func parseNginxMetadata(config *scalersconfig.ScalerConfig) (*nginxMetadata, error) {
+	meta := &nginxMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *nginxScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *nginxScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := fmt.Sprintf("nginx-%s", s.metadata.Metric)
+	if s.metadata.Upstream != "" {
+		metricName = fmt.Sprintf("nginx-%s-%s", s.metadata.Upstream, s.metadata.Metric)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the connections of the server, or the active connections or queued requests of the upstream
+func (s *nginxScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var value float64
+	var err error
+	if s.metadata.API == nginxAPIPlus {
+		value, err = s.getUpstreamMetric(ctx)
+	} else {
+		value, err = s.getStubStatusMetric(ctx)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting nginx %s: %w", s.metadata.Metric, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getStubStatusMetric parses the metric out of the page of stub_status:
+//
+//	Active connections: 291
+//	server accepts handled requests
+//	 16630948 16630948 31070465
+//	Reading: 6 Writing: 179 Waiting: 106
+func (s *nginxScaler) getStubStatusMetric(ctx context.Context) (float64, error) {
+	body, err := s.get(ctx, s.metadata.URL)
+	if err != nil {
+		return 0, err
+	}
+
+	label := nginxStubStatusMetrics[s.metadata.Metric] + ":"
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for i := 0; i < len(fields)-1; i++ {
+			if fields[i] == label || (i > 0 && fields[i-1]+" "+fields[i] == label) {
+				return strconv.ParseFloat(fields[i+1], 64)
+			}
+		}
+	}
+	return 0, fmt.Errorf("%q not found in the response of stub_status", strings.TrimSuffix(label, ":"))
+}
+
+// getUpstreamMetric sums up the active connections of the peers of the upstream, or returns the size of its queue
+func (s *nginxScaler) getUpstreamMetric(ctx context.Context) (float64, error) {
+	body, err := s.get(ctx, s.metadata.URL+"/http/upstreams/"+url.PathEscape(s.metadata.Upstream))
+	if err != nil {
+		return 0, err
+	}
+
+	var upstream nginxPlusUpstream
+	if err := json.Unmarshal(body, &upstream); err != nil {
+		return 0, fmt.Errorf("error parsing upstream: %w", err)
+	}
+	if s.metadata.Metric == nginxMetricPendingRequests {
+		if upstream.Queue == nil {
+			return 0, errors.New("queueing isn't enabled on the upstream")
+		}
+		return upstream.Queue.Size, nil
+	}
+	var active float64
+	for _, peer := range upstream.Peers {
+		active += peer.Active
+	}
+	return active, nil
+}
+
+func (s *nginxScaler) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
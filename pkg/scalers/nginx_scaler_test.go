@@ -0,0 +1,123 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseNginxMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type nginxMetricIdentifier struct {
+	metadataTestData *parseNginxMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testNginxMetadata = []parseNginxMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://nginx:8080/stub_status"}, map[string]string{}, false, "stub_status"},
+	{map[string]string{"url": "http://nginx:8080/api/9", "api": "plus", "upstream": "backend", "metric": "pendingRequests", "targetValue": "10"}, map[string]string{"username": "keda", "password": "secret"}, false, "plus api"},
+	{map[string]string{"url": "http://nginx:8080/stub_status", "metric": "waiting"}, map[string]string{}, false, "stub_status waiting"},
+	{map[string]string{"url": "http://nginx:8080/stub_status", "metric": "pendingRequests"}, map[string]string{}, true, "pending requests with stub_status"},
+	{map[string]string{"url": "http://nginx:8080/stub_status", "upstream": "backend"}, map[string]string{}, true, "upstream with stub_status"},
+	{map[string]string{"url": "http://nginx:8080/api/9", "api": "plus"}, map[string]string{}, true, "plus api without upstream"},
+	{map[string]string{"url": "http://nginx:8080/api/9", "api": "plus", "upstream": "backend", "metric": "reading"}, map[string]string{}, true, "reading with plus api"},
+	{map[string]string{"url": "http://nginx:8080/stub_status", "api": "vts"}, map[string]string{}, true, "unknown api"},
+	{map[string]string{"url": "http://nginx:8080/stub_status", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"url": "http://nginx:8080/stub_status"}, map[string]string{"username": "keda"}, true, "username without password"},
+}
+
+var nginxMetricIdentifiers = []nginxMetricIdentifier{
+	{&testNginxMetadata[1], 0, "s0-nginx-activeConnections"},
+	{&testNginxMetadata[2], 1, "s1-nginx-backend-pendingRequests"},
+}
+
+func TestNginxParseMetadata(t *testing.T) {
+	for _, testData := range testNginxMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseNginxMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestNginxGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range nginxMetricIdentifiers {
+		meta, err := parseNginxMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockNginxScaler := nginxScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockNginxScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestNginxGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stub_status":
+			_, _ = w.Write([]byte("Active connections: 291 \nserver accepts handled requests\n 16630948 16630948 31070465 \nReading: 6 Writing: 179 Waiting: 106 \n"))
+		case "/api/9/http/upstreams/backend":
+			user, password, _ := r.BasicAuth()
+			assert.Equal(t, "keda:secret", user+":"+password)
+			_, _ = w.Write([]byte(`{"peers":[{"id":0,"server":"10.0.0.1:80","active":3},{"id":1,"server":"10.0.0.2:80","active":4}],"queue":{"size":5,"max_size":100,"overflows":0},"zombies":0}`))
+		case "/api/9/http/upstreams/noqueue":
+			_, _ = w.Write([]byte(`{"peers":[{"id":0,"server":"10.0.0.1:80","active":0}],"zombies":0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"status":404,"text":"upstream not found","code":"UpstreamNotFound"}}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		metadata map[string]string
+		value    int64
+		isActive bool
+		err      string
+	}{
+		{map[string]string{"url": server.URL + "/stub_status"}, 291, true, ""},
+		{map[string]string{"url": server.URL + "/stub_status", "metric": "writing", "activationTargetValue": "200"}, 179, false, ""},
+		{map[string]string{"url": server.URL + "/stub_status", "metric": "waiting"}, 106, true, ""},
+		{map[string]string{"url": server.URL + "/api/9", "api": "plus", "upstream": "backend"}, 7, true, ""},
+		{map[string]string{"url": server.URL + "/api/9", "api": "plus", "upstream": "backend", "metric": "pendingRequests"}, 5, true, ""},
+		{map[string]string{"url": server.URL + "/api/9", "api": "plus", "upstream": "noqueue", "metric": "pendingRequests"}, 0, false, "queueing isn't enabled"},
+		{map[string]string{"url": server.URL + "/api/9", "api": "plus", "upstream": "missing"}, 0, false, "404"},
+	}
+	for _, test := range tests {
+		s, err := NewNginxScaler(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, AuthParams: map[string]string{"username": "keda", "password": "secret"}})
+		require.NoError(t, err)
+
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "nginx")
+		if test.err != "" {
+			assert.ErrorContains(t, err, test.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.isActive, isActive)
+		assert.Equal(t, test.value, metrics[0].Value.Value())
+	}
+}
@@ -0,0 +1,330 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	nvidiaDCGMGPUUtilization    = "gpuUtilization"
+	nvidiaDCGMMemoryUtilization = "memoryUtilization"
+	nvidiaDCGMMemoryUsed        = "memoryUsed"
+	nvidiaDCGMInferenceQueue    = "inferenceQueue"
+
+	// fields of the DCGM exporter, the utilization in percent and the frame buffer in MiB
+	dcgmFieldGPUUtil = "DCGM_FI_DEV_GPU_UTIL"
+	dcgmFieldFBUsed  = "DCGM_FI_DEV_FB_USED"
+	dcgmFieldFBFree  = "DCGM_FI_DEV_FB_FREE"
+)
+
+type nvidiaDCGMScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *nvidiaDCGMMetadata
+	kubeClient client.Client
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type nvidiaDCGMMetadata struct {
+	// PodSelector selects the pods of the workload, whose GPUs are reported by the DCGM exporter running on their nodes
+	PodSelector string `keda:"name=podSelector, order=triggerMetadata"`
+	Metric      string `keda:"name=metric,      order=triggerMetadata, enum=gpuUtilization;memoryUtilization;memoryUsed;inferenceQueue, optional, default=gpuUtilization"`
+	// Aggregation aggregates the values of the GPUs, or of the pods for the inference queue
+	Aggregation       string `keda:"name=aggregation,       order=triggerMetadata, enum=avg;max;sum, optional"`
+	ExporterSelector  string `keda:"name=exporterSelector,  order=triggerMetadata, optional, default=app=nvidia-dcgm-exporter"`
+	ExporterNamespace string `keda:"name=exporterNamespace, order=triggerMetadata, optional, default=gpu-operator"`
+	ExporterPort      int    `keda:"name=exporterPort,      order=triggerMetadata, optional, default=9400"`
+	// InferencePort and InferenceMetric locate the gauge of the requests waiting in the inference server, Triton by default
+	InferencePort         int     `keda:"name=inferencePort,         order=triggerMetadata, optional, default=8002"`
+	InferenceMetric       string  `keda:"name=inferenceMetric,       order=triggerMetadata, optional, default=nv_inference_pending_request_count"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+
+	triggerIndex     int
+	namespace        string
+	podSelector      labels.Selector
+	exporterSelector labels.Selector
+}
+
+func (m *nvidiaDCGMMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+
+	podSelector, err := labels.Parse(m.PodSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing pod selector: %w", err)
+	}
+	m.podSelector = podSelector
+
+	exporterSelector, err := labels.Parse(m.ExporterSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing exporter selector: %w", err)
+	}
+	m.exporterSelector = exporterSelector
+
+	if m.Aggregation == "" {
+		m.Aggregation = "avg"
+		if m.Metric == nvidiaDCGMInferenceQueue {
+			m.Aggregation = "sum"
+		}
+	}
+	return nil
+}
+
+// NewNvidiaDCGMScaler creates a new nvidiaDCGMScaler
+func NewNvidiaDCGMScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseNvidiaDCGMMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing nvidia dcgm metadata: %w", err)
+	}
+
+	return &nvidiaDCGMScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "nvidia_dcgm_scaler"),
+	}, nil
+}
+
+func parseNvidiaDCGMMetadata(config *scalersconfig.ScalerConfig) (*nvidiaDCGMMetadata, error) {
+	meta := &nvidiaDCGMMetadata{triggerIndex: config.TriggerIndex, namespace: config.ScalableObjectNamespace}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *nvidiaDCGMScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *nvidiaDCGMScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("nvidia-dcgm-%s", s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the aggregated utilization or memory of the GPUs of the workload, or its queued inference requests
+func (s *nvidiaDCGMScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	pods, err := s.listPods(ctx, s.metadata.namespace, s.metadata.podSelector)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error listing the pods of the workload: %w", err)
+	}
+
+	var values []float64
+	if s.metadata.Metric == nvidiaDCGMInferenceQueue {
+		values, err = s.getInferenceQueues(ctx, pods)
+	} else {
+		values, err = s.getGPUValues(ctx, pods)
+	}
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting %s: %w", s.metadata.Metric, err)
+	}
+
+	value := aggregateNvidiaDCGMValues(values, s.metadata.Aggregation)
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// listPods returns the running pods matching the selector which have an IP
+func (s *nvidiaDCGMScaler) listPods(ctx context.Context, namespace string, selector labels.Selector) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := s.kubeClient.List(ctx, podList, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(podList.Items, func(pod corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == ""
+	}), nil
+}
+
+// getInferenceQueues returns the requests waiting in the inference server of each pod
+func (s *nvidiaDCGMScaler) getInferenceQueues(ctx context.Context, pods []corev1.Pod) ([]float64, error) {
+	var values []float64
+	var errs []error
+	for _, pod := range pods {
+		families, err := s.scrape(ctx, pod.Status.PodIP, s.metadata.InferencePort)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error scraping pod %s: %w", pod.Name, err))
+			continue
+		}
+		var queue float64
+		for _, metric := range families[s.metadata.InferenceMetric].GetMetric() {
+			queue += nvidiaDCGMMetricValue(metric)
+		}
+		values = append(values, queue)
+	}
+	if len(values) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		s.logger.Error(err, "ignoring pod which couldn't be scraped")
+	}
+	return values, nil
+}
+
+// getGPUValues returns the value of each GPU of the pods, read from the exporters running on the nodes of the pods
+func (s *nvidiaDCGMScaler) getGPUValues(ctx context.Context, pods []corev1.Pod) ([]float64, error) {
+	nodes := map[string]bool{}
+	podNames := map[string]bool{}
+	for _, pod := range pods {
+		nodes[pod.Spec.NodeName] = true
+		podNames[pod.Name] = true
+	}
+	exporters, err := s.listPods(ctx, s.metadata.ExporterNamespace, s.metadata.exporterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the dcgm exporters: %w", err)
+	}
+
+	var values []float64
+	var errs []error
+	scraped := false
+	for _, exporter := range exporters {
+		if !nodes[exporter.Spec.NodeName] {
+			continue
+		}
+		families, err := s.scrape(ctx, exporter.Status.PodIP, s.metadata.ExporterPort)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error scraping dcgm exporter %s: %w", exporter.Name, err))
+			continue
+		}
+		scraped = true
+		values = append(values, s.gpuValues(families, podNames)...)
+	}
+	if !scraped && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		s.logger.Error(err, "ignoring dcgm exporter which couldn't be scraped")
+	}
+	return values, nil
+}
+
+// gpuValues returns the value of each GPU the exporter attributes to the pods of the workload
+func (s *nvidiaDCGMScaler) gpuValues(families map[string]*dto.MetricFamily, podNames map[string]bool) []float64 {
+	// fieldValues returns the values of the field for the GPUs of the workload, by UUID
+	fieldValues := func(field string) map[string]float64 {
+		values := map[string]float64{}
+		for _, metric := range families[field].GetMetric() {
+			metricLabels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+			if metricLabels["namespace"] != s.metadata.namespace || !podNames[metricLabels["pod"]] {
+				continue
+			}
+			values[metricLabels["UUID"]+"/"+metricLabels["gpu"]] = nvidiaDCGMMetricValue(metric)
+		}
+		return values
+	}
+
+	var values []float64
+	switch s.metadata.Metric {
+	case nvidiaDCGMGPUUtilization:
+		for _, value := range fieldValues(dcgmFieldGPUUtil) {
+			values = append(values, value)
+		}
+	case nvidiaDCGMMemoryUsed:
+		for _, value := range fieldValues(dcgmFieldFBUsed) {
+			values = append(values, value)
+		}
+	case nvidiaDCGMMemoryUtilization:
+		free := fieldValues(dcgmFieldFBFree)
+		for gpu, used := range fieldValues(dcgmFieldFBUsed) {
+			if total := used + free[gpu]; total > 0 {
+				values = append(values, used/total*100)
+			}
+		}
+	}
+	return values
+}
+
+func (s *nvidiaDCGMScaler) scrape(ctx context.Context, ip string, port int) (map[string]*dto.MetricFamily, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(ip, strconv.Itoa(port)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	parser := expfmt.TextParser{}
+	return parser.TextToMetricFamilies(strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n")))
+}
+
+func nvidiaDCGMMetricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue()
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue()
+	default:
+		return metric.GetUntyped().GetValue()
+	}
+}
+
+func aggregateNvidiaDCGMValues(values []float64, aggregation string) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	switch aggregation {
+	case "max":
+		return slices.Max(values)
+	case "sum", "avg":
+		var sum float64
+		for _, value := range values {
+			sum += value
+		}
+		if aggregation == "avg" {
+			return sum / float64(len(values))
+		}
+		return sum
+	}
+	return 0
+}
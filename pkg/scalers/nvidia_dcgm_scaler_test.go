@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseNvidiaDCGMMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type nvidiaDCGMMetricIdentifier struct {
+	metadataTestData *parseNvidiaDCGMMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testNvidiaDCGMMetadata = []parseNvidiaDCGMMetadataTestData{
+	{map[string]string{}, true, "nothing passed"},
+	{map[string]string{"podSelector": "app=inference", "targetValue": "70"}, false, "gpu utilization"},
+	{map[string]string{"podSelector": "app=inference", "metric": "inferenceQueue", "inferencePort": "8080", "targetValue": "10", "activationTargetValue": "1"}, false, "inference queue"},
+	{map[string]string{"podSelector": "app=inference", "metric": "memoryUtilization", "aggregation": "max", "exporterSelector": "app.kubernetes.io/name=dcgm-exporter", "exporterNamespace": "monitoring", "targetValue": "80"}, false, "memory utilization"},
+	{map[string]string{"podSelector": "app=inference"}, true, "no targetValue"},
+	{map[string]string{"targetValue": "70"}, true, "no podSelector"},
+	{map[string]string{"podSelector": "app in (", "targetValue": "70"}, true, "invalid pod selector"},
+	{map[string]string{"podSelector": "app=inference", "metric": "temperature", "targetValue": "70"}, true, "unknown metric"},
+	{map[string]string{"podSelector": "app=inference", "aggregation": "min", "targetValue": "70"}, true, "unknown aggregation"},
+}
+
+var nvidiaDCGMMetricIdentifiers = []nvidiaDCGMMetricIdentifier{
+	{&testNvidiaDCGMMetadata[1], 0, "s0-nvidia-dcgm-gpuUtilization"},
+	{&testNvidiaDCGMMetadata[2], 1, "s1-nvidia-dcgm-inferenceQueue"},
+}
+
+func TestNvidiaDCGMParseMetadata(t *testing.T) {
+	for _, testData := range testNvidiaDCGMMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseNvidiaDCGMMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestNvidiaDCGMGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range nvidiaDCGMMetricIdentifiers {
+		meta, err := parseNvidiaDCGMMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "default", TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockNvidiaDCGMScaler := nvidiaDCGMScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockNvidiaDCGMScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func createNvidiaDCGMPod(name, namespace, app, node string, phase corev1.PodPhase) client.Object {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: phase, PodIP: "127.0.0.1"},
+	}
+}
+
+const nvidiaDCGMTestMetrics = `# HELP DCGM_FI_DEV_GPU_UTIL GPU utilization (in %).
+# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+DCGM_FI_DEV_GPU_UTIL{gpu="0",UUID="GPU-a",device="nvidia0",Hostname="node-1",container="server",namespace="default",pod="inference-1"} 90
+DCGM_FI_DEV_GPU_UTIL{gpu="1",UUID="GPU-b",device="nvidia1",Hostname="node-1",container="server",namespace="default",pod="inference-2"} 50
+DCGM_FI_DEV_GPU_UTIL{gpu="2",UUID="GPU-c",device="nvidia2",Hostname="node-1",container="trainer",namespace="default",pod="training-1"} 100
+DCGM_FI_DEV_GPU_UTIL{gpu="3",UUID="GPU-d",device="nvidia3",Hostname="node-1"} 0
+# HELP DCGM_FI_DEV_FB_USED Framebuffer memory used (in MiB).
+# TYPE DCGM_FI_DEV_FB_USED gauge
+DCGM_FI_DEV_FB_USED{gpu="0",UUID="GPU-a",device="nvidia0",Hostname="node-1",container="server",namespace="default",pod="inference-1"} 12000
+DCGM_FI_DEV_FB_USED{gpu="1",UUID="GPU-b",device="nvidia1",Hostname="node-1",container="server",namespace="default",pod="inference-2"} 4000
+# HELP DCGM_FI_DEV_FB_FREE Framebuffer memory free (in MiB).
+# TYPE DCGM_FI_DEV_FB_FREE gauge
+DCGM_FI_DEV_FB_FREE{gpu="0",UUID="GPU-a",device="nvidia0",Hostname="node-1",container="server",namespace="default",pod="inference-1"} 4000
+DCGM_FI_DEV_FB_FREE{gpu="1",UUID="GPU-b",device="nvidia1",Hostname="node-1",container="server",namespace="default",pod="inference-2"} 12000
+`
+
+const nvidiaDCGMTestInferenceMetrics = `# HELP nv_inference_pending_request_count Instantaneous number of pending requests awaiting execution per-model.
+# TYPE nv_inference_pending_request_count gauge
+nv_inference_pending_request_count{model="resnet",version="1"} 3
+nv_inference_pending_request_count{model="bert",version="1"} 2
+`
+
+func TestNvidiaDCGMGetMetricsAndActivity(t *testing.T) {
+	serverPort := func(body string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/metrics", r.URL.Path)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+		return port
+	}
+	exporterPort := serverPort(nvidiaDCGMTestMetrics)
+	inferencePort := serverPort(nvidiaDCGMTestInferenceMetrics)
+
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createNvidiaDCGMPod("inference-1", "default", "inference", "node-1", corev1.PodRunning),
+		createNvidiaDCGMPod("inference-2", "default", "inference", "node-1", corev1.PodRunning),
+		createNvidiaDCGMPod("inference-3", "default", "inference", "node-2", corev1.PodPending),
+		createNvidiaDCGMPod("training-1", "default", "training", "node-1", corev1.PodRunning),
+		createNvidiaDCGMPod("dcgm-exporter-1", "gpu-operator", "nvidia-dcgm-exporter", "node-1", corev1.PodRunning),
+	).Build()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"average gpu utilization", map[string]string{"activationTargetValue": "50"}, 70, true},
+		{"max gpu utilization", map[string]string{"aggregation": "max"}, 90, true},
+		{"memory used", map[string]string{"metric": "memoryUsed", "aggregation": "sum"}, 16000, true},
+		{"memory utilization", map[string]string{"metric": "memoryUtilization"}, 50, true},
+		{"gpu utilization of pods without gpu", map[string]string{"podSelector": "app=batch"}, 0, false},
+		{"inference queue", map[string]string{"metric": "inferenceQueue", "inferencePort": inferencePort}, 10, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["exporterPort"] = exporterPort
+			tc.metadata["targetValue"] = "50"
+			if tc.metadata["podSelector"] == "" {
+				tc.metadata["podSelector"] = "app=inference"
+			}
+			s, err := NewNvidiaDCGMScaler(kubeClient, &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, ScalableObjectNamespace: "default"})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "nvidia-dcgm")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
+
+func TestNvidiaDCGMGetMetricsAndActivityUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createNvidiaDCGMPod("inference-1", "default", "inference", "node-1", corev1.PodRunning),
+		createNvidiaDCGMPod("dcgm-exporter-1", "gpu-operator", "nvidia-dcgm-exporter", "node-1", corev1.PodRunning),
+	).Build()
+	s, err := NewNvidiaDCGMScaler(kubeClient, &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"podSelector": "app=inference", "exporterPort": strconv.Itoa(port), "targetValue": "50"},
+		ScalableObjectNamespace: "default",
+	})
+	require.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "nvidia-dcgm")
+	assert.ErrorContains(t, err, "error scraping dcgm exporter dcgm-exporter-1")
+}
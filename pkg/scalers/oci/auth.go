@@ -0,0 +1,344 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youmark/pkcs8"
+)
+
+const (
+	// instanceMetadataURL is the version 2 of the metadata service of the compute instances
+	instanceMetadataURL = "http://169.254.169.254/opc/v2/"
+
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	// proxymuxPort is the port of the proxy of the control plane of OKE issuing the tokens of workload identity
+	proxymuxPort = "12250"
+
+	// sessionKeyBits is the size of the keys generated for the session tokens
+	sessionKeyBits = 2048
+	// sessionExpiryWindow is how long before their expiration the session tokens are renewed
+	sessionExpiryWindow = 5 * time.Minute
+)
+
+// APIKeyProvider signs the requests with the API key of a user.
+type APIKeyProvider struct {
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+// NewAPIKeyProvider returns the provider of the API key whose fingerprint is given, the private key being
+// in PEM format, optionally encrypted with the passphrase.
+func NewAPIKeyProvider(tenancyID, userID, fingerprint, privateKey, passphrase string) (*APIKeyProvider, error) {
+	key, err := parsePrivateKey([]byte(privateKey), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeyProvider{keyID: tenancyID + "/" + userID + "/" + fingerprint, key: key}, nil
+}
+
+func (p *APIKeyProvider) Key(context.Context) (string, *rsa.PrivateKey, error) {
+	return p.keyID, p.key, nil
+}
+
+// sessionProvider signs the requests with a session token, renewed with a new session key before it expires
+type sessionProvider struct {
+	lock   sync.Mutex
+	token  string
+	key    *rsa.PrivateKey
+	expiry time.Time
+	// issue returns a token for the public key of the session
+	issue func(ctx context.Context, publicKey []byte) (string, error)
+}
+
+func (p *sessionProvider) Key(ctx context.Context) (string, *rsa.PrivateKey, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.token != "" && time.Until(p.expiry) > sessionExpiryWindow {
+		return "ST$" + p.token, p.key, nil
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, sessionKeyBits)
+	if err != nil {
+		return "", nil, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	token, err := p.issue(ctx, publicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	expiry, err := tokenExpiry(token)
+	if err != nil {
+		return "", nil, err
+	}
+	p.token, p.key, p.expiry = token, key, expiry
+	return "ST$" + p.token, p.key, nil
+}
+
+// InstancePrincipalProvider signs the requests as the compute instance, e.g. the node of OKE the operator runs on,
+// with a session token issued by the identity service in exchange for the certificate of the instance.
+type InstancePrincipalProvider struct {
+	sessionProvider
+	httpClient *http.Client
+	// metadataURL and federationURL are overridden by the tests
+	metadataURL   string
+	federationURL string
+}
+
+// NewInstancePrincipalProvider returns the provider of the instance principal, the session tokens being issued
+// by the identity service of the region.
+func NewInstancePrincipalProvider(region string, httpClient *http.Client) *InstancePrincipalProvider {
+	p := &InstancePrincipalProvider{
+		httpClient:    httpClient,
+		metadataURL:   instanceMetadataURL,
+		federationURL: fmt.Sprintf("https://auth.%s.%s/v1/x509", region, Domain),
+	}
+	p.issue = p.issueToken
+	return p
+}
+
+func (p *InstancePrincipalProvider) issueToken(ctx context.Context, publicKey []byte) (string, error) {
+	leafPEM, err := p.getMetadata(ctx, "identity/cert.pem")
+	if err != nil {
+		return "", err
+	}
+	leafKeyPEM, err := p.getMetadata(ctx, "identity/key.pem")
+	if err != nil {
+		return "", err
+	}
+	intermediatePEM, err := p.getMetadata(ctx, "identity/intermediate.pem")
+	if err != nil {
+		return "", err
+	}
+
+	leafBlock, _ := pem.Decode(leafPEM)
+	if leafBlock == nil {
+		return "", errors.New("invalid certificate of the instance")
+	}
+	leaf, err := x509.ParseCertificate(leafBlock.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("error parsing certificate of the instance: %w", err)
+	}
+	leafKey, err := parsePrivateKey(leafKeyPEM, "")
+	if err != nil {
+		return "", fmt.Errorf("error parsing key of the instance: %w", err)
+	}
+	tenancyID := tenancyOfCertificate(leaf)
+	if tenancyID == "" {
+		return "", errors.New("no tenancy found in the certificate of the instance")
+	}
+	var intermediates []string
+	for rest := intermediatePEM; ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		intermediates = append(intermediates, base64.StdEncoding.EncodeToString(block.Bytes))
+	}
+
+	request := map[string]interface{}{
+		"certificate":              base64.StdEncoding.EncodeToString(leaf.Raw),
+		"publicKey":                base64.StdEncoding.EncodeToString(publicKey),
+		"intermediateCertificates": intermediates,
+		"purpose":                  "DEFAULT",
+		"fingerprintAlgorithm":     "SHA256",
+	}
+	var response struct {
+		Token string `json:"token"`
+	}
+	client := &Client{HTTPClient: p.httpClient, Keys: &APIKeyProvider{keyID: tenancyID + "/fed-x509-sha256/" + fingerprint(leaf.Raw), key: leafKey}}
+	if err := client.Do(ctx, http.MethodPost, p.federationURL, request, &response); err != nil {
+		return "", fmt.Errorf("error getting session token of the instance: %w", err)
+	}
+	return response.Token, nil
+}
+
+func (p *InstancePrincipalProvider) getMetadata(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metadataURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer Oracle")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error querying the instance metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from the instance metadata service for %s", resp.StatusCode, path)
+	}
+	return body, nil
+}
+
+// WorkloadIdentityProvider signs the requests as the service account of the operator, with a session token
+// issued by the control plane of OKE in exchange for the token of the service account.
+type WorkloadIdentityProvider struct {
+	sessionProvider
+	httpClient *http.Client
+	// tokenURL and tokenFile are overridden by the tests
+	tokenURL  string
+	tokenFile string
+}
+
+// NewWorkloadIdentityProvider returns the provider of the workload identity of the operator, the control plane
+// being reached through the kubernetes service and trusted with the CA of the service account.
+func NewWorkloadIdentityProvider(timeout time.Duration) (*WorkloadIdentityProvider, error) {
+	host := os.Getenv("KUBERNETES_SERVICE_HOST")
+	if host == "" {
+		return nil, errors.New("KUBERNETES_SERVICE_HOST isn't set, workload identity is only available within OKE")
+	}
+	ca, err := os.ReadFile(serviceAccountCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA of the service account: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid CA of the service account")
+	}
+
+	p := &WorkloadIdentityProvider{
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+		tokenURL:  "https://" + net.JoinHostPort(host, proxymuxPort) + "/resourcePrincipalSessionTokens",
+		tokenFile: serviceAccountTokenFile,
+	}
+	p.issue = p.issueToken
+	return p, nil
+}
+
+func (p *WorkloadIdentityProvider) issueToken(ctx context.Context, publicKey []byte) (string, error) {
+	serviceAccountToken, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("error reading token of the service account: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"podKey": base64.StdEncoding.EncodeToString(publicKey)})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(serviceAccountToken)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error getting session token of the workload: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d getting session token of the workload: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	// the response is the base64 encoding of the JSON holding the token
+	decoded, err := base64.StdEncoding.DecodeString(strings.Trim(string(respBody), "\" \n"))
+	if err != nil {
+		return "", fmt.Errorf("error decoding session token of the workload: %w", err)
+	}
+	var response struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(decoded, &response); err != nil {
+		return "", fmt.Errorf("error decoding session token of the workload: %w", err)
+	}
+	return strings.TrimPrefix(response.Token, "ST$"), nil
+}
+
+// tokenExpiry returns the expiration of the session token, which is a JWT
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("session token isn't a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error decoding session token: %w", err)
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("error decoding session token: %w", err)
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
+
+// tenancyOfCertificate returns the tenancy the certificate of an instance was issued for, which is held
+// by an organizational unit of its subject
+func tenancyOfCertificate(cert *x509.Certificate) string {
+	for _, unit := range cert.Subject.OrganizationalUnit {
+		for _, prefix := range []string{"opc-tenant:", "opc-identity:"} {
+			if tenancy, found := strings.CutPrefix(unit, prefix); found {
+				return tenancy
+			}
+		}
+	}
+	return ""
+}
+
+// fingerprint returns the SHA-256 fingerprint of the certificate, as colon separated hexadecimal bytes
+func fingerprint(der []byte) string {
+	hash := sha256.Sum256(der)
+	parts := make([]string, len(hash))
+	for i, b := range hash {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// parsePrivateKey parses an RSA key in PKCS #1 or PKCS #8 PEM format, the latter being possibly encrypted
+func parsePrivateKey(data []byte, passphrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key isn't in PEM format")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	var password []byte
+	if passphrase != "" {
+		password = []byte(passphrase)
+	}
+	parsed, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, password)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key isn't an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,125 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domain is the domain of the services of the commercial realm
+const Domain = "oraclecloud.com"
+
+// ServiceError is the body of the failed responses of the services.
+type ServiceError struct {
+	StatusCode   int
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	OpcRequestID string `json:"-"`
+}
+
+func (e *ServiceError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s: %s (opc-request-id %s)", e.StatusCode, e.Code, e.Message, e.OpcRequestID)
+}
+
+// KeyProvider provides the key id and the private key requests are signed with.
+type KeyProvider interface {
+	Key(ctx context.Context) (keyID string, key *rsa.PrivateKey, err error)
+}
+
+// Client sends the requests to the services, signed as of the HTTP signatures OCI authenticates requests with.
+type Client struct {
+	HTTPClient *http.Client
+	Keys       KeyProvider
+}
+
+// Do sends the request, with the JSON encoding of the body if it isn't nil, and decodes its JSON response into out.
+func (c *Client) Do(ctx context.Context, method, url string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	if body == nil {
+		req.Body, req.ContentLength = http.NoBody, 0
+	}
+	keyID, key, err := c.Keys.Key(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting signing key: %w", err)
+	}
+	if err := SignRequest(req, payload, keyID, key); err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		serviceError := &ServiceError{StatusCode: resp.StatusCode, OpcRequestID: resp.Header.Get("opc-request-id")}
+		if err := json.Unmarshal(respBody, serviceError); err != nil {
+			serviceError.Message = strings.TrimSpace(string(respBody))
+		}
+		return serviceError
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// SignRequest signs the request with the key. The date, target and host of the requests are signed,
+// as well as the content of the requests with a body.
+func SignRequest(req *http.Request, body []byte, keyID string, key *rsa.PrivateKey) error {
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	headers := []string{"date", "(request-target)", "host"}
+	if req.Method == http.MethodPost || req.Method == http.MethodPut || req.Method == http.MethodPatch {
+		hash := sha256.Sum256(body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		req.Header.Set("X-Content-Sha256", base64.StdEncoding.EncodeToString(hash[:]))
+		headers = append(headers, "content-length", "content-type", "x-content-sha256")
+	}
+
+	lines := make([]string, len(headers))
+	for i, header := range headers {
+		switch header {
+		case "(request-target)":
+			lines[i] = fmt.Sprintf("%s: %s %s", header, strings.ToLower(req.Method), req.URL.RequestURI())
+		case "host":
+			lines[i] = fmt.Sprintf("%s: %s", header, req.URL.Host)
+		default:
+			lines[i] = fmt.Sprintf("%s: %s", header, req.Header.Get(header))
+		}
+	}
+	hash := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return fmt.Errorf("error signing request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(`Signature version="1",keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyID, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
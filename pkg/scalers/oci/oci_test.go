@@ -0,0 +1,204 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSignatureRegexp = regexp.MustCompile(`^Signature version="1",keyId="([^"]+)",algorithm="rsa-sha256",headers="([^"]+)",signature="([^"]+)"$`)
+
+// verifySignature checks the signature of the request with the public key and returns its key id
+func verifySignature(t *testing.T, r *http.Request, publicKey *rsa.PublicKey) string {
+	match := testSignatureRegexp.FindStringSubmatch(r.Header.Get("Authorization"))
+	require.NotNil(t, match, r.Header.Get("Authorization"))
+
+	var lines []string
+	for _, header := range strings.Split(match[2], " ") {
+		switch header {
+		case "(request-target)":
+			lines = append(lines, fmt.Sprintf("%s: %s %s", header, strings.ToLower(r.Method), r.URL.RequestURI()))
+		case "host":
+			lines = append(lines, fmt.Sprintf("%s: %s", header, r.Host))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s", header, r.Header.Get(header)))
+		}
+	}
+	signature, err := base64.StdEncoding.DecodeString(match[3])
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature))
+	return match[1]
+}
+
+func generateKeyPEM(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// testSessionToken returns a JWT expiring after the duration, its signature not being checked
+func testSessionToken(expiresIn time.Duration) string {
+	payload, _ := json.Marshal(map[string]int64{"exp": time.Now().Add(expiresIn).Unix()})
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestClientAPIKey(t *testing.T) {
+	key, keyPEM := generateKeyPEM(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ocid1.tenancy.oc1..aaa/ocid1.user.oc1..bbb/12:34", verifySignature(t, r, &key.PublicKey))
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			hash := sha256.Sum256(body)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(hash[:]), r.Header.Get("X-Content-Sha256"))
+			assert.JSONEq(t, `{"name":"keda"}`, string(body))
+			w.Header().Set("opc-request-id", "ABC")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"NotAuthorizedOrNotFound","message":"Authorization failed or requested resource not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"visibleMessages":3}`))
+	}))
+	defer server.Close()
+
+	keys, err := NewAPIKeyProvider("ocid1.tenancy.oc1..aaa", "ocid1.user.oc1..bbb", "12:34", keyPEM, "")
+	require.NoError(t, err)
+	client := &Client{HTTPClient: server.Client(), Keys: keys}
+
+	var stats struct {
+		VisibleMessages int64 `json:"visibleMessages"`
+	}
+	assert.NoError(t, client.Do(context.Background(), http.MethodGet, server.URL+"/20210201/queues/q/stats?channelId=a", nil, &stats))
+	assert.Equal(t, int64(3), stats.VisibleMessages)
+
+	err = client.Do(context.Background(), http.MethodPost, server.URL+"/groups", map[string]string{"name": "keda"}, nil)
+	var serviceError *ServiceError
+	require.ErrorAs(t, err, &serviceError)
+	assert.Equal(t, "NotAuthorizedOrNotFound", serviceError.Code)
+	assert.Equal(t, "ABC", serviceError.OpcRequestID)
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	parsed, err := parsePrivateKey(pkcs1, "")
+	assert.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = parsePrivateKey([]byte("not a key"), "")
+	assert.ErrorContains(t, err, "PEM")
+}
+
+func TestInstancePrincipalProvider(t *testing.T) {
+	leafKey, leafKeyPEM := generateKeyPEM(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ocid1.instance.oc1..ccc", OrganizationalUnit: []string{"opc-certtype:instance", "opc-tenant:ocid1.tenancy.oc1..aaa"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, template, template, &leafKey.PublicKey, leafKey)
+	require.NoError(t, err)
+	leafPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+
+	var federations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/opc/v2/identity/cert.pem", "/opc/v2/identity/intermediate.pem":
+			assert.Equal(t, "Bearer Oracle", r.Header.Get("Authorization"))
+			_, _ = w.Write(leafPEM)
+		case "/opc/v2/identity/key.pem":
+			_, _ = w.Write([]byte(leafKeyPEM))
+		case "/v1/x509":
+			federations.Add(1)
+			keyID := verifySignature(t, r, &leafKey.PublicKey)
+			assert.Equal(t, "ocid1.tenancy.oc1..aaa/fed-x509-sha256/"+fingerprint(leafDER), keyID)
+			var request struct {
+				Certificate   string   `json:"certificate"`
+				PublicKey     string   `json:"publicKey"`
+				Intermediates []string `json:"intermediateCertificates"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(leafDER), request.Certificate)
+			assert.Len(t, request.Intermediates, 1)
+			assert.NotEmpty(t, request.PublicKey)
+			_, _ = fmt.Fprintf(w, `{"token":%q}`, testSessionToken(time.Hour))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewInstancePrincipalProvider("us-ashburn-1", server.Client())
+	provider.metadataURL = server.URL + "/opc/v2/"
+	provider.federationURL = server.URL + "/v1/x509"
+
+	keyID, key, err := provider.Key(context.Background())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(keyID, "ST$eyJ"))
+	assert.NotNil(t, key)
+
+	// the session token is reused until it's about to expire
+	_, _, err = provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), federations.Load())
+}
+
+func TestWorkloadIdentityProvider(t *testing.T) {
+	var issued atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/resourcePrincipalSessionTokens", r.URL.Path)
+		assert.Equal(t, "Bearer sa-token", r.Header.Get("Authorization"))
+		var request struct {
+			PodKey string `json:"podKey"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.NotEmpty(t, request.PodKey)
+
+		// the token expires within the expiry window, so that it's renewed on the next call
+		token, _ := json.Marshal(map[string]string{"token": "ST$" + testSessionToken(time.Minute)})
+		issued.Add(1)
+		_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(token)))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+	provider := &WorkloadIdentityProvider{httpClient: server.Client(), tokenURL: server.URL + "/resourcePrincipalSessionTokens", tokenFile: tokenFile}
+	provider.issue = provider.issueToken
+
+	keyID, _, err := provider.Key(context.Background())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(keyID, "ST$eyJ"))
+	assert.False(t, strings.HasPrefix(keyID, "ST$ST$"))
+
+	_, _, err = provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), issued.Load())
+}
@@ -0,0 +1,209 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/oci"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	ociAuthTypeAPIKey            = "apiKey"
+	ociAuthTypeInstancePrincipal = "instancePrincipal"
+	ociAuthTypeWorkloadIdentity  = "workloadIdentity"
+
+	ociQueueAPIVersion = "20210201"
+)
+
+type ociQueueScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *ociQueueMetadata
+	client     *oci.Client
+	logger     logr.Logger
+
+	// messagesEndpoint is resolved from the queue unless it's set in the metadata
+	messagesEndpointLock sync.Mutex
+	messagesEndpoint     string
+}
+
+// ociAuthMetadata is the authentication to the services of OCI, with the API key of a user or the identity of the operator
+type ociAuthMetadata struct {
+	AuthType             string `keda:"name=authType,             order=triggerMetadata;authParams, enum=apiKey;instancePrincipal;workloadIdentity, optional, default=apiKey"`
+	TenancyID            string `keda:"name=tenancyId,            order=authParams;triggerMetadata, optional"`
+	UserID               string `keda:"name=userId,               order=authParams, optional"`
+	Fingerprint          string `keda:"name=fingerprint,          order=authParams, optional"`
+	PrivateKey           string `keda:"name=privateKey,           order=authParams, optional"`
+	PrivateKeyPassphrase string `keda:"name=privateKeyPassphrase, order=authParams, optional"`
+}
+
+func (m *ociAuthMetadata) Validate() error {
+	if m.AuthType == ociAuthTypeAPIKey && (m.TenancyID == "" || m.UserID == "" || m.Fingerprint == "" || m.PrivateKey == "") {
+		return fmt.Errorf("tenancyId, userId, fingerprint and privateKey are required with the %s auth type", ociAuthTypeAPIKey)
+	}
+	return nil
+}
+
+// newKeyProvider returns the provider of the keys the requests to the services of the region are signed with
+func (m *ociAuthMetadata) newKeyProvider(config *scalersconfig.ScalerConfig, region string, httpClient *http.Client) (oci.KeyProvider, error) {
+	switch m.AuthType {
+	case ociAuthTypeInstancePrincipal:
+		return oci.NewInstancePrincipalProvider(region, httpClient), nil
+	case ociAuthTypeWorkloadIdentity:
+		return oci.NewWorkloadIdentityProvider(config.GlobalHTTPTimeout)
+	default:
+		return oci.NewAPIKeyProvider(m.TenancyID, m.UserID, m.Fingerprint, m.PrivateKey, m.PrivateKeyPassphrase)
+	}
+}
+
+type ociQueueMetadata struct {
+	triggerIndex int
+	Auth         ociAuthMetadata `keda:""`
+
+	QueueID string `keda:"name=queueId, order=triggerMetadata"`
+	Region  string `keda:"name=region,  order=triggerMetadata"`
+	// MessagesEndpoint is the endpoint of the messages of the queue, which is otherwise resolved from the queue
+	MessagesEndpoint string `keda:"name=messagesEndpoint, order=triggerMetadata, optional"`
+	// ChannelID restricts the messages to the ones of a channel of the queue
+	ChannelID             string  `keda:"name=channelId,             order=triggerMetadata, optional"`
+	QueueLength           float64 `keda:"name=queueLength,           order=triggerMetadata, optional, default=5"`
+	ActivationQueueLength float64 `keda:"name=activationQueueLength, order=triggerMetadata, optional"`
+	ScaleOnInFlight       bool    `keda:"name=scaleOnInFlight,       order=triggerMetadata, optional, default=true"`
+}
+
+func (m *ociQueueMetadata) Validate() error {
+	if m.QueueLength <= 0 {
+		return errors.New("queueLength must be greater than 0")
+	}
+	m.MessagesEndpoint = strings.TrimSuffix(m.MessagesEndpoint, "/")
+	return nil
+}
+
+// ociQueueStats is the response of GetStats
+type ociQueueStats struct {
+	Queue struct {
+		VisibleMessages  int64 `json:"visibleMessages"`
+		InFlightMessages int64 `json:"inFlightMessages"`
+	} `json:"queue"`
+}
+
+// NewOCIQueueScaler creates a new ociQueueScaler
+func NewOCIQueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOCIQueueMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing oci queue metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	keys, err := meta.Auth.newKeyProvider(config, meta.Region, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oci %s auth: %w", meta.Auth.AuthType, err)
+	}
+
+	return &ociQueueScaler{
+		metricType:       metricType,
+		metadata:         meta,
+		client:           &oci.Client{HTTPClient: httpClient, Keys: keys},
+		logger:           InitializeLogger(config, "oci_queue_scaler"),
+		messagesEndpoint: meta.MessagesEndpoint,
+	}, nil
+}
+
+func parseOCIQueueMetadata(config *scalersconfig.ScalerConfig) (*ociQueueMetadata, error) {
+	meta := &ociQueueMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (s *ociQueueScaler) Close(context.Context) error {
+	if s.client != nil {
+		s.client.HTTPClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *ociQueueScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("oci-queue-%s", s.metadata.QueueID))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.QueueLength),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of messages of the queue
+func (s *ociQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	length, err := s.getQueueLength(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting stats of queue %s: %w", s.metadata.QueueID, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(length))
+	return []external_metrics.ExternalMetricValue{metric}, float64(length) > s.metadata.ActivationQueueLength, nil
+}
+
+// getQueueLength returns the visible messages of the queue, with the in flight ones when enabled
+func (s *ociQueueScaler) getQueueLength(ctx context.Context) (int64, error) {
+	endpoint, err := s.getMessagesEndpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	statsURL := fmt.Sprintf("%s/%s/queues/%s/stats", endpoint, ociQueueAPIVersion, url.PathEscape(s.metadata.QueueID))
+	if s.metadata.ChannelID != "" {
+		statsURL += "?channelId=" + url.QueryEscape(s.metadata.ChannelID)
+	}
+	var stats ociQueueStats
+	if err := s.client.Do(ctx, http.MethodGet, statsURL, nil, &stats); err != nil {
+		return 0, err
+	}
+
+	length := stats.Queue.VisibleMessages
+	if s.metadata.ScaleOnInFlight {
+		length += stats.Queue.InFlightMessages
+	}
+	return length, nil
+}
+
+// getMessagesEndpoint returns the endpoint of the messages of the queue, which is read from the queue the first time
+func (s *ociQueueScaler) getMessagesEndpoint(ctx context.Context) (string, error) {
+	s.messagesEndpointLock.Lock()
+	defer s.messagesEndpointLock.Unlock()
+	if s.messagesEndpoint != "" {
+		return s.messagesEndpoint, nil
+	}
+
+	var queue struct {
+		MessagesEndpoint string `json:"messagesEndpoint"`
+	}
+	queueURL := fmt.Sprintf("https://messaging.%s.%s/%s/queues/%s", s.metadata.Region, oci.Domain, ociQueueAPIVersion, url.PathEscape(s.metadata.QueueID))
+	if err := s.client.Do(ctx, http.MethodGet, queueURL, nil, &queue); err != nil {
+		return "", fmt.Errorf("error getting messages endpoint of the queue: %w", err)
+	}
+	if queue.MessagesEndpoint == "" {
+		return "", errors.New("queue has no messages endpoint")
+	}
+	s.messagesEndpoint = strings.TrimSuffix(queue.MessagesEndpoint, "/")
+	return s.messagesEndpoint, nil
+}
@@ -0,0 +1,130 @@
+package scalers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOCIQueueMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type ociQueueMetricIdentifier struct {
+	metadataTestData *parseOCIQueueMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testOCIQueueAPIKey = map[string]string{"tenancyId": "ocid1.tenancy.oc1..aaa", "userId": "ocid1.user.oc1..bbb", "fingerprint": "12:34", "privateKey": "key"}
+
+var testOCIQueueMetadata = []parseOCIQueueMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1"}, testOCIQueueAPIKey, false, "api key"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1", "authType": "instancePrincipal", "channelId": "eu", "queueLength": "10", "activationQueueLength": "1", "scaleOnInFlight": "false"}, map[string]string{}, false, "instance principal"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1", "authType": "workloadIdentity", "messagesEndpoint": "https://cell-1.queue.messaging.us-ashburn-1.oci.oraclecloud.com/"}, map[string]string{}, false, "workload identity"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1"}, map[string]string{"tenancyId": "ocid1.tenancy.oc1..aaa"}, true, "incomplete api key"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1", "authType": "resourcePrincipal"}, map[string]string{}, true, "unknown auth type"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc"}, testOCIQueueAPIKey, true, "no region"},
+	{map[string]string{"queueId": "ocid1.queue.oc1.iad.ccc", "region": "us-ashburn-1", "queueLength": "0"}, testOCIQueueAPIKey, true, "zero queueLength"},
+}
+
+var ociQueueMetricIdentifiers = []ociQueueMetricIdentifier{
+	{&testOCIQueueMetadata[1], 0, "s0-oci-queue-ocid1-queue-oc1-iad-ccc"},
+	{&testOCIQueueMetadata[2], 1, "s1-oci-queue-ocid1-queue-oc1-iad-ccc"},
+}
+
+func TestOCIQueueParseMetadata(t *testing.T) {
+	for _, testData := range testOCIQueueMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseOCIQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestOCIQueueGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range ociQueueMetricIdentifiers {
+		meta, err := parseOCIQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockOCIQueueScaler := ociQueueScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockOCIQueueScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestOCIQueueGetMetricsAndActivity(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/20210201/queues/ocid1.queue.oc1.iad.ccc/stats", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), `Signature version="1",keyId="ocid1.tenancy.oc1..aaa/ocid1.user.oc1..bbb/12:34"`))
+		if r.URL.Query().Get("channelId") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"NotAuthorizedOrNotFound","message":"queue not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"queue":{"visibleMessages":7,"inFlightMessages":3},"dlq":{"visibleMessages":1,"inFlightMessages":0}}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+		isError       bool
+	}{
+		{"visible and in flight messages", map[string]string{}, 10, true, false},
+		{"visible messages", map[string]string{"scaleOnInFlight": "false", "activationQueueLength": "7"}, 7, false, false},
+		{"missing channel", map[string]string{"channelId": "missing"}, 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["queueId"] = "ocid1.queue.oc1.iad.ccc"
+			tc.metadata["region"] = "us-ashburn-1"
+			tc.metadata["messagesEndpoint"] = server.URL
+			authParams := map[string]string{"tenancyId": "ocid1.tenancy.oc1..aaa", "userId": "ocid1.user.oc1..bbb", "fingerprint": "12:34", "privateKey": keyPEM}
+			s, err := NewOCIQueueScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: authParams})
+			require.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "oci-queue")
+			if tc.isError {
+				assert.ErrorContains(t, err, "NotAuthorizedOrNotFound")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
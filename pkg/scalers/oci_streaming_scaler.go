@@ -0,0 +1,104 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/oci"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// ociStreamingScaler computes the consumer lag of a stream with the kafka scaler, through the Kafka compatible endpoint
+// of the stream pool. The stream pool authenticates the Kafka clients with the auth token of a user.
+type ociStreamingScaler struct {
+	metadata *ociStreamingMetadata
+	kafka    Scaler
+}
+
+type ociStreamingMetadata struct {
+	triggerIndex int
+
+	Region        string `keda:"name=region,        order=triggerMetadata"`
+	StreamPoolID  string `keda:"name=streamPoolId,  order=triggerMetadata"`
+	StreamName    string `keda:"name=streamName,    order=triggerMetadata"`
+	ConsumerGroup string `keda:"name=consumerGroup, order=triggerMetadata"`
+	// BootstrapServers is the Kafka endpoint of the stream pool, which is otherwise the one of the region
+	BootstrapServers string `keda:"name=bootstrapServers, order=triggerMetadata, optional"`
+
+	TenancyName string `keda:"name=tenancyName, order=authParams;triggerMetadata"`
+	Username    string `keda:"name=username,    order=authParams;triggerMetadata"`
+	AuthToken   string `keda:"name=authToken,   order=authParams"`
+}
+
+func (m *ociStreamingMetadata) Validate() error {
+	if m.BootstrapServers == "" {
+		m.BootstrapServers = fmt.Sprintf("cell-1.streaming.%s.oci.%s:9092", m.Region, oci.Domain)
+	}
+	return nil
+}
+
+// NewOCIStreamingScaler creates a new ociStreamingScaler
+func NewOCIStreamingScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	meta, err := parseOCIStreamingMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing oci streaming metadata: %w", err)
+	}
+
+	kafka, err := NewKafkaScaler(ctx, buildOCIStreamingKafkaConfig(config, meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ociStreamingScaler{
+		metadata: meta,
+		kafka:    kafka,
+	}, nil
+}
+
+func parseOCIStreamingMetadata(config *scalersconfig.ScalerConfig) (*ociStreamingMetadata, error) {
+	meta := &ociStreamingMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// buildOCIStreamingKafkaConfig returns the config of the kafka scaler for the stream. The remaining trigger metadata,
+// e.g. lagThreshold or offsetResetPolicy, is handed over as is.
+func buildOCIStreamingKafkaConfig(config *scalersconfig.ScalerConfig, meta *ociStreamingMetadata) *scalersconfig.ScalerConfig {
+	kafkaConfig := *config
+	kafkaConfig.TriggerMetadata = maps.Clone(config.TriggerMetadata)
+	kafkaConfig.TriggerMetadata["bootstrapServers"] = meta.BootstrapServers
+	kafkaConfig.TriggerMetadata["topic"] = meta.StreamName
+	kafkaConfig.TriggerMetadata["consumerGroup"] = meta.ConsumerGroup
+	kafkaConfig.TriggerMetadata["sasl"] = string(KafkaSASLTypePlaintext)
+	kafkaConfig.TriggerMetadata["tls"] = stringEnable
+	kafkaConfig.AuthParams = map[string]string{
+		"username": fmt.Sprintf("%s/%s/%s", meta.TenancyName, meta.Username, meta.StreamPoolID),
+		"password": meta.AuthToken,
+	}
+	return &kafkaConfig
+}
+
+// Close closes the kafka client of the kafka scaler
+func (s *ociStreamingScaler) Close(ctx context.Context) error {
+	return s.kafka.Close(ctx)
+}
+
+// GetMetricSpecForScaling returns the metric spec of the kafka scaler, named after the stream
+func (s *ociStreamingScaler) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
+	metricSpecs := s.kafka.GetMetricSpecForScaling(ctx)
+	metricName := kedautil.NormalizeString(fmt.Sprintf("oci-streaming-%s", s.metadata.StreamName))
+	metricSpecs[0].External.Metric.Name = GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName)
+	return metricSpecs
+}
+
+// GetMetricsAndActivity returns the consumer lag of the stream
+func (s *ociStreamingScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	return s.kafka.GetMetricsAndActivity(ctx, metricName)
+}
@@ -0,0 +1,65 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOCIStreamingMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+var testOCIStreamingAuthParams = map[string]string{"tenancyName": "acme", "username": "keda@example.com", "authToken": "token"}
+
+var testOCIStreamingMetadata = []parseOCIStreamingMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"region": "us-ashburn-1", "streamPoolId": "ocid1.streampool.oc1.iad.ddd", "streamName": "orders", "consumerGroup": "workers"}, testOCIStreamingAuthParams, false, "auth token"},
+	{map[string]string{"region": "us-ashburn-1", "streamPoolId": "ocid1.streampool.oc1.iad.ddd", "streamName": "orders", "consumerGroup": "workers", "bootstrapServers": "cell-2.streaming.us-ashburn-1.oci.oraclecloud.com:9092", "lagThreshold": "50"}, testOCIStreamingAuthParams, false, "bootstrap servers"},
+	{map[string]string{"region": "us-ashburn-1", "streamPoolId": "ocid1.streampool.oc1.iad.ddd", "streamName": "orders"}, testOCIStreamingAuthParams, true, "no consumer group"},
+	{map[string]string{"region": "us-ashburn-1", "streamPoolId": "ocid1.streampool.oc1.iad.ddd", "streamName": "orders", "consumerGroup": "workers"}, map[string]string{"tenancyName": "acme", "username": "keda@example.com"}, true, "no auth token"},
+}
+
+func TestOCIStreamingParseMetadata(t *testing.T) {
+	for _, testData := range testOCIStreamingMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseOCIStreamingMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestOCIStreamingKafkaConfig(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{TriggerMetadata: testOCIStreamingMetadata[1].metadata, AuthParams: testOCIStreamingMetadata[1].authParams, TriggerIndex: 1}
+	meta, err := parseOCIStreamingMetadata(config)
+	require.NoError(t, err)
+
+	kafkaMeta, err := parseKafkaMetadata(buildOCIStreamingKafkaConfig(config, meta), logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cell-1.streaming.us-ashburn-1.oci.oraclecloud.com:9092"}, kafkaMeta.bootstrapServers)
+	assert.Equal(t, "orders", kafkaMeta.topic)
+	assert.Equal(t, "workers", kafkaMeta.group)
+	assert.Equal(t, KafkaSASLTypePlaintext, kafkaMeta.saslType)
+	assert.True(t, kafkaMeta.enableTLS)
+	assert.Equal(t, "acme/keda@example.com/ocid1.streampool.oc1.iad.ddd", kafkaMeta.username)
+	assert.Equal(t, "token", kafkaMeta.password)
+	// the trigger metadata of the scaled object is left as is
+	assert.NotContains(t, config.TriggerMetadata, "topic")
+
+	s := ociStreamingScaler{metadata: meta, kafka: &kafkaScaler{metadata: kafkaMeta}}
+	metricSpec := s.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, "s1-oci-streaming-orders", metricSpec[0].External.Metric.Name)
+}
@@ -0,0 +1,224 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type openSearchScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *openSearchMetadata
+	httpClient *http.Client
+	// awsConfig holds the credentials signing the requests with SigV4, nil when they aren't signed
+	awsConfig *aws.Config
+	signer    *v4.Signer
+	logger    logr.Logger
+}
+
+type openSearchMetadata struct {
+	triggerIndex int
+
+	Addresses []string `keda:"name=addresses, order=triggerMetadata;authParams"`
+	// Index holds the indices, aliases or index patterns to search, e.g. logs-*
+	Index                 []string `keda:"name=index,                 order=triggerMetadata, separator=;"`
+	Query                 string   `keda:"name=query,                 order=triggerMetadata;authParams"`
+	SearchPipeline        string   `keda:"name=searchPipeline,        order=triggerMetadata, optional"`
+	ValueLocation         string   `keda:"name=valueLocation,         order=triggerMetadata"`
+	TargetValue           float64  `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64  `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool     `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username string `keda:"name=username, order=authParams;triggerMetadata, optional"`
+	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
+
+	// AwsRegion enables the SigV4 signing of the requests, for the service of the domains or the one of Serverless
+	AwsRegion  string `keda:"name=awsRegion,  order=triggerMetadata;authParams, optional"`
+	AwsService string `keda:"name=awsService, order=triggerMetadata, enum=es;aoss, optional, default=es"`
+
+	awsAuthorization awsutils.AuthorizationMetadata
+}
+
+func (m *openSearchMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if m.Username != "" && m.AwsRegion != "" {
+		return errors.New("username and password can't be used with awsRegion")
+	}
+	for i, address := range m.Addresses {
+		m.Addresses[i] = strings.TrimSuffix(address, "/")
+	}
+	return nil
+}
+
+// NewOpenSearchScaler creates a new OpenSearch scaler
+func NewOpenSearchScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOpenSearchMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing opensearch metadata: %w", err)
+	}
+
+	s := &openSearchScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "opensearch_scaler"),
+	}
+	if meta.AwsRegion != "" {
+		if s.awsConfig, err = awsutils.GetAwsConfig(context.Background(), meta.awsAuthorization); err != nil {
+			return nil, fmt.Errorf("error getting aws config: %w", err)
+		}
+		s.signer = v4.NewSigner()
+	}
+	return s, nil
+}
+
+func parseOpenSearchMetadata(config *scalersconfig.ScalerConfig) (*openSearchMetadata, error) {
+	meta := &openSearchMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+
+	if meta.AwsRegion != "" {
+		awsAuthorization, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, meta.AwsRegion, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+		if err != nil {
+			return nil, err
+		}
+		meta.awsAuthorization = awsAuthorization
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection and releases the aws credentials
+func (s *openSearchScaler) Close(context.Context) error {
+	if s.awsConfig != nil {
+		awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	}
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *openSearchScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("opensearch-%s", strings.Join(s.metadata.Index, "-")))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value found at valueLocation in the result of the search
+func (s *openSearchScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting opensearch: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+// getQueryResult runs the search on the addresses in order, the next address being only tried when the previous one can't be reached
+func (s *openSearchScaler) getQueryResult(ctx context.Context) (float64, error) {
+	var errs []error
+	for _, address := range s.metadata.Addresses {
+		body, err := s.search(ctx, address)
+		if err != nil {
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				errs = append(errs, err)
+				continue
+			}
+			return 0, err
+		}
+		return getValueFromSearch(body, s.metadata.ValueLocation)
+	}
+	return 0, errors.Join(errs...)
+}
+
+func (s *openSearchScaler) search(ctx context.Context, address string) ([]byte, error) {
+	indices := make([]string, len(s.metadata.Index))
+	for i, index := range s.metadata.Index {
+		indices[i] = url.PathEscape(index)
+	}
+	searchURL := fmt.Sprintf("%s/%s/_search", address, strings.Join(indices, ","))
+	if s.metadata.SearchPipeline != "" {
+		searchURL += "?search_pipeline=" + url.QueryEscape(s.metadata.SearchPipeline)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, searchURL, bytes.NewBufferString(s.metadata.Query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+	if s.awsConfig != nil {
+		if err := s.sign(ctx, req); err != nil {
+			return nil, fmt.Errorf("error signing request: %w", err)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, address, string(body))
+	}
+	return body, nil
+}
+
+// sign signs the request with SigV4, OpenSearch Serverless requiring the hash of the payload in X-Amz-Content-Sha256
+func (s *openSearchScaler) sign(ctx context.Context, req *http.Request) error {
+	credentials, err := s.awsConfig.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	payloadHash := sha256.Sum256([]byte(s.metadata.Query))
+	hash := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Content-Sha256", hash)
+	return s.signer.SignHTTP(ctx, credentials, req, hash, s.metadata.AwsService, s.metadata.AwsRegion, time.Now())
+}
@@ -0,0 +1,147 @@
+package scalers
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOpenSearchMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type openSearchMetricIdentifier struct {
+	metadataTestData *parseOpenSearchMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+const openSearchTestQuery = `{"size":0,"query":{"term":{"status":"pending"}}}`
+
+var testOpenSearchMetadata = []parseOpenSearchMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{"username": "admin", "password": "admin"}, false, "basic auth"},
+	{map[string]string{"addresses": "https://abc.us-east-1.aoss.amazonaws.com", "index": "jobs-*;retries", "query": openSearchTestQuery, "searchPipeline": "pending", "valueLocation": "hits.total.value", "targetValue": "10", "activationTargetValue": "1", "awsRegion": "us-east-1", "awsService": "aoss"}, map[string]string{"awsAccessKeyID": "AKIA", "awsSecretAccessKey": "secret"}, false, "sigv4 on serverless"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, false, "no auth"},
+	{map[string]string{"index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true, "missing addresses"},
+	{map[string]string{"addresses": "https://search:9200", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true, "missing index"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{}, true, "missing query"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "targetValue": "10"}, map[string]string{}, true, "missing valueLocation"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10"}, map[string]string{"username": "admin"}, true, "username without password"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10", "awsRegion": "us-east-1"}, map[string]string{"username": "admin", "password": "admin", "awsAccessKeyID": "AKIA", "awsSecretAccessKey": "secret"}, true, "basic auth and sigv4"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10", "awsRegion": "us-east-1", "awsService": "s3"}, map[string]string{"awsAccessKeyID": "AKIA", "awsSecretAccessKey": "secret"}, true, "unknown awsService"},
+	{map[string]string{"addresses": "https://search:9200", "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10", "awsRegion": "us-east-1"}, map[string]string{}, true, "sigv4 without credentials"},
+}
+
+var openSearchMetricIdentifiers = []openSearchMetricIdentifier{
+	{&testOpenSearchMetadata[1], 0, "s0-opensearch-jobs"},
+	{&testOpenSearchMetadata[2], 1, "s1-opensearch-jobs-*-retries"},
+}
+
+func TestOpenSearchParseMetadata(t *testing.T) {
+	for _, testData := range testOpenSearchMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseOpenSearchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestOpenSearchGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range openSearchMetricIdentifiers {
+		meta, err := parseOpenSearchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockOpenSearchScaler := openSearchScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockOpenSearchScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestOpenSearchGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/jobs-*,retries/_search", r.URL.Path)
+		assert.Equal(t, "pending", r.URL.Query().Get("search_pipeline"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, openSearchTestQuery, string(body))
+
+		username, password, _ := r.BasicAuth()
+		if username != "admin" || password != "admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"took":3,"timed_out":false,"hits":{"total":{"value":12,"relation":"eq"},"hits":[]}}`))
+	}))
+	defer server.Close()
+
+	// nothing listens on the port of the closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := "http://" + listener.Addr().String()
+	listener.Close()
+
+	metadata := map[string]string{"addresses": unreachable + "," + server.URL, "index": "jobs-*;retries", "query": openSearchTestQuery, "searchPipeline": "pending", "valueLocation": "hits.total.value", "targetValue": "10", "activationTargetValue": "5"}
+	s, err := NewOpenSearchScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"username": "admin", "password": "admin"}})
+	require.NoError(t, err)
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "opensearch")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.Equal(t, int64(12), metrics[0].Value.Value())
+
+	s, err = NewOpenSearchScaler(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"username": "admin", "password": "wrong"}})
+	require.NoError(t, err)
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "opensearch")
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestOpenSearchSigV4(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIAOPENSEARCH/"), authorization)
+		assert.Contains(t, authorization, "/us-east-1/aoss/aws4_request")
+		assert.Contains(t, authorization, "x-amz-content-sha256")
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+		// sha256 of openSearchTestQuery
+		assert.Equal(t, "ea66146a4210878547caa17fd58db954b34f68d27e4a51a427f16c34213c80e3", r.Header.Get("X-Amz-Content-Sha256"))
+		_, _ = w.Write([]byte(`{"hits":{"total":{"value":3,"relation":"eq"}}}`))
+	}))
+	defer server.Close()
+
+	s, err := NewOpenSearchScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:  map[string]string{"addresses": server.URL, "index": "jobs", "query": openSearchTestQuery, "valueLocation": "hits.total.value", "targetValue": "10", "awsRegion": "us-east-1", "awsService": "aoss"},
+		AuthParams:       map[string]string{"awsAccessKeyID": "AKIAOPENSEARCH", "awsSecretAccessKey": "secret"},
+		TriggerUniqueKey: "opensearch-sigv4",
+	})
+	require.NoError(t, err)
+	defer s.Close(context.Background())
+
+	metrics, _, err := s.GetMetricsAndActivity(context.Background(), "opensearch")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), metrics[0].Value.Value())
+}
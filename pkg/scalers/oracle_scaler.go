@@ -0,0 +1,239 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	go_ora "github.com/sijms/go-ora/v2"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// oracleAutoLoginWallet is the wallet opened without password, oraclePKCS12Wallet the one opened with walletPassword
+	oracleAutoLoginWallet = "cwallet.sso"
+	oraclePKCS12Wallet    = "ewallet.p12"
+)
+
+type oracleScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *oracleMetadata
+	connection *sql.DB
+	// walletDir holds the wallet written from the trigger authentication, go-ora reading it from disk
+	walletDir string
+	logger    logr.Logger
+}
+
+type oracleMetadata struct {
+	triggerIndex int
+
+	ConnectionString string `keda:"name=connectionString, order=authParams;resolvedEnv, optional"`
+	Host             string `keda:"name=host,             order=triggerMetadata;authParams, optional"`
+	Port             int    `keda:"name=port,             order=triggerMetadata;authParams, optional, default=1521"`
+	ServiceName      string `keda:"name=serviceName,      order=triggerMetadata;authParams, optional"`
+	Username         string `keda:"name=username,         order=triggerMetadata;authParams;resolvedEnv, optional"`
+	Password         string `keda:"name=password,         order=authParams;resolvedEnv, optional"`
+	// ProxyClientName is the user the session is opened for when Username is a proxy user
+	ProxyClientName string `keda:"name=proxyClientName, order=triggerMetadata;authParams, optional"`
+
+	EnableTLS      bool   `keda:"name=enableTLS,      order=triggerMetadata;authParams, optional"`
+	UnsafeSsl      bool   `keda:"name=unsafeSsl,      order=triggerMetadata, optional"`
+	Wallet         string `keda:"name=wallet,         order=authParams;resolvedEnv, optional"`
+	WalletPassword string `keda:"name=walletPassword, order=authParams;resolvedEnv, optional"`
+
+	Query                      string  `keda:"name=query,                     order=triggerMetadata"`
+	TargetQueryValue           float64 `keda:"name=targetQueryValue,          order=triggerMetadata"`
+	ActivationTargetQueryValue float64 `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
+
+	// wallet is the decoded content of Wallet
+	wallet []byte
+}
+
+func (m *oracleMetadata) Validate() error {
+	if m.ConnectionString == "" {
+		if m.Host == "" {
+			return errors.New("no host given")
+		}
+		if m.ServiceName == "" {
+			return errors.New("no serviceName given")
+		}
+		if m.Username == "" {
+			return errors.New("no username given")
+		}
+	}
+	if m.TargetQueryValue <= 0 {
+		return errors.New("targetQueryValue must be greater than 0")
+	}
+
+	if m.Wallet != "" {
+		wallet, err := base64.StdEncoding.DecodeString(m.Wallet)
+		if err != nil {
+			return fmt.Errorf("wallet must be base64 encoded: %w", err)
+		}
+		m.wallet = wallet
+	} else if m.WalletPassword != "" {
+		return errors.New("walletPassword can't be used without wallet")
+	}
+	return nil
+}
+
+// NewOracleScaler creates a new Oracle Database scaler
+func NewOracleScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOracleMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing oracle metadata: %w", err)
+	}
+
+	s := &oracleScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "oracle_scaler"),
+	}
+
+	if len(meta.wallet) > 0 {
+		if s.walletDir, err = writeOracleWallet(meta); err != nil {
+			return nil, fmt.Errorf("error writing oracle wallet: %w", err)
+		}
+	}
+
+	s.connection, err = sql.Open("oracle", buildOracleConnectionString(meta, s.walletDir))
+	if err == nil {
+		err = s.connection.Ping()
+	}
+	if err != nil {
+		_ = s.Close(context.Background())
+		return nil, fmt.Errorf("error establishing oracle connection: %w", err)
+	}
+	return s, nil
+}
+
+func parseOracleMetadata(config *scalersconfig.ScalerConfig) (*oracleMetadata, error) {
+	meta := &oracleMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// writeOracleWallet writes the wallet to a new directory, as a PKCS#12 wallet when it is protected by a password
+func writeOracleWallet(meta *oracleMetadata) (string, error) {
+	dir, err := os.MkdirTemp("", "keda-oracle-wallet-")
+	if err != nil {
+		return "", err
+	}
+	name := oracleAutoLoginWallet
+	if meta.WalletPassword != "" {
+		name = oraclePKCS12Wallet
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), meta.wallet, 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// buildOracleConnectionString returns the go-ora URL of the database, the options
+// for TLS, the wallet and the proxy user being added to a given connection string
+func buildOracleConnectionString(meta *oracleMetadata, walletDir string) string {
+	options := map[string]string{}
+	if meta.EnableTLS {
+		options["SSL"] = "true"
+		options["SSL VERIFY"] = strconv.FormatBool(!meta.UnsafeSsl)
+	}
+	if walletDir != "" {
+		options["WALLET"] = walletDir
+		if meta.WalletPassword != "" {
+			options["WALLET PASSWORD"] = meta.WalletPassword
+		}
+	}
+	if meta.ProxyClientName != "" {
+		options["PROXY CLIENT NAME"] = meta.ProxyClientName
+	}
+
+	if meta.ConnectionString == "" {
+		return go_ora.BuildUrl(meta.Host, meta.Port, meta.ServiceName, meta.Username, meta.Password, options)
+	}
+
+	connStr := meta.ConnectionString
+	if len(options) == 0 {
+		return connStr
+	}
+	// the options are appended to the ones of the connection string
+	optionsURL := go_ora.BuildUrl("", 0, "", "", "", options)
+	separator := "?"
+	if strings.Contains(connStr, "?") {
+		separator = "&"
+	}
+	_, query, _ := strings.Cut(optionsURL, "?")
+	return connStr + separator + query
+}
+
+// Close closes the connection to the database and removes the wallet
+func (s *oracleScaler) Close(context.Context) error {
+	var err error
+	if s.connection != nil {
+		if err = s.connection.Close(); err != nil {
+			s.logger.Error(err, "Error closing oracle connection")
+		}
+	}
+	if s.walletDir != "" {
+		if removeErr := os.RemoveAll(s.walletDir); removeErr != nil {
+			s.logger.Error(removeErr, "Error removing oracle wallet")
+		}
+	}
+	return err
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *oracleScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := "oracle"
+	if s.metadata.ServiceName != "" {
+		metricName = fmt.Sprintf("oracle-%s", s.metadata.ServiceName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the result of the query
+func (s *oracleScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting oracle: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetQueryValue, nil
+}
+
+// getQueryResult returns the first column of the first row returned by the query, NULL being returned as 0
+func (s *oracleScaler) getQueryResult(ctx context.Context) (float64, error) {
+	var value sql.NullFloat64
+	if err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&value); err != nil {
+		return 0, err
+	}
+	return value.Float64, nil
+}
@@ -0,0 +1,216 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	pinotMetricRecordsLag      = "recordsLag"
+	pinotMetricAvailabilityLag = "availabilityLag"
+)
+
+// pinotDefaultTargetValues are the default targets of the metrics: the records the consuming segments haven't
+// consumed yet, and the milliseconds the consumed records are behind the records of the stream
+var pinotDefaultTargetValues = map[string]float64{
+	pinotMetricRecordsLag:      10000,
+	pinotMetricAvailabilityLag: 60000,
+}
+
+type pinotScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *pinotMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type pinotMetadata struct {
+	triggerIndex int
+
+	ControllerURL         string  `keda:"name=controllerURL,         order=triggerMetadata;resolvedEnv"`
+	TableName             string  `keda:"name=tableName,             order=triggerMetadata"`
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=recordsLag;availabilityLag, optional, default=recordsLag"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	UnsafeSsl             bool    `keda:"name=unsafeSsl,             order=triggerMetadata, optional"`
+
+	Username    string `keda:"name=username,    order=authParams;resolvedEnv, optional"`
+	Password    string `keda:"name=password,    order=authParams;resolvedEnv, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams;resolvedEnv, optional"`
+}
+
+// pinotConsumingSegmentsInfo maps the consuming segments of the table to the consuming info reported by each replica
+type pinotConsumingSegmentsInfo struct {
+	SegmentToConsumingInfoMap map[string][]struct {
+		ServerName          string `json:"serverName"`
+		PartitionOffsetInfo struct {
+			RecordsLagMap        map[string]string `json:"recordsLagMap"`
+			AvailabilityLagMsMap map[string]string `json:"availabilityLagMsMap"`
+		} `json:"partitionOffsetInfo"`
+	} `json:"_segmentToConsumingInfoMap"`
+}
+
+func (m *pinotMetadata) Validate() error {
+	if m.TargetValue == 0 {
+		m.TargetValue = pinotDefaultTargetValues[m.Metric]
+	}
+	if m.TargetValue < 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return errors.New("username and password can't be used with bearerToken")
+	}
+	m.ControllerURL = strings.TrimSuffix(m.ControllerURL, "/")
+	return nil
+}
+
+// NewPinotScaler creates a new pinotScaler
+func NewPinotScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parsePinotMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing pinot metadata: %w", err)
+	}
+
+	return &pinotScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "pinot_scaler"),
+	}, nil
+}
+
+func parsePinotMetadata(config *scalersconfig.ScalerConfig) (*pinotMetadata, error) {
+	meta := &pinotMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *pinotScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *pinotScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("pinot-%s-%s", s.metadata.TableName, s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the lag of the consuming segments of the table
+func (s *pinotScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	lag, err := s.getLag(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting pinot %s: %w", s.metadata.Metric, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, lag)
+	return []external_metrics.ExternalMetricValue{metric}, lag > s.metadata.ActivationTargetValue, nil
+}
+
+// getLag returns the records lag summed up over the consuming segments, or the highest availability lag. As
+// each replica of a segment reports its own lag, only the replica lagging the most is considered.
+func (s *pinotScaler) getLag(ctx context.Context) (float64, error) {
+	info, err := s.getConsumingSegmentsInfo(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var lag float64
+	for segment, replicas := range info.SegmentToConsumingInfoMap {
+		var segmentLag float64
+		for _, replica := range replicas {
+			lagMap := replica.PartitionOffsetInfo.RecordsLagMap
+			if s.metadata.Metric == pinotMetricAvailabilityLag {
+				lagMap = replica.PartitionOffsetInfo.AvailabilityLagMsMap
+			}
+			for partition, partitionLag := range lagMap {
+				// the lag is UNKNOWN when the server can't fetch the offsets of the stream
+				value, err := strconv.ParseFloat(partitionLag, 64)
+				if err != nil {
+					s.logger.V(1).Info("ignoring unknown lag", "segment", segment, "server", replica.ServerName, "partition", partition, "lag", partitionLag)
+					continue
+				}
+				segmentLag = math.Max(segmentLag, value)
+			}
+		}
+
+		if s.metadata.Metric == pinotMetricRecordsLag {
+			lag += segmentLag
+		} else {
+			lag = math.Max(lag, segmentLag)
+		}
+	}
+	return lag, nil
+}
+
+func (s *pinotScaler) getConsumingSegmentsInfo(ctx context.Context) (*pinotConsumingSegmentsInfo, error) {
+	path := fmt.Sprintf("/tables/%s/consumingSegmentsInfo", url.PathEscape(s.metadata.TableName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.ControllerURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+s.metadata.BearerToken)
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, path, string(body))
+	}
+
+	info := &pinotConsumingSegmentsInfo{}
+	if err := json.Unmarshal(body, info); err != nil {
+		return nil, fmt.Errorf("error parsing consuming segments info: %w", err)
+	}
+	return info, nil
+}
@@ -0,0 +1,121 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parsePinotMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type pinotMetricIdentifier struct {
+	metadataTestData *parsePinotMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testPinotMetadata = []parsePinotMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events"}, map[string]string{}, false, "records lag"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events_REALTIME", "metric": "availabilityLag", "targetValue": "30000", "activationTargetValue": "1000"}, map[string]string{"username": "admin", "password": "secret"}, false, "availability lag with basic auth"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events"}, map[string]string{"bearerToken": "token"}, false, "bearer token"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000"}, map[string]string{}, true, "missing tableName"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events", "metric": "queries"}, map[string]string{}, true, "unknown metric"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events", "targetValue": "-1"}, map[string]string{}, true, "negative targetValue"},
+	{map[string]string{"controllerURL": "http://pinot-controller:9000", "tableName": "events"}, map[string]string{"username": "admin", "password": "secret", "bearerToken": "token"}, true, "basic auth and bearer token"},
+}
+
+var pinotMetricIdentifiers = []pinotMetricIdentifier{
+	{&testPinotMetadata[1], 0, "s0-pinot-events-recordsLag"},
+	{&testPinotMetadata[2], 1, "s1-pinot-events_REALTIME-availabilityLag"},
+}
+
+func TestPinotParseMetadata(t *testing.T) {
+	for _, testData := range testPinotMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parsePinotMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestPinotGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range pinotMetricIdentifiers {
+		meta, err := parsePinotMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockPinotScaler := pinotScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockPinotScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestPinotGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Path != "/tables/events/consumingSegmentsInfo" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"error":"Table events_REALTIME not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"serversFailingToRespond":0,"serversUnparsableRespond":0,"_segmentToConsumingInfoMap":{
+			"events__0__12__20240101T0000Z":[
+				{"serverName":"Server_pinot-server-0","consumerState":"CONSUMING","partitionOffsetInfo":{"recordsLagMap":{"0":"120"},"availabilityLagMsMap":{"0":"1500"}}},
+				{"serverName":"Server_pinot-server-1","consumerState":"CONSUMING","partitionOffsetInfo":{"recordsLagMap":{"0":"150"},"availabilityLagMsMap":{"0":"2500"}}}
+			],
+			"events__1__12__20240101T0000Z":[
+				{"serverName":"Server_pinot-server-0","consumerState":"CONSUMING","partitionOffsetInfo":{"recordsLagMap":{"1":"50"},"availabilityLagMsMap":{"1":"800"}}},
+				{"serverName":"Server_pinot-server-1","consumerState":"NOT_CONSUMING","partitionOffsetInfo":{"recordsLagMap":{"1":"UNKNOWN"},"availabilityLagMsMap":{"1":"UNKNOWN"}}}
+			]
+		}}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isError       bool
+	}{
+		{"records lag summed up over the segments", map[string]string{"tableName": "events"}, 200, false},
+		{"highest availability lag", map[string]string{"tableName": "events", "metric": "availabilityLag"}, 2500, false},
+		{"unknown table", map[string]string{"tableName": "clicks"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["controllerURL"] = server.URL
+			s, err := NewPinotScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"bearerToken": "token"}})
+			assert.NoError(t, err)
+
+			metrics, _, err := s.GetMetricsAndActivity(context.Background(), "pinot")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+		})
+	}
+}
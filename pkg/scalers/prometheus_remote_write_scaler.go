@@ -0,0 +1,257 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/remotewrite"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	remoteWriteAggregationSum = "sum"
+	remoteWriteAggregationMax = "max"
+	remoteWriteAggregationMin = "min"
+	remoteWriteAggregationAvg = "avg"
+)
+
+// prometheusRemoteWriteScaler scales on the samples pushed with the Prometheus remote-write protocol to the
+// endpoint of the ScaledObject exposed by keda-operator, /api/v1/write/<namespace>/<name>
+type prometheusRemoteWriteScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *prometheusRemoteWriteMetadata
+	unsubscribe func()
+	logger      logr.Logger
+
+	mu     sync.Mutex
+	series map[string]remoteWriteSeries
+	// pushed is notified of the samples pushed, to report the activity as soon as they're received
+	pushed chan struct{}
+}
+
+type prometheusRemoteWriteMetadata struct {
+	// Token authenticates the writes of the agents as a bearer token
+	Token string `keda:"name=token,               order=authParams"`
+	// MetricName is the name of the series, the __name__ label
+	MetricName string `keda:"name=metricName,          order=triggerMetadata"`
+	// Labels are the values of labels the series must have
+	Labels map[string]string `keda:"name=labels,              order=triggerMetadata, optional"`
+	// Aggregation combines the latest samples of the series matching the name and the labels
+	Aggregation string `keda:"name=aggregation,         order=triggerMetadata, enum=sum;max;min;avg, default=sum"`
+	// StaleAfterSeconds forgets the series without sample pushed for this long, e.g. of finished jobs
+	StaleAfterSeconds   int     `keda:"name=staleAfterSeconds,   order=triggerMetadata, default=300"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+
+	path         string
+	triggerIndex int
+}
+
+// remoteWriteSeries is the latest sample of a series, with the time it was received
+type remoteWriteSeries struct {
+	value     float64
+	timestamp int64
+	received  time.Time
+}
+
+func (m *prometheusRemoteWriteMetadata) Validate() error {
+	if m.Token == "" {
+		return errors.New("token must not be empty")
+	}
+	if m.StaleAfterSeconds <= 0 {
+		return errors.New("staleAfterSeconds must be greater than 0")
+	}
+	return nil
+}
+
+// NewPrometheusRemoteWriteScaler creates a new prometheusRemoteWriteScaler, receiving the samples from the default
+// receiver of keda-operator
+func NewPrometheusRemoteWriteScaler(config *scalersconfig.ScalerConfig) (PushScaler, error) {
+	return newPrometheusRemoteWriteScaler(config, remotewrite.DefaultReceiver)
+}
+
+func newPrometheusRemoteWriteScaler(config *scalersconfig.ScalerConfig, receiver *remotewrite.Receiver) (*prometheusRemoteWriteScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parsePrometheusRemoteWriteMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing prometheus remote-write metadata: %w", err)
+	}
+
+	s := &prometheusRemoteWriteScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "prometheus_remote_write_scaler"),
+		series:     map[string]remoteWriteSeries{},
+		pushed:     make(chan struct{}, 1),
+	}
+	s.unsubscribe = receiver.Subscribe(meta.path, meta.Token, s.receive)
+	return s, nil
+}
+
+func parsePrometheusRemoteWriteMetadata(config *scalersconfig.ScalerConfig) (*prometheusRemoteWriteMetadata, error) {
+	meta := &prometheusRemoteWriteMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing prometheus remote-write metadata: %w", err)
+	}
+	if config.AsMetricSource {
+		meta.Threshold = 0
+	}
+	meta.path = remotewrite.Path(config.ScalableObjectNamespace, config.ScalableObjectName)
+	return meta, nil
+}
+
+// receive keeps the latest sample of the series matching the name and the labels
+func (s *prometheusRemoteWriteScaler) receive(series []remotewrite.TimeSeries) {
+	now := time.Now()
+	matched := false
+
+	s.mu.Lock()
+	for _, ts := range series {
+		if !s.matches(ts.Labels) || len(ts.Samples) == 0 {
+			continue
+		}
+		latest := ts.Samples[0]
+		for _, sample := range ts.Samples[1:] {
+			if sample.Timestamp >= latest.Timestamp {
+				latest = sample
+			}
+		}
+
+		key := seriesKey(ts.Labels)
+		if previous, ok := s.series[key]; ok && previous.timestamp > latest.Timestamp {
+			// samples may be retried out of order
+			continue
+		}
+		s.series[key] = remoteWriteSeries{value: latest.Value, timestamp: latest.Timestamp, received: now}
+		matched = true
+	}
+	s.mu.Unlock()
+
+	if matched {
+		select {
+		case s.pushed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *prometheusRemoteWriteScaler) matches(labels map[string]string) bool {
+	if labels["__name__"] != s.metadata.MetricName {
+		return false
+	}
+	for name, value := range s.metadata.Labels {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// seriesKey identifies a series by its labels
+func seriesKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(0xff)
+		b.WriteString(labels[name])
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+// getValue aggregates the latest samples of the series, forgetting the stale series
+func (s *prometheusRemoteWriteScaler) getValue() float64 {
+	staleBefore := time.Now().Add(-time.Duration(s.metadata.StaleAfterSeconds) * time.Second)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result float64
+	count := 0
+	for key, series := range s.series {
+		if series.received.Before(staleBefore) {
+			delete(s.series, key)
+			continue
+		}
+		switch {
+		case count == 0:
+			result = series.value
+		case s.metadata.Aggregation == remoteWriteAggregationMax:
+			result = max(result, series.value)
+		case s.metadata.Aggregation == remoteWriteAggregationMin:
+			result = min(result, series.value)
+		case s.metadata.Aggregation == remoteWriteAggregationSum, s.metadata.Aggregation == remoteWriteAggregationAvg:
+			result += series.value
+		}
+		count++
+	}
+	if count > 0 && s.metadata.Aggregation == remoteWriteAggregationAvg {
+		result /= float64(count)
+	}
+	return result
+}
+
+func (s *prometheusRemoteWriteScaler) Close(context.Context) error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *prometheusRemoteWriteScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("prometheus-remote-write-%s", s.metadata.MetricName))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the aggregation of the latest samples pushed
+func (s *prometheusRemoteWriteScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value := s.getValue()
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationThreshold, nil
+}
+
+// Run reports the activity each time samples of the series are pushed
+func (s *prometheusRemoteWriteScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.pushed:
+			select {
+			case active <- s.getValue() > s.metadata.ActivationThreshold:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/remotewrite"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parsePrometheusRemoteWriteMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type prometheusRemoteWriteMetricIdentifier struct {
+	metadataTestData *parsePrometheusRemoteWriteMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testPrometheusRemoteWriteMetadata = []parsePrometheusRemoteWriteMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"metricName": "queue_length", "threshold": "10"}, map[string]string{"token": "secret"}, false, "properly formed"},
+	{map[string]string{"metricName": "queue_length", "threshold": "10", "labels": "queue=orders,env=prod", "aggregation": "max", "staleAfterSeconds": "60", "activationThreshold": "2"}, map[string]string{"token": "secret"}, false, "all options"},
+	{map[string]string{"metricName": "queue_length", "threshold": "10"}, map[string]string{}, true, "missing token"},
+	{map[string]string{"threshold": "10"}, map[string]string{"token": "secret"}, true, "missing metricName"},
+	{map[string]string{"metricName": "queue_length"}, map[string]string{"token": "secret"}, true, "missing threshold"},
+	{map[string]string{"metricName": "queue_length", "threshold": "10", "aggregation": "median"}, map[string]string{"token": "secret"}, true, "unknown aggregation"},
+	{map[string]string{"metricName": "queue_length", "threshold": "10", "staleAfterSeconds": "0"}, map[string]string{"token": "secret"}, true, "staleAfterSeconds not positive"},
+}
+
+var prometheusRemoteWriteMetricIdentifiers = []prometheusRemoteWriteMetricIdentifier{
+	{&testPrometheusRemoteWriteMetadata[1], 0, "s0-prometheus-remote-write-queue_length"},
+	{&testPrometheusRemoteWriteMetadata[2], 1, "s1-prometheus-remote-write-queue_length"},
+}
+
+func TestPrometheusRemoteWriteParseMetadata(t *testing.T) {
+	for _, testData := range testPrometheusRemoteWriteMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parsePrometheusRemoteWriteMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata:         testData.metadata,
+				AuthParams:              testData.authParams,
+				ScalableObjectNamespace: "default",
+				ScalableObjectName:      "consumer",
+			})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrometheusRemoteWriteGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range prometheusRemoteWriteMetricIdentifiers {
+		meta, err := parsePrometheusRemoteWriteMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams:      testData.metadataTestData.authParams,
+			TriggerIndex:    testData.triggerIndex,
+		})
+		require.NoError(t, err)
+
+		scaler := prometheusRemoteWriteScaler{metadata: meta}
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func newTestPrometheusRemoteWriteScaler(t *testing.T, receiver *remotewrite.Receiver, metadata map[string]string) *prometheusRemoteWriteScaler {
+	scaler, err := newPrometheusRemoteWriteScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         metadata,
+		AuthParams:              map[string]string{"token": "secret"},
+		ScalableObjectNamespace: "default",
+		ScalableObjectName:      "consumer",
+	}, receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = scaler.Close(context.Background()) })
+	return scaler
+}
+
+func TestPrometheusRemoteWriteAggregation(t *testing.T) {
+	pushed := []remotewrite.TimeSeries{
+		{
+			Labels:  map[string]string{"__name__": "queue_length", "queue": "orders", "instance": "a"},
+			Samples: []remotewrite.Sample{{Value: 8, Timestamp: 2000}, {Value: 3, Timestamp: 1000}},
+		},
+		{
+			Labels:  map[string]string{"__name__": "queue_length", "queue": "orders", "instance": "b"},
+			Samples: []remotewrite.Sample{{Value: 4, Timestamp: 2000}},
+		},
+		{
+			Labels:  map[string]string{"__name__": "queue_length", "queue": "invoices", "instance": "a"},
+			Samples: []remotewrite.Sample{{Value: 100, Timestamp: 2000}},
+		},
+		{
+			Labels:  map[string]string{"__name__": "queue_age_seconds", "queue": "orders", "instance": "a"},
+			Samples: []remotewrite.Sample{{Value: 1000, Timestamp: 2000}},
+		},
+	}
+
+	tests := []struct {
+		aggregation string
+		expected    float64
+	}{
+		{"sum", 12},
+		{"max", 8},
+		{"min", 4},
+		{"avg", 6},
+	}
+	for _, test := range tests {
+		t.Run(test.aggregation, func(t *testing.T) {
+			receiver := remotewrite.NewReceiver()
+			scaler := newTestPrometheusRemoteWriteScaler(t, receiver, map[string]string{
+				"metricName": "queue_length", "threshold": "10", "labels": "queue=orders", "aggregation": test.aggregation,
+			})
+
+			scaler.receive(pushed)
+			// older samples retried by the agent are ignored
+			scaler.receive([]remotewrite.TimeSeries{{
+				Labels:  map[string]string{"__name__": "queue_length", "queue": "orders", "instance": "a"},
+				Samples: []remotewrite.Sample{{Value: 50, Timestamp: 1500}},
+			}})
+
+			metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-prometheus-remote-write-queue_length")
+			require.NoError(t, err)
+			assert.True(t, active)
+			assert.Equal(t, test.expected, metrics[0].Value.AsApproximateFloat64())
+		})
+	}
+}
+
+func TestPrometheusRemoteWriteStaleSeries(t *testing.T) {
+	scaler := newTestPrometheusRemoteWriteScaler(t, remotewrite.NewReceiver(), map[string]string{
+		"metricName": "queue_length", "threshold": "10", "staleAfterSeconds": "60",
+	})
+	scaler.series["finished"] = remoteWriteSeries{value: 20, timestamp: 1000, received: time.Now().Add(-2 * time.Minute)}
+	scaler.series["running"] = remoteWriteSeries{value: 5, timestamp: 1000, received: time.Now()}
+
+	metrics, active, err := scaler.GetMetricsAndActivity(context.Background(), "s0-prometheus-remote-write-queue_length")
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, float64(5), metrics[0].Value.AsApproximateFloat64())
+	assert.NotContains(t, scaler.series, "finished")
+
+	delete(scaler.series, "running")
+	metrics, active, err = scaler.GetMetricsAndActivity(context.Background(), "s0-prometheus-remote-write-queue_length")
+	require.NoError(t, err)
+	assert.False(t, active)
+	assert.Equal(t, float64(0), metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestPrometheusRemoteWriteRun(t *testing.T) {
+	receiver := remotewrite.NewReceiver()
+	scaler := newTestPrometheusRemoteWriteScaler(t, receiver, map[string]string{
+		"metricName": "queue_length", "threshold": "10", "activationThreshold": "2",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go scaler.Run(ctx, active)
+
+	scaler.receive([]remotewrite.TimeSeries{{
+		Labels:  map[string]string{"__name__": "queue_length"},
+		Samples: []remotewrite.Sample{{Value: 5, Timestamp: 1000}},
+	}})
+	assert.True(t, <-active)
+
+	scaler.receive([]remotewrite.TimeSeries{{
+		Labels:  map[string]string{"__name__": "queue_length"},
+		Samples: []remotewrite.Sample{{Value: 0, Timestamp: 2000}},
+	}})
+	assert.False(t, <-active)
+
+	cancel()
+	_, ok := <-active
+	assert.False(t, ok)
+}
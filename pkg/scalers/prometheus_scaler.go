@@ -9,9 +9,13 @@ import (
 	"math"
 	"net/http"
 	url_pkg "net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
@@ -25,6 +29,9 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+// prometheusQueryNameRegex matches the names of the queries, usable as variables of the formula
+var prometheusQueryNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 type prometheusScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *prometheusMetadata
@@ -36,12 +43,16 @@ type prometheusScaler struct {
 // default value is true/t, to ignore the null value return from prometheus
 // change to false/f if can not accept prometheus return null values
 // https://github.com/kedacore/keda/issues/3065
+// Queries - named queries in the name=query form, separated by semicolons, instead of the query. Their values
+// are combined by the formula, referencing the queries by name, e.g. backlog / rate
 type prometheusMetadata struct {
 	triggerIndex int
 
 	PrometheusAuth      *authentication.Config `keda:"optional"`
 	ServerAddress       string                 `keda:"name=serverAddress,       order=triggerMetadata"`
-	Query               string                 `keda:"name=query,               order=triggerMetadata"`
+	Query               string                 `keda:"name=query,               order=triggerMetadata, 				optional"`
+	Queries             []string               `keda:"name=queries,             order=triggerMetadata, 				optional, separator=;"`
+	Formula             string                 `keda:"name=formula,             order=triggerMetadata, 				optional"`
 	QueryParameters     map[string]string      `keda:"name=queryParameters,     order=triggerMetadata, 				optional"`
 	Threshold           float64                `keda:"name=threshold,           order=triggerMetadata"`
 	ActivationThreshold float64                `keda:"name=activationThreshold, order=triggerMetadata, 				optional"`
@@ -50,6 +61,51 @@ type prometheusMetadata struct {
 	IgnoreNullValues    bool                   `keda:"name=ignoreNullValues,    order=triggerMetadata, 				optional, default=true"`
 	UnsafeSSL           bool                   `keda:"name=unsafeSsl,           order=triggerMetadata, 				optional"`
 	AwsRegion           string                 `keda:"name=awsRegion, 			order=triggerMetadata;authParams, 	optional"`
+
+	namedQueries []prometheusNamedQuery
+	formula      *vm.Program
+}
+
+type prometheusNamedQuery struct {
+	name  string
+	query string
+}
+
+func (m *prometheusMetadata) Validate() error {
+	if (m.Query == "") == (len(m.Queries) == 0) {
+		return errors.New("exactly one of query or queries must be provided")
+	}
+	if len(m.Queries) == 0 {
+		if m.Formula != "" {
+			return errors.New("formula can only be used with queries")
+		}
+		return nil
+	}
+	if m.Formula == "" {
+		return errors.New("formula must be provided with queries")
+	}
+
+	m.namedQueries = nil
+	variables := make(map[string]float64, len(m.Queries))
+	for _, q := range m.Queries {
+		name, query, ok := strings.Cut(q, "=")
+		name, query = strings.TrimSpace(name), strings.TrimSpace(query)
+		if !ok || query == "" || !prometheusQueryNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid query %q, expected name=query with a name made of letters, digits and underscores", q)
+		}
+		if _, found := variables[name]; found {
+			return fmt.Errorf("query %s is declared more than once", name)
+		}
+		variables[name] = 0
+		m.namedQueries = append(m.namedQueries, prometheusNamedQuery{name: name, query: query})
+	}
+
+	program, err := expr.Compile(m.Formula, expr.Env(variables), expr.AsFloat64())
+	if err != nil {
+		return fmt.Errorf("error compiling formula: %w", err)
+	}
+	m.formula = program
+	return nil
 }
 
 type promQueryResult struct {
@@ -183,8 +239,45 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 }
 
 func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
+	if s.metadata.formula == nil {
+		return s.executeQuery(ctx, s.metadata.Query)
+	}
+
+	values := make(map[string]float64, len(s.metadata.namedQueries))
+	for _, q := range s.metadata.namedQueries {
+		v, err := s.executeQuery(ctx, q.query)
+		if err != nil {
+			return -1, fmt.Errorf("error executing query %s: %w", q.name, err)
+		}
+		values[q.name] = v
+	}
+	return s.evaluateFormula(values)
+}
+
+// evaluateFormula combines the values of the named queries with the formula
+func (s *prometheusScaler) evaluateFormula(values map[string]float64) (float64, error) {
+	out, err := expr.Run(s.metadata.formula, values)
+	if err != nil {
+		return -1, fmt.Errorf("error evaluating formula: %w", err)
+	}
+	v, ok := out.(float64)
+	if !ok {
+		return -1, fmt.Errorf("formula returned %v instead of a number", out)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		// e.g. a rate of zero in the divisor
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("formula returned %f", v)
+	}
+	return v, nil
+}
+
+// executeQuery runs the query with the server, the parameters and the authentication of the scaler
+func (s *prometheusScaler) executeQuery(ctx context.Context, query string) (float64, error) {
 	t := time.Now().UTC().Format(time.RFC3339)
-	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
+	queryEscaped := url_pkg.QueryEscape(query)
 	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, t)
 
 	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
@@ -250,7 +343,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		}
 		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the result is empty")
 	} else if len(result.Data.Result) > 1 {
-		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.Query)
+		return -1, fmt.Errorf("prometheus query %s returned multiple elements", query)
 	}
 
 	valueLen := len(result.Data.Result[0].Value)
@@ -260,7 +353,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		}
 		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the value list is empty")
 	} else if valueLen < 2 {
-		return -1, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.Query)
+		return -1, fmt.Errorf("prometheus query %s didn't return enough values", query)
 	}
 
 	val := result.Data.Result[0].Value[1]
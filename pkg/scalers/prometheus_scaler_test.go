@@ -64,6 +64,20 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2=value2"}, false},
 	// queryParameters with wrong format
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2"}, true},
+	// named queries with formula
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "queries": `backlog=sum(queue_depth{queue="orders"}); rate=sum(rate(processed_total{queue="orders"}[5m]))`, "formula": "backlog / rate"}, false},
+	// both query and queries
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "queries": "a=up", "formula": "a"}, true},
+	// queries without formula
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "queries": "a=up"}, true},
+	// formula without queries
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "formula": "up * 2"}, true},
+	// query without name
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "queries": "sum(up)", "formula": "a"}, true},
+	// duplicated query name
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "queries": "a=up; a=up", "formula": "a"}, true},
+	// formula referencing an unknown query
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "queries": "a=up", "formula": "a / b"}, true},
 }
 
 var prometheusMetricIdentifiers = []prometheusMetricIdentifier{
@@ -544,3 +558,47 @@ IisErx3ap2o99Zn+Yotv/TGZkS+lfMLdbcOBr8a57Q==
 		})
 	}
 }
+
+func TestPrometheusScalerExecutePromQueryFormula(t *testing.T) {
+	values := map[string]string{
+		`sum(queue_depth{queue="orders"})`:               "120",
+		`sum(rate(processed_total{queue="orders"}[5m]))`: "4",
+		`sum(rate(processed_total{queue="empty"}[5m]))`:  "0",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		value, ok := values[request.URL.Query().Get("query")]
+		if !ok {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(writer, `{"data":{"result":[{"value":[1, "%s"]}]}}`, value)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		queries          string
+		ignoreNullValues string
+		expectedValue    float64
+		isError          bool
+	}{
+		{`backlog=sum(queue_depth{queue="orders"}); rate=sum(rate(processed_total{queue="orders"}[5m]))`, "true", 30, false},
+		{`backlog=sum(queue_depth{queue="orders"}); rate=sum(rate(processed_total{queue="empty"}[5m]))`, "true", 0, false},
+		{`backlog=sum(queue_depth{queue="orders"}); rate=sum(rate(processed_total{queue="empty"}[5m]))`, "false", -1, true},
+		{`backlog=sum(queue_depth{queue="orders"}); rate=sum(rate(unknown[5m]))`, "true", -1, true},
+	}
+	for _, test := range tests {
+		meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+			"serverAddress": server.URL, "threshold": "10", "queries": test.queries, "formula": "backlog / rate", "ignoreNullValues": test.ignoreNullValues,
+		}})
+		require.NoError(t, err)
+		scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.ExecutePromQuery(context.Background())
+		if test.isError {
+			assert.Error(t, err, test.queries)
+		} else {
+			assert.NoError(t, err, test.queries)
+		}
+		assert.Equal(t, test.expectedValue, value, test.queries)
+	}
+}
@@ -34,6 +34,11 @@ type pulsarMetadata struct {
 	subscription                  string
 	msgBacklogThreshold           int64
 	activationMsgBacklogThreshold int64
+	// metric is the backlog of the subscription, including the delayed messages or not
+	metric string
+	// limitToPartitionsWithBacklog caps the scaling of the exclusive and failover subscriptions of a partitioned
+	// topic, which have a single active consumer by partition, to the partitions with a backlog
+	limitToPartitionsWithBacklog bool
 
 	pulsarAuth *authentication.AuthMeta
 
@@ -48,31 +53,40 @@ const (
 	enable                     = "enable"
 	stringTrue                 = "true"
 	pulsarAuthModeHeader       = "X-Pulsar-Auth-Method-Name"
+
+	pulsarMetricMsgBacklog          = "msgBacklog"
+	pulsarMetricMsgBacklogNoDelayed = "msgBacklogNoDelayed"
+
+	pulsarSubscriptionTypeShared    = "Shared"
+	pulsarSubscriptionTypeKeyShared = "Key_Shared"
 )
 
 type pulsarSubscription struct {
-	Msgrateout                       float64       `json:"msgRateOut"`
-	Msgthroughputout                 float64       `json:"msgThroughputOut"`
-	Bytesoutcounter                  int           `json:"bytesOutCounter"`
-	Msgoutcounter                    int           `json:"msgOutCounter"`
-	Msgrateredeliver                 float64       `json:"msgRateRedeliver"`
-	Chuckedmessagerate               int           `json:"chuckedMessageRate"`
-	Msgbacklog                       int64         `json:"msgBacklog"`
-	Msgbacklognodelayed              int           `json:"msgBacklogNoDelayed"`
-	Blockedsubscriptiononunackedmsgs bool          `json:"blockedSubscriptionOnUnackedMsgs"`
-	Msgdelayed                       int           `json:"msgDelayed"`
-	Unackedmessages                  int           `json:"unackedMessages"`
-	Type                             string        `json:"type"`
-	Msgrateexpired                   float64       `json:"msgRateExpired"`
-	Lastexpiretimestamp              int           `json:"lastExpireTimestamp"`
-	Lastconsumedflowtimestamp        int64         `json:"lastConsumedFlowTimestamp"`
-	Lastconsumedtimestamp            int           `json:"lastConsumedTimestamp"`
-	Lastackedtimestamp               int           `json:"lastAckedTimestamp"`
-	Consumers                        []interface{} `json:"consumers"`
-	Isdurable                        bool          `json:"isDurable"`
-	Isreplicated                     bool          `json:"isReplicated"`
-	Consumersaftermarkdeleteposition struct {
-	} `json:"consumersAfterMarkDeletePosition"`
+	Msgrateout                       float64           `json:"msgRateOut"`
+	Msgthroughputout                 float64           `json:"msgThroughputOut"`
+	Bytesoutcounter                  int               `json:"bytesOutCounter"`
+	Msgoutcounter                    int               `json:"msgOutCounter"`
+	Msgrateredeliver                 float64           `json:"msgRateRedeliver"`
+	Chuckedmessagerate               int               `json:"chuckedMessageRate"`
+	Msgbacklog                       int64             `json:"msgBacklog"`
+	Msgbacklognodelayed              int64             `json:"msgBacklogNoDelayed"`
+	Blockedsubscriptiononunackedmsgs bool              `json:"blockedSubscriptionOnUnackedMsgs"`
+	Msgdelayed                       int               `json:"msgDelayed"`
+	Unackedmessages                  int               `json:"unackedMessages"`
+	Type                             string            `json:"type"`
+	Msgrateexpired                   float64           `json:"msgRateExpired"`
+	Lastexpiretimestamp              int               `json:"lastExpireTimestamp"`
+	Lastconsumedflowtimestamp        int64             `json:"lastConsumedFlowTimestamp"`
+	Lastconsumedtimestamp            int               `json:"lastConsumedTimestamp"`
+	Lastackedtimestamp               int               `json:"lastAckedTimestamp"`
+	Consumers                        []pulsarConsumer  `json:"consumers"`
+	Isdurable                        bool              `json:"isDurable"`
+	Isreplicated                     bool              `json:"isReplicated"`
+	Consumersaftermarkdeleteposition map[string]string `json:"consumersAfterMarkDeletePosition"`
+}
+
+type pulsarConsumer struct {
+	Consumername string `json:"consumerName"`
 }
 
 type pulsarStats struct {
@@ -93,6 +107,8 @@ type pulsarStats struct {
 	Replication       struct {
 	} `json:"replication"`
 	Deduplicationstatus string `json:"deduplicationStatus"`
+	// Partitions are the stats of each partition of a partitioned topic
+	Partitions map[string]pulsarStats `json:"partitions"`
 }
 
 // NewPulsarScaler creates a new PulsarScaler
@@ -153,7 +169,9 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, _ logr.Logger) (pul
 	}
 
 	topic := strings.ReplaceAll(meta.topic, "persistent://", "")
-	if config.TriggerMetadata["isPartitionedTopic"] == stringTrue {
+	// the stats of the partitions are part of the partitioned stats
+	isPartitionedTopic := config.TriggerMetadata["isPartitionedTopic"] == stringTrue
+	if isPartitionedTopic {
 		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/partitioned-stats"
 	} else {
 		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/stats"
@@ -189,6 +207,25 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, _ logr.Logger) (pul
 		meta.msgBacklogThreshold = t
 	}
 
+	meta.metric = pulsarMetricMsgBacklog
+	if val, ok := config.TriggerMetadata["metric"]; ok && val != "" {
+		if val != pulsarMetricMsgBacklog && val != pulsarMetricMsgBacklogNoDelayed {
+			return meta, fmt.Errorf("metric must be %s or %s", pulsarMetricMsgBacklog, pulsarMetricMsgBacklogNoDelayed)
+		}
+		meta.metric = val
+	}
+
+	if val, ok := config.TriggerMetadata["limitToPartitionsWithBacklog"]; ok {
+		limitToPartitionsWithBacklog, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing limitToPartitionsWithBacklog: %w", err)
+		}
+		if limitToPartitionsWithBacklog && !isPartitionedTopic {
+			return meta, errors.New("limitToPartitionsWithBacklog requires isPartitionedTopic")
+		}
+		meta.limitToPartitionsWithBacklog = limitToPartitionsWithBacklog
+	}
+
 	// For backwards compatibility, we need to map "tls: enable" to
 	if tls, ok := config.TriggerMetadata["tls"]; ok {
 		if tls == enable && (config.AuthParams["cert"] != "" || config.AuthParams["key"] != "") {
@@ -292,8 +329,60 @@ func (s *pulsarScaler) getMsgBackLog(ctx context.Context) (int64, bool, error) {
 	}
 
 	v, found := stats.Subscriptions[s.metadata.subscription]
+	if !found {
+		return 0, false, nil
+	}
 
-	return v.Msgbacklog, found, nil
+	return s.limitMsgBacklog(stats, v), true, nil
+}
+
+// getSubscriptionBacklog returns the backlog of the subscription, without the delayed messages for them not to
+// scale out the consumers before they can be delivered when the metric is msgBacklogNoDelayed
+func (s *pulsarScaler) getSubscriptionBacklog(subscription pulsarSubscription) int64 {
+	if s.metadata.metric == pulsarMetricMsgBacklogNoDelayed {
+		return subscription.Msgbacklognodelayed
+	}
+	return subscription.Msgbacklog
+}
+
+// limitMsgBacklog returns the backlog of the subscription, limited to what its type allows more consumers to
+// consume. The consumers that join a Key_Shared subscription only receive the messages of their keys once the
+// messages of these keys delivered before they joined are acknowledged, so the scaling is held at the current
+// consumers while they drain. The exclusive and failover subscriptions have a single active consumer by partition.
+func (s *pulsarScaler) limitMsgBacklog(stats *pulsarStats, subscription pulsarSubscription) int64 {
+	backlog := s.getSubscriptionBacklog(subscription)
+
+	switch subscription.Type {
+	case pulsarSubscriptionTypeKeyShared:
+		draining := len(subscription.Consumersaftermarkdeleteposition) > 0
+		consumers := make(map[string]bool)
+		for _, consumer := range subscription.Consumers {
+			consumers[consumer.Consumername] = true
+		}
+		for _, partition := range stats.Partitions {
+			if partitionSubscription, found := partition.Subscriptions[s.metadata.subscription]; found {
+				draining = draining || len(partitionSubscription.Consumersaftermarkdeleteposition) > 0
+			}
+		}
+		if draining && len(consumers) > 0 {
+			s.logger.V(1).Info("Key_Shared subscription is draining the messages of its recently joined consumers", "consumers", len(consumers))
+			return min(backlog, int64(len(consumers))*s.metadata.msgBacklogThreshold)
+		}
+	case pulsarSubscriptionTypeShared:
+	default:
+		if s.metadata.limitToPartitionsWithBacklog {
+			var partitionsWithBacklog int64
+			for name, partition := range stats.Partitions {
+				partitionBacklog := s.getSubscriptionBacklog(partition.Subscriptions[s.metadata.subscription])
+				s.logger.V(1).Info("Partition backlog", "partition", name, "backlog", partitionBacklog)
+				if partitionBacklog > 0 {
+					partitionsWithBacklog++
+				}
+			}
+			return min(backlog, partitionsWithBacklog*s.metadata.msgBacklogThreshold)
+		}
+	}
+	return backlog
 }
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
@@ -80,6 +80,14 @@ var parsePulsarMetadataTestDataset = []parsePulsarMetadataTestData{
 
 	// tls
 	{map[string]string{"adminURL": "https://localhost:8443", "tls": "enable", "cert": "certdata", "key": "keydata", "ca": "cadata", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "https://localhost:8443", "persistent://public/default/my-topic", "sub1"},
+	// backlog without the delayed messages
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "metric": "msgBacklogNoDelayed"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// failure, unknown metric
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "metric": "msgDelayed"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// limit to the partitions with a backlog
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "limitToPartitionsWithBacklog": "true"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// failure, limit to the partitions with a backlog of a topic that isn't partitioned
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "limitToPartitionsWithBacklog": "true"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
 }
 
 var parsePulsarMetadataTestAuthTLSDataset = []parsePulsarAuthParamsTestData{
@@ -414,3 +422,41 @@ func TestPulsarGetMetric(t *testing.T) {
 		fmt.Printf("%+v\n", metric)
 	}
 }
+
+func TestPulsarLimitMsgBacklog(t *testing.T) {
+	partitions := map[string]pulsarStats{
+		"persistent://public/default/my-topic-partition-0": {Subscriptions: map[string]pulsarSubscription{"sub1": {Msgbacklog: 90, Msgbacklognodelayed: 10}}},
+		"persistent://public/default/my-topic-partition-1": {Subscriptions: map[string]pulsarSubscription{"sub1": {Msgbacklog: 10, Msgbacklognodelayed: 0}}},
+		"persistent://public/default/my-topic-partition-2": {Subscriptions: map[string]pulsarSubscription{"sub1": {}}},
+	}
+	consumers := []pulsarConsumer{{Consumername: "c1"}, {Consumername: "c2"}, {Consumername: "c1"}}
+
+	testCases := []struct {
+		comment                      string
+		metric                       string
+		limitToPartitionsWithBacklog bool
+		subscription                 pulsarSubscription
+		backlog                      int64
+	}{
+		{"backlog", pulsarMetricMsgBacklog, false, pulsarSubscription{Type: "Exclusive", Msgbacklog: 100, Msgbacklognodelayed: 10}, 100},
+		{"backlog without the delayed messages", pulsarMetricMsgBacklogNoDelayed, false, pulsarSubscription{Type: "Exclusive", Msgbacklog: 100, Msgbacklognodelayed: 10}, 10},
+		{"failover limited to the partitions with a backlog", pulsarMetricMsgBacklog, true, pulsarSubscription{Type: "Failover", Msgbacklog: 100}, 20},
+		{"failover limited to the partitions with a backlog not delayed", pulsarMetricMsgBacklogNoDelayed, true, pulsarSubscription{Type: "Failover", Msgbacklog: 100, Msgbacklognodelayed: 10}, 10},
+		{"shared isn't limited to the partitions", pulsarMetricMsgBacklog, true, pulsarSubscription{Type: "Shared", Msgbacklog: 100}, 100},
+		{"key shared isn't limited to the partitions", pulsarMetricMsgBacklog, true, pulsarSubscription{Type: "Key_Shared", Msgbacklog: 100, Consumers: consumers}, 100},
+		{"key shared draining is held at the consumers", pulsarMetricMsgBacklog, false, pulsarSubscription{Type: "Key_Shared", Msgbacklog: 100, Consumers: consumers, Consumersaftermarkdeleteposition: map[string]string{"c2": "12:3"}}, 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.comment, func(t *testing.T) {
+			scaler := pulsarScaler{
+				metadata: pulsarMetadata{subscription: "sub1", msgBacklogThreshold: 10, metric: tc.metric, limitToPartitionsWithBacklog: tc.limitToPartitionsWithBacklog},
+				logger:   logr.Discard(),
+			}
+			backlog := scaler.limitMsgBacklog(&pulsarStats{Partitions: partitions}, tc.subscription)
+			if backlog != tc.backlog {
+				t.Errorf("Expected backlog %d but got %d", tc.backlog, backlog)
+			}
+		})
+	}
+}
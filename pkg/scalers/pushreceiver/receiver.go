@@ -0,0 +1,125 @@
+package pushreceiver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Decoder decodes the body of the request, the body being limited to the maximum request size of the receiver
+type Decoder[T any] func(req *http.Request) (T, error)
+
+// StatusError is an error of a decoder responded with its status code instead of 400 Bad Request
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Receiver receives the requests posted to the paths of the subscriptions, and passes what they carry to the
+// subscriptions of the path of the request whose bearer token authenticates the request
+type Receiver[T any] struct {
+	maxRequestSize int64
+	status         int
+	decode         Decoder[T]
+
+	mu            sync.RWMutex
+	subscriptions map[string][]*subscription[T]
+}
+
+type subscription[T any] struct {
+	token   string
+	handler func(T)
+}
+
+// NewReceiver returns a receiver without subscriptions, which decodes the requests of at most maxRequestSize
+// bytes with the decoder and responds with the status once they're passed to the subscriptions
+func NewReceiver[T any](maxRequestSize int64, status int, decode Decoder[T]) *Receiver[T] {
+	return &Receiver[T]{
+		maxRequestSize: maxRequestSize,
+		status:         status,
+		decode:         decode,
+		subscriptions:  map[string][]*subscription[T]{},
+	}
+}
+
+// Subscribe passes what's posted to the path with the bearer token to the handler, until unsubscribed. The requests
+// are never authenticated by an empty token
+func (r *Receiver[T]) Subscribe(path, token string, handler func(T)) (unsubscribe func()) {
+	s := &subscription[T]{token: token, handler: handler}
+
+	r.mu.Lock()
+	r.subscriptions[path] = append(r.subscriptions[path], s)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		subscriptions := r.subscriptions[path]
+		for i := range subscriptions {
+			if subscriptions[i] == s {
+				subscriptions = append(subscriptions[:i], subscriptions[i+1:]...)
+				break
+			}
+		}
+		if len(subscriptions) == 0 {
+			delete(r.subscriptions, path)
+		} else {
+			r.subscriptions[path] = subscriptions
+		}
+	}
+}
+
+func (r *Receiver[T]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.mu.RLock()
+	var handlers []func(T)
+	found := false
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	for _, s := range r.subscriptions[req.URL.Path] {
+		found = true
+		if s.token != "" && subtle.ConstantTimeCompare([]byte(s.token), []byte(token)) == 1 {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	r.mu.RUnlock()
+
+	switch {
+	case !found:
+		http.NotFound(w, req)
+		return
+	case len(handlers) == 0:
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, r.maxRequestSize)
+	v, err := r.decode(req)
+	if err != nil {
+		status := http.StatusBadRequest
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			status = statusErr.StatusCode
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	for _, handler := range handlers {
+		handler(v)
+	}
+	w.WriteHeader(r.status)
+}
@@ -0,0 +1,72 @@
+package pushreceiver
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeText(req *http.Request) (string, error) {
+	b, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	switch string(b) {
+	case "":
+		return "", errors.New("empty body")
+	case "unsupported":
+		return "", &StatusError{StatusCode: http.StatusUnsupportedMediaType, Err: errors.New("unsupported body")}
+	}
+	return string(b), nil
+}
+
+func TestReceiver(t *testing.T) {
+	receiver := NewReceiver(16, http.StatusAccepted, decodeText)
+	var received, unauthenticated []string
+	unsubscribe := receiver.Subscribe("/orders", "secret", func(s string) { received = append(received, s) })
+	receiver.Subscribe("/invoices", "", func(s string) { unauthenticated = append(unauthenticated, s) })
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+	}{
+		{"accepted", http.MethodPost, "/orders", "secret", "created", http.StatusAccepted},
+		{"wrong token", http.MethodPost, "/orders", "wrong", "created", http.StatusUnauthorized},
+		{"without token", http.MethodPost, "/orders", "", "created", http.StatusUnauthorized},
+		{"empty token of the subscription", http.MethodPost, "/invoices", "", "created", http.StatusUnauthorized},
+		{"unknown path", http.MethodPost, "/payments", "secret", "created", http.StatusNotFound},
+		{"not a post", http.MethodGet, "/orders", "secret", "", http.StatusMethodNotAllowed},
+		{"decoding error", http.MethodPost, "/orders", "secret", "", http.StatusBadRequest},
+		{"decoding error with status", http.MethodPost, "/orders", "secret", "unsupported", http.StatusUnsupportedMediaType},
+		{"too large", http.MethodPost, "/orders", "secret", strings.Repeat("a", 17), http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, req)
+			assert.Equal(t, test.status, w.Code)
+		})
+	}
+	assert.Equal(t, []string{"created"}, received)
+	assert.Empty(t, unauthenticated)
+
+	unsubscribe()
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("created"))
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,75 @@
+package pushreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ListenAndServe serves the handler over HTTPS on the address until the context is done, with the tls.crt and
+// tls.key of the certificate directory, read again when they're rotated
+func ListenAndServe(ctx context.Context, address, certDir string, handler http.Handler) error {
+	loader := &certificateLoader{certFile: filepath.Join(certDir, "tls.crt"), keyFile: filepath.Join(certDir, "tls.key")}
+	if _, err := loader.getCertificate(nil); err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: loader.getCertificate,
+		},
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// certificateLoader loads the certificate again when its file is modified
+type certificateLoader struct {
+	certFile, keyFile string
+
+	mu          sync.Mutex
+	certificate *tls.Certificate
+	modTime     time.Time
+}
+
+func (l *certificateLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	info, err := os.Stat(l.certFile)
+	if err == nil && l.certificate != nil && info.ModTime().Equal(l.modTime) {
+		return l.certificate, nil
+	}
+	if err == nil {
+		var certificate tls.Certificate
+		certificate, err = tls.LoadX509KeyPair(l.certFile, l.keyFile)
+		if err == nil {
+			l.certificate = &certificate
+			l.modTime = info.ModTime()
+		}
+	}
+	if l.certificate == nil {
+		return nil, fmt.Errorf("error loading the certificate: %w", err)
+	}
+	// keep serving the previous certificate while the files are being replaced
+	return l.certificate, nil
+}
@@ -0,0 +1,331 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// keys of the commands of the stream protocol, the key of a response being the one of its request with the highest bit set
+const (
+	commandQueryOffset      = 0x000b
+	commandPeerProperties   = 0x0011
+	commandSaslHandshake    = 0x0012
+	commandSaslAuthenticate = 0x0013
+	commandTune             = 0x0014
+	commandOpen             = 0x0015
+	commandClose            = 0x0016
+	commandStreamStats      = 0x001c
+	responseFlag            = 0x8000
+)
+
+const (
+	protocolVersion = 1
+	saslMechanism   = "PLAIN"
+	// maxFrameSize protects against reading garbage as the size of a frame
+	maxFrameSize = 1024 * 1024
+
+	responseOK       = 1
+	responseNoOffset = 19
+)
+
+// responseErrors are the meaning of the response codes which can be returned by the commands sent by the client
+var responseErrors = map[uint16]string{
+	2:  "stream does not exist",
+	6:  "stream not available",
+	7:  "sasl mechanism not supported",
+	8:  "authentication failure",
+	9:  "sasl error",
+	12: "virtual host access failure",
+	15: "internal error",
+	16: "access refused",
+	17: "precondition failed",
+}
+
+// StreamConfig contains the information required to connect to a RabbitMQ node over the stream protocol.
+type StreamConfig struct {
+	// Address is the host:port of the stream listener
+	Address  string
+	Vhost    string
+	Username string
+	Password string
+	// TLSConfig enables TLS when it isn't nil
+	TLSConfig *tls.Config
+	Timeout   time.Duration
+}
+
+// StreamOffsets are the offsets of a stream and the one stored by a consumer.
+type StreamOffsets struct {
+	// FirstChunkID and CommittedChunkID are the offsets of the first message of the first chunk of the stream
+	// and of its last committed chunk, the offset of the last message being unknown
+	FirstChunkID     int64
+	CommittedChunkID int64
+	// ConsumerOffset is the offset stored by the consumer, nil when it hasn't stored any offset yet
+	ConsumerOffset *int64
+}
+
+// Lag returns the number of messages the consumer still has to process. As only the offset of the first message
+// of the last committed chunk is known, the messages of that chunk are left out, the lag being a lower bound.
+func (o *StreamOffsets) Lag() int64 {
+	if o.CommittedChunkID < 0 {
+		// the stream is empty
+		return 0
+	}
+	if o.ConsumerOffset == nil {
+		// the consumer hasn't stored any offset, all the messages of the stream are still to be processed
+		return o.CommittedChunkID - max(o.FirstChunkID, 0) + 1
+	}
+	return max(o.CommittedChunkID-*o.ConsumerOffset, 0)
+}
+
+type streamClient struct {
+	conn          net.Conn
+	r             *bufio.Reader
+	correlationID uint32
+}
+
+// QueryStreamOffsets returns the offsets of the stream and the offset stored by the consumer.
+func QueryStreamOffsets(ctx context.Context, config *StreamConfig, stream, consumer string) (*StreamOffsets, error) {
+	c, err := dialStream(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	stats, err := c.streamStats(stream)
+	if err != nil {
+		return nil, fmt.Errorf("error getting stats of stream %s: %w", stream, err)
+	}
+	offsets := &StreamOffsets{FirstChunkID: stats["first_chunk_id"], CommittedChunkID: stats["committed_chunk_id"]}
+
+	offset, found, err := c.queryOffset(stream, consumer)
+	if err != nil {
+		return nil, fmt.Errorf("error querying offset of consumer %s: %w", consumer, err)
+	}
+	if found {
+		offsets.ConsumerOffset = &offset
+	}
+	return offsets, nil
+}
+
+func dialStream(ctx context.Context, config *StreamConfig) (*streamClient, error) {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	var conn net.Conn
+	var err error
+	if config.TLSConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: config.TLSConfig}).DialContext(ctx, "tcp", config.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", config.Address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", config.Address, err)
+	}
+	var deadline time.Time
+	if config.Timeout > 0 {
+		deadline = time.Now().Add(config.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c := &streamClient{conn: conn, r: bufio.NewReader(conn)}
+	if err := c.open(config); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// open negotiates the connection: peer properties, authentication, tuning and opening of the virtual host
+func (c *streamClient) open(config *StreamConfig) error {
+	if _, err := c.request(commandPeerProperties, appendInt32(nil, 0)); err != nil {
+		return fmt.Errorf("error exchanging peer properties: %w", err)
+	}
+	if _, err := c.request(commandSaslHandshake, nil); err != nil {
+		return fmt.Errorf("error during sasl handshake: %w", err)
+	}
+
+	credentials := []byte("\x00" + config.Username + "\x00" + config.Password)
+	data := appendString(nil, saslMechanism)
+	data = appendInt32(data, int32(len(credentials)))
+	if _, err := c.request(commandSaslAuthenticate, append(data, credentials...)); err != nil {
+		return fmt.Errorf("error authenticating: %w", err)
+	}
+
+	// the server proposes its frame max and heartbeat, they are accepted as they are
+	key, payload, err := c.readFrame()
+	if err != nil {
+		return err
+	}
+	if key != commandTune || len(payload) != 8 {
+		return fmt.Errorf("unexpected command %#x instead of tune", key)
+	}
+	if err := c.writeFrame(commandTune, payload); err != nil {
+		return err
+	}
+
+	if _, err := c.request(commandOpen, appendString(nil, config.Vhost)); err != nil {
+		return fmt.Errorf("error opening virtual host %s: %w", config.Vhost, err)
+	}
+	return nil
+}
+
+func (c *streamClient) streamStats(stream string) (map[string]int64, error) {
+	payload, err := c.request(commandStreamStats, appendString(nil, stream))
+	if err != nil {
+		return nil, err
+	}
+	r := &reader{data: payload}
+	count := r.int32()
+	stats := make(map[string]int64, max(count, 0))
+	for i := int32(0); i < count && r.err == nil; i++ {
+		key := r.string()
+		stats[key] = int64(r.uint64())
+	}
+	return stats, r.err
+}
+
+func (c *streamClient) queryOffset(stream, consumer string) (int64, bool, error) {
+	payload, err := c.request(commandQueryOffset, appendString(appendString(nil, consumer), stream))
+	var responseErr *responseError
+	if errors.As(err, &responseErr) && responseErr.code == responseNoOffset {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	r := &reader{data: payload}
+	offset := int64(r.uint64())
+	return offset, r.err == nil, r.err
+}
+
+func (c *streamClient) close() {
+	data := binary.BigEndian.AppendUint16(nil, responseOK)
+	_, _ = c.request(commandClose, appendString(data, "OK"))
+	c.conn.Close()
+}
+
+// request sends the command and returns the payload of its response following the response code
+func (c *streamClient) request(key uint16, data []byte) ([]byte, error) {
+	c.correlationID++
+	correlationID := c.correlationID
+	if err := c.writeFrame(key, append(binary.BigEndian.AppendUint32(nil, correlationID), data...)); err != nil {
+		return nil, err
+	}
+
+	for {
+		responseKey, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		// heartbeats and the frames of other commands are skipped
+		if responseKey != key|responseFlag {
+			continue
+		}
+		if len(payload) < 6 {
+			return nil, fmt.Errorf("response to command %#x is too short", key)
+		}
+		if binary.BigEndian.Uint32(payload) != correlationID {
+			continue
+		}
+		if code := binary.BigEndian.Uint16(payload[4:]); code != responseOK {
+			return nil, &responseError{code: code}
+		}
+		return payload[6:], nil
+	}
+}
+
+func (c *streamClient) writeFrame(key uint16, data []byte) error {
+	frame := binary.BigEndian.AppendUint32(nil, uint32(4+len(data)))
+	frame = binary.BigEndian.AppendUint16(frame, key)
+	frame = binary.BigEndian.AppendUint16(frame, protocolVersion)
+	_, err := c.conn.Write(append(frame, data...))
+	return err
+}
+
+// readFrame reads a frame and returns the key of its command and its payload
+func (c *streamClient) readFrame() (uint16, []byte, error) {
+	var size uint32
+	if err := binary.Read(c.r, binary.BigEndian, &size); err != nil {
+		return 0, nil, err
+	}
+	if size < 4 || size > maxFrameSize {
+		return 0, nil, fmt.Errorf("invalid frame size %d", size)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(c.r, frame); err != nil {
+		return 0, nil, err
+	}
+	return binary.BigEndian.Uint16(frame), frame[4:], nil
+}
+
+type responseError struct {
+	code uint16
+}
+
+func (e *responseError) Error() string {
+	if reason, found := responseErrors[e.code]; found {
+		return reason
+	}
+	return fmt.Sprintf("response code %d", e.code)
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(s)))
+	return append(buf, s...)
+}
+
+func appendInt32(buf []byte, i int32) []byte {
+	return binary.BigEndian.AppendUint32(buf, uint32(i))
+}
+
+// reader decodes the fields of a payload, keeping the first error so that it's checked once at the end
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) int32() int32 {
+	if b := r.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *reader) string() string {
+	b := r.next(2)
+	if b == nil {
+		return ""
+	}
+	return string(r.next(int(int16(binary.BigEndian.Uint16(b)))))
+}
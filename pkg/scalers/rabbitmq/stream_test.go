@@ -0,0 +1,129 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startStreamServer starts a stream listener with the stats of the stream and the offsets stored by the consumers
+func startStreamServer(t *testing.T, stats map[string]int64, offsets map[string]int64) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveStream(t, conn, stats, offsets)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveStream(t *testing.T, conn net.Conn, stats map[string]int64, offsets map[string]int64) {
+	defer conn.Close()
+	c := &streamClient{conn: conn, r: bufio.NewReader(conn)}
+	for {
+		key, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		if key == commandTune {
+			continue
+		}
+		r := &reader{data: payload[4:]}
+		code := uint16(responseOK)
+		var data []byte
+		switch key {
+		case commandPeerProperties:
+			data = appendInt32(nil, 0)
+		case commandSaslHandshake:
+			data = appendString(appendInt32(nil, 1), saslMechanism)
+		case commandSaslAuthenticate:
+			assert.Equal(t, saslMechanism, r.string())
+			if string(r.next(int(r.int32()))) != "\x00keda\x00secret" {
+				code = 8
+			}
+		case commandOpen:
+			assert.Equal(t, "/", r.string())
+		case commandStreamStats:
+			if r.string() != "events" {
+				code = 2
+				break
+			}
+			data = appendInt32(nil, int32(len(stats)))
+			for name, value := range stats {
+				data = binary.BigEndian.AppendUint64(appendString(data, name), uint64(value))
+			}
+		case commandQueryOffset:
+			offset, found := offsets[r.string()]
+			if !found {
+				code = responseNoOffset
+				break
+			}
+			data = binary.BigEndian.AppendUint64(nil, uint64(offset))
+		}
+
+		response := append(append([]byte(nil), payload[:4]...), binary.BigEndian.AppendUint16(nil, code)...)
+		_ = c.writeFrame(key|responseFlag, append(response, data...))
+		// the server proposes its settings once authenticated
+		if key == commandSaslAuthenticate && code == responseOK {
+			_ = c.writeFrame(commandTune, binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, maxFrameSize), 60))
+		}
+		if key == commandClose {
+			return
+		}
+	}
+}
+
+func TestQueryStreamOffsets(t *testing.T) {
+	addr := startStreamServer(t, map[string]int64{"first_chunk_id": 100, "committed_chunk_id": 250}, map[string]int64{"app": 180})
+	config := &StreamConfig{Address: addr, Vhost: "/", Username: "keda", Password: "secret", Timeout: time.Second}
+
+	offsets, err := QueryStreamOffsets(context.Background(), config, "events", "app")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), offsets.FirstChunkID)
+	assert.Equal(t, int64(250), offsets.CommittedChunkID)
+	require.NotNil(t, offsets.ConsumerOffset)
+	assert.Equal(t, int64(180), *offsets.ConsumerOffset)
+
+	offsets, err = QueryStreamOffsets(context.Background(), config, "events", "other")
+	require.NoError(t, err)
+	assert.Nil(t, offsets.ConsumerOffset)
+
+	_, err = QueryStreamOffsets(context.Background(), config, "missing", "app")
+	assert.ErrorContains(t, err, "stream does not exist")
+
+	config.Password = "wrong"
+	_, err = QueryStreamOffsets(context.Background(), config, "events", "app")
+	assert.ErrorContains(t, err, "authentication failure")
+}
+
+func TestStreamOffsetsLag(t *testing.T) {
+	offset := func(o int64) *int64 { return &o }
+	tests := []struct {
+		offsets StreamOffsets
+		lag     int64
+		comment string
+	}{
+		{StreamOffsets{FirstChunkID: -1, CommittedChunkID: -1}, 0, "empty stream"},
+		{StreamOffsets{FirstChunkID: 100, CommittedChunkID: 250}, 151, "no stored offset"},
+		{StreamOffsets{FirstChunkID: 100, CommittedChunkID: 250, ConsumerOffset: offset(180)}, 70, "stored offset"},
+		{StreamOffsets{FirstChunkID: 100, CommittedChunkID: 250, ConsumerOffset: offset(260)}, 0, "stored offset in the last chunk"},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			assert.Equal(t, test.lag, test.offsets.Lag())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	"github.com/kedacore/keda/v2/pkg/scalers/rabbitmq"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -37,9 +39,13 @@ const (
 	rabbitModeUnknown                      = "Unknown"
 	rabbitModeQueueLength                  = "QueueLength"
 	rabbitModeMessageRate                  = "MessageRate"
+	rabbitModeStreamLag                    = "StreamLag"
+	rabbitModeOldestMessageAge             = "OldestMessageAge"
 	defaultRabbitMQQueueLength             = 20
 	rabbitMetricType                       = "External"
 	rabbitRootVhostPath                    = "/%2F"
+	rabbitStreamDefaultPort                = "5552"
+	rabbitStreamDefaultTLSPort             = "5551"
 	rmqTLSEnable                           = "enable"
 	rmqTLSDisable                          = "disable"
 )
@@ -47,6 +53,7 @@ const (
 const (
 	httpProtocol    = "http"
 	amqpProtocol    = "amqp"
+	streamProtocol  = "stream"
 	autoProtocol    = "auto"
 	defaultProtocol = autoProtocol
 )
@@ -64,8 +71,10 @@ type rabbitMQScaler struct {
 	connection *amqp.Connection
 	channel    *amqp.Channel
 	httpClient *http.Client
-	azureOAuth *azure.ADWorkloadIdentityTokenProvider
-	logger     logr.Logger
+	// streamConfig is only set with the stream protocol, a connection being opened on each request
+	streamConfig *rabbitmq.StreamConfig
+	azureOAuth   *azure.ADWorkloadIdentityTokenProvider
+	logger       logr.Logger
 }
 
 type rabbitMQMetadata struct {
@@ -73,17 +82,19 @@ type rabbitMQMetadata struct {
 	triggerIndex   int    // scaler index
 
 	QueueName string `keda:"name=queueName, order=triggerMetadata"`
-	// QueueLength or MessageRate
+	// QueueLength, MessageRate, StreamLag or OldestMessageAge
 	Mode string `keda:"name=mode, order=triggerMetadata, optional, default=Unknown"`
 	//
 	QueueLength float64 `keda:"name=queueLength, order=triggerMetadata, optional"`
-	// trigger value (queue length or publish/sec. rate)
+	// trigger value (queue length, publish/sec. rate or age in seconds)
 	Value float64 `keda:"name=value, order=triggerMetadata, optional"`
 	// activation value
 	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional"`
-	// connection string for either HTTP or AMQP protocol
+	// name of the consumer whose offset lag is computed in StreamLag mode
+	ConsumerName string `keda:"name=consumerName, order=triggerMetadata, optional"`
+	// connection string for either HTTP, AMQP or stream protocol
 	Host string `keda:"name=host, order=triggerMetadata;authParams;resolvedEnv"`
-	// either http or amqp protocol
+	// either http, amqp or stream protocol
 	Protocol string `keda:"name=protocol, order=triggerMetadata;authParams, optional, default=auto"`
 	// override the vhost from the connection info
 	VhostName string `keda:"name=vhostName, order=triggerMetadata, optional"`
@@ -97,6 +108,10 @@ type rabbitMQMetadata struct {
 	Operation string `keda:"name=operation, order=triggerMetadata, optional, default=sum"`
 	// custom http timeout for a specific trigger
 	TimeoutMs int `keda:"name=timeout, order=triggerMetadata, optional"`
+	// how the depth of the dead-letter queue is taken into account
+	DeadLetter deadLetterMetadata `keda:""`
+	// name of the queue the messages of the queue are dead-lettered to
+	DeadLetterQueueName string `keda:"name=deadLetterQueueName, order=triggerMetadata, optional"`
 
 	Username string `keda:"name=username, order=authParams;resolvedEnv, optional"`
 	Password string `keda:"name=password, order=authParams;resolvedEnv, optional"`
@@ -117,9 +132,9 @@ type rabbitMQMetadata struct {
 }
 
 func (r *rabbitMQMetadata) Validate() error {
-	if r.Protocol != amqpProtocol && r.Protocol != httpProtocol && r.Protocol != autoProtocol {
-		return fmt.Errorf("the protocol has to be either `%s`, `%s`, or `%s` but is `%s`",
-			amqpProtocol, httpProtocol, autoProtocol, r.Protocol)
+	if r.Protocol != amqpProtocol && r.Protocol != httpProtocol && r.Protocol != streamProtocol && r.Protocol != autoProtocol {
+		return fmt.Errorf("the protocol has to be either `%s`, `%s`, `%s`, or `%s` but is `%s`",
+			amqpProtocol, httpProtocol, streamProtocol, autoProtocol, r.Protocol)
 	}
 
 	if r.EnableTLS != rmqTLSEnable && r.EnableTLS != rmqTLSDisable {
@@ -151,13 +166,15 @@ func (r *rabbitMQMetadata) Validate() error {
 			r.Protocol = amqpProtocol
 		case "http", "https":
 			r.Protocol = httpProtocol
+		case "rabbitmq-stream", "rabbitmq-stream+tls":
+			r.Protocol = streamProtocol
 		default:
 			return fmt.Errorf("unknown host URL scheme `%s`", parsedURL.Scheme)
 		}
 	}
 
-	if r.Protocol == amqpProtocol && r.WorkloadIdentityResource != "" {
-		return fmt.Errorf("workload identity is not supported for amqp protocol currently")
+	if r.Protocol != httpProtocol && r.WorkloadIdentityResource != "" {
+		return fmt.Errorf("workload identity is not supported for %s protocol currently", r.Protocol)
 	}
 
 	if r.UseRegex && r.Protocol != httpProtocol {
@@ -172,6 +189,19 @@ func (r *rabbitMQMetadata) Validate() error {
 		return err
 	}
 
+	if r.Protocol == streamProtocol && r.Mode != rabbitModeStreamLag {
+		return fmt.Errorf("protocol %s only supports mode %s", streamProtocol, rabbitModeStreamLag)
+	}
+
+	if r.DeadLetter.enabled() {
+		if r.DeadLetterQueueName == "" {
+			return fmt.Errorf("deadLetterQueueName must be specified with dlqBehavior %s", r.DeadLetter.DLQBehavior)
+		}
+		if r.Mode != rabbitModeQueueLength {
+			return fmt.Errorf("dlqBehavior %s is only supported with mode %s", r.DeadLetter.DLQBehavior, rabbitModeQueueLength)
+		}
+	}
+
 	return nil
 }
 
@@ -202,12 +232,24 @@ func (r *rabbitMQMetadata) validateTrigger() error {
 		return fmt.Errorf("%s must be specified", rabbitValueTriggerConfigName)
 	}
 
-	if r.Mode != rabbitModeQueueLength && r.Mode != rabbitModeMessageRate {
-		return fmt.Errorf("trigger mode %s must be one of %s, %s", r.Mode, rabbitModeQueueLength, rabbitModeMessageRate)
+	if r.Mode != rabbitModeQueueLength && r.Mode != rabbitModeMessageRate && r.Mode != rabbitModeStreamLag && r.Mode != rabbitModeOldestMessageAge {
+		return fmt.Errorf("trigger mode %s must be one of %s, %s, %s, %s", r.Mode, rabbitModeQueueLength, rabbitModeMessageRate, rabbitModeStreamLag, rabbitModeOldestMessageAge)
 	}
 
-	if r.Mode == rabbitModeMessageRate && r.Protocol != httpProtocol {
-		return fmt.Errorf("protocol %s not supported; must be http to use mode %s", r.Protocol, rabbitModeMessageRate)
+	if (r.Mode == rabbitModeMessageRate || r.Mode == rabbitModeOldestMessageAge) && r.Protocol != httpProtocol {
+		return fmt.Errorf("protocol %s not supported; must be http to use mode %s", r.Protocol, r.Mode)
+	}
+
+	if r.Mode == rabbitModeStreamLag {
+		if r.Protocol == amqpProtocol {
+			return fmt.Errorf("protocol %s not supported; must be http or %s to use mode %s", r.Protocol, streamProtocol, rabbitModeStreamLag)
+		}
+		if r.ConsumerName == "" {
+			return fmt.Errorf("consumerName must be specified to use mode %s", rabbitModeStreamLag)
+		}
+		if r.UseRegex {
+			return fmt.Errorf("useRegex is not supported with mode %s", rabbitModeStreamLag)
+		}
 	}
 
 	if r.Protocol == amqpProtocol && r.TimeoutMs != 0 {
@@ -226,6 +268,9 @@ type queueInfo struct {
 	MessagesUnacknowledged int         `json:"messages_unacknowledged"`
 	MessageStat            messageStat `json:"message_stats"`
 	Name                   string      `json:"name"`
+	// HeadMessageTimestamp is the timestamp property of the first message of the queue, in seconds since the epoch,
+	// which is only known when the publishers set it
+	HeadMessageTimestamp int64 `json:"head_message_timestamp"`
 }
 
 type regexQueueInfo struct {
@@ -233,6 +278,15 @@ type regexQueueInfo struct {
 	TotalPages int         `json:"page_count"`
 }
 
+// streamConsumerInfo is a consumer of a stream as listed by the stream management plugin
+type streamConsumerInfo struct {
+	Queue struct {
+		Name string `json:"name"`
+	} `json:"queue"`
+	Properties map[string]string `json:"properties"`
+	OffsetLag  int64             `json:"offset_lag"`
+}
+
 type messageStat struct {
 	PublishDetail publishDetail `json:"publish_details"`
 }
@@ -276,6 +330,13 @@ func NewRabbitMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		s.httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
 	}
 
+	if meta.Protocol == streamProtocol {
+		s.streamConfig, err = getStreamConfig(meta, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing rabbitmq stream connection string: %w", err)
+		}
+	}
+
 	if meta.Protocol == amqpProtocol {
 		// Override vhost if requested.
 		host := meta.Host
@@ -360,6 +421,46 @@ func getConnectionAndChannel(host string, meta *rabbitMQMetadata) (*amqp.Connect
 	return conn, channel, nil
 }
 
+// getStreamConfig returns the configuration of the connections to the stream listener, TLS being enabled
+// either with the rabbitmq-stream+tls scheme or with the tls auth parameter
+func getStreamConfig(meta *rabbitMQMetadata, timeout time.Duration) (*rabbitmq.StreamConfig, error) {
+	parsedURL, err := url.Parse(meta.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &rabbitmq.StreamConfig{
+		Vhost:    strings.TrimPrefix(parsedURL.Path, "/"),
+		Username: parsedURL.User.Username(),
+		Timeout:  timeout,
+	}
+	config.Password, _ = parsedURL.User.Password()
+	if config.Vhost == "" {
+		config.Vhost = "/"
+	}
+	if meta.VhostName != "" {
+		config.Vhost = meta.VhostName
+	}
+	if meta.Username != "" && meta.Password != "" {
+		config.Username = meta.Username
+		config.Password = meta.Password
+	}
+
+	port := rabbitStreamDefaultPort
+	if parsedURL.Scheme == "rabbitmq-stream+tls" || meta.EnableTLS == rmqTLSEnable {
+		config.TLSConfig, err = kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		port = rabbitStreamDefaultTLSPort
+	}
+	if parsedURL.Port() != "" {
+		port = parsedURL.Port()
+	}
+	config.Address = net.JoinHostPort(parsedURL.Hostname(), port)
+	return config, nil
+}
+
 // Close disposes of RabbitMQ connections
 func (s *rabbitMQScaler) Close(context.Context) error {
 	if s.connection != nil {
@@ -376,6 +477,11 @@ func (s *rabbitMQScaler) Close(context.Context) error {
 }
 
 func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
+	if s.metadata.Mode == rabbitModeStreamLag {
+		lag, err := s.getStreamLag(ctx)
+		return lag, 0, err
+	}
+
 	if s.metadata.Protocol == httpProtocol {
 		info, err := s.getQueueInfoViaHTTP(ctx)
 		if err != nil {
@@ -399,12 +505,94 @@ func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, er
 	return int64(items.Messages), 0, nil
 }
 
-func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, error) {
-	var result queueInfo
+// getDeadLetterQueueLength returns the number of messages of the dead-letter queue
+func (s *rabbitMQScaler) getDeadLetterQueueLength(ctx context.Context) (int64, error) {
+	if s.metadata.Protocol != httpProtocol {
+		items, err := s.channel.QueueDeclarePassive(s.metadata.DeadLetterQueueName, false, false, false, false, amqp.Table{})
+		if err != nil {
+			return -1, err
+		}
+		return int64(items.Messages), nil
+	}
 
+	parsedURL, err := url.Parse(s.metadata.Host)
+	if err != nil {
+		return -1, err
+	}
+	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.VhostName)
+	parsedURL.Path = subpaths
+	if s.metadata.Username != "" && s.metadata.Password != "" {
+		parsedURL.User = url.UserPassword(s.metadata.Username, s.metadata.Password)
+	}
+
+	r, err := s.doManagementRequest(ctx, fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.DeadLetterQueueName)))
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(r.Body)
+		return -1, fmt.Errorf("error requesting rabbitMQ dead-letter queue: %s, response: %s", r.Status, body)
+	}
+	var info queueInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return -1, err
+	}
+	return int64(info.Messages), nil
+}
+
+// getStreamLag returns the offset lag of the consumer of the stream
+func (s *rabbitMQScaler) getStreamLag(ctx context.Context) (int64, error) {
+	if s.metadata.Protocol == streamProtocol {
+		offsets, err := rabbitmq.QueryStreamOffsets(ctx, s.streamConfig, s.metadata.QueueName, s.metadata.ConsumerName)
+		if err != nil {
+			return -1, err
+		}
+		return offsets.Lag(), nil
+	}
+
+	parsedURL, err := url.Parse(s.metadata.Host)
+	if err != nil {
+		return -1, err
+	}
+	vhost, subpaths := getVhostAndPathFromURL(parsedURL.Path, s.metadata.VhostName)
+	parsedURL.Path = subpaths
+	if s.metadata.Username != "" && s.metadata.Password != "" {
+		parsedURL.User = url.UserPassword(s.metadata.Username, s.metadata.Password)
+	}
+
+	r, err := s.doManagementRequest(ctx, fmt.Sprintf("%s/api/stream/consumers%s", parsedURL.String(), vhost))
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(r.Body)
+		return -1, fmt.Errorf("error requesting rabbitMQ stream consumers: %s, response: %s", r.Status, body)
+	}
+	var consumers []streamConsumerInfo
+	if err := json.NewDecoder(r.Body).Decode(&consumers); err != nil {
+		return -1, err
+	}
+
+	// the management API only knows the consumers which are connected, the instances of a
+	// consumer sharing its name, the lag of the one lagging the most is returned
+	var lag int64
+	for _, consumer := range consumers {
+		if consumer.Queue.Name == s.metadata.QueueName && consumer.Properties["name"] == s.metadata.ConsumerName {
+			lag = max(lag, consumer.OffsetLag)
+		}
+	}
+	return lag, nil
+}
+
+// doManagementRequest sends a GET request to the management API, authenticated with workload identity if configured
+func (s *rabbitMQScaler) doManagementRequest(ctx context.Context, url string) (*http.Response, error) {
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return result, err
+		return nil, err
 	}
 
 	if s.metadata.WorkloadIdentityResource != "" {
@@ -414,39 +602,29 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 
 		err = s.azureOAuth.Refresh()
 		if err != nil {
-			return result, err
+			return nil, err
 		}
 
 		request.Header.Set("Authorization", "Bearer "+s.azureOAuth.OAuthToken())
 	}
 
-	r, err := s.httpClient.Do(request)
+	return s.httpClient.Do(request)
+}
+
+func getJSON(ctx context.Context, s *rabbitMQScaler, url string, result interface{}) error {
+	r, err := s.doManagementRequest(ctx, url)
 	if err != nil {
-		return result, err
+		return err
 	}
 
 	defer r.Body.Close()
 
 	if r.StatusCode == 200 {
-		if s.metadata.UseRegex {
-			var queues regexQueueInfo
-			err = json.NewDecoder(r.Body).Decode(&queues)
-			if err != nil {
-				return queueInfo{}, err
-			}
-			if queues.TotalPages > 1 {
-				return queueInfo{}, fmt.Errorf("regex matches more queues than can be recovered at once")
-			}
-			result, err := getComposedQueue(s, queues.Queues)
-			return result, err
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&result)
-		return result, err
+		return json.NewDecoder(r.Body).Decode(result)
 	}
 
 	body, _ := io.ReadAll(r.Body)
-	return result, fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
+	return fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
 func getVhostAndPathFromURL(rawPath, vhostName string) (resolvedVhostPath, resolvedPath string) {
@@ -481,23 +659,53 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP(ctx context.Context) (*queueInfo, e
 		parsedURL.User = url.UserPassword(s.metadata.Username, s.metadata.Password)
 	}
 
-	var getQueueInfoManagementURI string
 	if s.metadata.UseRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName), s.metadata.PageSize)
-	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName))
+		// the queues of the vhost matching the regex are aggregated across all the pages
+		var queues []queueInfo
+		for page := 1; ; page++ {
+			getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s?page=%d&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, page, url.QueryEscape(s.metadata.QueueName), s.metadata.PageSize)
+
+			var result regexQueueInfo
+			if err := getJSON(ctx, s, getQueueInfoManagementURI, &result); err != nil {
+				return nil, err
+			}
+			queues = append(queues, result.Queues...)
+
+			if page >= result.TotalPages {
+				break
+			}
+		}
+
+		info, err := getComposedQueue(s, queues)
+		if err != nil {
+			return nil, err
+		}
+		return &info, nil
 	}
 
-	var info queueInfo
-	info, err = getJSON(ctx, s, getQueueInfoManagementURI)
+	getQueueInfoManagementURI := fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.QueueName))
 
-	if err != nil {
+	var info queueInfo
+	if err := getJSON(ctx, s, getQueueInfoManagementURI, &info); err != nil {
 		return nil, err
 	}
 
 	return &info, nil
 }
 
+// getOldestMessageAge returns the seconds the first message of the queue, or the oldest of the first messages of the
+// queues matching the regex, has been published for. Messages without timestamp property count as just published.
+func (s *rabbitMQScaler) getOldestMessageAge(ctx context.Context) (float64, error) {
+	info, err := s.getQueueInfoViaHTTP(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if info.HeadMessageTimestamp == 0 {
+		return 0, nil
+	}
+	return max(time.Since(time.Unix(info.HeadMessageTimestamp, 0)).Seconds(), 0), nil
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
@@ -515,6 +723,15 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if s.metadata.Mode == rabbitModeOldestMessageAge {
+		age, err := s.getOldestMessageAge(ctx)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
+		}
+		metric := GenerateMetricInMili(metricName, age)
+		return []external_metrics.ExternalMetricValue{metric}, age > s.metadata.ActivationValue, nil
+	}
+
 	messages, publishRate, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
@@ -522,7 +739,15 @@ func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 
 	var metric external_metrics.ExternalMetricValue
 	var isActive bool
-	if s.metadata.Mode == rabbitModeQueueLength {
+	if s.metadata.DeadLetter.enabled() {
+		deadLetterMessages, err := s.getDeadLetterQueueLength(ctx)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
+		}
+		var value float64
+		value, isActive = s.metadata.DeadLetter.apply(float64(messages), float64(deadLetterMessages), s.metadata.ActivationValue)
+		metric = GenerateMetricInMili(metricName, value)
+	} else if s.metadata.Mode != rabbitModeMessageRate {
 		metric = GenerateMetricInMili(metricName, float64(messages))
 		isActive = float64(messages) > s.metadata.ActivationValue
 	} else {
@@ -537,6 +762,7 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	var queue = queueInfo{}
 	queue.Name = "composed-queue"
 	queue.MessagesUnacknowledged = 0
+	queue.HeadMessageTimestamp = getOldestHeadMessageTimestamp(q)
 	if len(q) > 0 {
 		switch s.metadata.Operation {
 		case sumOperation:
@@ -565,6 +791,17 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	return queue, nil
 }
 
+// getOldestHeadMessageTimestamp returns the timestamp of the oldest first message of the queues, regardless of the operation
+func getOldestHeadMessageTimestamp(q []queueInfo) int64 {
+	var oldest int64
+	for _, value := range q {
+		if value.HeadMessageTimestamp != 0 && (oldest == 0 || value.HeadMessageTimestamp < oldest) {
+			oldest = value.HeadMessageTimestamp
+		}
+	}
+	return oldest
+}
+
 func getSum(q []queueInfo) (int, int, float64) {
 	var sumMessages int
 	var sumMessagesReady int
@@ -0,0 +1,290 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	redisInfoMetricUsedMemoryRatio        = "usedMemoryRatio"
+	redisInfoMetricConnectedClients       = "connectedClients"
+	redisInfoMetricInstantaneousOpsPerSec = "instantaneousOpsPerSec"
+
+	redisInfoAggregationMax = "max"
+	redisInfoAggregationSum = "sum"
+	redisInfoAggregationAvg = "avg"
+)
+
+// redisInfoScaler scales on the INFO of the Redis nodes, e.g. the proxy tiers in front of Redis
+type redisInfoScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *redisInfoMetadata
+	closeFn    func() error
+	getInfoFn  func(context.Context) ([]string, error)
+	logger     logr.Logger
+}
+
+type redisInfoMetadata struct {
+	Metric string `keda:"name=metric,       order=triggerMetadata, enum=usedMemoryRatio;connectedClients;instantaneousOpsPerSec"`
+	// Aggregation is how the values of the nodes are combined, the highest ratio of used memory or the sum of
+	// the clients and operations by default
+	Aggregation           string              `keda:"name=aggregation,       order=triggerMetadata, enum=max;sum;avg, optional"`
+	TargetValue           float64             `keda:"name=targetValue,       order=triggerMetadata"`
+	ActivationTargetValue float64             `keda:"name=activationTargetValue,       order=triggerMetadata, optional"`
+	DatabaseIndex         int                 `keda:"name=databaseIndex,       order=triggerMetadata, optional"`
+	MetadataEnableTLS     string              `keda:"name=enableTLS,       order=triggerMetadata, optional"`
+	AuthParamEnableTLS    string              `keda:"name=tls,       order=authParams, optional"`
+	ConnectionInfo        redisConnectionInfo `keda:"optional"`
+	triggerIndex          int
+}
+
+func (r *redisInfoMetadata) Validate() error {
+	if err := validateRedisAddress(&r.ConnectionInfo); err != nil {
+		return err
+	}
+
+	if err := r.ConnectionInfo.SetEnableTLS(r.MetadataEnableTLS, r.AuthParamEnableTLS); err != nil {
+		return err
+	}
+	r.MetadataEnableTLS, r.AuthParamEnableTLS = "", ""
+
+	if r.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+
+	if r.Aggregation == "" {
+		r.Aggregation = redisInfoAggregationSum
+		if r.Metric == redisInfoMetricUsedMemoryRatio {
+			r.Aggregation = redisInfoAggregationMax
+		}
+	}
+	return nil
+}
+
+// NewRedisInfoScaler creates a new redisInfoScaler
+func NewRedisInfoScaler(ctx context.Context, isClustered, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "redis_info_scaler")
+
+	meta, err := parseRedisInfoMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redis info metadata: %w", err)
+	}
+
+	if isClustered {
+		return createClusteredRedisInfoScaler(ctx, meta, metricType, logger)
+	}
+
+	var client *redis.Client
+	if isSentinel {
+		client, err = getRedisSentinelClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+	} else {
+		client, err = getRedisClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connection to redis failed: %w", err)
+	}
+
+	closeFn := func() error {
+		if err := client.Close(); err != nil {
+			logger.Error(err, "error closing redis client")
+			return err
+		}
+		return nil
+	}
+
+	infoFn := func(ctx context.Context) ([]string, error) {
+		info, err := client.Info(ctx, meta.getInfoSection()).Result()
+		if err != nil {
+			return nil, err
+		}
+		return []string{info}, nil
+	}
+
+	return &redisInfoScaler{
+		metricType: metricType,
+		metadata:   meta,
+		closeFn:    closeFn,
+		getInfoFn:  infoFn,
+		logger:     logger,
+	}, nil
+}
+
+func createClusteredRedisInfoScaler(ctx context.Context, meta *redisInfoMetadata, metricType v2.MetricTargetType, logger logr.Logger) (Scaler, error) {
+	client, err := getRedisClusterClient(ctx, meta.ConnectionInfo)
+	if err != nil {
+		return nil, fmt.Errorf("connection to redis cluster failed: %w", err)
+	}
+
+	closeFn := func() error {
+		if err := client.Close(); err != nil {
+			logger.Error(err, "error closing redis client")
+			return err
+		}
+		return nil
+	}
+
+	// the INFO of each node of the cluster, the shards being visited concurrently
+	infoFn := func(ctx context.Context) ([]string, error) {
+		var lock sync.Mutex
+		var infos []string
+		err := client.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
+			info, err := shard.Info(ctx, meta.getInfoSection()).Result()
+			if err != nil {
+				return err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			infos = append(infos, info)
+			return nil
+		})
+		return infos, err
+	}
+
+	return &redisInfoScaler{
+		metricType: metricType,
+		metadata:   meta,
+		closeFn:    closeFn,
+		getInfoFn:  infoFn,
+		logger:     logger,
+	}, nil
+}
+
+func parseRedisInfoMetadata(config *scalersconfig.ScalerConfig) (*redisInfoMetadata, error) {
+	meta := &redisInfoMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing redis info metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// getInfoSection returns the section of the INFO the metric is part of
+func (r *redisInfoMetadata) getInfoSection() string {
+	switch r.Metric {
+	case redisInfoMetricUsedMemoryRatio:
+		return "memory"
+	case redisInfoMetricConnectedClients:
+		return "clients"
+	default:
+		return "stats"
+	}
+}
+
+// getNodeValue returns the value of the metric in the INFO of a node. The ratio of used memory is relative to the
+// maxmemory of the node, or to the memory of its system when it has no maxmemory.
+func (r *redisInfoMetadata) getNodeValue(info string) (float64, error) {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, found := strings.Cut(strings.TrimSpace(line), ":"); found {
+			fields[name] = value
+		}
+	}
+
+	field := func(name string) (float64, error) {
+		value, found := fields[name]
+		if !found {
+			return 0, fmt.Errorf("%s not found in the INFO of the node", name)
+		}
+		return strconv.ParseFloat(value, 64)
+	}
+
+	switch r.Metric {
+	case redisInfoMetricUsedMemoryRatio:
+		usedMemory, err := field("used_memory")
+		if err != nil {
+			return 0, err
+		}
+		maxMemory, err := field("maxmemory")
+		if err != nil || maxMemory == 0 {
+			if maxMemory, err = field("total_system_memory"); err != nil {
+				return 0, err
+			}
+		}
+		if maxMemory == 0 {
+			return 0, errors.New("neither maxmemory nor total_system_memory of the node is known")
+		}
+		return usedMemory / maxMemory, nil
+	case redisInfoMetricConnectedClients:
+		return field("connected_clients")
+	default:
+		return field("instantaneous_ops_per_sec")
+	}
+}
+
+// aggregate returns the value of the metric across the nodes
+func (r *redisInfoMetadata) aggregate(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	result := values[0]
+	for _, value := range values[1:] {
+		if r.Aggregation == redisInfoAggregationMax {
+			result = max(result, value)
+		} else {
+			result += value
+		}
+	}
+	if r.Aggregation == redisInfoAggregationAvg {
+		result /= float64(len(values))
+	}
+	return result
+}
+
+func (s *redisInfoScaler) Close(context.Context) error {
+	return s.closeFn()
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *redisInfoScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := util.NormalizeString(fmt.Sprintf("redis-info-%s", s.metadata.Metric))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity connects to Redis and aggregates the metric of the INFO of its nodes
+func (s *redisInfoScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	infos, err := s.getInfoFn(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting info")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	values := make([]float64, 0, len(infos))
+	for _, info := range infos {
+		value, err := s.metadata.getNodeValue(info)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting %s: %w", s.metadata.Metric, err)
+		}
+		values = append(values, value)
+	}
+	value := s.metadata.aggregate(values)
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
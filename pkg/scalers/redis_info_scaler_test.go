@@ -0,0 +1,127 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseRedisInfoMetadataTestData struct {
+	metadata    map[string]string
+	isError     bool
+	aggregation string
+	comment     string
+}
+
+type redisInfoMetricIdentifier struct {
+	metadataTestData *parseRedisInfoMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testRedisInfoMetadata = []parseRedisInfoMetadataTestData{
+	{map[string]string{}, true, "", "empty metadata"},
+	{map[string]string{"address": "redis:6379", "metric": "usedMemoryRatio", "targetValue": "0.7"}, false, "max", "used memory ratio"},
+	{map[string]string{"address": "redis:6379", "metric": "connectedClients", "targetValue": "100"}, false, "sum", "connected clients"},
+	{map[string]string{"address": "redis:6379", "metric": "instantaneousOpsPerSec", "targetValue": "5000", "aggregation": "avg"}, false, "avg", "operations per second averaged"},
+	{map[string]string{"address": "redis:6379", "metric": "usedCpu", "targetValue": "1"}, true, "", "unknown metric"},
+	{map[string]string{"address": "redis:6379", "metric": "connectedClients", "targetValue": "100", "aggregation": "min"}, true, "", "unknown aggregation"},
+	{map[string]string{"address": "redis:6379", "metric": "connectedClients"}, true, "", "missing targetValue"},
+	{map[string]string{"address": "redis:6379", "metric": "connectedClients", "targetValue": "0"}, true, "", "targetValue not positive"},
+	{map[string]string{"metric": "connectedClients", "targetValue": "100"}, true, "", "missing address"},
+}
+
+var redisInfoMetricIdentifiers = []redisInfoMetricIdentifier{
+	{&testRedisInfoMetadata[1], 0, "s0-redis-info-usedMemoryRatio"},
+	{&testRedisInfoMetadata[2], 1, "s1-redis-info-connectedClients"},
+}
+
+func TestRedisInfoParseMetadata(t *testing.T) {
+	for _, testData := range testRedisInfoMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			meta, err := parseRedisInfoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: map[string]string{}})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if err == nil && meta.Aggregation != testData.aggregation {
+				t.Errorf("Expected aggregation %s but got %s", testData.aggregation, meta.Aggregation)
+			}
+		})
+	}
+}
+
+func TestRedisInfoGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range redisInfoMetricIdentifiers {
+		meta, err := parseRedisInfoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: map[string]string{}, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockRedisInfoScaler := redisInfoScaler{"", meta, nil, nil, logr.Discard()}
+
+		metricSpec := mockRedisInfoScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestRedisInfoGetMetricsAndActivity(t *testing.T) {
+	nodes := []string{
+		"# Memory\r\nused_memory:300\r\nmaxmemory:1000\r\ntotal_system_memory:4000\r\n" +
+			"# Clients\r\nconnected_clients:10\r\n# Stats\r\ninstantaneous_ops_per_sec:200\r\n",
+		"# Memory\r\nused_memory:1000\r\nmaxmemory:0\r\ntotal_system_memory:2000\r\n" +
+			"# Clients\r\nconnected_clients:30\r\n# Stats\r\ninstantaneous_ops_per_sec:600\r\n",
+	}
+
+	testCases := []struct {
+		metadata map[string]string
+		value    float64
+		isActive bool
+	}{
+		{map[string]string{"address": "redis:6379", "metric": "usedMemoryRatio", "targetValue": "0.7"}, 0.5, true},
+		{map[string]string{"address": "redis:6379", "metric": "usedMemoryRatio", "targetValue": "0.7", "aggregation": "avg"}, 0.4, true},
+		{map[string]string{"address": "redis:6379", "metric": "connectedClients", "targetValue": "100"}, 40, true},
+		{map[string]string{"address": "redis:6379", "metric": "connectedClients", "targetValue": "100", "aggregation": "max", "activationTargetValue": "30"}, 30, false},
+		{map[string]string{"address": "redis:6379", "metric": "instantaneousOpsPerSec", "targetValue": "500", "aggregation": "avg"}, 400, true},
+	}
+
+	for _, tc := range testCases {
+		meta, err := parseRedisInfoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		getInfoFn := func(context.Context) ([]string, error) { return nodes, nil }
+		mockRedisInfoScaler := redisInfoScaler{"", meta, nil, getInfoFn, logr.Discard()}
+
+		metrics, isActive, err := mockRedisInfoScaler.GetMetricsAndActivity(context.Background(), "metric")
+		assert.Nil(t, err)
+		assert.InDelta(t, tc.value, metrics[0].Value.AsApproximateFloat64(), 0.001, tc.metadata)
+		assert.Equal(t, tc.isActive, isActive, tc.metadata)
+	}
+}
+
+func TestRedisInfoGetMetricsAndActivityErrors(t *testing.T) {
+	meta, err := parseRedisInfoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"address": "redis:6379", "metric": "usedMemoryRatio", "targetValue": "0.7"}, AuthParams: map[string]string{}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	getInfoFn := func(context.Context) ([]string, error) { return []string{"# Memory\r\nused_memory:300\r\n"}, nil }
+	mockRedisInfoScaler := redisInfoScaler{"", meta, nil, getInfoFn, logr.Discard()}
+	_, _, err = mockRedisInfoScaler.GetMetricsAndActivity(context.Background(), "metric")
+	assert.NotNil(t, err)
+
+	getInfoFn = func(context.Context) ([]string, error) { return nil, errors.New("connection refused") }
+	mockRedisInfoScaler = redisInfoScaler{"", meta, nil, getInfoFn, logr.Discard()}
+	_, _, err = mockRedisInfoScaler.GetMetricsAndActivity(context.Background(), "metric")
+	assert.NotNil(t, err)
+}
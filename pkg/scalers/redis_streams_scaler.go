@@ -22,6 +22,7 @@ const (
 	xPendingFactor scaleFactor = iota + 1
 	xLengthFactor
 	lagFactor
+	pendingEntryAgeFactor
 )
 
 const (
@@ -52,11 +53,13 @@ type redisStreamsMetadata struct {
 	TargetPendingEntriesCount int64               `keda:"name=pendingEntriesCount,       order=triggerMetadata, optional, default=5"`
 	TargetStreamLength        int64               `keda:"name=streamLength,       order=triggerMetadata, optional, default=5"`
 	TargetLag                 int64               `keda:"name=lagCount,       order=triggerMetadata, optional"`
+	TargetPendingEntryAge     int64               `keda:"name=pendingEntryAge,       order=triggerMetadata, optional"`
 	StreamName                string              `keda:"name=stream,       order=triggerMetadata"`
 	ConsumerGroupName         string              `keda:"name=consumerGroup,       order=triggerMetadata, optional"`
 	DatabaseIndex             int                 `keda:"name=databaseIndex,       order=triggerMetadata, optional"`
 	ConnectionInfo            redisConnectionInfo `keda:"optional"`
 	ActivationLagCount        int64               `keda:"name=activationLagCount,       order=triggerMetadata, optional"`
+	ActivationPendingEntryAge int64               `keda:"name=activationPendingEntryAge,       order=triggerMetadata, optional"`
 	MetadataEnableTLS         string              `keda:"name=enableTLS,       order=triggerMetadata, optional"`
 	AuthParamEnableTLS        string              `keda:"name=tls,       order=authParams, optional"`
 }
@@ -77,9 +80,21 @@ func (r *redisStreamsMetadata) Validate() error {
 		return ErrRedisMissingStreamName
 	}
 
+	if r.TargetPendingEntryAge != 0 {
+		if r.ConsumerGroupName == "" {
+			return errors.New("consumerGroup required for Redis pending entry age")
+		}
+		if r.TargetLag != 0 {
+			return errors.New("lagCount and pendingEntryAge cannot be set simultaneously")
+		}
+	}
+
 	if r.ConsumerGroupName != "" {
 		r.TargetStreamLength = 0
-		if r.TargetLag != 0 {
+		if r.TargetPendingEntryAge != 0 {
+			r.scaleFactor = pendingEntryAgeFactor
+			r.TargetPendingEntriesCount = 0
+		} else if r.TargetLag != 0 {
 			r.scaleFactor = lagFactor
 			r.TargetPendingEntriesCount = 0
 
@@ -194,6 +209,24 @@ func createEntriesCountFn(client redis.Cmdable, meta *redisStreamsMetadata) (ent
 			}
 			return pendingEntries.Count, nil
 		}
+	case pendingEntryAgeFactor:
+		entriesCountFn = func(ctx context.Context) (int64, error) {
+			// the oldest pending entry is the first one, its idle time being the time since it was last delivered
+			pendingEntries, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+				Stream: meta.StreamName,
+				Group:  meta.ConsumerGroupName,
+				Start:  "-",
+				End:    "+",
+				Count:  1,
+			}).Result()
+			if err != nil {
+				return -1, err
+			}
+			if len(pendingEntries) == 0 {
+				return 0, nil
+			}
+			return int64(pendingEntries[0].Idle.Seconds()), nil
+		}
 	case xLengthFactor:
 		entriesCountFn = func(ctx context.Context) (int64, error) {
 			entriesLength, err := client.XLen(ctx, meta.StreamName).Result()
@@ -310,6 +343,8 @@ func (s *redisStreamsScaler) GetMetricSpecForScaling(context.Context) []v2.Metri
 		metricValue = s.metadata.TargetStreamLength
 	case lagFactor:
 		metricValue = s.metadata.TargetLag
+	case pendingEntryAgeFactor:
+		metricValue = s.metadata.TargetPendingEntryAge
 	}
 
 	externalMetric := &v2.ExternalMetricSource{
@@ -331,6 +366,11 @@ func (s *redisStreamsScaler) GetMetricsAndActivity(ctx context.Context, metricNa
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
+	activationValue := s.metadata.ActivationLagCount
+	if s.metadata.scaleFactor == pendingEntryAgeFactor {
+		activationValue = s.metadata.ActivationPendingEntryAge
+	}
+
 	metric := GenerateMetricInMili(metricName, float64(metricCount))
-	return []external_metrics.ExternalMetricValue{metric}, metricCount > s.metadata.ActivationLagCount, nil
+	return []external_metrics.ExternalMetricValue{metric}, metricCount > activationValue, nil
 }
@@ -122,6 +122,16 @@ func TestParseRedisStreamsMetadata(t *testing.T) {
 	}
 }
 
+func TestParseRedisStreamsPendingEntryAgeMetadata(t *testing.T) {
+	metadata := map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntryAge": "30", "activationPendingEntryAge": "5", "address": "REDIS_SERVER"}
+	m, err := parseRedisStreamsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, ResolvedEnv: map[string]string{"REDIS_SERVER": "myredis:6379"}, AuthParams: map[string]string{}})
+	assert.Nil(t, err)
+	assert.Equal(t, pendingEntryAgeFactor, m.scaleFactor)
+	assert.Equal(t, int64(30), m.TargetPendingEntryAge)
+	assert.Equal(t, int64(5), m.ActivationPendingEntryAge)
+	assert.Equal(t, int64(0), m.TargetPendingEntriesCount)
+}
+
 func TestParseRedisStreamsMetadataForInvalidCases(t *testing.T) {
 	resolvedEnvMap := map[string]string{
 		"REDIS_SERVER":   "myredis:6379",
@@ -154,6 +164,12 @@ func TestParseRedisStreamsMetadataForInvalidCases(t *testing.T) {
 
 		{"invalid databaseIndex", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "databaseIndex": "junk", "enableTLS": "false"}, resolvedEnvMap},
 
+		{"invalid pendingEntryAge", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntryAge": "junk", "address": "REDIS_SERVER"}, resolvedEnvMap},
+
+		{"pendingEntryAge without consumerGroup", map[string]string{"stream": "my-stream", "pendingEntryAge": "30", "address": "REDIS_SERVER"}, resolvedEnvMap},
+
+		{"pendingEntryAge with lagCount", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntryAge": "30", "lagCount": "5", "activationLagCount": "3", "address": "REDIS_SERVER"}, resolvedEnvMap},
+
 		{"invalid enableTLS", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "databaseIndex": "1", "enableTLS": "no"}, resolvedEnvMap},
 	}
 
@@ -0,0 +1,186 @@
+package remotewrite
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/pushreceiver"
+)
+
+const (
+	// PathPrefix is the prefix of the paths of the ScaledObjects, /api/v1/write/<namespace>/<name>
+	PathPrefix = "/api/v1/write/"
+
+	// maxRequestSize protects against decompressing garbage, Prometheus sending requests of a few MB at most
+	maxRequestSize = 32 * 1024 * 1024
+)
+
+// DefaultReceiver is the receiver served by keda-operator when the remote-write endpoint is enabled
+var DefaultReceiver = NewReceiver()
+
+// TimeSeries is a time series of a write request, with its samples
+type TimeSeries struct {
+	Labels  map[string]string
+	Samples []Sample
+}
+
+// Sample is a sample of a time series, the timestamp being in milliseconds
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Receiver receives the write requests of the Prometheus remote-write protocol 1.0, and passes the time series
+// to the subscriptions of the path of the request whose token authenticates the request
+type Receiver = pushreceiver.Receiver[[]TimeSeries]
+
+// NewReceiver returns a receiver without subscriptions
+func NewReceiver() *Receiver {
+	return pushreceiver.NewReceiver(maxRequestSize, http.StatusNoContent, decodeRequest)
+}
+
+// Path returns the path of the ScaledObject
+func Path(namespace, name string) string {
+	return PathPrefix + namespace + "/" + name
+}
+
+func decodeRequest(req *http.Request) ([]TimeSeries, error) {
+	// remote-write 2.0 requests are announced with the proto parameter
+	if contentType := req.Header.Get("Content-Type"); strings.Contains(contentType, "proto=") && !strings.Contains(contentType, "prometheus.WriteRequest") {
+		return nil, &pushreceiver.StatusError{StatusCode: http.StatusUnsupportedMediaType, Err: errors.New("only remote-write 1.0 is supported")}
+	}
+	compressed, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeWriteRequest(compressed)
+}
+
+// DecodeWriteRequest decodes the snappy compressed WriteRequest protobuf message of remote-write 1.0
+func DecodeWriteRequest(compressed []byte) ([]TimeSeries, error) {
+	length, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing write request: %w", err)
+	}
+	if length > maxRequestSize {
+		return nil, fmt.Errorf("write request of %d bytes is too large", length)
+	}
+	b, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing write request: %w", err)
+	}
+
+	var series []TimeSeries
+	err = consumeMessage(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		// WriteRequest.timeseries
+		if num != 1 || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		ts, err := decodeTimeSeries(v)
+		if err != nil {
+			return 0, err
+		}
+		series = append(series, ts)
+		return n, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error decoding write request: %w", err)
+	}
+	return series, nil
+}
+
+func decodeTimeSeries(b []byte) (TimeSeries, error) {
+	ts := TimeSeries{Labels: map[string]string{}}
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType || (num != 1 && num != 2) {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		if num == 1 {
+			name, value, err := decodeLabel(v)
+			if err != nil {
+				return 0, err
+			}
+			ts.Labels[name] = value
+		} else {
+			sample, err := decodeSample(v)
+			if err != nil {
+				return 0, err
+			}
+			ts.Samples = append(ts.Samples, sample)
+		}
+		return n, nil
+	})
+	return ts, err
+}
+
+func decodeLabel(b []byte) (name, value string, err error) {
+	err = consumeMessage(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType || (num != 1 && num != 2) {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeString(b)
+		if num == 1 {
+			name = v
+		} else {
+			value = v
+		}
+		return n, nil
+	})
+	return name, value, err
+}
+
+func decodeSample(b []byte) (Sample, error) {
+	var sample Sample
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			sample.Value = math.Float64frombits(v)
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			sample.Timestamp = int64(v)
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	return sample, err
+}
+
+// consumeMessage passes the fields of the message to the consumer, which returns the length of the value of the
+// field it consumed, or a negative length when the value is malformed
+func consumeMessage(b []byte, consume func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		n, err := consume(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package remotewrite
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// encodeWriteRequest encodes the time series as a snappy compressed WriteRequest, as sent by Prometheus
+func encodeWriteRequest(series []TimeSeries) []byte {
+	var request []byte
+	for _, ts := range series {
+		var b []byte
+		for name, value := range ts.Labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, value)
+			b = protowire.AppendTag(b, 1, protowire.BytesType)
+			b = protowire.AppendBytes(b, label)
+		}
+		for _, s := range ts.Samples {
+			var sample []byte
+			sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+			sample = protowire.AppendFixed64(sample, math.Float64bits(s.Value))
+			sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+			sample = protowire.AppendVarint(sample, uint64(s.Timestamp))
+			b = protowire.AppendTag(b, 2, protowire.BytesType)
+			b = protowire.AppendBytes(b, sample)
+		}
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, b)
+	}
+	// metadata of the request, ignored
+	request = protowire.AppendTag(request, 3, protowire.BytesType)
+	request = protowire.AppendBytes(request, []byte{})
+	return snappy.Encode(nil, request)
+}
+
+var testSeries = []TimeSeries{
+	{
+		Labels:  map[string]string{"__name__": "queue_length", "queue": "orders"},
+		Samples: []Sample{{Value: 3, Timestamp: 1700000000000}, {Value: 5.5, Timestamp: 1700000015000}},
+	},
+	{
+		Labels:  map[string]string{"__name__": "queue_length", "queue": "invoices"},
+		Samples: []Sample{{Value: 1, Timestamp: 1700000015000}},
+	},
+}
+
+func TestDecodeWriteRequest(t *testing.T) {
+	series, err := DecodeWriteRequest(encodeWriteRequest(testSeries))
+	require.NoError(t, err)
+	assert.Equal(t, testSeries, series)
+
+	_, err = DecodeWriteRequest([]byte("garbage"))
+	assert.Error(t, err)
+
+	_, err = DecodeWriteRequest(snappy.Encode(nil, []byte{0x0a, 0xff}))
+	assert.Error(t, err)
+}
+
+func TestReceiver(t *testing.T) {
+	receiver := NewReceiver()
+	var received []TimeSeries
+	unsubscribe := receiver.Subscribe(Path("default", "consumer"), "secret", func(series []TimeSeries) {
+		received = append(received, series...)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   []byte
+		status int
+	}{
+		{"authenticated", http.MethodPost, "/api/v1/write/default/consumer", "secret", encodeWriteRequest(testSeries), http.StatusNoContent},
+		{"wrong token", http.MethodPost, "/api/v1/write/default/consumer", "wrong", encodeWriteRequest(testSeries), http.StatusUnauthorized},
+		{"without token", http.MethodPost, "/api/v1/write/default/consumer", "", encodeWriteRequest(testSeries), http.StatusUnauthorized},
+		{"unknown scaled object", http.MethodPost, "/api/v1/write/default/producer", "secret", encodeWriteRequest(testSeries), http.StatusNotFound},
+		{"not a post", http.MethodGet, "/api/v1/write/default/consumer", "secret", nil, http.StatusMethodNotAllowed},
+		{"malformed request", http.MethodPost, "/api/v1/write/default/consumer", "secret", []byte("garbage"), http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, bytes.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("Content-Encoding", "snappy")
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, req)
+			assert.Equal(t, test.status, w.Code)
+		})
+	}
+	assert.Equal(t, testSeries, received)
+
+	unsubscribe()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/write/default/consumer", bytes.NewReader(encodeWriteRequest(testSeries)))
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestReceiverRemoteWrite2(t *testing.T) {
+	receiver := NewReceiver()
+	receiver.Subscribe(Path("default", "consumer"), "secret", func([]TimeSeries) {})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/write/default/consumer", bytes.NewReader(encodeWriteRequest(testSeries)))
+	req.Header.Set("Content-Type", "application/x-protobuf;proto=io.prometheus.write.v2.Request")
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
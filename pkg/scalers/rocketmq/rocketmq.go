@@ -0,0 +1,328 @@
+package rocketmq
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// request codes of the RocketMQ remoting protocol
+const (
+	codeQueryConsumerOffset = 14
+	codeGetMaxOffset        = 30
+	codeGetRouteInfoByTopic = 105
+)
+
+// response codes of the RocketMQ remoting protocol
+const (
+	codeSuccess       = 0
+	codeQueryNotFound = 22
+)
+
+const (
+	// masterBrokerID is the id of the master in the broker addresses of a broker set
+	masterBrokerID = "0"
+	// flagResponse marks a remoting command as a response
+	flagResponse = 1
+	// serializeTypeJSON is the serialization type of the header of the remoting commands
+	serializeTypeJSON = 0
+	// maxFrameLength protects against reading garbage as the length of a frame
+	maxFrameLength = 16 * 1024 * 1024
+)
+
+// Config contains the information required to query a RocketMQ cluster.
+type Config struct {
+	// NameServer is the address of the name server or, for RocketMQ 5.x, of the proxy
+	NameServer string
+	AccessKey  string
+	SecretKey  string
+	Timeout    time.Duration
+}
+
+// Client queries the offsets of a RocketMQ cluster with the remoting protocol.
+type Client struct {
+	*Config
+	opaque atomic.Int32
+}
+
+// QueueOffset contains the offsets of a message queue for a consumer group.
+type QueueOffset struct {
+	BrokerName     string
+	QueueID        int
+	BrokerOffset   int64
+	ConsumerOffset int64
+	// Committed is false when the consumer group hasn't committed any offset for the queue yet
+	Committed bool
+}
+
+// Lag returns the number of messages of the queue which haven't been consumed yet.
+func (q QueueOffset) Lag() int64 {
+	if q.BrokerOffset <= q.ConsumerOffset {
+		return 0
+	}
+	return q.BrokerOffset - q.ConsumerOffset
+}
+
+type remotingCommand struct {
+	Code      int               `json:"code"`
+	Language  string            `json:"language"`
+	Version   int               `json:"version"`
+	Opaque    int32             `json:"opaque"`
+	Flag      int               `json:"flag"`
+	Remark    string            `json:"remark,omitempty"`
+	ExtFields map[string]string `json:"extFields,omitempty"`
+	Body      []byte            `json:"-"`
+}
+
+type topicRouteData struct {
+	QueueDatas []struct {
+		BrokerName     string `json:"brokerName"`
+		ReadQueueNums  int    `json:"readQueueNums"`
+		WriteQueueNums int    `json:"writeQueueNums"`
+	} `json:"queueDatas"`
+	BrokerDatas []struct {
+		BrokerName  string            `json:"brokerName"`
+		BrokerAddrs map[string]string `json:"brokerAddrs"`
+	} `json:"brokerDatas"`
+}
+
+// numericKeyRegex matches the unquoted integer map keys written by the fastjson serializer of RocketMQ
+var numericKeyRegex = regexp.MustCompile(`([{,])(\d+):`)
+
+// NewClient returns a new RocketMQ client.
+func NewClient(c *Config) (*Client, error) {
+	if c.NameServer == "" {
+		return nil, errors.New("name server was not set")
+	}
+	if (c.AccessKey == "") != (c.SecretKey == "") {
+		return nil, errors.New("both access key and secret key must be provided")
+	}
+	return &Client{Config: c}, nil
+}
+
+// GetQueueOffsets returns the offsets of all the readable queues of the topic for the consumer group.
+func (c *Client) GetQueueOffsets(ctx context.Context, topic, consumerGroup string) ([]QueueOffset, error) {
+	route, err := c.getTopicRoute(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	masters := map[string]string{}
+	for _, broker := range route.BrokerDatas {
+		if addr, found := broker.BrokerAddrs[masterBrokerID]; found {
+			masters[broker.BrokerName] = addr
+		}
+	}
+
+	var offsets []QueueOffset
+	for _, queueData := range route.QueueDatas {
+		addr, found := masters[queueData.BrokerName]
+		if !found {
+			return nil, fmt.Errorf("no master found for broker %s", queueData.BrokerName)
+		}
+		brokerOffsets, err := c.getBrokerQueueOffsets(ctx, addr, queueData.BrokerName, topic, consumerGroup, queueData.ReadQueueNums)
+		if err != nil {
+			return nil, fmt.Errorf("error getting offsets from broker %s: %w", queueData.BrokerName, err)
+		}
+		offsets = append(offsets, brokerOffsets...)
+	}
+	return offsets, nil
+}
+
+func (c *Client) getTopicRoute(ctx context.Context, topic string) (*topicRouteData, error) {
+	conn, err := c.dial(ctx, c.NameServer)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	response, err := c.invoke(conn, codeGetRouteInfoByTopic, map[string]string{"topic": topic})
+	if err != nil {
+		return nil, fmt.Errorf("error getting route of topic %s: %w", topic, err)
+	}
+	if response.Code != codeSuccess {
+		return nil, fmt.Errorf("error getting route of topic %s: code %d, %s", topic, response.Code, response.Remark)
+	}
+
+	route := &topicRouteData{}
+	if err := json.Unmarshal(numericKeyRegex.ReplaceAll(response.Body, []byte(`$1"$2":`)), route); err != nil {
+		return nil, fmt.Errorf("error parsing route of topic %s: %w", topic, err)
+	}
+	return route, nil
+}
+
+func (c *Client) getBrokerQueueOffsets(ctx context.Context, addr, brokerName, topic, consumerGroup string, queues int) ([]QueueOffset, error) {
+	conn, err := c.dial(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	offsets := make([]QueueOffset, 0, queues)
+	for queueID := 0; queueID < queues; queueID++ {
+		offset := QueueOffset{BrokerName: brokerName, QueueID: queueID}
+
+		response, err := c.invoke(conn, codeGetMaxOffset, map[string]string{
+			"topic":   topic,
+			"queueId": strconv.Itoa(queueID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if offset.BrokerOffset, err = parseOffset(response); err != nil {
+			return nil, fmt.Errorf("error getting max offset of queue %d: %w", queueID, err)
+		}
+
+		response, err = c.invoke(conn, codeQueryConsumerOffset, map[string]string{
+			"consumerGroup": consumerGroup,
+			"topic":         topic,
+			"queueId":       strconv.Itoa(queueID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if response.Code != codeQueryNotFound {
+			if offset.ConsumerOffset, err = parseOffset(response); err != nil {
+				return nil, fmt.Errorf("error getting consumer offset of queue %d: %w", queueID, err)
+			}
+			offset.Committed = true
+		}
+
+		offsets = append(offsets, offset)
+	}
+	return offsets, nil
+}
+
+func parseOffset(response *remotingCommand) (int64, error) {
+	if response.Code != codeSuccess {
+		return 0, fmt.Errorf("code %d, %s", response.Code, response.Remark)
+	}
+	return strconv.ParseInt(response.ExtFields["offset"], 10, 64)
+}
+
+func (c *Client) dial(ctx context.Context, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", addr, err)
+	}
+	var deadline time.Time
+	if c.Timeout > 0 {
+		deadline = time.Now().Add(c.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// invoke sends the request over the connection and waits for its response
+func (c *Client) invoke(conn net.Conn, code int, extFields map[string]string) (*remotingCommand, error) {
+	request := &remotingCommand{
+		Code:      code,
+		Language:  "GO",
+		Opaque:    c.opaque.Add(1),
+		ExtFields: extFields,
+	}
+	if c.AccessKey != "" {
+		c.sign(request)
+	}
+
+	frame, err := encodeCommand(request)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(frame); err != nil {
+		return nil, err
+	}
+
+	response, err := decodeCommand(conn)
+	if err != nil {
+		return nil, err
+	}
+	if response.Flag&flagResponse == 0 || response.Opaque != request.Opaque {
+		return nil, fmt.Errorf("unexpected command received for request %d", request.Opaque)
+	}
+	return response, nil
+}
+
+// sign adds the ACL signature to the request, it's the HmacSHA1 of the values of
+// the extended fields sorted by their keys, followed by the body
+func (c *Client) sign(request *remotingCommand) {
+	request.ExtFields["AccessKey"] = c.AccessKey
+
+	keys := make([]string, 0, len(request.ExtFields))
+	for key := range request.ExtFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var content strings.Builder
+	for _, key := range keys {
+		content.WriteString(request.ExtFields[key])
+	}
+	content.Write(request.Body)
+
+	mac := hmac.New(sha1.New, []byte(c.SecretKey))
+	mac.Write([]byte(content.String()))
+	request.ExtFields["Signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encodeCommand encodes the command as a frame: the frame length, the serialization type
+// and the header length, the JSON header and the body
+func encodeCommand(command *remotingCommand) ([]byte, error) {
+	header, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+	frame := make([]byte, 8, 8+len(header)+len(command.Body))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(4+len(header)+len(command.Body)))
+	binary.BigEndian.PutUint32(frame[4:8], serializeTypeJSON<<24|uint32(len(header)))
+	frame = append(frame, header...)
+	return append(frame, command.Body...), nil
+}
+
+// decodeCommand reads a remoting command from the reader.
+func decodeCommand(r io.Reader) (*remotingCommand, error) {
+	var lengths [8]byte
+	if _, err := io.ReadFull(r, lengths[:]); err != nil {
+		return nil, err
+	}
+	frameLength := binary.BigEndian.Uint32(lengths[0:4])
+	headerInfo := binary.BigEndian.Uint32(lengths[4:8])
+	if headerInfo>>24 != serializeTypeJSON {
+		return nil, fmt.Errorf("unsupported serialization type %d", headerInfo>>24)
+	}
+	headerLength := headerInfo & 0xFFFFFF
+	if frameLength > maxFrameLength || headerLength > frameLength-4 {
+		return nil, fmt.Errorf("invalid frame of length %d with header of length %d", frameLength, headerLength)
+	}
+
+	data := make([]byte, frameLength-4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	command := &remotingCommand{}
+	if err := json.Unmarshal(data[:headerLength], command); err != nil {
+		return nil, fmt.Errorf("error parsing command header: %w", err)
+	}
+	command.Body = data[headerLength:]
+	return command, nil
+}
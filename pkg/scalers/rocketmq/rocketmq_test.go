@@ -0,0 +1,121 @@
+package rocketmq
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockServer answers the remoting commands with the handler until the listener is closed
+func mockServer(t *testing.T, handler func(request *remotingCommand) *remotingCommand) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				for {
+					request, err := decodeCommand(conn)
+					if err != nil {
+						return
+					}
+					response := handler(request)
+					response.Opaque = request.Opaque
+					response.Flag = flagResponse
+					frame, err := encodeCommand(response)
+					if err != nil {
+						return
+					}
+					if _, err := conn.Write(frame); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestGetQueueOffsets(t *testing.T) {
+	brokerOffsets := map[string]int64{"0": 100, "1": 50}
+	consumerOffsets := map[string]int64{"0": 40}
+
+	brokerAddr := mockServer(t, func(request *remotingCommand) *remotingCommand {
+		assert.Equal(t, "orders", request.ExtFields["topic"])
+		queueID := request.ExtFields["queueId"]
+		switch request.Code {
+		case codeGetMaxOffset:
+			return &remotingCommand{Code: codeSuccess, ExtFields: map[string]string{"offset": fmt.Sprint(brokerOffsets[queueID])}}
+		case codeQueryConsumerOffset:
+			assert.Equal(t, "billing", request.ExtFields["consumerGroup"])
+			if offset, found := consumerOffsets[queueID]; found {
+				return &remotingCommand{Code: codeSuccess, ExtFields: map[string]string{"offset": fmt.Sprint(offset)}}
+			}
+			return &remotingCommand{Code: codeQueryNotFound, Remark: "Not found"}
+		}
+		return &remotingCommand{Code: 1, Remark: "unexpected request"}
+	})
+	nameServerAddr := mockServer(t, func(request *remotingCommand) *remotingCommand {
+		assert.Equal(t, codeGetRouteInfoByTopic, request.Code)
+		// brokerAddrs is written by fastjson with unquoted integer keys
+		body := fmt.Sprintf(`{"brokerDatas":[{"brokerAddrs":{0:"%s",1:"127.0.0.1:1"},"brokerName":"broker-a","cluster":"DefaultCluster"}],`+
+			`"queueDatas":[{"brokerName":"broker-a","perm":6,"readQueueNums":2,"topicSysFlag":0,"writeQueueNums":2}]}`, brokerAddr)
+		return &remotingCommand{Code: codeSuccess, Body: []byte(body)}
+	})
+
+	client, err := NewClient(&Config{NameServer: nameServerAddr, Timeout: 5 * time.Second})
+	assert.NoError(t, err)
+
+	offsets, err := client.GetQueueOffsets(context.Background(), "orders", "billing")
+	assert.NoError(t, err)
+	assert.Equal(t, []QueueOffset{
+		{BrokerName: "broker-a", QueueID: 0, BrokerOffset: 100, ConsumerOffset: 40, Committed: true},
+		{BrokerName: "broker-a", QueueID: 1, BrokerOffset: 50},
+	}, offsets)
+	assert.Equal(t, int64(60), offsets[0].Lag())
+	assert.Equal(t, int64(50), offsets[1].Lag())
+}
+
+func TestGetQueueOffsetsTopicNotFound(t *testing.T) {
+	nameServerAddr := mockServer(t, func(*remotingCommand) *remotingCommand {
+		return &remotingCommand{Code: 17, Remark: "No topic route info in name server for the topic: orders"}
+	})
+
+	client, err := NewClient(&Config{NameServer: nameServerAddr, Timeout: 5 * time.Second})
+	assert.NoError(t, err)
+
+	_, err = client.GetQueueOffsets(context.Background(), "orders", "billing")
+	assert.ErrorContains(t, err, "No topic route info")
+}
+
+func TestSign(t *testing.T) {
+	client, err := NewClient(&Config{NameServer: "localhost:9876", AccessKey: "rocketmq2", SecretKey: "12345678"})
+	assert.NoError(t, err)
+
+	request := &remotingCommand{Code: codeGetRouteInfoByTopic, ExtFields: map[string]string{"topic": "orders"}}
+	client.sign(request)
+
+	assert.Equal(t, "rocketmq2", request.ExtFields["AccessKey"])
+	// base64(HmacSHA1("12345678", "rocketmq2" + "orders"))
+	assert.Equal(t, "v16wSxyxEf8y2zCbL4aQWhNf0j8=", request.ExtFields["Signature"])
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(&Config{})
+	assert.Error(t, err)
+
+	_, err = NewClient(&Config{NameServer: "localhost:9876", AccessKey: "rocketmq2"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/rocketmq"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const rocketMQOffsetResetPolicyLatest = "latest"
+
+type rocketMQScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *rocketMQMetadata
+	client     *rocketmq.Client
+	logger     logr.Logger
+}
+
+type rocketMQMetadata struct {
+	triggerIndex int
+
+	// NameServer is the address of the name server, or of the proxy for RocketMQ 5.x
+	NameServer             string `keda:"name=nameServer, order=triggerMetadata;resolvedEnv"`
+	Topic                  string `keda:"name=topic, order=triggerMetadata"`
+	ConsumerGroup          string `keda:"name=consumerGroup, order=triggerMetadata"`
+	LagThreshold           int64  `keda:"name=lagThreshold, order=triggerMetadata, optional, default=10"`
+	ActivationLagThreshold int64  `keda:"name=activationLagThreshold, order=triggerMetadata, optional, default=0"`
+	OffsetResetPolicy      string `keda:"name=offsetResetPolicy, order=triggerMetadata, enum=earliest;latest, optional, default=latest"`
+	AllowIdleConsumers     bool   `keda:"name=allowIdleConsumers, order=triggerMetadata, optional, default=false"`
+
+	// ACL
+	AccessKey string `keda:"name=accessKey, order=authParams;resolvedEnv, optional"`
+	SecretKey string `keda:"name=secretKey, order=authParams;resolvedEnv, optional"`
+}
+
+func (m *rocketMQMetadata) Validate() error {
+	if m.LagThreshold <= 0 {
+		return errors.New("lagThreshold must be a positive number")
+	}
+	if m.ActivationLagThreshold < 0 {
+		return errors.New("activationLagThreshold must be a positive number")
+	}
+	if (m.AccessKey == "") != (m.SecretKey == "") {
+		return errors.New("both accessKey and secretKey must be provided")
+	}
+	return nil
+}
+
+// NewRocketMQScaler creates a new rocketMQScaler
+func NewRocketMQScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseRocketMQMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rocketmq metadata: %w", err)
+	}
+
+	client, err := rocketmq.NewClient(&rocketmq.Config{
+		NameServer: meta.NameServer,
+		AccessKey:  meta.AccessKey,
+		SecretKey:  meta.SecretKey,
+		Timeout:    config.GlobalHTTPTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating rocketmq client: %w", err)
+	}
+
+	return &rocketMQScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "rocketmq_scaler"),
+	}, nil
+}
+
+func parseRocketMQMetadata(config *scalersconfig.ScalerConfig) (*rocketMQMetadata, error) {
+	meta := &rocketMQMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close is a no-op, the connections to RocketMQ are closed after each query
+func (s *rocketMQScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *rocketMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("rocketmq-%s-%s", s.metadata.Topic, s.metadata.ConsumerGroup))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.LagThreshold),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the lag of the consumer group on the topic
+func (s *rocketMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	offsets, err := s.client.GetQueueOffsets(ctx, s.metadata.Topic, s.metadata.ConsumerGroup)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting rocketmq consumer group lag: %w", err)
+	}
+
+	totalLag := s.totalLag(offsets)
+	metric := GenerateMetricInMili(metricName, float64(totalLag))
+	return []external_metrics.ExternalMetricValue{metric}, totalLag > s.metadata.ActivationLagThreshold, nil
+}
+
+// totalLag sums up the lag of the queues, a consumer group consumes each queue with
+// a single consumer so the lag is capped to the number of queues unless idle consumers are allowed
+func (s *rocketMQScaler) totalLag(offsets []rocketmq.QueueOffset) int64 {
+	var totalLag int64
+	for _, offset := range offsets {
+		if !offset.Committed && s.metadata.OffsetResetPolicy == rocketMQOffsetResetPolicyLatest {
+			// the consumer group starts from the latest offset, nothing to consume yet
+			continue
+		}
+		totalLag += offset.Lag()
+	}
+
+	if !s.metadata.AllowIdleConsumers {
+		if maxLag := int64(len(offsets)) * s.metadata.LagThreshold; totalLag > maxLag {
+			s.logger.V(1).Info(fmt.Sprintf("capping the lag to %d, the topic %s has %d queues", maxLag, s.metadata.Topic, len(offsets)))
+			totalLag = maxLag
+		}
+	}
+	return totalLag
+}
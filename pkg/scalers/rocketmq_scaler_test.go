@@ -0,0 +1,100 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/rocketmq"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseRocketMQMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type rocketMQMetricIdentifier struct {
+	metadataTestData *parseRocketMQMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testRocketMQMetadata = []parseRocketMQMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing"}, map[string]string{}, false, "required metadata only"},
+	{map[string]string{"nameServer": "rocketmq-proxy:8080", "topic": "orders", "consumerGroup": "billing", "lagThreshold": "20", "activationLagThreshold": "5", "offsetResetPolicy": "earliest", "allowIdleConsumers": "true"}, map[string]string{}, false, "all metadata"},
+	{map[string]string{"topic": "orders", "consumerGroup": "billing"}, map[string]string{}, true, "missing nameServer"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "consumerGroup": "billing"}, map[string]string{}, true, "missing topic"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders"}, map[string]string{}, true, "missing consumerGroup"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing", "lagThreshold": "0"}, map[string]string{}, true, "zero lagThreshold"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing", "activationLagThreshold": "-1"}, map[string]string{}, true, "negative activationLagThreshold"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing", "offsetResetPolicy": "none"}, map[string]string{}, true, "invalid offsetResetPolicy"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing"}, map[string]string{"accessKey": "rocketmq2", "secretKey": "12345678"}, false, "ACL credentials"},
+	{map[string]string{"nameServer": "rocketmq-namesrv:9876", "topic": "orders", "consumerGroup": "billing"}, map[string]string{"accessKey": "rocketmq2"}, true, "accessKey without secretKey"},
+}
+
+var rocketMQMetricIdentifiers = []rocketMQMetricIdentifier{
+	{&testRocketMQMetadata[1], 0, "s0-rocketmq-orders-billing"},
+	{&testRocketMQMetadata[2], 1, "s1-rocketmq-orders-billing"},
+}
+
+func TestRocketMQParseMetadata(t *testing.T) {
+	for _, testData := range testRocketMQMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseRocketMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestRocketMQGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range rocketMQMetricIdentifiers {
+		meta, err := parseRocketMQMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockRocketMQScaler := rocketMQScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockRocketMQScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestRocketMQTotalLag(t *testing.T) {
+	offsets := []rocketmq.QueueOffset{
+		{QueueID: 0, BrokerOffset: 100, ConsumerOffset: 40, Committed: true},
+		{QueueID: 1, BrokerOffset: 50},
+		{QueueID: 2, BrokerOffset: 10, ConsumerOffset: 10, Committed: true},
+	}
+
+	testCases := []struct {
+		name     string
+		metadata rocketMQMetadata
+		expected int64
+	}{
+		{"latest ignores uncommitted queues", rocketMQMetadata{LagThreshold: 100, OffsetResetPolicy: "latest"}, 60},
+		{"earliest counts uncommitted queues", rocketMQMetadata{LagThreshold: 100, OffsetResetPolicy: "earliest"}, 110},
+		{"lag capped to the number of queues", rocketMQMetadata{LagThreshold: 10, OffsetResetPolicy: "earliest"}, 30},
+		{"idle consumers allowed", rocketMQMetadata{LagThreshold: 10, OffsetResetPolicy: "earliest", AllowIdleConsumers: true}, 110},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := rocketMQScaler{metadata: &tc.metadata, logger: logr.Discard()}
+			assert.Equal(t, tc.expected, s.totalLag(offsets))
+		})
+	}
+}
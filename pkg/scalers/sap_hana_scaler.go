@@ -0,0 +1,192 @@
+package scalers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type sapHanaScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sapHanaMetadata
+	connection *sql.DB
+	logger     logr.Logger
+}
+
+type sapHanaMetadata struct {
+	triggerIndex int
+
+	// Host is the address of the SQL port of the database, e.g. hana:30015 or <instance>.hana.ondemand.com:443
+	Host          string `keda:"name=host,          order=triggerMetadata;authParams"`
+	DatabaseName  string `keda:"name=databaseName,  order=triggerMetadata;authParams, optional"`
+	DefaultSchema string `keda:"name=defaultSchema, order=triggerMetadata, optional"`
+	Username      string `keda:"name=username,      order=triggerMetadata;authParams;resolvedEnv, optional"`
+	Password      string `keda:"name=password,      order=authParams;resolvedEnv, optional"`
+
+	EnableTLS bool   `keda:"name=enableTLS, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, optional"`
+	Ca        string `keda:"name=ca,        order=authParams, optional"`
+	// Cert and Key authenticate the user with its X.509 certificate instead of a password
+	Cert string `keda:"name=cert, order=authParams, optional"`
+	Key  string `keda:"name=key,  order=authParams, optional"`
+
+	Query                      string  `keda:"name=query,                      order=triggerMetadata"`
+	TargetQueryValue           float64 `keda:"name=targetQueryValue,           order=triggerMetadata"`
+	ActivationTargetQueryValue float64 `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
+}
+
+func (m *sapHanaMetadata) Validate() error {
+	if _, _, err := net.SplitHostPort(m.Host); err != nil {
+		return fmt.Errorf("host must be of the form <host>:<port>: %w", err)
+	}
+	if m.TargetQueryValue <= 0 {
+		return errors.New("targetQueryValue must be greater than 0")
+	}
+
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	if m.Cert != "" {
+		if m.Username != "" || m.Password != "" {
+			return errors.New("username and password can't be used with cert and key")
+		}
+		if !m.EnableTLS {
+			return errors.New("enableTLS must be true to authenticate with cert and key")
+		}
+	} else if m.Username == "" || m.Password == "" {
+		return errors.New("either username and password or cert and key must be provided")
+	}
+	if !m.EnableTLS && m.Ca != "" {
+		return errors.New("enableTLS must be true to use ca")
+	}
+	return nil
+}
+
+// NewSapHanaScaler creates a new SAP HANA scaler
+func NewSapHanaScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSapHanaMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sap hana metadata: %w", err)
+	}
+
+	connector, err := newSapHanaConnector(meta, config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating sap hana connector: %w", err)
+	}
+	connection := sql.OpenDB(connector)
+	if err := connection.Ping(); err != nil {
+		connection.Close()
+		return nil, fmt.Errorf("error establishing sap hana connection: %w", err)
+	}
+
+	return &sapHanaScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: connection,
+		logger:     InitializeLogger(config, "sap_hana_scaler"),
+	}, nil
+}
+
+func parseSapHanaMetadata(config *scalersconfig.ScalerConfig) (*sapHanaMetadata, error) {
+	meta := &sapHanaMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// newSapHanaConnector returns the connector to the database, authenticating with the X.509 certificate when it is given
+func newSapHanaConnector(meta *sapHanaMetadata, config *scalersconfig.ScalerConfig) (*driver.Connector, error) {
+	var connector *driver.Connector
+	if meta.Cert != "" {
+		var err error
+		if connector, err = driver.NewX509AuthConnector(meta.Host, []byte(meta.Cert), []byte(meta.Key)); err != nil {
+			return nil, err
+		}
+	} else {
+		connector = driver.NewBasicAuthConnector(meta.Host, meta.Username, meta.Password)
+	}
+
+	if meta.EnableTLS {
+		tlsConfig, err := kedautil.NewTLSConfig("", "", meta.Ca, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName, _, _ = net.SplitHostPort(meta.Host)
+		connector.SetTLSConfig(tlsConfig)
+	}
+	if meta.DefaultSchema != "" {
+		connector.SetDefaultSchema(meta.DefaultSchema)
+	}
+	if config.GlobalHTTPTimeout > 0 {
+		connector.SetTimeout(config.GlobalHTTPTimeout)
+	}
+	if meta.DatabaseName != "" {
+		connector = connector.WithDatabase(meta.DatabaseName)
+	}
+	return connector, nil
+}
+
+// Close closes the connection to the database
+func (s *sapHanaScaler) Close(context.Context) error {
+	if s.connection != nil {
+		if err := s.connection.Close(); err != nil {
+			s.logger.Error(err, "Error closing sap hana connection")
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *sapHanaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := "sap-hana"
+	if s.metadata.DatabaseName != "" {
+		metricName = fmt.Sprintf("sap-hana-%s", s.metadata.DatabaseName)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the result of the query
+func (s *sapHanaScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting sap hana: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetQueryValue, nil
+}
+
+// getQueryResult returns the first column of the first row returned by the query, NULL being returned as 0
+func (s *sapHanaScaler) getQueryResult(ctx context.Context) (float64, error) {
+	var value sql.NullFloat64
+	if err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&value); err != nil {
+		return 0, err
+	}
+	return value.Float64, nil
+}
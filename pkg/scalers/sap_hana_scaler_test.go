@@ -0,0 +1,122 @@
+package scalers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseSapHanaMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type sapHanaMetricIdentifier struct {
+	metadataTestData *parseSapHanaMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSapHanaMetadata = []parseSapHanaMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "username": "KEDA"}, map[string]string{"password": "secret"}, false, "basic auth"},
+	{map[string]string{"host": "hana.hanacloud.ondemand.com:443", "databaseName": "HXE", "defaultSchema": "QUEUES", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "activationTargetQueryValue": "1", "enableTLS": "true"}, map[string]string{"cert": "cert", "key": "key"}, false, "X.509 auth"},
+	{map[string]string{"host": "hana", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "username": "KEDA"}, map[string]string{"password": "secret"}, true, "host without port"},
+	{map[string]string{"host": "hana:30015", "targetQueryValue": "10", "username": "KEDA"}, map[string]string{"password": "secret"}, true, "missing query"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "0", "username": "KEDA"}, map[string]string{"password": "secret"}, true, "zero targetQueryValue"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "username": "KEDA"}, map[string]string{}, true, "missing password"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "enableTLS": "true"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10"}, map[string]string{"cert": "cert", "key": "key"}, true, "X.509 auth without TLS"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "username": "KEDA", "enableTLS": "true"}, map[string]string{"password": "secret", "cert": "cert", "key": "key"}, true, "basic and X.509 auth"},
+	{map[string]string{"host": "hana:30015", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "username": "KEDA"}, map[string]string{"password": "secret", "ca": "ca"}, true, "ca without TLS"},
+}
+
+var sapHanaMetricIdentifiers = []sapHanaMetricIdentifier{
+	{&testSapHanaMetadata[1], 0, "s0-sap-hana"},
+	{&testSapHanaMetadata[2], 1, "s1-sap-hana-HXE"},
+}
+
+func TestSapHanaParseMetadata(t *testing.T) {
+	for _, testData := range testSapHanaMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseSapHanaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestSapHanaGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range sapHanaMetricIdentifiers {
+		meta, err := parseSapHanaMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSapHanaScaler := sapHanaScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockSapHanaScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSapHanaConnector(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "KEDA"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"host": "hana.hanacloud.ondemand.com:443", "databaseName": "HXE", "defaultSchema": "QUEUES", "query": "SELECT COUNT(*) FROM JOBS", "targetQueryValue": "10", "enableTLS": "true"},
+		AuthParams: map[string]string{
+			"cert": string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+			"key":  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+		},
+		GlobalHTTPTimeout: 5 * time.Second,
+	}
+	meta, err := parseSapHanaMetadata(config)
+	require.NoError(t, err)
+
+	connector, err := newSapHanaConnector(meta, config)
+	require.NoError(t, err)
+	assert.Equal(t, "hana.hanacloud.ondemand.com:443", connector.Host())
+	assert.Equal(t, "HXE", connector.DatabaseName())
+	assert.Equal(t, "QUEUES", connector.DefaultSchema())
+	assert.Equal(t, 5*time.Second, connector.Timeout())
+	assert.Equal(t, "hana.hanacloud.ondemand.com", connector.TLSConfig().ServerName)
+
+	config.AuthParams["key"] = "not a key"
+	meta, err = parseSapHanaMetadata(config)
+	require.NoError(t, err)
+	_, err = newSapHanaConnector(meta, config)
+	assert.Error(t, err)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	metrics "github.com/rcrowley/go-metrics"
@@ -62,6 +63,20 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// PushScalerConnectionState is the state of the connection of a push scaler to the server pushing the activity
+type PushScalerConnectionState struct {
+	Connected bool
+	// LastMessageTime is when the last message was received, zero if none was
+	LastMessageTime time.Time
+	// Err is the reason of the last disconnection
+	Err error
+}
+
+// ConnectionStateReporter is implemented by the push scalers reporting the state of their connection
+type ConnectionStateReporter interface {
+	ConnectionState() PushScalerConnectionState
+}
+
 var (
 	// ErrScalerUnsupportedUtilizationMetricType is returned when v2.UtilizationMetricType
 	// is provided as the metric target type for scaler.
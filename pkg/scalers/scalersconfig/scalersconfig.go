@@ -50,6 +50,16 @@ type ScalerConfig struct {
 	// Any requests for metrics in between are read from the cache
 	TriggerUseCachedMetrics bool
 
+	// Marks whether the trigger is a canary, its metrics don't contribute to the scaling decision
+	TriggerCanary bool
+
+	// Marks whether the metrics of the trigger are replaced by their rate of change by second over the window
+	TriggerRateOfChange       bool
+	TriggerRateOfChangeWindow time.Duration
+
+	// PollingInterval is the interval the scale loop polls the scalable object at
+	PollingInterval time.Duration
+
 	// TriggerMetadata
 	TriggerMetadata map[string]string
 
@@ -0,0 +1,274 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	sidekiqScaleOnLatency = "latency"
+
+	sidekiqScheduleKey = "schedule"
+	sidekiqRetryKey    = "retry"
+
+	// sidekiqMillisecondsThreshold tells apart the timestamps in milliseconds written by
+	// Sidekiq 8 from the timestamps in seconds written by the previous versions
+	sidekiqMillisecondsThreshold = 1e11
+)
+
+type sidekiqScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sidekiqMetadata
+	client     redis.UniversalClient
+	logger     logr.Logger
+}
+
+type sidekiqMetadata struct {
+	Queues                []string            `keda:"name=queues,                  order=triggerMetadata, optional, default=default"`
+	Namespace             string              `keda:"name=namespace,               order=triggerMetadata, optional"`
+	ScaleOn               string              `keda:"name=scaleOn,                 order=triggerMetadata, enum=depth;latency, optional, default=depth"`
+	TargetQueueLength     int64               `keda:"name=targetQueueLength,       order=triggerMetadata, optional, default=5"`
+	ActivationQueueLength int64               `keda:"name=activationQueueLength,   order=triggerMetadata, optional"`
+	TargetLatency         float64             `keda:"name=targetLatency,           order=triggerMetadata, optional, default=30"`
+	ActivationLatency     float64             `keda:"name=activationLatency,       order=triggerMetadata, optional"`
+	IncludeScheduled      bool                `keda:"name=includeScheduled,        order=triggerMetadata, optional, default=true"`
+	IncludeRetries        bool                `keda:"name=includeRetries,          order=triggerMetadata, optional, default=true"`
+	DatabaseIndex         int                 `keda:"name=databaseIndex,           order=triggerMetadata, optional"`
+	MetadataEnableTLS     string              `keda:"name=enableTLS,               order=triggerMetadata, optional"`
+	AuthParamEnableTLS    string              `keda:"name=tls,                     order=authParams, optional"`
+	ConnectionInfo        redisConnectionInfo `keda:"optional"`
+	triggerIndex          int
+}
+
+// sidekiqJob is the subset of the payload of a Sidekiq job used by the scaler
+type sidekiqJob struct {
+	Queue      string  `json:"queue"`
+	EnqueuedAt float64 `json:"enqueued_at"`
+}
+
+// sidekiqQueueStats contains the jobs of the queues ready to be processed and the age of the oldest one
+type sidekiqQueueStats struct {
+	Depth   int64
+	Latency float64
+}
+
+func (m *sidekiqMetadata) Validate() error {
+	if m.TargetQueueLength <= 0 {
+		return errors.New("targetQueueLength must be greater than 0")
+	}
+	if m.TargetLatency <= 0 {
+		return errors.New("targetLatency must be greater than 0")
+	}
+
+	if err := validateRedisAddress(&m.ConnectionInfo); err != nil {
+		return err
+	}
+
+	err := m.ConnectionInfo.SetEnableTLS(m.MetadataEnableTLS, m.AuthParamEnableTLS)
+	if err == nil {
+		m.MetadataEnableTLS, m.AuthParamEnableTLS = "", ""
+	}
+	return err
+}
+
+// key returns the key in the namespace of redis-namespace, if any
+func (m *sidekiqMetadata) key(key string) string {
+	if m.Namespace == "" {
+		return key
+	}
+	return m.Namespace + ":" + key
+}
+
+// NewSidekiqScaler creates a new sidekiqScaler
+func NewSidekiqScaler(ctx context.Context, isSentinel bool, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSidekiqMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sidekiq metadata: %w", err)
+	}
+
+	var client *redis.Client
+	if isSentinel {
+		client, err = getRedisSentinelClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis sentinel failed: %w", err)
+		}
+	} else {
+		client, err = getRedisClient(ctx, meta.ConnectionInfo, meta.DatabaseIndex)
+		if err != nil {
+			return nil, fmt.Errorf("connection to redis failed: %w", err)
+		}
+	}
+
+	return &sidekiqScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "sidekiq_scaler"),
+	}, nil
+}
+
+func parseSidekiqMetadata(config *scalersconfig.ScalerConfig) (*sidekiqMetadata, error) {
+	meta := &sidekiqMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing sidekiq metadata: %w", err)
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+func (s *sidekiqScaler) Close(context.Context) error {
+	if err := s.client.Close(); err != nil {
+		s.logger.Error(err, "error closing redis client")
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *sidekiqScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := util.NormalizeString(fmt.Sprintf("sidekiq-%s-%s", s.metadata.ScaleOn, strings.Join(s.metadata.Queues, "-")))
+	target := GetMetricTarget(s.metricType, s.metadata.TargetQueueLength)
+	if s.metadata.ScaleOn == sidekiqScaleOnLatency {
+		target = GetMetricTargetMili(s.metricType, s.metadata.TargetLatency)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: target,
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the depth or the latency of the queues
+func (s *sidekiqScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	stats, err := s.getQueueStats(ctx, time.Now())
+	if err != nil {
+		s.logger.Error(err, "error getting sidekiq queue stats")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	if s.metadata.ScaleOn == sidekiqScaleOnLatency {
+		metric := GenerateMetricInMili(metricName, stats.Latency)
+		return []external_metrics.ExternalMetricValue{metric}, stats.Latency > s.metadata.ActivationLatency, nil
+	}
+	metric := GenerateMetricInMili(metricName, float64(stats.Depth))
+	return []external_metrics.ExternalMetricValue{metric}, stats.Depth > s.metadata.ActivationQueueLength, nil
+}
+
+// getQueueStats sums up the jobs enqueued in the queues and, if included, the scheduled jobs and the retries
+// which are due: they're only enqueued by the poller of a running Sidekiq process, so nothing picks them up
+// when the workers are scaled to zero. The latency is the age of the oldest of those jobs.
+func (s *sidekiqScaler) getQueueStats(ctx context.Context, now time.Time) (*sidekiqQueueStats, error) {
+	lengths := make([]*redis.IntCmd, len(s.metadata.Queues))
+	oldest := make([]*redis.StringCmd, len(s.metadata.Queues))
+	// the error of each command is checked below, LINDEX of an empty queue fails with redis.Nil
+	_, _ = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, queue := range s.metadata.Queues {
+			key := s.metadata.key("queue:" + queue)
+			lengths[i] = pipe.LLen(ctx, key)
+			// jobs are pushed on the left and fetched from the right
+			oldest[i] = pipe.LIndex(ctx, key, -1)
+		}
+		return nil
+	})
+
+	stats := &sidekiqQueueStats{}
+	for i, queue := range s.metadata.Queues {
+		length, err := lengths[i].Result()
+		if err != nil {
+			return nil, fmt.Errorf("error getting length of queue %s: %w", queue, err)
+		}
+		stats.Depth += length
+
+		payload, err := oldest[i].Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting oldest job of queue %s: %w", queue, err)
+		}
+		var job sidekiqJob
+		if err := json.Unmarshal([]byte(payload), &job); err != nil {
+			return nil, fmt.Errorf("error parsing job of queue %s: %w", queue, err)
+		}
+		if job.EnqueuedAt > 0 {
+			stats.Latency = math.Max(stats.Latency, now.Sub(sidekiqTime(job.EnqueuedAt)).Seconds())
+		}
+	}
+
+	var sortedSets []string
+	if s.metadata.IncludeScheduled {
+		sortedSets = append(sortedSets, sidekiqScheduleKey)
+	}
+	if s.metadata.IncludeRetries {
+		sortedSets = append(sortedSets, sidekiqRetryKey)
+	}
+	for _, sortedSet := range sortedSets {
+		if err := s.addDueJobs(ctx, stats, s.metadata.key(sortedSet), now); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// addDueJobs adds the jobs of the queues in the sorted set, scored by the time they're due, which are due at the given time
+func (s *sidekiqScaler) addDueJobs(ctx context.Context, stats *sidekiqQueueStats, key string, now time.Time) error {
+	dueJobs, err := s.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatFloat(float64(now.UnixMicro())/1e6, 'f', -1, 64),
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("error getting due jobs of %s: %w", key, err)
+	}
+
+	for _, dueJob := range dueJobs {
+		payload, ok := dueJob.Member.(string)
+		if !ok {
+			continue
+		}
+		var job sidekiqJob
+		if err := json.Unmarshal([]byte(payload), &job); err != nil {
+			s.logger.V(1).Info("skipping malformed job", "key", key, "error", err)
+			continue
+		}
+		if !slices.Contains(s.metadata.Queues, job.Queue) {
+			continue
+		}
+		stats.Depth++
+		stats.Latency = math.Max(stats.Latency, now.Sub(sidekiqTime(dueJob.Score)).Seconds())
+	}
+	return nil
+}
+
+// sidekiqTime converts a timestamp of Sidekiq, in seconds or in milliseconds depending on its version, to a time
+func sidekiqTime(timestamp float64) time.Time {
+	if timestamp > sidekiqMillisecondsThreshold {
+		return time.UnixMilli(int64(timestamp))
+	}
+	return time.UnixMicro(int64(timestamp * 1e6))
+}
@@ -0,0 +1,95 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseSidekiqMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type sidekiqMetricIdentifier struct {
+	metadataTestData *parseSidekiqMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSidekiqMetadata = []parseSidekiqMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"address": "localhost:6379"}, map[string]string{}, false, "default queue"},
+	{map[string]string{"queues": "critical,default", "namespace": "myapp", "scaleOn": "latency", "targetLatency": "10", "activationLatency": "1", "hostFromEnv": "REDIS_HOST", "portFromEnv": "REDIS_PORT"}, map[string]string{}, false, "latency on several queues"},
+	{map[string]string{"queues": "default", "targetQueueLength": "20", "activationQueueLength": "5", "includeScheduled": "false", "includeRetries": "false"}, map[string]string{"address": "localhost:6379", "password": "secret"}, false, "depth without scheduled jobs and retries"},
+	{map[string]string{"queues": "default", "scaleOn": "throughput", "address": "localhost:6379"}, map[string]string{}, true, "invalid scaleOn"},
+	{map[string]string{"queues": "default", "targetQueueLength": "0", "address": "localhost:6379"}, map[string]string{}, true, "zero targetQueueLength"},
+	{map[string]string{"queues": "default", "targetLatency": "-1", "address": "localhost:6379"}, map[string]string{}, true, "negative targetLatency"},
+	{map[string]string{"queues": "default"}, map[string]string{}, true, "missing address"},
+	{map[string]string{"queues": "default"}, map[string]string{"addresses": "sentinel:26379", "sentinelMaster": "mymaster"}, false, "sentinel in authParams"},
+}
+
+var sidekiqMetricIdentifiers = []sidekiqMetricIdentifier{
+	{&testSidekiqMetadata[1], 0, "s0-sidekiq-depth-default"},
+	{&testSidekiqMetadata[2], 1, "s1-sidekiq-latency-critical-default"},
+}
+
+func TestSidekiqParseMetadata(t *testing.T) {
+	for _, testData := range testSidekiqMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseSidekiqMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testRedisResolvedEnv})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestSidekiqGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range sidekiqMetricIdentifiers {
+		meta, err := parseSidekiqMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testRedisResolvedEnv, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSidekiqScaler := sidekiqScaler{metricType: v2.AverageValueMetricType, metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockSidekiqScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSidekiqLatencyTarget(t *testing.T) {
+	meta, err := parseSidekiqMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testSidekiqMetadata[2].metadata, ResolvedEnv: testRedisResolvedEnv})
+	assert.NoError(t, err)
+	mockSidekiqScaler := sidekiqScaler{metricType: v2.AverageValueMetricType, metadata: meta, logger: logr.Discard()}
+
+	metricSpec := mockSidekiqScaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, int64(10000), metricSpec[0].External.Target.AverageValue.MilliValue())
+}
+
+func TestSidekiqKey(t *testing.T) {
+	assert.Equal(t, "queue:default", (&sidekiqMetadata{}).key("queue:default"))
+	assert.Equal(t, "myapp:queue:default", (&sidekiqMetadata{Namespace: "myapp"}).key("queue:default"))
+}
+
+func TestSidekiqTime(t *testing.T) {
+	expected := time.UnixMilli(1700000000123)
+
+	// seconds with a fraction before Sidekiq 8, milliseconds since
+	assert.Equal(t, expected, sidekiqTime(1700000000.123))
+	assert.Equal(t, expected, sidekiqTime(1700000000123))
+}
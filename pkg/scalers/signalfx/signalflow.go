@@ -0,0 +1,177 @@
+package signalfx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxEventLength protects against reading garbage as an event of the stream
+const maxEventLength = 8 * 1024 * 1024
+
+// Client executes SignalFlow programs with the REST API of the stream endpoint of a realm of Splunk Observability
+// Cloud, as per https://dev.splunk.com/observability/reference/api/signalflow/latest
+type Client struct {
+	httpClient *http.Client
+	streamURL  string
+	token      string
+}
+
+// NewClient returns a client of the stream endpoint of the realm, e.g. us1, authenticated with the access token
+func NewClient(httpClient *http.Client, realm, token string) *Client {
+	return NewClientWithURL(httpClient, fmt.Sprintf("https://stream.%s.signalfx.com", realm), token)
+}
+
+// NewClientWithURL returns a client of the stream endpoint at the URL, e.g. a proxy
+func NewClientWithURL(httpClient *http.Client, streamURL, token string) *Client {
+	return &Client{httpClient: httpClient, streamURL: strings.TrimRight(streamURL, "/"), token: token}
+}
+
+type dataMessage struct {
+	Data []struct {
+		TSID  string   `json:"tsId"`
+		Value *float64 `json:"value"`
+	} `json:"data"`
+	LogicalTimestampMs int64 `json:"logicalTimestampMs"`
+}
+
+type controlMessage struct {
+	Event string `json:"event"`
+}
+
+type errorMessage struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+	Context struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"context"`
+}
+
+// Execute runs the program over the window between start and stop, and returns the latest value of each time
+// series it published, by id of time series
+func (c *Client) Execute(ctx context.Context, program string, start, stop time.Time) (map[string]float64, error) {
+	params := url.Values{}
+	params.Set("start", strconv.FormatInt(start.UnixMilli(), 10))
+	params.Set("stop", strconv.FormatInt(stop.UnixMilli(), 10))
+	params.Set("immediate", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.streamURL+"/v2/signalflow/execute?"+params.Encode(), strings.NewReader(program))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-SF-Token", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("signalflow api returned error. status: %d response: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	values := make(map[string]float64)
+	err = readEvents(resp.Body, func(event string, data []byte) (bool, error) {
+		switch event {
+		case "data":
+			var message dataMessage
+			if err := json.Unmarshal(data, &message); err != nil {
+				return false, fmt.Errorf("error decoding the data of the stream: %w", err)
+			}
+			for _, point := range message.Data {
+				if point.Value != nil {
+					values[point.TSID] = *point.Value
+				}
+			}
+		case "error":
+			var message errorMessage
+			if err := json.Unmarshal(data, &message); err != nil {
+				return false, fmt.Errorf("signalflow program failed: %s", data)
+			}
+			return false, message.toError()
+		case "control-message":
+			var message controlMessage
+			if err := json.Unmarshal(data, &message); err != nil {
+				return false, fmt.Errorf("error decoding the control message of the stream: %w", err)
+			}
+			// the computation is over, the connection being kept open for some time otherwise
+			if message.Event == "END_OF_CHANNEL" || message.Event == "CHANNEL_ABORT" {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (m *errorMessage) toError() error {
+	messages := make([]string, 0, len(m.Context.Errors)+1)
+	if m.Message != "" {
+		messages = append(messages, m.Message)
+	}
+	for _, e := range m.Context.Errors {
+		messages = append(messages, e.Message)
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("signalflow program failed with error %d", m.Error)
+	}
+	return fmt.Errorf("signalflow program failed: %s", strings.Join(messages, ", "))
+}
+
+// readEvents reads the server-sent events of the stream, passing them to the handler until it's done
+func readEvents(r io.Reader, handler func(event string, data []byte) (bool, error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEventLength)
+
+	var event string
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				done, err := handler(event, data)
+				if err != nil || done {
+					return err
+				}
+			}
+			event, data = "", nil
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		_, err := handler(event, data)
+		return err
+	}
+	return nil
+}
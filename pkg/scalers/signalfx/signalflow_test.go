@@ -0,0 +1,72 @@
+package signalfx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProgram = "data('cpu.utilization', filter=filter('service', 'checkout')).mean().publish()"
+
+func TestExecute(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	stop := start.Add(time.Minute)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/signalflow/execute", r.URL.Path)
+		assert.Equal(t, "1700000000000", r.URL.Query().Get("start"))
+		assert.Equal(t, "1700000060000", r.URL.Query().Get("stop"))
+		assert.Equal(t, "token", r.Header.Get("X-SF-Token"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, testProgram, string(body))
+
+		_, _ = io.WriteString(w, "event: control-message\ndata: {\"event\":\"STREAM_START\"}\n\n"+
+			"event: metadata\ndata: {\"tsId\":\"AAAA\",\"properties\":{}}\n\n"+
+			"event: data\ndata: {\ndata: \"data\":[{\"tsId\":\"AAAA\",\"value\":10},{\"tsId\":\"BBBB\",\"value\":20}],\"logicalTimestampMs\":1700000010000}\n\n"+
+			"event: data\ndata: {\"data\":[{\"tsId\":\"AAAA\",\"value\":15},{\"tsId\":\"BBBB\",\"value\":null}],\"logicalTimestampMs\":1700000020000}\n\n"+
+			"event: control-message\ndata: {\"event\":\"END_OF_CHANNEL\"}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClientWithURL(server.Client(), server.URL+"/", "token")
+	values, err := client.Execute(context.Background(), testProgram, start, stop)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"AAAA": 15, "BBBB": 20}, values)
+}
+
+func TestExecuteErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		status   int
+		response string
+		expected string
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"message":"Unauthorized"}`, "status: 401"},
+		{"invalid program", http.StatusOK, "event: error\ndata: {\"error\":400,\"message\":\"Program failed\",\"context\":{\"errors\":[{\"message\":\"unknown function 'dat'\"}]}}\n\n", "unknown function 'dat'"},
+		{"invalid data", http.StatusOK, "event: data\ndata: {\"data\":\n\n", "error decoding the data of the stream"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = io.WriteString(w, tc.response)
+			}))
+			defer server.Close()
+
+			client := NewClientWithURL(server.Client(), server.URL, "token")
+			_, err := client.Execute(context.Background(), testProgram, time.Now().Add(-time.Minute), time.Now())
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	client := NewClient(http.DefaultClient, "us1", "token")
+	assert.Equal(t, "https://stream.us1.signalfx.com", client.streamURL)
+}
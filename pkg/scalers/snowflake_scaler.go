@@ -0,0 +1,269 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/youmark/pkcs8"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	snowflakeStatementsPath = "/api/v2/statements"
+	// snowflakeJWTLifetime is the lifetime of the tokens, Snowflake rejects tokens valid for more than an hour
+	snowflakeJWTLifetime = time.Hour
+	// snowflakePollInterval is the interval between the checks of the status of a statement still running
+	snowflakePollInterval = 500 * time.Millisecond
+)
+
+type snowflakeScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *snowflakeMetadata
+	httpClient *http.Client
+	privateKey *rsa.PrivateKey
+	logger     logr.Logger
+}
+
+type snowflakeMetadata struct {
+	triggerIndex int
+
+	// Account is the account identifier, e.g. myorg-myaccount or xy12345.us-east-1
+	Account                    string  `keda:"name=account,                    order=triggerMetadata;authParams"`
+	Endpoint                   string  `keda:"name=endpoint,                   order=triggerMetadata, optional"`
+	Warehouse                  string  `keda:"name=warehouse,                  order=triggerMetadata, optional"`
+	Database                   string  `keda:"name=database,                   order=triggerMetadata, optional"`
+	Schema                     string  `keda:"name=schema,                     order=triggerMetadata, optional"`
+	Role                       string  `keda:"name=role,                       order=triggerMetadata;authParams, optional"`
+	Query                      string  `keda:"name=query,                      order=triggerMetadata"`
+	TargetQueryValue           float64 `keda:"name=targetQueryValue,           order=triggerMetadata"`
+	ActivationTargetQueryValue float64 `keda:"name=activationTargetQueryValue, order=triggerMetadata, optional"`
+
+	Username             string `keda:"name=username,             order=triggerMetadata;authParams;resolvedEnv"`
+	PrivateKey           string `keda:"name=privateKey,           order=authParams;resolvedEnv"`
+	PrivateKeyPassphrase string `keda:"name=privateKeyPassphrase, order=authParams;resolvedEnv, optional"`
+}
+
+type snowflakeStatementRequest struct {
+	Statement string `json:"statement"`
+	Warehouse string `json:"warehouse,omitempty"`
+	Database  string `json:"database,omitempty"`
+	Schema    string `json:"schema,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+type snowflakeStatementResponse struct {
+	Code               string      `json:"code"`
+	Message            string      `json:"message"`
+	StatementStatusURL string      `json:"statementStatusUrl"`
+	Data               [][]*string `json:"data"`
+}
+
+func (m *snowflakeMetadata) Validate() error {
+	if m.TargetQueryValue <= 0 {
+		return errors.New("targetQueryValue must be greater than 0")
+	}
+	if m.Endpoint == "" {
+		m.Endpoint = fmt.Sprintf("https://%s.snowflakecomputing.com", m.Account)
+	}
+	m.Endpoint = strings.TrimSuffix(m.Endpoint, "/")
+	return nil
+}
+
+// NewSnowflakeScaler creates a new snowflakeScaler
+func NewSnowflakeScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSnowflakeMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing snowflake metadata: %w", err)
+	}
+
+	privateKey, err := parseSnowflakePrivateKey(meta.PrivateKey, meta.PrivateKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing snowflake private key: %w", err)
+	}
+
+	return &snowflakeScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		privateKey: privateKey,
+		logger:     InitializeLogger(config, "snowflake_scaler"),
+	}, nil
+}
+
+func parseSnowflakeMetadata(config *scalersconfig.ScalerConfig) (*snowflakeMetadata, error) {
+	meta := &snowflakeMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// parseSnowflakePrivateKey parses the PKCS#8 private key of the key pair of the user, which may be encrypted
+func parseSnowflakePrivateKey(privateKey, passphrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return pkcs8.ParsePKCS8PrivateKeyRSA(block.Bytes, []byte(passphrase))
+}
+
+// Close closes the http client connection.
+func (s *snowflakeScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *snowflakeScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := "snowflake"
+	if s.metadata.Database != "" {
+		name += "-" + s.metadata.Database
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueryValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the result of the query
+func (s *snowflakeScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting snowflake: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetQueryValue, nil
+}
+
+// getQueryResult submits the query with the SQL API and returns the first column of its first row,
+// NULL being returned as 0. The status of the statement is polled when it doesn't complete right away.
+func (s *snowflakeScaler) getQueryResult(ctx context.Context) (float64, error) {
+	body, err := json.Marshal(snowflakeStatementRequest{
+		Statement: s.metadata.Query,
+		Warehouse: s.metadata.Warehouse,
+		Database:  s.metadata.Database,
+		Schema:    s.metadata.Schema,
+		Role:      s.metadata.Role,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	response, status, err := s.doRequest(ctx, http.MethodPost, snowflakeStatementsPath, body)
+	for err == nil && status == http.StatusAccepted {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(snowflakePollInterval):
+		}
+		response, status, err = s.doRequest(ctx, http.MethodGet, response.StatementStatusURL, nil)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if len(response.Data) == 0 || len(response.Data[0]) == 0 {
+		return 0, errors.New("query returned no row")
+	}
+	value := response.Data[0][0]
+	if value == nil {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(*value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("query returned %q instead of a number: %w", *value, err)
+	}
+	return number, nil
+}
+
+func (s *snowflakeScaler) doRequest(ctx context.Context, method, path string, body []byte) (*snowflakeStatementResponse, int, error) {
+	token, err := s.getJWT()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error signing jwt: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.metadata.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", "KEYPAIR_JWT")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	response := &snowflakeStatementResponse{}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		if json.Unmarshal(respBody, response) == nil && response.Message != "" {
+			return nil, 0, fmt.Errorf("unexpected status code %d from snowflake: code %s, %s", resp.StatusCode, response.Code, response.Message)
+		}
+		return nil, 0, fmt.Errorf("unexpected status code %d from snowflake: %s", resp.StatusCode, string(respBody))
+	}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return nil, 0, fmt.Errorf("error parsing snowflake response: %w", err)
+	}
+	return response, resp.StatusCode, nil
+}
+
+// getJWT returns a token for key pair authentication, issued by the fingerprint of the public key of the user
+func (s *snowflakeScaler) getJWT() (string, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(&s.privateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	fingerprint := sha256.Sum256(publicKey)
+
+	// the account locator is used without its region and cloud
+	account, _, _ := strings.Cut(strings.ToUpper(s.metadata.Account), ".")
+	subject := account + "." + strings.ToUpper(s.metadata.Username)
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": subject + ".SHA256:" + base64.StdEncoding.EncodeToString(fingerprint[:]),
+		"sub": subject,
+		"iat": now.Unix(),
+		"exp": now.Add(snowflakeJWTLifetime).Unix(),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.privateKey)
+}
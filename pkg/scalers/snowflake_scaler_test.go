@@ -0,0 +1,160 @@
+package scalers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseSnowflakeMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type snowflakeMetricIdentifier struct {
+	metadataTestData *parseSnowflakeMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSnowflakeMetadata = []parseSnowflakeMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"account": "myorg-myaccount", "query": "SELECT count(*) FROM tasks", "targetQueryValue": "10"}, map[string]string{"username": "keda", "privateKey": "key"}, false, "required metadata"},
+	{map[string]string{"account": "xy12345.us-east-1", "warehouse": "etl", "database": "pipelines", "schema": "public", "role": "scaler", "query": "SELECT count(*) FROM tasks", "targetQueryValue": "10", "activationTargetQueryValue": "1"}, map[string]string{"username": "keda", "privateKey": "key", "privateKeyPassphrase": "secret"}, false, "all metadata"},
+	{map[string]string{"account": "myorg-myaccount", "targetQueryValue": "10"}, map[string]string{"username": "keda", "privateKey": "key"}, true, "missing query"},
+	{map[string]string{"account": "myorg-myaccount", "query": "SELECT count(*) FROM tasks", "targetQueryValue": "0"}, map[string]string{"username": "keda", "privateKey": "key"}, true, "zero targetQueryValue"},
+	{map[string]string{"account": "myorg-myaccount", "query": "SELECT count(*) FROM tasks", "targetQueryValue": "10"}, map[string]string{"username": "keda"}, true, "missing private key"},
+}
+
+var snowflakeMetricIdentifiers = []snowflakeMetricIdentifier{
+	{&testSnowflakeMetadata[1], 0, "s0-snowflake"},
+	{&testSnowflakeMetadata[2], 1, "s1-snowflake-pipelines"},
+}
+
+func TestSnowflakeParseMetadata(t *testing.T) {
+	for _, testData := range testSnowflakeMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseSnowflakeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestSnowflakeGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range snowflakeMetricIdentifiers {
+		meta, err := parseSnowflakeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSnowflakeScaler := snowflakeScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockSnowflakeScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSnowflakeGetMetricsAndActivity(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privateKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "KEYPAIR_JWT", r.Header.Get("X-Snowflake-Authorization-Token-Type"))
+		token, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), func(*jwt.Token) (interface{}, error) {
+			return &privateKey.PublicKey, nil
+		})
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		subject, _ := token.Claims.GetSubject()
+		assert.Equal(t, "XY12345.KEDA", subject)
+		issuer, _ := token.Claims.GetIssuer()
+		assert.True(t, strings.HasPrefix(issuer, "XY12345.KEDA.SHA256:"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/statements":
+			var request snowflakeStatementRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "etl", request.Warehouse)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"code":"333334","message":"Asynchronous execution in progress.","statementHandle":"01b2","statementStatusUrl":"/api/v2/statements/01b2"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/statements/01b2" && polls == 0:
+			polls++
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"code":"333334","message":"Asynchronous execution in progress.","statementHandle":"01b2","statementStatusUrl":"/api/v2/statements/01b2"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/statements/01b2":
+			_, _ = w.Write([]byte(`{"code":"090001","message":"Statement executed successfully.","data":[["17"]]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s, err := NewSnowflakeScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"account":                    "xy12345.us-east-1",
+			"endpoint":                   server.URL,
+			"warehouse":                  "etl",
+			"query":                      "SELECT count(*) FROM tasks WHERE state = 'PENDING'",
+			"targetQueryValue":           "5",
+			"activationTargetQueryValue": "20",
+		},
+		AuthParams: map[string]string{"username": "keda", "privateKey": privateKeyPEM},
+	})
+	require.NoError(t, err)
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "snowflake")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+	assert.Equal(t, int64(17), metrics[0].Value.Value())
+}
+
+func TestSnowflakeGetMetricsAndActivityError(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"code":"002003","message":"SQL compilation error: Object 'TASKS' does not exist or not authorized."}`))
+	}))
+	defer server.Close()
+
+	s, err := NewSnowflakeScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"account": "myorg-myaccount", "endpoint": server.URL, "query": "SELECT count(*) FROM tasks", "targetQueryValue": "5"},
+		AuthParams:      map[string]string{"username": "keda", "privateKey": string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))},
+	})
+	require.NoError(t, err)
+
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "snowflake")
+	assert.ErrorContains(t, err, "SQL compilation error")
+}
@@ -0,0 +1,164 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/signalfx"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	splunkObservabilityAggregatorMax = "max"
+	splunkObservabilityAggregatorMin = "min"
+	splunkObservabilityAggregatorAvg = "avg"
+	splunkObservabilityAggregatorSum = "sum"
+)
+
+// splunkObservabilityScaler scales on the result of a SignalFlow program of Splunk Observability Cloud
+type splunkObservabilityScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *splunkObservabilityMetadata
+	httpClient *http.Client
+	client     *signalfx.Client
+	logger     logr.Logger
+}
+
+type splunkObservabilityMetadata struct {
+	AccessToken string `keda:"name=accessToken, order=authParams"`
+	Realm       string `keda:"name=realm,       order=triggerMetadata;authParams"`
+	// Query is the SignalFlow program, publishing the time series the scaler scales on
+	Query string `keda:"name=query,       order=triggerMetadata"`
+	// Duration is the number of seconds of data the program is executed over, the latest value of each time
+	// series being used
+	Duration              int     `keda:"name=duration,              order=triggerMetadata, default=60"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+	// QueryAggregator is how the values of the time series published by the program are combined
+	QueryAggregator string `keda:"name=queryAggregator,       order=triggerMetadata, enum=max;min;avg;sum, default=max"`
+	// MetricUnavailableValue is the value when the program published no data, an error otherwise
+	MetricUnavailableValue float64 `keda:"name=metricUnavailableValue, order=triggerMetadata, optional"`
+	useFiller              bool
+	triggerIndex           int
+}
+
+func (m *splunkObservabilityMetadata) Validate() error {
+	if m.Duration <= 0 {
+		return errors.New("duration must be greater than 0")
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// NewSplunkObservabilityScaler creates a new splunkObservabilityScaler
+func NewSplunkObservabilityScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSplunkObservabilityMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing splunk observability metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+
+	return &splunkObservabilityScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		client:     signalfx.NewClient(httpClient, meta.Realm, meta.AccessToken),
+		logger:     InitializeLogger(config, "splunk_observability_scaler"),
+	}, nil
+}
+
+func parseSplunkObservabilityMetadata(config *scalersconfig.ScalerConfig) (*splunkObservabilityMetadata, error) {
+	meta := &splunkObservabilityMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing splunk observability metadata: %w", err)
+	}
+	_, meta.useFiller = config.TriggerMetadata["metricUnavailableValue"]
+	return meta, nil
+}
+
+func (s *splunkObservabilityScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *splunkObservabilityScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, "signalfx"),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// getQueryResult executes the program over the last duration and aggregates the latest values of its time series
+func (s *splunkObservabilityScaler) getQueryResult(ctx context.Context) (float64, error) {
+	stop := time.Now()
+	start := stop.Add(-time.Duration(s.metadata.Duration) * time.Second)
+	values, err := s.client.Execute(ctx, s.metadata.Query, start, stop)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(values) == 0 {
+		if !s.metadata.useFiller {
+			return -1, errors.New("no data published by the signalflow program for the given duration")
+		}
+		return s.metadata.MetricUnavailableValue, nil
+	}
+
+	var result float64
+	first := true
+	for _, value := range values {
+		switch {
+		case first:
+			result = value
+			first = false
+		case s.metadata.QueryAggregator == splunkObservabilityAggregatorMin:
+			result = min(result, value)
+		case s.metadata.QueryAggregator == splunkObservabilityAggregatorMax:
+			result = max(result, value)
+		case s.metadata.QueryAggregator == splunkObservabilityAggregatorSum, s.metadata.QueryAggregator == splunkObservabilityAggregatorAvg:
+			result += value
+		}
+	}
+	if s.metadata.QueryAggregator == splunkObservabilityAggregatorAvg {
+		result /= float64(len(values))
+	}
+	return result, nil
+}
+
+// GetMetricsAndActivity executes the SignalFlow program and returns its value
+func (s *splunkObservabilityScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing signalflow program")
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error executing signalflow program: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
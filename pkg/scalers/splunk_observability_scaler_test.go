@@ -0,0 +1,123 @@
+package scalers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/signalfx"
+)
+
+type parseSplunkObservabilityMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type splunkObservabilityMetricIdentifier struct {
+	metadataTestData *parseSplunkObservabilityMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSplunkObservabilityQuery = "data('k8s.container.cpu_time', filter=filter('k8s.deployment.name', 'checkout')).mean().publish()"
+
+var testSplunkObservabilityMetadata = []parseSplunkObservabilityMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"realm": "us1", "query": testSplunkObservabilityQuery, "targetValue": "50"}, map[string]string{"accessToken": "token"}, false, "properly formed"},
+	{map[string]string{"query": testSplunkObservabilityQuery, "targetValue": "50", "duration": "120", "queryAggregator": "avg", "activationTargetValue": "5", "metricUnavailableValue": "0"}, map[string]string{"accessToken": "token", "realm": "eu0"}, false, "realm in auth params and all options"},
+	{map[string]string{"realm": "us1", "query": testSplunkObservabilityQuery, "targetValue": "50"}, map[string]string{}, true, "missing accessToken"},
+	{map[string]string{"query": testSplunkObservabilityQuery, "targetValue": "50"}, map[string]string{"accessToken": "token"}, true, "missing realm"},
+	{map[string]string{"realm": "us1", "targetValue": "50"}, map[string]string{"accessToken": "token"}, true, "missing query"},
+	{map[string]string{"realm": "us1", "query": testSplunkObservabilityQuery}, map[string]string{"accessToken": "token"}, true, "missing targetValue"},
+	{map[string]string{"realm": "us1", "query": testSplunkObservabilityQuery, "targetValue": "50", "queryAggregator": "median"}, map[string]string{"accessToken": "token"}, true, "unknown queryAggregator"},
+	{map[string]string{"realm": "us1", "query": testSplunkObservabilityQuery, "targetValue": "50", "duration": "0"}, map[string]string{"accessToken": "token"}, true, "duration not positive"},
+}
+
+var splunkObservabilityMetricIdentifiers = []splunkObservabilityMetricIdentifier{
+	{&testSplunkObservabilityMetadata[1], 0, "s0-signalfx"},
+	{&testSplunkObservabilityMetadata[2], 1, "s1-signalfx"},
+}
+
+func TestSplunkObservabilityParseMetadata(t *testing.T) {
+	for _, testData := range testSplunkObservabilityMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestSplunkObservabilityGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range splunkObservabilityMetricIdentifiers {
+		meta, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSplunkObservabilityScaler := splunkObservabilityScaler{"", meta, nil, nil, logr.Discard()}
+
+		metricSpec := mockSplunkObservabilityScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSplunkObservabilityGetMetricsAndActivity(t *testing.T) {
+	stream := "event: data\ndata: {\"data\":[{\"tsId\":\"AAAA\",\"value\":10},{\"tsId\":\"BBBB\",\"value\":30}]}\n\n" +
+		"event: control-message\ndata: {\"event\":\"END_OF_CHANNEL\"}\n\n"
+	emptyStream := "event: control-message\ndata: {\"event\":\"END_OF_CHANNEL\"}\n\n"
+
+	testCases := []struct {
+		metadata map[string]string
+		stream   string
+		value    float64
+		isActive bool
+		isError  bool
+	}{
+		{map[string]string{"targetValue": "50"}, stream, 30, true, false},
+		{map[string]string{"targetValue": "50", "queryAggregator": "min"}, stream, 10, true, false},
+		{map[string]string{"targetValue": "50", "queryAggregator": "avg", "activationTargetValue": "20"}, stream, 20, false, false},
+		{map[string]string{"targetValue": "50", "queryAggregator": "sum"}, stream, 40, true, false},
+		{map[string]string{"targetValue": "50", "metricUnavailableValue": "0"}, emptyStream, 0, false, false},
+		{map[string]string{"targetValue": "50"}, emptyStream, 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, tc.stream)
+		}))
+
+		tc.metadata["realm"] = "us1"
+		tc.metadata["query"] = testSplunkObservabilityQuery
+		meta, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"accessToken": "token"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		client := signalfx.NewClientWithURL(server.Client(), server.URL, "token")
+		mockSplunkObservabilityScaler := splunkObservabilityScaler{"", meta, server.Client(), client, logr.Discard()}
+
+		metrics, isActive, err := mockSplunkObservabilityScaler.GetMetricsAndActivity(context.Background(), "signalfx")
+		server.Close()
+		if tc.isError {
+			assert.Error(t, err, tc.metadata)
+			continue
+		}
+		assert.NoError(t, err, tc.metadata)
+		assert.InDelta(t, tc.value, metrics[0].Value.AsApproximateFloat64(), 0.001, tc.metadata)
+		assert.Equal(t, tc.isActive, isActive, tc.metadata)
+	}
+}
@@ -0,0 +1,181 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	tektonKindPipelineRun = "pipelineRun"
+	tektonKindTaskRun     = "taskRun"
+)
+
+var tektonListGVKs = map[string]schema.GroupVersionKind{
+	tektonKindPipelineRun: {Group: "tekton.dev", Version: "v1", Kind: "PipelineRunList"},
+	tektonKindTaskRun:     {Group: "tekton.dev", Version: "v1", Kind: "TaskRunList"},
+}
+
+// tektonPendingReasons are the reasons of the Succeeded condition of the runs which haven't started yet:
+// runs held back with spec.status, and task runs whose pod hasn't been scheduled yet
+var tektonPendingReasons = []string{"PipelineRunPending", "TaskRunPending", "Pending"}
+
+type tektonScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *tektonMetadata
+	kubeClient client.Client
+	logger     logr.Logger
+}
+
+type tektonMetadata struct {
+	Kind            string  `keda:"name=kind,            order=triggerMetadata, enum=pipelineRun;taskRun, optional, default=pipelineRun"`
+	LabelSelector   string  `keda:"name=labelSelector,   order=triggerMetadata, optional"`
+	ServiceAccount  string  `keda:"name=serviceAccount,  order=triggerMetadata, optional"`
+	Namespace       string  `keda:"name=namespace,       order=triggerMetadata, optional"`
+	Value           float64 `keda:"name=value,           order=triggerMetadata, optional, default=5"`
+	ActivationValue float64 `keda:"name=activationValue, order=triggerMetadata, optional"`
+
+	triggerIndex  int
+	labelSelector labels.Selector
+}
+
+func (m *tektonMetadata) Validate() error {
+	if m.Value <= 0 {
+		return errors.New("value must be a float greater than 0")
+	}
+
+	selector, err := labels.Parse(m.LabelSelector)
+	if err != nil {
+		return fmt.Errorf("error parsing label selector: %w", err)
+	}
+	m.labelSelector = selector
+	return nil
+}
+
+// NewTektonScaler creates a new tektonScaler
+func NewTektonScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTektonMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing tekton metadata: %w", err)
+	}
+
+	return &tektonScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		logger:     InitializeLogger(config, "tekton_scaler"),
+	}, nil
+}
+
+func parseTektonMetadata(config *scalersconfig.ScalerConfig) (*tektonMetadata, error) {
+	meta := &tektonMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	if meta.Namespace == "" {
+		meta.Namespace = config.ScalableObjectNamespace
+	}
+	return meta, nil
+}
+
+func (s *tektonScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *tektonScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("tekton-%s-%s", s.metadata.Kind, s.metadata.Namespace))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Value),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of pending runs
+func (s *tektonScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	runs, err := s.getPendingRunCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error counting pending tekton %ss: %w", s.metadata.Kind, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(runs))
+	return []external_metrics.ExternalMetricValue{metric}, float64(runs) > s.metadata.ActivationValue, nil
+}
+
+func (s *tektonScaler) getPendingRunCount(ctx context.Context) (int64, error) {
+	runList := &unstructured.UnstructuredList{}
+	runList.SetGroupVersionKind(tektonListGVKs[s.metadata.Kind])
+	listOptions := client.ListOptions{
+		LabelSelector: s.metadata.labelSelector,
+		Namespace:     s.metadata.Namespace,
+	}
+	if err := s.kubeClient.List(ctx, runList, &listOptions); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, run := range runList.Items {
+		if !isTektonRunPending(run) {
+			continue
+		}
+		if s.metadata.ServiceAccount != "" && s.getServiceAccount(run) != s.metadata.ServiceAccount {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// getServiceAccount returns the service account of the run, which is part of the task run template of pipeline runs
+func (s *tektonScaler) getServiceAccount(run unstructured.Unstructured) string {
+	if s.metadata.Kind == tektonKindPipelineRun {
+		serviceAccount, _, _ := unstructured.NestedString(run.Object, "spec", "taskRunTemplate", "serviceAccountName")
+		return serviceAccount
+	}
+	serviceAccount, _, _ := unstructured.NestedString(run.Object, "spec", "serviceAccountName")
+	return serviceAccount
+}
+
+// isTektonRunPending returns whether the run hasn't started yet, which is the case when it's held back
+// with spec.status, the controller hasn't reconciled it yet or its Succeeded condition is pending
+func isTektonRunPending(run unstructured.Unstructured) bool {
+	specStatus, _, _ := unstructured.NestedString(run.Object, "spec", "status")
+	if specStatus == "PipelineRunPending" || specStatus == "TaskRunPending" {
+		return true
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(run.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["type"] != "Succeeded" {
+			continue
+		}
+		if conditionMap["status"] != "Unknown" {
+			return false
+		}
+		reason, _ := conditionMap["reason"].(string)
+		return slices.Contains(tektonPendingReasons, reason)
+	}
+	return true
+}
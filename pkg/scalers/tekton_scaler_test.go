@@ -0,0 +1,124 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTektonMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+type tektonMetricIdentifier struct {
+	metadataTestData *parseTektonMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testTektonMetadata = []parseTektonMetadataTestData{
+	{map[string]string{}, false, "nothing passed"},
+	{map[string]string{"labelSelector": "tekton.dev/pipeline=build", "value": "2"}, false, "label selector"},
+	{map[string]string{"kind": "taskRun", "namespace": "ci", "serviceAccount": "builder", "activationValue": "1"}, false, "all metadata"},
+	{map[string]string{"kind": "customRun"}, true, "unknown kind"},
+	{map[string]string{"labelSelector": "app in ("}, true, "invalid label selector"},
+	{map[string]string{"value": "0"}, true, "zero value"},
+}
+
+var tektonMetricIdentifiers = []tektonMetricIdentifier{
+	{&testTektonMetadata[1], 0, "s0-tekton-pipelineRun-default"},
+	{&testTektonMetadata[2], 1, "s1-tekton-taskRun-ci"},
+}
+
+func TestTektonParseMetadata(t *testing.T) {
+	for _, testData := range testTektonMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseTektonMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "default"})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestTektonGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range tektonMetricIdentifiers {
+		meta, err := parseTektonMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "default", TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTektonScaler := tektonScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockTektonScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func createTektonRun(kind, name, pipeline string, spec map[string]interface{}, conditionStatus, conditionReason string) client.Object {
+	run := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	run.SetGroupVersionKind(schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: kind})
+	run.SetName(name)
+	run.SetNamespace("default")
+	run.SetLabels(map[string]string{"tekton.dev/pipeline": pipeline})
+	if conditionStatus != "" {
+		run.Object["status"] = map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Succeeded", "status": conditionStatus, "reason": conditionReason},
+		}}
+	}
+	return run
+}
+
+func TestTektonGetMetricsAndActivity(t *testing.T) {
+	builderTemplate := map[string]interface{}{"taskRunTemplate": map[string]interface{}{"serviceAccountName": "builder"}}
+	kubeClient := fake.NewClientBuilder().WithObjects(
+		createTektonRun("PipelineRun", "build-1", "build", map[string]interface{}{"status": "PipelineRunPending"}, "Unknown", "PipelineRunPending"),
+		createTektonRun("PipelineRun", "build-2", "build", builderTemplate, "", ""),
+		createTektonRun("PipelineRun", "build-3", "build", builderTemplate, "Unknown", "Running"),
+		createTektonRun("PipelineRun", "build-4", "build", builderTemplate, "True", "Succeeded"),
+		createTektonRun("PipelineRun", "deploy-1", "deploy", map[string]interface{}{}, "Unknown", "PipelineRunPending"),
+		createTektonRun("TaskRun", "build-1-clone", "build", map[string]interface{}{"serviceAccountName": "builder"}, "Unknown", "Pending"),
+		createTektonRun("TaskRun", "build-3-clone", "build", map[string]interface{}{"serviceAccountName": "builder"}, "Unknown", "Running"),
+		createTektonRun("TaskRun", "build-4-clone", "build", map[string]interface{}{"serviceAccountName": "builder"}, "False", "Failed"),
+	).Build()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"pending pipeline runs", map[string]string{"activationValue": "2"}, 3, true},
+		{"pending pipeline runs matching the label selector", map[string]string{"labelSelector": "tekton.dev/pipeline=build", "activationValue": "2"}, 2, false},
+		{"pending pipeline runs of the service account", map[string]string{"serviceAccount": "builder"}, 1, true},
+		{"pending task runs", map[string]string{"kind": "taskRun"}, 1, true},
+		{"pending runs of another namespace", map[string]string{"namespace": "other"}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewTektonScaler(kubeClient, &scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, ScalableObjectNamespace: "default"})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "tekton")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	temporalQueueTypeWorkflow = "workflow"
+	temporalQueueTypeActivity = "activity"
+)
+
+// temporalTaskQueueTypes maps the queue types to the TaskQueueType enum of the Temporal API
+var temporalTaskQueueTypes = map[string]string{
+	temporalQueueTypeWorkflow: "TASK_QUEUE_TYPE_WORKFLOW",
+	temporalQueueTypeActivity: "TASK_QUEUE_TYPE_ACTIVITY",
+}
+
+type temporalScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *temporalMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type temporalMetadata struct {
+	triggerIndex int
+
+	Endpoint                  string  `keda:"name=endpoint, order=triggerMetadata;resolvedEnv"`
+	Namespace                 string  `keda:"name=namespace, order=triggerMetadata, optional, default=default"`
+	TaskQueue                 string  `keda:"name=taskQueue, order=triggerMetadata"`
+	QueueType                 string  `keda:"name=queueType, order=triggerMetadata, enum=workflow;activity, default=workflow"`
+	TargetQueueSize           float64 `keda:"name=targetQueueSize, order=triggerMetadata, optional, default=5"`
+	ActivationTargetQueueSize float64 `keda:"name=activationTargetQueueSize, order=triggerMetadata, optional, default=0"`
+	UnsafeSsl                 bool    `keda:"name=unsafeSsl, order=triggerMetadata, optional, default=false"`
+
+	// Authentication
+	APIKey      string `keda:"name=apiKey, order=authParams;resolvedEnv, optional"`
+	Cert        string `keda:"name=cert, order=authParams, optional"`
+	Key         string `keda:"name=key, order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	CA          string `keda:"name=ca, order=authParams, optional"`
+}
+
+func (m *temporalMetadata) Validate() error {
+	if m.TargetQueueSize <= 0 {
+		return errors.New("targetQueueSize must be greater than 0")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	return nil
+}
+
+// temporalTaskQueueStats is the subset of TaskQueueStats used by the scaler,
+// int64 values are encoded as strings by the Temporal HTTP API
+type temporalTaskQueueStats struct {
+	ApproximateBacklogCount json.Number `json:"approximateBacklogCount"`
+}
+
+// temporalDescribeTaskQueueResponse is the subset of DescribeTaskQueueResponse used by the scaler
+type temporalDescribeTaskQueueResponse struct {
+	VersionsInfo map[string]struct {
+		TypesInfo map[string]struct {
+			Stats *temporalTaskQueueStats `json:"stats"`
+		} `json:"typesInfo"`
+	} `json:"versionsInfo"`
+}
+
+// NewTemporalScaler creates a new temporalScaler
+func NewTemporalScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTemporalMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing temporal metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.Cert != "" || meta.CA != "" {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	return &temporalScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "temporal_scaler"),
+	}, nil
+}
+
+func parseTemporalMetadata(config *scalersconfig.ScalerConfig) (*temporalMetadata, error) {
+	meta := &temporalMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *temporalScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *temporalScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("temporal-%s-%s-%s", s.metadata.Namespace, s.metadata.TaskQueue, s.metadata.QueueType))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetQueueSize),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the backlog of the task queue
+func (s *temporalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	backlog, err := s.getTaskQueueBacklog(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting temporal task queue backlog: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, backlog)
+	return []external_metrics.ExternalMetricValue{metric}, backlog > s.metadata.ActivationTargetQueueSize, nil
+}
+
+// getTaskQueueBacklog describes the task queue in enhanced mode with the HTTP API of Temporal
+// and sums up the approximate backlog count of the queue type over all the worker versions
+func (s *temporalScaler) getTaskQueueBacklog(ctx context.Context) (float64, error) {
+	query := url.Values{}
+	query.Set("apiMode", "DESCRIBE_TASK_QUEUE_MODE_ENHANCED")
+	query.Set("reportStats", "true")
+	query.Set("taskQueueTypes", temporalTaskQueueTypes[s.metadata.QueueType])
+	describeURL := fmt.Sprintf("%s/api/v1/namespaces/%s/task-queues/%s?%s",
+		strings.TrimSuffix(s.metadata.Endpoint, "/"), url.PathEscape(s.metadata.Namespace), url.PathEscape(s.metadata.TaskQueue), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, describeURL, nil)
+	if err != nil {
+		return -1, err
+	}
+	if s.metadata.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.metadata.APIKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return -1, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("unexpected status code %d from temporal: %s", resp.StatusCode, string(body))
+	}
+
+	var describeResponse temporalDescribeTaskQueueResponse
+	if err := json.Unmarshal(body, &describeResponse); err != nil {
+		return -1, fmt.Errorf("error parsing temporal response: %w", err)
+	}
+
+	var backlog float64
+	for _, versionInfo := range describeResponse.VersionsInfo {
+		for _, typeInfo := range versionInfo.TypesInfo {
+			if typeInfo.Stats == nil || typeInfo.Stats.ApproximateBacklogCount == "" {
+				continue
+			}
+			count, err := typeInfo.Stats.ApproximateBacklogCount.Float64()
+			if err != nil {
+				return -1, fmt.Errorf("error parsing approximateBacklogCount: %w", err)
+			}
+			backlog += count
+		}
+	}
+	return backlog, nil
+}
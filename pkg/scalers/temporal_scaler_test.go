@@ -0,0 +1,142 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTemporalMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type temporalMetricIdentifier struct {
+	metadataTestData *parseTemporalMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testTemporalMetadata = []parseTemporalMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "orders"}, map[string]string{}, false, "required metadata only"},
+	{map[string]string{"endpoint": "http://temporal:7243", "namespace": "payments", "taskQueue": "orders", "queueType": "activity", "targetQueueSize": "10", "activationTargetQueueSize": "2"}, map[string]string{}, false, "all metadata"},
+	{map[string]string{"taskQueue": "orders"}, map[string]string{}, true, "missing endpoint"},
+	{map[string]string{"endpoint": "http://temporal:7243"}, map[string]string{}, true, "missing taskQueue"},
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "orders", "queueType": "nexus"}, map[string]string{}, true, "unknown queueType"},
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "orders", "targetQueueSize": "0"}, map[string]string{}, true, "zero targetQueueSize"},
+	{map[string]string{"endpoint": "http://temporal:7243", "taskQueue": "orders"}, map[string]string{"apiKey": "secret"}, false, "api key"},
+	{map[string]string{"endpoint": "https://temporal:7243", "taskQueue": "orders"}, map[string]string{"cert": "cert", "key": "key", "ca": "ca"}, false, "mTLS"},
+	{map[string]string{"endpoint": "https://temporal:7243", "taskQueue": "orders"}, map[string]string{"cert": "cert"}, true, "cert without key"},
+}
+
+var temporalMetricIdentifiers = []temporalMetricIdentifier{
+	{&testTemporalMetadata[1], 0, "s0-temporal-default-orders-workflow"},
+	{&testTemporalMetadata[2], 1, "s1-temporal-payments-orders-activity"},
+}
+
+func TestTemporalParseMetadata(t *testing.T) {
+	for _, testData := range testTemporalMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestTemporalGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range temporalMetricIdentifiers {
+		meta, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTemporalScaler := temporalScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		metricSpec := mockTemporalScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestTemporalGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name             string
+		response         string
+		statusCode       int
+		expectedBacklog  int64
+		expectedIsActive bool
+		isError          bool
+	}{
+		{
+			name:             "backlog summed over versions",
+			response:         `{"versionsInfo":{"":{"typesInfo":{"2":{"stats":{"approximateBacklogCount":"12","tasksAddRate":1.5}}}},"v2":{"typesInfo":{"2":{"stats":{"approximateBacklogCount":"3"}}}}}}`,
+			statusCode:       http.StatusOK,
+			expectedBacklog:  15,
+			expectedIsActive: true,
+		},
+		{
+			name:             "empty backlog",
+			response:         `{"versionsInfo":{"":{"typesInfo":{"2":{"stats":{}}}}}}`,
+			statusCode:       http.StatusOK,
+			expectedBacklog:  0,
+			expectedIsActive: false,
+		},
+		{
+			name:       "unauthorized",
+			response:   `{"code":16,"message":"unauthorized"}`,
+			statusCode: http.StatusUnauthorized,
+			isError:    true,
+		},
+		{
+			name:       "malformed response",
+			response:   `not json`,
+			statusCode: http.StatusOK,
+			isError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/namespaces/payments/task-queues/orders", r.URL.Path)
+				assert.Equal(t, "DESCRIBE_TASK_QUEUE_MODE_ENHANCED", r.URL.Query().Get("apiMode"))
+				assert.Equal(t, "true", r.URL.Query().Get("reportStats"))
+				assert.Equal(t, "TASK_QUEUE_TYPE_ACTIVITY", r.URL.Query().Get("taskQueueTypes"))
+				assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			scaler, err := NewTemporalScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"endpoint": server.URL, "namespace": "payments", "taskQueue": "orders", "queueType": "activity", "activationTargetQueueSize": "1"},
+				AuthParams:      map[string]string{"apiKey": "secret"},
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-temporal-payments-orders-activity")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIsActive, isActive)
+			assert.Equal(t, tc.expectedBacklog, metrics[0].Value.Value())
+		})
+	}
+}
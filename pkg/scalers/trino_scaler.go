@@ -0,0 +1,211 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	trinoQueriesPath = "/v1/query"
+
+	trinoQueryStateQueued  = "QUEUED"
+	trinoQueryStateRunning = "RUNNING"
+)
+
+type trinoScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *trinoMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type trinoMetadata struct {
+	triggerIndex int
+
+	URL string `keda:"name=url, order=triggerMetadata;resolvedEnv"`
+	// Flavor selects the prefix of the headers, Presto still using X-Presto-*
+	Flavor string `keda:"name=flavor, order=triggerMetadata, enum=trino;presto, optional, default=trino"`
+	// ResourceGroup is the dotted id of the resource group, its subgroups being included, e.g. global.adhoc
+	ResourceGroup              string `keda:"name=resourceGroup,              order=triggerMetadata, optional"`
+	IncludeQueued              bool   `keda:"name=includeQueued,              order=triggerMetadata, optional, default=true"`
+	IncludeBlocked             bool   `keda:"name=includeBlocked,             order=triggerMetadata, optional, default=true"`
+	IncludeRunning             bool   `keda:"name=includeRunning,             order=triggerMetadata, optional"`
+	TargetQueryCount           int64  `keda:"name=targetQueryCount,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetQueryCount int64  `keda:"name=activationTargetQueryCount, order=triggerMetadata, optional"`
+	UnsafeSsl                  bool   `keda:"name=unsafeSsl,                  order=triggerMetadata, optional"`
+
+	User        string `keda:"name=user,        order=triggerMetadata;authParams, optional, default=keda"`
+	Username    string `keda:"name=username,    order=authParams;resolvedEnv, optional"`
+	Password    string `keda:"name=password,    order=authParams;resolvedEnv, optional"`
+	BearerToken string `keda:"name=bearerToken, order=authParams;resolvedEnv, optional"`
+}
+
+type trinoQueryInfo struct {
+	State           string   `json:"state"`
+	ResourceGroupID []string `json:"resourceGroupId"`
+	QueryStats      struct {
+		FullyBlocked bool `json:"fullyBlocked"`
+	} `json:"queryStats"`
+}
+
+func (m *trinoMetadata) Validate() error {
+	if m.TargetQueryCount <= 0 {
+		return errors.New("targetQueryCount must be greater than 0")
+	}
+	if !m.IncludeQueued && !m.IncludeBlocked && !m.IncludeRunning {
+		return errors.New("at least one of includeQueued, includeBlocked or includeRunning must be true")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if m.Username != "" && m.BearerToken != "" {
+		return errors.New("username and password can't be used with bearerToken")
+	}
+	m.URL = strings.TrimSuffix(m.URL, "/")
+	return nil
+}
+
+// NewTrinoScaler creates a new trinoScaler
+func NewTrinoScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTrinoMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing trino metadata: %w", err)
+	}
+
+	return &trinoScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "trino_scaler"),
+	}, nil
+}
+
+func parseTrinoMetadata(config *scalersconfig.ScalerConfig) (*trinoMetadata, error) {
+	meta := &trinoMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close closes the http client connection.
+func (s *trinoScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *trinoScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := s.metadata.Flavor
+	if s.metadata.ResourceGroup != "" {
+		name += "-" + s.metadata.ResourceGroup
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetQueryCount),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number of queued and blocked queries of the resource group
+func (s *trinoScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queries, err := s.getQueryCount(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting %s queries: %w", s.metadata.Flavor, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(queries))
+	return []external_metrics.ExternalMetricValue{metric}, queries > s.metadata.ActivationTargetQueryCount, nil
+}
+
+func (s *trinoScaler) getQueryCount(ctx context.Context) (int64, error) {
+	queries, err := s.getQueries(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var count int64
+	for _, query := range queries {
+		if !s.inResourceGroup(query.ResourceGroupID) {
+			continue
+		}
+		switch {
+		case query.State == trinoQueryStateQueued && s.metadata.IncludeQueued,
+			query.State == trinoQueryStateRunning && query.QueryStats.FullyBlocked && s.metadata.IncludeBlocked,
+			query.State == trinoQueryStateRunning && !query.QueryStats.FullyBlocked && s.metadata.IncludeRunning:
+			count++
+		}
+	}
+	return count, nil
+}
+
+// inResourceGroup returns whether the resource group of the query is the resource group, or one of its subgroups
+func (s *trinoScaler) inResourceGroup(resourceGroupID []string) bool {
+	if s.metadata.ResourceGroup == "" {
+		return true
+	}
+	group := strings.Join(resourceGroupID, ".")
+	return group == s.metadata.ResourceGroup || strings.HasPrefix(group, s.metadata.ResourceGroup+".")
+}
+
+func (s *trinoScaler) getQueries(ctx context.Context) ([]trinoQueryInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL+trinoQueriesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	headerPrefix := "X-Trino-"
+	if s.metadata.Flavor == "presto" {
+		headerPrefix = "X-Presto-"
+	}
+	req.Header.Set(headerPrefix+"User", s.metadata.User)
+	switch {
+	case s.metadata.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+s.metadata.BearerToken)
+	case s.metadata.Username != "":
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, trinoQueriesPath, string(body))
+	}
+
+	var queries []trinoQueryInfo
+	if err := json.Unmarshal(body, &queries); err != nil {
+		return nil, fmt.Errorf("error parsing queries: %w", err)
+	}
+	return queries, nil
+}
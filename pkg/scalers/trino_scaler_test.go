@@ -0,0 +1,116 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTrinoMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type trinoMetricIdentifier struct {
+	metadataTestData *parseTrinoMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testTrinoMetadata = []parseTrinoMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"url": "http://trino:8080"}, map[string]string{}, false, "url only"},
+	{map[string]string{"url": "http://presto:8080", "flavor": "presto", "resourceGroup": "global.adhoc", "includeRunning": "true", "targetQueryCount": "10", "activationTargetQueryCount": "1"}, map[string]string{"user": "scaler", "username": "admin", "password": "admin"}, false, "all metadata with basic auth"},
+	{map[string]string{"url": "http://trino:8080"}, map[string]string{"bearerToken": "token"}, false, "bearer token"},
+	{map[string]string{"url": "http://trino:8080", "flavor": "hive"}, map[string]string{}, true, "unknown flavor"},
+	{map[string]string{"url": "http://trino:8080", "includeQueued": "false", "includeBlocked": "false"}, map[string]string{}, true, "no query included"},
+	{map[string]string{"url": "http://trino:8080", "targetQueryCount": "0"}, map[string]string{}, true, "zero targetQueryCount"},
+	{map[string]string{"url": "http://trino:8080"}, map[string]string{"username": "admin"}, true, "username without password"},
+}
+
+var trinoMetricIdentifiers = []trinoMetricIdentifier{
+	{&testTrinoMetadata[1], 0, "s0-trino"},
+	{&testTrinoMetadata[2], 1, "s1-presto-global-adhoc"},
+}
+
+func TestTrinoParseMetadata(t *testing.T) {
+	for _, testData := range testTrinoMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestTrinoGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range trinoMetricIdentifiers {
+		meta, err := parseTrinoMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTrinoScaler := trinoScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockTrinoScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestTrinoGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/query", r.URL.Path)
+		if r.Header.Get("X-Trino-User") == "" && r.Header.Get("X-Presto-User") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"queryId":"q1","state":"QUEUED","resourceGroupId":["global","adhoc"],"queryStats":{"fullyBlocked":false}},
+			{"queryId":"q2","state":"QUEUED","resourceGroupId":["global","etl"],"queryStats":{"fullyBlocked":false}},
+			{"queryId":"q3","state":"RUNNING","resourceGroupId":["global","adhoc","alice"],"queryStats":{"fullyBlocked":true}},
+			{"queryId":"q4","state":"RUNNING","resourceGroupId":["global","adhoc"],"queryStats":{"fullyBlocked":false}},
+			{"queryId":"q5","state":"FINISHED","resourceGroupId":["global","adhoc"],"queryStats":{"fullyBlocked":false}},
+			{"queryId":"q6","state":"QUEUED","resourceGroupId":["global","adhocs"],"queryStats":{"fullyBlocked":false}}
+		]`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+		isActive      bool
+	}{
+		{"queued and blocked queries", map[string]string{}, 4, true},
+		{"queued and blocked queries of the resource group", map[string]string{"resourceGroup": "global.adhoc", "activationTargetQueryCount": "2"}, 2, false},
+		{"all the queries of the resource group", map[string]string{"resourceGroup": "global.adhoc", "includeRunning": "true"}, 3, true},
+		{"queued queries of presto", map[string]string{"flavor": "presto", "includeBlocked": "false"}, 3, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["url"] = server.URL
+			s, err := NewTrinoScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "trino")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.isActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/pushreceiver"
+)
+
+const (
+	// PathPrefix is the prefix of the paths of the triggers, /api/v1/webhook/<namespace>/<name>/<trigger>
+	PathPrefix = "/api/v1/webhook/"
+
+	maxRequestSize = 64 * 1024
+)
+
+// DefaultReceiver is the receiver served by keda-operator when the webhook endpoint is enabled
+var DefaultReceiver = NewReceiver()
+
+// Signal is the body of the requests, a metric value or an activation signal, or both
+type Signal struct {
+	Value  *float64 `json:"value,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// Receiver receives the signals posted to the paths of the triggers, and passes them to the subscriptions of the
+// path of the request whose token authenticates the request
+type Receiver = pushreceiver.Receiver[Signal]
+
+// NewReceiver returns a receiver without subscriptions
+func NewReceiver() *Receiver {
+	return pushreceiver.NewReceiver(maxRequestSize, http.StatusNoContent, decodeSignal)
+}
+
+// Path returns the path of the trigger of the ScaledObject
+func Path(namespace, name, trigger string) string {
+	return PathPrefix + namespace + "/" + name + "/" + trigger
+}
+
+func decodeSignal(req *http.Request) (Signal, error) {
+	var signal Signal
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&signal); err != nil {
+		return signal, fmt.Errorf("error decoding signal: %w", err)
+	}
+	if signal.Value == nil && signal.Active == nil {
+		return signal, errors.New("the signal must have a value or be an activation signal")
+	}
+	return signal, nil
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiver(t *testing.T) {
+	receiver := NewReceiver()
+	var received []Signal
+	unsubscribe := receiver.Subscribe(Path("default", "consumer", "orders"), "secret", func(signal Signal) {
+		received = append(received, signal)
+	})
+
+	value, active := 12.5, true
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		status int
+	}{
+		{"value", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "secret", `{"value": 12.5}`, http.StatusNoContent},
+		{"activation signal", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "secret", `{"active": true}`, http.StatusNoContent},
+		{"wrong token", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "wrong", `{"value": 1}`, http.StatusUnauthorized},
+		{"without token", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "", `{"value": 1}`, http.StatusUnauthorized},
+		{"unknown trigger", http.MethodPost, "/api/v1/webhook/default/consumer/invoices", "secret", `{"value": 1}`, http.StatusNotFound},
+		{"not a post", http.MethodGet, "/api/v1/webhook/default/consumer/orders", "secret", "", http.StatusMethodNotAllowed},
+		{"empty signal", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "secret", `{}`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "secret", `{"value": 1, "queue": "orders"}`, http.StatusBadRequest},
+		{"value not a number", http.MethodPost, "/api/v1/webhook/default/consumer/orders", "secret", `{"value": "many"}`, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+			if test.token != "" {
+				req.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			w := httptest.NewRecorder()
+			receiver.ServeHTTP(w, req)
+			assert.Equal(t, test.status, w.Code)
+		})
+	}
+	assert.Equal(t, []Signal{{Value: &value}, {Active: &active}}, received)
+
+	unsubscribe()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/default/consumer/orders", strings.NewReader(`{"value": 1}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
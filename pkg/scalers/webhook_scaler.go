@@ -0,0 +1,177 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/webhook"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// webhookScaler scales on the latest metric value, or activation signal, posted to the endpoint of the trigger
+// exposed by keda-operator, /api/v1/webhook/<namespace>/<name>/<trigger>
+type webhookScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *webhookMetadata
+	unsubscribe func()
+	logger      logr.Logger
+
+	mu       sync.Mutex
+	signal   webhook.Signal
+	received time.Time
+	// pushed is notified of the signals posted, to report the activity as soon as they're received
+	pushed chan struct{}
+}
+
+type webhookMetadata struct {
+	// Token authenticates the requests as a bearer token
+	Token string `keda:"name=token,               order=authParams"`
+	// TTLSeconds forgets the latest signal when nothing is posted for this long
+	TTLSeconds          int     `keda:"name=ttlSeconds,          order=triggerMetadata, default=300"`
+	Threshold           float64 `keda:"name=threshold,           order=triggerMetadata"`
+	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, default=0"`
+
+	trigger      string
+	path         string
+	triggerIndex int
+}
+
+func (m *webhookMetadata) Validate() error {
+	if m.Token == "" {
+		return errors.New("token must not be empty")
+	}
+	if m.TTLSeconds <= 0 {
+		return errors.New("ttlSeconds must be greater than 0")
+	}
+	return nil
+}
+
+// NewWebhookScaler creates a new webhookScaler, receiving the signals from the default receiver of keda-operator
+func NewWebhookScaler(config *scalersconfig.ScalerConfig) (PushScaler, error) {
+	return newWebhookScaler(config, webhook.DefaultReceiver)
+}
+
+func newWebhookScaler(config *scalersconfig.ScalerConfig, receiver *webhook.Receiver) (*webhookScaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWebhookMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook metadata: %w", err)
+	}
+
+	s := &webhookScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "webhook_scaler"),
+		pushed:     make(chan struct{}, 1),
+	}
+	s.unsubscribe = receiver.Subscribe(meta.path, meta.Token, s.receive)
+	return s, nil
+}
+
+func parseWebhookMetadata(config *scalersconfig.ScalerConfig) (*webhookMetadata, error) {
+	meta := &webhookMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing webhook metadata: %w", err)
+	}
+	if config.AsMetricSource {
+		meta.Threshold = 0
+	}
+
+	// the triggers without name are identified by their index
+	meta.trigger = config.TriggerName
+	if meta.trigger == "" {
+		meta.trigger = strconv.Itoa(config.TriggerIndex)
+	}
+	meta.path = webhook.Path(config.ScalableObjectNamespace, config.ScalableObjectName, meta.trigger)
+	return meta, nil
+}
+
+func (s *webhookScaler) receive(signal webhook.Signal) {
+	s.mu.Lock()
+	s.signal = signal
+	s.received = time.Now()
+	s.mu.Unlock()
+
+	select {
+	case s.pushed <- struct{}{}:
+	default:
+	}
+}
+
+// getValueAndActivity returns the value and the activity of the latest signal, or 0 and inactive when the signal is
+// stale. The activity is the one signaled, if any, or whether the value is greater than the activation threshold.
+func (s *webhookScaler) getValueAndActivity() (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.received.IsZero() || time.Since(s.received) > time.Duration(s.metadata.TTLSeconds)*time.Second {
+		return 0, false
+	}
+	var value float64
+	if s.signal.Value != nil {
+		value = *s.signal.Value
+	}
+	if s.signal.Active != nil {
+		return value, *s.signal.Active
+	}
+	return value, value > s.metadata.ActivationThreshold
+}
+
+func (s *webhookScaler) Close(context.Context) error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *webhookScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("webhook-%s", s.metadata.trigger))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.Threshold),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the value of the latest signal posted
+func (s *webhookScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, active := s.getValueAndActivity()
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, active, nil
+}
+
+// Run reports the activity each time a signal is posted
+func (s *webhookScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.pushed:
+			_, isActive := s.getValueAndActivity()
+			select {
+			case active <- isActive:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/webhook"
+)
+
+type parseWebhookMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type webhookMetricIdentifier struct {
+	metadataTestData *parseWebhookMetadataTestData
+	triggerIndex     int
+	triggerName      string
+	name             string
+}
+
+var testWebhookMetadata = []parseWebhookMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "empty metadata"},
+	{map[string]string{"threshold": "10"}, map[string]string{"token": "secret"}, false, "properly formed"},
+	{map[string]string{"threshold": "10", "activationThreshold": "2", "ttlSeconds": "60"}, map[string]string{"token": "secret"}, false, "all options"},
+	{map[string]string{"threshold": "10"}, map[string]string{}, true, "missing token"},
+	{map[string]string{}, map[string]string{"token": "secret"}, true, "missing threshold"},
+	{map[string]string{"threshold": "10", "ttlSeconds": "0"}, map[string]string{"token": "secret"}, true, "ttlSeconds not positive"},
+}
+
+var webhookMetricIdentifiers = []webhookMetricIdentifier{
+	{&testWebhookMetadata[1], 0, "", "s0-webhook-0"},
+	{&testWebhookMetadata[2], 1, "orders", "s1-webhook-orders"},
+}
+
+func TestWebhookParseMetadata(t *testing.T) {
+	for _, testData := range testWebhookMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseWebhookMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata:         testData.metadata,
+				AuthParams:              testData.authParams,
+				ScalableObjectNamespace: "default",
+				ScalableObjectName:      "consumer",
+			})
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range webhookMetricIdentifiers {
+		meta, err := parseWebhookMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams:      testData.metadataTestData.authParams,
+			TriggerIndex:    testData.triggerIndex,
+			TriggerName:     testData.triggerName,
+		})
+		require.NoError(t, err)
+
+		scaler := webhookScaler{metadata: meta}
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func newTestWebhookScaler(t *testing.T, receiver *webhook.Receiver, metadata map[string]string) *webhookScaler {
+	scaler, err := newWebhookScaler(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         metadata,
+		AuthParams:              map[string]string{"token": "secret"},
+		ScalableObjectNamespace: "default",
+		ScalableObjectName:      "consumer",
+		TriggerName:             "orders",
+	}, receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = scaler.Close(context.Background()) })
+	return scaler
+}
+
+func TestWebhookGetMetricsAndActivity(t *testing.T) {
+	value, active, inactive := 5.0, true, false
+	tests := []struct {
+		comment        string
+		signal         *webhook.Signal
+		received       time.Time
+		expectedValue  float64
+		expectedActive bool
+	}{
+		{"nothing posted", nil, time.Time{}, 0, false},
+		{"value above activation", &webhook.Signal{Value: &value}, time.Now(), 5, true},
+		{"activation signal", &webhook.Signal{Active: &active}, time.Now(), 0, true},
+		{"value deactivated", &webhook.Signal{Value: &value, Active: &inactive}, time.Now(), 5, false},
+		{"stale value", &webhook.Signal{Value: &value}, time.Now().Add(-2 * time.Minute), 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			scaler := newTestWebhookScaler(t, webhook.NewReceiver(), map[string]string{"threshold": "10", "activationThreshold": "2", "ttlSeconds": "60"})
+			if test.signal != nil {
+				scaler.receive(*test.signal)
+				scaler.received = test.received
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "s0-webhook-orders")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedActive, isActive)
+			assert.Equal(t, test.expectedValue, metrics[0].Value.AsApproximateFloat64())
+		})
+	}
+}
+
+func TestWebhookRun(t *testing.T) {
+	receiver := webhook.NewReceiver()
+	scaler := newTestWebhookScaler(t, receiver, map[string]string{"threshold": "10"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	active := make(chan bool)
+	go scaler.Run(ctx, active)
+
+	high, low := 3.0, 0.0
+	scaler.receive(webhook.Signal{Value: &high})
+	assert.True(t, <-active)
+
+	scaler.receive(webhook.Signal{Value: &low})
+	assert.False(t, <-active)
+
+	cancel()
+	_, ok := <-active
+	assert.False(t, ok)
+}
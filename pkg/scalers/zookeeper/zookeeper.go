@@ -0,0 +1,388 @@
+package zookeeper
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// op codes of the requests
+const (
+	opGetData      = 4
+	opAuth         = 100
+	opSASL         = 102
+	opCloseSession = -11
+)
+
+// xidAuth is the reserved xid of the authentication requests
+const xidAuth = -4
+
+const (
+	// sessionTimeout is the timeout of the session requested to the server, a session being only used for a query
+	sessionTimeout = 10 * time.Second
+	// maxPacketSize protects against reading garbage as the length of a packet
+	maxPacketSize = 4 * 1024 * 1024
+
+	digestScheme = "digest"
+	// saslServerName and saslProtocol form the digest-uri expected by the DIGEST-MD5 SASL mechanism of ZooKeeper
+	saslServerName = "zk-sasl-md5"
+	saslProtocol   = "zookeeper"
+	// a single response is sent per nonce, without integrity nor confidentiality protection
+	digestNonceCount = "00000001"
+	digestQop        = "auth"
+)
+
+// errorCodes are the meaning of the error codes returned in the reply headers
+var errorCodes = map[int32]string{
+	-4:   "connection loss",
+	-102: "not authenticated",
+	-112: "session expired",
+	-115: "authentication failed",
+}
+
+// ErrNoNode is returned when the znode doesn't exist.
+var ErrNoNode = errors.New("node does not exist")
+
+// Config contains the information required to connect to a ZooKeeper ensemble.
+type Config struct {
+	// Servers are the host:port of the servers of the ensemble, in the order they are tried
+	Servers []string
+	// Username and Password authenticate either with the digest scheme or with the DIGEST-MD5 SASL mechanism
+	Username string
+	Password string
+	SASL     bool
+	Timeout  time.Duration
+}
+
+// Node is the content of a znode and the number of its children.
+type Node struct {
+	Data        []byte
+	NumChildren int32
+}
+
+type conn struct {
+	net.Conn
+	r   *bufio.Reader
+	xid int32
+}
+
+// GetNode returns the content and the number of children of the znode, the servers being tried in order
+// until one of them can be connected to.
+func GetNode(ctx context.Context, config *Config, path string) (*Node, error) {
+	var errs []error
+	for _, server := range config.Servers {
+		c, err := dial(ctx, config, server)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error connecting to %s: %w", server, err))
+			continue
+		}
+		return c.getNode(config, path)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no server was set")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *conn) getNode(config *Config, path string) (*Node, error) {
+	defer c.Close()
+	if err := c.authenticate(config); err != nil {
+		return nil, err
+	}
+	node, err := c.getData(path)
+	if err != nil {
+		return nil, err
+	}
+	c.closeSession()
+	return node, nil
+}
+
+func dial(ctx context.Context, config *Config, server string) (*conn, error) {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", server)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if config.Timeout > 0 {
+		deadline = time.Now().Add(config.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := netConn.SetDeadline(deadline); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	c := &conn{Conn: netConn, r: bufio.NewReader(netConn)}
+	if err := c.connect(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect creates a new session
+func (c *conn) connect() error {
+	// protocol version, last zxid seen, timeout, session id and password of a new session
+	var buf []byte
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint64(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sessionTimeout.Milliseconds()))
+	buf = binary.BigEndian.AppendUint64(buf, 0)
+	buf = appendBuffer(buf, make([]byte, 16))
+	if err := c.writePacket(buf); err != nil {
+		return err
+	}
+
+	packet, err := c.readPacket()
+	if err != nil {
+		return err
+	}
+	r := &reader{data: packet}
+	r.int32()
+	timeout := r.int32()
+	if r.err != nil {
+		return r.err
+	}
+	if timeout <= 0 {
+		return errors.New("session refused by the server")
+	}
+	return nil
+}
+
+func (c *conn) authenticate(config *Config) error {
+	if config.Username == "" {
+		return nil
+	}
+	if config.SASL {
+		return c.authenticateSASL(config.Username, config.Password)
+	}
+
+	buf := binary.BigEndian.AppendUint32(nil, 0)
+	buf = appendString(buf, digestScheme)
+	buf = appendBuffer(buf, []byte(config.Username+":"+config.Password))
+	if _, err := c.request(xidAuth, opAuth, buf); err != nil {
+		return fmt.Errorf("error authenticating with the digest scheme: %w", err)
+	}
+	return nil
+}
+
+// authenticateSASL authenticates with the DIGEST-MD5 mechanism, the client sending an empty
+// initial response and answering the challenge of the server
+func (c *conn) authenticateSASL(username, password string) error {
+	c.xid++
+	reply, err := c.request(c.xid, opSASL, appendBuffer(nil, nil))
+	if err != nil {
+		return fmt.Errorf("error starting sasl authentication: %w", err)
+	}
+	r := &reader{data: reply}
+	challenge := r.buffer()
+	if r.err != nil {
+		return r.err
+	}
+
+	response, err := digestMD5Response(string(challenge), username, password)
+	if err != nil {
+		return err
+	}
+	c.xid++
+	if _, err := c.request(c.xid, opSASL, appendBuffer(nil, []byte(response))); err != nil {
+		return fmt.Errorf("error during sasl authentication: %w", err)
+	}
+	return nil
+}
+
+func (c *conn) getData(path string) (*Node, error) {
+	c.xid++
+	reply, err := c.request(c.xid, opGetData, append(appendString(nil, path), 0))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &reader{data: reply}
+	data := r.buffer()
+	// the czxid, mzxid, ctime and mtime, version, cversion and aversion, and ephemeral owner of the stat
+	r.next(8*4 + 4*3 + 8)
+	r.int32()
+	numChildren := r.int32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &Node{Data: data, NumChildren: numChildren}, nil
+}
+
+// closeSession closes the session rather than letting it expire, the error being ignored as the query is done
+func (c *conn) closeSession() {
+	c.xid++
+	_, _ = c.request(c.xid, opCloseSession, nil)
+}
+
+// request sends the request and returns the body of its reply
+func (c *conn) request(xid, op int32, body []byte) ([]byte, error) {
+	buf := binary.BigEndian.AppendUint32(nil, uint32(xid))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(op))
+	if err := c.writePacket(append(buf, body...)); err != nil {
+		return nil, err
+	}
+
+	for {
+		packet, err := c.readPacket()
+		if err != nil {
+			return nil, err
+		}
+		r := &reader{data: packet}
+		replyXid := r.int32()
+		r.next(8)
+		code := r.int32()
+		if r.err != nil {
+			return nil, r.err
+		}
+		// watcher events, pings and replies to other requests are skipped
+		if replyXid != xid {
+			continue
+		}
+		if code != 0 {
+			return nil, codeError(code)
+		}
+		return r.data, nil
+	}
+}
+
+func (c *conn) writePacket(packet []byte) error {
+	_, err := c.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(packet))), packet...))
+	return err
+}
+
+func (c *conn) readPacket() ([]byte, error) {
+	var length int32
+	if err := binary.Read(c.r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if length < 0 || length > maxPacketSize {
+		return nil, fmt.Errorf("invalid packet length %d", length)
+	}
+	packet := make([]byte, length)
+	if _, err := io.ReadFull(c.r, packet); err != nil {
+		return nil, err
+	}
+	return packet, nil
+}
+
+func codeError(code int32) error {
+	if code == -101 {
+		return ErrNoNode
+	}
+	if reason, found := errorCodes[code]; found {
+		return errors.New(reason)
+	}
+	return fmt.Errorf("error code %d", code)
+}
+
+// digestMD5Response computes the response to the DIGEST-MD5 challenge as described by RFC 2831
+func digestMD5Response(challenge, username, password string) (string, error) {
+	params := parseDigestChallenge(challenge)
+	nonce := params["nonce"]
+	if nonce == "" {
+		return "", fmt.Errorf("no nonce in the sasl challenge %q", challenge)
+	}
+	realm := params["realm"]
+
+	cnonceBytes := make([]byte, 16)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", err
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+	digestURI := saslProtocol + "/" + saslServerName
+
+	return fmt.Sprintf(`charset=utf-8,username="%s",realm="%s",nonce="%s",nc=%s,cnonce="%s",digest-uri="%s",maxbuf=65536,response=%s,qop=%s`,
+		username, realm, nonce, digestNonceCount, cnonce, digestURI, digestMD5(username, realm, password, nonce, cnonce, digestURI), digestQop), nil
+}
+
+// digestMD5 computes the response value proving the knowledge of the password
+func digestMD5(username, realm, password, nonce, cnonce, digestURI string) string {
+	credentials := md5.Sum([]byte(username + ":" + realm + ":" + password))
+	a1 := md5.Sum(append(credentials[:], ":"+nonce+":"+cnonce...))
+	a2 := md5.Sum([]byte("AUTHENTICATE:" + digestURI))
+	response := md5.Sum([]byte(hex.EncodeToString(a1[:]) + ":" + nonce + ":" + digestNonceCount + ":" + cnonce + ":" + digestQop + ":" + hex.EncodeToString(a2[:])))
+	return hex.EncodeToString(response[:])
+}
+
+// parseDigestChallenge parses the comma separated key=value pairs of the challenge, the values being possibly quoted
+func parseDigestChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	for challenge != "" {
+		key, rest, found := strings.Cut(challenge, "=")
+		if !found {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			rest = "," + rest
+		}
+		params[strings.TrimSpace(key)] = value
+		challenge = strings.TrimPrefix(rest, ",")
+	}
+	return params
+}
+
+func appendString(buf []byte, s string) []byte {
+	return appendBuffer(buf, []byte(s))
+}
+
+func appendBuffer(buf []byte, b []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+// reader decodes the fields of a packet, keeping the first error so that it's checked once at the end
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) int32() int32 {
+	if b := r.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+// buffer reads a length prefixed buffer, a length of -1 being a null buffer
+func (r *reader) buffer() []byte {
+	length := r.int32()
+	if length == -1 {
+		return nil
+	}
+	return r.next(int(length))
+}
@@ -0,0 +1,165 @@
+package zookeeper
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNonce = "OA6MG9tEQGm2hh"
+
+type testZNode struct {
+	data        string
+	numChildren int32
+}
+
+// startServer starts a server holding the znodes, readable once authenticated as keda:secret
+func startServer(t *testing.T, znodes map[string]testZNode) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			netConn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(t, &conn{Conn: netConn, r: bufio.NewReader(netConn)}, znodes)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serve(t *testing.T, c *conn, znodes map[string]testZNode) {
+	defer c.Close()
+	if _, err := c.readPacket(); err != nil {
+		return
+	}
+	var connected []byte
+	connected = binary.BigEndian.AppendUint32(connected, 0)
+	connected = binary.BigEndian.AppendUint32(connected, uint32(sessionTimeout.Milliseconds()))
+	connected = binary.BigEndian.AppendUint64(connected, 1)
+	connected = appendBuffer(connected, make([]byte, 16))
+	_ = c.writePacket(connected)
+
+	authenticated := false
+	for {
+		packet, err := c.readPacket()
+		if err != nil {
+			return
+		}
+		r := &reader{data: packet}
+		xid, op := r.int32(), r.int32()
+
+		var code int32
+		var body []byte
+		switch op {
+		case opAuth:
+			r.int32()
+			assert.Equal(t, digestScheme, r.string())
+			authenticated = string(r.buffer()) == "keda:secret"
+		case opSASL:
+			token := string(r.buffer())
+			if token == "" {
+				body = appendString(nil, `realm="zk-sasl-md5",nonce="`+testNonce+`",qop="auth",charset=utf-8,algorithm=md5-sess`)
+				break
+			}
+			params := parseDigestChallenge(token)
+			assert.Equal(t, "zookeeper/zk-sasl-md5", params["digest-uri"])
+			authenticated = params["response"] == digestMD5("keda", "zk-sasl-md5", "secret", testNonce, params["cnonce"], params["digest-uri"])
+			body = appendString(nil, "rspauth=0")
+		case opGetData:
+			znode, found := znodes[r.string()]
+			switch {
+			case !authenticated:
+				code = -102
+			case !found:
+				code = -101
+			default:
+				body = appendString(nil, znode.data)
+				body = append(body, make([]byte, 8*4+4*3+8+4)...)
+				body = binary.BigEndian.AppendUint32(body, uint32(znode.numChildren))
+				body = binary.BigEndian.AppendUint64(body, 0)
+			}
+		}
+		if op == opSASL && !authenticated && body == nil {
+			code = -115
+		}
+
+		// a ping is sent before the reply to check that it's skipped
+		ping := binary.BigEndian.AppendUint32(nil, uint32(0xfffffffe))
+		_ = c.writePacket(append(ping, make([]byte, 12)...))
+
+		reply := binary.BigEndian.AppendUint32(nil, uint32(xid))
+		reply = binary.BigEndian.AppendUint64(reply, 1)
+		reply = binary.BigEndian.AppendUint32(reply, uint32(code))
+		_ = c.writePacket(append(reply, body...))
+		if op == opCloseSession {
+			return
+		}
+	}
+}
+
+func (r *reader) string() string {
+	return string(r.buffer())
+}
+
+func TestGetNode(t *testing.T) {
+	addr := startServer(t, map[string]testZNode{
+		"/jobs/pending": {"42\n", 0},
+		"/workers":      {"", 7},
+	})
+
+	for _, sasl := range []bool{false, true} {
+		config := &Config{Servers: []string{addr}, Username: "keda", Password: "secret", SASL: sasl, Timeout: time.Second}
+
+		node, err := GetNode(context.Background(), config, "/jobs/pending")
+		require.NoError(t, err)
+		assert.Equal(t, "42\n", string(node.Data))
+
+		node, err = GetNode(context.Background(), config, "/workers")
+		require.NoError(t, err)
+		assert.Equal(t, int32(7), node.NumChildren)
+
+		_, err = GetNode(context.Background(), config, "/missing")
+		assert.ErrorIs(t, err, ErrNoNode)
+
+		config.Password = "wrong"
+		_, err = GetNode(context.Background(), config, "/workers")
+		assert.Error(t, err)
+	}
+}
+
+func TestGetNodeFailover(t *testing.T) {
+	addr := startServer(t, map[string]testZNode{"/workers": {"", 3}})
+
+	// nothing listens on the port of the closed listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	listener.Close()
+
+	config := &Config{Servers: []string{unreachable, addr}, Username: "keda", Password: "secret", Timeout: time.Second}
+	node, err := GetNode(context.Background(), config, "/workers")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), node.NumChildren)
+
+	config.Servers = []string{unreachable}
+	_, err = GetNode(context.Background(), config, "/workers")
+	assert.ErrorContains(t, err, "error connecting to "+unreachable)
+}
+
+func TestDigestMD5(t *testing.T) {
+	// example of RFC 2831
+	assert.Equal(t, "d388dad90d4bbd760a152321f2143af7", digestMD5("chris", "elwood.innosoft.com", "secret", "OA6MG9tEQGm2hh", "OA6MHXh6VqTrRk", "imap/elwood.innosoft.com"))
+
+	params := parseDigestChallenge(`realm="zk-sasl-md5",nonce="a,b",qop="auth",charset=utf-8,algorithm=md5-sess`)
+	assert.Equal(t, map[string]string{"realm": "zk-sasl-md5", "nonce": "a,b", "qop": "auth", "charset": "utf-8", "algorithm": "md5-sess"}, params)
+}
@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/zookeeper"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	zookeeperModeChildren = "children"
+	zookeeperAuthSASL     = "sasl"
+)
+
+type zookeeperScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *zookeeperMetadata
+	config     *zookeeper.Config
+	logger     logr.Logger
+}
+
+type zookeeperMetadata struct {
+	triggerIndex int
+
+	// Hosts are the host:port of the servers of the ensemble
+	Hosts []string `keda:"name=hosts, order=triggerMetadata;resolvedEnv"`
+	Path  string   `keda:"name=path,  order=triggerMetadata"`
+	// Mode is whether the number held by the znode or its number of children is returned
+	Mode                  string  `keda:"name=mode,                  order=triggerMetadata, enum=data;children, optional, default=data"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, optional, default=5"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, optional"`
+
+	// AuthMechanism is the digest scheme of the ACLs or the DIGEST-MD5 SASL mechanism
+	AuthMechanism string `keda:"name=authMechanism, order=authParams, enum=digest;sasl, optional, default=digest"`
+	Username      string `keda:"name=username,      order=authParams;resolvedEnv, optional"`
+	Password      string `keda:"name=password,      order=authParams;resolvedEnv, optional"`
+}
+
+func (m *zookeeperMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	if !strings.HasPrefix(m.Path, "/") {
+		return fmt.Errorf("path must be absolute, got %q", m.Path)
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	return nil
+}
+
+// NewZookeeperScaler creates a new zookeeperScaler
+func NewZookeeperScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseZookeeperMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing zookeeper metadata: %w", err)
+	}
+
+	return &zookeeperScaler{
+		metricType: metricType,
+		metadata:   meta,
+		config: &zookeeper.Config{
+			Servers:  meta.Hosts,
+			Username: meta.Username,
+			Password: meta.Password,
+			SASL:     meta.AuthMechanism == zookeeperAuthSASL,
+			Timeout:  config.GlobalHTTPTimeout,
+		},
+		logger: InitializeLogger(config, "zookeeper_scaler"),
+	}, nil
+}
+
+func parseZookeeperMetadata(config *scalersconfig.ScalerConfig) (*zookeeperMetadata, error) {
+	meta := &zookeeperMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Close does nothing as a session is opened for each query
+func (s *zookeeperScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *zookeeperScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("zookeeper-%s%s", s.metadata.Mode, s.metadata.Path))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the number held by the znode or its number of children
+func (s *zookeeperScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error reading znode %s: %w", s.metadata.Path, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *zookeeperScaler) getValue(ctx context.Context) (float64, error) {
+	node, err := zookeeper.GetNode(ctx, s.config, s.metadata.Path)
+	if err != nil {
+		return -1, err
+	}
+	if s.metadata.Mode == zookeeperModeChildren {
+		return float64(node.NumChildren), nil
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(node.Data)), 64)
+	if err != nil {
+		return -1, fmt.Errorf("content %q isn't a number", node.Data)
+	}
+	return value, nil
+}
@@ -0,0 +1,71 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseZookeeperMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+	comment    string
+}
+
+type zookeeperMetricIdentifier struct {
+	metadataTestData *parseZookeeperMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testZookeeperMetadata = []parseZookeeperMetadataTestData{
+	{map[string]string{}, map[string]string{}, true, "nothing passed"},
+	{map[string]string{"hosts": "zk-0:2181,zk-1:2181,zk-2:2181", "path": "/jobs/pending"}, map[string]string{}, false, "data mode"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue", "mode": "children", "targetValue": "10", "activationTargetValue": "2"}, map[string]string{"username": "keda", "password": "secret"}, false, "children mode with digest auth"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue"}, map[string]string{"authMechanism": "sasl", "username": "keda", "password": "secret"}, false, "sasl auth"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue"}, map[string]string{"authMechanism": "kerberos", "username": "keda", "password": "secret"}, true, "unknown auth mechanism"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue"}, map[string]string{"username": "keda"}, true, "username without password"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "queue"}, map[string]string{}, true, "relative path"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue", "mode": "stat"}, map[string]string{}, true, "unknown mode"},
+	{map[string]string{"hosts": "zk-0:2181", "path": "/queue", "targetValue": "0"}, map[string]string{}, true, "zero targetValue"},
+	{map[string]string{"path": "/queue"}, map[string]string{}, true, "missing hosts"},
+}
+
+var zookeeperMetricIdentifiers = []zookeeperMetricIdentifier{
+	{&testZookeeperMetadata[1], 0, "s0-zookeeper-data-jobs-pending"},
+	{&testZookeeperMetadata[2], 1, "s1-zookeeper-children-queue"},
+}
+
+func TestZookeeperParseMetadata(t *testing.T) {
+	for _, testData := range testZookeeperMetadata {
+		t.Run(testData.comment, func(t *testing.T) {
+			_, err := parseZookeeperMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+		})
+	}
+}
+
+func TestZookeeperGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range zookeeperMetricIdentifiers {
+		meta, err := parseZookeeperMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockZookeeperScaler := zookeeperScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockZookeeperScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
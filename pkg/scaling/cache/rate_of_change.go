@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+type rateOfChangeKey struct {
+	triggerIndex int
+	metricName   string
+}
+
+type rateOfChangeSample struct {
+	time  time.Time
+	value float64
+}
+
+// rateOfChange records the values of the metrics of the trigger polled at the time, and returns the metrics with
+// their values replaced by their change by second since the latest value polled at least the window before, or
+// the oldest one. Decreasing metrics have a rate of 0, the HPA not scaling on negative values.
+// The metrics are polled by the scale loop as well as by the HPA, so a value is only recorded once the polling
+// interval has elapsed since the latest one, for the samples not to depend on how often the HPA reads the metrics.
+func (c *ScalersCache) rateOfChange(triggerIndex int, window, pollingInterval time.Duration, metrics []external_metrics.ExternalMetricValue, now time.Time) []external_metrics.ExternalMetricValue {
+	c.rateOfChangeMutex.Lock()
+	defer c.rateOfChangeMutex.Unlock()
+	if c.rateOfChangeSamples == nil {
+		c.rateOfChangeSamples = map[rateOfChangeKey][]rateOfChangeSample{}
+	}
+
+	rates := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		key := rateOfChangeKey{triggerIndex: triggerIndex, metricName: metric.MetricName}
+		current := rateOfChangeSample{time: now, value: metric.Value.AsApproximateFloat64()}
+		samples := c.rateOfChangeSamples[key]
+		if len(samples) == 0 || now.Sub(samples[len(samples)-1].time) >= pollingInterval {
+			samples = append(samples, current)
+		}
+		// the first sample is the baseline of the rate, the latest one polled at least the window before
+		for len(samples) > 1 && !samples[1].time.After(now.Add(-window)) {
+			samples = samples[1:]
+		}
+		c.rateOfChangeSamples[key] = samples
+
+		var rate float64
+		first := samples[0]
+		if elapsed := current.time.Sub(first.time).Seconds(); elapsed > 0 && current.value > first.value {
+			rate = (current.value - first.value) / elapsed
+		}
+		metric.Value = *resource.NewMilliQuantity(int64(rate*1000), resource.DecimalSI)
+		rates = append(rates, metric)
+	}
+	return rates
+}
@@ -29,8 +29,10 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/predictive"
 )
 
 var log = logf.Log.WithName("scalers_cache")
@@ -41,7 +43,16 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
-	mutex                    sync.RWMutex
+	// CompiledTriggerExpressions are the compiled expressions of the triggers, by trigger name
+	CompiledTriggerExpressions map[string]*vm.Program
+	mutex                      sync.RWMutex
+
+	// PredictiveHistories forecast the metrics of the triggers of a predictive ScaledObject
+	PredictiveHistories *predictive.Histories
+
+	// rateOfChangeSamples are the values polled within the window of the rateOfChange triggers
+	rateOfChangeSamples map[rateOfChangeKey][]rateOfChangeSample
+	rateOfChangeMutex   sync.Mutex
 }
 
 type ScalerBuilder struct {
@@ -101,12 +112,16 @@ func (c *ScalersCache) Close(ctx context.Context) {
 	}
 }
 
-// GetMetricSpecForScaling returns metrics specs for all scalers in the cache
+// GetMetricSpecForScaling returns metrics specs for all scalers in the cache,
+// canary scalers are skipped as they don't contribute to the scaling
 func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 	var spec []v2.MetricSpec
 	for _, s := range c.Scalers {
+		if s.ScalerConfig.TriggerCanary {
+			continue
+		}
 		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
 	}
 	return spec
@@ -147,19 +162,41 @@ func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index
 	if err != nil {
 		return nil, false, -1, err
 	}
-	startTime := time.Now()
-	metric, activity, err := sb.Scaler.GetMetricsAndActivity(ctx, metricName)
-	if err == nil {
-		return metric, activity, time.Since(startTime), nil
+	metric, activity, latency, err := c.getMetricsAndActivity(ctx, sb.Scaler, sb.ScalerConfig, metricName)
+	if err != nil {
+		ns, err := c.refreshScaler(ctx, index)
+		if err != nil {
+			return nil, false, -1, err
+		}
+		metric, activity, latency, err = c.getMetricsAndActivity(ctx, ns, sb.ScalerConfig, metricName)
+		if err != nil {
+			return metric, activity, latency, err
+		}
 	}
 
-	ns, err := c.refreshScaler(ctx, index)
-	if err != nil {
-		return nil, false, -1, err
+	if sb.ScalerConfig.TriggerRateOfChange {
+		metric = c.rateOfChange(index, sb.ScalerConfig.TriggerRateOfChangeWindow, sb.ScalerConfig.PollingInterval, metric, time.Now())
+	}
+	// the metrics are forecast here for the HPA and the activation of the scale loop to use the same values,
+	// a trigger being active when a higher load is forecast so that the target is scaled from zero ahead of it
+	if c.PredictiveHistories != nil && c.ScaledObject != nil && c.ScaledObject.IsPredictive() {
+		logger := log.WithValues("scaledObject.Namespace", c.ScaledObject.Namespace, "scaledObject.Name", c.ScaledObject.Name)
+		var raised bool
+		metric, raised = c.PredictiveHistories.Forecast(ctx, logger, c.ScaledObject, metric)
+		activity = activity || raised
 	}
-	startTime = time.Now()
-	metric, activity, err = ns.GetMetricsAndActivity(ctx, metricName)
-	return metric, activity, time.Since(startTime), err
+	return metric, activity, latency, nil
+}
+
+// getMetricsAndActivity calls the scaler and records the latency and the error per scaler type
+func (c *ScalersCache) getMetricsAndActivity(ctx context.Context, scaler scalers.Scaler, config scalersconfig.ScalerConfig, metricName string) ([]external_metrics.ExternalMetricValue, bool, time.Duration, error) {
+	startTime := time.Now()
+	metric, activity, err := scaler.GetMetricsAndActivity(ctx, metricName)
+	latency := time.Since(startTime)
+
+	metricscollector.RecordScalerTypeLatency(config.TriggerType, config.ScalableObjectNamespace, config.ScalableObjectName, latency)
+	metricscollector.RecordScalerTypeError(config.TriggerType, err)
+	return metric, activity, latency, err
 }
 
 func (c *ScalersCache) refreshScaler(ctx context.Context, index int) (scalers.Scaler, error) {
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/predictive"
+)
+
+func TestGetMetricsAndActivityForScalerRecordsScalerTypeMetrics(t *testing.T) {
+	const scalerLatency = 60 * time.Millisecond
+
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-queue").DoAndReturn(
+		func(context.Context, string) ([]external_metrics.ExternalMetricValue, bool, error) {
+			time.Sleep(scalerLatency)
+			return []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 5)}, true, nil
+		})
+
+	metricscollector.NewMetricsCollectors(true, false, true)
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: scaler,
+			ScalerConfig: scalersconfig.ScalerConfig{
+				TriggerType:             "rabbitmq",
+				ScalableObjectNamespace: "default",
+				ScalableObjectName:      "consumer",
+			},
+		}},
+	}
+
+	_, isActive, latency, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-queue")
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+	assert.GreaterOrEqual(t, latency, scalerLatency)
+
+	// the sum depends on the scheduling, so the count and the first bucket including the fixed latency are compared
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	var histogram *dto.Histogram
+	for _, family := range families {
+		if family.GetName() == "keda_scaler_type_metrics_latency_seconds" {
+			assert.Len(t, family.GetMetric(), 1)
+			metric := family.GetMetric()[0]
+			assert.Equal(t, map[string]string{"scaler_type": "rabbitmq", "namespace": "default", "name": "consumer"}, getLabels(metric))
+			histogram = metric.GetHistogram()
+		}
+	}
+	assert.NotNil(t, histogram)
+	assert.Equal(t, uint64(1), histogram.GetSampleCount())
+	assert.GreaterOrEqual(t, histogram.GetSampleSum(), scalerLatency.Seconds())
+	for _, bucket := range histogram.GetBucket() {
+		if bucket.GetCumulativeCount() > 0 {
+			assert.Equal(t, 0.1, bucket.GetUpperBound())
+			break
+		}
+	}
+}
+
+func TestGetMetricsAndActivityForScalerRateOfChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	gomock.InOrder(
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-queue").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 5)}, true, nil),
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-queue").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 500)}, true, nil),
+	)
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: scaler,
+			ScalerConfig: scalersconfig.ScalerConfig{
+				TriggerType:               "rabbitmq",
+				TriggerRateOfChange:       true,
+				TriggerRateOfChangeWindow: time.Minute,
+			},
+		}},
+	}
+
+	metrics, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-queue")
+	assert.NoError(t, err)
+	assert.True(t, isActive, "the activity is the one of the scaler")
+	assert.Equal(t, 0.0, metrics[0].Value.AsApproximateFloat64(), "the rate is 0 until the metric is polled twice")
+
+	time.Sleep(10 * time.Millisecond)
+	metrics, _, _, err = cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-queue")
+	assert.NoError(t, err)
+	assert.Equal(t, "s0-queue", metrics[0].MetricName)
+	assert.Greater(t, metrics[0].Value.AsApproximateFloat64(), 0.0)
+}
+
+// historyStore is a predictive store returning its samples
+type historyStore struct {
+	samples []predictive.Sample
+}
+
+func (s *historyStore) Append(context.Context, string, []predictive.Sample, int) error {
+	return nil
+}
+
+func (s *historyStore) Load(context.Context, string) ([]predictive.Sample, error) {
+	return s.samples, nil
+}
+
+func TestGetMetricsAndActivityForScalerPredictive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-queue").Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 100)}, false, nil).Times(2)
+
+	// the load increased by 10 every minute of the last 10 minutes
+	store := &historyStore{}
+	now := time.Now().Truncate(time.Minute)
+	for i := 0; i < 10; i++ {
+		store.samples = append(store.samples, predictive.Sample{Time: now.Add(time.Duration(i-10) * time.Minute), Value: float64(10 * i)})
+	}
+	so := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{Predictive: &kedav1alpha1.PredictiveConfig{HorizonMinutes: 10}},
+		},
+	}
+	cache := ScalersCache{
+		ScaledObject: so,
+		Scalers: []ScalerBuilder{{
+			Scaler:       scaler,
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerType: "rabbitmq"},
+		}},
+	}
+
+	metrics, isActive, _, err := cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-queue")
+	assert.NoError(t, err)
+	assert.False(t, isActive, "the metrics aren't forecast without histories")
+	assert.Equal(t, 100.0, metrics[0].Value.AsApproximateFloat64())
+
+	cache.PredictiveHistories = predictive.NewHistories(store)
+	metrics, isActive, _, err = cache.GetMetricsAndActivityForScaler(context.Background(), 0, "s0-queue")
+	assert.NoError(t, err)
+	assert.True(t, isActive, "the trigger is active when a higher load is forecast")
+	assert.Greater(t, metrics[0].Value.AsApproximateFloat64(), 100.0)
+}
+
+func TestRateOfChange(t *testing.T) {
+	cache := ScalersCache{}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(seconds int, value float64) float64 {
+		metrics := []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", value)}
+		return cache.rateOfChange(0, time.Minute, 30*time.Second, metrics, start.Add(time.Duration(seconds)*time.Second))[0].Value.AsApproximateFloat64()
+	}
+
+	assert.Equal(t, 0.0, rate(0, 100))
+	assert.Equal(t, 2.0, rate(30, 160), "the rate is computed since the oldest value within the window")
+	assert.Equal(t, 1.5, rate(60, 190), "the value polled the window before is the baseline")
+	assert.Equal(t, 0.5, rate(90, 190), "the values older than the window are dropped")
+	assert.Equal(t, 0.0, rate(120, 100), "decreasing metrics have a rate of 0")
+	assert.Len(t, cache.rateOfChangeSamples[rateOfChangeKey{triggerIndex: 0, metricName: "s0-queue"}], 3)
+
+	// the rates are computed by trigger
+	metrics := []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 1000)}
+	assert.Equal(t, 0.0, cache.rateOfChange(1, time.Minute, 30*time.Second, metrics, start.Add(150*time.Second))[0].Value.AsApproximateFloat64())
+}
+
+func TestRateOfChangeReadBetweenPolls(t *testing.T) {
+	cache := ScalersCache{}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(seconds int, value float64) float64 {
+		metrics := []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", value)}
+		return cache.rateOfChange(0, time.Minute, 30*time.Second, metrics, start.Add(time.Duration(seconds)*time.Second))[0].Value.AsApproximateFloat64()
+	}
+
+	assert.Equal(t, 0.0, rate(0, 100))
+	assert.Equal(t, 2.0, rate(10, 120), "the rate is computed with the value read between two polls")
+	assert.Equal(t, 2.0, rate(20, 140), "the rate is computed with the value read between two polls")
+	assert.Equal(t, 2.0, rate(30, 160))
+	assert.Len(t, cache.rateOfChangeSamples[rateOfChangeKey{triggerIndex: 0, metricName: "s0-queue"}], 2, "the values read within the polling interval aren't recorded")
+	assert.Equal(t, 2.0, rate(70, 240), "the baseline is the value polled the window before")
+	assert.Equal(t, 2.0, rate(80, 260), "the baseline is the value polled the window before")
+}
+
+func getLabels(metric *dto.Metric) map[string]string {
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	return labels
+}
@@ -29,6 +29,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/k8s"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
@@ -38,15 +39,24 @@ const (
 	defaultCooldownPeriod        = 5 * 60 // 5 minutes
 )
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+// ScaleExecutor contains methods RequestJobScale, RequestScale, ForgetScaledObject and Shutdown
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
+	// ForgetScaledObject releases what the executor keeps for the deleted ScaledObject
+	ForgetScaledObject(ctx context.Context, scaledObjectIdentifier string)
+	// Shutdown flushes and closes the exporters of the scale events, when the operator stops
+	Shutdown(ctx context.Context) error
 }
 
 // ScaleExecutorOptions contains the optional parameters for the RequestScale method.
 type ScaleExecutorOptions struct {
 	ActiveTriggers []string
+	// MetricValue is the highest metric value reported by the triggers, it's attached to the exported scale events
+	MetricValue float64
+	// DesiredReplicas is the replica count computed from the metrics for scale targets in remote clusters,
+	// which aren't scaled by an HPA
+	DesiredReplicas *int32
 }
 
 type scaleExecutor struct {
@@ -55,6 +65,8 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+	otelEvents       *otelScaleEventExporter
+	remoteClusters   *k8s.RemoteClusters
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
@@ -65,9 +77,21 @@ func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGette
 		reconcilerScheme: reconcilerScheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
 		recorder:         recorder,
+		otelEvents:       newOtelScaleEventExporter(),
+		remoteClusters:   k8s.NewRemoteClusters(client),
 	}
 }
 
+func (e *scaleExecutor) ForgetScaledObject(ctx context.Context, scaledObjectIdentifier string) {
+	if err := e.otelEvents.forget(ctx, scaledObjectIdentifier); err != nil {
+		e.logger.Error(err, "error shutting down OpenTelemetry LoggerProvider", "scaledObject", scaledObjectIdentifier)
+	}
+}
+
+func (e *scaleExecutor) Shutdown(ctx context.Context) error {
+	return e.otelEvents.shutdown(ctx)
+}
+
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
 	now := metav1.Now()
 	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const otelScaleEventsLoggerName = "keda-scale-events"
+
+// otelScaleEventsRetryConfig keeps the retries short, scaling decisions shouldn't pile up
+// in memory when the collector isn't reachable
+var otelScaleEventsRetryConfig = otlploggrpc.RetryConfig{
+	Enabled:         true,
+	InitialInterval: 100 * time.Millisecond,
+	MaxInterval:     time.Second,
+	MaxElapsedTime:  5 * time.Second,
+}
+
+// otelScaleEventExporter exports the scaling decisions of ScaledObjects as OpenTelemetry log records,
+// one LoggerProvider is created per OTLP endpoint and shared across ScaledObjects. The LoggerProvider
+// of an endpoint is shut down once no ScaledObject exports to it any more.
+type otelScaleEventExporter struct {
+	mutex     sync.Mutex
+	providers map[string]*sdklog.LoggerProvider
+	// endpoints are the OTLP endpoints of the ScaledObjects by their identifier
+	endpoints map[string]string
+}
+
+func newOtelScaleEventExporter() *otelScaleEventExporter {
+	return &otelScaleEventExporter{
+		providers: map[string]*sdklog.LoggerProvider{},
+		endpoints: map[string]string{},
+	}
+}
+
+// getProvider returns the LoggerProvider for the endpoint, creating it if it doesn't exist yet
+func (o *otelScaleEventExporter) getProvider(ctx context.Context, endpoint string) (*sdklog.LoggerProvider, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if provider, ok := o.providers[endpoint]; ok {
+		return provider, nil
+	}
+
+	options := []otlploggrpc.Option{otlploggrpc.WithRetry(otelScaleEventsRetryConfig)}
+	if strings.Contains(endpoint, "://") {
+		options = append(options, otlploggrpc.WithEndpointURL(endpoint))
+	} else {
+		options = append(options, otlploggrpc.WithEndpoint(endpoint))
+	}
+
+	exporter, err := otlploggrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP log exporter for %s: %w", endpoint, err)
+	}
+
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+	o.providers[endpoint] = provider
+	return provider, nil
+}
+
+// export emits a log record describing the scaling decision to the collector configured in the ScaledObject
+func (o *otelScaleEventExporter) export(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, trigger string, fromReplicas, toReplicas int32, metricValue float64) {
+	endpoint := scaledObject.Spec.OtelExporterEndpoint
+	if err := o.track(ctx, scaledObject.GenerateIdentifier(), endpoint); err != nil {
+		logger.Error(err, "error shutting down OpenTelemetry LoggerProvider of the previous endpoint")
+	}
+	if endpoint == "" {
+		return
+	}
+
+	provider, err := o.getProvider(ctx, endpoint)
+	if err != nil {
+		logger.Error(err, "error exporting scale event to OpenTelemetry collector")
+		return
+	}
+
+	record := otellog.Record{}
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(otellog.SeverityInfo)
+	record.SetBody(otellog.StringValue(fmt.Sprintf("Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, fromReplicas, toReplicas)))
+	record.AddAttributes(
+		otellog.String("keda.scaledobject.namespace", scaledObject.Namespace),
+		otellog.String("keda.scaledobject.name", scaledObject.Name),
+		otellog.String("keda.trigger", trigger),
+		otellog.Int64("keda.from_replicas", int64(fromReplicas)),
+		otellog.Int64("keda.to_replicas", int64(toReplicas)),
+		otellog.Float64("keda.metric_value", metricValue),
+	)
+
+	provider.Logger(otelScaleEventsLoggerName).Emit(ctx, record)
+}
+
+// track records the endpoint the ScaledObject exports to, shutting down the LoggerProvider of its previous
+// endpoint when no other ScaledObject exports to it
+func (o *otelScaleEventExporter) track(ctx context.Context, scaledObjectIdentifier, endpoint string) error {
+	o.mutex.Lock()
+	if previous, ok := o.endpoints[scaledObjectIdentifier]; ok && previous == endpoint {
+		o.mutex.Unlock()
+		return nil
+	}
+	provider := o.release(scaledObjectIdentifier)
+	if endpoint != "" {
+		o.endpoints[scaledObjectIdentifier] = endpoint
+	}
+	o.mutex.Unlock()
+
+	return shutdownProvider(ctx, provider)
+}
+
+// forget removes the ScaledObject, shutting down the LoggerProvider of its endpoint when no other ScaledObject
+// exports to it
+func (o *otelScaleEventExporter) forget(ctx context.Context, scaledObjectIdentifier string) error {
+	o.mutex.Lock()
+	provider := o.release(scaledObjectIdentifier)
+	o.mutex.Unlock()
+
+	return shutdownProvider(ctx, provider)
+}
+
+// release removes the endpoint of the ScaledObject, and returns the LoggerProvider of the endpoint once
+// no other ScaledObject references it, removed as well. The mutex must be held.
+func (o *otelScaleEventExporter) release(scaledObjectIdentifier string) *sdklog.LoggerProvider {
+	endpoint, ok := o.endpoints[scaledObjectIdentifier]
+	if !ok {
+		return nil
+	}
+	delete(o.endpoints, scaledObjectIdentifier)
+	for _, other := range o.endpoints {
+		if other == endpoint {
+			return nil
+		}
+	}
+	provider := o.providers[endpoint]
+	delete(o.providers, endpoint)
+	return provider
+}
+
+// shutdown flushes and shuts down the LoggerProviders of all the endpoints
+func (o *otelScaleEventExporter) shutdown(ctx context.Context) error {
+	o.mutex.Lock()
+	providers := o.providers
+	o.providers = map[string]*sdklog.LoggerProvider{}
+	o.endpoints = map[string]string{}
+	o.mutex.Unlock()
+
+	var errs []error
+	for _, provider := range providers {
+		errs = append(errs, shutdownProvider(ctx, provider))
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownProvider flushes the pending log records of the LoggerProvider and closes its connection
+func shutdownProvider(ctx context.Context, provider *sdklog.LoggerProvider) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// fakeLogsServer is a fake OTLP collector which captures the received log records
+type fakeLogsServer struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mutex   sync.Mutex
+	records []*logspb.LogRecord
+}
+
+func (f *fakeLogsServer) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, resourceLogs := range req.GetResourceLogs() {
+		for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+			f.records = append(f.records, scopeLogs.GetLogRecords()...)
+		}
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func startFakeLogsServer(t *testing.T) (*fakeLogsServer, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Could not start listener:", err)
+	}
+	server := &fakeLogsServer{}
+	grpcServer := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+	return server, "http://" + lis.Addr().String()
+}
+
+func TestOtelScaleEventExporterExportsLogRecord(t *testing.T) {
+	server, endpoint := startFakeLogsServer(t)
+
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			OtelExporterEndpoint: endpoint,
+		},
+	}
+
+	exporter := newOtelScaleEventExporter()
+	exporter.export(context.Background(), logr.Discard(), scaledObject, "kafka", 0, 5, 42.5)
+
+	provider, err := exporter.getProvider(context.Background(), endpoint)
+	assert.NoError(t, err)
+	assert.NoError(t, provider.ForceFlush(context.Background()))
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	assert.Len(t, server.records, 1)
+
+	record := server.records[0]
+	assert.Equal(t, "kafka", getLogRecordAttribute(record, "keda.trigger").GetStringValue())
+	assert.Equal(t, int64(0), getLogRecordAttribute(record, "keda.from_replicas").GetIntValue())
+	assert.Equal(t, int64(5), getLogRecordAttribute(record, "keda.to_replicas").GetIntValue())
+	assert.Equal(t, 42.5, getLogRecordAttribute(record, "keda.metric_value").GetDoubleValue())
+}
+
+func TestOtelScaleEventExporterSkipsWithoutEndpoint(t *testing.T) {
+	scaledObject := &v1alpha1.ScaledObject{
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+		},
+	}
+
+	exporter := newOtelScaleEventExporter()
+	exporter.export(context.Background(), logr.Discard(), scaledObject, "kafka", 0, 5, 42.5)
+	assert.Empty(t, exporter.providers)
+}
+
+func TestOtelScaleEventExporterShutsDownUnreferencedProviders(t *testing.T) {
+	_, endpoint := startFakeLogsServer(t)
+	_, otherEndpoint := startFakeLogsServer(t)
+	ctx := context.Background()
+
+	newScaledObject := func(name, endpoint string) *v1alpha1.ScaledObject {
+		return &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "namespace"},
+			Spec: v1alpha1.ScaledObjectSpec{
+				ScaleTargetRef:       &v1alpha1.ScaleTarget{Name: name},
+				OtelExporterEndpoint: endpoint,
+			},
+		}
+	}
+	first := newScaledObject("first", endpoint)
+	second := newScaledObject("second", endpoint)
+
+	exporter := newOtelScaleEventExporter()
+	exporter.export(ctx, logr.Discard(), first, "kafka", 0, 1, 1)
+	exporter.export(ctx, logr.Discard(), second, "kafka", 0, 1, 1)
+	assert.Len(t, exporter.providers, 1)
+
+	// the provider is kept while another ScaledObject exports to the endpoint
+	assert.NoError(t, exporter.forget(ctx, first.GenerateIdentifier()))
+	assert.Contains(t, exporter.providers, endpoint)
+
+	// the provider of the previous endpoint is shut down when the endpoint changes
+	exporter.export(ctx, logr.Discard(), newScaledObject("second", otherEndpoint), "kafka", 0, 1, 1)
+	assert.NotContains(t, exporter.providers, endpoint)
+	assert.Contains(t, exporter.providers, otherEndpoint)
+
+	// and when the endpoint is removed
+	exporter.export(ctx, logr.Discard(), newScaledObject("second", ""), "kafka", 0, 1, 1)
+	assert.Empty(t, exporter.providers)
+	assert.Empty(t, exporter.endpoints)
+
+	exporter.export(ctx, logr.Discard(), first, "kafka", 0, 1, 1)
+	assert.NoError(t, exporter.shutdown(ctx))
+	assert.Empty(t, exporter.providers)
+	assert.Empty(t, exporter.endpoints)
+}
+
+func getLogRecordAttribute(record *logspb.LogRecord, key string) *commonpb.AnyValue {
+	for _, kv := range record.GetAttributes() {
+		if kv.GetKey() == key {
+			return kv.GetValue()
+		}
+	}
+	return nil
+}
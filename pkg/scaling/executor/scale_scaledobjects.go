@@ -18,6 +18,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -26,7 +27,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/scale"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -44,8 +52,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	var currentReplicas int32
 	targetName := scaledObject.Spec.ScaleTargetRef.Name
 	targetGVKR := scaledObject.Status.ScaleTargetGVKR
+	isRemoteTarget := scaledObject.Spec.TargetCluster != ""
 	switch {
-	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
+	case !isRemoteTarget && targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
 		deployment := &appsv1.Deployment{}
 		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, deployment)
 		if err != nil {
@@ -53,7 +62,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *deployment.Spec.Replicas
-	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
+	case !isRemoteTarget && targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
 		statefulSet := &appsv1.StatefulSet{}
 		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet)
 		if err != nil {
@@ -134,8 +143,20 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// AND
 			// replica count is equal to 0
 
-			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers)
+			// Scale the ScaleTarget up, once the ScaledObjects it depends on have enough ready replicas
+			if !e.areDependenciesReady(ctx, logger, scaledObject) {
+				return
+			}
+			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options)
+		case isRemoteTarget && !isError && options != nil && options.DesiredReplicas != nil:
+			// triggers are active and the ScaleTarget lives in a remote cluster, there is no HPA scaling it
+
+			// Scale the ScaleTarget to the replicas computed from the metrics and update LastActiveTime to now
+			e.scaleRemoteTarget(ctx, logger, scaledObject, currentScale, currentReplicas, *options.DesiredReplicas, max(minReplicas, 1), options)
+			if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
+				logger.Error(err, "Error updating last active time")
+				return
+			}
 		case isError:
 			// some triggers are active, but some responded with error
 
@@ -166,7 +187,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is a fallback replicas count defined
 
 			// Scale to the fallback replicas count
-			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
+			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas, options)
 		case isError && scaledObject.Spec.Fallback == nil:
 			// there are no active triggers, but a scaler responded with an error
 			// AND
@@ -191,7 +212,14 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale, options)
+		case isRemoteTarget && currentReplicas > minReplicas && minReplicas > 0:
+			// there are no active triggers, the ScaleTarget lives in a remote cluster, where no HPA scales it in
+			// AND
+			// ScaleTarget replicas count is greater than minimum replica count specified in ScaledObject
+
+			// ScaleTarget replicas count to minimum
+			e.scaleRemoteTarget(ctx, logger, scaledObject, currentScale, currentReplicas, minReplicas, minReplicas, options)
 		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
 			// there are no active triggers
 			// AND
@@ -230,12 +258,13 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	}
 }
 
-func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32) {
+func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, currentScale *autoscalingv1.Scale, logger logr.Logger, currentReplicas int32, options *ScaleExecutorOptions) {
 	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, scaledObject.Spec.Fallback.Replicas)
 	if err == nil {
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+		e.otelEvents.export(ctx, logger, scaledObject, strings.Join(options.ActiveTriggers, ";"), currentReplicas, scaledObject.Spec.Fallback.Replicas, options.MetricValue)
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
@@ -244,7 +273,7 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, options *ScaleExecutorOptions) {
 	var initialCooldownPeriod, cooldownPeriod time.Duration
 
 	if scaledObject.Spec.InitialCooldownPeriod != nil {
@@ -271,6 +300,26 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 		// or last time a trigger was active was > cooldown period, so scale in.
 		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
 
+		if scale == nil {
+			// Wasn't retrieved earlier, grab it now as it's needed to check the PodDisruptionBudgets
+			var err error
+			scale, err = e.getScaleTargetScale(ctx, scaledObject)
+			if err != nil {
+				logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+				return
+			}
+		}
+
+		// PodDisruptionBudgets of ScaleTargets in remote clusters aren't visible here
+		if scaledObject.Spec.TargetCluster == "" {
+			var clamped bool
+			scaleToReplicas, clamped = e.clampToPodDisruptionBudgets(ctx, logger, scaledObject, scaleToReplicas)
+			if clamped && scaleToReplicas >= scale.Spec.Replicas {
+				logger.V(1).Info("ScaleTarget scale in is blocked by PodDisruptionBudget", "Replicas Count", scale.Spec.Replicas)
+				return
+			}
+		}
+
 		currentReplicas, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, scaleToReplicas)
 		if err == nil {
 			msg := "Successfully set ScaleTarget replicas count to ScaledObject"
@@ -283,6 +332,7 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
+			e.otelEvents.export(ctx, logger, scaledObject, strings.Join(options.ActiveTriggers, ";"), currentReplicas, scaleToReplicas, options.MetricValue)
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
 				return
@@ -306,7 +356,7 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	}
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, activeTriggers []string) {
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, options *ScaleExecutorOptions) {
 	var replicas int32
 	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
 		replicas = *scaledObject.Spec.MinReplicaCount
@@ -320,7 +370,8 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
-		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(activeTriggers, ";"))
+		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(options.ActiveTriggers, ";"))
+		e.otelEvents.export(ctx, logger, scaledObject, strings.Join(options.ActiveTriggers, ";"), currentReplicas, replicas, options.MetricValue)
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
@@ -332,8 +383,24 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 	}
 }
 
+// getScaleClient returns the scale client of the cluster where the ScaleTarget lives
+func (e *scaleExecutor) getScaleClient(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (scale.ScalesGetter, error) {
+	if scaledObject.Spec.TargetCluster == "" {
+		return e.scaleClient, nil
+	}
+	cluster, err := e.remoteClusters.Get(ctx, scaledObject.Namespace, scaledObject.Spec.TargetCluster)
+	if err != nil {
+		return nil, err
+	}
+	return cluster.ScaleClient, nil
+}
+
 func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
-	return e.scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	scaleClient, err := e.getScaleClient(ctx, scaledObject)
+	if err != nil {
+		return nil, err
+	}
+	return scaleClient.Scales(scaledObject.Namespace).Get(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
 }
 
 func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, replicas int32) (int32, error) {
@@ -350,10 +417,206 @@ func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObje
 	currentReplicas := scale.Spec.Replicas
 	scale.Spec.Replicas = replicas
 
-	_, err := e.scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
+	scaleClient, err := e.getScaleClient(ctx, scaledObject)
+	if err != nil {
+		return currentReplicas, err
+	}
+	_, err = scaleClient.Scales(scaledObject.Namespace).Update(ctx, scaledObject.Status.ScaleTargetGVKR.GroupResource(), scale, metav1.UpdateOptions{})
 	return currentReplicas, err
 }
 
+// scaleRemoteTarget scales the ScaleTarget living in a remote cluster to the desired replicas bounded by
+// the min and max replica counts, it takes over what the HPA does for ScaleTargets in the local cluster
+func (e *scaleExecutor) scaleRemoteTarget(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, currentReplicas, desiredReplicas, minReplicas int32, options *ScaleExecutorOptions) {
+	replicas := desiredReplicas
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	if maxReplicas := scaledObject.GetHPAMaxReplicas(); replicas > maxReplicas {
+		replicas = maxReplicas
+	}
+	if replicas == currentReplicas {
+		return
+	}
+	if scaledObject.IsBelowMinScalingStep(currentReplicas, replicas) {
+		logger.V(1).Info("Skipping scaling of ScaleTarget in remote cluster, the change is smaller than minScalingStep",
+			"Original Replicas Count", currentReplicas,
+			"Desired Replicas Count", replicas)
+		return
+	}
+
+	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, replicas)
+	if err != nil {
+		logger.Error(err, "Error scaling ScaleTarget in remote cluster", "targetCluster", scaledObject.Spec.TargetCluster)
+		return
+	}
+	logger.Info("Successfully scaled ScaleTarget in remote cluster",
+		"targetCluster", scaledObject.Spec.TargetCluster,
+		"Original Replicas Count", currentReplicas,
+		"New Replicas Count", replicas)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaled, "Scaled %s %s/%s in cluster %s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, scaledObject.Spec.TargetCluster, currentReplicas, replicas)
+	if options != nil {
+		e.otelEvents.export(ctx, logger, scaledObject, strings.Join(options.ActiveTriggers, ";"), currentReplicas, replicas, options.MetricValue)
+	}
+}
+
+// areDependenciesReady checks that the scale targets of the ScaledObjects listed in dependsOn have
+// at least minAvailableReplicas ready replicas. If some of them don't, a ScaleUpBlockedByDependency event is emitted.
+func (e *scaleExecutor) areDependenciesReady(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) bool {
+	for _, dependency := range scaledObject.Spec.DependsOn {
+		key := dependency.GetNamespacedName(scaledObject.Namespace)
+		minAvailable := dependency.GetMinAvailableReplicas()
+
+		readyReplicas, err := e.getDependencyReadyReplicas(ctx, key)
+		if err != nil {
+			logger.Error(err, "Error getting ready replicas of the ScaledObject dependency", "dependency", key.String())
+			readyReplicas = 0
+		}
+		if readyReplicas < minAvailable {
+			logger.V(1).Info("Not scaling up, dependency doesn't have enough ready replicas", "dependency", key.String(), "readyReplicas", readyReplicas, "minAvailableReplicas", minAvailable)
+			e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.ScaleUpBlockedByDependency,
+				"Scale up of %s %s/%s is waiting for ScaledObject %s to have %d ready replicas, it has %d",
+				scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, key.String(), minAvailable, readyReplicas)
+			return false
+		}
+	}
+	return true
+}
+
+// getDependencyReadyReplicas returns the ready replicas of the scale target of the referenced ScaledObject,
+// the replicas reported by /scale subresource are used for targets other than Deployment and StatefulSet
+func (e *scaleExecutor) getDependencyReadyReplicas(ctx context.Context, key client.ObjectKey) (int32, error) {
+	dependency := &kedav1alpha1.ScaledObject{}
+	if err := e.client.Get(ctx, key, dependency); err != nil {
+		return 0, err
+	}
+	targetGVKR := dependency.Status.ScaleTargetGVKR
+	if targetGVKR == nil {
+		return 0, fmt.Errorf("scale target of ScaledObject %s hasn't been detected yet", key.String())
+	}
+	targetKey := client.ObjectKey{Namespace: dependency.Namespace, Name: dependency.Spec.ScaleTargetRef.Name}
+
+	switch {
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := e.client.Get(ctx, targetKey, deployment); err != nil {
+			return 0, err
+		}
+		return deployment.Status.ReadyReplicas, nil
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := e.client.Get(ctx, targetKey, statefulSet); err != nil {
+			return 0, err
+		}
+		return statefulSet.Status.ReadyReplicas, nil
+	default:
+		scale, err := e.getScaleTargetScale(ctx, dependency)
+		if err != nil {
+			return 0, err
+		}
+		return scale.Status.Replicas, nil
+	}
+}
+
+// clampToPodDisruptionBudgets returns the replica count the ScaleTarget can be scaled in to without violating
+// the minAvailable of the PodDisruptionBudgets selecting its pods, and whether it was clamped. If a PodDisruptionBudget
+// would be violated, the replica count is clamped to its minimum and a ScaleDownBlockedByPDB event is emitted.
+func (e *scaleExecutor) clampToPodDisruptionBudgets(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, replicas int32) (int32, bool) {
+	podLabels, err := GetScaleTargetPodLabels(ctx, e.client, scaledObject, scaledObject.Status.ScaleTargetGVKR)
+	if err != nil {
+		logger.Error(err, "Error getting the pod labels of the scale target, scaling in without checking PodDisruptionBudgets")
+		return replicas, false
+	}
+	minAvailable, pdbName, err := GetPodDisruptionBudgetMinAvailable(ctx, e.client, logger, scaledObject.Namespace, podLabels, true)
+	if err != nil {
+		logger.Error(err, "Error getting PodDisruptionBudgets, scaling in without checking them")
+		return replicas, false
+	}
+	if replicas >= minAvailable {
+		return replicas, false
+	}
+
+	e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.ScaleDownBlockedByPDB,
+		"Scale in of %s %s/%s to %d replicas is blocked by PodDisruptionBudget %s, keeping at least %d replicas",
+		scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, replicas, pdbName, minAvailable)
+	return minAvailable, true
+}
+
+// GetScaleTargetPodLabels returns the labels of the pod template of the scale target of the ScaledObject, the pod
+// template of targets other than Deployment and StatefulSet being read as a PodSpecable duck type
+func GetScaleTargetPodLabels(ctx context.Context, c client.Client, scaledObject *kedav1alpha1.ScaledObject, targetGVKR *kedav1alpha1.GroupVersionKindResource) (labels.Set, error) {
+	if targetGVKR == nil {
+		return nil, fmt.Errorf("scale target of ScaledObject %s/%s hasn't been detected yet", scaledObject.Namespace, scaledObject.Name)
+	}
+	targetKey := client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}
+
+	switch {
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, targetKey, deployment); err != nil {
+			return nil, err
+		}
+		return deployment.Spec.Template.Labels, nil
+	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := c.Get(ctx, targetKey, statefulSet); err != nil {
+			return nil, err
+		}
+		return statefulSet.Spec.Template.Labels, nil
+	default:
+		unstruct := &unstructured.Unstructured{}
+		unstruct.SetGroupVersionKind(targetGVKR.GroupVersionKind())
+		if err := c.Get(ctx, targetKey, unstruct); err != nil {
+			return nil, err
+		}
+		withPods := &duckv1.WithPod{}
+		if err := duck.FromUnstructured(unstruct, withPods); err != nil {
+			return nil, fmt.Errorf("error reading the pod template of the scale target: %w", err)
+		}
+		return withPods.Spec.Template.Labels, nil
+	}
+}
+
+// GetPodDisruptionBudgetMinAvailable returns the highest minAvailable of the PodDisruptionBudgets in the namespace
+// selecting the pods with the labels, and the name of that PodDisruptionBudget. It returns 0 when none of them
+// selects the pods. A percentage minAvailable is scaled against the pods expected by the PodDisruptionBudget, and is
+// ignored unless withPercentages is set.
+func GetPodDisruptionBudgetMinAvailable(ctx context.Context, c client.Client, logger logr.Logger, namespace string, podLabels labels.Set, withPercentages bool) (int32, string, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(ctx, pdbList, client.InNamespace(namespace)); err != nil {
+		return 0, "", err
+	}
+
+	minAvailable := int32(0)
+	pdbName := ""
+	for _, pdb := range pdbList.Items {
+		if pdb.Spec.MinAvailable == nil || pdb.Spec.Selector == nil {
+			continue
+		}
+		if pdb.Spec.MinAvailable.Type == intstr.String && !withPercentages {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			logger.Error(err, "Error parsing PodDisruptionBudget selector", "podDisruptionBudget", pdb.Name)
+			continue
+		}
+		if !selector.Matches(podLabels) {
+			continue
+		}
+		value, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, int(pdb.Status.ExpectedPods), true)
+		if err != nil {
+			logger.Error(err, "Error getting PodDisruptionBudget minAvailable", "podDisruptionBudget", pdb.Name)
+			continue
+		}
+		if int32(value) > minAvailable {
+			minAvailable = int32(value)
+			pdbName = pdb.Name
+		}
+	}
+	return minAvailable, pdbName, nil
+}
+
 // getIdleOrMinimumReplicaCount returns true if the second value returned is from IdleReplicaCount
 // it returns false if it is from MinReplicaCount followed by the actual value
 func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool, int32) {
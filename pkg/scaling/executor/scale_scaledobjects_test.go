@@ -25,11 +25,21 @@ import (
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_remotecluster"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
 )
 
@@ -134,7 +144,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &numberOfReplicas,
 		},
-	})
+	}).Times(2)
 
 	scale := &autoscalingv1.Scale{
 		Spec: autoscalingv1.ScaleSpec{
@@ -142,6 +152,7 @@ func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
 		},
 	}
 
+	client.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
 	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
 	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
@@ -317,7 +328,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &numberOfReplicas,
 		},
-	})
+	}).Times(2)
 
 	scale := &autoscalingv1.Scale{
 		Spec: autoscalingv1.ScaleSpec{
@@ -325,6 +336,7 @@ func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 		},
 	}
 
+	client.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
 	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
 	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
@@ -522,3 +534,265 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	eventstring := <-recorder.Events
 	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger", eventstring)
 }
+
+func TestScaleToZeroIsClampedByPodDisruptionBudget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(2)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(10)
+
+	// the scale target is read for its replicas, then for the labels of its pods
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "worker", "tier": "backend"}},
+			},
+		},
+	}).Times(2)
+
+	pdbMinAvailable := intstr.FromInt32(3)
+	pdbMinAvailablePercentage := intstr.FromString("40%")
+	client.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, policyv1.PodDisruptionBudgetList{
+		Items: []policyv1.PodDisruptionBudget{
+			{
+				ObjectMeta: v1.ObjectMeta{Name: "other", Namespace: "namespace"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: &pdbMinAvailable,
+					Selector:     &v1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+				},
+			},
+			{
+				ObjectMeta: v1.ObjectMeta{Name: "pdb", Namespace: "namespace"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: &pdbMinAvailable,
+					Selector:     &v1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}},
+				},
+			},
+			{
+				ObjectMeta: v1.ObjectMeta{Name: "pdb-set-based", Namespace: "namespace"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: &pdbMinAvailablePercentage,
+					Selector: &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{
+						{Key: "app", Operator: v1.LabelSelectorOpIn, Values: []string{"worker", "web"}},
+						{Key: "canary", Operator: v1.LabelSelectorOpDoesNotExist},
+					}},
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{ExpectedPods: 10},
+			},
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: numberOfReplicas,
+		},
+		Status: autoscalingv1.ScaleStatus{
+			Selector: "app in (worker),!canary",
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false, &ScaleExecutorOptions{})
+
+	// the percentage of the PodDisruptionBudget with a set-based selector is scaled against its expected pods
+	assert.Equal(t, int32(4), scale.Spec.Replicas)
+	eventstring := <-recorder.Events
+	assert.Equal(t, "Warning ScaleDownBlockedByPDB Scale in of  namespace/name to 0 replicas is blocked by PodDisruptionBudget pdb-set-based, keeping at least 4 replicas", eventstring)
+}
+
+func TestScaleUpWaitsForDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+
+	// app depends on pool, which depends on db
+	db := newDependentScaledObject("db")
+	pool := newDependentScaledObject("pool", v1alpha1.ScaledObjectDependency{Name: "db"})
+	app := newDependentScaledObject("app", v1alpha1.ScaledObjectDependency{Name: "pool"})
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1alpha1.AddToScheme(scheme))
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.ScaledObject{}, &appsv1.Deployment{}).
+		WithObjects(db, pool, app, newDependentDeployment("db", 0), newDependentDeployment("pool", 0), newDependentDeployment("app", 0)).
+		Build()
+	// the fake client drops the status of objects with status subresource on create
+	for _, so := range []*v1alpha1.ScaledObject{db, pool, app} {
+		assert.NoError(t, client.Status().Update(context.TODO(), so))
+	}
+
+	scaled := []string{}
+	mockScaleClient.EXPECT().Scales("namespace").Return(mockScaleInterface).AnyTimes()
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ schema.GroupResource, name string, _ v1.GetOptions) (*autoscalingv1.Scale, error) {
+			return &autoscalingv1.Scale{ObjectMeta: v1.ObjectMeta{Name: name}}, nil
+		}).AnyTimes()
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ schema.GroupResource, scale *autoscalingv1.Scale, _ v1.UpdateOptions) (*autoscalingv1.Scale, error) {
+			scaled = append(scaled, scale.Name)
+			return scale, nil
+		}).AnyTimes()
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+	requestScale := func(so *v1alpha1.ScaledObject) {
+		scaleExecutor.RequestScale(context.TODO(), so, true, false, &ScaleExecutorOptions{})
+	}
+	setReadyReplicas := func(name string, readyReplicas int32) {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Namespace: "namespace", Name: name}, deployment))
+		deployment.Status.ReadyReplicas = readyReplicas
+		assert.NoError(t, client.Status().Update(context.TODO(), deployment))
+	}
+
+	// db isn't ready, neither pool nor app are scaled up
+	requestScale(pool)
+	requestScale(app)
+	assert.Empty(t, scaled)
+	assert.Equal(t, "Warning ScaleUpBlockedByDependency Scale up of  namespace/pool is waiting for ScaledObject namespace/db to have 1 ready replicas, it has 0", <-recorder.Events)
+
+	// db is ready, pool is scaled up, but app waits for pool to be ready
+	setReadyReplicas("db", 1)
+	requestScale(pool)
+	requestScale(app)
+	assert.Equal(t, []string{"pool"}, scaled)
+
+	// pool is ready too, app is scaled up
+	setReadyReplicas("pool", 1)
+	requestScale(app)
+	assert.Equal(t, []string{"pool", "app"}, scaled)
+}
+
+func newDependentScaledObject(name string, dependsOn ...v1alpha1.ScaledObjectDependency) *v1alpha1.ScaledObject {
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: name,
+			},
+			DependsOn: dependsOn,
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	return scaledObject
+}
+
+func newDependentDeployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "namespace",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}
+}
+
+func TestScaleRemoteTarget(t *testing.T) {
+	tests := []struct {
+		name             string
+		currentReplicas  int32
+		desiredReplicas  int32
+		expectedReplicas int32
+		expectedEvent    string
+	}{
+		{name: "scale out", currentReplicas: 2, desiredReplicas: 4, expectedReplicas: 4, expectedEvent: "Normal KEDAScaleTargetScaled Scaled apps/v1.Deployment namespace/app in cluster remote from 2 to 4"},
+		{name: "scale in", currentReplicas: 4, desiredReplicas: 3, expectedReplicas: 3, expectedEvent: "Normal KEDAScaleTargetScaled Scaled apps/v1.Deployment namespace/app in cluster remote from 4 to 3"},
+		{name: "bounded by max replicas", currentReplicas: 2, desiredReplicas: 20, expectedReplicas: 5, expectedEvent: "Normal KEDAScaleTargetScaled Scaled apps/v1.Deployment namespace/app in cluster remote from 2 to 5"},
+		{name: "bounded by min replicas", currentReplicas: 4, desiredReplicas: 0, expectedReplicas: 1, expectedEvent: "Normal KEDAScaleTargetScaled Scaled apps/v1.Deployment namespace/app in cluster remote from 4 to 1"},
+		{name: "already scaled", currentReplicas: 3, desiredReplicas: 3, expectedReplicas: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remote := mock_remotecluster.NewServer("namespace", map[string]int32{"app": test.currentReplicas})
+			defer remote.Close()
+
+			scaledObject := newDependentScaledObject("app")
+			scaledObject.Spec.TargetCluster = "remote"
+			scaledObject.Spec.MaxReplicaCount = ptr.To[int32](5)
+			scaledObject.Status.ScaleTargetKind = "apps/v1.Deployment"
+			scaledObject.Status.ScaleTargetGVKR = &v1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+			scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, v1alpha1.ScaledObjectConditionReadySuccessReason, v1alpha1.ScaledObjectConditionReadySuccessMessage)
+
+			scheme := runtime.NewScheme()
+			assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+			assert.NoError(t, v1alpha1.AddToScheme(scheme))
+			client := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&v1alpha1.ScaledObject{}).
+				WithObjects(
+					scaledObject,
+					&v1alpha1.ClusterConfig{
+						ObjectMeta: v1.ObjectMeta{Name: "remote", Namespace: "namespace"},
+						Spec:       v1alpha1.ClusterConfigSpec{KubeconfigSecretRef: v1alpha1.KubeconfigSecretRef{Name: "kubeconfig"}},
+					},
+					&corev1.Secret{
+						ObjectMeta: v1.ObjectMeta{Name: "kubeconfig", Namespace: "namespace"},
+						Data:       map[string][]byte{v1alpha1.DefaultClusterConfigKubeconfigKey: remote.Kubeconfig()},
+					},
+				).Build()
+			recorder := record.NewFakeRecorder(1)
+
+			// the ScaleTarget in the remote cluster is scaled directly, the local scale client isn't used
+			scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+			scaleExecutor.RequestScale(context.TODO(), scaledObject, true, false, &ScaleExecutorOptions{DesiredReplicas: ptr.To(test.desiredReplicas)})
+
+			replicas, _ := remote.Replicas("app")
+			assert.Equal(t, test.expectedReplicas, replicas)
+			if test.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else if assert.Len(t, recorder.Events, 1) {
+				assert.Equal(t, test.expectedEvent, <-recorder.Events)
+			}
+		})
+	}
+}
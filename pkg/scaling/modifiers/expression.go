@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/go-logr/logr"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// HandleTriggerExpressions replaces the metric values of the triggers with an expression by the result of
+// the expression, evaluated with the metric values of the other triggers. The metric value of the trigger is
+// kept when the expression can't be evaluated.
+func HandleTriggerExpressions(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, metricTriggerList map[string]string, fallbackActive bool, cacheObj *cache.ScalersCache, log logr.Logger) []external_metrics.ExternalMetricValue {
+	// dont manipulate with metrics if fallback is currently active
+	if so == nil || fallbackActive || !so.IsUsingTriggerExpressions() {
+		return metrics
+	}
+
+	data := make(map[string]float64)
+	for _, metric := range metrics {
+		if trigger, ok := metricTriggerList[metric.MetricName]; ok {
+			data[trigger] = metric.Value.AsApproximateFloat64()
+		}
+	}
+
+	result := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		trigger := metricTriggerList[metric.MetricName]
+		if program, ok := cacheObj.CompiledTriggerExpressions[trigger]; ok {
+			value, err := calculateTriggerExpression(program, data)
+			if err != nil {
+				log.Error(err, "error evaluating trigger expression", "trigger", trigger)
+			} else {
+				metric.Value.SetMilli(int64(value * 1000))
+			}
+		}
+		result = append(result, metric)
+	}
+	log.V(1).Info("returned metrics after trigger expressions are applied", "metrics", result)
+	return result
+}
+
+// calculateTriggerExpression runs the compiled expression with the metric values, divisions by a zero
+// metric value are reported as errors
+func calculateTriggerExpression(program *vm.Program, data map[string]float64) (float64, error) {
+	out, err := expr.Run(program, data)
+	if err != nil {
+		return 0, fmt.Errorf("error trying to run trigger expression: %w", err)
+	}
+	value := out.(float64)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("trigger expression returned %v, probably because of a division by zero", value)
+	}
+	return value, nil
+}
+
+// FilterMetricsByName returns the metrics with the name
+func FilterMetricsByName(metrics []external_metrics.ExternalMetricValue, metricName string) []external_metrics.ExternalMetricValue {
+	var result []external_metrics.ExternalMetricValue
+	for _, metric := range metrics {
+		if metric.MetricName == metricName {
+			result = append(result, metric)
+		}
+	}
+	return result
+}
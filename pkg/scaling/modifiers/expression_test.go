@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestHandleTriggerExpressions(t *testing.T) {
+	so := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "lag", Type: "kafka"},
+				{Name: "workers", Type: "prometheus"},
+				{Name: "buffer", Type: "prometheus"},
+				{Name: "computed", Type: "prometheus", Metadata: map[string]string{kedav1alpha1.TriggerExpressionMetadataKey: "lag / workers - buffer"}},
+			},
+		},
+	}
+	programs, err := kedav1alpha1.CompileTriggerExpressions(so.Spec.Triggers)
+	assert.NoError(t, err)
+	cacheObj := &cache.ScalersCache{CompiledTriggerExpressions: programs}
+	pairs := map[string]string{"s0-lag": "lag", "s1-workers": "workers", "s2-buffer": "buffer", "s3-computed": "computed"}
+
+	newMetrics := func(workers float64) []external_metrics.ExternalMetricValue {
+		return []external_metrics.ExternalMetricValue{
+			scalers.GenerateMetricInMili("s0-lag", 100),
+			scalers.GenerateMetricInMili("s1-workers", workers),
+			scalers.GenerateMetricInMili("s2-buffer", 5),
+			scalers.GenerateMetricInMili("s3-computed", 1),
+		}
+	}
+
+	metrics := HandleTriggerExpressions(so, newMetrics(4), pairs, false, cacheObj, logr.Discard())
+	computed := FilterMetricsByName(metrics, "s3-computed")
+	assert.Len(t, computed, 1)
+	assert.Equal(t, float64(20), computed[0].Value.AsApproximateFloat64())
+	assert.Equal(t, float64(100), FilterMetricsByName(metrics, "s0-lag")[0].Value.AsApproximateFloat64())
+
+	// division by a zero metric value keeps the metric value of the trigger
+	metrics = HandleTriggerExpressions(so, newMetrics(0), pairs, false, cacheObj, logr.Discard())
+	assert.Equal(t, float64(1), FilterMetricsByName(metrics, "s3-computed")[0].Value.AsApproximateFloat64())
+
+	// expressions aren't applied while fallback is active
+	metrics = HandleTriggerExpressions(so, newMetrics(4), pairs, true, cacheObj, logr.Discard())
+	assert.Equal(t, float64(1), FilterMetricsByName(metrics, "s3-computed")[0].Value.AsApproximateFloat64())
+}
@@ -119,11 +119,17 @@ func calculateScalingModifiersFormula(list []external_metrics.ExternalMetricValu
 
 // GetPairTriggerAndMetric adds new pair of trigger-metric to the list for
 // scalingModifiers formula list thats needed to map the metric value to
-// trigger name. This is only ran if scalingModifiers.Formula is defined in SO.
+// trigger name. This is only ran if scalingModifiers.Formula or trigger expressions
+// are defined in SO.
 func GetPairTriggerAndMetric(so *kedav1alpha1.ScaledObject, metric string, trigger string) (map[string]string, error) {
 	list := map[string]string{}
-	if so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Formula != "" {
+	usingFormula := so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Formula != ""
+	if usingFormula || so.IsUsingTriggerExpressions() {
 		if trigger == "" {
+			if !usingFormula {
+				// unnamed triggers can't be referenced by trigger expressions
+				return list, nil
+			}
 			return list, fmt.Errorf("trigger name not given with compositeScaler for metric %s", metric)
 		}
 
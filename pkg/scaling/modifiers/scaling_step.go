@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"math"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// HandleMinScalingStep replaces the value of each metric whose desired replicas differ from the current replicas
+// by less than minScalingStep with the value for which the HPA keeps the current replicas. The desired replicas
+// are computed the way the HPA does, from the targets of the metrics in its spec.
+func HandleMinScalingStep(so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, hpaMetricSpecs []v2.MetricSpec, currentReplicas int32, log logr.Logger) []external_metrics.ExternalMetricValue {
+	if so == nil || so.Spec.MinScalingStep == nil || currentReplicas == 0 {
+		return metrics
+	}
+
+	targets := make(map[string]v2.MetricTarget, len(hpaMetricSpecs))
+	for _, spec := range hpaMetricSpecs {
+		if spec.External != nil {
+			targets[spec.External.Metric.Name] = spec.External.Target
+		}
+	}
+
+	result := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		target, ok := targets[metric.MetricName]
+		if !ok {
+			result = append(result, metric)
+			continue
+		}
+
+		value := metric.Value.AsApproximateFloat64()
+		var desiredReplicas int32
+		var keepValue float64
+		switch {
+		case target.Type == v2.AverageValueMetricType && target.AverageValue != nil && target.AverageValue.AsApproximateFloat64() > 0:
+			averageValue := target.AverageValue.AsApproximateFloat64()
+			desiredReplicas = int32(math.Ceil(value / averageValue))
+			keepValue = averageValue * float64(currentReplicas)
+		case target.Type == v2.ValueMetricType && target.Value != nil && target.Value.AsApproximateFloat64() > 0:
+			targetValue := target.Value.AsApproximateFloat64()
+			desiredReplicas = int32(math.Ceil(float64(currentReplicas) * value / targetValue))
+			keepValue = targetValue
+		default:
+			result = append(result, metric)
+			continue
+		}
+
+		if so.IsBelowMinScalingStep(currentReplicas, desiredReplicas) {
+			log.V(1).Info("Keeping current replicas, the change is smaller than minScalingStep", "metricName", metric.MetricName, "currentReplicas", currentReplicas, "desiredReplicas", desiredReplicas)
+			metric.Value.SetMilli(int64(keepValue * 1000))
+		}
+		result = append(result, metric)
+	}
+	return result
+}
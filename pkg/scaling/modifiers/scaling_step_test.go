@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newAverageValueMetricSpec(metricName string, averageValue int64) v2.MetricSpec {
+	return v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: metricName},
+			Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(averageValue, resource.DecimalSI)},
+		},
+	}
+}
+
+// hpaDesiredReplicas computes the replicas the HPA scales to for an AverageValue metric
+func hpaDesiredReplicas(metric external_metrics.ExternalMetricValue, averageValue float64) int32 {
+	return int32(math.Ceil(metric.Value.AsApproximateFloat64() / averageValue))
+}
+
+func TestHandleMinScalingStepSkipsSmallChanges(t *testing.T) {
+	step := int32(2)
+	so := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{MinScalingStep: &step}}
+	hpaMetricSpecs := []v2.MetricSpec{newAverageValueMetricSpec("s0-metric", 10)}
+
+	currentReplicas := int32(10)
+	// the metric oscillates around the target of 10 replicas, desired replicas stay within 1 replica
+	for _, value := range []int64{99, 101, 100, 95, 109, 91, 110} {
+		metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(value, resource.DecimalSI)}}
+		metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, currentReplicas, logr.Discard())
+		assert.Len(t, metrics, 1)
+		currentReplicas = hpaDesiredReplicas(metrics[0], 10)
+		assert.Equal(t, int32(10), currentReplicas, "replicas changed for metric value %d", value)
+	}
+
+	// the full desired replicas are applied once the change reaches the step
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(125, resource.DecimalSI)}}
+	metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, currentReplicas, logr.Discard())
+	assert.Equal(t, int32(13), hpaDesiredReplicas(metrics[0], 10))
+
+	metrics = []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(80, resource.DecimalSI)}}
+	metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, 10, logr.Discard())
+	assert.Equal(t, int32(8), hpaDesiredReplicas(metrics[0], 10))
+}
+
+func TestHandleMinScalingStepValueTarget(t *testing.T) {
+	step := int32(3)
+	so := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{MinScalingStep: &step}}
+	hpaMetricSpecs := []v2.MetricSpec{{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: "s0-metric"},
+			Target: v2.MetricTarget{Type: v2.ValueMetricType, Value: resource.NewQuantity(100, resource.DecimalSI)},
+		},
+	}}
+
+	// 4 replicas * 120 / 100 = 5 desired replicas, which is within the step
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(120, resource.DecimalSI)}}
+	metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, 4, logr.Discard())
+	assert.Equal(t, float64(100), metrics[0].Value.AsApproximateFloat64())
+
+	// 4 replicas * 200 / 100 = 8 desired replicas, which exceeds the step
+	metrics = []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(200, resource.DecimalSI)}}
+	metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, 4, logr.Discard())
+	assert.Equal(t, float64(200), metrics[0].Value.AsApproximateFloat64())
+}
+
+func TestHandleMinScalingStepIgnoresScalingFromZero(t *testing.T) {
+	step := int32(5)
+	so := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{MinScalingStep: &step}}
+	hpaMetricSpecs := []v2.MetricSpec{newAverageValueMetricSpec("s0-metric", 10)}
+
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-metric", Value: *resource.NewQuantity(20, resource.DecimalSI)}}
+	metrics = HandleMinScalingStep(so, metrics, hpaMetricSpecs, 0, logr.Discard())
+	assert.Equal(t, float64(20), metrics[0].Value.AsApproximateFloat64())
+}
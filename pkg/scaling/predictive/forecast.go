@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"math"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	defaultHistoryMinutes = 60
+
+	// the smoothing factors of the level, the trend and the season of the holtWinters model, favoring the recent
+	// values for the level and smoothing the trend over a longer period
+	holtWintersAlpha = 0.5
+	holtWintersBeta  = 0.1
+	holtWintersGamma = 0.3
+)
+
+// historySteps returns the number of steps of the history of the metrics
+func historySteps(config *kedav1alpha1.PredictiveConfig) int {
+	switch {
+	case config.HistoryMinutes > 0:
+		return int(config.HistoryMinutes)
+	case config.Model == kedav1alpha1.PredictiveModelHoltWinters:
+		return 2 * int(config.SeasonMinutes)
+	default:
+		return defaultHistoryMinutes
+	}
+}
+
+// zScore returns the number of standard deviations of the upper bound of the prediction interval of the confidence
+// level, 0 for the forecast itself
+func zScore(confidence int32) float64 {
+	if confidence <= 50 {
+		return 0
+	}
+	return math.Sqrt2 * math.Erfinv(2*float64(confidence)/100-1)
+}
+
+// forecast returns the upper bound of the prediction interval of the confidence level of the value the steps after
+// the latest of the evenly spaced values. It returns false when there aren't enough values for the model.
+func forecast(config *kedav1alpha1.PredictiveConfig, values []float64, steps int) (float64, bool) {
+	var value, stdErr float64
+	var ok bool
+	if config.Model == kedav1alpha1.PredictiveModelHoltWinters {
+		value, stdErr, ok = forecastHoltWinters(values, int(config.SeasonMinutes), steps)
+	} else {
+		value, stdErr, ok = forecastLinear(values, steps)
+	}
+	if !ok {
+		return 0, false
+	}
+	return value + zScore(config.Confidence)*stdErr, true
+}
+
+// forecastLinear extrapolates the least squares line of the values, and returns the standard error of the forecast
+func forecastLinear(values []float64, steps int) (float64, float64, bool) {
+	n := float64(len(values))
+	if len(values) < 3 {
+		return 0, 0, false
+	}
+
+	var meanX, meanY float64
+	for i, y := range values {
+		meanX += float64(i)
+		meanY += y
+	}
+	meanX /= n
+	meanY /= n
+
+	var sxx, sxy float64
+	for i, y := range values {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (y - meanY)
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var sse float64
+	for i, y := range values {
+		residual := y - (intercept + slope*float64(i))
+		sse += residual * residual
+	}
+	sigma := math.Sqrt(sse / (n - 2))
+
+	x := n - 1 + float64(steps)
+	stdErr := sigma * math.Sqrt(1+1/n+(x-meanX)*(x-meanX)/sxx)
+	return intercept + slope*x, stdErr, true
+}
+
+// forecastHoltWinters forecasts the values with the additive Holt-Winters model of the season, and returns the
+// standard error of the forecast, approximated from the errors of the one step forecasts of the values
+func forecastHoltWinters(values []float64, season, steps int) (float64, float64, bool) {
+	if season < 2 || len(values) < 2*season {
+		return 0, 0, false
+	}
+
+	// the level and the trend are initialized from the first two seasons, and the seasonal components from the first
+	var firstMean, secondMean float64
+	for i := 0; i < season; i++ {
+		firstMean += values[i]
+		secondMean += values[season+i]
+	}
+	firstMean /= float64(season)
+	secondMean /= float64(season)
+
+	level := firstMean
+	trend := (secondMean - firstMean) / float64(season)
+	seasonal := make([]float64, season)
+	for i := 0; i < season; i++ {
+		seasonal[i] = values[i] - firstMean
+	}
+
+	var sse float64
+	for t := season; t < len(values); t++ {
+		s := seasonal[t%season]
+		residual := values[t] - (level + trend + s)
+		sse += residual * residual
+
+		previousLevel := level
+		level = holtWintersAlpha*(values[t]-s) + (1-holtWintersAlpha)*(level+trend)
+		trend = holtWintersBeta*(level-previousLevel) + (1-holtWintersBeta)*trend
+		seasonal[t%season] = holtWintersGamma*(values[t]-level) + (1-holtWintersGamma)*s
+	}
+	sigma := math.Sqrt(sse / float64(len(values)-season))
+
+	// the errors of the level accumulate over the steps
+	stdErr := sigma * math.Sqrt(1+float64(steps-1)*holtWintersAlpha*holtWintersAlpha)
+	return level + float64(steps)*trend + seasonal[(len(values)-1+steps)%season], stdErr, true
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"time"
+)
+
+// step is the interval between the samples of the histories, the metrics being forecast a number of steps ahead
+const step = time.Minute
+
+// Sample is the value of a metric during a step of its history
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// ringBuffer holds the samples of the latest steps of a metric, the oldest samples being overwritten by the new ones
+type ringBuffer struct {
+	samples []Sample
+	start   int
+	size    int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{samples: make([]Sample, capacity)}
+}
+
+func (b *ringBuffer) capacity() int {
+	return len(b.samples)
+}
+
+func (b *ringBuffer) last() (Sample, bool) {
+	if b.size == 0 {
+		return Sample{}, false
+	}
+	return b.samples[(b.start+b.size-1)%len(b.samples)], true
+}
+
+func (b *ringBuffer) push(sample Sample) {
+	if b.size < len(b.samples) {
+		b.samples[(b.start+b.size)%len(b.samples)] = sample
+		b.size++
+		return
+	}
+	b.samples[b.start] = sample
+	b.start = (b.start + 1) % len(b.samples)
+}
+
+// add records the value as the sample of the step of the time, replacing the previous value of the step. The steps
+// missed since the previous sample get its value, so that the samples stay evenly spaced. It returns the samples of
+// the steps completed by the value.
+func (b *ringBuffer) add(t time.Time, value float64) []Sample {
+	t = t.Truncate(step)
+	last, ok := b.last()
+	switch {
+	case !ok || t.Sub(last.Time) >= time.Duration(len(b.samples))*step:
+		// the previous samples are too old to be part of the history
+		b.start, b.size = 0, 0
+		b.push(Sample{Time: t, Value: value})
+		return nil
+	case t.Before(last.Time):
+		return nil
+	case t.Equal(last.Time):
+		b.samples[(b.start+b.size-1)%len(b.samples)].Value = value
+		return nil
+	}
+
+	completed := []Sample{last}
+	for missed := last.Time.Add(step); missed.Before(t); missed = missed.Add(step) {
+		b.push(Sample{Time: missed, Value: last.Value})
+		completed = append(completed, Sample{Time: missed, Value: last.Value})
+	}
+	b.push(Sample{Time: t, Value: value})
+	return completed
+}
+
+// resize changes the capacity of the buffer, keeping the latest samples
+func (b *ringBuffer) resize(capacity int) {
+	samples := b.list()
+	if len(samples) > capacity {
+		samples = samples[len(samples)-capacity:]
+	}
+	b.samples = make([]Sample, capacity)
+	b.start, b.size = 0, 0
+	for _, sample := range samples {
+		b.push(sample)
+	}
+}
+
+// list returns the samples from the oldest to the latest
+func (b *ringBuffer) list() []Sample {
+	samples := make([]Sample, 0, b.size)
+	for i := 0; i < b.size; i++ {
+		samples = append(samples, b.samples[(b.start+i)%len(b.samples)])
+	}
+	return samples
+}
+
+// values returns the values of the samples from the oldest to the latest
+func (b *ringBuffer) values() []float64 {
+	values := make([]float64, 0, b.size)
+	for i := 0; i < b.size; i++ {
+		values = append(values, b.samples[(b.start+i)%len(b.samples)].Value)
+	}
+	return values
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// Histories record the metrics of the triggers of the ScaledObjects in ring buffers, to forecast them
+type Histories struct {
+	mu      sync.Mutex
+	buffers map[string]*ringBuffer
+	store   Store
+}
+
+// NewHistories returns empty histories, appended to the store as well when it isn't nil
+func NewHistories(store Store) *Histories {
+	return &Histories{buffers: map[string]*ringBuffer{}, store: store}
+}
+
+// Forecast records the values of the metrics of a trigger of the ScaledObject, and returns the metrics with their
+// values replaced by their forecast horizonMinutes ahead when it's higher, and whether any of them was. The target is
+// thus scaled out ahead of the load, and isn't scaled in before the load decreases.
+func (h *Histories) Forecast(ctx context.Context, logger logr.Logger, so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue) ([]external_metrics.ExternalMetricValue, bool) {
+	return h.forecast(ctx, logger, so, metrics, time.Now())
+}
+
+func (h *Histories) forecast(ctx context.Context, logger logr.Logger, so *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, now time.Time) ([]external_metrics.ExternalMetricValue, bool) {
+	if !so.IsPredictive() {
+		return metrics, false
+	}
+	config := so.Spec.Advanced.Predictive
+	capacity := historySteps(config)
+
+	forecasts := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	raised := false
+	for _, metric := range metrics {
+		value := metric.Value.AsApproximateFloat64()
+		key := so.GenerateIdentifier() + "/" + metric.MetricName
+		values := h.record(ctx, logger, key, capacity, now, value)
+
+		predicted, ok := forecast(config, values, int(config.HorizonMinutes))
+		if ok && !math.IsNaN(predicted) && predicted > value && predicted < math.MaxInt64/1000 {
+			logger.V(1).Info("Forecasting metric", "metricName", metric.MetricName, "value", value, "forecast", predicted)
+			metric.Value = *resource.NewMilliQuantity(int64(predicted*1000), resource.DecimalSI)
+			raised = true
+		}
+		forecasts = append(forecasts, metric)
+	}
+	return forecasts, raised
+}
+
+// record adds the value to the history of the key, and returns the values of the history
+func (h *Histories) record(ctx context.Context, logger logr.Logger, key string, capacity int, now time.Time, value float64) []float64 {
+	buffer := h.getBuffer(ctx, logger, key, capacity)
+
+	h.mu.Lock()
+	completed := buffer.add(now, value)
+	values := buffer.values()
+	h.mu.Unlock()
+
+	if h.store != nil && len(completed) > 0 {
+		if err := h.store.Append(ctx, key, completed, capacity); err != nil {
+			logger.Error(err, "error appending to the history of the metric in the store", "key", key)
+		}
+	}
+	return values
+}
+
+// getBuffer returns the ring buffer of the history of the key, loaded from the store if it isn't in memory yet
+func (h *Histories) getBuffer(ctx context.Context, logger logr.Logger, key string, capacity int) *ringBuffer {
+	h.mu.Lock()
+	buffer, ok := h.buffers[key]
+	if ok && buffer.capacity() != capacity {
+		buffer.resize(capacity)
+	}
+	h.mu.Unlock()
+	if ok {
+		return buffer
+	}
+
+	var samples []Sample
+	if h.store != nil {
+		var err error
+		if samples, err = h.store.Load(ctx, key); err != nil {
+			logger.Error(err, "error loading the history of the metric from the store", "key", key)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if buffer, ok := h.buffers[key]; ok {
+		return buffer
+	}
+	buffer = newRingBuffer(capacity)
+	for _, sample := range samples {
+		buffer.add(sample.Time, sample.Value)
+	}
+	h.buffers[key] = buffer
+	return buffer
+}
+
+// Forget removes the histories of the metrics of the ScaledObject from memory, those in the store expiring on their own
+func (h *Histories) Forget(scaledObjectIdentifier string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key := range h.buffers {
+		if strings.HasPrefix(key, scaledObjectIdentifier+"/") {
+			delete(h.buffers, key)
+		}
+	}
+}
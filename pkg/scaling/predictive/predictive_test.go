@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRingBuffer(t *testing.T) {
+	buffer := newRingBuffer(4)
+
+	assert.Empty(t, buffer.add(start, 1))
+	assert.Empty(t, buffer.add(start.Add(30*time.Second), 2), "the value of the step is replaced")
+	assert.Equal(t, []Sample{{Time: start, Value: 2}}, buffer.add(start.Add(time.Minute), 3))
+	assert.Equal(t, []float64{2, 3}, buffer.values())
+
+	// the missed steps get the previous value
+	completed := buffer.add(start.Add(4*time.Minute), 5)
+	assert.Equal(t, []Sample{{Time: start.Add(time.Minute), Value: 3}, {Time: start.Add(2 * time.Minute), Value: 3}, {Time: start.Add(3 * time.Minute), Value: 3}}, completed)
+	assert.Equal(t, []float64{3, 3, 3, 5}, buffer.values(), "the oldest samples are overwritten")
+
+	assert.Empty(t, buffer.add(start, 7), "older samples are ignored")
+	assert.Equal(t, []float64{3, 3, 3, 5}, buffer.values())
+
+	buffer.resize(2)
+	assert.Equal(t, []float64{3, 5}, buffer.values())
+
+	assert.Empty(t, buffer.add(start.Add(time.Hour), 8), "the history is reset after a gap longer than its capacity")
+	assert.Equal(t, []float64{8}, buffer.values())
+}
+
+func TestForecastLinear(t *testing.T) {
+	values := []float64{10, 12, 14, 16, 18}
+
+	value, stdErr, ok := forecastLinear(values, 5)
+	assert.True(t, ok)
+	assert.InDelta(t, 28, value, 0.001)
+	assert.InDelta(t, 0, stdErr, 0.001)
+
+	_, _, ok = forecastLinear(values[:2], 5)
+	assert.False(t, ok)
+
+	noisy := []float64{10, 13, 13, 17, 17}
+	value, stdErr, ok = forecastLinear(noisy, 5)
+	assert.True(t, ok)
+	assert.InDelta(t, 26.6, value, 0.001)
+	assert.Greater(t, stdErr, 0.0)
+
+	config := &kedav1alpha1.PredictiveConfig{HorizonMinutes: 5, Confidence: 95}
+	upper, ok := forecast(config, noisy, 5)
+	assert.True(t, ok)
+	assert.InDelta(t, value+1.645*stdErr, upper, 0.01)
+}
+
+func TestForecastHoltWinters(t *testing.T) {
+	// a daily pattern of 8 steps repeating with a slowly increasing level
+	pattern := []float64{10, 20, 40, 80, 80, 40, 20, 10}
+	var values []float64
+	for i := 0; i < 4*len(pattern); i++ {
+		values = append(values, pattern[i%len(pattern)]+float64(i)*0.1)
+	}
+
+	// the peak of the season is 4 steps after the latest value
+	value, _, ok := forecastHoltWinters(values, len(pattern), 4)
+	assert.True(t, ok)
+	assert.InDelta(t, 80+float64(len(values)+3)*0.1, value, 2)
+
+	_, _, ok = forecastHoltWinters(values[:len(pattern)+1], len(pattern), 3)
+	assert.False(t, ok, "two seasons are required")
+}
+
+func TestZScore(t *testing.T) {
+	assert.Equal(t, 0.0, zScore(0))
+	assert.Equal(t, 0.0, zScore(50))
+	assert.InDelta(t, 1.645, zScore(95), 0.001)
+	assert.InDelta(t, 2.326, zScore(99), 0.001)
+}
+
+type memoryStore struct {
+	samples map[string][]Sample
+}
+
+func (s *memoryStore) Append(_ context.Context, key string, samples []Sample, capacity int) error {
+	s.samples[key] = append(s.samples[key], samples...)
+	if len(s.samples[key]) > capacity {
+		s.samples[key] = s.samples[key][len(s.samples[key])-capacity:]
+	}
+	return nil
+}
+
+func (s *memoryStore) Load(_ context.Context, key string) ([]Sample, error) {
+	return s.samples[key], nil
+}
+
+func newPredictiveScaledObject(config *kedav1alpha1.PredictiveConfig) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{Predictive: config},
+		},
+	}
+}
+
+func metricValues(value float64) []external_metrics.ExternalMetricValue {
+	return []external_metrics.ExternalMetricValue{{
+		MetricName: "s0-queue",
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+	}}
+}
+
+func TestHistoriesForecast(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{samples: map[string][]Sample{}}
+	histories := NewHistories(store)
+	so := newPredictiveScaledObject(&kedav1alpha1.PredictiveConfig{HorizonMinutes: 10})
+
+	// an increasing load is forecast ahead
+	var forecasts []external_metrics.ExternalMetricValue
+	var raised bool
+	for i := 0; i < 10; i++ {
+		forecasts, raised = histories.forecast(ctx, logr.Discard(), so, metricValues(float64(10*i)), start.Add(time.Duration(i)*time.Minute))
+	}
+	assert.True(t, raised)
+	assert.InDelta(t, 190, forecasts[0].Value.AsApproximateFloat64(), 0.01)
+	assert.Len(t, store.samples["scaledobject.default.consumer/s0-queue"], 9, "the completed steps are stored")
+
+	// the history is loaded from the store once forgotten, without the step in progress
+	histories.Forget(so.GenerateIdentifier())
+	assert.Empty(t, histories.buffers)
+	restarted := NewHistories(store)
+	forecasts, _ = restarted.forecast(ctx, logr.Discard(), so, metricValues(100), start.Add(10*time.Minute))
+	assert.Greater(t, forecasts[0].Value.AsApproximateFloat64(), 100.0)
+	assert.Equal(t, []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 80, 100}, restarted.buffers["scaledobject.default.consumer/s0-queue"].values())
+}
+
+func TestHistoriesForecastDecreasingLoad(t *testing.T) {
+	histories := NewHistories(nil)
+	so := newPredictiveScaledObject(&kedav1alpha1.PredictiveConfig{HorizonMinutes: 10, Confidence: 90})
+
+	// a decreasing load isn't forecast, so that the target isn't scaled in ahead
+	var forecasts []external_metrics.ExternalMetricValue
+	var raised bool
+	for i := 0; i < 10; i++ {
+		forecasts, raised = histories.forecast(context.Background(), logr.Discard(), so, metricValues(float64(100-10*i)), start.Add(time.Duration(i)*time.Minute))
+	}
+	assert.False(t, raised)
+	assert.InDelta(t, 10, forecasts[0].Value.AsApproximateFloat64(), 0.01)
+}
+
+func TestHistoriesForecastWithoutPredictive(t *testing.T) {
+	histories := NewHistories(nil)
+	so := newPredictiveScaledObject(nil)
+
+	metrics := metricValues(5)
+	forecasts, raised := histories.forecast(context.Background(), logr.Discard(), so, metrics, start)
+	assert.Equal(t, metrics, forecasts)
+	assert.False(t, raised)
+	assert.Empty(t, histories.buffers)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predictive
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store keeps the histories of the metrics outside of keda-operator, so that they survive its restarts
+type Store interface {
+	// Append adds the samples to the history of the key, keeping its latest samples up to the capacity
+	Append(ctx context.Context, key string, samples []Sample, capacity int) error
+	// Load returns the samples of the history of the key, from the oldest to the latest
+	Load(ctx context.Context, key string) ([]Sample, error)
+}
+
+const redisKeyPrefix = "keda:predictive:"
+
+type redisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore returns a store of the histories kept in Redis as lists of JSON samples, expiring when they're not
+// appended to for the duration of their capacity
+func NewRedisStore(client redis.UniversalClient) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Append(ctx context.Context, key string, samples []Sample, capacity int) error {
+	values := make([]interface{}, 0, len(samples))
+	for _, sample := range samples {
+		value, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+
+	key = redisKeyPrefix + key
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, values...)
+		pipe.LTrim(ctx, key, int64(-capacity), -1)
+		pipe.Expire(ctx, key, time.Duration(capacity)*step)
+		return nil
+	})
+	return err
+}
+
+func (s *redisStore) Load(ctx context.Context, key string) ([]Sample, error) {
+	values, err := s.client.LRange(ctx, redisKeyPrefix+key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]Sample, 0, len(values))
+	for _, value := range values {
+		var sample Sample
+		if err := json.Unmarshal([]byte(value), &sample); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
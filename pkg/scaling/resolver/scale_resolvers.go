@@ -223,6 +223,29 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 	return resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace, secretsLister)
 }
 
+// ResolveWebhookSigningSecret provides the secret used to sign webhook notifications, referenced by webhookSigningSecret in TriggerAuthentication
+func ResolveWebhookSigningSecret(ctx context.Context, client client.Client, logger logr.Logger,
+	triggerAuthRef *kedav1alpha1.AuthenticationRef, namespace string, secretsLister corev1listers.SecretLister) ([]byte, error) {
+	if triggerAuthRef == nil || triggerAuthRef.Name == "" {
+		return nil, fmt.Errorf("authenticationRef is required to resolve webhook signing secret")
+	}
+
+	triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+	if err != nil {
+		return nil, err
+	}
+	secretRef := triggerAuthSpec.WebhookSigningSecret
+	if secretRef == nil {
+		return nil, fmt.Errorf("webhookSigningSecret isn't defined in triggerAuth %s", triggerAuthRef.Name)
+	}
+
+	secret := resolveAuthSecret(ctx, client, logger, secretRef.Name, triggerNamespace, secretRef.Key, secretsLister)
+	if secret == "" {
+		return nil, fmt.Errorf("key %s of secret %s referenced by webhookSigningSecret is empty or doesn't exist", secretRef.Key, secretRef.Name)
+	}
+	return []byte(secret), nil
+}
+
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
 // based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
 func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger,
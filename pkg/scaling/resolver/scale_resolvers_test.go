@@ -642,6 +642,102 @@ func TestResolveAuthRef(t *testing.T) {
 	}
 }
 
+func TestResolveWebhookSigningSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      secretName,
+		},
+		Data: map[string][]byte{secretKey: []byte(secretData)},
+	}
+	tests := []struct {
+		name     string
+		existing []runtime.Object
+		soar     *kedav1alpha1.AuthenticationRef
+		expected []byte
+		isError  bool
+	}{
+		{
+			name:    "no authenticationRef",
+			isError: true,
+		},
+		{
+			name:    "no triggerauth exists",
+			soar:    &kedav1alpha1.AuthenticationRef{Name: "notthere"},
+			isError: true,
+		},
+		{
+			name: "triggerauth without webhookSigningSecret",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+				},
+			},
+			soar:    &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName},
+			isError: true,
+		},
+		{
+			name: "webhookSigningSecret references missing key",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						WebhookSigningSecret: &kedav1alpha1.AuthWebhookSigningSecret{Name: secretName, Key: "notthere"},
+					},
+				},
+				secret,
+			},
+			soar:    &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName},
+			isError: true,
+		},
+		{
+			name: "webhookSigningSecret is resolved",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						WebhookSigningSecret: &kedav1alpha1.AuthWebhookSigningSecret{Name: secretName, Key: secretKey},
+					},
+				},
+				secret,
+			},
+			soar:     &kedav1alpha1.AuthenticationRef{Name: triggerAuthenticationName},
+			expected: []byte(secretData),
+		},
+	}
+	var secretsLister corev1listers.SecretLister
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ResolveWebhookSigningSecret(
+				context.Background(),
+				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
+				logf.Log.WithName("test"),
+				test.soar,
+				namespace,
+				secretsLister)
+
+			if err != nil && !test.isError {
+				t.Errorf("Expected success but got error, %s", err)
+			}
+			if test.isError && err == nil {
+				t.Errorf("Expected error but got success, %#v", test)
+			}
+			if diff := cmp.Diff(got, test.expected); diff != "" {
+				t.Errorf("Returned secret is different: %s", diff)
+			}
+		})
+	}
+}
+
 func TestResolveDependentEnv(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -19,6 +19,7 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -27,6 +28,8 @@ import (
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -47,8 +50,10 @@ import (
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+	"github.com/kedacore/keda/v2/pkg/scaling/predictive"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
 var log = logf.Log.WithName("scale_handler")
@@ -62,6 +67,7 @@ type ScaleHandler interface {
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
+	Shutdown(ctx context.Context) error
 }
 
 type scaleHandler struct {
@@ -74,10 +80,12 @@ type scaleHandler struct {
 	scalerCachesLock         *sync.RWMutex
 	scaledObjectsMetricCache metricscache.MetricsCache
 	secretsLister            corev1listers.SecretLister
+	predictiveHistories      *predictive.Histories
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, the metrics of the predictive ScaledObjects being forecast with the
+// predictive histories unless they're nil
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister, predictiveHistories *predictive.Histories) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
 		scaleLoopContexts:        &sync.Map{},
@@ -88,6 +96,7 @@ func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, recon
 		scalerCachesLock:         &sync.RWMutex{},
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 		secretsLister:            secretsLister,
+		predictiveHistories:      predictiveHistories,
 	}
 }
 
@@ -149,6 +158,12 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		if h.predictiveHistories != nil {
+			h.predictiveHistories.Forget(key)
+		}
+		if _, isScaledObject := scalableObject.(*kedav1alpha1.ScaledObject); isScaledObject {
+			h.scaleExecutor.ForgetScaledObject(ctx, key)
+		}
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -246,7 +261,17 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			return
 		}
 
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers})
+		options := &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers, MetricValue: getMaxMetricValue(metricsRecords)}
+		if obj.Spec.TargetCluster != "" {
+			// there is no HPA scaling ScaleTargets in remote clusters, the replicas are computed from the metrics instead
+			cache, err := h.GetScalersCache(ctx, obj)
+			if err != nil {
+				log.Error(err, "error getting scalers cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
+			} else {
+				options.DesiredReplicas = getDesiredReplicasFromMetrics(cache.GetMetricSpecForScaling(ctx), metricsRecords)
+			}
+		}
+		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, options)
 
 		if len(metricsRecords) > 0 {
 			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
@@ -372,6 +397,17 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 			}
 			newCache.CompiledFormula = program
 		}
+		if obj.IsUsingTriggerExpressions() {
+			programs, err := kedav1alpha1.CompileTriggerExpressions(obj.Spec.Triggers)
+			if err != nil {
+				log.Error(err, "error compiling trigger expressions")
+				return nil, err
+			}
+			newCache.CompiledTriggerExpressions = programs
+		}
+		if obj.IsPredictive() {
+			newCache.PredictiveHistories = h.predictiveHistories
+		}
 		newCache.ScaledObject = obj
 	default:
 	}
@@ -391,6 +427,11 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 	return h.scalerCaches[key], nil
 }
 
+// Shutdown releases the resources of the scale handler, when the operator stops
+func (h *scaleHandler) Shutdown(ctx context.Context) error {
+	return h.scaleExecutor.Shutdown(ctx)
+}
+
 // ClearScalersCache invalidates chache for the input scalableObject
 func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject interface{}) error {
 	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
@@ -583,13 +624,36 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		return nil, fmt.Errorf("no matching metrics found for %s", metricsName)
 	}
 
+	// trigger expressions need all the external metrics, only the requested one is returned if there isn't any formula
+	if scaledObject.IsUsingTriggerExpressions() {
+		matchingMetrics = modifiers.HandleTriggerExpressions(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, cache, logger)
+		if !scaledObject.IsUsingModifiers() {
+			matchingMetrics = modifiers.FilterMetricsByName(matchingMetrics, metricsName)
+		}
+	}
+
 	// handle scalingModifiers here and simply return the matchingMetrics
 	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, cache, logger)
+
+	// small replica changes are skipped by reporting the metric values keeping the current replicas to the HPA
+	if scaledObject.Spec.MinScalingStep != nil && !isFallbackActive {
+		matchingMetrics = h.handleMinScalingStep(ctx, logger, scaledObject, matchingMetrics)
+	}
 	return &external_metrics.ExternalMetricValueList{
 		Items: matchingMetrics,
 	}, nil
 }
 
+// handleMinScalingStep applies minScalingStep of the ScaledObject to the metrics, using the targets and the current replicas of its HPA
+func (h *scaleHandler) handleMinScalingStep(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue) []external_metrics.ExternalMetricValue {
+	hpa := &v2.HorizontalPodAutoscaler{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: scaledObject.Status.HpaName, Namespace: scaledObject.Namespace}, hpa); err != nil {
+		logger.Error(err, "error getting HPA, minScalingStep isn't applied")
+		return metrics
+	}
+	return modifiers.HandleMinScalingStep(scaledObject, metrics, hpa.Spec.Metrics, hpa.Status.CurrentReplicas, logger)
+}
+
 // getScaledObjectState returns whether the input ScaledObject:
 // is active as the first return value,
 // the second return value indicates whether there was any error during querying scalers,
@@ -636,7 +700,13 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	}
 	wg.Wait()
 	close(results)
+	var canaryResults []scalerState
 	for result := range results {
+		// canary triggers are only recorded in the status, they don't contribute to the scaling decision
+		if result.IsCanary {
+			canaryResults = append(canaryResults, result)
+			continue
+		}
 		if result.IsActive {
 			isScaledObjectActive = true
 			activeTriggers = append(activeTriggers, result.TriggerName)
@@ -665,7 +735,11 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		logger.V(1).Info("scaler error encountered, clearing scaler cache")
 	}
 
-	// apply scaling modifiers
+	h.updateCanaryTriggersStatus(ctx, logger, scaledObject, canaryResults)
+	h.updatePushScalersStatus(ctx, logger, scaledObject, allScalers, scalerConfigs)
+
+	// apply trigger expressions and scaling modifiers
+	matchingMetrics = modifiers.HandleTriggerExpressions(scaledObject, matchingMetrics, metricTriggerPairList, false, cache, logger)
 	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, nil, cache, logger)
 
 	// when we are using formula, we need to reevaluate if it's active here
@@ -712,12 +786,14 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 // info for calculating the ScaledObjectState
 type scalerState struct {
 	// IsActive will be overrided by formula calculation
-	IsActive    bool
-	TriggerName string
-	Metrics     []external_metrics.ExternalMetricValue
-	Pairs       map[string]string
-	Records     map[string]metricscache.MetricsRecord
-	Err         error
+	IsActive     bool
+	IsCanary     bool
+	TriggerName  string
+	TriggerIndex int
+	Metrics      []external_metrics.ExternalMetricValue
+	Pairs        map[string]string
+	Records      map[string]metricscache.MetricsRecord
+	Err          error
 }
 
 // getScalerState returns getStateScalerResult with the state
@@ -739,6 +815,8 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 	if scalerConfig.TriggerName != "" {
 		result.TriggerName = scalerConfig.TriggerName
 	}
+	result.IsCanary = scalerConfig.TriggerCanary
+	result.TriggerIndex = triggerIndex
 
 	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
 	if err != nil {
@@ -807,6 +885,94 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 	return result
 }
 
+// updateCanaryTriggersStatus records the last metrics of the canary triggers in the ScaledObject status,
+// the entries of triggers which aren't canaries anymore are removed
+func (h *scaleHandler) updateCanaryTriggersStatus(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, canaryResults []scalerState) {
+	if len(canaryResults) == 0 && len(scaledObject.Status.CanaryTriggers) == 0 {
+		return
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	canaryTriggers := make(map[string]kedav1alpha1.CanaryTriggerStatus, len(canaryResults))
+	for _, result := range canaryResults {
+		canaryStatus := kedav1alpha1.CanaryTriggerStatus{
+			IsActive:  result.IsActive,
+			StartTime: now,
+		}
+		previous, found := scaledObject.Status.CanaryTriggers[result.TriggerName]
+		if found {
+			canaryStatus.StartTime = previous.StartTime
+		}
+		if len(result.Metrics) > 0 {
+			canaryStatus.MetricValue = result.Metrics[0].Value.String()
+		}
+		if result.Err != nil {
+			canaryStatus.Error = result.Err.Error()
+		}
+		if result.TriggerIndex < len(scaledObject.Spec.Triggers) {
+			canaryDuration := scaledObject.Spec.Triggers[result.TriggerIndex].GetCanaryDuration()
+			canaryStatus.ValidationCompleted = now.Sub(canaryStatus.StartTime.Time) >= canaryDuration
+		}
+		// LastUpdateTime is only refreshed when the status of the trigger changes, not on every poll
+		canaryStatus.LastUpdateTime = previous.LastUpdateTime
+		if !found || !equality.Semantic.DeepEqual(canaryStatus, previous) {
+			canaryStatus.LastUpdateTime = now
+		}
+		canaryTriggers[result.TriggerName] = canaryStatus
+	}
+
+	if len(canaryTriggers) == 0 {
+		canaryTriggers = nil
+	}
+	if equality.Semantic.DeepEqual(canaryTriggers, scaledObject.Status.CanaryTriggers) {
+		return
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.CanaryTriggers = canaryTriggers
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating status of canary triggers")
+	}
+}
+
+// updatePushScalersStatus records the state of the connections of the push scalers reporting it in the ScaledObject
+// status, so that the activity of disconnected push triggers isn't silently stale
+func (h *scaleHandler) updatePushScalersStatus(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, allScalers []scalers.Scaler, scalerConfigs []scalersconfig.ScalerConfig) {
+	pushScalers := map[string]kedav1alpha1.PushScalerStatus{}
+	for i, scaler := range allScalers {
+		reporter, ok := scaler.(scalers.ConnectionStateReporter)
+		if !ok {
+			continue
+		}
+		state := reporter.ConnectionState()
+		pushStatus := kedav1alpha1.PushScalerStatus{Connected: state.Connected}
+		if !state.LastMessageTime.IsZero() {
+			lastMessageTime := metav1.NewTime(state.LastMessageTime.Truncate(time.Second))
+			pushStatus.LastMessageTime = &lastMessageTime
+		}
+		if state.Err != nil {
+			pushStatus.Error = state.Err.Error()
+		}
+
+		triggerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+		if scalerConfigs[i].TriggerName != "" {
+			triggerName = scalerConfigs[i].TriggerName
+		}
+		pushScalers[triggerName] = pushStatus
+	}
+
+	if len(pushScalers) == 0 {
+		pushScalers = nil
+	}
+	if equality.Semantic.DeepEqual(pushScalers, scaledObject.Status.PushScalers) {
+		return
+	}
+	status := scaledObject.Status.DeepCopy()
+	status.PushScalers = pushScalers
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating status of push scalers")
+	}
+}
+
 // / --------------------------------------------------------------------------- ///
 // / ----------             ScaledJob related methods               --------- ///
 // / --------------------------------------------------------------------------- ///
@@ -826,6 +992,10 @@ func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 	var scalersMetrics []scaledjob.ScalerMetrics
 	scalers, scalerConfigs := cache.GetScalers()
 	for scalerIndex, scaler := range scalers {
+		// canary triggers aren't supported by ScaledJobs, they must not drive the scaling of the jobs
+		if scalerConfigs[scalerIndex].TriggerCanary {
+			continue
+		}
 		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
 		if scalerConfigs[scalerIndex].TriggerName != "" {
 			scalerName = scalerConfigs[scalerIndex].TriggerName
@@ -913,7 +1083,7 @@ func (h *scaleHandler) getTrueMetricArray(ctx context.Context, metricName string
 
 	// bug fix for the invalid cache (not loaded properly) and needs to be fetched again
 	// Tracking issue: https://github.com/kedacore/keda/issues/4955
-	if so != nil && so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Target != "" {
+	if so != nil && ((so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers.Target != "") || so.IsUsingTriggerExpressions()) {
 		if len(so.Status.ExternalMetricNames) == 0 {
 			scaledObject := &kedav1alpha1.ScaledObject{}
 			err := h.client.Get(ctx, types.NamespacedName{Name: so.Name, Namespace: so.Namespace}, scaledObject)
@@ -933,3 +1103,43 @@ func (h *scaleHandler) getTrueMetricArray(ctx context.Context, metricName string
 	}
 	return []string{metricName}, nil
 }
+
+// getMaxMetricValue returns the highest metric value of the metrics records,
+// it's used to describe the scaling decision
+func getMaxMetricValue(metricsRecords map[string]metricscache.MetricsRecord) float64 {
+	maxValue := float64(0)
+	for _, record := range metricsRecords {
+		for _, metric := range record.Metric {
+			if value := metric.Value.AsApproximateFloat64(); value > maxValue {
+				maxValue = value
+			}
+		}
+	}
+	return maxValue
+}
+
+// getDesiredReplicasFromMetrics computes the replica count for the metrics the way the HPA does for AverageValue targets,
+// the highest replica count across the metrics is returned. Nil is returned if there isn't any AverageValue metric.
+func getDesiredReplicasFromMetrics(metricSpecs []v2.MetricSpec, metricsRecords map[string]metricscache.MetricsRecord) *int32 {
+	var desiredReplicas *int32
+	for _, metricSpec := range metricSpecs {
+		if metricSpec.External == nil || metricSpec.External.Target.Type != v2.AverageValueMetricType || metricSpec.External.Target.AverageValue == nil {
+			continue
+		}
+		target := metricSpec.External.Target.AverageValue.AsApproximateFloat64()
+		record, ok := metricsRecords[metricSpec.External.Metric.Name]
+		if !ok || target <= 0 {
+			continue
+		}
+
+		value := float64(0)
+		for _, metric := range record.Metric {
+			value += metric.Value.AsApproximateFloat64()
+		}
+		replicas := int32(math.Ceil(value / target))
+		if desiredReplicas == nil || replicas > *desiredReplicas {
+			desiredReplicas = &replicas
+		}
+	}
+	return desiredReplicas
+}
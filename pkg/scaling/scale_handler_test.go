@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/expr-lang/expr"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
@@ -1031,3 +1032,225 @@ func expectNoStatusPatch(ctrl *gomock.Controller) {
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 }
+
+func TestGetDesiredReplicasFromMetrics(t *testing.T) {
+	averageValueSpec := func(averageValue int64, metricName string) v2.MetricSpec {
+		metricSpec := createMetricSpec(averageValue, metricName)
+		metricSpec.External.Target.Type = v2.AverageValueMetricType
+		return metricSpec
+	}
+	valueSpec := createMetricSpec(10, "s1-value")
+	valueSpec.External.Target.Type = v2.ValueMetricType
+	records := map[string]metricscache.MetricsRecord{
+		"s0-queue":  {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-queue", 25)}},
+		"s1-lag":    {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s1-lag", 40), scalers.GenerateMetricInMili("s1-lag", 20)}},
+		"s1-value":  {Metric: []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s1-value", 1000)}},
+		"s2-absent": {},
+	}
+
+	// ceil(25/10) = 3 and ceil((40+20)/10) = 6, the highest is used
+	desired := getDesiredReplicasFromMetrics([]v2.MetricSpec{averageValueSpec(10, "s0-queue"), averageValueSpec(10, "s1-lag")}, records)
+	assert.NotNil(t, desired)
+	assert.Equal(t, int32(6), *desired)
+
+	desired = getDesiredReplicasFromMetrics([]v2.MetricSpec{averageValueSpec(10, "s2-absent")}, records)
+	assert.NotNil(t, desired)
+	assert.Equal(t, int32(0), *desired)
+
+	// Value targets and metrics without records are ignored
+	assert.Nil(t, getDesiredReplicasFromMetrics([]v2.MetricSpec{valueSpec, averageValueSpec(10, "s3-unknown")}, records))
+}
+
+func TestCanaryTriggerIsRecordedButDoesNotScale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	recorder := record.NewFakeRecorder(1)
+
+	newScaler := func(metricName string, value float64, isActive bool) *mock_scalers.MockScaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName)}).AnyTimes()
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, value)}, isActive, nil)
+		return scaler
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNameGlobal,
+			Namespace: testNamespaceGlobal,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "current", Type: "kafka"},
+				{Name: "candidate", Type: "kafka", Canary: true},
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       newScaler("s0-lag", 2, false),
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "current", TriggerIndex: 0},
+		}, {
+			Scaler:       newScaler("s1-lag", 100, true),
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "candidate", TriggerIndex: 1, TriggerCanary: true},
+		}},
+		Recorder: recorder,
+	}
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	// the active canary trigger must not activate the ScaledObject
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), false, false, gomock.Any())
+
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+
+	assert.Len(t, scaledObject.Status.CanaryTriggers, 1)
+	canaryStatus := scaledObject.Status.CanaryTriggers["candidate"]
+	assert.Equal(t, "100", canaryStatus.MetricValue)
+	assert.True(t, canaryStatus.IsActive)
+	assert.False(t, canaryStatus.ValidationCompleted)
+
+	// the canary metric isn't exposed to the HPA
+	metricSpecs := scalerCache.GetMetricSpecForScaling(context.TODO())
+	assert.Len(t, metricSpecs, 1)
+	assert.Equal(t, "s0-lag", metricSpecs[0].External.Metric.Name)
+}
+
+func TestCanaryTriggersStatusIsOnlyUpdatedOnChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	sh := scaleHandler{client: mockClient}
+
+	recorded := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: testNameGlobal, Namespace: testNamespaceGlobal},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Name: "candidate", Type: "kafka", Canary: true}},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			CanaryTriggers: map[string]kedav1alpha1.CanaryTriggerStatus{
+				"candidate": {MetricValue: "100", IsActive: true, StartTime: recorded, LastUpdateTime: recorded},
+			},
+		},
+	}
+	newResult := func(value float64) []scalerState {
+		return []scalerState{{
+			IsActive:     true,
+			IsCanary:     true,
+			TriggerName:  "candidate",
+			TriggerIndex: 0,
+			Metrics:      []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-lag", value)},
+		}}
+	}
+
+	// the status isn't patched when the canary trigger reports the same value
+	sh.updateCanaryTriggersStatus(context.TODO(), logr.Discard(), scaledObject, newResult(100))
+	assert.Equal(t, recorded, scaledObject.Status.CanaryTriggers["candidate"].LastUpdateTime)
+
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sh.updateCanaryTriggersStatus(context.TODO(), logr.Discard(), scaledObject, newResult(150))
+
+	canaryStatus := scaledObject.Status.CanaryTriggers["candidate"]
+	assert.Equal(t, "150", canaryStatus.MetricValue)
+	assert.Equal(t, recorded, canaryStatus.StartTime)
+	assert.True(t, canaryStatus.LastUpdateTime.After(recorded.Time))
+}
+
+type connectionStateScaler struct {
+	*mock_scalers.MockScaler
+	state scalers.PushScalerConnectionState
+}
+
+func (s *connectionStateScaler) ConnectionState() scalers.PushScalerConnectionState {
+	return s.state
+}
+
+func TestPushScalersConnectionStateIsRecorded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
+	recorder := record.NewFakeRecorder(1)
+
+	metricName := "s0-queue"
+	mockScaler := mock_scalers.NewMockScaler(ctrl)
+	mockScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName)}).AnyTimes()
+	mockScaler.EXPECT().GetMetricsAndActivity(gomock.Any(), metricName).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, 2)}, true, nil).AnyTimes()
+	pushScaler := &connectionStateScaler{
+		MockScaler: mockScaler,
+		state:      scalers.PushScalerConnectionState{Connected: false, Err: errors.New("connection refused")},
+	}
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNameGlobal,
+			Namespace: testNamespaceGlobal,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Name: "queue", Type: "external-push"},
+			},
+		},
+	}
+
+	scalerCache := cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       pushScaler,
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerName: "queue", TriggerIndex: 0},
+		}},
+		Recorder: recorder,
+	}
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		scaleExecutor:            mockExecutor,
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+	}
+
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockExecutor.EXPECT().RequestScale(gomock.Any(), gomock.Any(), true, false, gomock.Any()).Times(2)
+
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+
+	assert.Len(t, scaledObject.Status.PushScalers, 1)
+	pushStatus := scaledObject.Status.PushScalers["queue"]
+	assert.False(t, pushStatus.Connected)
+	assert.Nil(t, pushStatus.LastMessageTime)
+	assert.Equal(t, "connection refused", pushStatus.Error)
+
+	// the status isn't updated while the state of the connection doesn't change
+	sh.checkScalers(context.TODO(), &scaledObject, &sync.RWMutex{})
+}
@@ -54,22 +54,26 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				}
 			}
 			config := &scalersconfig.ScalerConfig{
-				ScalableObjectName:      withTriggers.Name,
-				ScalableObjectNamespace: withTriggers.Namespace,
-				ScalableObjectType:      withTriggers.Kind,
-				TriggerName:             trigger.Name,
-				TriggerMetadata:         trigger.Metadata,
-				TriggerType:             trigger.Type,
-				TriggerUseCachedMetrics: trigger.UseCachedMetrics,
-				ResolvedEnv:             resolvedEnv,
-				AuthParams:              make(map[string]string),
-				GlobalHTTPTimeout:       h.globalHTTPTimeout,
-				TriggerIndex:            triggerIndex,
-				MetricType:              trigger.MetricType,
-				AsMetricSource:          asMetricSource,
-				ScaledObject:            withTriggers,
-				Recorder:                h.recorder,
-				TriggerUniqueKey:        fmt.Sprintf("%s-%s-%s-%d", withTriggers.Kind, withTriggers.Namespace, withTriggers.Name, triggerIndex),
+				ScalableObjectName:        withTriggers.Name,
+				ScalableObjectNamespace:   withTriggers.Namespace,
+				ScalableObjectType:        withTriggers.Kind,
+				TriggerName:               trigger.Name,
+				TriggerMetadata:           trigger.Metadata,
+				TriggerType:               trigger.Type,
+				TriggerUseCachedMetrics:   trigger.UseCachedMetrics,
+				TriggerCanary:             trigger.Canary,
+				TriggerRateOfChange:       trigger.RateOfChange,
+				TriggerRateOfChangeWindow: trigger.GetRateOfChangeWindow(),
+				PollingInterval:           withTriggers.GetPollingInterval(),
+				ResolvedEnv:               resolvedEnv,
+				AuthParams:                make(map[string]string),
+				GlobalHTTPTimeout:         h.globalHTTPTimeout,
+				TriggerIndex:              triggerIndex,
+				MetricType:                trigger.MetricType,
+				AsMetricSource:            asMetricSource,
+				ScaledObject:              withTriggers,
+				Recorder:                  h.recorder,
+				TriggerUniqueKey:          fmt.Sprintf("%s-%s-%s-%d", withTriggers.Kind, withTriggers.Namespace, withTriggers.Name, triggerIndex),
 			}
 
 			authParams, podIdentity, err := resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace, h.secretsLister)
@@ -121,10 +125,18 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 	switch triggerType {
 	case "activemq":
 		return scalers.NewActiveMQScaler(config)
+	case "airflow":
+		return scalers.NewAirflowScaler(config)
+	case "alibaba-mns":
+		return scalers.NewAlibabaMNSScaler(config)
+	case "amqp":
+		return scalers.NewAMQPScaler(config)
 	case "apache-kafka":
 		return scalers.NewApacheKafkaScaler(ctx, config)
 	case "arangodb":
 		return scalers.NewArangoDBScaler(config)
+	case "argo-workflows":
+		return scalers.NewArgoWorkflowsScaler(client, config)
 	case "artemis-queue":
 		return scalers.NewArtemisQueueScaler(config)
 	case "aws-cloudwatch":
@@ -135,6 +147,10 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAwsDynamoDBStreamsScaler(ctx, config)
 	case "aws-kinesis-stream":
 		return scalers.NewAwsKinesisStreamScaler(ctx, config)
+	case "aws-redshift":
+		return scalers.NewAwsRedshiftScaler(ctx, config)
+	case "aws-s3":
+		return scalers.NewAwsS3Scaler(ctx, config)
 	case "aws-sqs-queue":
 		return scalers.NewAwsSqsQueueScaler(ctx, config)
 	case "azure-app-insights":
@@ -157,8 +173,28 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAzureServiceBusScaler(ctx, config)
 	case "beanstalkd":
 		return scalers.NewBeanstalkdScaler(config)
+	case "buildkite":
+		return scalers.NewBuildkiteScaler(config)
+	case "bullmq":
+		return scalers.NewBullMQScaler(ctx, false, false, config)
+	case "bullmq-cluster":
+		return scalers.NewBullMQScaler(ctx, true, false, config)
+	case "bullmq-sentinel":
+		return scalers.NewBullMQScaler(ctx, false, true, config)
 	case "cassandra":
 		return scalers.NewCassandraScaler(config)
+	case "celery":
+		return scalers.NewCeleryScaler(ctx, config)
+	case "clickhouse":
+		return scalers.NewClickHouseScaler(config)
+	case "cloudevents":
+		return scalers.NewCloudEventsScaler(config)
+	case "cockroachdb":
+		return scalers.NewCockroachDBScaler(ctx, config)
+	case "consul":
+		return scalers.NewConsulScaler(config)
+	case "couchbase":
+		return scalers.NewCouchbaseScaler(config)
 	case "couchdb":
 		return scalers.NewCouchDBScaler(ctx, config)
 	case "cpu":
@@ -167,10 +203,14 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewCronScaler(config)
 	case "datadog":
 		return scalers.NewDatadogScaler(ctx, config)
+	case "druid":
+		return scalers.NewDruidScaler(config)
 	case "dynatrace":
 		return scalers.NewDynatraceScaler(config)
 	case "elasticsearch":
 		return scalers.NewElasticsearchScaler(config)
+	case "envoy":
+		return scalers.NewEnvoyScaler(config)
 	case "etcd":
 		return scalers.NewEtcdScaler(config)
 	case "external":
@@ -180,10 +220,24 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewExternalMockScaler(config)
 	case "external-push":
 		return scalers.NewExternalPushScaler(config)
+	case "faktory":
+		return scalers.NewFaktoryScaler(config)
+	case "file-transfer":
+		return scalers.NewFileTransferScaler(config)
+	case "flink":
+		return scalers.NewFlinkScaler(config)
+	case "gateway-api":
+		return scalers.NewGatewayAPIScaler(client, config)
+	case "gcp-bigquery":
+		return scalers.NewGcpBigQueryScaler(config)
 	case "gcp-cloudtasks":
 		return scalers.NewGcpCloudTasksScaler(config)
 	case "gcp-pubsub":
 		return scalers.NewPubSubScaler(config)
+	case "gcp-pubsub-lite":
+		return scalers.NewPubSubLiteScaler(config)
+	case "gcp-spanner":
+		return scalers.NewSpannerScaler(config)
 	case "gcp-stackdriver":
 		return scalers.NewStackdriverScaler(ctx, config)
 	case "gcp-storage":
@@ -192,26 +246,42 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewGitHubRunnerScaler(config)
 	case "graphite":
 		return scalers.NewGraphiteScaler(config)
+	case "haproxy":
+		return scalers.NewHAProxyScaler(config)
+	case "hazelcast":
+		return scalers.NewHazelcastScaler(config)
 	case "huawei-cloudeye":
 		return scalers.NewHuaweiCloudeyeScaler(config)
 	case "ibmmq":
 		return scalers.NewIBMMQScaler(config)
+	case "imap":
+		return scalers.NewIMAPScaler(config)
 	case "influxdb":
 		return scalers.NewInfluxDBScaler(config)
+	case "istio":
+		return scalers.NewIstioScaler(config)
+	case "jenkins":
+		return scalers.NewJenkinsScaler(config)
 	case "kafka":
 		return scalers.NewKafkaScaler(ctx, config)
+	case "kubernetes-events":
+		return scalers.NewKubernetesEventsScaler(client, config)
 	case "kubernetes-workload":
 		return scalers.NewKubernetesWorkloadScaler(client, config)
 	case "liiklus":
 		return scalers.NewLiiklusScaler(config)
 	case "loki":
 		return scalers.NewLokiScaler(config)
+	case "memcached":
+		return scalers.NewMemcachedScaler(config)
 	case "memory":
 		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
 	case "metrics-api":
 		return scalers.NewMetricsAPIScaler(config)
 	case "mongodb":
 		return scalers.NewMongoDBScaler(ctx, config)
+	case "mqtt":
+		return scalers.NewMQTTScaler(config)
 	case "mssql":
 		return scalers.NewMSSQLScaler(config)
 	case "mysql":
@@ -220,18 +290,34 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewNATSJetStreamScaler(config)
 	case "new-relic":
 		return scalers.NewNewRelicScaler(config)
+	case "nginx":
+		return scalers.NewNginxScaler(config)
 	case "nsq":
 		return scalers.NewNSQScaler(config)
+	case "nvidia-dcgm":
+		return scalers.NewNvidiaDCGMScaler(client, config)
+	case "oci-queue":
+		return scalers.NewOCIQueueScaler(config)
+	case "oci-streaming":
+		return scalers.NewOCIStreamingScaler(ctx, config)
+	case "opensearch":
+		return scalers.NewOpenSearchScaler(config)
 	case "openstack-metric":
 		return scalers.NewOpenstackMetricScaler(ctx, config)
 	case "openstack-swift":
 		return scalers.NewOpenstackSwiftScaler(config)
+	case "oracle":
+		return scalers.NewOracleScaler(config)
+	case "pinot":
+		return scalers.NewPinotScaler(config)
 	case "postgresql":
 		return scalers.NewPostgreSQLScaler(ctx, config)
 	case "predictkube":
 		return scalers.NewPredictKubeScaler(ctx, config)
 	case "prometheus":
 		return scalers.NewPrometheusScaler(config)
+	case "prometheus-remote-write":
+		return scalers.NewPrometheusRemoteWriteScaler(config)
 	case "pulsar":
 		return scalers.NewPulsarScaler(config)
 	case "rabbitmq":
@@ -240,24 +326,52 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisScaler(ctx, false, false, config)
 	case "redis-cluster":
 		return scalers.NewRedisScaler(ctx, true, false, config)
+	case "redis-cluster-info":
+		return scalers.NewRedisInfoScaler(ctx, true, false, config)
 	case "redis-cluster-streams":
 		return scalers.NewRedisStreamsScaler(ctx, true, false, config)
+	case "redis-info":
+		return scalers.NewRedisInfoScaler(ctx, false, false, config)
 	case "redis-sentinel":
 		return scalers.NewRedisScaler(ctx, false, true, config)
+	case "redis-sentinel-info":
+		return scalers.NewRedisInfoScaler(ctx, false, true, config)
 	case "redis-sentinel-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
 	case "redis-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
+	case "rocketmq":
+		return scalers.NewRocketMQScaler(config)
+	case "sap-hana":
+		return scalers.NewSapHanaScaler(config)
 	case "selenium-grid":
 		return scalers.NewSeleniumGridScaler(config)
+	case "sidekiq":
+		return scalers.NewSidekiqScaler(ctx, false, config)
+	case "sidekiq-sentinel":
+		return scalers.NewSidekiqScaler(ctx, true, config)
+	case "snowflake":
+		return scalers.NewSnowflakeScaler(config)
 	case "solace-event-queue":
 		return scalers.NewSolaceScaler(config)
 	case "solr":
 		return scalers.NewSolrScaler(config)
 	case "splunk":
 		return scalers.NewSplunkScaler(config)
+	case "splunk-observability":
+		return scalers.NewSplunkObservabilityScaler(config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "tekton":
+		return scalers.NewTektonScaler(client, config)
+	case "temporal":
+		return scalers.NewTemporalScaler(config)
+	case "trino":
+		return scalers.NewTrinoScaler(config)
+	case "webhook":
+		return scalers.NewWebhookScaler(config)
+	case "zookeeper":
+		return scalers.NewZookeeperScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
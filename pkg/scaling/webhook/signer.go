@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook signs the notifications KEDA sends to webhook receivers,
+// so receivers can verify they come from KEDA and haven't been tampered with
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the request body
+	SignatureHeader = "X-KEDA-Signature-256"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	ErrEmptySecret        = errors.New("webhook signing secret is empty")
+	ErrMissingSignature   = fmt.Errorf("%s header is missing", SignatureHeader)
+	ErrInvalidSignature   = errors.New("webhook signature doesn't match the body")
+	errMalformedSignature = fmt.Errorf("%s header must be in format %s<hex digest>", SignatureHeader, signaturePrefix)
+)
+
+// WebhookSigner signs the JSON body of webhook notifications with HMAC-SHA256
+type WebhookSigner struct {
+	secret []byte
+}
+
+// NewWebhookSigner creates a WebhookSigner using the secret resolved from TriggerAuthentication
+func NewWebhookSigner(secret []byte) (*WebhookSigner, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+	return &WebhookSigner{secret: secret}, nil
+}
+
+// Sign returns the value of the signature header for the body
+func (s *WebhookSigner) Sign(body []byte) string {
+	return signaturePrefix + hex.EncodeToString(computeHMAC(s.secret, body))
+}
+
+// NewSignedRequest marshals the payload to JSON and returns a POST request to the url carrying the signature header
+func (s *WebhookSigner) NewSignedRequest(ctx context.Context, url string, payload interface{}) (*http.Request, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, s.Sign(body))
+	return req, nil
+}
+
+// WebhookVerifier verifies the signature of webhook notifications, it's meant for the receivers
+type WebhookVerifier struct {
+	secret []byte
+}
+
+// NewWebhookVerifier creates a WebhookVerifier using the same secret as the WebhookSigner
+func NewWebhookVerifier(secret []byte) (*WebhookVerifier, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+	return &WebhookVerifier{secret: secret}, nil
+}
+
+// Verify checks the signature header value against the body
+func (v *WebhookVerifier) Verify(body []byte, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	digest, found := strings.CutPrefix(signature, signaturePrefix)
+	if !found {
+		return errMalformedSignature
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return errMalformedSignature
+	}
+
+	// hmac.Equal compares in constant time to not leak the expected signature through timing
+	if !hmac.Equal(expected, computeHMAC(v.secret, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyRequest reads the body of the request and verifies its signature, the body is returned when it's valid
+func (v *WebhookVerifier) VerifyRequest(req *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading webhook body: %w", err)
+	}
+	if err := v.Verify(body, req.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func computeHMAC(secret, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("keda-webhook-secret")
+
+type testPayload struct {
+	ScaledObject string `json:"scaledObject"`
+	Replicas     int32  `json:"replicas"`
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	signer, err := NewWebhookSigner(testSecret)
+	assert.NoError(t, err)
+	verifier, err := NewWebhookVerifier(testSecret)
+	assert.NoError(t, err)
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := verifier.VerifyRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received = body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := signer.NewSignedRequest(context.Background(), server.URL, testPayload{ScaledObject: "default/app", Replicas: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"scaledObject":"default/app","replicas":3}`, string(received))
+}
+
+func TestVerifyRejectsInvalidSignatures(t *testing.T) {
+	signer, _ := NewWebhookSigner(testSecret)
+	otherSigner, _ := NewWebhookSigner([]byte("other-secret"))
+	verifier, _ := NewWebhookVerifier(testSecret)
+
+	body := []byte(`{"scaledObject":"default/app","replicas":3}`)
+	signature := signer.Sign(body)
+
+	tests := []struct {
+		name        string
+		body        []byte
+		signature   string
+		expectedErr error
+	}{
+		{name: "valid", body: body, signature: signature},
+		{name: "tampered body", body: []byte(`{"scaledObject":"default/app","replicas":30}`), signature: signature, expectedErr: ErrInvalidSignature},
+		{name: "other secret", body: body, signature: otherSigner.Sign(body), expectedErr: ErrInvalidSignature},
+		{name: "missing signature", body: body, expectedErr: ErrMissingSignature},
+		{name: "missing prefix", body: body, signature: signature[len(signaturePrefix):], expectedErr: errMalformedSignature},
+		{name: "not hex", body: body, signature: signaturePrefix + "zz", expectedErr: errMalformedSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifier.Verify(test.body, test.signature)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestEmptySecretIsRejected(t *testing.T) {
+	_, err := NewWebhookSigner(nil)
+	assert.ErrorIs(t, err, ErrEmptySecret)
+	_, err = NewWebhookVerifier([]byte{})
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
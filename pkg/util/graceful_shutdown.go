@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// ContextWithGracefulShutdown returns a context which isn't canceled right away when the parent is, typically on SIGTERM,
+// but once timeout has elapsed after that. It lets in-flight work complete during a graceful shutdown.
+// The values of the parent are kept and the returned cancel function has to be called to release the resources.
+func ContextWithGracefulShutdown(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	stop := context.AfterFunc(parent, func() {
+		timeoutCtx, timeoutCancel := context.WithTimeout(ctx, timeout)
+		context.AfterFunc(timeoutCtx, func() {
+			timeoutCancel()
+			cancel()
+		})
+	})
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
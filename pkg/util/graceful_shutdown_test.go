@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sleepingReconcile simulates a reconcile which takes d to complete, unless its context is canceled before
+func sleepingReconcile(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func runReconcileAndSendSIGTERM(t *testing.T, reconcileDuration, gracefulShutdownTimeout time.Duration) (time.Duration, error) {
+	t.Helper()
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	done := make(chan error)
+	start := time.Now()
+	go func() {
+		ctx, cancel := ContextWithGracefulShutdown(signalCtx, gracefulShutdownTimeout)
+		defer cancel()
+		done <- sleepingReconcile(ctx, reconcileDuration)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+	<-signalCtx.Done()
+
+	select {
+	case err := <-done:
+		return time.Since(start), err
+	case <-time.After(5 * time.Second):
+		t.Fatal("reconcile didn't exit")
+		return 0, nil
+	}
+}
+
+func TestGracefulShutdownCompletesInFlightReconcile(t *testing.T) {
+	elapsed, err := runReconcileAndSendSIGTERM(t, 200*time.Millisecond, time.Second)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestGracefulShutdownCancelsReconcileAfterTimeout(t *testing.T) {
+	elapsed, err := runReconcileAndSendSIGTERM(t, 10*time.Second, 200*time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+}
+
+func TestGracefulShutdownKeepsParentValues(t *testing.T) {
+	type key struct{}
+	parent := context.WithValue(context.Background(), key{}, "value")
+	ctx, cancel := ContextWithGracefulShutdown(parent, time.Second)
+	assert.Equal(t, "value", ctx.Value(key{}))
+
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
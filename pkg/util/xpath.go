@@ -0,0 +1,359 @@
+package util
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is an element of a parsed XML document
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlNode
+	// text is the character data directly inside the element
+	text strings.Builder
+}
+
+// xpathStep is a location step of an XPath expression, e.g. the queue[@name='orders'] of /stats//queue[@name='orders']
+type xpathStep struct {
+	descendant bool
+	test       string
+	predicates []string
+}
+
+// GetValuesByXPath returns the string values of the nodes of the XML document selected by the XPath expression.
+// It supports the abbreviated syntax of location paths:
+//   - the child (/) and descendant (//) axes, the . step
+//   - element names, ignoring namespace prefixes, and *
+//   - @attribute and text() as last step
+//   - the predicates [n], [last()], [@attribute], [@attribute='value'], [element] and [element='value']
+//
+// The value of an element is its text content, including the one of its descendants.
+//
+// Examples:
+//
+// GetValuesByXPath(body, "/stats/queue/size")
+// GetValuesByXPath(body, "//queue[@name='orders']/@length")
+// GetValuesByXPath(body, "/stats/queue[last()]/size")
+func GetValuesByXPath(body []byte, path string) ([]string, error) {
+	steps, err := parseXPath(path)
+	if err != nil {
+		return nil, err
+	}
+	document, err := parseXMLDocument(body)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*xmlNode{document}
+	for i, step := range steps {
+		last := i == len(steps)-1
+		switch {
+		case strings.HasPrefix(step.test, "@"):
+			if !last {
+				return nil, fmt.Errorf("attribute step '%s' must be the last step of '%s'", step.test, path)
+			}
+			var values []string
+			for _, node := range candidates(nodes, step.descendant, true) {
+				if value, ok := node.attr(step.test[1:]); ok {
+					values = append(values, value)
+				}
+			}
+			return values, nil
+		case step.test == "text()":
+			if !last {
+				return nil, fmt.Errorf("text() must be the last step of '%s'", path)
+			}
+			var values []string
+			for _, node := range candidates(nodes, step.descendant, true) {
+				values = append(values, strings.TrimSpace(node.text.String()))
+			}
+			return values, nil
+		}
+
+		var selected []*xmlNode
+		for _, node := range nodes {
+			var matching []*xmlNode
+			if step.test == "." {
+				matching = []*xmlNode{node}
+			} else {
+				for _, candidate := range candidates([]*xmlNode{node}, step.descendant, false) {
+					if step.test == "*" || localName(step.test) == candidate.name {
+						matching = append(matching, candidate)
+					}
+				}
+			}
+			for _, predicate := range step.predicates {
+				matching, err = filterNodes(matching, predicate)
+				if err != nil {
+					return nil, err
+				}
+			}
+			selected = append(selected, matching...)
+		}
+		nodes = unique(selected)
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, strings.TrimSpace(node.textContent()))
+	}
+	return values, nil
+}
+
+func parseXMLDocument(body []byte) (*xmlNode, error) {
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		current := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local, attrs: t.Attr}
+			current.children = append(current.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			current.text.Write(t)
+		}
+	}
+	if len(document.children) == 0 {
+		return nil, errors.New("no root element found in xml document")
+	}
+	return document, nil
+}
+
+// parseXPath splits the path in its steps, ignoring the slashes inside of the predicates
+func parseXPath(path string) ([]xpathStep, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("empty xpath")
+	}
+	path = strings.TrimPrefix(path, "/")
+
+	var steps []xpathStep
+	descendant := false
+	for path != "" {
+		if strings.HasPrefix(path, "/") {
+			if descendant {
+				return nil, fmt.Errorf("invalid xpath, unexpected '/' in '%s'", path)
+			}
+			descendant = true
+			path = path[1:]
+			continue
+		}
+
+		end, depth, quote := len(path), 0, rune(0)
+	scan:
+		for i, c := range path {
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == '[':
+				depth++
+			case c == ']':
+				depth--
+			case c == '/' && depth == 0:
+				end = i
+				break scan
+			}
+		}
+		if depth != 0 || quote != 0 {
+			return nil, fmt.Errorf("invalid xpath, unbalanced predicate in '%s'", path)
+		}
+
+		step, err := parseXPathStep(path[:end], descendant)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		descendant = false
+		if path[end:] == "/" {
+			return nil, errors.New("invalid xpath, missing step after '/'")
+		}
+		path = strings.TrimPrefix(path[end:], "/")
+	}
+	if descendant || len(steps) == 0 {
+		return nil, errors.New("invalid xpath, missing step after '/'")
+	}
+	return steps, nil
+}
+
+func parseXPathStep(s string, descendant bool) (xpathStep, error) {
+	step := xpathStep{descendant: descendant}
+	test, rest, _ := strings.Cut(s, "[")
+	step.test = strings.TrimSpace(test)
+	if step.test == "" {
+		return step, fmt.Errorf("invalid xpath step '%s'", s)
+	}
+	if rest == "" {
+		return step, nil
+	}
+
+	rest = "[" + rest
+	for rest != "" {
+		if !strings.HasPrefix(rest, "[") {
+			return step, fmt.Errorf("invalid xpath step '%s'", s)
+		}
+		end, quote := -1, rune(0)
+		for i, c := range rest {
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				}
+				continue
+			}
+			if c == '\'' || c == '"' {
+				quote = c
+			}
+			if c == ']' {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			return step, fmt.Errorf("invalid xpath step '%s'", s)
+		}
+		step.predicates = append(step.predicates, strings.TrimSpace(rest[1:end]))
+		rest = rest[end+1:]
+	}
+	return step, nil
+}
+
+// candidates returns the children of the nodes, or their descendants. The nodes themselves are included when
+// selecting their attributes or text.
+func candidates(nodes []*xmlNode, descendant, self bool) []*xmlNode {
+	var result []*xmlNode
+	var walk func(node *xmlNode)
+	walk = func(node *xmlNode) {
+		for _, child := range node.children {
+			result = append(result, child)
+			if descendant {
+				walk(child)
+			}
+		}
+	}
+	for _, node := range nodes {
+		if self {
+			result = append(result, node)
+			if !descendant {
+				continue
+			}
+		}
+		walk(node)
+	}
+	return unique(result)
+}
+
+func filterNodes(nodes []*xmlNode, predicate string) ([]*xmlNode, error) {
+	if predicate == "last()" {
+		if len(nodes) == 0 {
+			return nil, nil
+		}
+		return nodes[len(nodes)-1:], nil
+	}
+	if position, err := strconv.Atoi(predicate); err == nil {
+		if position < 1 || position > len(nodes) {
+			return nil, nil
+		}
+		return nodes[position-1 : position], nil
+	}
+
+	name, value, hasValue := strings.Cut(predicate, "=")
+	name = strings.TrimSpace(name)
+	if hasValue {
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return nil, fmt.Errorf("unsupported xpath predicate '%s', the value must be quoted", predicate)
+		}
+		value = value[1 : len(value)-1]
+	}
+	if name == "" || strings.ContainsAny(name, "()[]/ ") {
+		return nil, fmt.Errorf("unsupported xpath predicate '%s'", predicate)
+	}
+
+	var result []*xmlNode
+	for _, node := range nodes {
+		if node.matches(name, value, hasValue) {
+			result = append(result, node)
+		}
+	}
+	return result, nil
+}
+
+// matches returns whether the node has the attribute, or the child element, with the value if given
+func (n *xmlNode) matches(name, value string, hasValue bool) bool {
+	if strings.HasPrefix(name, "@") {
+		v, ok := n.attr(name[1:])
+		return ok && (!hasValue || v == value)
+	}
+	for _, child := range n.children {
+		if child.name == localName(name) && (!hasValue || strings.TrimSpace(child.textContent()) == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == localName(name) {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+func (n *xmlNode) textContent() string {
+	if len(n.children) == 0 {
+		return n.text.String()
+	}
+	var b strings.Builder
+	b.WriteString(n.text.String())
+	for _, child := range n.children {
+		b.WriteString(child.textContent())
+	}
+	return b.String()
+}
+
+func localName(name string) string {
+	if _, local, ok := strings.Cut(name, ":"); ok {
+		return local
+	}
+	return name
+}
+
+func unique(nodes []*xmlNode) []*xmlNode {
+	seen := make(map[*xmlNode]bool, len(nodes))
+	result := nodes[:0:0]
+	for _, node := range nodes {
+		if !seen[node] {
+			seen[node] = true
+			result = append(result, node)
+		}
+	}
+	return result
+}
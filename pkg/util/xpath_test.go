@@ -0,0 +1,75 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testXPathDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<stats xmlns:q="urn:queues" version="2">
+	<q:queue name="orders" length="3">
+		<consumers>2</consumers>
+	</q:queue>
+	<q:queue name="invoices" length="7">
+		<consumers>1</consumers>
+	</q:queue>
+	<shard id="1">
+		<q:queue name="refunds" length="2"><consumers>0</consumers></q:queue>
+	</shard>
+	<total>12<unit>items</unit></total>
+</stats>`
+
+func TestGetValuesByXPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "root attribute", path: "/stats/@version", expected: []string{"2"}},
+		{name: "children", path: "/stats/queue/consumers", expected: []string{"2", "1"}},
+		{name: "namespace prefix", path: "/stats/q:queue/@length", expected: []string{"3", "7"}},
+		{name: "descendants", path: "//queue/@length", expected: []string{"3", "7", "2"}},
+		{name: "descendant attributes", path: "/stats/shard//@length", expected: []string{"2"}},
+		{name: "relative path", path: "stats/shard/@id", expected: []string{"1"}},
+		{name: "wildcard", path: "/stats/*/queue/@name", expected: []string{"refunds"}},
+		{name: "attribute predicate", path: "//queue[@name='invoices']/consumers", expected: []string{"1"}},
+		{name: "double quoted predicate", path: `//queue[@name="orders"]/@length`, expected: []string{"3"}},
+		{name: "child predicate", path: "//queue[consumers='0']/@name", expected: []string{"refunds"}},
+		{name: "attribute exists", path: "/stats/*[@id]/queue/@name", expected: []string{"refunds"}},
+		{name: "position", path: "/stats/queue[2]/@name", expected: []string{"invoices"}},
+		{name: "last", path: "/stats/queue[last()]/@name", expected: []string{"invoices"}},
+		{name: "predicates", path: "/stats/queue[@length][1]/@name", expected: []string{"orders"}},
+		{name: "text content", path: "/stats/total", expected: []string{"12items"}},
+		{name: "text", path: "/stats/total/text()", expected: []string{"12"}},
+		{name: "self", path: "/stats/total/./unit", expected: []string{"items"}},
+		{name: "slash in predicate", path: "//queue[@name='a/b']", expected: nil},
+		{name: "not found", path: "/stats/missing", expected: []string{}},
+		{name: "empty", path: "", wantErr: true},
+		{name: "trailing slash", path: "/stats/", wantErr: true},
+		{name: "triple slash", path: "/stats///queue", wantErr: true},
+		{name: "unbalanced predicate", path: "/stats/queue[@name='orders'", wantErr: true},
+		{name: "unquoted predicate value", path: "/stats/queue[@name=orders]", wantErr: true},
+		{name: "unsupported predicate", path: "/stats/queue[count(consumers)]", wantErr: true},
+		{name: "attribute not last", path: "/stats/@version/queue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := GetValuesByXPath([]byte(testXPathDocument), tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expected), len(values))
+			if len(tt.expected) > 0 {
+				assert.Equal(t, tt.expected, values)
+			}
+		})
+	}
+
+	_, err := GetValuesByXPath([]byte("not xml"), "/stats")
+	assert.Error(t, err)
+}